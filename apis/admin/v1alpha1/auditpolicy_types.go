@@ -11,6 +11,8 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	apisv1alpha1 "github.com/SAP/crossplane-provider-hana/apis/v1alpha1"
 )
 
 // AuditPolicyParameters are the configurable fields of a AuditPolicy.
@@ -38,6 +40,10 @@ type AuditPolicyParameters struct {
 
 // AuditPolicyObservation are the observable fields of a AuditPolicy.
 type AuditPolicyObservation struct {
+	// ExternalID is the stable identifier of the audit policy, its name. It is
+	// also published as the externalID connection detail.
+	// +kubebuilder:validation:Optional
+	ExternalID string `json:"externalID,omitempty"`
 
 	// +kubebuilder:validation:Optional
 	PolicyName string `json:"policyName,omitempty"`
@@ -68,6 +74,10 @@ type AuditPolicySpec struct {
 type AuditPolicyStatus struct {
 	xpv1.ResourceStatus `json:",inline"`
 	AtProvider          AuditPolicyObservation `json:"atProvider,omitempty"`
+
+	// SQLStatistics counts the SQL the last reconcile ran against HANA.
+	// +kubebuilder:validation:Optional
+	SQLStatistics *apisv1alpha1.SQLStatistics `json:"sqlStatistics,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -78,7 +88,7 @@ type AuditPolicyStatus struct {
 // +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
 // +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
 // +kubebuilder:subresource:status
-// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,hana}
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,hana},shortName={hanaauditpolicy}
 type AuditPolicy struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
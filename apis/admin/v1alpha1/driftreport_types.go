@@ -0,0 +1,135 @@
+/*
+Copyright 2026 SAP SE or an SAP affiliate company and contributors.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	apisv1alpha1 "github.com/SAP/crossplane-provider-hana/apis/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// DriftReportParameters are the configurable fields of a DriftReport.
+type DriftReportParameters struct {
+	// UnmanagedUserPattern is a SQL LIKE pattern. Catalog users matching it
+	// that are not managed by a User of the same ProviderConfig are counted
+	// as unmanaged.
+	// +kubebuilder:default="%"
+	UnmanagedUserPattern string `json:"unmanagedUserPattern,omitempty"`
+
+	// Interval between two reports. Defaults to the poll interval of the
+	// provider.
+	// +optional
+	Interval *metav1.Duration `json:"interval,omitempty"`
+}
+
+// DriftReportObservation is the aggregate report of a DriftReport.
+type DriftReportObservation struct {
+	// ManagedUsers is the number of Users of the ProviderConfig.
+	ManagedUsers int `json:"managedUsers"`
+
+	// DriftedUsers are the names of Users whose catalog state differs from
+	// their spec.
+	DriftedUsers []string `json:"driftedUsers,omitempty"`
+
+	// MissingUsers are the names of Users that do not exist in the catalog.
+	MissingUsers []string `json:"missingUsers,omitempty"`
+
+	// ForeignGrantUsers are the names of Users holding privileges outside
+	// their spec that users other than the technical user of the provider
+	// granted, rather than privileges the provider failed to revoke.
+	ForeignGrantUsers []string `json:"foreignGrantUsers,omitempty"`
+
+	// UnmanagedUsers is the number of catalog users matching the unmanaged
+	// user pattern that are not managed by a User.
+	UnmanagedUsers int `json:"unmanagedUsers"`
+
+	// OrphanedUsers are the names of catalog users the provider tagged with a
+	// User that no longer exists, for example because it was deleted with
+	// the Orphan deletion policy.
+	OrphanedUsers []string `json:"orphanedUsers,omitempty"`
+
+	// ManagedRoles is the number of Roles of the ProviderConfig.
+	ManagedRoles int `json:"managedRoles"`
+
+	// DriftedRoles are the names of Roles whose catalog state differs from
+	// their spec.
+	DriftedRoles []string `json:"driftedRoles,omitempty"`
+
+	// MissingRoles are the names of Roles that do not exist in the catalog.
+	MissingRoles []string `json:"missingRoles,omitempty"`
+
+	// FailedObservations are the names of Users and Roles that could not be
+	// observed.
+	FailedObservations []string `json:"failedObservations,omitempty"`
+
+	// LastReportTime is the time of the last report.
+	LastReportTime *metav1.Time `json:"lastReportTime,omitempty"`
+}
+
+// A DriftReportSpec defines the desired state of a DriftReport.
+type DriftReportSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       DriftReportParameters `json:"forProvider,omitempty"`
+}
+
+// A DriftReportStatus represents the observed state of a DriftReport.
+type DriftReportStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          DriftReportObservation `json:"atProvider,omitempty"`
+
+	// SQLStatistics counts the SQL the last reconcile ran against HANA.
+	// +kubebuilder:validation:Optional
+	SQLStatistics *apisv1alpha1.SQLStatistics `json:"sqlStatistics,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A DriftReport periodically compares the Users and Roles of its
+// ProviderConfig against the catalog and reports drift. It never changes the
+// catalog or the Users and Roles it reports on.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="USERS",type="integer",JSONPath=".status.atProvider.managedUsers"
+// +kubebuilder:printcolumn:name="UNMANAGED",type="integer",JSONPath=".status.atProvider.unmanagedUsers"
+// +kubebuilder:printcolumn:name="ORPHANED",type="string",JSONPath=".status.atProvider.orphanedUsers",priority=1
+// +kubebuilder:printcolumn:name="LAST-REPORT",type="date",JSONPath=".status.atProvider.lastReportTime"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,sql,hana},shortName={hanadrift}
+type DriftReport struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DriftReportSpec   `json:"spec"`
+	Status DriftReportStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// DriftReportList contains a list of DriftReport
+type DriftReportList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DriftReport `json:"items"`
+}
+
+// DriftReport type metadata.
+var (
+	DriftReportKind             = reflect.TypeFor[DriftReport]().Name()
+	DriftReportGroupKind        = schema.GroupKind{Group: Group, Kind: DriftReportKind}.String()
+	DriftReportKindAPIVersion   = DriftReportKind + "." + SchemeGroupVersion.String()
+	DriftReportGroupVersionKind = SchemeGroupVersion.WithKind(DriftReportKind)
+)
+
+func init() {
+	SchemeBuilder.Register(
+		&DriftReport{},
+		&DriftReportList{},
+	)
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2026 SAP SE or an SAP affiliate company and contributors.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	apisv1alpha1 "github.com/SAP/crossplane-provider-hana/apis/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// MonitoringUserParameters are the configurable fields of a MonitoringUser.
+type MonitoringUserParameters struct {
+	// Username of the monitoring user. Defaults to the external name of the
+	// MonitoringUser, or its metadata.name.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Pattern:=`^[^",\$\.'\+<>|\[\]\{\}\(\)!%*,/:;=\?@\\^~\x60]+$`
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Value is immutable"
+	Username string `json:"username,omitempty"`
+
+	// PasswordSecretRef references the password of the monitoring user. The
+	// password never expires and need not be changed on first logon.
+	// +kubebuilder:validation:Required
+	PasswordSecretRef xpv1.SecretKeySelector `json:"passwordSecretRef"`
+
+	// Statistics additionally grants reading the alerts and the history the
+	// statistics service collects in the _SYS_STATISTICS schema.
+	// +kubebuilder:validation:Optional
+	Statistics bool `json:"statistics,omitempty"`
+
+	// Usergroup of the monitoring user. Defaults to the defaultUsergroup of
+	// the ProviderConfig, or DEFAULT.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Pattern:=`^[^",\$\.'\+\-<>|\[\]\{\}\(\)!%*,/:;=\?@\\^~\x60]+$`
+	Usergroup string `json:"usergroup,omitempty"`
+}
+
+// MonitoringUserObservation are the observable fields of a MonitoringUser.
+type MonitoringUserObservation struct {
+	// Username of the monitoring user.
+	// +kubebuilder:validation:Optional
+	Username string `json:"username,omitempty"`
+
+	// Privileges are the monitoring privileges the user is granted.
+	// +kubebuilder:validation:Optional
+	Privileges []string `json:"privileges,omitempty"`
+
+	// Roles are the monitoring roles the user is granted.
+	// +kubebuilder:validation:Optional
+	Roles []string `json:"roles,omitempty"`
+
+	// PasswordUpToDate is whether the user logs on with the password of its
+	// secret.
+	// +kubebuilder:validation:Optional
+	PasswordUpToDate *bool `json:"passwordUpToDate,omitempty"`
+}
+
+// A MonitoringUserSpec defines the desired state of a MonitoringUser.
+type MonitoringUserSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       MonitoringUserParameters `json:"forProvider"`
+}
+
+// A MonitoringUserStatus represents the observed state of a MonitoringUser.
+type MonitoringUserStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          MonitoringUserObservation `json:"atProvider,omitempty"`
+
+	// SQLStatistics counts the SQL the last reconcile ran against HANA.
+	// +kubebuilder:validation:Optional
+	SQLStatistics *apisv1alpha1.SQLStatistics `json:"sqlStatistics,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A MonitoringUser is a technical user for monitoring tools with the read-only
+// privileges and roles of the monitoring bundle of the provider, such as
+// CATALOG READ and the MONITORING role. The bundle is maintained with the
+// provider, so monitoring users need no privilege lists of their own.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="USERNAME",type="string",JSONPath=".status.atProvider.username"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,sql,hana},shortName={hanamonitoringuser}
+type MonitoringUser struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   MonitoringUserSpec   `json:"spec"`
+	Status MonitoringUserStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// MonitoringUserList contains a list of MonitoringUser
+type MonitoringUserList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []MonitoringUser `json:"items"`
+}
+
+// MonitoringUser type metadata.
+var (
+	MonitoringUserKind             = reflect.TypeFor[MonitoringUser]().Name()
+	MonitoringUserGroupKind        = schema.GroupKind{Group: Group, Kind: MonitoringUserKind}.String()
+	MonitoringUserKindAPIVersion   = MonitoringUserKind + "." + SchemeGroupVersion.String()
+	MonitoringUserGroupVersionKind = SchemeGroupVersion.WithKind(MonitoringUserKind)
+)
+
+func init() {
+	SchemeBuilder.Register(
+		&MonitoringUser{},
+		&MonitoringUserList{},
+	)
+}
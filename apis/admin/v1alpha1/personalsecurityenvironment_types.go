@@ -11,6 +11,8 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	apisv1alpha1 "github.com/SAP/crossplane-provider-hana/apis/v1alpha1"
 )
 
 // CertificateRef references certificates
@@ -46,13 +48,22 @@ type X509ProviderRef struct {
 
 	// +kubebuilder:validation:Optional
 	ProviderRef *xpv1.Reference `json:"providerRef,omitempty"`
+
+	// ProviderSelector selects an X509Provider managed resource by its
+	// labels. It must match exactly one X509Provider, as the provider set
+	// for the PSE or the user would otherwise depend on the order they are
+	// listed in.
+	// +kubebuilder:validation:Optional
+	ProviderSelector *xpv1.Selector `json:"providerSelector,omitempty"`
 }
 
 // PersonalSecurityEnvironmentParameters defines the parameters for PSE
 type PersonalSecurityEnvironmentParameters struct {
-	// Name for the PSE
-	// +kubebuilder:validation:Required
-	Name string `json:"name"`
+	// Name for the PSE, taken as written, including its case. Defaults to
+	// the external name of the PersonalSecurityEnvironment, or its
+	// metadata.name.
+	// +kubebuilder:validation:Optional
+	Name string `json:"name,omitempty"`
 
 	// Reference to X509Provider
 	// +kubebuilder:validation:Optional
@@ -61,6 +72,73 @@ type PersonalSecurityEnvironmentParameters struct {
 	// Certificate references to add to the PSE
 	// +kubebuilder:validation:Optional
 	CertificateRefs []CertificateRef `json:"certificateRefs,omitempty"`
+
+	// CertificateRotation configures how certificate changes are applied to the PSE
+	// +kubebuilder:validation:Optional
+	CertificateRotation *CertificateRotation `json:"certificateRotation,omitempty"`
+
+	// CRLSecretRef references a Secret key holding a PEM encoded certificate
+	// revocation list. Client certificates revoked by it can no longer
+	// authenticate. Removing the reference drops the CRL from the PSE.
+	// +kubebuilder:validation:Optional
+	CRLSecretRef *xpv1.SecretKeySelector `json:"crlSecretRef,omitempty"`
+}
+
+// Certificate rotation strategies.
+const (
+	// RotationStrategyReplace adds and drops certificates in one step.
+	RotationStrategyReplace = "Replace"
+	// RotationStrategyAddBeforeRemove adds new certificates before dropping
+	// superseded ones, so the PSE is never left without a valid certificate.
+	RotationStrategyAddBeforeRemove = "AddBeforeRemove"
+)
+
+// Certificate rotation phases.
+const (
+	RotationPhaseAdding   = "Adding"
+	RotationPhaseRemoving = "Removing"
+	RotationPhaseComplete = "Complete"
+)
+
+// CertificateRotation defines the rotation strategy for PSE certificates
+type CertificateRotation struct {
+	// Strategy used when the certificate references change
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=Replace;AddBeforeRemove
+	// +kubebuilder:default:=Replace
+	Strategy string `json:"strategy,omitempty"`
+
+	// KeepPrevious is the number of superseded certificates kept in the PSE.
+	// Only used with the AddBeforeRemove strategy.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	KeepPrevious int `json:"keepPrevious,omitempty"`
+}
+
+// CertificateRotationStatus reports the progress of a certificate rotation
+type CertificateRotationStatus struct {
+	// Phase of the last certificate rotation
+	// +kubebuilder:validation:Optional
+	Phase string `json:"phase,omitempty"`
+
+	// RetainedCertificateRefs are superseded certificates kept in the PSE
+	// +kubebuilder:validation:Optional
+	RetainedCertificateRefs []CertificateRef `json:"retainedCertificateRefs,omitempty"`
+
+	// LastRotationTime is when the last certificate rotation completed
+	// +kubebuilder:validation:Optional
+	LastRotationTime *metav1.Time `json:"lastRotationTime,omitempty"`
+}
+
+// CRLStatus reports the certificate revocation list applied to the PSE
+type CRLStatus struct {
+	// SHA256 is the hex encoded digest of the applied CRL
+	// +kubebuilder:validation:Optional
+	SHA256 string `json:"sha256,omitempty"`
+
+	// LastUpdateTime is when the CRL was last applied
+	// +kubebuilder:validation:Optional
+	LastUpdateTime *metav1.Time `json:"lastUpdateTime,omitempty"`
 }
 
 // PersonalSecurityEnvironmentSpec defines the desired state of PersonalSecurityEnvironment
@@ -72,10 +150,27 @@ type PersonalSecurityEnvironmentSpec struct {
 type PersonalSecurityEnvironmentStatus struct {
 	xpv1.ResourceStatus `json:",inline"`
 	AtProvider          PersonalSecurityEnvironmentObservation `json:"atProvider,omitempty"`
+
+	// Rotation reports the progress of certificate rotations
+	// +kubebuilder:validation:Optional
+	Rotation *CertificateRotationStatus `json:"rotation,omitempty"`
+
+	// CRL reports the certificate revocation list applied to the PSE
+	// +kubebuilder:validation:Optional
+	CRL *CRLStatus `json:"crl,omitempty"`
+
+	// SQLStatistics counts the SQL the last reconcile ran against HANA.
+	// +kubebuilder:validation:Optional
+	SQLStatistics *apisv1alpha1.SQLStatistics `json:"sqlStatistics,omitempty"`
 }
 
 // PersonalSecurityEnvironmentObservation defines the observed state of PersonalSecurityEnvironment
 type PersonalSecurityEnvironmentObservation struct {
+	// ExternalID is the stable identifier of the PSE, its name. It is also
+	// published as the externalID connection detail.
+	// +kubebuilder:validation:Optional
+	ExternalID string `json:"externalID,omitempty"`
+
 	// Name of the PSE
 	// +kubebuilder:validation:Optional
 	Name string `json:"name,omitempty"`
@@ -97,7 +192,7 @@ type PersonalSecurityEnvironmentObservation struct {
 // +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
 // +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
 // +kubebuilder:subresource:status
-// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,hana},shortName={pse}
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,hana},shortName={pse,hanapse}
 type PersonalSecurityEnvironment struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
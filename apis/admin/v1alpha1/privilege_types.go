@@ -0,0 +1,47 @@
+/*
+Copyright 2026 SAP SE or an SAP affiliate company and contributors.
+*/
+
+package v1alpha1
+
+// Types of typed privileges.
+const (
+	PrivilegeTypeSystem       = "System"
+	PrivilegeTypeSchema       = "Schema"
+	PrivilegeTypeObject       = "Object"
+	PrivilegeTypeRemoteSource = "RemoteSource"
+)
+
+// TypedPrivilege is a privilege given by its parts instead of as a string.
+// Its identifiers are taken as written, as if they were in double quotes.
+// +kubebuilder:validation:XValidation:rule="self.type != 'System' || (!has(self.schema) && !has(self.object))",message="system privileges take neither schema nor object"
+// +kubebuilder:validation:XValidation:rule="self.type != 'Schema' || (has(self.schema) && !has(self.object))",message="schema privileges take a schema and no object"
+// +kubebuilder:validation:XValidation:rule="self.type != 'Object' || has(self.object)",message="object privileges take an object"
+// +kubebuilder:validation:XValidation:rule="self.type != 'RemoteSource' || (!has(self.schema) && has(self.object))",message="remote source privileges take the remote source as object and no schema"
+type TypedPrivilege struct {
+	// Type of the privilege.
+	// +kubebuilder:validation:Enum=System;Schema;Object;RemoteSource
+	Type string `json:"type"`
+
+	// Name of the privilege, e.g. SELECT or CATALOG READ.
+	// +kubebuilder:validation:Pattern=`^[A-Za-z_]+( [A-Za-z_]+)*$`
+	Name string `json:"name"`
+
+	// Schema the privilege is granted on, for schema privileges, or of the
+	// object, for object privileges. Object privileges without a schema
+	// apply to the default schema of the technical user.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MinLength=1
+	Schema string `json:"schema,omitempty"`
+
+	// Object the privilege is granted on, for object privileges, or the
+	// remote source, for remote source privileges.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MinLength=1
+	Object string `json:"object,omitempty"`
+
+	// Grantable grants the privilege WITH ADMIN OPTION, for system
+	// privileges, or WITH GRANT OPTION otherwise.
+	// +kubebuilder:validation:Optional
+	Grantable bool `json:"grantable,omitempty"`
+}
@@ -11,6 +11,8 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	apisv1alpha1 "github.com/SAP/crossplane-provider-hana/apis/v1alpha1"
 )
 
 // RoleParameters are the configurable fields of a Role.
@@ -29,16 +31,33 @@ type RoleParameters struct {
 	// +listType=set
 	Privileges []string `json:"privileges,omitempty"`
 
+	// TypedPrivileges are privileges given by their parts, granted in
+	// addition to Privileges.
+	// +kubebuilder:validation:Optional
+	TypedPrivileges []TypedPrivilege `json:"typedPrivileges,omitempty"`
+
 	// +kubebuilder:validation:Optional
 	Rolegroup string `json:"rolegroup,omitempty"`
 
 	// +kubebuilder:validation:Optional
 	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Value is immutable"
 	NoGrantToCreator bool `json:"noGrantToCreator,omitempty"`
+
+	// ExecutionUserSecretRef references a secret with the username and
+	// password of the technical user the provider connects as to manage this
+	// role, such as the owner of a schema. Defaults to the user of the
+	// ProviderConfig.
+	// +kubebuilder:validation:Optional
+	ExecutionUserSecretRef *xpv1.SecretReference `json:"executionUserSecretRef,omitempty"`
 }
 
 // RoleObservation are the observable fields of a Role.
 type RoleObservation struct {
+	// ExternalID is the stable identifier of the role, SCHEMA.ROLE for roles in
+	// a schema. It is also published as the externalID connection detail.
+	// +kubebuilder:validation:Optional
+	ExternalID string `json:"externalID,omitempty"`
+
 	// +kubebuilder:validation:Optional
 	RoleName string `json:"roleName"`
 
@@ -62,6 +81,10 @@ type RoleSpec struct {
 type RoleStatus struct {
 	xpv1.ResourceStatus `json:",inline"`
 	AtProvider          RoleObservation `json:"atProvider,omitempty"`
+
+	// SQLStatistics counts the SQL the last reconcile ran against HANA.
+	// +kubebuilder:validation:Optional
+	SQLStatistics *apisv1alpha1.SQLStatistics `json:"sqlStatistics,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -72,7 +95,7 @@ type RoleStatus struct {
 // +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
 // +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
 // +kubebuilder:subresource:status
-// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,sql}
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,sql,hana},shortName={hanarole}
 type Role struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
@@ -8,6 +8,8 @@ import (
 	"reflect"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	apisv1alpha1 "github.com/SAP/crossplane-provider-hana/apis/v1alpha1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 )
@@ -31,6 +33,11 @@ type RolegroupParameters struct {
 
 // RolegroupObservation are the observable fields of a Rolegroup.
 type RolegroupObservation struct {
+	// ExternalID is the stable identifier of the role group, its name. It is
+	// also published as the externalID connection detail.
+	// +kubebuilder:validation:Optional
+	ExternalID string `json:"externalID,omitempty"`
+
 	// +kubebuilder:validation:Optional
 	RolegroupName string `json:"rolegroupName"`
 
@@ -47,6 +54,10 @@ type RolegroupSpec struct {
 type RolegroupStatus struct {
 	xpv1.ResourceStatus `json:",inline"`
 	AtProvider          RolegroupObservation `json:"atProvider,omitempty"`
+
+	// SQLStatistics counts the SQL the last reconcile ran against HANA.
+	// +kubebuilder:validation:Optional
+	SQLStatistics *apisv1alpha1.SQLStatistics `json:"sqlStatistics,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -57,7 +68,7 @@ type RolegroupStatus struct {
 // +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
 // +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
 // +kubebuilder:subresource:status
-// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,sql}
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,sql,hana},shortName={hanarolegroup}
 type Rolegroup struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
@@ -0,0 +1,104 @@
+/*
+Copyright 2026 SAP SE or an SAP affiliate company and contributors.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	apisv1alpha1 "github.com/SAP/crossplane-provider-hana/apis/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// SingletonParameters are the configurable fields of a Singleton.
+type SingletonParameters struct {
+	// ObservationQuery is a query that returns at least one row if the
+	// artifact exists, e.g.
+	// SELECT 1 FROM SYS.ST_SPATIAL_REFERENCE_SYSTEMS WHERE SRS_ID = 3857.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	ObservationQuery string `json:"observationQuery"`
+
+	// CreateStatement creates the artifact if the observation query returns
+	// no rows, e.g. a CREATE SPATIAL REFERENCE SYSTEM statement or the CALL
+	// of a procedure enabling a feature.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Value is immutable"
+	CreateStatement string `json:"createStatement"`
+
+	// DropStatement drops the artifact when the Singleton is deleted. Without
+	// it, the artifact is kept.
+	// +kubebuilder:validation:Optional
+	DropStatement string `json:"dropStatement,omitempty"`
+}
+
+// SingletonObservation are the observable fields of a Singleton.
+type SingletonObservation struct {
+	// Exists is whether the observation query returned a row.
+	// +kubebuilder:validation:Optional
+	Exists bool `json:"exists,omitempty"`
+}
+
+// A SingletonSpec defines the desired state of a Singleton.
+type SingletonSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       SingletonParameters `json:"forProvider"`
+}
+
+// A SingletonStatus represents the observed state of a Singleton.
+type SingletonStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          SingletonObservation `json:"atProvider,omitempty"`
+
+	// SQLStatistics counts the SQL the last reconcile ran against HANA.
+	// +kubebuilder:validation:Optional
+	SQLStatistics *apisv1alpha1.SQLStatistics `json:"sqlStatistics,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A Singleton is an artifact of the database that is created once by a SQL
+// statement and cannot be changed, such as a spatial reference system. Its
+// existence is checked with a query, so the statement is only run if the
+// artifact is missing.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,sql,hana},shortName={hanasingleton}
+type Singleton struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   SingletonSpec   `json:"spec"`
+	Status SingletonStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// SingletonList contains a list of Singleton
+type SingletonList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Singleton `json:"items"`
+}
+
+// Singleton type metadata.
+var (
+	SingletonKind             = reflect.TypeFor[Singleton]().Name()
+	SingletonGroupKind        = schema.GroupKind{Group: Group, Kind: SingletonKind}.String()
+	SingletonKindAPIVersion   = SingletonKind + "." + SchemeGroupVersion.String()
+	SingletonGroupVersionKind = SchemeGroupVersion.WithKind(SingletonKind)
+)
+
+func init() {
+	SchemeBuilder.Register(
+		&Singleton{},
+		&SingletonList{},
+	)
+}
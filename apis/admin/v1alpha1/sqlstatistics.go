@@ -0,0 +1,119 @@
+/*
+Copyright 2026 SAP SE or an SAP affiliate company and contributors.
+*/
+
+package v1alpha1
+
+import (
+	apisv1alpha1 "github.com/SAP/crossplane-provider-hana/apis/v1alpha1"
+)
+
+// GetSQLStatistics of this AuditPolicy.
+func (mg *AuditPolicy) GetSQLStatistics() *apisv1alpha1.SQLStatistics {
+	return mg.Status.SQLStatistics
+}
+
+// SetSQLStatistics of this AuditPolicy.
+func (mg *AuditPolicy) SetSQLStatistics(s *apisv1alpha1.SQLStatistics) {
+	mg.Status.SQLStatistics = s
+}
+
+// GetSQLStatistics of this DriftReport.
+func (mg *DriftReport) GetSQLStatistics() *apisv1alpha1.SQLStatistics {
+	return mg.Status.SQLStatistics
+}
+
+// SetSQLStatistics of this DriftReport.
+func (mg *DriftReport) SetSQLStatistics(s *apisv1alpha1.SQLStatistics) {
+	mg.Status.SQLStatistics = s
+}
+
+// GetSQLStatistics of this PersonalSecurityEnvironment.
+func (mg *PersonalSecurityEnvironment) GetSQLStatistics() *apisv1alpha1.SQLStatistics {
+	return mg.Status.SQLStatistics
+}
+
+// SetSQLStatistics of this PersonalSecurityEnvironment.
+func (mg *PersonalSecurityEnvironment) SetSQLStatistics(s *apisv1alpha1.SQLStatistics) {
+	mg.Status.SQLStatistics = s
+}
+
+// GetSQLStatistics of this Role.
+func (mg *Role) GetSQLStatistics() *apisv1alpha1.SQLStatistics {
+	return mg.Status.SQLStatistics
+}
+
+// SetSQLStatistics of this Role.
+func (mg *Role) SetSQLStatistics(s *apisv1alpha1.SQLStatistics) {
+	mg.Status.SQLStatistics = s
+}
+
+// GetSQLStatistics of this Rolegroup.
+func (mg *Rolegroup) GetSQLStatistics() *apisv1alpha1.SQLStatistics {
+	return mg.Status.SQLStatistics
+}
+
+// SetSQLStatistics of this Rolegroup.
+func (mg *Rolegroup) SetSQLStatistics(s *apisv1alpha1.SQLStatistics) {
+	mg.Status.SQLStatistics = s
+}
+
+// GetSQLStatistics of this User.
+func (mg *User) GetSQLStatistics() *apisv1alpha1.SQLStatistics {
+	return mg.Status.SQLStatistics
+}
+
+// SetSQLStatistics of this User.
+func (mg *User) SetSQLStatistics(s *apisv1alpha1.SQLStatistics) {
+	mg.Status.SQLStatistics = s
+}
+
+// GetSQLStatistics of this UserReplication.
+func (mg *UserReplication) GetSQLStatistics() *apisv1alpha1.SQLStatistics {
+	return mg.Status.SQLStatistics
+}
+
+// SetSQLStatistics of this UserReplication.
+func (mg *UserReplication) SetSQLStatistics(s *apisv1alpha1.SQLStatistics) {
+	mg.Status.SQLStatistics = s
+}
+
+// GetSQLStatistics of this Usergroup.
+func (mg *Usergroup) GetSQLStatistics() *apisv1alpha1.SQLStatistics {
+	return mg.Status.SQLStatistics
+}
+
+// SetSQLStatistics of this Usergroup.
+func (mg *Usergroup) SetSQLStatistics(s *apisv1alpha1.SQLStatistics) {
+	mg.Status.SQLStatistics = s
+}
+
+// GetSQLStatistics of this X509Provider.
+func (mg *X509Provider) GetSQLStatistics() *apisv1alpha1.SQLStatistics {
+	return mg.Status.SQLStatistics
+}
+
+// SetSQLStatistics of this X509Provider.
+func (mg *X509Provider) SetSQLStatistics(s *apisv1alpha1.SQLStatistics) {
+	mg.Status.SQLStatistics = s
+}
+
+// GetSQLStatistics of this Singleton.
+func (mg *Singleton) GetSQLStatistics() *apisv1alpha1.SQLStatistics {
+	return mg.Status.SQLStatistics
+}
+
+// SetSQLStatistics of this Singleton.
+func (mg *Singleton) SetSQLStatistics(s *apisv1alpha1.SQLStatistics) {
+	mg.Status.SQLStatistics = s
+}
+
+// GetSQLStatistics of this MonitoringUser.
+func (mg *MonitoringUser) GetSQLStatistics() *apisv1alpha1.SQLStatistics {
+	return mg.Status.SQLStatistics
+}
+
+// SetSQLStatistics of this MonitoringUser.
+func (mg *MonitoringUser) SetSQLStatistics(s *apisv1alpha1.SQLStatistics) {
+	mg.Status.SQLStatistics = s
+}
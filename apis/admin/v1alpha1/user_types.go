@@ -11,110 +11,495 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	apisv1alpha1 "github.com/SAP/crossplane-provider-hana/apis/v1alpha1"
 )
 
 // Authentication includes different authentication methods
 type Authentication struct {
 	Password      *Password         `json:"password,omitempty"`
 	X509Providers []X509UserMapping `json:"x509Providers,omitempty"`
+
+	// WaitForDependencies keeps the user from becoming Ready until the
+	// X509Provider resources of its X.509 mappings, and the
+	// PersonalSecurityEnvironment resources that reference them, are Ready.
+	// Without it the user is Ready as soon as it is mapped, while logins
+	// still fail until the provider and the certificates of its PSE are in
+	// place.
+	// +kubebuilder:validation:Optional
+	WaitForDependencies bool `json:"waitForDependencies,omitempty"`
 }
 
 // Password authentication type
+// +kubebuilder:validation:XValidation:rule="!(has(self.passwordSecretRef) && has(self.passwordStoreRef))",message="passwordSecretRef and passwordStoreRef are mutually exclusive"
 type Password struct {
-	PasswordSecretRef        *xpv1.SecretKeySelector `json:"passwordSecretRef,omitempty"`
-	ForceFirstPasswordChange bool                    `json:"forceFirstPasswordChange,omitempty"`
+	PasswordSecretRef *xpv1.SecretKeySelector `json:"passwordSecretRef,omitempty"`
+
+	// PasswordStoreRef references the password in an external secret store,
+	// e.g. Vault, instead of a Kubernetes Secret. It requires the external
+	// secret stores feature of the provider.
+	// +optional
+	PasswordStoreRef *PasswordStoreReference `json:"passwordStoreRef,omitempty"`
+
+	ForceFirstPasswordChange bool `json:"forceFirstPasswordChange,omitempty"`
 }
 
+// A PasswordStoreReference references a key of a secret in the external
+// secret store of a StoreConfig.
+type PasswordStoreReference struct {
+	// Name of the secret in the store.
+	Name string `json:"name"`
+
+	// Scope of the secret, e.g. the parent path in Vault or the namespace in
+	// Kubernetes. Defaults to the default scope of the StoreConfig.
+	// +optional
+	Scope string `json:"scope,omitempty"`
+
+	// Key of the password in the secret.
+	Key string `json:"key"`
+
+	// StoreConfigRef is the StoreConfig of the secret store.
+	// +optional
+	// +kubebuilder:default={"name": "default"}
+	StoreConfigRef *xpv1.Reference `json:"configRef,omitempty"`
+}
+
+// ConnectionRestrictions restrict when a user may connect. HANA has no
+// per-user restrictions on client addresses or applications, these are
+// configured on the instance instead.
+// +kubebuilder:validation:XValidation:rule="!has(self.validFrom) || !has(self.validUntil) || timestamp(self.validFrom) < timestamp(self.validUntil)",message="validFrom must be before validUntil"
+type ConnectionRestrictions struct {
+	// ValidFrom is the earliest time the user may connect. Defaults to the
+	// time the restrictions are applied.
+	// +kubebuilder:validation:Optional
+	ValidFrom *metav1.Time `json:"validFrom,omitempty"`
+
+	// ValidUntil is the latest time the user may connect. The user may
+	// connect forever when unset.
+	// +kubebuilder:validation:Optional
+	ValidUntil *metav1.Time `json:"validUntil,omitempty"`
+}
+
+// AnnotationRenamePolicy selects what happens when the username of an
+// existing User changes. By default the change is rejected, with
+// RenamePolicyRecreate the user of the previous username is dropped and a
+// user with the new username created.
+const (
+	AnnotationRenamePolicy = "hana.sap.crossplane.io/rename-policy"
+	RenamePolicyRecreate   = "Recreate"
+)
+
 // UserParameters are the configurable fields of a User.
 type UserParameters struct {
+	// Username of the user. Defaults to the external name of the User, or
+	// its metadata.name. Changes are rejected unless the User sets the
+	// rename-policy annotation to Recreate.
 	// +kubebuilder:validation:Optional
-	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Value is immutable"
 	// +kubebuilder:validation:Pattern:=`^[^",\$\.'\+<>|\[\]\{\}\(\)!%*,/:;=\?@\\^~\x60]+$`
-	Username string `json:"username"`
+	Username string `json:"username,omitempty"`
+
+	// CaseSensitive keeps the username exactly as written. By default the
+	// username is folded to uppercase, as HANA does for unquoted identifiers.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Value is immutable"
+	// +kubebuilder:default:=false
+	CaseSensitive bool `json:"caseSensitive,omitempty"`
 
+	// RestrictedUser creates a restricted user. HANA cannot convert between
+	// restricted and standard users in place, so the user has to be recreated
+	// to change it.
 	// +kubebuilder:validation:Optional
 	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Value is immutable"
 	// +kubebuilder:default:=false
 	RestrictedUser bool `json:"restrictedUser" default:"false"`
 
+	// ClientConnect controls whether the user may connect with SQL clients.
+	// Restricted users cannot connect unless it is enabled. The setting is
+	// left untouched when unset.
+	// +kubebuilder:validation:Optional
+	ClientConnect *bool `json:"clientConnect,omitempty"`
+
+	// ConnectionRestrictions restrict the validity period of the user. The
+	// validity period is left untouched when unset.
+	// +kubebuilder:validation:Optional
+	ConnectionRestrictions *ConnectionRestrictions `json:"connectionRestrictions,omitempty"`
+
 	Authentication Authentication `json:"authentication,omitempty"`
 
 	// +listType=set
 	Privileges []string `json:"privileges,omitempty"`
 
+	// TypedPrivileges are privileges given by their parts, granted in
+	// addition to Privileges.
+	// +kubebuilder:validation:Optional
+	TypedPrivileges []TypedPrivilege `json:"typedPrivileges,omitempty"`
+
 	// +listType=set
 	Roles []string `json:"roles,omitempty"`
 
+	// Parameters of the user, e.g. LOCALE or EMAIL ADDRESS. Parameters that
+	// are not listed are cleared, and so are parameters set to an empty
+	// string.
 	// +kubebuilder:validation:Optional
-	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Value is immutable"
 	Parameters map[string]string `json:"parameters,omitempty"`
 
+	// Usergroup of the user. Defaults to the defaultUsergroup of the
+	// ProviderConfig, or DEFAULT. DEFAULT is the default usergroup, set it
+	// to move the user out of its usergroup.
 	// +kubebuilder:validation:Pattern:=`^[^",\$\.'\+\-<>|\[\]\{\}\(\)!%*,/:;=\?@\\^~\x60]+$`
-	// +kubebuilder:default:=DEFAULT
-	Usergroup string `json:"usergroup,omitempty" default:"DEFAULT"`
+	Usergroup string `json:"usergroup,omitempty"`
 
 	// +kubebuilder:default:=true
 	// +kubebuilder:validation:Optional
 	IsPasswordLifetimeCheckEnabled bool `json:"isPasswordLifetimeCheckEnabled" default:"true"`
+
+	// PasswordNeverExpires keeps the password of a technical user from
+	// expiring. It disables the password lifetime check of the user, which
+	// exempts it from the maximum password lifetime of the password policy
+	// of its usergroup and of the instance, and sets passwords without
+	// forcing a change on first logon. It overrides
+	// isPasswordLifetimeCheckEnabled and forceFirstPasswordChange.
+	// +kubebuilder:validation:Optional
+	PasswordNeverExpires bool `json:"passwordNeverExpires,omitempty"`
+
+	// NoDefaultRole skips the PUBLIC role every non-restricted user is
+	// granted by default. The provider revokes PUBLIC from the user unless it
+	// is listed in roles.
+	// +kubebuilder:validation:Optional
+	NoDefaultRole bool `json:"noDefaultRole,omitempty"`
+
+	// TerminateSessionsOnDelete deactivates the user and disconnects its
+	// active sessions before the user is dropped, so sessions of deleted
+	// applications neither block nor outlive the deletion.
+	// +kubebuilder:validation:Optional
+	TerminateSessionsOnDelete bool `json:"terminateSessionsOnDelete,omitempty"`
+
+	// NoDefaultPrivilege skips the CREATE ANY privilege on the schema of the
+	// user, which the provider expects under the strict privilege management
+	// policy. HANA grants the privilege to the owner of the schema, so it
+	// stays in place but is no longer reported or reconciled.
+	// +kubebuilder:validation:Optional
+	NoDefaultPrivilege bool `json:"noDefaultPrivilege,omitempty"`
+
+	// ManagePrivileges lets the provider reconcile the privileges of the
+	// user. Set it to false to leave them to another system, such as HDI.
+	// Defaults to true.
+	// +kubebuilder:validation:Optional
+	ManagePrivileges *bool `json:"managePrivileges,omitempty"`
+
+	// ManageRoles lets the provider reconcile the roles of the user.
+	// Defaults to true.
+	// +kubebuilder:validation:Optional
+	ManageRoles *bool `json:"manageRoles,omitempty"`
+
+	// ManageParameters lets the provider reconcile the parameters of the
+	// user. Defaults to true.
+	// +kubebuilder:validation:Optional
+	ManageParameters *bool `json:"manageParameters,omitempty"`
+
+	// ManagePassword lets the provider reconcile the password of the user.
+	// The password is still set when the user is created. Defaults to true.
+	// +kubebuilder:validation:Optional
+	ManagePassword *bool `json:"managePassword,omitempty"`
+
+	// ExecutionUserSecretRef references a secret with the username and
+	// password of the technical user the provider connects as to manage this
+	// user, such as the owner of a schema. Defaults to the user of the
+	// ProviderConfig.
+	// +kubebuilder:validation:Optional
+	ExecutionUserSecretRef *xpv1.SecretReference `json:"executionUserSecretRef,omitempty"`
+
+	// PublishCACertificate adds the CA certificate the HANA endpoint
+	// presents to the connection details of the user, under the ca.crt key.
+	// +kubebuilder:validation:Optional
+	PublishCACertificate bool `json:"publishCACertificate,omitempty"`
+}
+
+// PendingRevocations are the privileges and roles the next update of a User
+// revokes.
+type PendingRevocations struct {
+	// Count is the number of pending revocations
+	Count int `json:"count"`
+
+	// +kubebuilder:validation:Optional
+	Privileges []string `json:"privileges,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	Roles []string `json:"roles,omitempty"`
+
+	// ForeignPrivileges are the privileges among Privileges that users other
+	// than the technical user of the provider granted.
+	// +kubebuilder:validation:Optional
+	ForeignPrivileges []string `json:"foreignPrivileges,omitempty"`
+}
+
+// PrivilegeGrantObservation is a privilege granted to a user, and the user
+// who granted it.
+type PrivilegeGrantObservation struct {
+	Privilege string `json:"privilege"`
+	Grantor   string `json:"grantor"`
+}
+
+// ApplicationUserObservation is the application identity a database user is
+// mapped to by its XS_ user parameters.
+type ApplicationUserObservation struct {
+	// IsApplicationUser is true if the XS_APPLICATIONUSER parameter marks
+	// the user as an application user
+	// +kubebuilder:validation:Optional
+	IsApplicationUser bool `json:"isApplicationUser,omitempty"`
+
+	// RoleCollections are the XS advanced role collections assigned to the
+	// user by its XS_RC_ parameters
+	// +kubebuilder:validation:Optional
+	// +listType=set
+	RoleCollections []string `json:"roleCollections,omitempty"`
+
+	// Parameters are all XS_ parameters of the user
+	// +kubebuilder:validation:Optional
+	Parameters map[string]string `json:"parameters,omitempty"`
 }
 
 // UserObservation are the observable fields of a User.
 type UserObservation struct {
+	// ExternalID is the stable identifier of the user, its username. It is also
+	// published as the externalID connection detail.
+	// +kubebuilder:validation:Optional
+	ExternalID string `json:"externalID,omitempty"`
+
 	// +kubebuilder:validation:Optional
 	Username *string `json:"username,omitempty"`
 
 	// +kubebuilder:validation:Optional
 	RestrictedUser *bool `json:"restrictedUser,omitempty"`
 
+	// +kubebuilder:validation:Optional
+	IsClientConnectEnabled *bool `json:"isClientConnectEnabled,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	ValidFrom *metav1.Time `json:"validFrom,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	ValidUntil *metav1.Time `json:"validUntil,omitempty"`
+
+	// LastSuccessfulConnect is the time the user last connected
+	// +kubebuilder:validation:Optional
+	LastSuccessfulConnect *metav1.Time `json:"lastSuccessfulConnect,omitempty"`
+
+	// InvalidConnectAttempts is the number of failed connection attempts
+	// since the last successful connect
+	// +kubebuilder:validation:Optional
+	InvalidConnectAttempts *int32 `json:"invalidConnectAttempts,omitempty"`
+
+	// IsLocked is true if the user is deactivated, e.g. because the
+	// password policy locks users indefinitely after too many failed
+	// connection attempts
+	// +kubebuilder:validation:Optional
+	IsLocked *bool `json:"isLocked,omitempty"`
+
+	// ExternalIdentity is the Kerberos or JWT identity the user is mapped to
+	// +kubebuilder:validation:Optional
+	ExternalIdentity string `json:"externalIdentity,omitempty"`
+
+	// PendingRevocations are the privileges and roles that are granted to
+	// the user but no longer desired. Revocations beyond the revocation
+	// threshold of the grant policy wait for confirmation.
+	// +kubebuilder:validation:Optional
+	PendingRevocations *PendingRevocations `json:"pendingRevocations,omitempty"`
+
+	// ApplicationUser is the application identity the user is mapped to by
+	// its XS_ user parameters, as set up by XS advanced or SAP Analytics
+	// Cloud. It is observed only; the XS_ parameters are not part of
+	// Parameters.
+	// +kubebuilder:validation:Optional
+	ApplicationUser *ApplicationUserObservation `json:"applicationUser,omitempty"`
+
 	// +kubebuilder:validation:Optional
 	X509Providers []X509UserMapping `json:"x509Providers,omitempty"`
 
+	// LastAuthentication is the last successful login of the user recorded
+	// in the audit log. Only reported if an audit policy audits CONNECT
+	// actions and the technical user may read the audit log.
+	// +kubebuilder:validation:Optional
+	LastAuthentication *UserAuthenticationObservation `json:"lastAuthentication,omitempty"`
+
 	// +kubebuilder:validation:Optional
 	LastPasswordChangeTime metav1.Time `json:"lastPasswordChangeTime,omitempty"`
 
 	// +kubebuilder:validation:Optional
 	PasswordUpToDate *bool `json:"passwordUpToDate,omitempty"`
 
+	// PasswordExpiresAt is the time the password expires under the password
+	// policy that applies to the user. Unset if the password does not expire.
+	// +kubebuilder:validation:Optional
+	PasswordExpiresAt *metav1.Time `json:"passwordExpiresAt,omitempty"`
+
+	// DaysUntilPasswordExpiry is the number of whole days until the password
+	// expires, negative once it expired.
+	// +kubebuilder:validation:Optional
+	DaysUntilPasswordExpiry *int32 `json:"daysUntilPasswordExpiry,omitempty"`
+
 	// +kubebuilder:validation:Optional
 	CreatedAt metav1.Time `json:"createdAt,omitempty"`
 
 	// +kubebuilder:validation:Optional
 	Privileges []string `json:"privileges,omitempty"`
 
+	// ForeignGrants are the privileges of the user that users other than
+	// the technical user of the provider granted, with their grantor, e.g.
+	// privileges an administrator granted by hand. Implicit grants of SYS
+	// are left out.
+	// +kubebuilder:validation:Optional
+	ForeignGrants []PrivilegeGrantObservation `json:"foreignGrants,omitempty"`
+
 	// +kubebuilder:validation:Optional
 	Roles []string `json:"roles,omitempty"`
 
+	// UnrevokableRoles are roles removed from the spec that stay granted,
+	// since the technical user lacks the privilege to revoke them, e.g.
+	// roles owned by _SYS_REPO or an HDI container.
+	// +kubebuilder:validation:Optional
+	UnrevokableRoles []string `json:"unrevokableRoles,omitempty"`
+
 	// +kubebuilder:validation:Optional
 	Parameters map[string]string `json:"parameters,omitempty"`
 
+	// ManagedResource is the name of the User the user is tagged with. The
+	// tag is not part of the observed parameters.
+	ManagedResource string `json:"managedResource,omitempty"`
+
 	// +kubebuilder:validation:Optional
 	Usergroup *string `json:"usergroup,omitempty"`
 
 	// +kubebuilder:validation:Optional
 	IsPasswordLifetimeCheckEnabled *bool `json:"isPasswordLifetimeCheckEnabled,omitempty"`
 
+	// PasswordPolicy is the password policy whose maximum password lifetime
+	// applies to the password of the user: NeverExpires if the password
+	// lifetime check of the user is disabled, Usergroup if its usergroup
+	// enables a password policy of its own, or else Instance. Unset if the
+	// user has no password.
+	// +kubebuilder:validation:Optional
+	PasswordPolicy string `json:"passwordPolicy,omitempty"`
+
 	// +kubebuilder:validation:Optional
 	IsPasswordEnabled *bool `json:"isPasswordEnabled,omitempty"`
+
+	// UnobservedFields lists the fields the provider could not observe in
+	// usergroup operator mode. They are not reconciled.
+	// +kubebuilder:validation:Optional
+	UnobservedFields []string `json:"unobservedFields,omitempty"`
+
+	// AppliedDefaults lists the privileges and roles the provider grants the
+	// user by default, in addition to those of the spec.
+	// +kubebuilder:validation:Optional
+	AppliedDefaults []string `json:"appliedDefaults,omitempty"`
+
+	// Aspects reports which managed aspects of the user converged. The User
+	// is only Ready once all of them did.
+	// +kubebuilder:validation:Optional
+	Aspects *UserAspectsObservation `json:"aspects,omitempty"`
+}
+
+// UserAuthenticationObservation is a successful login of a user recorded in
+// the audit log.
+type UserAuthenticationObservation struct {
+	// Time is the time of the login.
+	Time metav1.Time `json:"time"`
+
+	// Method is the authentication method of the login, such as PASSWORD or
+	// X509. Unset if the audit log does not name it.
+	// +kubebuilder:validation:Optional
+	Method string `json:"method,omitempty"`
+
+	// X509Provider is the name of the X.509 provider of the mapping the
+	// certificate of the login matched.
+	// +kubebuilder:validation:Optional
+	X509Provider string `json:"x509Provider,omitempty"`
+
+	// SubjectName is the subject of the mapping the certificate of the login
+	// matched.
+	// +kubebuilder:validation:Optional
+	SubjectName string `json:"subjectName,omitempty"`
+}
+
+// UserAspectsObservation reports for each managed aspect of a user whether it
+// converged to the desired state. Aspects that are not observed in usergroup
+// operator mode or not managed by the User count as converged.
+type UserAspectsObservation struct {
+	Privileges    bool `json:"privileges"`
+	Roles         bool `json:"roles"`
+	Parameters    bool `json:"parameters"`
+	X509Providers bool `json:"x509Providers"`
+	Password      bool `json:"password"`
+}
+
+// A PrivilegeSelector selects granted privileges by their canonical form, in
+// which identifiers are quoted, e.g. SELECT ON SCHEMA "APP_1".
+// +kubebuilder:validation:XValidation:rule="has(self.prefix) != has(self.regex)",message="exactly one of prefix and regex must be set"
+type PrivilegeSelector struct {
+	// Prefix selects the privileges starting with it, e.g. SELECT ON SCHEMA.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MinLength=1
+	Prefix string `json:"prefix,omitempty"`
+
+	// Regex selects the privileges matching the regular expression, e.g.
+	// ON SCHEMA "APP_[^"]*"$ for all privileges on schemas starting with APP_.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MinLength=1
+	Regex string `json:"regex,omitempty"`
 }
 
 // A UserSpec defines the desired state of a User.
+// +kubebuilder:validation:XValidation:rule="!has(self.privilegeManagementPolicy) || self.privilegeManagementPolicy != 'selector' || has(self.privilegeSelectors)",message="the selector privilege management policy requires privilegeSelectors"
 type UserSpec struct {
 	xpv1.ResourceSpec `json:",inline"`
 	ForProvider       UserParameters `json:"forProvider"`
 
 	// +kubebuilder:validation:Optional
-	// +kubebuilder:validation:Enum=strict;lax
+	// +kubebuilder:validation:Enum=strict;lax;selector
 	// +kubebuilder:default:=strict
 	// PrivilegeManagementPolicy defines the privilege management policy for the user.
 	// 'strict' means that all privileges are managed by crossplane, and other privileges not defined in the spec will be removed.
 	// 'lax' means that crossplane will only manage the privileges defined in the spec, and other privileges will not be removed.
+	// 'selector' means that crossplane manages the privileges defined in the spec and those matched by privilegeSelectors, and other privileges will not be removed.
 	PrivilegeManagementPolicy string `json:"privilegeManagementPolicy,omitempty"`
+
+	// PrivilegeSelectors select the granted privileges that are managed under
+	// the 'selector' privilege management policy, in addition to the
+	// privileges defined in the spec.
+	// +kubebuilder:validation:Optional
+	PrivilegeSelectors []PrivilegeSelector `json:"privilegeSelectors,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=Protected;Unprotected
+	// ProtectionPolicy protects the user from being dropped.
+	// 'Protected' means that deleting the User does not drop the user, but
+	// blocks the deletion with a DeletionBlocked condition until the policy is
+	// removed or set to 'Unprotected'.
+	ProtectionPolicy string `json:"protectionPolicy,omitempty"`
 }
 
+// Protection policies of users.
+const (
+	ProtectionPolicyProtected   = "Protected"
+	ProtectionPolicyUnprotected = "Unprotected"
+)
+
+// Password policies reported for users.
+const (
+	PasswordPolicyNeverExpires = "NeverExpires"
+	PasswordPolicyUsergroup    = "Usergroup"
+	PasswordPolicyInstance     = "Instance"
+)
+
 // A UserStatus represents the observed state of a User.
 type UserStatus struct {
 	xpv1.ResourceStatus `json:",inline"`
 	AtProvider          UserObservation `json:"atProvider,omitempty"`
+
+	// SQLStatistics counts the SQL the last reconcile ran against HANA.
+	// +kubebuilder:validation:Optional
+	SQLStatistics *apisv1alpha1.SQLStatistics `json:"sqlStatistics,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -123,9 +508,12 @@ type UserStatus struct {
 // +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
 // +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
 // +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
+// +kubebuilder:printcolumn:name="LOCKED",type="boolean",JSONPath=".status.atProvider.isLocked",priority=1
+// +kubebuilder:printcolumn:name="INVALID-CONNECTS",type="integer",JSONPath=".status.atProvider.invalidConnectAttempts",priority=1
+// +kubebuilder:printcolumn:name="LAST-CONNECT",type="date",JSONPath=".status.atProvider.lastSuccessfulConnect",priority=1
 // +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
 // +kubebuilder:subresource:status
-// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,sql}
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,sql,hana},shortName={hanauser}
 type User struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
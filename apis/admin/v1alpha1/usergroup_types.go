@@ -8,6 +8,8 @@ import (
 	"reflect"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	apisv1alpha1 "github.com/SAP/crossplane-provider-hana/apis/v1alpha1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 )
@@ -34,6 +36,11 @@ type UsergroupParameters struct {
 
 // UsergroupObservation are the observable fields of a Usergroup.
 type UsergroupObservation struct {
+	// ExternalID is the stable identifier of the usergroup, its name. It is also
+	// published as the externalID connection detail.
+	// +kubebuilder:validation:Optional
+	ExternalID string `json:"externalID,omitempty"`
+
 	// +kubebuilder:validation:Optional
 	UsergroupName string `json:"usergroupName"`
 
@@ -52,6 +59,10 @@ type UsergroupSpec struct {
 type UsergroupStatus struct {
 	xpv1.ResourceStatus `json:",inline"`
 	AtProvider          UsergroupObservation `json:"atProvider,omitempty"`
+
+	// SQLStatistics counts the SQL the last reconcile ran against HANA.
+	// +kubebuilder:validation:Optional
+	SQLStatistics *apisv1alpha1.SQLStatistics `json:"sqlStatistics,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -62,7 +73,7 @@ type UsergroupStatus struct {
 // +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
 // +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
 // +kubebuilder:subresource:status
-// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,sql}
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,sql,hana},shortName={hanausergroup}
 type Usergroup struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
@@ -0,0 +1,129 @@
+/*
+Copyright 2026 SAP SE or an SAP affiliate company and contributors.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	apisv1alpha1 "github.com/SAP/crossplane-provider-hana/apis/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// Replication modes of a UserReplication.
+const (
+	ReplicationModeOnce       = "Once"
+	ReplicationModeContinuous = "Continuous"
+)
+
+// ReplicationSource is the user or role whose grants are replicated.
+// +kubebuilder:validation:XValidation:rule="has(self.user) != has(self.role)",message="exactly one of user and role must be set"
+type ReplicationSource struct {
+	// User whose privileges and roles are replicated.
+	// +kubebuilder:validation:Optional
+	User string `json:"user,omitempty"`
+
+	// Role whose privileges and roles are replicated, qualified with its
+	// schema for schema-local roles.
+	// +kubebuilder:validation:Optional
+	Role string `json:"role,omitempty"`
+}
+
+// UserReplicationParameters are the configurable fields of a UserReplication.
+type UserReplicationParameters struct {
+	// Source is the user or role whose grants are replicated.
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Value is immutable"
+	Source ReplicationSource `json:"source"`
+
+	// TargetUser is the user the grants are replicated to.
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Value is immutable"
+	TargetUser string `json:"targetUser"`
+
+	// Mode selects whether the grants are copied once, or whether grants the
+	// source gains later are copied as well. Grants are never revoked from
+	// the target user. Defaults to Continuous.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=Once;Continuous
+	// +kubebuilder:default:=Continuous
+	Mode string `json:"mode,omitempty"`
+}
+
+// UserReplicationObservation are the observable fields of a UserReplication.
+type UserReplicationObservation struct {
+	// Privileges of the source the target user holds.
+	// +kubebuilder:validation:Optional
+	Privileges []string `json:"privileges,omitempty"`
+
+	// Roles of the source the target user holds.
+	// +kubebuilder:validation:Optional
+	Roles []string `json:"roles,omitempty"`
+
+	// ReplicatedAt is the time the target user first held all grants of the
+	// source.
+	// +kubebuilder:validation:Optional
+	ReplicatedAt *metav1.Time `json:"replicatedAt,omitempty"`
+}
+
+// A UserReplicationSpec defines the desired state of a UserReplication.
+type UserReplicationSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       UserReplicationParameters `json:"forProvider"`
+}
+
+// A UserReplicationStatus represents the observed state of a UserReplication.
+type UserReplicationStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          UserReplicationObservation `json:"atProvider,omitempty"`
+
+	// SQLStatistics counts the SQL the last reconcile ran against HANA.
+	// +kubebuilder:validation:Optional
+	SQLStatistics *apisv1alpha1.SQLStatistics `json:"sqlStatistics,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A UserReplication copies the privileges and roles of a user or role to
+// another user, e.g. to swap the technical user of an application.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="TARGET",type="string",JSONPath=".spec.forProvider.targetUser"
+// +kubebuilder:printcolumn:name="MODE",type="string",JSONPath=".spec.forProvider.mode"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,sql,hana},shortName={hanareplication}
+type UserReplication struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   UserReplicationSpec   `json:"spec"`
+	Status UserReplicationStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// UserReplicationList contains a list of UserReplication
+type UserReplicationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []UserReplication `json:"items"`
+}
+
+// UserReplication type metadata.
+var (
+	UserReplicationKind             = reflect.TypeFor[UserReplication]().Name()
+	UserReplicationGroupKind        = schema.GroupKind{Group: Group, Kind: UserReplicationKind}.String()
+	UserReplicationKindAPIVersion   = UserReplicationKind + "." + SchemeGroupVersion.String()
+	UserReplicationGroupVersionKind = SchemeGroupVersion.WithKind(UserReplicationKind)
+)
+
+func init() {
+	SchemeBuilder.Register(
+		&UserReplication{},
+		&UserReplicationList{},
+	)
+}
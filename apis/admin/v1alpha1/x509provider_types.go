@@ -11,17 +11,22 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	apisv1alpha1 "github.com/SAP/crossplane-provider-hana/apis/v1alpha1"
 )
 
 // X509ProviderParameters are the configurable fields of a X509Provider.
 type X509ProviderParameters struct {
-	// Name of the X509 provider
-	// +kubebuilder:validation:Required
+	// Name of the X509 provider, taken as written, including its case.
+	// Defaults to the external name of the X509Provider, or its
+	// metadata.name.
+	// +kubebuilder:validation:Optional
 	// +kubebuilder:validation:MinLength=1
 	// +kubebuilder:validation:MaxLength=127
-	Name string `json:"name"`
+	Name string `json:"name,omitempty"`
 
-	// Issuer distinguished name
+	// Issuer distinguished name in RFC 2253 format, e.g.
+	// "CN=SAP Cloud Root CA, O=SAP SE, C=DE".
 	// +kubebuilder:validation:Required
 	// +kubebuilder:validation:MinLength=1
 	Issuer string `json:"issuer"`
@@ -37,6 +42,11 @@ type X509ProviderParameters struct {
 
 // X509ProviderObservation are the observable fields of a X509Provider.
 type X509ProviderObservation struct {
+	// ExternalID is the stable identifier of the X.509 provider, its name. It is
+	// also published as the externalID connection detail.
+	// +kubebuilder:validation:Optional
+	ExternalID string `json:"externalID,omitempty"`
+
 	// Name of the X509 provider
 	// +kubebuilder:validation:Optional
 	Name *string `json:"name,omitempty"`
@@ -64,6 +74,10 @@ type X509ProviderSpec struct {
 type X509ProviderStatus struct {
 	xpv1.ResourceStatus `json:",inline"`
 	AtProvider          X509ProviderObservation `json:"atProvider,omitempty"`
+
+	// SQLStatistics counts the SQL the last reconcile ran against HANA.
+	// +kubebuilder:validation:Optional
+	SQLStatistics *apisv1alpha1.SQLStatistics `json:"sqlStatistics,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -74,7 +88,7 @@ type X509ProviderStatus struct {
 // +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
 // +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
 // +kubebuilder:subresource:status
-// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,hana}
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,hana},shortName={x509p}
 type X509Provider struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
@@ -9,10 +9,39 @@ Copyright 2026 SAP SE or an SAP affiliate company and contributors.
 package v1alpha1
 
 import (
-	"github.com/crossplane/crossplane-runtime/apis/common/v1"
+	apisv1alpha1 "github.com/SAP/crossplane-provider-hana/apis/v1alpha1"
+	commonv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ApplicationUserObservation) DeepCopyInto(out *ApplicationUserObservation) {
+	*out = *in
+	if in.RoleCollections != nil {
+		in, out := &in.RoleCollections, &out.RoleCollections
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ApplicationUserObservation.
+func (in *ApplicationUserObservation) DeepCopy() *ApplicationUserObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(ApplicationUserObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AuditPolicy) DeepCopyInto(out *AuditPolicy) {
 	*out = *in
@@ -154,6 +183,11 @@ func (in *AuditPolicyStatus) DeepCopyInto(out *AuditPolicyStatus) {
 	*out = *in
 	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
 	in.AtProvider.DeepCopyInto(&out.AtProvider)
+	if in.SQLStatistics != nil {
+		in, out := &in.SQLStatistics, &out.SQLStatistics
+		*out = new(apisv1alpha1.SQLStatistics)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AuditPolicyStatus.
@@ -193,6 +227,25 @@ func (in *Authentication) DeepCopy() *Authentication {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CRLStatus) DeepCopyInto(out *CRLStatus) {
+	*out = *in
+	if in.LastUpdateTime != nil {
+		in, out := &in.LastUpdateTime, &out.LastUpdateTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CRLStatus.
+func (in *CRLStatus) DeepCopy() *CRLStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CRLStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CertificateRef) DeepCopyInto(out *CertificateRef) {
 	*out = *in
@@ -219,27 +272,71 @@ func (in *CertificateRef) DeepCopy() *CertificateRef {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Password) DeepCopyInto(out *Password) {
+func (in *CertificateRotation) DeepCopyInto(out *CertificateRotation) {
 	*out = *in
-	if in.PasswordSecretRef != nil {
-		in, out := &in.PasswordSecretRef, &out.PasswordSecretRef
-		*out = new(v1.SecretKeySelector)
-		**out = **in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateRotation.
+func (in *CertificateRotation) DeepCopy() *CertificateRotation {
+	if in == nil {
+		return nil
 	}
+	out := new(CertificateRotation)
+	in.DeepCopyInto(out)
+	return out
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Password.
-func (in *Password) DeepCopy() *Password {
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CertificateRotationStatus) DeepCopyInto(out *CertificateRotationStatus) {
+	*out = *in
+	if in.RetainedCertificateRefs != nil {
+		in, out := &in.RetainedCertificateRefs, &out.RetainedCertificateRefs
+		*out = make([]CertificateRef, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastRotationTime != nil {
+		in, out := &in.LastRotationTime, &out.LastRotationTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CertificateRotationStatus.
+func (in *CertificateRotationStatus) DeepCopy() *CertificateRotationStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(Password)
+	out := new(CertificateRotationStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PersonalSecurityEnvironment) DeepCopyInto(out *PersonalSecurityEnvironment) {
+func (in *ConnectionRestrictions) DeepCopyInto(out *ConnectionRestrictions) {
+	*out = *in
+	if in.ValidFrom != nil {
+		in, out := &in.ValidFrom, &out.ValidFrom
+		*out = (*in).DeepCopy()
+	}
+	if in.ValidUntil != nil {
+		in, out := &in.ValidUntil, &out.ValidUntil
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConnectionRestrictions.
+func (in *ConnectionRestrictions) DeepCopy() *ConnectionRestrictions {
+	if in == nil {
+		return nil
+	}
+	out := new(ConnectionRestrictions)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DriftReport) DeepCopyInto(out *DriftReport) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -247,18 +344,18 @@ func (in *PersonalSecurityEnvironment) DeepCopyInto(out *PersonalSecurityEnviron
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PersonalSecurityEnvironment.
-func (in *PersonalSecurityEnvironment) DeepCopy() *PersonalSecurityEnvironment {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DriftReport.
+func (in *DriftReport) DeepCopy() *DriftReport {
 	if in == nil {
 		return nil
 	}
-	out := new(PersonalSecurityEnvironment)
+	out := new(DriftReport)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *PersonalSecurityEnvironment) DeepCopyObject() runtime.Object {
+func (in *DriftReport) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -266,31 +363,31 @@ func (in *PersonalSecurityEnvironment) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PersonalSecurityEnvironmentList) DeepCopyInto(out *PersonalSecurityEnvironmentList) {
+func (in *DriftReportList) DeepCopyInto(out *DriftReportList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]PersonalSecurityEnvironment, len(*in))
+		*out = make([]DriftReport, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PersonalSecurityEnvironmentList.
-func (in *PersonalSecurityEnvironmentList) DeepCopy() *PersonalSecurityEnvironmentList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DriftReportList.
+func (in *DriftReportList) DeepCopy() *DriftReportList {
 	if in == nil {
 		return nil
 	}
-	out := new(PersonalSecurityEnvironmentList)
+	out := new(DriftReportList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *PersonalSecurityEnvironmentList) DeepCopyObject() runtime.Object {
+func (in *DriftReportList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -298,90 +395,120 @@ func (in *PersonalSecurityEnvironmentList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PersonalSecurityEnvironmentObservation) DeepCopyInto(out *PersonalSecurityEnvironmentObservation) {
+func (in *DriftReportObservation) DeepCopyInto(out *DriftReportObservation) {
 	*out = *in
-	if in.CertificateRefs != nil {
-		in, out := &in.CertificateRefs, &out.CertificateRefs
-		*out = make([]CertificateRef, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.DriftedUsers != nil {
+		in, out := &in.DriftedUsers, &out.DriftedUsers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MissingUsers != nil {
+		in, out := &in.MissingUsers, &out.MissingUsers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ForeignGrantUsers != nil {
+		in, out := &in.ForeignGrantUsers, &out.ForeignGrantUsers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.OrphanedUsers != nil {
+		in, out := &in.OrphanedUsers, &out.OrphanedUsers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DriftedRoles != nil {
+		in, out := &in.DriftedRoles, &out.DriftedRoles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MissingRoles != nil {
+		in, out := &in.MissingRoles, &out.MissingRoles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.FailedObservations != nil {
+		in, out := &in.FailedObservations, &out.FailedObservations
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastReportTime != nil {
+		in, out := &in.LastReportTime, &out.LastReportTime
+		*out = (*in).DeepCopy()
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PersonalSecurityEnvironmentObservation.
-func (in *PersonalSecurityEnvironmentObservation) DeepCopy() *PersonalSecurityEnvironmentObservation {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DriftReportObservation.
+func (in *DriftReportObservation) DeepCopy() *DriftReportObservation {
 	if in == nil {
 		return nil
 	}
-	out := new(PersonalSecurityEnvironmentObservation)
+	out := new(DriftReportObservation)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PersonalSecurityEnvironmentParameters) DeepCopyInto(out *PersonalSecurityEnvironmentParameters) {
+func (in *DriftReportParameters) DeepCopyInto(out *DriftReportParameters) {
 	*out = *in
-	if in.X509ProviderRef != nil {
-		in, out := &in.X509ProviderRef, &out.X509ProviderRef
-		*out = new(X509ProviderRef)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.CertificateRefs != nil {
-		in, out := &in.CertificateRefs, &out.CertificateRefs
-		*out = make([]CertificateRef, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.Interval != nil {
+		in, out := &in.Interval, &out.Interval
+		*out = new(v1.Duration)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PersonalSecurityEnvironmentParameters.
-func (in *PersonalSecurityEnvironmentParameters) DeepCopy() *PersonalSecurityEnvironmentParameters {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DriftReportParameters.
+func (in *DriftReportParameters) DeepCopy() *DriftReportParameters {
 	if in == nil {
 		return nil
 	}
-	out := new(PersonalSecurityEnvironmentParameters)
+	out := new(DriftReportParameters)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PersonalSecurityEnvironmentSpec) DeepCopyInto(out *PersonalSecurityEnvironmentSpec) {
+func (in *DriftReportSpec) DeepCopyInto(out *DriftReportSpec) {
 	*out = *in
 	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
 	in.ForProvider.DeepCopyInto(&out.ForProvider)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PersonalSecurityEnvironmentSpec.
-func (in *PersonalSecurityEnvironmentSpec) DeepCopy() *PersonalSecurityEnvironmentSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DriftReportSpec.
+func (in *DriftReportSpec) DeepCopy() *DriftReportSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(PersonalSecurityEnvironmentSpec)
+	out := new(DriftReportSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PersonalSecurityEnvironmentStatus) DeepCopyInto(out *PersonalSecurityEnvironmentStatus) {
+func (in *DriftReportStatus) DeepCopyInto(out *DriftReportStatus) {
 	*out = *in
 	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
 	in.AtProvider.DeepCopyInto(&out.AtProvider)
+	if in.SQLStatistics != nil {
+		in, out := &in.SQLStatistics, &out.SQLStatistics
+		*out = new(apisv1alpha1.SQLStatistics)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PersonalSecurityEnvironmentStatus.
-func (in *PersonalSecurityEnvironmentStatus) DeepCopy() *PersonalSecurityEnvironmentStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DriftReportStatus.
+func (in *DriftReportStatus) DeepCopy() *DriftReportStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(PersonalSecurityEnvironmentStatus)
+	out := new(DriftReportStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Role) DeepCopyInto(out *Role) {
+func (in *MonitoringUser) DeepCopyInto(out *MonitoringUser) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -389,18 +516,18 @@ func (in *Role) DeepCopyInto(out *Role) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Role.
-func (in *Role) DeepCopy() *Role {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonitoringUser.
+func (in *MonitoringUser) DeepCopy() *MonitoringUser {
 	if in == nil {
 		return nil
 	}
-	out := new(Role)
+	out := new(MonitoringUser)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *Role) DeepCopyObject() runtime.Object {
+func (in *MonitoringUser) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -408,31 +535,31 @@ func (in *Role) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RoleList) DeepCopyInto(out *RoleList) {
+func (in *MonitoringUserList) DeepCopyInto(out *MonitoringUserList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]Role, len(*in))
+		*out = make([]MonitoringUser, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleList.
-func (in *RoleList) DeepCopy() *RoleList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonitoringUserList.
+func (in *MonitoringUserList) DeepCopy() *MonitoringUserList {
 	if in == nil {
 		return nil
 	}
-	out := new(RoleList)
+	out := new(MonitoringUserList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *RoleList) DeepCopyObject() runtime.Object {
+func (in *MonitoringUserList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -440,91 +567,665 @@ func (in *RoleList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RoleObservation) DeepCopyInto(out *RoleObservation) {
+func (in *MonitoringUserObservation) DeepCopyInto(out *MonitoringUserObservation) {
 	*out = *in
-	if in.LdapGroups != nil {
-		in, out := &in.LdapGroups, &out.LdapGroups
+	if in.Privileges != nil {
+		in, out := &in.Privileges, &out.Privileges
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
-	if in.Privileges != nil {
-		in, out := &in.Privileges, &out.Privileges
+	if in.Roles != nil {
+		in, out := &in.Roles, &out.Roles
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.PasswordUpToDate != nil {
+		in, out := &in.PasswordUpToDate, &out.PasswordUpToDate
+		*out = new(bool)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleObservation.
-func (in *RoleObservation) DeepCopy() *RoleObservation {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonitoringUserObservation.
+func (in *MonitoringUserObservation) DeepCopy() *MonitoringUserObservation {
 	if in == nil {
 		return nil
 	}
-	out := new(RoleObservation)
+	out := new(MonitoringUserObservation)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RoleParameters) DeepCopyInto(out *RoleParameters) {
+func (in *MonitoringUserParameters) DeepCopyInto(out *MonitoringUserParameters) {
 	*out = *in
-	if in.LdapGroups != nil {
-		in, out := &in.LdapGroups, &out.LdapGroups
-		*out = make([]string, len(*in))
-		copy(*out, *in)
+	out.PasswordSecretRef = in.PasswordSecretRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonitoringUserParameters.
+func (in *MonitoringUserParameters) DeepCopy() *MonitoringUserParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(MonitoringUserParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MonitoringUserSpec) DeepCopyInto(out *MonitoringUserSpec) {
+	*out = *in
+	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
+	out.ForProvider = in.ForProvider
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonitoringUserSpec.
+func (in *MonitoringUserSpec) DeepCopy() *MonitoringUserSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MonitoringUserSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MonitoringUserStatus) DeepCopyInto(out *MonitoringUserStatus) {
+	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+	in.AtProvider.DeepCopyInto(&out.AtProvider)
+	if in.SQLStatistics != nil {
+		in, out := &in.SQLStatistics, &out.SQLStatistics
+		*out = new(apisv1alpha1.SQLStatistics)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MonitoringUserStatus.
+func (in *MonitoringUserStatus) DeepCopy() *MonitoringUserStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(MonitoringUserStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Password) DeepCopyInto(out *Password) {
+	*out = *in
+	if in.PasswordSecretRef != nil {
+		in, out := &in.PasswordSecretRef, &out.PasswordSecretRef
+		*out = new(commonv1.SecretKeySelector)
+		**out = **in
+	}
+	if in.PasswordStoreRef != nil {
+		in, out := &in.PasswordStoreRef, &out.PasswordStoreRef
+		*out = new(PasswordStoreReference)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Password.
+func (in *Password) DeepCopy() *Password {
+	if in == nil {
+		return nil
 	}
+	out := new(Password)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PasswordStoreReference) DeepCopyInto(out *PasswordStoreReference) {
+	*out = *in
+	if in.StoreConfigRef != nil {
+		in, out := &in.StoreConfigRef, &out.StoreConfigRef
+		*out = new(commonv1.Reference)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PasswordStoreReference.
+func (in *PasswordStoreReference) DeepCopy() *PasswordStoreReference {
+	if in == nil {
+		return nil
+	}
+	out := new(PasswordStoreReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PendingRevocations) DeepCopyInto(out *PendingRevocations) {
+	*out = *in
 	if in.Privileges != nil {
 		in, out := &in.Privileges, &out.Privileges
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Roles != nil {
+		in, out := &in.Roles, &out.Roles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ForeignPrivileges != nil {
+		in, out := &in.ForeignPrivileges, &out.ForeignPrivileges
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleParameters.
-func (in *RoleParameters) DeepCopy() *RoleParameters {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PendingRevocations.
+func (in *PendingRevocations) DeepCopy() *PendingRevocations {
 	if in == nil {
 		return nil
 	}
-	out := new(RoleParameters)
+	out := new(PendingRevocations)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RoleSpec) DeepCopyInto(out *RoleSpec) {
+func (in *PersonalSecurityEnvironment) DeepCopyInto(out *PersonalSecurityEnvironment) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PersonalSecurityEnvironment.
+func (in *PersonalSecurityEnvironment) DeepCopy() *PersonalSecurityEnvironment {
+	if in == nil {
+		return nil
+	}
+	out := new(PersonalSecurityEnvironment)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PersonalSecurityEnvironment) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PersonalSecurityEnvironmentList) DeepCopyInto(out *PersonalSecurityEnvironmentList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]PersonalSecurityEnvironment, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PersonalSecurityEnvironmentList.
+func (in *PersonalSecurityEnvironmentList) DeepCopy() *PersonalSecurityEnvironmentList {
+	if in == nil {
+		return nil
+	}
+	out := new(PersonalSecurityEnvironmentList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PersonalSecurityEnvironmentList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PersonalSecurityEnvironmentObservation) DeepCopyInto(out *PersonalSecurityEnvironmentObservation) {
+	*out = *in
+	if in.CertificateRefs != nil {
+		in, out := &in.CertificateRefs, &out.CertificateRefs
+		*out = make([]CertificateRef, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PersonalSecurityEnvironmentObservation.
+func (in *PersonalSecurityEnvironmentObservation) DeepCopy() *PersonalSecurityEnvironmentObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(PersonalSecurityEnvironmentObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PersonalSecurityEnvironmentParameters) DeepCopyInto(out *PersonalSecurityEnvironmentParameters) {
+	*out = *in
+	if in.X509ProviderRef != nil {
+		in, out := &in.X509ProviderRef, &out.X509ProviderRef
+		*out = new(X509ProviderRef)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CertificateRefs != nil {
+		in, out := &in.CertificateRefs, &out.CertificateRefs
+		*out = make([]CertificateRef, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.CertificateRotation != nil {
+		in, out := &in.CertificateRotation, &out.CertificateRotation
+		*out = new(CertificateRotation)
+		**out = **in
+	}
+	if in.CRLSecretRef != nil {
+		in, out := &in.CRLSecretRef, &out.CRLSecretRef
+		*out = new(commonv1.SecretKeySelector)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PersonalSecurityEnvironmentParameters.
+func (in *PersonalSecurityEnvironmentParameters) DeepCopy() *PersonalSecurityEnvironmentParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(PersonalSecurityEnvironmentParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PersonalSecurityEnvironmentSpec) DeepCopyInto(out *PersonalSecurityEnvironmentSpec) {
+	*out = *in
+	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PersonalSecurityEnvironmentSpec.
+func (in *PersonalSecurityEnvironmentSpec) DeepCopy() *PersonalSecurityEnvironmentSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PersonalSecurityEnvironmentSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PersonalSecurityEnvironmentStatus) DeepCopyInto(out *PersonalSecurityEnvironmentStatus) {
+	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+	in.AtProvider.DeepCopyInto(&out.AtProvider)
+	if in.Rotation != nil {
+		in, out := &in.Rotation, &out.Rotation
+		*out = new(CertificateRotationStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CRL != nil {
+		in, out := &in.CRL, &out.CRL
+		*out = new(CRLStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SQLStatistics != nil {
+		in, out := &in.SQLStatistics, &out.SQLStatistics
+		*out = new(apisv1alpha1.SQLStatistics)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PersonalSecurityEnvironmentStatus.
+func (in *PersonalSecurityEnvironmentStatus) DeepCopy() *PersonalSecurityEnvironmentStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PersonalSecurityEnvironmentStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrivilegeGrantObservation) DeepCopyInto(out *PrivilegeGrantObservation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrivilegeGrantObservation.
+func (in *PrivilegeGrantObservation) DeepCopy() *PrivilegeGrantObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(PrivilegeGrantObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrivilegeSelector) DeepCopyInto(out *PrivilegeSelector) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrivilegeSelector.
+func (in *PrivilegeSelector) DeepCopy() *PrivilegeSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(PrivilegeSelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReplicationSource) DeepCopyInto(out *ReplicationSource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReplicationSource.
+func (in *ReplicationSource) DeepCopy() *ReplicationSource {
+	if in == nil {
+		return nil
+	}
+	out := new(ReplicationSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Role) DeepCopyInto(out *Role) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Role.
+func (in *Role) DeepCopy() *Role {
+	if in == nil {
+		return nil
+	}
+	out := new(Role)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Role) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RoleList) DeepCopyInto(out *RoleList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Role, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleList.
+func (in *RoleList) DeepCopy() *RoleList {
+	if in == nil {
+		return nil
+	}
+	out := new(RoleList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RoleList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RoleObservation) DeepCopyInto(out *RoleObservation) {
+	*out = *in
+	if in.LdapGroups != nil {
+		in, out := &in.LdapGroups, &out.LdapGroups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Privileges != nil {
+		in, out := &in.Privileges, &out.Privileges
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleObservation.
+func (in *RoleObservation) DeepCopy() *RoleObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(RoleObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RoleParameters) DeepCopyInto(out *RoleParameters) {
+	*out = *in
+	if in.LdapGroups != nil {
+		in, out := &in.LdapGroups, &out.LdapGroups
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Privileges != nil {
+		in, out := &in.Privileges, &out.Privileges
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.TypedPrivileges != nil {
+		in, out := &in.TypedPrivileges, &out.TypedPrivileges
+		*out = make([]TypedPrivilege, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExecutionUserSecretRef != nil {
+		in, out := &in.ExecutionUserSecretRef, &out.ExecutionUserSecretRef
+		*out = new(commonv1.SecretReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleParameters.
+func (in *RoleParameters) DeepCopy() *RoleParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(RoleParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RoleSpec) DeepCopyInto(out *RoleSpec) {
+	*out = *in
+	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleSpec.
+func (in *RoleSpec) DeepCopy() *RoleSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RoleSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RoleStatus) DeepCopyInto(out *RoleStatus) {
+	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+	in.AtProvider.DeepCopyInto(&out.AtProvider)
+	if in.SQLStatistics != nil {
+		in, out := &in.SQLStatistics, &out.SQLStatistics
+		*out = new(apisv1alpha1.SQLStatistics)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleStatus.
+func (in *RoleStatus) DeepCopy() *RoleStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RoleStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Rolegroup) DeepCopyInto(out *Rolegroup) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Rolegroup.
+func (in *Rolegroup) DeepCopy() *Rolegroup {
+	if in == nil {
+		return nil
+	}
+	out := new(Rolegroup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Rolegroup) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RolegroupList) DeepCopyInto(out *RolegroupList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Rolegroup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolegroupList.
+func (in *RolegroupList) DeepCopy() *RolegroupList {
+	if in == nil {
+		return nil
+	}
+	out := new(RolegroupList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RolegroupList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RolegroupObservation) DeepCopyInto(out *RolegroupObservation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolegroupObservation.
+func (in *RolegroupObservation) DeepCopy() *RolegroupObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(RolegroupObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RolegroupParameters) DeepCopyInto(out *RolegroupParameters) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolegroupParameters.
+func (in *RolegroupParameters) DeepCopy() *RolegroupParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(RolegroupParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RolegroupSpec) DeepCopyInto(out *RolegroupSpec) {
 	*out = *in
 	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
-	in.ForProvider.DeepCopyInto(&out.ForProvider)
+	out.ForProvider = in.ForProvider
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleSpec.
-func (in *RoleSpec) DeepCopy() *RoleSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolegroupSpec.
+func (in *RolegroupSpec) DeepCopy() *RolegroupSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(RoleSpec)
+	out := new(RolegroupSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RoleStatus) DeepCopyInto(out *RoleStatus) {
+func (in *RolegroupStatus) DeepCopyInto(out *RolegroupStatus) {
 	*out = *in
 	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
-	in.AtProvider.DeepCopyInto(&out.AtProvider)
+	out.AtProvider = in.AtProvider
+	if in.SQLStatistics != nil {
+		in, out := &in.SQLStatistics, &out.SQLStatistics
+		*out = new(apisv1alpha1.SQLStatistics)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RoleStatus.
-func (in *RoleStatus) DeepCopy() *RoleStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolegroupStatus.
+func (in *RolegroupStatus) DeepCopy() *RolegroupStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(RoleStatus)
+	out := new(RolegroupStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Rolegroup) DeepCopyInto(out *Rolegroup) {
+func (in *Singleton) DeepCopyInto(out *Singleton) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -532,18 +1233,18 @@ func (in *Rolegroup) DeepCopyInto(out *Rolegroup) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Rolegroup.
-func (in *Rolegroup) DeepCopy() *Rolegroup {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Singleton.
+func (in *Singleton) DeepCopy() *Singleton {
 	if in == nil {
 		return nil
 	}
-	out := new(Rolegroup)
+	out := new(Singleton)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *Rolegroup) DeepCopyObject() runtime.Object {
+func (in *Singleton) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -551,31 +1252,31 @@ func (in *Rolegroup) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RolegroupList) DeepCopyInto(out *RolegroupList) {
+func (in *SingletonList) DeepCopyInto(out *SingletonList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]Rolegroup, len(*in))
+		*out = make([]Singleton, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolegroupList.
-func (in *RolegroupList) DeepCopy() *RolegroupList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SingletonList.
+func (in *SingletonList) DeepCopy() *SingletonList {
 	if in == nil {
 		return nil
 	}
-	out := new(RolegroupList)
+	out := new(SingletonList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *RolegroupList) DeepCopyObject() runtime.Object {
+func (in *SingletonList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -583,65 +1284,85 @@ func (in *RolegroupList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RolegroupObservation) DeepCopyInto(out *RolegroupObservation) {
+func (in *SingletonObservation) DeepCopyInto(out *SingletonObservation) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolegroupObservation.
-func (in *RolegroupObservation) DeepCopy() *RolegroupObservation {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SingletonObservation.
+func (in *SingletonObservation) DeepCopy() *SingletonObservation {
 	if in == nil {
 		return nil
 	}
-	out := new(RolegroupObservation)
+	out := new(SingletonObservation)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RolegroupParameters) DeepCopyInto(out *RolegroupParameters) {
+func (in *SingletonParameters) DeepCopyInto(out *SingletonParameters) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolegroupParameters.
-func (in *RolegroupParameters) DeepCopy() *RolegroupParameters {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SingletonParameters.
+func (in *SingletonParameters) DeepCopy() *SingletonParameters {
 	if in == nil {
 		return nil
 	}
-	out := new(RolegroupParameters)
+	out := new(SingletonParameters)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RolegroupSpec) DeepCopyInto(out *RolegroupSpec) {
+func (in *SingletonSpec) DeepCopyInto(out *SingletonSpec) {
 	*out = *in
 	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
 	out.ForProvider = in.ForProvider
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolegroupSpec.
-func (in *RolegroupSpec) DeepCopy() *RolegroupSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SingletonSpec.
+func (in *SingletonSpec) DeepCopy() *SingletonSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(RolegroupSpec)
+	out := new(SingletonSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RolegroupStatus) DeepCopyInto(out *RolegroupStatus) {
+func (in *SingletonStatus) DeepCopyInto(out *SingletonStatus) {
 	*out = *in
 	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
 	out.AtProvider = in.AtProvider
+	if in.SQLStatistics != nil {
+		in, out := &in.SQLStatistics, &out.SQLStatistics
+		*out = new(apisv1alpha1.SQLStatistics)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RolegroupStatus.
-func (in *RolegroupStatus) DeepCopy() *RolegroupStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SingletonStatus.
+func (in *SingletonStatus) DeepCopy() *SingletonStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(RolegroupStatus)
+	out := new(SingletonStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TypedPrivilege) DeepCopyInto(out *TypedPrivilege) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TypedPrivilege.
+func (in *TypedPrivilege) DeepCopy() *TypedPrivilege {
+	if in == nil {
+		return nil
+	}
+	out := new(TypedPrivilege)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -673,6 +1394,37 @@ func (in *User) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UserAspectsObservation) DeepCopyInto(out *UserAspectsObservation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UserAspectsObservation.
+func (in *UserAspectsObservation) DeepCopy() *UserAspectsObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(UserAspectsObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UserAuthenticationObservation) DeepCopyInto(out *UserAuthenticationObservation) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UserAuthenticationObservation.
+func (in *UserAuthenticationObservation) DeepCopy() *UserAuthenticationObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(UserAuthenticationObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *UserList) DeepCopyInto(out *UserList) {
 	*out = *in
@@ -718,6 +1470,43 @@ func (in *UserObservation) DeepCopyInto(out *UserObservation) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.IsClientConnectEnabled != nil {
+		in, out := &in.IsClientConnectEnabled, &out.IsClientConnectEnabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ValidFrom != nil {
+		in, out := &in.ValidFrom, &out.ValidFrom
+		*out = (*in).DeepCopy()
+	}
+	if in.ValidUntil != nil {
+		in, out := &in.ValidUntil, &out.ValidUntil
+		*out = (*in).DeepCopy()
+	}
+	if in.LastSuccessfulConnect != nil {
+		in, out := &in.LastSuccessfulConnect, &out.LastSuccessfulConnect
+		*out = (*in).DeepCopy()
+	}
+	if in.InvalidConnectAttempts != nil {
+		in, out := &in.InvalidConnectAttempts, &out.InvalidConnectAttempts
+		*out = new(int32)
+		**out = **in
+	}
+	if in.IsLocked != nil {
+		in, out := &in.IsLocked, &out.IsLocked
+		*out = new(bool)
+		**out = **in
+	}
+	if in.PendingRevocations != nil {
+		in, out := &in.PendingRevocations, &out.PendingRevocations
+		*out = new(PendingRevocations)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ApplicationUser != nil {
+		in, out := &in.ApplicationUser, &out.ApplicationUser
+		*out = new(ApplicationUserObservation)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.X509Providers != nil {
 		in, out := &in.X509Providers, &out.X509Providers
 		*out = make([]X509UserMapping, len(*in))
@@ -725,23 +1514,47 @@ func (in *UserObservation) DeepCopyInto(out *UserObservation) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.LastAuthentication != nil {
+		in, out := &in.LastAuthentication, &out.LastAuthentication
+		*out = new(UserAuthenticationObservation)
+		(*in).DeepCopyInto(*out)
+	}
 	in.LastPasswordChangeTime.DeepCopyInto(&out.LastPasswordChangeTime)
 	if in.PasswordUpToDate != nil {
 		in, out := &in.PasswordUpToDate, &out.PasswordUpToDate
 		*out = new(bool)
 		**out = **in
 	}
+	if in.PasswordExpiresAt != nil {
+		in, out := &in.PasswordExpiresAt, &out.PasswordExpiresAt
+		*out = (*in).DeepCopy()
+	}
+	if in.DaysUntilPasswordExpiry != nil {
+		in, out := &in.DaysUntilPasswordExpiry, &out.DaysUntilPasswordExpiry
+		*out = new(int32)
+		**out = **in
+	}
 	in.CreatedAt.DeepCopyInto(&out.CreatedAt)
 	if in.Privileges != nil {
 		in, out := &in.Privileges, &out.Privileges
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.ForeignGrants != nil {
+		in, out := &in.ForeignGrants, &out.ForeignGrants
+		*out = make([]PrivilegeGrantObservation, len(*in))
+		copy(*out, *in)
+	}
 	if in.Roles != nil {
 		in, out := &in.Roles, &out.Roles
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.UnrevokableRoles != nil {
+		in, out := &in.UnrevokableRoles, &out.UnrevokableRoles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.Parameters != nil {
 		in, out := &in.Parameters, &out.Parameters
 		*out = make(map[string]string, len(*in))
@@ -764,6 +1577,21 @@ func (in *UserObservation) DeepCopyInto(out *UserObservation) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.UnobservedFields != nil {
+		in, out := &in.UnobservedFields, &out.UnobservedFields
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AppliedDefaults != nil {
+		in, out := &in.AppliedDefaults, &out.AppliedDefaults
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Aspects != nil {
+		in, out := &in.Aspects, &out.Aspects
+		*out = new(UserAspectsObservation)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UserObservation.
@@ -779,12 +1607,27 @@ func (in *UserObservation) DeepCopy() *UserObservation {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *UserParameters) DeepCopyInto(out *UserParameters) {
 	*out = *in
+	if in.ClientConnect != nil {
+		in, out := &in.ClientConnect, &out.ClientConnect
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ConnectionRestrictions != nil {
+		in, out := &in.ConnectionRestrictions, &out.ConnectionRestrictions
+		*out = new(ConnectionRestrictions)
+		(*in).DeepCopyInto(*out)
+	}
 	in.Authentication.DeepCopyInto(&out.Authentication)
 	if in.Privileges != nil {
 		in, out := &in.Privileges, &out.Privileges
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.TypedPrivileges != nil {
+		in, out := &in.TypedPrivileges, &out.TypedPrivileges
+		*out = make([]TypedPrivilege, len(*in))
+		copy(*out, *in)
+	}
 	if in.Roles != nil {
 		in, out := &in.Roles, &out.Roles
 		*out = make([]string, len(*in))
@@ -797,6 +1640,31 @@ func (in *UserParameters) DeepCopyInto(out *UserParameters) {
 			(*out)[key] = val
 		}
 	}
+	if in.ManagePrivileges != nil {
+		in, out := &in.ManagePrivileges, &out.ManagePrivileges
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ManageRoles != nil {
+		in, out := &in.ManageRoles, &out.ManageRoles
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ManageParameters != nil {
+		in, out := &in.ManageParameters, &out.ManageParameters
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ManagePassword != nil {
+		in, out := &in.ManagePassword, &out.ManagePassword
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ExecutionUserSecretRef != nil {
+		in, out := &in.ExecutionUserSecretRef, &out.ExecutionUserSecretRef
+		*out = new(commonv1.SecretReference)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UserParameters.
@@ -809,11 +1677,159 @@ func (in *UserParameters) DeepCopy() *UserParameters {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UserReplication) DeepCopyInto(out *UserReplication) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UserReplication.
+func (in *UserReplication) DeepCopy() *UserReplication {
+	if in == nil {
+		return nil
+	}
+	out := new(UserReplication)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *UserReplication) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UserReplicationList) DeepCopyInto(out *UserReplicationList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]UserReplication, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UserReplicationList.
+func (in *UserReplicationList) DeepCopy() *UserReplicationList {
+	if in == nil {
+		return nil
+	}
+	out := new(UserReplicationList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *UserReplicationList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UserReplicationObservation) DeepCopyInto(out *UserReplicationObservation) {
+	*out = *in
+	if in.Privileges != nil {
+		in, out := &in.Privileges, &out.Privileges
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Roles != nil {
+		in, out := &in.Roles, &out.Roles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ReplicatedAt != nil {
+		in, out := &in.ReplicatedAt, &out.ReplicatedAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UserReplicationObservation.
+func (in *UserReplicationObservation) DeepCopy() *UserReplicationObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(UserReplicationObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UserReplicationParameters) DeepCopyInto(out *UserReplicationParameters) {
+	*out = *in
+	out.Source = in.Source
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UserReplicationParameters.
+func (in *UserReplicationParameters) DeepCopy() *UserReplicationParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(UserReplicationParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UserReplicationSpec) DeepCopyInto(out *UserReplicationSpec) {
+	*out = *in
+	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
+	out.ForProvider = in.ForProvider
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UserReplicationSpec.
+func (in *UserReplicationSpec) DeepCopy() *UserReplicationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(UserReplicationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UserReplicationStatus) DeepCopyInto(out *UserReplicationStatus) {
+	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+	in.AtProvider.DeepCopyInto(&out.AtProvider)
+	if in.SQLStatistics != nil {
+		in, out := &in.SQLStatistics, &out.SQLStatistics
+		*out = new(apisv1alpha1.SQLStatistics)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UserReplicationStatus.
+func (in *UserReplicationStatus) DeepCopy() *UserReplicationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(UserReplicationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *UserSpec) DeepCopyInto(out *UserSpec) {
 	*out = *in
 	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
 	in.ForProvider.DeepCopyInto(&out.ForProvider)
+	if in.PrivilegeSelectors != nil {
+		in, out := &in.PrivilegeSelectors, &out.PrivilegeSelectors
+		*out = make([]PrivilegeSelector, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UserSpec.
@@ -831,6 +1847,11 @@ func (in *UserStatus) DeepCopyInto(out *UserStatus) {
 	*out = *in
 	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
 	in.AtProvider.DeepCopyInto(&out.AtProvider)
+	if in.SQLStatistics != nil {
+		in, out := &in.SQLStatistics, &out.SQLStatistics
+		*out = new(apisv1alpha1.SQLStatistics)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UserStatus.
@@ -968,6 +1989,11 @@ func (in *UsergroupStatus) DeepCopyInto(out *UsergroupStatus) {
 	*out = *in
 	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
 	in.AtProvider.DeepCopyInto(&out.AtProvider)
+	if in.SQLStatistics != nil {
+		in, out := &in.SQLStatistics, &out.SQLStatistics
+		*out = new(apisv1alpha1.SQLStatistics)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UsergroupStatus.
@@ -1104,7 +2130,12 @@ func (in *X509ProviderRef) DeepCopyInto(out *X509ProviderRef) {
 	*out = *in
 	if in.ProviderRef != nil {
 		in, out := &in.ProviderRef, &out.ProviderRef
-		*out = new(v1.Reference)
+		*out = new(commonv1.Reference)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ProviderSelector != nil {
+		in, out := &in.ProviderSelector, &out.ProviderSelector
+		*out = new(commonv1.Selector)
 		(*in).DeepCopyInto(*out)
 	}
 }
@@ -1141,6 +2172,11 @@ func (in *X509ProviderStatus) DeepCopyInto(out *X509ProviderStatus) {
 	*out = *in
 	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
 	in.AtProvider.DeepCopyInto(&out.AtProvider)
+	if in.SQLStatistics != nil {
+		in, out := &in.SQLStatistics, &out.SQLStatistics
+		*out = new(apisv1alpha1.SQLStatistics)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new X509ProviderStatus.
@@ -16,6 +16,24 @@ func (l *AuditPolicyList) GetItems() []resource.Managed {
 	return items
 }
 
+// GetItems of this DriftReportList.
+func (l *DriftReportList) GetItems() []resource.Managed {
+	items := make([]resource.Managed, len(l.Items))
+	for i := range l.Items {
+		items[i] = &l.Items[i]
+	}
+	return items
+}
+
+// GetItems of this MonitoringUserList.
+func (l *MonitoringUserList) GetItems() []resource.Managed {
+	items := make([]resource.Managed, len(l.Items))
+	for i := range l.Items {
+		items[i] = &l.Items[i]
+	}
+	return items
+}
+
 // GetItems of this PersonalSecurityEnvironmentList.
 func (l *PersonalSecurityEnvironmentList) GetItems() []resource.Managed {
 	items := make([]resource.Managed, len(l.Items))
@@ -43,6 +61,15 @@ func (l *RolegroupList) GetItems() []resource.Managed {
 	return items
 }
 
+// GetItems of this SingletonList.
+func (l *SingletonList) GetItems() []resource.Managed {
+	items := make([]resource.Managed, len(l.Items))
+	for i := range l.Items {
+		items[i] = &l.Items[i]
+	}
+	return items
+}
+
 // GetItems of this UserList.
 func (l *UserList) GetItems() []resource.Managed {
 	items := make([]resource.Managed, len(l.Items))
@@ -52,6 +79,15 @@ func (l *UserList) GetItems() []resource.Managed {
 	return items
 }
 
+// GetItems of this UserReplicationList.
+func (l *UserReplicationList) GetItems() []resource.Managed {
+	items := make([]resource.Managed, len(l.Items))
+	for i := range l.Items {
+		items[i] = &l.Items[i]
+	}
+	return items
+}
+
 // GetItems of this UsergroupList.
 func (l *UsergroupList) GetItems() []resource.Managed {
 	items := make([]resource.Managed, len(l.Items))
@@ -0,0 +1,130 @@
+/*
+Copyright 2026 SAP SE.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	apisv1alpha1 "github.com/SAP/crossplane-provider-hana/apis/v1alpha1"
+)
+
+// BackupSchedule is the schedule of the automatic backups of a HANA Cloud
+// instance.
+type BackupSchedule struct {
+	// Enabled switches the automatic backups of the instance on or off
+	// +kubebuilder:validation:Optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// StartTime is the time of day the daily full backup starts, in UTC and
+	// formatted as HH:MM
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Pattern=`^([01][0-9]|2[0-3]):[0-5][0-9]$`
+	StartTime string `json:"startTime,omitempty"`
+}
+
+// BackupSettings are the backup settings of a HANA Cloud instance.
+type BackupSettings struct {
+	// RetentionDays is the number of days backups are kept
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=215
+	RetentionDays *int32 `json:"retentionDays,omitempty"`
+
+	// Schedule is the schedule of the automatic backups
+	// +kubebuilder:validation:Optional
+	Schedule *BackupSchedule `json:"schedule,omitempty"`
+}
+
+// BackupConfigurationParameters are the configurable fields of a BackupConfiguration.
+type BackupConfigurationParameters struct {
+	// ServiceInstanceID is the GUID of the HANA Cloud service instance
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="serviceInstanceID is immutable"
+	ServiceInstanceID string `json:"serviceInstanceID"`
+
+	// BackupSettings to manage. Settings that are not set keep their
+	// current value.
+	BackupSettings `json:",inline"`
+
+	// AdminCredentialsSecretRef references a Secret containing admin API credentials
+	// +kubebuilder:validation:Required
+	AdminCredentialsSecretRef AdminCredentialsSecretRef `json:"adminCredentialsSecretRef"`
+
+	// Proxy routes the requests to the HANA Cloud Admin API through a proxy
+	// +kubebuilder:validation:Optional
+	Proxy *apisv1alpha1.ProxyConfig `json:"proxy,omitempty"`
+}
+
+// BackupConfigurationObservation are the observable fields of a BackupConfiguration.
+type BackupConfigurationObservation struct {
+	// BackupSettings are the current backup settings of the instance
+	BackupSettings `json:",inline"`
+
+	// Operation is the last asynchronous operation applying the settings
+	// +kubebuilder:validation:Optional
+	Operation OperationObservation `json:"operation,omitempty"`
+}
+
+// BackupConfigurationSpec defines the desired state of a BackupConfiguration.
+type BackupConfigurationSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       BackupConfigurationParameters `json:"forProvider"`
+}
+
+// BackupConfigurationStatus represents the observed state of a BackupConfiguration.
+type BackupConfigurationStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          BackupConfigurationObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// BackupConfiguration manages the backup retention and schedule of a HANA
+// Cloud instance through the HANA Cloud Admin API. Changes are applied
+// asynchronously; deleting the resource leaves the settings as they are.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="INSTANCE-ID",type="string",JSONPath=".spec.forProvider.serviceInstanceID"
+// +kubebuilder:printcolumn:name="RETENTION",type="integer",JSONPath=".status.atProvider.retentionDays"
+// +kubebuilder:printcolumn:name="OPERATION",type="string",JSONPath=".status.atProvider.operation.state"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,inventory,hana},shortName={hanabackup}
+type BackupConfiguration struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   BackupConfigurationSpec   `json:"spec"`
+	Status BackupConfigurationStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// BackupConfigurationList contains a list of BackupConfiguration
+type BackupConfigurationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []BackupConfiguration `json:"items"`
+}
+
+// BackupConfiguration type metadata.
+var (
+	BackupConfigurationKind             = reflect.TypeOf(BackupConfiguration{}).Name()
+	BackupConfigurationGroupKind        = schema.GroupKind{Group: Group, Kind: BackupConfigurationKind}.String()
+	BackupConfigurationKindAPIVersion   = BackupConfigurationKind + "." + SchemeGroupVersion.String()
+	BackupConfigurationGroupVersionKind = SchemeGroupVersion.WithKind(BackupConfigurationKind)
+)
+
+func init() {
+	SchemeBuilder.Register(
+		&BackupConfiguration{},
+		&BackupConfigurationList{},
+	)
+}
@@ -0,0 +1,123 @@
+/*
+Copyright 2026 SAP SE.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	apisv1alpha1 "github.com/SAP/crossplane-provider-hana/apis/v1alpha1"
+)
+
+// HanaCloudInstanceInfoParameters are the configurable fields of a HanaCloudInstanceInfo.
+type HanaCloudInstanceInfoParameters struct {
+	// ServiceInstanceID is the GUID of the HANA Cloud service instance
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="serviceInstanceID is immutable"
+	ServiceInstanceID string `json:"serviceInstanceID"`
+
+	// AdminCredentialsSecretRef references a Secret containing admin API credentials
+	// +kubebuilder:validation:Required
+	AdminCredentialsSecretRef AdminCredentialsSecretRef `json:"adminCredentialsSecretRef"`
+
+	// Proxy routes the requests to the HANA Cloud Admin API through a proxy
+	// +kubebuilder:validation:Optional
+	Proxy *apisv1alpha1.ProxyConfig `json:"proxy,omitempty"`
+}
+
+// InstanceEndpoint is an endpoint clients connect to a HANA Cloud instance
+// through.
+type InstanceEndpoint struct {
+	// Type of the endpoint, e.g. database
+	Type string `json:"type,omitempty"`
+
+	// Host name of the endpoint
+	Host string `json:"host,omitempty"`
+
+	// Port of the endpoint
+	Port int32 `json:"port,omitempty"`
+}
+
+// HanaCloudInstanceInfoObservation are the observable fields of a HanaCloudInstanceInfo.
+type HanaCloudInstanceInfoObservation struct {
+	// Status of the instance, e.g. RUNNING or STOPPED
+	Status string `json:"status,omitempty"`
+
+	// Version of HANA the instance runs
+	Version string `json:"version,omitempty"`
+
+	// MemoryGB is the memory of the instance in GB
+	MemoryGB *int32 `json:"memoryGB,omitempty"`
+
+	// VCPU is the number of virtual CPUs of the instance
+	VCPU *int32 `json:"vcpu,omitempty"`
+
+	// StorageGB is the storage of the instance in GB
+	StorageGB *int32 `json:"storageGB,omitempty"`
+
+	// Endpoints of the instance
+	Endpoints []InstanceEndpoint `json:"endpoints,omitempty"`
+}
+
+// HanaCloudInstanceInfoSpec defines the desired state of a HanaCloudInstanceInfo.
+type HanaCloudInstanceInfoSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       HanaCloudInstanceInfoParameters `json:"forProvider"`
+}
+
+// HanaCloudInstanceInfoStatus represents the observed state of a HanaCloudInstanceInfo.
+type HanaCloudInstanceInfoStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          HanaCloudInstanceInfoObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// HanaCloudInstanceInfo reports the state of a HANA Cloud instance from the
+// HANA Cloud Admin API. It only observes the instance: creating or deleting
+// the resource leaves the instance as it is.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="INSTANCE-ID",type="string",JSONPath=".spec.forProvider.serviceInstanceID"
+// +kubebuilder:printcolumn:name="STATUS",type="string",JSONPath=".status.atProvider.status"
+// +kubebuilder:printcolumn:name="VERSION",type="string",JSONPath=".status.atProvider.version"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:path=hanacloudinstanceinfos,scope=Cluster,categories={crossplane,managed,inventory}
+type HanaCloudInstanceInfo struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   HanaCloudInstanceInfoSpec   `json:"spec"`
+	Status HanaCloudInstanceInfoStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// HanaCloudInstanceInfoList contains a list of HanaCloudInstanceInfo
+type HanaCloudInstanceInfoList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []HanaCloudInstanceInfo `json:"items"`
+}
+
+// HanaCloudInstanceInfo type metadata.
+var (
+	HanaCloudInstanceInfoKind             = reflect.TypeOf(HanaCloudInstanceInfo{}).Name()
+	HanaCloudInstanceInfoGroupKind        = schema.GroupKind{Group: Group, Kind: HanaCloudInstanceInfoKind}.String()
+	HanaCloudInstanceInfoKindAPIVersion   = HanaCloudInstanceInfoKind + "." + SchemeGroupVersion.String()
+	HanaCloudInstanceInfoGroupVersionKind = SchemeGroupVersion.WithKind(HanaCloudInstanceInfoKind)
+)
+
+func init() {
+	SchemeBuilder.Register(
+		&HanaCloudInstanceInfo{},
+		&HanaCloudInstanceInfoList{},
+	)
+}
@@ -0,0 +1,133 @@
+/*
+Copyright 2026 SAP SE.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	apisv1alpha1 "github.com/SAP/crossplane-provider-hana/apis/v1alpha1"
+)
+
+// ConfigurationParameter is an ini-like configuration parameter of a HANA
+// Cloud instance, e.g. file "indexserver.ini", section "json", key "enabled".
+type ConfigurationParameter struct {
+	// File is the configuration file the parameter belongs to
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	File string `json:"file"`
+
+	// Section is the section of the configuration file
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Section string `json:"section"`
+
+	// Key is the name of the parameter
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Key string `json:"key"`
+
+	// Value is the desired value of the parameter
+	// +kubebuilder:validation:Required
+	Value string `json:"value"`
+}
+
+// InstanceConfigurationParameters are the configurable fields of an InstanceConfiguration.
+type InstanceConfigurationParameters struct {
+	// ServiceInstanceID is the GUID of the HANA Cloud service instance
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="serviceInstanceID is immutable"
+	ServiceInstanceID string `json:"serviceInstanceID"`
+
+	// Parameters are the configuration parameters to manage. Parameters that
+	// are not listed keep their current value.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	// +listType=map
+	// +listMapKey=file
+	// +listMapKey=section
+	// +listMapKey=key
+	Parameters []ConfigurationParameter `json:"parameters"`
+
+	// AdminCredentialsSecretRef references a Secret containing admin API credentials
+	// +kubebuilder:validation:Required
+	AdminCredentialsSecretRef AdminCredentialsSecretRef `json:"adminCredentialsSecretRef"`
+
+	// Proxy routes the requests to the HANA Cloud Admin API through a proxy
+	// +kubebuilder:validation:Optional
+	Proxy *apisv1alpha1.ProxyConfig `json:"proxy,omitempty"`
+}
+
+// InstanceConfigurationObservation are the observable fields of an InstanceConfiguration.
+type InstanceConfigurationObservation struct {
+	// Parameters are the current values of the managed parameters. Parameters
+	// that are not set on the instance are omitted.
+	// +kubebuilder:validation:Optional
+	Parameters []ConfigurationParameter `json:"parameters,omitempty"`
+
+	// Operation is the last asynchronous operation applying the parameters
+	// +kubebuilder:validation:Optional
+	Operation OperationObservation `json:"operation,omitempty"`
+}
+
+// InstanceConfigurationSpec defines the desired state of an InstanceConfiguration.
+type InstanceConfigurationSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       InstanceConfigurationParameters `json:"forProvider"`
+}
+
+// InstanceConfigurationStatus represents the observed state of an InstanceConfiguration.
+type InstanceConfigurationStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          InstanceConfigurationObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// InstanceConfiguration manages configuration parameters of a HANA Cloud
+// instance through the HANA Cloud Admin API. Changes are applied
+// asynchronously; deleting the resource leaves the parameters as they are.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="INSTANCE-ID",type="string",JSONPath=".spec.forProvider.serviceInstanceID"
+// +kubebuilder:printcolumn:name="OPERATION",type="string",JSONPath=".status.atProvider.operation.state"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,inventory,hana},shortName={hanaconfig}
+type InstanceConfiguration struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   InstanceConfigurationSpec   `json:"spec"`
+	Status InstanceConfigurationStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// InstanceConfigurationList contains a list of InstanceConfiguration
+type InstanceConfigurationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []InstanceConfiguration `json:"items"`
+}
+
+// InstanceConfiguration type metadata.
+var (
+	InstanceConfigurationKind             = reflect.TypeOf(InstanceConfiguration{}).Name()
+	InstanceConfigurationGroupKind        = schema.GroupKind{Group: Group, Kind: InstanceConfigurationKind}.String()
+	InstanceConfigurationKindAPIVersion   = InstanceConfigurationKind + "." + SchemeGroupVersion.String()
+	InstanceConfigurationGroupVersionKind = SchemeGroupVersion.WithKind(InstanceConfigurationKind)
+)
+
+func init() {
+	SchemeBuilder.Register(
+		&InstanceConfiguration{},
+		&InstanceConfigurationList{},
+	)
+}
@@ -11,6 +11,8 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	apisv1alpha1 "github.com/SAP/crossplane-provider-hana/apis/v1alpha1"
 )
 
 // AdminCredentialsSecretRef references a Secret containing admin API credentials
@@ -29,7 +31,32 @@ type AdminCredentialsSecretRef struct {
 	Key string `json:"key"`
 }
 
+// OperationObservation tracks an asynchronous operation of the HANA Cloud
+// Admin API across reconciles.
+type OperationObservation struct {
+	// ID is the ID of the last operation
+	// +kubebuilder:validation:Optional
+	ID string `json:"id,omitempty"`
+
+	// State is the state of the last operation: IN_PROGRESS, SUCCEEDED,
+	// FAILED, or TIMED_OUT if it did not finish in time
+	// +kubebuilder:validation:Optional
+	State string `json:"state,omitempty"`
+
+	// Message is the message the Admin API returned for the last operation
+	// +kubebuilder:validation:Optional
+	Message string `json:"message,omitempty"`
+
+	// StartTime is the time the last operation was accepted
+	// +kubebuilder:validation:Optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+}
+
 // InstanceMappingParameters are the configurable fields of an InstanceMapping.
+// +kubebuilder:validation:XValidation:rule="self.platform != 'cloudfoundry' || self.primaryID.matches('^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$')",message="primaryID must be the org GUID for platform cloudfoundry"
+// +kubebuilder:validation:XValidation:rule="self.platform != 'cloudfoundry' || !has(self.secondaryID) || self.secondaryID.matches('^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$')",message="secondaryID must be the space GUID for platform cloudfoundry"
+// +kubebuilder:validation:XValidation:rule="self.platform != 'kubernetes' || !has(self.secondaryID) || self.secondaryID.matches('^[a-z0-9]([-a-z0-9]{0,61}[a-z0-9])?$')",message="secondaryID must be a namespace name for platform kubernetes"
+// +kubebuilder:validation:XValidation:rule="self.platform != 'subaccount-api-access' || !has(self.secondaryID)",message="secondaryID must not be set for platform subaccount-api-access"
 type InstanceMappingParameters struct {
 	// ServiceInstanceID is the GUID of the HANA Cloud service instance
 	// +kubebuilder:validation:Required
@@ -46,7 +73,8 @@ type InstanceMappingParameters struct {
 	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="primaryID is immutable"
 	PrimaryID string `json:"primaryID"`
 
-	// SecondaryID is the namespace (for kubernetes) or space GUID (for cloudfoundry)
+	// SecondaryID is the namespace (for kubernetes) or space GUID (for cloudfoundry).
+	// Omit it to map the whole cluster or org.
 	// +kubebuilder:validation:Optional
 	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="secondaryID is immutable"
 	SecondaryID *string `json:"secondaryID,omitempty"`
@@ -59,10 +87,20 @@ type InstanceMappingParameters struct {
 	// AdminCredentialsSecretRef references a Secret containing admin API credentials
 	// +kubebuilder:validation:Required
 	AdminCredentialsSecretRef AdminCredentialsSecretRef `json:"adminCredentialsSecretRef"`
+
+	// Proxy routes the requests to the HANA Cloud Admin API through a proxy
+	// +kubebuilder:validation:Optional
+	Proxy *apisv1alpha1.ProxyConfig `json:"proxy,omitempty"`
 }
 
 // InstanceMappingObservation are the observable fields of an InstanceMapping.
 type InstanceMappingObservation struct {
+	// ExternalID is the stable identifier of the mapping, formatted as
+	// <serviceInstanceID>/<primaryID>[/<secondaryID>]. It is also published as
+	// the externalID connection detail.
+	// +kubebuilder:validation:Optional
+	ExternalID string `json:"externalID,omitempty"`
+
 	// MappingExists indicates if the mapping exists in HANA Cloud
 	// +kubebuilder:validation:Optional
 	MappingExists bool `json:"mappingExists,omitempty"`
@@ -70,6 +108,11 @@ type InstanceMappingObservation struct {
 	// LastSyncTime is the timestamp of the last successful sync
 	// +kubebuilder:validation:Optional
 	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+
+	// Operation is the last asynchronous operation creating or deleting the
+	// mapping
+	// +kubebuilder:validation:Optional
+	Operation OperationObservation `json:"operation,omitempty"`
 }
 
 // InstanceMappingSpec defines the desired state of an InstanceMapping.
@@ -98,7 +141,7 @@ type InstanceMappingStatus struct {
 // +kubebuilder:printcolumn:name="SECONDARY-ID",type="string",JSONPath=".spec.forProvider.secondaryID"
 // +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
 // +kubebuilder:subresource:status
-// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,inventory}
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,inventory,hana},shortName={hanamapping}
 type InstanceMapping struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
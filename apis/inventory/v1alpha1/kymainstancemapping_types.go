@@ -13,6 +13,13 @@ import (
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 )
 
+// Checks of the target namespaces of a KymaInstanceMapping.
+const (
+	TargetNamespaceCheckNone   = "None"
+	TargetNamespaceCheckVerify = "Verify"
+	TargetNamespaceCheckCreate = "Create"
+)
+
 // SecretReference references a Secret in a specific namespace
 type SecretReference struct {
 	// Name is the name of the Secret
@@ -41,15 +48,24 @@ type KymaConnectionReference struct {
 }
 
 // KymaInstanceMappingParameters are the configurable fields of a KymaInstanceMapping.
+// +kubebuilder:validation:XValidation:rule="!has(self.targetNamespace) || !has(self.targetNamespaces)",message="targetNamespace and targetNamespaces are mutually exclusive"
+// +kubebuilder:validation:XValidation:rule="has(self.adminBindingRef) != has(self.adminCredentialsSecretRef)",message="exactly one of adminBindingRef and adminCredentialsSecretRef must be set"
 type KymaInstanceMappingParameters struct {
 	// KymaConnectionRef references the kubeconfig secret for connecting to a remote Kyma cluster.
 	// If not specified, the controller uses the local cluster where it's running.
 	// +kubebuilder:validation:Optional
 	KymaConnectionRef *KymaConnectionReference `json:"kymaConnectionRef,omitempty"`
 
-	// AdminBindingRef references the ServiceBinding that provides admin API credentials
-	// +kubebuilder:validation:Required
-	AdminBindingRef ResourceReference `json:"adminBindingRef"`
+	// AdminBindingRef references the ServiceBinding on the Kyma cluster that
+	// provides admin API credentials
+	// +kubebuilder:validation:Optional
+	AdminBindingRef *ResourceReference `json:"adminBindingRef,omitempty"`
+
+	// AdminCredentialsSecretRef references a Secret on the management cluster
+	// containing the admin API credentials, for landscapes that do not expose
+	// ServiceBindings on the Kyma cluster
+	// +kubebuilder:validation:Optional
+	AdminCredentialsSecretRef *AdminCredentialsSecretRef `json:"adminCredentialsSecretRef,omitempty"`
 
 	// ServiceInstanceRef references the ServiceInstance (to extract instanceID)
 	// +kubebuilder:validation:Required
@@ -61,6 +77,23 @@ type KymaInstanceMappingParameters struct {
 	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="targetNamespace is immutable"
 	TargetNamespace *string `json:"targetNamespace,omitempty"`
 
+	// TargetNamespaces are Kubernetes namespaces to map, with one child
+	// InstanceMapping per namespace. Unlike TargetNamespace, namespaces can
+	// be added and removed.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:MinItems=1
+	// +listType=set
+	TargetNamespaces []string `json:"targetNamespaces,omitempty"`
+
+	// TargetNamespaceCheck selects whether the target namespaces are checked
+	// on the Kyma cluster before they are mapped. Verify fails the mapping
+	// while a target namespace does not exist, Create creates missing target
+	// namespaces. Defaults to None, which maps them unchecked.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=None;Verify;Create
+	// +kubebuilder:default:=None
+	TargetNamespaceCheck string `json:"targetNamespaceCheck,omitempty"`
+
 	// ClusterIDConfigMapRef references the ConfigMap containing CLUSTER_ID
 	// Defaults to kyma-system/sap-btp-operator-config if not specified
 	// +kubebuilder:validation:Optional
@@ -72,9 +105,11 @@ type KymaInstanceMappingParameters struct {
 	IsDefault bool `json:"isDefault,omitempty"`
 
 	// CredentialsSecretNamespace is the namespace where the intermediate credentials
-	// Secret and InstanceMapping CR will be created.
+	// Secret will be created. Defaults to crossplane-system, or to the namespace
+	// of a namespaced KymaInstanceMapping, which may not reference Secrets in
+	// other namespaces. Child InstanceMappings share the namespace of the
+	// KymaInstanceMapping.
 	// +kubebuilder:validation:Optional
-	// +kubebuilder:default="crossplane-system"
 	CredentialsSecretNamespace string `json:"credentialsSecretNamespace,omitempty"`
 }
 
@@ -121,7 +156,8 @@ type HANACloudObservation struct {
 
 // ChildResourcesReference contains references to child resources created by KymaInstanceMapping
 type ChildResourcesReference struct {
-	// InstanceMappingName is the name of the created InstanceMapping CR
+	// InstanceMappingName is the name of the created InstanceMapping CR. It
+	// is empty with TargetNamespaces, see Mappings instead.
 	// +kubebuilder:validation:Optional
 	InstanceMappingName string `json:"instanceMappingName,omitempty"`
 
@@ -133,17 +169,47 @@ type ChildResourcesReference struct {
 	// +kubebuilder:validation:Optional
 	CredentialsSecretNamespace string `json:"credentialsSecretNamespace,omitempty"`
 
-	// InstanceMappingReady indicates if the child InstanceMapping is ready
+	// InstanceMappingReady indicates if the child InstanceMappings are ready
 	// +kubebuilder:validation:Optional
 	InstanceMappingReady bool `json:"instanceMappingReady,omitempty"`
 
-	// InstanceMappingSynced indicates if the child InstanceMapping is synced
+	// InstanceMappingSynced indicates if the child InstanceMappings are synced
 	// +kubebuilder:validation:Optional
 	InstanceMappingSynced bool `json:"instanceMappingSynced,omitempty"`
 }
 
+// TargetMappingObservation is the status of the child InstanceMapping of one
+// of the TargetNamespaces.
+type TargetMappingObservation struct {
+	// TargetNamespace is the mapped Kubernetes namespace
+	TargetNamespace string `json:"targetNamespace"`
+
+	// InstanceMappingName is the name of the child InstanceMapping CR
+	InstanceMappingName string `json:"instanceMappingName"`
+
+	// ExternalID is the stable identifier of the mapping of the namespace, as
+	// reported by the child InstanceMapping
+	// +kubebuilder:validation:Optional
+	ExternalID string `json:"externalID,omitempty"`
+
+	// Ready indicates if the child InstanceMapping is ready
+	// +kubebuilder:validation:Optional
+	Ready bool `json:"ready,omitempty"`
+
+	// Synced indicates if the child InstanceMapping is synced
+	// +kubebuilder:validation:Optional
+	Synced bool `json:"synced,omitempty"`
+}
+
 // KymaInstanceMappingObservation are the observable fields of a KymaInstanceMapping.
 type KymaInstanceMappingObservation struct {
+	// ExternalID is the stable identifier of the mapping, formatted as
+	// <serviceInstanceID>/<primaryID>[/<secondaryID>]. It is also published as
+	// the externalID connection detail. It is empty with TargetNamespaces, see
+	// Mappings instead.
+	// +kubebuilder:validation:Optional
+	ExternalID string `json:"externalID,omitempty"`
+
 	// Kyma contains information extracted from the remote Kyma cluster
 	// +kubebuilder:validation:Optional
 	Kyma *KymaClusterObservation `json:"kyma,omitempty"`
@@ -155,6 +221,11 @@ type KymaInstanceMappingObservation struct {
 	// ChildResources contains references to the created child resources (Secret and InstanceMapping)
 	// +kubebuilder:validation:Optional
 	ChildResources *ChildResourcesReference `json:"childResources,omitempty"`
+
+	// Mappings contains the status of the child InstanceMapping of each of the
+	// TargetNamespaces
+	// +kubebuilder:validation:Optional
+	Mappings []TargetMappingObservation `json:"mappings,omitempty"`
 }
 
 // A KymaInstanceMappingSpec defines the desired state of a KymaInstanceMapping.
@@ -181,7 +252,7 @@ type KymaInstanceMappingStatus struct {
 // +kubebuilder:printcolumn:name="NAMESPACE",type="string",JSONPath=".spec.forProvider.targetNamespace"
 // +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
 // +kubebuilder:subresource:status
-// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,inventory}
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,inventory,hana},shortName={kymamapping}
 type KymaInstanceMapping struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
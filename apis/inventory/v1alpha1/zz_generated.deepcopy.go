@@ -9,6 +9,7 @@ Copyright 2026 SAP SE or an SAP affiliate company and contributors.
 package v1alpha1
 
 import (
+	apisv1alpha1 "github.com/SAP/crossplane-provider-hana/apis/v1alpha1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -27,6 +28,183 @@ func (in *AdminCredentialsSecretRef) DeepCopy() *AdminCredentialsSecretRef {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupConfiguration) DeepCopyInto(out *BackupConfiguration) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupConfiguration.
+func (in *BackupConfiguration) DeepCopy() *BackupConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BackupConfiguration) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupConfigurationList) DeepCopyInto(out *BackupConfigurationList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]BackupConfiguration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupConfigurationList.
+func (in *BackupConfigurationList) DeepCopy() *BackupConfigurationList {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupConfigurationList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BackupConfigurationList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupConfigurationObservation) DeepCopyInto(out *BackupConfigurationObservation) {
+	*out = *in
+	in.BackupSettings.DeepCopyInto(&out.BackupSettings)
+	in.Operation.DeepCopyInto(&out.Operation)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupConfigurationObservation.
+func (in *BackupConfigurationObservation) DeepCopy() *BackupConfigurationObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupConfigurationObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupConfigurationParameters) DeepCopyInto(out *BackupConfigurationParameters) {
+	*out = *in
+	in.BackupSettings.DeepCopyInto(&out.BackupSettings)
+	out.AdminCredentialsSecretRef = in.AdminCredentialsSecretRef
+	if in.Proxy != nil {
+		in, out := &in.Proxy, &out.Proxy
+		*out = new(apisv1alpha1.ProxyConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupConfigurationParameters.
+func (in *BackupConfigurationParameters) DeepCopy() *BackupConfigurationParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupConfigurationParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupConfigurationSpec) DeepCopyInto(out *BackupConfigurationSpec) {
+	*out = *in
+	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupConfigurationSpec.
+func (in *BackupConfigurationSpec) DeepCopy() *BackupConfigurationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupConfigurationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupConfigurationStatus) DeepCopyInto(out *BackupConfigurationStatus) {
+	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+	in.AtProvider.DeepCopyInto(&out.AtProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupConfigurationStatus.
+func (in *BackupConfigurationStatus) DeepCopy() *BackupConfigurationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupConfigurationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupSchedule) DeepCopyInto(out *BackupSchedule) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupSchedule.
+func (in *BackupSchedule) DeepCopy() *BackupSchedule {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupSchedule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackupSettings) DeepCopyInto(out *BackupSettings) {
+	*out = *in
+	if in.RetentionDays != nil {
+		in, out := &in.RetentionDays, &out.RetentionDays
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Schedule != nil {
+		in, out := &in.Schedule, &out.Schedule
+		*out = new(BackupSchedule)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackupSettings.
+func (in *BackupSettings) DeepCopy() *BackupSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(BackupSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ChildResourcesReference) DeepCopyInto(out *ChildResourcesReference) {
 	*out = *in
@@ -42,6 +220,21 @@ func (in *ChildResourcesReference) DeepCopy() *ChildResourcesReference {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigurationParameter) DeepCopyInto(out *ConfigurationParameter) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigurationParameter.
+func (in *ConfigurationParameter) DeepCopy() *ConfigurationParameter {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigurationParameter)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *HANACloudObservation) DeepCopyInto(out *HANACloudObservation) {
 	*out = *in
@@ -62,6 +255,310 @@ func (in *HANACloudObservation) DeepCopy() *HANACloudObservation {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HanaCloudInstanceInfo) DeepCopyInto(out *HanaCloudInstanceInfo) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HanaCloudInstanceInfo.
+func (in *HanaCloudInstanceInfo) DeepCopy() *HanaCloudInstanceInfo {
+	if in == nil {
+		return nil
+	}
+	out := new(HanaCloudInstanceInfo)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *HanaCloudInstanceInfo) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HanaCloudInstanceInfoList) DeepCopyInto(out *HanaCloudInstanceInfoList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]HanaCloudInstanceInfo, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HanaCloudInstanceInfoList.
+func (in *HanaCloudInstanceInfoList) DeepCopy() *HanaCloudInstanceInfoList {
+	if in == nil {
+		return nil
+	}
+	out := new(HanaCloudInstanceInfoList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *HanaCloudInstanceInfoList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HanaCloudInstanceInfoObservation) DeepCopyInto(out *HanaCloudInstanceInfoObservation) {
+	*out = *in
+	if in.MemoryGB != nil {
+		in, out := &in.MemoryGB, &out.MemoryGB
+		*out = new(int32)
+		**out = **in
+	}
+	if in.VCPU != nil {
+		in, out := &in.VCPU, &out.VCPU
+		*out = new(int32)
+		**out = **in
+	}
+	if in.StorageGB != nil {
+		in, out := &in.StorageGB, &out.StorageGB
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Endpoints != nil {
+		in, out := &in.Endpoints, &out.Endpoints
+		*out = make([]InstanceEndpoint, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HanaCloudInstanceInfoObservation.
+func (in *HanaCloudInstanceInfoObservation) DeepCopy() *HanaCloudInstanceInfoObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(HanaCloudInstanceInfoObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HanaCloudInstanceInfoParameters) DeepCopyInto(out *HanaCloudInstanceInfoParameters) {
+	*out = *in
+	out.AdminCredentialsSecretRef = in.AdminCredentialsSecretRef
+	if in.Proxy != nil {
+		in, out := &in.Proxy, &out.Proxy
+		*out = new(apisv1alpha1.ProxyConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HanaCloudInstanceInfoParameters.
+func (in *HanaCloudInstanceInfoParameters) DeepCopy() *HanaCloudInstanceInfoParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(HanaCloudInstanceInfoParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HanaCloudInstanceInfoSpec) DeepCopyInto(out *HanaCloudInstanceInfoSpec) {
+	*out = *in
+	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HanaCloudInstanceInfoSpec.
+func (in *HanaCloudInstanceInfoSpec) DeepCopy() *HanaCloudInstanceInfoSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(HanaCloudInstanceInfoSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HanaCloudInstanceInfoStatus) DeepCopyInto(out *HanaCloudInstanceInfoStatus) {
+	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+	in.AtProvider.DeepCopyInto(&out.AtProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HanaCloudInstanceInfoStatus.
+func (in *HanaCloudInstanceInfoStatus) DeepCopy() *HanaCloudInstanceInfoStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(HanaCloudInstanceInfoStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InstanceConfiguration) DeepCopyInto(out *InstanceConfiguration) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstanceConfiguration.
+func (in *InstanceConfiguration) DeepCopy() *InstanceConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(InstanceConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *InstanceConfiguration) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InstanceConfigurationList) DeepCopyInto(out *InstanceConfigurationList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]InstanceConfiguration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstanceConfigurationList.
+func (in *InstanceConfigurationList) DeepCopy() *InstanceConfigurationList {
+	if in == nil {
+		return nil
+	}
+	out := new(InstanceConfigurationList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *InstanceConfigurationList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InstanceConfigurationObservation) DeepCopyInto(out *InstanceConfigurationObservation) {
+	*out = *in
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = make([]ConfigurationParameter, len(*in))
+		copy(*out, *in)
+	}
+	in.Operation.DeepCopyInto(&out.Operation)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstanceConfigurationObservation.
+func (in *InstanceConfigurationObservation) DeepCopy() *InstanceConfigurationObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(InstanceConfigurationObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InstanceConfigurationParameters) DeepCopyInto(out *InstanceConfigurationParameters) {
+	*out = *in
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = make([]ConfigurationParameter, len(*in))
+		copy(*out, *in)
+	}
+	out.AdminCredentialsSecretRef = in.AdminCredentialsSecretRef
+	if in.Proxy != nil {
+		in, out := &in.Proxy, &out.Proxy
+		*out = new(apisv1alpha1.ProxyConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstanceConfigurationParameters.
+func (in *InstanceConfigurationParameters) DeepCopy() *InstanceConfigurationParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(InstanceConfigurationParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InstanceConfigurationSpec) DeepCopyInto(out *InstanceConfigurationSpec) {
+	*out = *in
+	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstanceConfigurationSpec.
+func (in *InstanceConfigurationSpec) DeepCopy() *InstanceConfigurationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(InstanceConfigurationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InstanceConfigurationStatus) DeepCopyInto(out *InstanceConfigurationStatus) {
+	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+	in.AtProvider.DeepCopyInto(&out.AtProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstanceConfigurationStatus.
+func (in *InstanceConfigurationStatus) DeepCopy() *InstanceConfigurationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(InstanceConfigurationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InstanceEndpoint) DeepCopyInto(out *InstanceEndpoint) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstanceEndpoint.
+func (in *InstanceEndpoint) DeepCopy() *InstanceEndpoint {
+	if in == nil {
+		return nil
+	}
+	out := new(InstanceEndpoint)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *InstanceMapping) DeepCopyInto(out *InstanceMapping) {
 	*out = *in
@@ -128,6 +625,7 @@ func (in *InstanceMappingObservation) DeepCopyInto(out *InstanceMappingObservati
 		in, out := &in.LastSyncTime, &out.LastSyncTime
 		*out = (*in).DeepCopy()
 	}
+	in.Operation.DeepCopyInto(&out.Operation)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstanceMappingObservation.
@@ -149,6 +647,11 @@ func (in *InstanceMappingParameters) DeepCopyInto(out *InstanceMappingParameters
 		**out = **in
 	}
 	out.AdminCredentialsSecretRef = in.AdminCredentialsSecretRef
+	if in.Proxy != nil {
+		in, out := &in.Proxy, &out.Proxy
+		*out = new(apisv1alpha1.ProxyConfig)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InstanceMappingParameters.
@@ -303,6 +806,11 @@ func (in *KymaInstanceMappingObservation) DeepCopyInto(out *KymaInstanceMappingO
 		*out = new(ChildResourcesReference)
 		**out = **in
 	}
+	if in.Mappings != nil {
+		in, out := &in.Mappings, &out.Mappings
+		*out = make([]TargetMappingObservation, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KymaInstanceMappingObservation.
@@ -323,13 +831,27 @@ func (in *KymaInstanceMappingParameters) DeepCopyInto(out *KymaInstanceMappingPa
 		*out = new(KymaConnectionReference)
 		**out = **in
 	}
-	out.AdminBindingRef = in.AdminBindingRef
+	if in.AdminBindingRef != nil {
+		in, out := &in.AdminBindingRef, &out.AdminBindingRef
+		*out = new(ResourceReference)
+		**out = **in
+	}
+	if in.AdminCredentialsSecretRef != nil {
+		in, out := &in.AdminCredentialsSecretRef, &out.AdminCredentialsSecretRef
+		*out = new(AdminCredentialsSecretRef)
+		**out = **in
+	}
 	out.ServiceInstanceRef = in.ServiceInstanceRef
 	if in.TargetNamespace != nil {
 		in, out := &in.TargetNamespace, &out.TargetNamespace
 		*out = new(string)
 		**out = **in
 	}
+	if in.TargetNamespaces != nil {
+		in, out := &in.TargetNamespaces, &out.TargetNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.ClusterIDConfigMapRef != nil {
 		in, out := &in.ClusterIDConfigMapRef, &out.ClusterIDConfigMapRef
 		*out = new(ResourceReference)
@@ -401,6 +923,25 @@ func (in *MappingID) DeepCopy() *MappingID {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OperationObservation) DeepCopyInto(out *OperationObservation) {
+	*out = *in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperationObservation.
+func (in *OperationObservation) DeepCopy() *OperationObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(OperationObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ResourceReference) DeepCopyInto(out *ResourceReference) {
 	*out = *in
@@ -430,3 +971,18 @@ func (in *SecretReference) DeepCopy() *SecretReference {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TargetMappingObservation) DeepCopyInto(out *TargetMappingObservation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TargetMappingObservation.
+func (in *TargetMappingObservation) DeepCopy() *TargetMappingObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(TargetMappingObservation)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2026 SAP SE or an SAP affiliate company and contributors.
+*/
+
+package v1alpha1
+
+import (
+	"reflect"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	apisv1alpha1 "github.com/SAP/crossplane-provider-hana/apis/v1alpha1"
+)
+
+// CollectionParameters are the configurable fields of a Collection.
+// +kubebuilder:validation:XValidation:rule="has(self.schema) || has(self.schemaRef)",message="Either schema or schemaRef must be set"
+type CollectionParameters struct {
+	// CollectionName is the name of the JSON collection in the document
+	// store.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Value is immutable"
+	CollectionName string `json:"collectionName"`
+
+	// Schema the collection is created in.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Value is immutable"
+	Schema string `json:"schema,omitempty"`
+
+	// SchemaRef references the DbSchema the collection is created in, if
+	// schema is not set.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="Value is immutable"
+	SchemaRef *xpv1.Reference `json:"schemaRef,omitempty"`
+}
+
+// CollectionObservation are the observable fields of a Collection.
+type CollectionObservation struct {
+	// ExternalID is the stable identifier of the collection, its schema and
+	// name. It is also published as the externalID connection detail.
+	// +kubebuilder:validation:Optional
+	ExternalID string `json:"externalID,omitempty"`
+
+	// +kubebuilder:validation:Optional
+	CollectionName string `json:"collectionName,omitempty"`
+	// +kubebuilder:validation:Optional
+	Schema string `json:"schema,omitempty"`
+}
+
+// A CollectionSpec defines the desired state of a Collection.
+type CollectionSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       CollectionParameters `json:"forProvider"`
+}
+
+// A CollectionStatus represents the observed state of a Collection.
+type CollectionStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          CollectionObservation `json:"atProvider,omitempty"`
+
+	// SQLStatistics counts the SQL the last reconcile ran against HANA.
+	// +kubebuilder:validation:Optional
+	SQLStatistics *apisv1alpha1.SQLStatistics `json:"sqlStatistics,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A Collection is a JSON collection of the document store of HANA Cloud. It
+// is only reconciled if the document store is enabled on the database.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="SCHEMA",type="string",JSONPath=".status.atProvider.schema"
+// +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,hana},shortName={hanacollection}
+type Collection struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   CollectionSpec   `json:"spec"`
+	Status CollectionStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// CollectionList contains a list of Collection
+type CollectionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Collection `json:"items"`
+}
+
+// Collection type metadata.
+var (
+	CollectionKind             = reflect.TypeFor[Collection]().Name()
+	CollectionGroupKind        = schema.GroupKind{Group: Group, Kind: CollectionKind}.String()
+	CollectionKindAPIVersion   = CollectionKind + "." + SchemeGroupVersion.String()
+	CollectionGroupVersionKind = SchemeGroupVersion.WithKind(CollectionKind)
+)
+
+func init() {
+	SchemeBuilder.Register(
+		&Collection{},
+		&CollectionList{},
+	)
+}
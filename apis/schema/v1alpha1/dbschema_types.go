@@ -11,6 +11,8 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	apisv1alpha1 "github.com/SAP/crossplane-provider-hana/apis/v1alpha1"
 )
 
 // DbSchemaParameters are the configurable fields of a Dbschema.
@@ -25,6 +27,11 @@ type DbSchemaParameters struct {
 
 // DbschemaObservation are the observable fields of a Dbschema.
 type DbSchemaObservation struct {
+	// ExternalID is the stable identifier of the schema, its name. It is also
+	// published as the externalID connection detail.
+	// +kubebuilder:validation:Optional
+	ExternalID string `json:"externalID,omitempty"`
+
 	// +kubebuilder:validation:Optional
 	SchemaName string `json:"schemaName,omitempty"`
 	Owner      string `json:"owner,omitempty"`
@@ -40,6 +47,10 @@ type DbSchemaSpec struct {
 type DbSchemaStatus struct {
 	xpv1.ResourceStatus `json:",inline"`
 	AtProvider          DbSchemaObservation `json:"atProvider,omitempty"`
+
+	// SQLStatistics counts the SQL the last reconcile ran against HANA.
+	// +kubebuilder:validation:Optional
+	SQLStatistics *apisv1alpha1.SQLStatistics `json:"sqlStatistics,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -50,7 +61,7 @@ type DbSchemaStatus struct {
 // +kubebuilder:printcolumn:name="EXTERNAL-NAME",type="string",JSONPath=".metadata.annotations.crossplane\\.io/external-name"
 // +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
 // +kubebuilder:subresource:status
-// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,hana}
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,hana},shortName={hanaschema}
 type DbSchema struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
@@ -0,0 +1,29 @@
+/*
+Copyright 2026 SAP SE or an SAP affiliate company and contributors.
+*/
+
+package v1alpha1
+
+import (
+	apisv1alpha1 "github.com/SAP/crossplane-provider-hana/apis/v1alpha1"
+)
+
+// GetSQLStatistics of this DbSchema.
+func (mg *DbSchema) GetSQLStatistics() *apisv1alpha1.SQLStatistics {
+	return mg.Status.SQLStatistics
+}
+
+// SetSQLStatistics of this DbSchema.
+func (mg *DbSchema) SetSQLStatistics(s *apisv1alpha1.SQLStatistics) {
+	mg.Status.SQLStatistics = s
+}
+
+// GetSQLStatistics of this Collection.
+func (mg *Collection) GetSQLStatistics() *apisv1alpha1.SQLStatistics {
+	return mg.Status.SQLStatistics
+}
+
+// SetSQLStatistics of this Collection.
+func (mg *Collection) SetSQLStatistics(s *apisv1alpha1.SQLStatistics) {
+	mg.Status.SQLStatistics = s
+}
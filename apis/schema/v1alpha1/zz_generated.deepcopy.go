@@ -9,9 +9,144 @@ Copyright 2026 SAP SE or an SAP affiliate company and contributors.
 package v1alpha1
 
 import (
+	apisv1alpha1 "github.com/SAP/crossplane-provider-hana/apis/v1alpha1"
+	"github.com/crossplane/crossplane-runtime/apis/common/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Collection) DeepCopyInto(out *Collection) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Collection.
+func (in *Collection) DeepCopy() *Collection {
+	if in == nil {
+		return nil
+	}
+	out := new(Collection)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Collection) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CollectionList) DeepCopyInto(out *CollectionList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Collection, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CollectionList.
+func (in *CollectionList) DeepCopy() *CollectionList {
+	if in == nil {
+		return nil
+	}
+	out := new(CollectionList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *CollectionList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CollectionObservation) DeepCopyInto(out *CollectionObservation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CollectionObservation.
+func (in *CollectionObservation) DeepCopy() *CollectionObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(CollectionObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CollectionParameters) DeepCopyInto(out *CollectionParameters) {
+	*out = *in
+	if in.SchemaRef != nil {
+		in, out := &in.SchemaRef, &out.SchemaRef
+		*out = new(v1.Reference)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CollectionParameters.
+func (in *CollectionParameters) DeepCopy() *CollectionParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(CollectionParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CollectionSpec) DeepCopyInto(out *CollectionSpec) {
+	*out = *in
+	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CollectionSpec.
+func (in *CollectionSpec) DeepCopy() *CollectionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(CollectionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CollectionStatus) DeepCopyInto(out *CollectionStatus) {
+	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+	out.AtProvider = in.AtProvider
+	if in.SQLStatistics != nil {
+		in, out := &in.SQLStatistics, &out.SQLStatistics
+		*out = new(apisv1alpha1.SQLStatistics)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CollectionStatus.
+func (in *CollectionStatus) DeepCopy() *CollectionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(CollectionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DbSchema) DeepCopyInto(out *DbSchema) {
 	*out = *in
@@ -123,6 +258,11 @@ func (in *DbSchemaStatus) DeepCopyInto(out *DbSchemaStatus) {
 	*out = *in
 	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
 	out.AtProvider = in.AtProvider
+	if in.SQLStatistics != nil {
+		in, out := &in.SQLStatistics, &out.SQLStatistics
+		*out = new(apisv1alpha1.SQLStatistics)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DbSchemaStatus.
@@ -7,6 +7,15 @@ package v1alpha1
 
 import resource "github.com/crossplane/crossplane-runtime/pkg/resource"
 
+// GetItems of this CollectionList.
+func (l *CollectionList) GetItems() []resource.Managed {
+	items := make([]resource.Managed, len(l.Items))
+	for i := range l.Items {
+		items[i] = &l.Items[i]
+	}
+	return items
+}
+
 // GetItems of this DbSchemaList.
 func (l *DbSchemaList) GetItems() []resource.Managed {
 	items := make([]resource.Managed, len(l.Items))
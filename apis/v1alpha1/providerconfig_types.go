@@ -6,7 +6,10 @@ package v1alpha1
 
 import (
 	"reflect"
+	"strings"
+	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
@@ -17,6 +20,456 @@ import (
 type ProviderConfigSpec struct {
 	// Credentials required to authenticate to this provider.
 	Credentials ProviderCredentials `json:"credentials"`
+
+	// TLS configures the TLS connection to the HANA SQL endpoint.
+	// +optional
+	TLS *TLSConfig `json:"tls,omitempty"`
+
+	// FailoverEndpoints are further endpoints of a HANA system with system
+	// replication, as host or host:port. They are tried in order whenever the
+	// endpoint of the connection secret cannot be reached. The port defaults
+	// to the port of the connection secret.
+	// +optional
+	FailoverEndpoints []string `json:"failoverEndpoints,omitempty"`
+
+	// Proxy routes the connection to the HANA SQL endpoint through a proxy,
+	// e.g. the SAP Cloud Connector for on-premise systems.
+	// +optional
+	Proxy *ProxyConfig `json:"proxy,omitempty"`
+
+	// UsergroupOperator switches the provider to usergroup operator mode for
+	// technical users that hold USERGROUP OPERATOR on a single usergroup
+	// instead of USER ADMIN.
+	// +optional
+	UsergroupOperator *UsergroupOperatorConfig `json:"usergroupOperator,omitempty"`
+
+	// CatalogRead declares that the technical user holds CATALOG READ, e.g.
+	// through a role, so the catalog views show the grants of all users.
+	// Otherwise grants the catalog hides, e.g. of users in usergroups created
+	// with NO GRANT TO CREATOR, are reported as unobserved instead of as drift.
+	// +optional
+	CatalogRead bool `json:"catalogRead,omitempty"`
+
+	// DefaultUsergroup is the usergroup of Users that do not set one, e.g. a
+	// dedicated usergroup with its own password policy. Defaults to the
+	// DEFAULT usergroup of HANA.
+	// +optional
+	// +kubebuilder:validation:Pattern:=`^[^",\$\.'\+\-<>|\[\]\{\}\(\)!%*,/:;=\?@\\^~\x60]+$`
+	DefaultUsergroup string `json:"defaultUsergroup,omitempty"`
+
+	// DefaultPrivilege is the privilege on their own schema that Users with
+	// the strict privilege management policy are expected to hold, e.g.
+	// without the grant option. ${USERNAME} is replaced by the username.
+	// Defaults to CREATE ANY ON SCHEMA "${USERNAME}" WITH GRANT OPTION.
+	// +optional
+	// +kubebuilder:validation:XValidation:rule="self.contains('${USERNAME}')",message="defaultPrivilege must contain ${USERNAME}"
+	DefaultPrivilege string `json:"defaultPrivilege,omitempty"`
+
+	// PasswordExpiryWarningDays is how many days before their password
+	// expires Users report the PasswordExpiring condition and a warning
+	// event, so rotation can happen before the user is locked out. Only
+	// applies to users with the password lifetime check enabled. Defaults
+	// to 14.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	PasswordExpiryWarningDays *int32 `json:"passwordExpiryWarningDays,omitempty"`
+
+	// IdentifierCase controls how the names of users, roles, schemas,
+	// collections and usergroups, and the identifiers in privileges and
+	// roles, are compared with the catalog. Upper folds unquoted identifiers
+	// to uppercase, as HANA does in SQL, and keeps identifiers written in
+	// double quotes as they are. Preserve keeps all identifiers as written, including
+	// usernames. By default usernames are folded unless the User is
+	// case-sensitive, and all other identifiers are kept as written.
+	// +optional
+	// +kubebuilder:validation:Enum=Upper;Preserve
+	IdentifierCase string `json:"identifierCase,omitempty"`
+
+	// GrantPolicy restricts the privileges and roles the provider may grant,
+	// regardless of what a managed resource requests.
+	// +optional
+	GrantPolicy *GrantPolicy `json:"grantPolicy,omitempty"`
+
+	// ObjectComments maintains a comment on the users, roles and schemas the
+	// provider manages, so DBAs see which resource manages them.
+	// +optional
+	ObjectComments *ObjectCommentsConfig `json:"objectComments,omitempty"`
+}
+
+// TLSConfig configures the TLS connection to the HANA SQL endpoint.
+type TLSConfig struct {
+	// RootCASecretRef references a PEM encoded CA bundle that is trusted in
+	// addition to the system roots, e.g. for landscapes with a private CA.
+	// +optional
+	RootCASecretRef *xpv1.SecretKeySelector `json:"rootCASecretRef,omitempty"`
+
+	// ValidateCertificate controls whether the server certificate is
+	// validated, like sslValidateCertificate of the HANA clients.
+	// +optional
+	// +kubebuilder:default:=true
+	ValidateCertificate *bool `json:"validateCertificate,omitempty"`
+
+	// ServerName overrides the hostname used to verify the server
+	// certificate. Defaults to the endpoint of the connection secret.
+	// +optional
+	ServerName string `json:"serverName,omitempty"`
+
+	// MinVersion is the minimum TLS version accepted.
+	// +optional
+	// +kubebuilder:validation:Enum="1.2";"1.3"
+	MinVersion string `json:"minVersion,omitempty"`
+}
+
+// ObjectCommentsConfig configures the comments on managed catalog objects.
+type ObjectCommentsConfig struct {
+	// ClusterName identifies the Kubernetes cluster in the comments.
+	// +optional
+	ClusterName string `json:"clusterName,omitempty"`
+}
+
+// UsergroupOperatorConfig configures the usergroup operator mode.
+type UsergroupOperatorConfig struct {
+	// Usergroup the technical user operates. Users are only created in and
+	// kept in this usergroup, and catalog views the technical user cannot
+	// read are left unobserved instead of failing the reconciliation.
+	// +kubebuilder:validation:MinLength=1
+	Usergroup string `json:"usergroup"`
+}
+
+// Proxy types supported by a ProxyConfig.
+const (
+	ProxyTypeHTTPConnect = "HTTPConnect"
+	ProxyTypeSOCKS5      = "SOCKS5"
+)
+
+// Identifier case strategies supported by a ProviderConfig.
+const (
+	IdentifierCaseUpper    = "Upper"
+	IdentifierCasePreserve = "Preserve"
+)
+
+// ProxyConfig configures a proxy that connections are tunneled through.
+type ProxyConfig struct {
+	// Type of the proxy.
+	// +kubebuilder:validation:Enum=HTTPConnect;SOCKS5
+	// +kubebuilder:default:=SOCKS5
+	Type string `json:"type,omitempty"`
+
+	// Address of the proxy as host:port, e.g.
+	// connectivity-proxy.kyma-system.svc.cluster.local:20004.
+	// +kubebuilder:validation:MinLength=1
+	Address string `json:"address"`
+
+	// CredentialsSecretRef references a Secret with the username and password
+	// keys used to authenticate to the proxy.
+	// +optional
+	CredentialsSecretRef *xpv1.SecretReference `json:"credentialsSecretRef,omitempty"`
+}
+
+// GrantPolicy lists privileges and roles the provider must never grant.
+type GrantPolicy struct {
+	// ForbiddenPrivileges are never granted, e.g. USER ADMIN or
+	// CATALOG READ WITH ADMIN OPTION. An entry without a grant or admin option
+	// also forbids the grantable variant.
+	// +optional
+	ForbiddenPrivileges []string `json:"forbiddenPrivileges,omitempty"`
+
+	// ForbiddenRoles are never granted. Role names are matched case-insensitively.
+	// +optional
+	ForbiddenRoles []string `json:"forbiddenRoles,omitempty"`
+
+	// HighRiskPrivileges are only granted once a second identity approved the
+	// managed resource through the approved-by annotation.
+	// +optional
+	HighRiskPrivileges []string `json:"highRiskPrivileges,omitempty"`
+
+	// HighRiskRoles are only granted once a second identity approved the
+	// managed resource through the approved-by annotation.
+	// +optional
+	HighRiskRoles []string `json:"highRiskRoles,omitempty"`
+
+	// RevocationThreshold is the number of privileges and roles that may be
+	// revoked from a User in one update. More revocations, e.g. when an
+	// adopted user is switched to the strict privilege management policy,
+	// are held back until they are confirmed through the
+	// confirm-revocations annotation. Unlimited if unset.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	RevocationThreshold *int32 `json:"revocationThreshold,omitempty"`
+}
+
+// AnnotationConfirmRevocations confirms the revocations pending on a managed
+// resource beyond the revocation threshold. Its value must be the number of
+// pending revocations, so that a confirmation does not cover revocations
+// added later.
+const AnnotationConfirmRevocations = "hana.sap.crossplane.io/confirm-revocations"
+
+// Annotations of the two-person approval for high-risk grants. Both are
+// written by the admission webhook with the identity of the requesting user.
+const (
+	// AnnotationRequestedBy records who last changed the grants of a resource.
+	AnnotationRequestedBy = "hana.sap.crossplane.io/requested-by"
+	// AnnotationApprovedBy records who approved the current grants. Setting it
+	// to any value approves as the requesting user.
+	AnnotationApprovedBy = "hana.sap.crossplane.io/approved-by"
+)
+
+// Condition types and reasons for the grant policy.
+const (
+	// TypeGrantPolicy indicates whether a managed resource complies with the
+	// grant policy of its ProviderConfig.
+	TypeGrantPolicy xpv1.ConditionType = "GrantPolicy"
+
+	ReasonGrantAllowed   xpv1.ConditionReason = "GrantAllowed"
+	ReasonGrantForbidden xpv1.ConditionReason = "GrantForbidden"
+
+	// TypeApproval indicates whether the high-risk grants of a managed
+	// resource have been approved by a second identity.
+	TypeApproval xpv1.ConditionType = "Approval"
+
+	ReasonApproved        xpv1.ConditionReason = "Approved"
+	ReasonApprovalPending xpv1.ConditionReason = "ApprovalPending"
+)
+
+// GrantAllowed returns a condition indicating that the requested grants comply
+// with the grant policy.
+func GrantAllowed() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeGrantPolicy,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonGrantAllowed,
+	}
+}
+
+// GrantForbidden returns a condition indicating that the requested grants are
+// rejected by the grant policy.
+func GrantForbidden(err error) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeGrantPolicy,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonGrantForbidden,
+		Message:            err.Error(),
+	}
+}
+
+// Condition type and reasons for the targets of privileges.
+const (
+	// TypeGrantTargets indicates whether the schemas and objects a managed
+	// resource is granted privileges on exist.
+	TypeGrantTargets xpv1.ConditionType = "GrantTargets"
+
+	ReasonTargetsAvailable xpv1.ConditionReason = "TargetsAvailable"
+	ReasonMissingTarget    xpv1.ConditionReason = "MissingTarget"
+)
+
+// GrantTargetsAvailable returns a condition indicating that all schemas and
+// objects the privileges refer to exist.
+func GrantTargetsAvailable() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeGrantTargets,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonTargetsAvailable,
+	}
+}
+
+// GrantTargetsMissing returns a condition indicating that privileges on the
+// given schemas or objects are held back until they exist.
+func GrantTargetsMissing(objects []string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeGrantTargets,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonMissingTarget,
+		Message:            "privileges are not granted until these objects exist: " + strings.Join(objects, ", "),
+	}
+}
+
+// Condition type and reasons for revoking roles.
+const (
+	// TypeRoleRevocation indicates whether the roles removed from a managed
+	// resource could be revoked.
+	TypeRoleRevocation xpv1.ConditionType = "RoleRevocation"
+
+	ReasonRolesRevoked       xpv1.ConditionReason = "RolesRevoked"
+	ReasonRevokeNotPermitted xpv1.ConditionReason = "RevokeNotPermitted"
+)
+
+// RolesRevoked returns a condition indicating that all roles removed from a
+// managed resource were revoked.
+func RolesRevoked() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeRoleRevocation,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonRolesRevoked,
+	}
+}
+
+// RolesNotRevoked returns a condition indicating that the given roles stay
+// granted, since the technical user lacks the privilege to revoke them.
+func RolesNotRevoked(roles []string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeRoleRevocation,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonRevokeNotPermitted,
+		Message: "insufficient privilege to revoke these roles, e.g. as they are owned by _SYS_REPO or an HDI container; " +
+			"they stay granted until revoked by their owner: " + strings.Join(roles, ", "),
+	}
+}
+
+// AnnotationDeletionPolicy selects whether a Schema or Role waits for the
+// managed resources that depend on it before it is dropped, and whether a
+// PersonalSecurityEnvironment waits for the purposes set for it outside of
+// its spec to be unset. Set it to DeletionPolicyCascade to drop it right away.
+const (
+	AnnotationDeletionPolicy = "hana.sap.crossplane.io/deletion-policy"
+	DeletionPolicyCascade    = "cascade"
+)
+
+// Annotations propagating changes of connection secrets into the Deployments
+// consuming them, so that a rotated password does not break running apps.
+const (
+	// AnnotationRestartOnSecretChange lists the comma-separated names of the
+	// connection secrets, in the namespace of the annotated Deployment, whose
+	// changes restart the pods of the Deployment.
+	AnnotationRestartOnSecretChange = "hana.sap.crossplane.io/restart-on-secret-change"
+	// AnnotationConnectionSecretHash is set on the pod template of the
+	// Deployment to the hash of the listed connection secrets. Changing it
+	// rolls the pods out.
+	AnnotationConnectionSecretHash = "hana.sap.crossplane.io/connection-secret-hash"
+)
+
+// Condition type and reasons for the deletion of resources that others
+// depend on.
+const (
+	// TypeDeletionBlocked indicates that the deletion of a managed resource
+	// waits for the managed resources that depend on it.
+	TypeDeletionBlocked xpv1.ConditionType = "DeletionBlocked"
+
+	ReasonDependentsExist xpv1.ConditionReason = "DependentsExist"
+	ReasonProtected       xpv1.ConditionReason = "Protected"
+	ReasonPurposesSet     xpv1.ConditionReason = "PurposesSet"
+)
+
+// DeletionBlocked returns a condition indicating that the deletion waits until
+// the given dependent resources are gone.
+func DeletionBlocked(dependents []string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeDeletionBlocked,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonDependentsExist,
+		Message: "waiting for these dependent resources to be deleted: " + strings.Join(dependents, ", ") +
+			"; set the " + AnnotationDeletionPolicy + " annotation to " + DeletionPolicyCascade + " to delete anyway",
+	}
+}
+
+// PurposesSet returns a condition indicating that the deletion of a PSE waits
+// until the given purposes, set outside of its spec, are unset.
+func PurposesSet(purposes []string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeDeletionBlocked,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonPurposesSet,
+		Message: "the PSE cannot be dropped while these purposes are set for it: " + strings.Join(purposes, ", ") +
+			"; unset them, or set the " + AnnotationDeletionPolicy + " annotation to " + DeletionPolicyCascade + " to unset them and delete anyway",
+	}
+}
+
+// DeletionProtected returns a condition indicating that the deletion waits
+// until the protection policy of the resource is removed.
+func DeletionProtected() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeDeletionBlocked,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonProtected,
+		Message:            "the resource is protected from deletion; remove its protectionPolicy to delete it",
+	}
+}
+
+// Condition type and reasons for the password expiry of users.
+const (
+	// TypePasswordExpiring indicates that the password of a user expires
+	// within the warning period of its ProviderConfig.
+	TypePasswordExpiring xpv1.ConditionType = "PasswordExpiring"
+
+	ReasonPasswordValid       xpv1.ConditionReason = "PasswordValid"
+	ReasonPasswordExpiresSoon xpv1.ConditionReason = "PasswordExpiresSoon"
+	ReasonPasswordExpired     xpv1.ConditionReason = "PasswordExpired"
+)
+
+// PasswordValid returns a condition indicating that the password of a user
+// does not expire within the warning period.
+func PasswordValid() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypePasswordExpiring,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonPasswordValid,
+	}
+}
+
+// PasswordExpiresSoon returns a condition indicating that the password of a
+// user expires at the given time, within the warning period.
+func PasswordExpiresSoon(expiresAt metav1.Time) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypePasswordExpiring,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonPasswordExpiresSoon,
+		Message:            "password expires at " + expiresAt.UTC().Format(time.RFC3339),
+	}
+}
+
+// PasswordExpired returns a condition indicating that the password of a user
+// expired at the given time.
+func PasswordExpired(expiredAt metav1.Time) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypePasswordExpiring,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonPasswordExpired,
+		Message:            "password expired at " + expiredAt.UTC().Format(time.RFC3339),
+	}
+}
+
+// ReasonMissingPrivilege indicates that a managed resource is not reconciled,
+// as the technical user lacks the system privilege to manage it.
+const ReasonMissingPrivilege xpv1.ConditionReason = "MissingPrivilege"
+
+// MissingPrivilege returns a condition indicating that a managed resource is
+// not reconciled, as the technical user lacks the system privilege to manage
+// it.
+func MissingPrivilege(err error) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               xpv1.TypeReady,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonMissingPrivilege,
+		Message:            err.Error(),
+	}
+}
+
+// ReasonMissingService indicates that a managed resource is not reconciled,
+// as the service to manage it is not enabled on the database.
+const ReasonMissingService xpv1.ConditionReason = "MissingService"
+
+// MissingService returns a condition indicating that a managed resource is
+// not reconciled, as the service to manage it is not enabled on the database.
+func MissingService(err error) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               xpv1.TypeReady,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonMissingService,
+		Message:            err.Error(),
+	}
 }
 
 const (
@@ -31,11 +484,37 @@ type ProviderCredentials struct {
 	Source xpv1.CredentialsSource `json:"source"`
 
 	ConnectionSecretRef *xpv1.SecretReference `json:"connectionSecretRef,omitempty"`
+
+	// Env is the environment variable of the provider that holds the
+	// connection credentials with the Environment source, as a JSON object of
+	// the keys of a connection secret.
+	// +optional
+	Env *xpv1.EnvSelector `json:"env,omitempty"`
+
+	// Fs is the file of the provider that holds the connection credentials
+	// with the Filesystem source, e.g. one rendered by a Vault agent sidecar,
+	// as a JSON object of the keys of a connection secret.
+	// +optional
+	Fs *xpv1.FsSelector `json:"fs,omitempty"`
+
+	// ServiceKey is the key of the connection secret that holds a HANA Cloud
+	// service key, the JSON of a service binding as the BTP cockpit or
+	// `cf service-key` show it. Its host, port, user, password and
+	// certificate are used instead of the endpoint, port, username, password
+	// and TLS root CA keys of the secret. With the Environment and Filesystem
+	// sources, the variable or file holds the service key itself.
+	// +optional
+	ServiceKey string `json:"serviceKey,omitempty"`
 }
 
 // A ProviderConfigStatus reflects the observed state of a ProviderConfig.
 type ProviderConfigStatus struct {
 	xpv1.ProviderConfigStatus `json:",inline"`
+
+	// ActiveEndpoint is the host:port the provider is currently connected to
+	// when failover endpoints are configured.
+	// +optional
+	ActiveEndpoint string `json:"activeEndpoint,omitempty"`
 }
 
 // +kubebuilder:object:root=true
@@ -43,8 +522,9 @@ type ProviderConfigStatus struct {
 // A ProviderConfig configures a hana provider.
 // +kubebuilder:subresource:status
 // +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:printcolumn:name="ACTIVE-ENDPOINT",type="string",JSONPath=".status.activeEndpoint",priority=1
 // +kubebuilder:printcolumn:name="SECRET-NAME",type="string",JSONPath=".spec.credentials.secretRef.name",priority=1
-// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,provider,hana}
 type ProviderConfig struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
@@ -76,3 +556,60 @@ func init() {
 		&ProviderConfigList{},
 	)
 }
+
+// Condition type and reasons for confirming revocations.
+const (
+	// TypeRevocationConfirmation indicates whether the pending revocations of
+	// a managed resource are within the revocation threshold or confirmed.
+	TypeRevocationConfirmation xpv1.ConditionType = "RevocationConfirmation"
+
+	ReasonRevocationsConfirmed xpv1.ConditionReason = "RevocationsConfirmed"
+	ReasonConfirmationRequired xpv1.ConditionReason = "ConfirmationRequired"
+)
+
+// RevocationsConfirmed returns a condition indicating that the pending
+// revocations are within the revocation threshold or confirmed.
+func RevocationsConfirmed() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeRevocationConfirmation,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonRevocationsConfirmed,
+	}
+}
+
+// RevocationsUnconfirmed returns a condition indicating that the pending
+// revocations exceed the revocation threshold and wait for confirmation.
+func RevocationsUnconfirmed(err error) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeRevocationConfirmation,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonConfirmationRequired,
+		Message:            err.Error(),
+	}
+}
+
+// Approved returns a condition indicating that the high-risk grants have been
+// approved.
+func Approved(approver string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeApproval,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonApproved,
+		Message:            "approved by " + approver,
+	}
+}
+
+// ApprovalPending returns a condition indicating that the high-risk grants
+// wait for a second identity's approval.
+func ApprovalPending(err error) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeApproval,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonApprovalPending,
+		Message:            err.Error(),
+	}
+}
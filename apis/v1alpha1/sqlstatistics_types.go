@@ -0,0 +1,33 @@
+/*
+Copyright 2026 SAP SE or an SAP affiliate company and contributors.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// SQLStatistics counts the SQL a reconcile of a managed resource ran against
+// HANA.
+type SQLStatistics struct {
+	// StatementsExecuted is the number of statements executed, such as
+	// GRANT or ALTER USER.
+	StatementsExecuted int64 `json:"statementsExecuted"`
+
+	// QueriesExecuted is the number of queries run, e.g. to observe the
+	// resource.
+	QueriesExecuted int64 `json:"queriesExecuted"`
+
+	// Duration of the reconcile, from the start of the observation to the
+	// end of the create, update or delete that followed it, if any.
+	Duration metav1.Duration `json:"duration"`
+}
+
+// An SQLStatisticsReporter reports the SQL statistics of its last reconcile
+// in its status.
+// +kubebuilder:object:generate=false
+type SQLStatisticsReporter interface {
+	GetSQLStatistics() *SQLStatistics
+	SetSQLStatistics(s *SQLStatistics)
+}
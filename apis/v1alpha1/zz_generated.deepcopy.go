@@ -13,6 +13,61 @@ import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GrantPolicy) DeepCopyInto(out *GrantPolicy) {
+	*out = *in
+	if in.ForbiddenPrivileges != nil {
+		in, out := &in.ForbiddenPrivileges, &out.ForbiddenPrivileges
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ForbiddenRoles != nil {
+		in, out := &in.ForbiddenRoles, &out.ForbiddenRoles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.HighRiskPrivileges != nil {
+		in, out := &in.HighRiskPrivileges, &out.HighRiskPrivileges
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.HighRiskRoles != nil {
+		in, out := &in.HighRiskRoles, &out.HighRiskRoles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RevocationThreshold != nil {
+		in, out := &in.RevocationThreshold, &out.RevocationThreshold
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GrantPolicy.
+func (in *GrantPolicy) DeepCopy() *GrantPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(GrantPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ObjectCommentsConfig) DeepCopyInto(out *ObjectCommentsConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ObjectCommentsConfig.
+func (in *ObjectCommentsConfig) DeepCopy() *ObjectCommentsConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ObjectCommentsConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ProviderConfig) DeepCopyInto(out *ProviderConfig) {
 	*out = *in
@@ -76,6 +131,41 @@ func (in *ProviderConfigList) DeepCopyObject() runtime.Object {
 func (in *ProviderConfigSpec) DeepCopyInto(out *ProviderConfigSpec) {
 	*out = *in
 	in.Credentials.DeepCopyInto(&out.Credentials)
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(TLSConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.FailoverEndpoints != nil {
+		in, out := &in.FailoverEndpoints, &out.FailoverEndpoints
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Proxy != nil {
+		in, out := &in.Proxy, &out.Proxy
+		*out = new(ProxyConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UsergroupOperator != nil {
+		in, out := &in.UsergroupOperator, &out.UsergroupOperator
+		*out = new(UsergroupOperatorConfig)
+		**out = **in
+	}
+	if in.PasswordExpiryWarningDays != nil {
+		in, out := &in.PasswordExpiryWarningDays, &out.PasswordExpiryWarningDays
+		*out = new(int32)
+		**out = **in
+	}
+	if in.GrantPolicy != nil {
+		in, out := &in.GrantPolicy, &out.GrantPolicy
+		*out = new(GrantPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ObjectComments != nil {
+		in, out := &in.ObjectComments, &out.ObjectComments
+		*out = new(ObjectCommentsConfig)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderConfigSpec.
@@ -170,6 +260,16 @@ func (in *ProviderCredentials) DeepCopyInto(out *ProviderCredentials) {
 		*out = new(v1.SecretReference)
 		**out = **in
 	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = new(v1.EnvSelector)
+		**out = **in
+	}
+	if in.Fs != nil {
+		in, out := &in.Fs, &out.Fs
+		*out = new(v1.FsSelector)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderCredentials.
@@ -182,6 +282,42 @@ func (in *ProviderCredentials) DeepCopy() *ProviderCredentials {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProxyConfig) DeepCopyInto(out *ProxyConfig) {
+	*out = *in
+	if in.CredentialsSecretRef != nil {
+		in, out := &in.CredentialsSecretRef, &out.CredentialsSecretRef
+		*out = new(v1.SecretReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProxyConfig.
+func (in *ProxyConfig) DeepCopy() *ProxyConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ProxyConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SQLStatistics) DeepCopyInto(out *SQLStatistics) {
+	*out = *in
+	out.Duration = in.Duration
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SQLStatistics.
+func (in *SQLStatistics) DeepCopy() *SQLStatistics {
+	if in == nil {
+		return nil
+	}
+	out := new(SQLStatistics)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StoreConfig) DeepCopyInto(out *StoreConfig) {
 	*out = *in
@@ -272,3 +408,43 @@ func (in *StoreConfigStatus) DeepCopy() *StoreConfigStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TLSConfig) DeepCopyInto(out *TLSConfig) {
+	*out = *in
+	if in.RootCASecretRef != nil {
+		in, out := &in.RootCASecretRef, &out.RootCASecretRef
+		*out = new(v1.SecretKeySelector)
+		**out = **in
+	}
+	if in.ValidateCertificate != nil {
+		in, out := &in.ValidateCertificate, &out.ValidateCertificate
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TLSConfig.
+func (in *TLSConfig) DeepCopy() *TLSConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(TLSConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UsergroupOperatorConfig) DeepCopyInto(out *UsergroupOperatorConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UsergroupOperatorConfig.
+func (in *UsergroupOperatorConfig) DeepCopy() *UsergroupOperatorConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(UsergroupOperatorConfig)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -8,7 +8,7 @@ import (
 	"context"
 	"os"
 	"path/filepath"
-	"time"
+	"strings"
 
 	kingpin "github.com/alecthomas/kingpin/v2"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
@@ -16,7 +16,9 @@ import (
 	"k8s.io/client-go/tools/leaderelection/resourcelock"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 	"github.com/crossplane/crossplane-runtime/pkg/controller"
@@ -27,32 +29,138 @@ import (
 
 	"github.com/SAP/crossplane-provider-hana/apis"
 	apisv1alpha1 "github.com/SAP/crossplane-provider-hana/apis/v1alpha1"
+	"github.com/SAP/crossplane-provider-hana/internal/audit"
+	"github.com/SAP/crossplane-provider-hana/internal/bootstrap"
 	"github.com/SAP/crossplane-provider-hana/internal/clients/hana"
+	"github.com/SAP/crossplane-provider-hana/internal/clients/hana/simulator"
+	"github.com/SAP/crossplane-provider-hana/internal/clients/xsql"
 	hanaController "github.com/SAP/crossplane-provider-hana/internal/controller"
 	"github.com/SAP/crossplane-provider-hana/internal/controller/features"
+	"github.com/SAP/crossplane-provider-hana/internal/generate"
+	"github.com/SAP/crossplane-provider-hana/internal/sqlstats"
+	hanaWebhook "github.com/SAP/crossplane-provider-hana/internal/webhook"
 )
 
+const defaultLeaderElectionID = "crossplane-leader-election-provider-hana"
+
+// leaderElectionIDFor returns the default leader election ID for the selected
+// controllers. Deployments running different controllers must not share a
+// Lease, otherwise only one of them would ever be leading.
+func leaderElectionIDFor(s hanaController.Selection) string {
+	if s.All() {
+		return defaultLeaderElectionID
+	}
+	return defaultLeaderElectionID + "-" + strings.Join(s.Names(), "-")
+}
+
 func main() {
 	var (
 		app            = kingpin.New(filepath.Base(os.Args[0]), "hana support for Crossplane.").DefaultEnvars()
 		debug          = app.Flag("debug", "Run with debug logging.").Short('d').Bool()
 		leaderElection = app.Flag("leader-election", "Use leader election for the controller manager.").Short('l').Default("false").OverrideDefaultFromEnvar("LEADER_ELECTION").Bool()
 
+		leaderElectionID        = app.Flag("leader-election-id", "Name of the Lease used for leader election. Defaults to "+defaultLeaderElectionID+", suffixed with the selected controllers if --controllers is set.").Envar("LEADER_ELECTION_ID").String()
+		leaderElectionNamespace = app.Flag("leader-election-namespace", "Namespace of the Lease used for leader election. Defaults to the namespace the provider runs in.").Envar("LEADER_ELECTION_NAMESPACE").String()
+		leaseDuration           = app.Flag("leader-election-lease-duration", "How long non-leaders wait before trying to acquire the Lease of a leader that stopped renewing it.").Default("60s").Envar("LEADER_ELECTION_LEASE_DURATION").Duration()
+		renewDeadline           = app.Flag("leader-election-renew-deadline", "How long the leader retries renewing the Lease before giving up leadership.").Default("50s").Envar("LEADER_ELECTION_RENEW_DEADLINE").Duration()
+		retryPeriod             = app.Flag("leader-election-retry-period", "How long to wait between attempts to acquire or renew the Lease.").Default("2s").Envar("LEADER_ELECTION_RETRY_PERIOD").Duration()
+		controllers             = app.Flag("controllers", "Controllers to run, comma-separated or repeated. Either a group or the kind of a managed resource, all controllers run if unset. Known controllers: "+strings.Join(hanaController.Names(), ", ")+".").Envar("CONTROLLERS").Strings()
+
 		syncInterval     = app.Flag("sync", "How often all resources will be double-checked for drift from the desired state.").Short('s').Default("1h").Duration()
 		pollInterval     = app.Flag("poll", "How often individual resources will be checked for drift from the desired state").Default("1m").Duration()
+		pollJitter       = app.Flag("poll-jitter", "Random duration added to or subtracted from the poll interval of each resource, so that polls are spread over time. A duration for all controllers or <controller>=<duration> for a group or kind, comma-separated or repeated.").Envar("POLL_JITTER").Strings()
 		maxReconcileRate = app.Flag("max-reconcile-rate", "The global maximum rate per second at which resources may checked for drift from the desired state.").Default("10").Int()
+		shutdownTimeout  = app.Flag("shutdown-timeout", "How long reconciles in progress may run after the provider is asked to stop, e.g. to complete their SQL statements.").Default("30s").Envar("SHUTDOWN_TIMEOUT").Duration()
+
+		namespace                  = app.Flag("namespace", "Namespace used to set as default scope in default secret store config, and of the credentials Secret of generated examples.").Default("crossplane-system").Envar("POD_NAMESPACE").String()
+		enableExternalSecretStores = app.Flag("enable-external-secret-stores", "Enable support for ExternalSecretStores. Same as --enable-feature=EnableAlphaExternalSecretStores.").Default("false").Envar("ENABLE_EXTERNAL_SECRET_STORES").Bool()
+		enableFeatures             = app.Flag("enable-feature", "Feature flags to enable, comma-separated or repeated. Known flags: "+strings.Join(features.Names(), ", ")+".").Envar("ENABLE_FEATURES").Strings()
+		userSecretNamespaces       = app.Flag("user-secret-namespaces", "Namespaces of the password Secrets whose changes are propagated to Users, comma-separated or repeated. Secrets in all namespaces are watched if unset.").Envar("USER_SECRET_NAMESPACES").Strings()
+		auditLogFile               = app.Flag("audit-log-file", "File that statements creating, updating or deleting managed resources are appended to as JSON lines. Not audited if neither this nor --audit-log-url is set.").Envar("AUDIT_LOG_FILE").String()
+		auditLogURL                = app.Flag("audit-log-url", "HTTP endpoint that statements creating, updating or deleting managed resources are posted to as JSON.").Envar("AUDIT_LOG_URL").String()
+		dev                        = app.Flag("dev", "Run against an in-memory simulation of HANA instead of the databases of the ProviderConfigs, e.g. for local development. Only users, roles, rolegroups, schemas, usergroups and X.509 providers are simulated.").Default("false").Envar("DEV").Bool()
+		webhookTLSCertDir          = app.Flag("webhook-tls-cert-dir", "The directory of TLS certificate that will be used by the webhook server. There should be tls.crt and tls.key files. Webhooks are disabled if unset.").Envar("TLS_SERVER_CERTS_DIR").String()
 
-		namespace                  = app.Flag("namespace", "Namespace used to set as default scope in default secret store config.").Default("crossplane-system").Envar("POD_NAMESPACE").String()
-		enableExternalSecretStores = app.Flag("enable-external-secret-stores", "Enable support for ExternalSecretStores.").Default("false").Envar("ENABLE_EXTERNAL_SECRET_STORES").Bool()
+		_           = app.Command("run", "Run the provider.").Default()
+		generateCmd = app.Command("generate", "Print example manifests that bootstrap the provider: a ProviderConfig with its credentials Secret and a User authenticating with X.509 certificates.")
+		genEndpoint = generateCmd.Flag("endpoint", "Endpoint of the HANA database.").Default("<endpoint>").String()
+		genPort     = generateCmd.Flag("port", "Port of the HANA database.").Default("443").Int()
+		genPC       = generateCmd.Flag("provider-config", "Name of the ProviderConfig.").Default("default").String()
+		genIssuer   = generateCmd.Flag("issuer", "Distinguished name of the CA issuing the client certificates.").Default("CN=Example CA, O=Example").String()
+		genSubject  = generateCmd.Flag("subject", "Distinguished name of the client certificate of the user.").Default("CN=app, O=Example").String()
+		genUsername = generateCmd.Flag("username", "Name of the user.").Default("X509_USER").String()
+
+		bootstrapCmd       = app.Command("bootstrap", "Create the technical user of the provider with the minimal privileges the controllers need, using temporary DBADMIN credentials, and write the credentials Secret of its ProviderConfig.")
+		bootEndpoint       = bootstrapCmd.Flag("endpoint", "Endpoint of the HANA database.").Required().String()
+		bootPort           = bootstrapCmd.Flag("port", "Port of the HANA database.").Default("443").String()
+		bootAdminUser      = bootstrapCmd.Flag("admin-user", "User creating the technical user.").Default("DBADMIN").String()
+		bootPasswordFile   = bootstrapCmd.Flag("admin-password-file", "File holding the password of the admin user, e.g. a mounted Secret. Read from the "+bootstrap.AdminPasswordEnv+" environment variable if unset.").Envar("BOOTSTRAP_ADMIN_PASSWORD_FILE").String()
+		bootUsername       = bootstrapCmd.Flag("username", "Name of the technical user.").Default("CROSSPLANE").String()
+		bootUsergroup      = bootstrapCmd.Flag("usergroup-operator", "Usergroup the technical user operates in usergroup operator mode, instead of holding USER ADMIN.").String()
+		bootPrivileges     = bootstrapCmd.Flag("privilege", "Additional system privilege of the technical user, e.g. AUDIT ADMIN. Can be repeated.").Strings()
+		bootProviderConfig = bootstrapCmd.Flag("provider-config", "Name of the ProviderConfig, the credentials Secret is named <provider-config>-credentials.").Default("default").String()
 	)
-	kingpin.MustParse(app.Parse(os.Args[1:]))
+	command := kingpin.MustParse(app.Parse(os.Args[1:]))
+	if command == generateCmd.FullCommand() {
+		kingpin.FatalIfError(generate.Write(os.Stdout, generate.Options{
+			Endpoint:       *genEndpoint,
+			Port:           *genPort,
+			Namespace:      *namespace,
+			ProviderConfig: *genPC,
+			Issuer:         *genIssuer,
+			Subject:        *genSubject,
+			Username:       *genUsername,
+		}), "Cannot generate examples")
+		return
+	}
 
 	// Configure logging with klog
 	ctrl.SetLogger(zap.New(zap.UseDevMode(false)))
 	log := logging.NewLogrLogger(ctrl.Log.WithName("provider-hana"))
 
+	if command == bootstrapCmd.FullCommand() {
+		ctx := ctrl.SetupSignalHandler()
+		adminPassword, err := bootstrap.AdminPassword(*bootPasswordFile)
+		kingpin.FatalIfError(err, "Cannot get the password of the admin user")
+		db, err := hana.New(log).Connect(ctx, map[string][]byte{
+			xpv1.ResourceCredentialsSecretEndpointKey: []byte(*bootEndpoint),
+			xpv1.ResourceCredentialsSecretPortKey:     []byte(*bootPort),
+			xpv1.ResourceCredentialsSecretUserKey:     []byte(*bootAdminUser),
+			xpv1.ResourceCredentialsSecretPasswordKey: []byte(adminPassword),
+		})
+		kingpin.FatalIfError(err, "Cannot connect to HANA")
+		cfg, err := ctrl.GetConfig()
+		kingpin.FatalIfError(err, "Cannot get API server rest config")
+		kube, err := client.New(cfg, client.Options{})
+		kingpin.FatalIfError(err, "Cannot create Kubernetes client")
+		o := bootstrap.Options{
+			Username:          *bootUsername,
+			UsergroupOperator: *bootUsergroup,
+			Privileges:        *bootPrivileges,
+			Endpoint:          *bootEndpoint,
+			Port:              *bootPort,
+			Namespace:         *namespace,
+			Name:              *bootProviderConfig + "-credentials",
+		}
+		kingpin.FatalIfError(bootstrap.Run(ctx, db, kube, o), "Cannot bootstrap the technical user")
+		log.Info("Created technical user", "username", o.Username, "secret", o.Namespace+"/"+o.Name)
+		return
+	}
+
 	log.Info("Starting provider-hana", "debug", *debug)
 
+	selection, err := hanaController.Select(*controllers)
+	kingpin.FatalIfError(err, "Cannot select controllers")
+	if !selection.All() {
+		log.Info("Running selected controllers only", "controllers", selection.Names())
+	}
+	kingpin.FatalIfError(hanaController.ConfigurePollJitter(*pollJitter), "Cannot configure poll jitter")
+	if *leaderElectionID == "" {
+		*leaderElectionID = leaderElectionIDFor(selection)
+	}
+
+	ctx := ctrl.SetupSignalHandler()
+
 	cfg, err := ctrl.GetConfig()
 	kingpin.FatalIfError(err, "Cannot get API server rest config")
 
@@ -69,10 +177,18 @@ func main() {
 		// server. Switching to Leases only and longer leases appears to
 		// alleviate this.
 		LeaderElection:             *leaderElection,
-		LeaderElectionID:           "crossplane-leader-election-provider-hana",
+		LeaderElectionID:           *leaderElectionID,
+		LeaderElectionNamespace:    *leaderElectionNamespace,
 		LeaderElectionResourceLock: resourcelock.LeasesResourceLock,
-		LeaseDuration:              func() *time.Duration { d := 60 * time.Second; return &d }(),
-		RenewDeadline:              func() *time.Duration { d := 50 * time.Second; return &d }(),
+		LeaseDuration:              leaseDuration,
+		RenewDeadline:              renewDeadline,
+		RetryPeriod:                retryPeriod,
+		// New reconciles stop on shutdown, those in progress get the
+		// shutdown timeout to complete
+		GracefulShutdownTimeout: shutdownTimeout,
+		WebhookServer: webhook.NewServer(webhook.Options{
+			CertDir: *webhookTLSCertDir,
+		}),
 	})
 	kingpin.FatalIfError(err, "Cannot create controller manager")
 	kingpin.FatalIfError(apis.AddToScheme(mgr.GetScheme()), "Cannot add hana APIs to scheme")
@@ -85,13 +201,16 @@ func main() {
 		Features:                &feature.Flags{},
 	}
 
-	o.Features.Enable(features.EnableAlphaManagementPolicies)
-	log.Info("Beta feature enabled by default", "flag", features.EnableAlphaManagementPolicies)
-
 	if *enableExternalSecretStores {
-		o.Features.Enable(features.EnableAlphaExternalSecretStores)
-		log.Info("Alpha feature enabled", "flag", features.EnableAlphaExternalSecretStores)
+		*enableFeatures = append(*enableFeatures, string(features.EnableAlphaExternalSecretStores))
+	}
+	enabled, err := features.Enable(o.Features, *enableFeatures)
+	kingpin.FatalIfError(err, "Cannot enable feature flags")
+	for _, flag := range enabled {
+		log.Info("Feature enabled", "flag", flag)
+	}
 
+	if o.Features.Enabled(features.EnableAlphaExternalSecretStores) {
 		// Ensure default store config exists.
 		kingpin.FatalIfError(resource.Ignore(kerrors.IsAlreadyExists, mgr.GetClient().Create(context.Background(), &apisv1alpha1.StoreConfig{
 			ObjectMeta: metav1.ObjectMeta{
@@ -107,9 +226,35 @@ func main() {
 		})), "cannot create default store config")
 	}
 
-	hanaDB := hana.New(log.WithValues("component", "hanaDB"))
+	var hanaDB xsql.Connector
+	if *dev {
+		hanaDB = simulator.New()
+		log.Info("Simulating HANA in memory, the databases of ProviderConfigs are not connected to")
+	} else {
+		hanaDB = hana.New(log.WithValues("component", "hanaDB"), hana.WithDrain(ctx, *shutdownTimeout))
+	}
 	defer hanaDB.Disconnect() //nolint:errcheck
 
-	kingpin.FatalIfError(hanaController.Setup(mgr, o, hanaDB), "Cannot setup hana controllers")
-	kingpin.FatalIfError(mgr.Start(ctrl.SetupSignalHandler()), "Cannot start controller manager")
+	switch {
+	case *auditLogFile != "" && *auditLogURL != "":
+		kingpin.Fatalf("Cannot set both --audit-log-file and --audit-log-url")
+	case *auditLogFile != "":
+		sink, err := audit.NewFileSink(*auditLogFile)
+		kingpin.FatalIfError(err, "Cannot create audit log")
+		hanaDB = audit.NewConnector(hanaDB, sink, log.WithValues("component", "audit"))
+		log.Info("Writing audit log", "file", *auditLogFile)
+	case *auditLogURL != "":
+		hanaDB = audit.NewConnector(hanaDB, audit.NewHTTPSink(*auditLogURL), log.WithValues("component", "audit"))
+		log.Info("Posting audit log", "url", *auditLogURL)
+	}
+
+	hanaDB = sqlstats.NewConnector(hanaDB)
+
+	if *webhookTLSCertDir != "" {
+		kingpin.FatalIfError(hanaWebhook.Setup(mgr), "Cannot setup hana webhooks")
+		o.Features.Enable(features.AdmissionWebhooks)
+	}
+	settings := hanaController.Settings{UserSecretNamespaces: *userSecretNamespaces}
+	kingpin.FatalIfError(hanaController.Setup(mgr, o, hanaDB, selection, settings), "Cannot setup hana controllers")
+	kingpin.FatalIfError(mgr.Start(ctx), "Cannot start controller manager")
 }
@@ -0,0 +1,151 @@
+/*
+Copyright 2026 SAP SE or an SAP affiliate company and contributors.
+*/
+
+// Package audit records the statements the provider executes while creating,
+// updating or deleting managed resources, so changes to the database can be
+// traced back to the resource and ProviderConfig that made them.
+package audit
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/SAP/crossplane-provider-hana/internal/clients/xsql"
+)
+
+// Mutating actions of the managed reconciler.
+const (
+	ActionCreate = "create"
+	ActionUpdate = "update"
+	ActionDelete = "delete"
+)
+
+// Outcomes of a statement.
+const (
+	OutcomeSuccess = "success"
+	OutcomeError   = "error"
+)
+
+// An Event records a statement executed on behalf of a managed resource.
+type Event struct {
+	Time time.Time `json:"time"`
+	// Controller that reconciled the resource, e.g.
+	// managed/user.admin.hana.sap.crossplane.io.
+	Controller string `json:"controller"`
+	// Resource is the name of the managed resource.
+	Resource string `json:"resource"`
+	// ProviderConfig whose credentials executed the statement.
+	ProviderConfig string `json:"providerConfig"`
+	// Action of the managed reconciler that executed the statement.
+	Action string `json:"action"`
+	// StatementClass is the leading keywords of the statement, e.g.
+	// ALTER USER. Statements are not recorded in full, as they may contain
+	// passwords.
+	StatementClass string `json:"statementClass"`
+	Outcome        string `json:"outcome"`
+	Error          string `json:"error,omitempty"`
+}
+
+// A Sink writes audit events.
+type Sink interface {
+	Write(ctx context.Context, e Event) error
+}
+
+type actionKey struct{}
+
+// action is the mutating action a context belongs to.
+type action struct {
+	controller     string
+	resource       string
+	providerConfig string
+	action         string
+}
+
+// WithAction returns a context whose statements are recorded as executed by
+// the action of the named controller on mg.
+func WithAction(ctx context.Context, controller string, mg resource.Managed, act string) context.Context {
+	a := action{controller: controller, resource: mg.GetName(), action: act}
+	if ref := mg.GetProviderConfigReference(); ref != nil {
+		a.providerConfig = ref.Name
+	}
+	return context.WithValue(ctx, actionKey{}, a)
+}
+
+// NewConnector returns a Connector whose DBs record the statements executed
+// within an action to sink. Write errors are logged and never fail a
+// statement.
+func NewConnector(next xsql.Connector, sink Sink, log logging.Logger) xsql.Connector {
+	return &connector{Connector: next, sink: sink, log: log}
+}
+
+type connector struct {
+	xsql.Connector
+	sink Sink
+	log  logging.Logger
+}
+
+func (c *connector) Connect(ctx context.Context, creds map[string][]byte) (xsql.DB, error) {
+	db, err := c.Connector.Connect(ctx, creds)
+	if err != nil {
+		return nil, err
+	}
+	return &auditedDB{DB: db, sink: c.sink, log: c.log}, nil
+}
+
+// auditedDB records the statements run with ExecContext. Queries only read
+// and are not recorded.
+type auditedDB struct {
+	xsql.DB
+	sink Sink
+	log  logging.Logger
+}
+
+// Unwrap returns the audited DB.
+func (d *auditedDB) Unwrap() xsql.DB {
+	return d.DB
+}
+
+func (d *auditedDB) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	res, err := d.DB.ExecContext(ctx, query, args...)
+	a, ok := ctx.Value(actionKey{}).(action)
+	if !ok {
+		return res, err
+	}
+	e := Event{
+		Time:           time.Now().UTC(),
+		Controller:     a.controller,
+		Resource:       a.resource,
+		ProviderConfig: a.providerConfig,
+		Action:         a.action,
+		StatementClass: StatementClass(query),
+		Outcome:        OutcomeSuccess,
+	}
+	if err != nil {
+		e.Outcome, e.Error = OutcomeError, err.Error()
+	}
+	// The event is written even if the reconcile was canceled meanwhile.
+	if werr := d.sink.Write(context.WithoutCancel(ctx), e); werr != nil {
+		d.log.Info("Cannot write audit event", "resource", e.Resource, "statementClass", e.StatementClass, "error", werr)
+	}
+	return res, err
+}
+
+// StatementClass returns the leading keyword of a statement, followed by the
+// kind of object for CREATE, ALTER and DROP statements.
+func StatementClass(query string) string {
+	words := strings.Fields(strings.ToUpper(query))
+	switch {
+	case len(words) == 0:
+		return ""
+	case len(words) > 1 && (words[0] == "CREATE" || words[0] == "ALTER" || words[0] == "DROP"):
+		return words[0] + " " + words[1]
+	default:
+		return words[0]
+	}
+}
@@ -0,0 +1,204 @@
+/*
+Copyright 2026 SAP SE or an SAP affiliate company and contributors.
+*/
+
+package audit
+
+import (
+	"bufio"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/SAP/crossplane-provider-hana/apis/admin/v1alpha1"
+	"github.com/SAP/crossplane-provider-hana/internal/clients/fake"
+	"github.com/SAP/crossplane-provider-hana/internal/clients/xsql"
+)
+
+// recordingSink collects the written events.
+type recordingSink struct {
+	events []Event
+	err    error
+}
+
+func (s *recordingSink) Write(_ context.Context, e Event) error {
+	s.events = append(s.events, e)
+	return s.err
+}
+
+// mockConnector returns db on every Connect.
+type mockConnector struct {
+	db xsql.DB
+}
+
+func (c mockConnector) Connect(context.Context, map[string][]byte) (xsql.DB, error) { return c.db, nil }
+func (c mockConnector) Disconnect() error                                           { return nil }
+
+func TestStatementClass(t *testing.T) {
+	cases := map[string]string{
+		`CREATE USER "APP" PASSWORD "secret"`: "CREATE USER",
+		`  alter pse "P" ADD CRL '...'`:       "ALTER PSE",
+		`DROP ROLE "R"`:                       "DROP ROLE",
+		`GRANT SELECT ON SCHEMA "S" TO "U"`:   "GRANT",
+		"DROP":                                "DROP",
+		"":                                    "",
+	}
+	for query, want := range cases {
+		if got := StatementClass(query); got != want {
+			t.Errorf("StatementClass(%q) = %q, want %q", query, got, want)
+		}
+	}
+}
+
+func TestAuditedDB(t *testing.T) {
+	errBoom := errors.New("boom")
+	user := &v1alpha1.User{ObjectMeta: metav1.ObjectMeta{Name: "app"}}
+	user.SetProviderConfigReference(&xpv1.Reference{Name: "default"})
+
+	cases := map[string]struct {
+		reason  string
+		ctx     context.Context
+		execErr error
+		sinkErr error
+		want    []Event
+	}{
+		"NoAction": {
+			reason: "Statements outside of an action should not be recorded",
+			ctx:    context.Background(),
+		},
+		"Success": {
+			reason: "A statement of an action should be recorded with its class",
+			ctx:    WithAction(context.Background(), "managed/user", user, ActionCreate),
+			want: []Event{{
+				Controller: "managed/user", Resource: "app", ProviderConfig: "default",
+				Action: ActionCreate, StatementClass: "CREATE USER", Outcome: OutcomeSuccess,
+			}},
+		},
+		"Error": {
+			reason:  "A failed statement should be recorded with its error",
+			ctx:     WithAction(context.Background(), "managed/user", user, ActionCreate),
+			execErr: errBoom,
+			want: []Event{{
+				Controller: "managed/user", Resource: "app", ProviderConfig: "default",
+				Action: ActionCreate, StatementClass: "CREATE USER", Outcome: OutcomeError, Error: "boom",
+			}},
+		},
+		"SinkError": {
+			reason:  "A sink error should not fail the statement",
+			ctx:     WithAction(context.Background(), "managed/user", user, ActionCreate),
+			sinkErr: errBoom,
+			want: []Event{{
+				Controller: "managed/user", Resource: "app", ProviderConfig: "default",
+				Action: ActionCreate, StatementClass: "CREATE USER", Outcome: OutcomeSuccess,
+			}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			sink := &recordingSink{err: tc.sinkErr}
+			db := fake.MockDB{
+				MockExecContext: func(ctx context.Context, query string, args ...any) (sql.Result, error) {
+					return nil, tc.execErr
+				},
+			}
+			c := NewConnector(mockConnector{db: db}, sink, logging.NewNopLogger())
+			adb, err := c.Connect(context.Background(), nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := adb.ExecContext(tc.ctx, `CREATE USER "APP" PASSWORD "secret"`); !errors.Is(err, tc.execErr) {
+				t.Errorf("\n%s\nExecContext(...): error = %v, want %v", tc.reason, err, tc.execErr)
+			}
+			if diff := cmp.Diff(tc.want, sink.events, cmpopts.IgnoreFields(Event{}, "Time")); diff != "" {
+				t.Errorf("\n%s\nExecContext(...): -want events, +got events:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestFileSink(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	sink, err := NewFileSink(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	events := []Event{
+		{Resource: "a", StatementClass: "CREATE USER", Outcome: OutcomeSuccess},
+		{Resource: "b", StatementClass: "DROP ROLE", Outcome: OutcomeError, Error: "boom"},
+	}
+	for _, e := range events {
+		if err := sink.Write(context.Background(), e); err != nil {
+			t.Fatalf("Write(...): %v", err)
+		}
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close() //nolint:errcheck
+	var got []Event
+	for s := bufio.NewScanner(f); s.Scan(); {
+		var e Event
+		if err := json.Unmarshal(s.Bytes(), &e); err != nil {
+			t.Fatalf("line %q: %v", s.Text(), err)
+		}
+		got = append(got, e)
+	}
+	if diff := cmp.Diff(events, got); diff != "" {
+		t.Errorf("-want events, +got events:\n%s", diff)
+	}
+}
+
+func TestHTTPSink(t *testing.T) {
+	cases := map[string]struct {
+		reason  string
+		status  int
+		wantErr bool
+	}{
+		"Accepted": {
+			reason: "An event accepted by the endpoint should be written",
+			status: http.StatusAccepted,
+		},
+		"Rejected": {
+			reason:  "An event rejected by the endpoint should return an error",
+			status:  http.StatusInternalServerError,
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var got Event
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				b, _ := io.ReadAll(r.Body)
+				_ = json.Unmarshal(b, &got)
+				w.WriteHeader(tc.status)
+			}))
+			defer srv.Close()
+
+			e := Event{Resource: "a", StatementClass: "GRANT", Outcome: OutcomeSuccess}
+			err := NewHTTPSink(srv.URL).Write(context.Background(), e)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("\n%s\nWrite(...): error = %v, wantErr %v", tc.reason, err, tc.wantErr)
+			}
+			if diff := cmp.Diff(e, got); diff != "" {
+				t.Errorf("\n%s\nWrite(...): -want posted event, +got posted event:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2026 SAP SE or an SAP affiliate company and contributors.
+*/
+
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+)
+
+const (
+	errOpenFile     = "cannot open audit log file: %w"
+	errPostEvent    = "cannot post audit event: %w"
+	errPostedStatus = "audit endpoint responded with status %s"
+
+	// httpTimeout bounds how long a statement waits for the audit endpoint.
+	httpTimeout = 5 * time.Second
+)
+
+// NewFileSink returns a Sink that appends events as JSON lines to the file at
+// path, creating it if necessary.
+func NewFileSink(path string) (Sink, error) {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600) //nolint:gosec // path is set by the operator
+	if err != nil {
+		return nil, fmt.Errorf(errOpenFile, err)
+	}
+	return &writerSink{w: f}, nil
+}
+
+// writerSink writes events as JSON lines.
+type writerSink struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func (s *writerSink) Write(_ context.Context, e Event) error {
+	b, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, err = s.w.Write(append(b, '\n'))
+	return err
+}
+
+// NewHTTPSink returns a Sink that posts each event as JSON to url.
+func NewHTTPSink(url string) Sink {
+	return &httpSink{url: url, client: &http.Client{Timeout: httpTimeout}}
+}
+
+type httpSink struct {
+	url    string
+	client *http.Client
+}
+
+func (s *httpSink) Write(ctx context.Context, e Event) error {
+	b, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(b))
+	if err != nil {
+		return fmt.Errorf(errPostEvent, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf(errPostEvent, err)
+	}
+	defer resp.Body.Close() //nolint:errcheck
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf(errPostedStatus, resp.Status)
+	}
+	return nil
+}
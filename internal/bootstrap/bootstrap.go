@@ -0,0 +1,181 @@
+/*
+Copyright 2026 SAP SE or an SAP affiliate company and contributors.
+*/
+
+// Package bootstrap creates the technical user of the provider from temporary
+// DBADMIN credentials. The user gets the minimal privileges the controllers
+// need, and its credentials are written to the Secret of the ProviderConfig,
+// so that DBADMIN is not needed afterwards.
+package bootstrap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/password"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/SAP/crossplane-provider-hana/internal/clients/hana/statements"
+	"github.com/SAP/crossplane-provider-hana/internal/clients/xsql"
+)
+
+const (
+	errGeneratePassword = "cannot generate password: %w"
+	errInvalidPrivilege = "invalid system privilege %q"
+	errQueryUser        = "cannot query user %s: %w"
+	errCreateUser       = "cannot create user %s: %w"
+	errGrant            = "cannot grant %s to %s: %w"
+	errWriteSecret      = "cannot write secret %s/%s: %w"
+	errReadPassword     = "cannot read admin password: %w"
+	errNoPassword       = "no admin password: set " + AdminPasswordEnv + " or pass a password file"
+
+	queryUser = "SELECT USER_NAME FROM SYS.USERS WHERE USER_NAME = ?"
+)
+
+// AdminPasswordEnv is the environment variable the password of the DBADMIN
+// credentials is read from if no password file is passed.
+const AdminPasswordEnv = "BOOTSTRAP_ADMIN_PASSWORD"
+
+// Privileges are the system privileges the controllers need besides
+// administering users, which is either USER ADMIN or USERGROUP OPERATOR on
+// the usergroup of the usergroup operator mode.
+var Privileges = []string{"ROLE ADMIN", "CATALOG READ"}
+
+// systemPrivilege matches the names of system privileges, so that additional
+// privileges passed on the command line cannot change the GRANT statement.
+var systemPrivilege = regexp.MustCompile(`^[A-Z][A-Z ]*[A-Z]$`)
+
+// Options customize the technical user.
+type Options struct {
+	// Username of the technical user.
+	Username string
+	// UsergroupOperator is the usergroup the technical user operates in the
+	// usergroup operator mode of the ProviderConfig. The user is granted
+	// USER ADMIN if it is empty.
+	UsergroupOperator string
+	// Privileges are system privileges granted in addition to the ones the
+	// controllers need, e.g. AUDIT ADMIN for AuditPolicies.
+	Privileges []string
+
+	// Endpoint and Port of the HANA database written to the Secret.
+	Endpoint string
+	Port     string
+	// Namespace and Name of the credentials Secret of the ProviderConfig.
+	Namespace string
+	Name      string
+}
+
+// AdminPassword returns the password of the DBADMIN credentials from the file,
+// or from the AdminPasswordEnv environment variable if file is empty. It is
+// never taken from the command line, where process listings would show it.
+// Trailing line breaks of the file are removed.
+func AdminPassword(file string) (string, error) {
+	password := os.Getenv(AdminPasswordEnv)
+	if file != "" {
+		b, err := os.ReadFile(file) //nolint:gosec // G304: the file is passed by the operator running the command
+		if err != nil {
+			return "", fmt.Errorf(errReadPassword, err)
+		}
+		password = strings.TrimRight(string(b), "\r\n")
+	}
+	if password == "" {
+		return "", errors.New(errNoPassword)
+	}
+	return password, nil
+}
+
+// Run creates the technical user with a generated password and writes its
+// credentials Secret. The Secret is written first, so that the password is
+// never lost: if the user cannot be created, running again generates a new
+// one.
+func Run(ctx context.Context, db xsql.DB, kube client.Client, o Options) error {
+	if _, err := Grants(o); err != nil {
+		return err
+	}
+	pw, err := password.Generate()
+	if err != nil {
+		return fmt.Errorf(errGeneratePassword, err)
+	}
+	if err := WriteSecret(ctx, kube, o, pw); err != nil {
+		return err
+	}
+	return CreateUser(ctx, db, o, pw)
+}
+
+// Grants returns what the technical user is granted, as written after GRANT.
+func Grants(o Options) ([]string, error) {
+	grants := []string{"USER ADMIN"}
+	if o.UsergroupOperator != "" {
+		grants = []string{"USERGROUP OPERATOR ON USERGROUP " + statements.Identifier(o.UsergroupOperator)}
+	}
+	grants = append(grants, Privileges...)
+	for _, p := range o.Privileges {
+		if !systemPrivilege.MatchString(p) {
+			return nil, fmt.Errorf(errInvalidPrivilege, p)
+		}
+		grants = append(grants, p)
+	}
+	return grants, nil
+}
+
+// CreateUser creates the technical user with password and grants it the
+// privileges of the options. An existing user gets the new password, so that
+// the Secret matches it, and is granted missing privileges. The password of
+// the technical user never expires, as nobody would change it in time.
+func CreateUser(ctx context.Context, db xsql.DB, o Options, password string) error {
+	grants, err := Grants(o)
+	if err != nil {
+		return err
+	}
+
+	var name string
+	err = xsql.ScanRow(ctx, db.QueryRowContext(ctx, queryUser, o.Username), &name)
+	if err != nil && !xsql.IsNoRows(err) {
+		return fmt.Errorf(errQueryUser, o.Username, err)
+	}
+
+	create := statements.CreateUser(o.Username).Password(password, false).String()
+	if err == nil {
+		create = statements.AlterUser(o.Username).Password(password, false)
+	}
+	for _, query := range []string{create, statements.AlterUser(o.Username).PasswordLifetime(false)} {
+		if _, err := db.ExecContext(ctx, query); err != nil {
+			return fmt.Errorf(errCreateUser, o.Username, err)
+		}
+	}
+
+	for _, grant := range grants {
+		if _, err := db.ExecContext(ctx, statements.Grant(grant, statements.Identifier(o.Username), statements.NoOption)); err != nil {
+			return fmt.Errorf(errGrant, grant, o.Username, err)
+		}
+	}
+	return nil
+}
+
+// WriteSecret creates or updates the credentials Secret of the ProviderConfig
+// with the credentials of the technical user.
+func WriteSecret(ctx context.Context, kube client.Client, o Options, password string) error {
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: o.Namespace, Name: o.Name}}
+	_, err := controllerutil.CreateOrUpdate(ctx, kube, secret, func() error {
+		if secret.Data == nil {
+			secret.Data = map[string][]byte{}
+		}
+		secret.Data[xpv1.ResourceCredentialsSecretEndpointKey] = []byte(o.Endpoint)
+		secret.Data[xpv1.ResourceCredentialsSecretPortKey] = []byte(o.Port)
+		secret.Data[xpv1.ResourceCredentialsSecretUserKey] = []byte(o.Username)
+		secret.Data[xpv1.ResourceCredentialsSecretPasswordKey] = []byte(password)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf(errWriteSecret, o.Namespace, o.Name, err)
+	}
+	return nil
+}
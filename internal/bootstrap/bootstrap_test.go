@@ -0,0 +1,167 @@
+/*
+Copyright 2026 SAP SE or an SAP affiliate company and contributors.
+*/
+
+package bootstrap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	kubefake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/SAP/crossplane-provider-hana/internal/clients/fake"
+)
+
+func TestGrants(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		o      Options
+		want   []string
+		err    error
+	}{
+		"UserAdmin": {
+			reason: "Without usergroup operator the technical user administers all users",
+			o:      Options{Privileges: []string{"AUDIT ADMIN"}},
+			want:   []string{"USER ADMIN", "ROLE ADMIN", "CATALOG READ", "AUDIT ADMIN"},
+		},
+		"UsergroupOperator": {
+			reason: "With usergroup operator the technical user only operates the usergroup",
+			o:      Options{UsergroupOperator: "APP_USERS"},
+			want:   []string{`USERGROUP OPERATOR ON USERGROUP "APP_USERS"`, "ROLE ADMIN", "CATALOG READ"},
+		},
+		"InvalidPrivilege": {
+			reason: "Additional privileges must be plain system privileges",
+			o:      Options{Privileges: []string{"USER ADMIN TO PUBLIC; DROP USER DBADMIN"}},
+			err:    fmt.Errorf(errInvalidPrivilege, "USER ADMIN TO PUBLIC; DROP USER DBADMIN"),
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := Grants(tc.o)
+			if diff := cmp.Diff(tc.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nGrants(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nGrants(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+// TestGoldenSQL records the statements creating the technical user in
+// testdata, so that changes to its privileges show up in review.
+func TestGoldenSQL(t *testing.T) {
+	cases := map[string]Options{
+		"create_user":               {Username: "CROSSPLANE", Privileges: []string{"AUDIT ADMIN"}},
+		"create_usergroup_operator": {Username: "CROSSPLANE", UsergroupOperator: "APP_USERS"},
+	}
+	for name, o := range cases {
+		t.Run(name, func(t *testing.T) {
+			db := &fake.SQLRecorder{}
+			if err := CreateUser(context.Background(), db, o, "Pa$$w0rd"); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			fake.AssertGolden(t, name, db.Statements)
+		})
+	}
+}
+
+func TestCreateUserExisting(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	mock.ExpectQuery("SELECT USER_NAME FROM SYS.USERS").WithArgs("CROSSPLANE").WillReturnRows(sqlmock.NewRows([]string{"USER_NAME"}).AddRow("CROSSPLANE"))
+	mock.ExpectExec(`ALTER USER "CROSSPLANE" PASSWORD`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`ALTER USER "CROSSPLANE" DISABLE PASSWORD LIFETIME`).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`GRANT USER ADMIN TO "CROSSPLANE"`).WillReturnError(errors.New("boom"))
+
+	err := CreateUser(context.Background(), db, Options{Username: "CROSSPLANE"}, "Pa$$w0rd")
+	want := fmt.Errorf(errGrant, "USER ADMIN", "CROSSPLANE", errors.New("boom"))
+	if diff := cmp.Diff(want, err, test.EquateErrors()); diff != "" {
+		t.Errorf("CreateUser(...): -want error, +got error:\n%s\n", diff)
+	}
+}
+
+func TestWriteSecret(t *testing.T) {
+	existing := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "crossplane-system", Name: "default-credentials"},
+		Data: map[string][]byte{
+			xpv1.ResourceCredentialsSecretPasswordKey: []byte("old"),
+			"proxy": []byte("http://proxy:3128"),
+		},
+	}
+	kube := kubefake.NewClientBuilder().WithObjects(existing).Build()
+	o := Options{Username: "CROSSPLANE", Endpoint: "hana.example.com", Port: "443", Namespace: "crossplane-system", Name: "default-credentials"}
+
+	if err := WriteSecret(context.Background(), kube, o, "Pa$$w0rd"); err != nil {
+		t.Fatalf("WriteSecret(...): %v", err)
+	}
+	got := &corev1.Secret{}
+	if err := kube.Get(context.Background(), types.NamespacedName{Namespace: o.Namespace, Name: o.Name}, got); err != nil {
+		t.Fatalf("cannot get secret: %v", err)
+	}
+	want := map[string][]byte{
+		xpv1.ResourceCredentialsSecretEndpointKey: []byte("hana.example.com"),
+		xpv1.ResourceCredentialsSecretPortKey:     []byte("443"),
+		xpv1.ResourceCredentialsSecretUserKey:     []byte("CROSSPLANE"),
+		xpv1.ResourceCredentialsSecretPasswordKey: []byte("Pa$$w0rd"),
+		"proxy": []byte("http://proxy:3128"),
+	}
+	if diff := cmp.Diff(want, got.Data); diff != "" {
+		t.Errorf("WriteSecret(...): -want data, +got data:\n%s\n", diff)
+	}
+}
+
+func TestAdminPassword(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "password")
+	if err := os.WriteFile(file, []byte("from-file\n"), 0o600); err != nil {
+		t.Fatalf("cannot write password file: %v", err)
+	}
+
+	cases := map[string]struct {
+		reason string
+		env    string
+		file   string
+		want   string
+		err    error
+	}{
+		"Env": {
+			reason: "The password should be read from the environment without a password file",
+			env:    "from-env",
+			want:   "from-env",
+		},
+		"File": {
+			reason: "The password file should take precedence, without its trailing line break",
+			env:    "from-env",
+			file:   file,
+			want:   "from-file",
+		},
+		"Missing": {
+			reason: "An error should be returned if no password is set",
+			err:    errors.New(errNoPassword),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv(AdminPasswordEnv, tc.env)
+			got, err := AdminPassword(tc.file)
+			if diff := cmp.Diff(tc.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nAdminPassword(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nAdminPassword(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
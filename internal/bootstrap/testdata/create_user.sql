@@ -0,0 +1,6 @@
+CREATE USER "CROSSPLANE" PASSWORD "Pa$$w0rd" NO FORCE_FIRST_PASSWORD_CHANGE
+ALTER USER "CROSSPLANE" DISABLE PASSWORD LIFETIME
+GRANT USER ADMIN TO "CROSSPLANE"
+GRANT ROLE ADMIN TO "CROSSPLANE"
+GRANT CATALOG READ TO "CROSSPLANE"
+GRANT AUDIT ADMIN TO "CROSSPLANE"
@@ -0,0 +1,5 @@
+CREATE USER "CROSSPLANE" PASSWORD "Pa$$w0rd" NO FORCE_FIRST_PASSWORD_CHANGE
+ALTER USER "CROSSPLANE" DISABLE PASSWORD LIFETIME
+GRANT USERGROUP OPERATOR ON USERGROUP "APP_USERS" TO "CROSSPLANE"
+GRANT ROLE ADMIN TO "CROSSPLANE"
+GRANT CATALOG READ TO "CROSSPLANE"
@@ -0,0 +1,65 @@
+package fake
+
+import (
+	"context"
+	"database/sql"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/go-cmp/cmp"
+)
+
+var updateGolden = flag.Bool("update-golden", false, "Rewrite the golden SQL files with the statements the clients emit.")
+
+// SQLRecorder is a DB that records the statements executed through it. All
+// statements succeed and all queries return no rows.
+type SQLRecorder struct {
+	Statements []string
+}
+
+func (r *SQLRecorder) ExecContext(_ context.Context, query string, _ ...any) (sql.Result, error) {
+	r.Statements = append(r.Statements, query)
+	return sqlmock.NewResult(0, 0), nil
+}
+
+// nolint: contextcheck
+func (r *SQLRecorder) QueryRowContext(_ context.Context, query string, args ...any) *sql.Row {
+	db, mock, _ := sqlmock.New()
+	mock.ExpectQuery("").WillReturnRows(sqlmock.NewRows([]string{"NONE"}))
+	return db.QueryRowContext(context.Background(), query, args...)
+}
+
+func (r *SQLRecorder) QueryContext(_ context.Context, _ string, _ ...any) (*sql.Rows, error) {
+	return MockRowsToSQLRows(sqlmock.NewRows([]string{})), nil
+}
+
+// AssertGolden compares the statements with testdata/<name>.sql of the test
+// package, one statement per line. Run the tests with -update-golden to
+// rewrite the file after an intended change of the generated SQL.
+func AssertGolden(t *testing.T, name string, statements []string) {
+	t.Helper()
+	path := filepath.Join("testdata", name+".sql")
+	got := strings.Join(statements, "\n") + "\n"
+
+	if *updateGolden {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatalf("cannot create golden directory: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(got), 0o600); err != nil {
+			t.Fatalf("cannot write golden file: %v", err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		t.Fatalf("cannot read golden file, run with -update-golden to create it: %v", err)
+	}
+	if diff := cmp.Diff(string(want), got); diff != "" {
+		t.Errorf("%s: statements differ from golden file, run with -update-golden if intended: -want, +got:\n%s", path, diff)
+	}
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2026 SAP SE or an SAP affiliate company and contributors.
+*/
+
+package hana
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/SAP/crossplane-provider-hana/internal/clients/xsql"
+)
+
+var (
+	// ErrMissingPrivilege is returned for capabilities that need a system
+	// privilege the connected user does not hold.
+	ErrMissingPrivilege = errors.New("missing privilege")
+	// ErrMissingService is returned for capabilities that need a service
+	// that is not enabled on the database, e.g. the document store.
+	ErrMissingService = errors.New("missing service")
+)
+
+const (
+	errMissingPrivilege = "%w: managing %s requires %s, which the technical user does not hold"
+	errMissingService   = "%w: managing %s requires the %s service, which is not enabled on the database"
+
+	privilegesQuery = "SELECT DISTINCT PRIVILEGE FROM SYS.EFFECTIVE_PRIVILEGES WHERE USER_NAME = CURRENT_USER AND OBJECT_TYPE = 'SYSTEMPRIVILEGE'"
+	servicesQuery   = "SELECT DISTINCT SERVICE_NAME FROM SYS.M_SERVICES WHERE ACTIVE_STATUS = 'YES'"
+
+	// privilegesTTL is how long probed privileges and services are trusted,
+	// so that privileges granted to the technical user or services enabled
+	// later enable their capabilities without a restart.
+	privilegesTTL = 5 * time.Minute
+)
+
+// A Capability is the management of objects that needs any of a set of
+// system privileges, and possibly a service of the database.
+type Capability struct {
+	name       string
+	privileges []string
+	service    string
+}
+
+func (c Capability) String() string {
+	return c.name
+}
+
+// Capabilities of the technical user.
+var (
+	ManageRoles         = Capability{name: "roles", privileges: []string{"ROLE ADMIN"}}
+	ManageUsergroups    = Capability{name: "usergroups", privileges: []string{"USERGROUP ADMIN"}}
+	ManageSchemas       = Capability{name: "schemas", privileges: []string{"CREATE SCHEMA"}}
+	ManageAuditPolicies = Capability{name: "audit policies", privileges: []string{"AUDIT ADMIN"}}
+	ManageX509Providers = Capability{name: "X.509 providers", privileges: []string{"TRUST ADMIN"}}
+	ManagePSEs          = Capability{name: "PSEs", privileges: []string{"TRUST ADMIN"}}
+	// ManageCollections needs the document store of HANA Cloud. Collections
+	// are created in schemas, so no system privilege is required.
+	ManageCollections = Capability{name: "collections", service: "docstore"}
+)
+
+// probedNames are the names a probe of a connection returned, e.g. its
+// system privileges.
+type probedNames struct {
+	mu       sync.Mutex
+	held     map[string]bool
+	probedAt time.Time
+}
+
+// get returns the names the query returns on db, probing them again once they
+// are older than privilegesTTL. It returns false if they cannot be probed.
+func (p *probedNames) get(ctx context.Context, db xsql.DB, query string) (map[string]bool, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.held != nil && time.Since(p.probedAt) < privilegesTTL {
+		return p.held, true
+	}
+
+	rows, err := db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, false
+	}
+	defer rows.Close() //nolint:errcheck
+	held := map[string]bool{}
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, false
+		}
+		held[name] = true
+	}
+	if rows.Err() != nil {
+		return nil, false
+	}
+	p.held, p.probedAt = held, time.Now()
+	return held, true
+}
+
+// Require returns an error wrapping ErrMissingPrivilege if the user db is
+// connected as holds none of the privileges of the capability, or wrapping
+// ErrMissingService if the service of the capability is not enabled.
+// Capabilities are assumed if the privileges or services cannot be probed,
+// e.g. for connections of other connectors, so the server rejects what the
+// user may not do.
+func Require(ctx context.Context, db xsql.DB, c Capability) error {
+	edb, ok := asEndpointDB(db)
+	if !ok {
+		return nil
+	}
+	if len(c.privileges) > 0 {
+		if held, ok := edb.privileges.get(ctx, edb.DB, privilegesQuery); ok && !slices.ContainsFunc(c.privileges, func(p string) bool { return held[p] }) {
+			return fmt.Errorf(errMissingPrivilege, ErrMissingPrivilege, c, strings.Join(c.privileges, " or "))
+		}
+	}
+	if c.service != "" {
+		if active, ok := edb.services.get(ctx, edb.DB, servicesQuery); ok && !active[c.service] {
+			return fmt.Errorf(errMissingService, ErrMissingService, c, c.service)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,142 @@
+package hana
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/SAP/crossplane-provider-hana/internal/clients/fake"
+)
+
+func TestRequire(t *testing.T) {
+	cases := map[string]struct {
+		reason     string
+		privileges []string
+		queryErr   error
+		want       error
+	}{
+		"Held": {
+			reason:     "A capability should be available if the user holds its privilege",
+			privileges: []string{"CATALOG READ", "ROLE ADMIN"},
+		},
+		"Missing": {
+			reason:     "A capability should be missing if the user does not hold its privilege",
+			privileges: []string{"CATALOG READ"},
+			want:       fmt.Errorf(errMissingPrivilege, ErrMissingPrivilege, ManageRoles, "ROLE ADMIN"),
+		},
+		"NotProbed": {
+			reason:   "A capability should be assumed if the privileges cannot be probed",
+			queryErr: errors.New("boom"),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("sqlmock.New(): %v", err)
+			}
+			q := mock.ExpectQuery("SELECT DISTINCT PRIVILEGE FROM SYS.EFFECTIVE_PRIVILEGES")
+			if tc.queryErr != nil {
+				q.WillReturnError(tc.queryErr)
+			} else {
+				rows := sqlmock.NewRows([]string{"PRIVILEGE"})
+				for _, p := range tc.privileges {
+					rows.AddRow(p)
+				}
+				q.WillReturnRows(rows)
+			}
+
+			edb := &endpointDB{DB: db}
+			got := Require(context.Background(), edb, ManageRoles)
+			if diff := cmp.Diff(tc.want, got, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nRequire(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("\n%s\nRequire(...): %v", tc.reason, err)
+			}
+		})
+	}
+}
+
+func TestRequireCached(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("sqlmock.New(): %v", err)
+	}
+	mock.ExpectQuery("SELECT DISTINCT PRIVILEGE FROM SYS.EFFECTIVE_PRIVILEGES").
+		WillReturnRows(sqlmock.NewRows([]string{"PRIVILEGE"}).AddRow("TRUST ADMIN"))
+
+	edb := &endpointDB{DB: db}
+	for _, c := range []Capability{ManageX509Providers, ManagePSEs, ManageAuditPolicies} {
+		_ = Require(context.Background(), edb, c)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Require(...) should probe the privileges once: %v", err)
+	}
+	if err := Require(context.Background(), edb, ManageAuditPolicies); !errors.Is(err, ErrMissingPrivilege) {
+		t.Errorf("Require(ManageAuditPolicies) = %v, want %v", err, ErrMissingPrivilege)
+	}
+}
+
+func TestRequireOtherConnector(t *testing.T) {
+	if err := Require(context.Background(), fake.MockDB{}, ManageRoles); err != nil {
+		t.Errorf("Require(...) on connections of other connectors: %v", err)
+	}
+}
+
+func TestRequireService(t *testing.T) {
+	cases := map[string]struct {
+		reason   string
+		services []string
+		queryErr error
+		want     error
+	}{
+		"Enabled": {
+			reason:   "A capability should be available if its service is enabled",
+			services: []string{"indexserver", "docstore"},
+		},
+		"Missing": {
+			reason:   "A capability should be missing if its service is not enabled",
+			services: []string{"indexserver"},
+			want:     fmt.Errorf(errMissingService, ErrMissingService, ManageCollections, "docstore"),
+		},
+		"NotProbed": {
+			reason:   "A capability should be assumed if the services cannot be probed",
+			queryErr: errors.New("boom"),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("sqlmock.New(): %v", err)
+			}
+			q := mock.ExpectQuery("SELECT DISTINCT SERVICE_NAME FROM SYS.M_SERVICES")
+			if tc.queryErr != nil {
+				q.WillReturnError(tc.queryErr)
+			} else {
+				rows := sqlmock.NewRows([]string{"SERVICE_NAME"})
+				for _, s := range tc.services {
+					rows.AddRow(s)
+				}
+				q.WillReturnRows(rows)
+			}
+
+			edb := &endpointDB{DB: db}
+			got := Require(context.Background(), edb, ManageCollections)
+			if diff := cmp.Diff(tc.want, got, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nRequire(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("\n%s\nRequire(...): %v", tc.reason, err)
+			}
+		})
+	}
+}
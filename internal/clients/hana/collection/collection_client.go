@@ -0,0 +1,62 @@
+/*
+Copyright 2026 SAP SE or an SAP affiliate company and contributors.
+*/
+
+package collection
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/SAP/crossplane-provider-hana/apis/schema/v1alpha1"
+	"github.com/SAP/crossplane-provider-hana/internal/clients/hana"
+	"github.com/SAP/crossplane-provider-hana/internal/clients/xsql"
+	"github.com/SAP/crossplane-provider-hana/internal/utils"
+)
+
+// CollectionClient defines the interface for collection client operations.
+// The parameters name the schema of the collection in Schema.
+type CollectionClient = hana.QueryClient[v1alpha1.CollectionParameters, v1alpha1.CollectionObservation]
+
+// Client struct holds the connection to the db
+type Client struct {
+	xsql.DB
+}
+
+// New creates a new db client
+func New(db xsql.DB) Client {
+	return Client{
+		DB: db,
+	}
+}
+
+// Read checks the state of the collection
+func (c Client) Read(ctx context.Context, parameters *v1alpha1.CollectionParameters) (*v1alpha1.CollectionObservation, error) {
+	observed := &v1alpha1.CollectionObservation{}
+
+	query := "SELECT SCHEMA_NAME, COLLECTION_NAME FROM SYS.COLLECTIONS WHERE SCHEMA_NAME = ? AND COLLECTION_NAME = ?"
+	err := xsql.ScanRow(ctx, c.QueryRowContext(ctx, query, parameters.Schema, parameters.CollectionName), &observed.Schema, &observed.CollectionName)
+	if xsql.IsNoRows(err) {
+		return observed, nil
+	}
+
+	return observed, err
+}
+
+// Create a new collection
+func (c Client) Create(ctx context.Context, parameters *v1alpha1.CollectionParameters) error {
+	_, err := c.ExecContext(ctx, fmt.Sprintf("CREATE COLLECTION %s", qualifiedName(parameters)))
+	return err
+}
+
+// Delete an existing collection
+func (c Client) Delete(ctx context.Context, parameters *v1alpha1.CollectionParameters) error {
+	_, err := c.ExecContext(ctx, fmt.Sprintf("DROP COLLECTION %s", qualifiedName(parameters)))
+	return err
+}
+
+// qualifiedName returns the quoted name of the collection, qualified by its
+// schema.
+func qualifiedName(parameters *v1alpha1.CollectionParameters) string {
+	return utils.QuoteIdentifier(parameters.Schema) + "." + utils.QuoteIdentifier(parameters.CollectionName)
+}
@@ -0,0 +1,143 @@
+/*
+Copyright 2026 SAP SE or an SAP affiliate company and contributors.
+*/
+
+package collection
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/SAP/crossplane-provider-hana/apis/schema/v1alpha1"
+	"github.com/SAP/crossplane-provider-hana/internal/clients/fake"
+)
+
+func TestRead(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type want struct {
+		observed *v1alpha1.CollectionObservation
+		err      error
+	}
+
+	cases := map[string]struct {
+		reason string
+		rows   *sqlmock.Rows
+		err    error
+		want   want
+	}{
+		"ErrRead": {
+			reason: "Any errors encountered while reading the collection should be returned",
+			err:    errBoom,
+			want:   want{observed: &v1alpha1.CollectionObservation{}, err: errBoom},
+		},
+		"NotFound": {
+			reason: "A missing collection should be observed empty",
+			rows:   sqlmock.NewRows([]string{"SCHEMA_NAME", "COLLECTION_NAME"}),
+			want:   want{observed: &v1alpha1.CollectionObservation{}},
+		},
+		"Success": {
+			reason: "The schema and name of an existing collection should be observed",
+			rows:   sqlmock.NewRows([]string{"SCHEMA_NAME", "COLLECTION_NAME"}).AddRow("APP", "Orders"),
+			want:   want{observed: &v1alpha1.CollectionObservation{Schema: "APP", CollectionName: "Orders"}},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			db := fake.MockDB{
+				MockQueryRowContext: func(ctx context.Context, query string, args ...any) *sql.Row {
+					db, mock, _ := sqlmock.New()
+					q := mock.ExpectQuery("SELECT SCHEMA_NAME, COLLECTION_NAME FROM SYS.COLLECTIONS").WithArgs("APP", "Orders")
+					if tc.err != nil {
+						q.WillReturnError(tc.err)
+					} else {
+						q.WillReturnRows(tc.rows)
+					}
+					return db.QueryRowContext(ctx, query, args...)
+				},
+			}
+			got, err := New(db).Read(context.Background(), &v1alpha1.CollectionParameters{Schema: "APP", CollectionName: "Orders"})
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nRead(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.observed, got); diff != "" {
+				t.Errorf("\n%s\nRead(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCreateDelete(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type want struct {
+		query string
+		err   error
+	}
+
+	cases := map[string]struct {
+		reason     string
+		op         func(Client, *v1alpha1.CollectionParameters) error
+		parameters *v1alpha1.CollectionParameters
+		err        error
+		want       want
+	}{
+		"Create": {
+			reason: "Collections should be created in their schema",
+			op: func(c Client, p *v1alpha1.CollectionParameters) error {
+				return c.Create(context.Background(), p)
+			},
+			parameters: &v1alpha1.CollectionParameters{Schema: "APP", CollectionName: "Orders"},
+			want:       want{query: `CREATE COLLECTION "APP"."Orders"`},
+		},
+		"CreateQuoted": {
+			reason: "Double quotes in the names should be escaped",
+			op: func(c Client, p *v1alpha1.CollectionParameters) error {
+				return c.Create(context.Background(), p)
+			},
+			parameters: &v1alpha1.CollectionParameters{Schema: "APP", CollectionName: `My"Orders`},
+			want:       want{query: `CREATE COLLECTION "APP"."My""Orders"`},
+		},
+		"ErrCreate": {
+			reason: "Any errors encountered while creating the collection should be returned",
+			op: func(c Client, p *v1alpha1.CollectionParameters) error {
+				return c.Create(context.Background(), p)
+			},
+			parameters: &v1alpha1.CollectionParameters{Schema: "APP", CollectionName: "Orders"},
+			err:        errBoom,
+			want:       want{query: `CREATE COLLECTION "APP"."Orders"`, err: errBoom},
+		},
+		"Delete": {
+			reason: "Collections should be dropped from their schema",
+			op: func(c Client, p *v1alpha1.CollectionParameters) error {
+				return c.Delete(context.Background(), p)
+			},
+			parameters: &v1alpha1.CollectionParameters{Schema: "APP", CollectionName: "Orders"},
+			want:       want{query: `DROP COLLECTION "APP"."Orders"`},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var got string
+			db := fake.MockDB{
+				MockExecContext: func(ctx context.Context, query string, args ...any) (sql.Result, error) {
+					got = query
+					return nil, tc.err
+				},
+			}
+			err := tc.op(New(db), tc.parameters)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\n-want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.query, got); diff != "" {
+				t.Errorf("\n%s\n-want query, +got query:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
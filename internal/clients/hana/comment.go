@@ -0,0 +1,63 @@
+package hana
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	apisv1alpha1 "github.com/SAP/crossplane-provider-hana/apis/v1alpha1"
+	"github.com/SAP/crossplane-provider-hana/internal/clients/xsql"
+	"github.com/SAP/crossplane-provider-hana/internal/utils"
+)
+
+// Catalog object types the provider comments on.
+const (
+	CommentOnUser   = "USER"
+	CommentOnRole   = "ROLE"
+	CommentOnSchema = "SCHEMA"
+)
+
+// commentViews are the catalog views holding the comments of each object
+// type, with the column naming the object.
+var commentViews = map[string][2]string{
+	CommentOnUser:   {"SYS.USERS", "USER_NAME"},
+	CommentOnRole:   {"SYS.ROLES", "ROLE_NAME"},
+	CommentOnSchema: {"SYS.SCHEMAS", "SCHEMA_NAME"},
+}
+
+// ManagedComment returns the comment for a catalog object managed by the
+// resource of the given kind and name, or "" if the ProviderConfig does not
+// maintain comments.
+func ManagedComment(cfg *apisv1alpha1.ObjectCommentsConfig, kind, name string) string {
+	if cfg == nil {
+		return ""
+	}
+	comment := fmt.Sprintf("managed by Crossplane, %s %s", kind, name)
+	if cfg.ClusterName != "" {
+		comment += ", cluster " + cfg.ClusterName
+	}
+	return comment
+}
+
+// ReadComment returns the comment of a catalog object, or "" if it has none.
+func ReadComment(ctx context.Context, db xsql.DB, objectType, name string) (string, error) {
+	v, ok := commentViews[objectType]
+	if !ok {
+		return "", fmt.Errorf("cannot comment on %s", objectType)
+	}
+
+	var comment sql.NullString
+	query := fmt.Sprintf("SELECT COMMENTS FROM %s WHERE %s = ?", v[0], v[1])
+	if err := xsql.ScanRow(ctx, db.QueryRowContext(ctx, query, name), &comment); err != nil && !xsql.IsNoRows(err) {
+		return "", err
+	}
+	return comment.String, nil
+}
+
+// SetComment sets the comment of a catalog object. The name must already be
+// quoted.
+func SetComment(ctx context.Context, db xsql.DB, objectType, quotedName, comment string) error {
+	query := fmt.Sprintf("COMMENT ON %s %s IS '%s'", objectType, quotedName, utils.EscapeSingleQuotes(comment))
+	_, err := db.ExecContext(ctx, query)
+	return err
+}
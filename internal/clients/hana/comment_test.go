@@ -0,0 +1,85 @@
+package hana
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/go-cmp/cmp"
+
+	apisv1alpha1 "github.com/SAP/crossplane-provider-hana/apis/v1alpha1"
+	"github.com/SAP/crossplane-provider-hana/internal/clients/fake"
+)
+
+func TestManagedComment(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		cfg    *apisv1alpha1.ObjectCommentsConfig
+		want   string
+	}{
+		"Disabled": {
+			reason: "No comment should be maintained without a configuration",
+		},
+		"NoCluster": {
+			reason: "The comment should name the managing resource",
+			cfg:    &apisv1alpha1.ObjectCommentsConfig{},
+			want:   "managed by Crossplane, User demo-user",
+		},
+		"Cluster": {
+			reason: "The comment should name the cluster if it is configured",
+			cfg:    &apisv1alpha1.ObjectCommentsConfig{ClusterName: "prod-eu10"},
+			want:   "managed by Crossplane, User demo-user, cluster prod-eu10",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, ManagedComment(tc.cfg, "User", "demo-user")); diff != "" {
+				t.Errorf("\n%s\nManagedComment(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+// nolint: contextcheck
+func TestReadComment(t *testing.T) {
+	db := fake.MockDB{
+		MockQueryRowContext: func(ctx context.Context, query string, args ...any) *sql.Row {
+			db, mock, _ := sqlmock.New()
+			if query != "SELECT COMMENTS FROM SYS.SCHEMAS WHERE SCHEMA_NAME = ?" {
+				mock.ExpectQuery("SELECT").WillReturnError(errors.New("unexpected query"))
+			} else {
+				mock.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows([]string{"COMMENTS"}).AddRow("managed by Crossplane"))
+			}
+			return db.QueryRowContext(context.Background(), "SELECT")
+		},
+	}
+
+	got, err := ReadComment(context.Background(), db, CommentOnSchema, "DEMO_SCHEMA")
+	if err != nil {
+		t.Fatalf("ReadComment(...): unexpected error: %v", err)
+	}
+	if diff := cmp.Diff("managed by Crossplane", got); diff != "" {
+		t.Errorf("ReadComment(...): -want, +got:\n%s\n", diff)
+	}
+}
+
+func TestSetComment(t *testing.T) {
+	var got string
+	db := fake.MockDB{
+		MockExecContext: func(ctx context.Context, query string, args ...any) (sql.Result, error) {
+			got = query
+			return nil, nil
+		},
+	}
+
+	if err := SetComment(context.Background(), db, CommentOnUser, `"DEMO_USER"`, "managed by Crossplane, User o'brien"); err != nil {
+		t.Fatalf("SetComment(...): unexpected error: %v", err)
+	}
+	want := `COMMENT ON USER "DEMO_USER" IS 'managed by Crossplane, User o''brien'`
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("SetComment(...): -want, +got:\n%s\n", diff)
+	}
+}
@@ -0,0 +1,71 @@
+package hana
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apisv1alpha1 "github.com/SAP/crossplane-provider-hana/apis/v1alpha1"
+)
+
+// Errors of getting the credentials of a ProviderConfig.
+const (
+	ErrNoSecretRef = "ProviderConfig does not reference a credentials Secret"
+	ErrGetSecret   = "cannot get credentials Secret: %w"
+
+	errExtractCredentials = "cannot extract credentials from %s: %w"
+	errParseCredentials   = "cannot parse credentials: expected a JSON object of connection credentials: %w"
+	errCredentialsSource  = "credentials source %s is not supported"
+)
+
+// Credentials returns the connection credentials of a ProviderConfig from
+// its credentials source. A connection Secret provides its keys. An
+// environment variable or file provides them as a JSON object, or holds the
+// service key itself if the ProviderConfig names a service key.
+func Credentials(ctx context.Context, kube client.Client, pc *apisv1alpha1.ProviderConfig) (map[string][]byte, error) {
+	cs := pc.Spec.Credentials
+	switch cs.Source {
+	case xpv1.CredentialsSourceSecret, "":
+		ref := cs.ConnectionSecretRef
+		if ref == nil {
+			return nil, errors.New(ErrNoSecretRef)
+		}
+		s := &corev1.Secret{}
+		if err := kube.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, s); err != nil {
+			return nil, fmt.Errorf(ErrGetSecret, err)
+		}
+		return s.Data, nil
+	case xpv1.CredentialsSourceEnvironment, xpv1.CredentialsSourceFilesystem:
+		data, err := resource.CommonCredentialExtractor(ctx, cs.Source, kube, xpv1.CommonCredentialSelectors{Env: cs.Env, Fs: cs.Fs})
+		if err != nil {
+			return nil, fmt.Errorf(errExtractCredentials, cs.Source, err)
+		}
+		return parseCredentials(data, cs.ServiceKey)
+	default:
+		return nil, fmt.Errorf(errCredentialsSource, cs.Source)
+	}
+}
+
+// parseCredentials returns the connection credentials in data, which is the
+// service key under serviceKey if it is set.
+func parseCredentials(data []byte, serviceKey string) (map[string][]byte, error) {
+	if serviceKey != "" {
+		return map[string][]byte{serviceKey: data}, nil
+	}
+	values := map[string]string{}
+	if err := json.Unmarshal(data, &values); err != nil {
+		return nil, fmt.Errorf(errParseCredentials, err)
+	}
+	creds := make(map[string][]byte, len(values))
+	for k, v := range values {
+		creds[k] = []byte(v)
+	}
+	return creds, nil
+}
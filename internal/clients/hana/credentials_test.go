@@ -0,0 +1,103 @@
+package hana
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apisv1alpha1 "github.com/SAP/crossplane-provider-hana/apis/v1alpha1"
+)
+
+func TestCredentials(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	path := filepath.Join(t.TempDir(), "credentials")
+	if err := os.WriteFile(path, []byte(`{"endpoint":"hana.example.com","port":"443","username":"ADMIN","password":"secret"}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("HANA_SERVICE_KEY", `{"host":"hana.example.com","port":443}`)
+	t.Setenv("HANA_CREDENTIALS", "not json")
+
+	cases := map[string]struct {
+		reason  string
+		creds   apisv1alpha1.ProviderCredentials
+		kube    client.Client
+		want    map[string][]byte
+		wantErr error
+	}{
+		"Secret": {
+			reason: "The keys of the connection secret should be returned",
+			creds:  apisv1alpha1.ProviderCredentials{Source: xpv1.CredentialsSourceSecret, ConnectionSecretRef: &xpv1.SecretReference{Name: "admin"}},
+			kube: &test.MockClient{
+				MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+					obj.(*corev1.Secret).Data = map[string][]byte{"endpoint": []byte("hana.example.com")}
+					return nil
+				}),
+			},
+			want: map[string][]byte{"endpoint": []byte("hana.example.com")},
+		},
+		"NoSecretRef": {
+			reason:  "A Secret source without a connection secret should be an error",
+			creds:   apisv1alpha1.ProviderCredentials{Source: xpv1.CredentialsSourceSecret},
+			wantErr: errors.New(ErrNoSecretRef),
+		},
+		"ErrGetSecret": {
+			reason:  "Any errors encountered while getting the connection secret should be returned",
+			creds:   apisv1alpha1.ProviderCredentials{Source: xpv1.CredentialsSourceSecret, ConnectionSecretRef: &xpv1.SecretReference{Name: "admin"}},
+			kube:    &test.MockClient{MockGet: test.NewMockGetFn(errBoom)},
+			wantErr: fmt.Errorf(ErrGetSecret, errBoom),
+		},
+		"Filesystem": {
+			reason: "The JSON object of a file should be returned as credentials",
+			creds:  apisv1alpha1.ProviderCredentials{Source: xpv1.CredentialsSourceFilesystem, Fs: &xpv1.FsSelector{Path: path}},
+			want: map[string][]byte{
+				xpv1.ResourceCredentialsSecretEndpointKey: []byte("hana.example.com"),
+				xpv1.ResourceCredentialsSecretPortKey:     []byte("443"),
+				xpv1.ResourceCredentialsSecretUserKey:     []byte("ADMIN"),
+				xpv1.ResourceCredentialsSecretPasswordKey: []byte("secret"),
+			},
+		},
+		"EnvironmentServiceKey": {
+			reason: "An environment variable should be returned under the service key if one is named",
+			creds:  apisv1alpha1.ProviderCredentials{Source: xpv1.CredentialsSourceEnvironment, Env: &xpv1.EnvSelector{Name: "HANA_SERVICE_KEY"}, ServiceKey: "key"},
+			want:   map[string][]byte{"key": []byte(`{"host":"hana.example.com","port":443}`)},
+		},
+		"ErrParse": {
+			reason:  "Credentials that are no JSON object should be an error",
+			creds:   apisv1alpha1.ProviderCredentials{Source: xpv1.CredentialsSourceEnvironment, Env: &xpv1.EnvSelector{Name: "HANA_CREDENTIALS"}},
+			wantErr: fmt.Errorf(errParseCredentials, errors.New("invalid character 'o' in literal null (expecting 'u')")),
+		},
+		"ErrNoSelector": {
+			reason:  "A Filesystem source without a path should be an error",
+			creds:   apisv1alpha1.ProviderCredentials{Source: xpv1.CredentialsSourceFilesystem},
+			wantErr: fmt.Errorf(errExtractCredentials, xpv1.CredentialsSourceFilesystem, errors.New("cannot extract from filesystem when no path specified")),
+		},
+		"InjectedIdentity": {
+			reason:  "Sources without credentials for HANA should be an error",
+			creds:   apisv1alpha1.ProviderCredentials{Source: xpv1.CredentialsSourceInjectedIdentity},
+			wantErr: fmt.Errorf(errCredentialsSource, xpv1.CredentialsSourceInjectedIdentity),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			pc := &apisv1alpha1.ProviderConfig{Spec: apisv1alpha1.ProviderConfigSpec{Credentials: tc.creds}}
+			got, err := Credentials(context.Background(), tc.kube, pc)
+			if diff := cmp.Diff(tc.wantErr, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nCredentials(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nCredentials(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -33,7 +33,7 @@ func (c Client) Read(ctx context.Context, parameters *v1alpha1.DbSchemaParameter
 	}
 
 	query := "SELECT SCHEMA_NAME, SCHEMA_OWNER FROM SYS.SCHEMAS WHERE SCHEMA_NAME = ?"
-	err := c.QueryRowContext(ctx, query, parameters.SchemaName).Scan(&observed.SchemaName, &observed.Owner)
+	err := xsql.ScanRow(ctx, c.QueryRowContext(ctx, query, parameters.SchemaName), &observed.SchemaName, &observed.Owner)
 	if xsql.IsNoRows(err) {
 		return observed, nil
 	}
@@ -0,0 +1,58 @@
+package hana
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+// Option configures a HANA Connector.
+type Option func(*hanaDB)
+
+// WithDrain lets statements keep running for up to timeout after shutdown is
+// cancelled, so that the SQL sequences of reconciles in progress, e.g. a
+// CREATE USER followed by its grants, complete instead of being killed midway.
+// Statements are cancelled once the timeout elapsed.
+func WithDrain(shutdown context.Context, timeout time.Duration) Option {
+	return func(h *hanaDB) {
+		abort, cancel := context.WithCancel(context.Background())
+		context.AfterFunc(shutdown, func() {
+			h.logger.Info("Draining SQL statements in progress", "timeout", timeout)
+			time.AfterFunc(timeout, cancel)
+		})
+		h.abort = abort
+	}
+}
+
+// ExecContext executes a statement. Unless the pool drains on shutdown, the
+// statement is cancelled with ctx.
+func (e *endpointDB) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	ctx, cancel := drainContext(ctx, e.abort)
+	defer cancel()
+	return e.DB.ExecContext(ctx, query, args...)
+}
+
+// drainContext returns a context that keeps the values and the deadline of
+// ctx, but is cancelled with abort instead of ctx. Queries are not drained,
+// only statements changing the database are worth completing.
+func drainContext(ctx, abort context.Context) (context.Context, context.CancelFunc) {
+	if abort == nil {
+		return ctx, func() {}
+	}
+
+	out := context.WithoutCancel(ctx)
+	cancelDeadline := context.CancelFunc(func() {})
+	if deadline, ok := ctx.Deadline(); ok {
+		out, cancelDeadline = context.WithDeadline(out, deadline)
+	}
+	out, cancel := context.WithCancel(out)
+	if abort.Err() != nil {
+		cancel()
+	}
+	stop := context.AfterFunc(abort, cancel)
+	return out, func() {
+		stop()
+		cancel()
+		cancelDeadline()
+	}
+}
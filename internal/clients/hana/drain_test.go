@@ -0,0 +1,61 @@
+package hana
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestExecContextDrain(t *testing.T) {
+	cases := map[string]struct {
+		reason  string
+		drain   bool
+		aborted bool
+		want    error
+	}{
+		"NotDraining": {
+			reason: "Without draining, statements should be cancelled with their context",
+			want:   context.Canceled,
+		},
+		"Draining": {
+			reason: "While draining, statements should run although their context was cancelled",
+			drain:  true,
+		},
+		"Aborted": {
+			reason:  "Statements should be cancelled once the drain timeout elapsed",
+			drain:   true,
+			aborted: true,
+			want:    context.Canceled,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			if err != nil {
+				t.Fatalf("sqlmock.New(): %v", err)
+			}
+			mock.ExpectExec("GRANT").WillReturnResult(sqlmock.NewResult(0, 0))
+
+			edb := &endpointDB{DB: db}
+			if tc.drain {
+				abort, cancel := context.WithCancel(context.Background())
+				defer cancel()
+				if tc.aborted {
+					cancel()
+				}
+				edb.abort = abort
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+			_, err = edb.ExecContext(ctx, `GRANT SELECT ON SCHEMA "DEMO" TO "DEMO_USER"`)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nExecContext(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package driftreport
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/SAP/crossplane-provider-hana/internal/clients/hana/user"
+	"github.com/SAP/crossplane-provider-hana/internal/clients/xsql"
+)
+
+const (
+	// ErrListUsers is returned when the catalog users cannot be listed.
+	ErrListUsers = "cannot list users: %w"
+	// ErrListTaggedUsers is returned when the tagged users cannot be listed.
+	ErrListTaggedUsers = "cannot list tagged users: %w"
+)
+
+// DriftReportClient defines the interface for drift report client operations
+type DriftReportClient interface {
+	ListUsers(ctx context.Context, pattern string) ([]string, error)
+	ListTaggedUsers(ctx context.Context) (map[string]string, error)
+}
+
+// Client struct holds the connection to the db
+type Client struct {
+	xsql.DB
+}
+
+// New creates a new db client
+func New(db xsql.DB) Client {
+	return Client{
+		DB: db,
+	}
+}
+
+// ListUsers returns the names of the catalog users matching the SQL LIKE
+// pattern.
+func (c Client) ListUsers(ctx context.Context, pattern string) ([]string, error) {
+	query := "SELECT USER_NAME FROM SYS.USERS WHERE USER_NAME LIKE ?"
+	rows, err := c.QueryContext(ctx, query, pattern)
+	if err != nil {
+		return nil, fmt.Errorf(ErrListUsers, err)
+	}
+	defer rows.Close() //nolint:errcheck
+
+	var users []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, fmt.Errorf(ErrListUsers, err)
+		}
+		users = append(users, name)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf(ErrListUsers, err)
+	}
+	return users, nil
+}
+
+// ListTaggedUsers returns the catalog users the provider tagged with a User,
+// mapped to the name of the User.
+func (c Client) ListTaggedUsers(ctx context.Context) (map[string]string, error) {
+	query := "SELECT USER_NAME, VALUE FROM SYS.USER_PARAMETERS WHERE PARAMETER = ?"
+	rows, err := c.QueryContext(ctx, query, user.ResourceParameter)
+	if err != nil {
+		return nil, fmt.Errorf(ErrListTaggedUsers, err)
+	}
+	defer rows.Close() //nolint:errcheck
+
+	users := map[string]string{}
+	for rows.Next() {
+		var name, resource string
+		if err := rows.Scan(&name, &resource); err != nil {
+			return nil, fmt.Errorf(ErrListTaggedUsers, err)
+		}
+		users[name] = resource
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf(ErrListTaggedUsers, err)
+	}
+	return users, nil
+}
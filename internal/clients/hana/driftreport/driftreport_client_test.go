@@ -0,0 +1,99 @@
+package driftreport
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/SAP/crossplane-provider-hana/internal/clients/fake"
+)
+
+// nolint: contextcheck
+func TestListUsers(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type want struct {
+		users []string
+		err   error
+	}
+
+	cases := map[string]struct {
+		reason string
+		db     fake.MockDB
+		want   want
+	}{
+		"ErrList": {
+			reason: "Any errors encountered while listing users should be returned",
+			db: fake.MockDB{
+				MockQueryContext: func(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+					return nil, errBoom
+				},
+			},
+			want: want{
+				err: fmt.Errorf(ErrListUsers, errBoom),
+			},
+		},
+		"Success": {
+			reason: "The names of the matching users should be returned",
+			db: fake.MockDB{
+				MockQueryContext: func(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+					if len(args) != 1 || args[0] != "APP_%" {
+						return nil, errors.New("unexpected pattern")
+					}
+					db, mock, _ := sqlmock.New()
+					rows := sqlmock.NewRows([]string{"USER_NAME"}).
+						AddRow("APP_ONE").
+						AddRow("APP_TWO")
+					mock.ExpectQuery("SELECT").WillReturnRows(rows)
+					return db.QueryContext(context.Background(), "SELECT")
+				},
+			},
+			want: want{
+				users: []string{"APP_ONE", "APP_TWO"},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			c := New(tc.db)
+			got, err := c.ListUsers(context.Background(), "APP_%")
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nc.ListUsers(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.users, got); diff != "" {
+				t.Errorf("\n%s\nc.ListUsers(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+// nolint: contextcheck
+func TestListTaggedUsers(t *testing.T) {
+	db := fake.MockDB{
+		MockQueryContext: func(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+			if len(args) != 1 || args[0] != "CROSSPLANE_RESOURCE" {
+				return nil, errors.New("unexpected parameter")
+			}
+			db, mock, _ := sqlmock.New()
+			rows := sqlmock.NewRows([]string{"USER_NAME", "VALUE"}).
+				AddRow("APP_ONE", "app-one")
+			mock.ExpectQuery("SELECT").WillReturnRows(rows)
+			return db.QueryContext(context.Background(), "SELECT")
+		},
+	}
+
+	got, err := New(db).ListTaggedUsers(context.Background())
+	if err != nil {
+		t.Fatalf("c.ListTaggedUsers(...): unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(map[string]string{"APP_ONE": "app-one"}, got); diff != "" {
+		t.Errorf("c.ListTaggedUsers(...): -want, +got:\n%s\n", diff)
+	}
+}
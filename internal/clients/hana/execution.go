@@ -0,0 +1,56 @@
+package hana
+
+import (
+	"context"
+	"fmt"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// CredentialsKeyExecutionUser marks connection credentials of an execution
+// user. Execution users get a smaller connection pool than the technical user
+// of the ProviderConfig, as only the resources naming them connect as them.
+const CredentialsKeyExecutionUser = "executionUser"
+
+// Connection pool limits for execution users.
+const (
+	executionUserMaxOpenConns = 2
+	executionUserMaxIdleConns = 1
+)
+
+const (
+	errGetExecutionUserSecret   = "cannot get execution user secret: %w"
+	errExecutionUserKeyNotFound = "key %s not found in execution user secret %s/%s"
+)
+
+// ExecutionCredentials returns the connection credentials with the username
+// and password replaced by those of the execution user secret. The supplied
+// credentials are returned unchanged if no secret is referenced, and are not
+// modified otherwise.
+func ExecutionCredentials(ctx context.Context, kube client.Client, ref *xpv1.SecretReference, creds map[string][]byte) (map[string][]byte, error) {
+	if ref == nil {
+		return creds, nil
+	}
+
+	s := &corev1.Secret{}
+	if err := kube.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, s); err != nil {
+		return nil, fmt.Errorf(errGetExecutionUserSecret, err)
+	}
+
+	res := make(map[string][]byte, len(creds)+1)
+	for k, v := range creds {
+		res[k] = v
+	}
+	for _, k := range []string{xpv1.ResourceCredentialsSecretUserKey, xpv1.ResourceCredentialsSecretPasswordKey} {
+		v, ok := s.Data[k]
+		if !ok {
+			return nil, fmt.Errorf(errExecutionUserKeyNotFound, k, ref.Namespace, ref.Name)
+		}
+		res[k] = v
+	}
+	res[CredentialsKeyExecutionUser] = []byte("true")
+	return res, nil
+}
@@ -0,0 +1,86 @@
+package hana
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestExecutionCredentials(t *testing.T) {
+	creds := map[string][]byte{
+		xpv1.ResourceCredentialsSecretEndpointKey: []byte("hana.example.com"),
+		xpv1.ResourceCredentialsSecretUserKey:     []byte("ADMIN"),
+		xpv1.ResourceCredentialsSecretPasswordKey: []byte("admin-password"),
+	}
+	ref := &xpv1.SecretReference{Name: "schema-owner", Namespace: "default"}
+
+	cases := map[string]struct {
+		reason  string
+		ref     *xpv1.SecretReference
+		kube    client.Client
+		want    map[string][]byte
+		wantErr bool
+	}{
+		"NoExecutionUser": {
+			reason: "Credentials should be returned unchanged without an execution user",
+			want:   creds,
+		},
+		"ExecutionUser": {
+			reason: "The username and password of the execution user should replace those of the credentials",
+			ref:    ref,
+			kube: &test.MockClient{
+				MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+					obj.(*corev1.Secret).Data = map[string][]byte{
+						xpv1.ResourceCredentialsSecretUserKey:     []byte("SCHEMA_OWNER"),
+						xpv1.ResourceCredentialsSecretPasswordKey: []byte("owner-password"),
+					}
+					return nil
+				}),
+			},
+			want: map[string][]byte{
+				xpv1.ResourceCredentialsSecretEndpointKey: []byte("hana.example.com"),
+				xpv1.ResourceCredentialsSecretUserKey:     []byte("SCHEMA_OWNER"),
+				xpv1.ResourceCredentialsSecretPasswordKey: []byte("owner-password"),
+				CredentialsKeyExecutionUser:               []byte("true"),
+			},
+		},
+		"ErrGetSecret": {
+			reason:  "An error getting the execution user secret should be returned",
+			ref:     ref,
+			kube:    &test.MockClient{MockGet: test.NewMockGetFn(errors.New("boom"))},
+			wantErr: true,
+		},
+		"ErrPasswordNotFound": {
+			reason: "A missing password in the execution user secret should be an error",
+			ref:    ref,
+			kube: &test.MockClient{
+				MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+					obj.(*corev1.Secret).Data = map[string][]byte{xpv1.ResourceCredentialsSecretUserKey: []byte("SCHEMA_OWNER")}
+					return nil
+				}),
+			},
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := ExecutionCredentials(context.Background(), tc.kube, tc.ref, creds)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("\n%s\nExecutionCredentials(...): want error %t, got %v", tc.reason, tc.wantErr, err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nExecutionCredentials(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			if string(creds[xpv1.ResourceCredentialsSecretUserKey]) != "ADMIN" {
+				t.Errorf("\n%s\nExecutionCredentials(...): supplied credentials were modified", tc.reason)
+			}
+		})
+	}
+}
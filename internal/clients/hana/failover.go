@@ -0,0 +1,93 @@
+package hana
+
+import (
+	"context"
+	"database/sql"
+	"net"
+	"strings"
+	"sync/atomic"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apisv1alpha1 "github.com/SAP/crossplane-provider-hana/apis/v1alpha1"
+	"github.com/SAP/crossplane-provider-hana/internal/clients/xsql"
+)
+
+// endpointDB is a connection pool to one endpoint of a HANA system.
+type endpointDB struct {
+	*sql.DB
+	endpoint string
+	version  string
+	ca       atomic.Pointer[[]byte]
+	// privileges and services are probed on demand, as only some
+	// controllers need them
+	privileges probedNames
+	services   probedNames
+	abort      context.Context
+}
+
+type hostPort struct {
+	host, port string
+}
+
+func (e hostPort) String() string {
+	return net.JoinHostPort(e.host, e.port)
+}
+
+// failoverEndpoints returns the endpoint of the connection credentials
+// followed by the failover endpoints, in the order they are tried.
+func failoverEndpoints(creds map[string][]byte) []hostPort {
+	port := string(creds[xpv1.ResourceCredentialsSecretPortKey])
+	res := []hostPort{{host: string(creds[xpv1.ResourceCredentialsSecretEndpointKey]), port: port}}
+
+	for _, e := range strings.Split(string(creds[CredentialsKeyFailoverEndpoints]), ",") {
+		e = strings.TrimSpace(e)
+		if e == "" {
+			continue
+		}
+		ep := hostPort{host: e, port: port}
+		if host, p, err := net.SplitHostPort(e); err == nil {
+			ep = hostPort{host: host, port: p}
+		}
+		res = append(res, ep)
+	}
+	return res
+}
+
+// asEndpointDB returns the endpointDB db was returned as or wraps. DBs
+// wrapping another DB expose it with an Unwrap method.
+func asEndpointDB(db xsql.DB) (*endpointDB, bool) {
+	for {
+		switch d := db.(type) {
+		case *endpointDB:
+			return d, true
+		case interface{ Unwrap() xsql.DB }:
+			db = d.Unwrap()
+		default:
+			return nil, false
+		}
+	}
+}
+
+// Endpoint returns the host:port db is connected to, or an empty string if
+// db was not returned by a HANA Connector.
+func Endpoint(db xsql.DB) string {
+	if edb, ok := asEndpointDB(db); ok {
+		return edb.endpoint
+	}
+	return ""
+}
+
+// ReportActiveEndpoint records the endpoint db is connected to in the status
+// of a ProviderConfig with failover endpoints.
+func ReportActiveEndpoint(ctx context.Context, kube client.Client, pc *apisv1alpha1.ProviderConfig, db xsql.DB) error {
+	endpoint := Endpoint(db)
+	if len(pc.Spec.FailoverEndpoints) == 0 || endpoint == "" || pc.Status.ActiveEndpoint == endpoint {
+		return nil
+	}
+
+	orig := pc.DeepCopy()
+	pc.Status.ActiveEndpoint = endpoint
+	return kube.Status().Patch(ctx, pc, client.MergeFrom(orig))
+}
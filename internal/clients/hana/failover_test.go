@@ -0,0 +1,126 @@
+package hana
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apisv1alpha1 "github.com/SAP/crossplane-provider-hana/apis/v1alpha1"
+	"github.com/SAP/crossplane-provider-hana/internal/clients/fake"
+	"github.com/SAP/crossplane-provider-hana/internal/clients/xsql"
+)
+
+func TestFailoverEndpoints(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		creds  map[string][]byte
+		want   []string
+	}{
+		"PrimaryOnly": {
+			reason: "Without failover endpoints only the primary should be tried",
+			creds: map[string][]byte{
+				xpv1.ResourceCredentialsSecretEndpointKey: []byte("hana-1"),
+				xpv1.ResourceCredentialsSecretPortKey:     []byte("443"),
+			},
+			want: []string{"hana-1:443"},
+		},
+		"Failover": {
+			reason: "Failover endpoints should follow the primary and default to its port",
+			creds: map[string][]byte{
+				xpv1.ResourceCredentialsSecretEndpointKey: []byte("hana-1"),
+				xpv1.ResourceCredentialsSecretPortKey:     []byte("443"),
+				CredentialsKeyFailoverEndpoints:           []byte("hana-2, hana-3:30015,"),
+			},
+			want: []string{"hana-1:443", "hana-2:443", "hana-3:30015"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var got []string
+			for _, ep := range failoverEndpoints(tc.creds) {
+				got = append(got, ep.String())
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nfailoverEndpoints(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+// wrappedDB wraps a DB like the audit log does.
+type wrappedDB struct {
+	xsql.DB
+}
+
+func (w wrappedDB) Unwrap() xsql.DB { return w.DB }
+
+func TestReportActiveEndpoint(t *testing.T) {
+	cases := map[string]struct {
+		reason    string
+		failover  []string
+		active    string
+		db        xsql.DB
+		wantPatch bool
+	}{
+		"NoFailover": {
+			reason: "Nothing should be reported without failover endpoints",
+			db:     &endpointDB{DB: &sql.DB{}, endpoint: "hana-2:443"},
+		},
+		"NotHANA": {
+			reason:   "Nothing should be reported for connections of other connectors",
+			failover: []string{"hana-2"},
+			db:       fake.MockDB{},
+		},
+		"Unchanged": {
+			reason:   "An unchanged endpoint should not be patched",
+			failover: []string{"hana-2"},
+			active:   "hana-2:443",
+			db:       &endpointDB{DB: &sql.DB{}, endpoint: "hana-2:443"},
+		},
+		"Changed": {
+			reason:    "A changed endpoint should be patched into the status",
+			failover:  []string{"hana-2"},
+			active:    "hana-1:443",
+			db:        &endpointDB{DB: &sql.DB{}, endpoint: "hana-2:443"},
+			wantPatch: true,
+		},
+		"Wrapped": {
+			reason:    "The endpoint of a wrapped connection should be patched into the status",
+			failover:  []string{"hana-2"},
+			active:    "hana-1:443",
+			db:        wrappedDB{DB: &endpointDB{DB: &sql.DB{}, endpoint: "hana-2:443"}},
+			wantPatch: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			patched := false
+			kube := &test.MockClient{
+				MockStatusPatch: func(_ context.Context, obj client.Object, _ client.Patch, _ ...client.SubResourcePatchOption) error {
+					patched = true
+					if diff := cmp.Diff("hana-2:443", obj.(*apisv1alpha1.ProviderConfig).Status.ActiveEndpoint); diff != "" {
+						t.Errorf("\n%s\nReportActiveEndpoint(...): -want, +got:\n%s\n", tc.reason, diff)
+					}
+					return nil
+				},
+			}
+			pc := &apisv1alpha1.ProviderConfig{
+				Spec:   apisv1alpha1.ProviderConfigSpec{FailoverEndpoints: tc.failover},
+				Status: apisv1alpha1.ProviderConfigStatus{ActiveEndpoint: tc.active},
+			}
+			if err := ReportActiveEndpoint(context.Background(), kube, pc, tc.db); err != nil {
+				t.Fatalf("\n%s\nReportActiveEndpoint(...): unexpected error: %v", tc.reason, err)
+			}
+			if patched != tc.wantPatch {
+				t.Errorf("\n%s\nReportActiveEndpoint(...): want patch %t, got %t", tc.reason, tc.wantPatch, patched)
+			}
+		})
+	}
+}
@@ -5,34 +5,44 @@ import (
 	"crypto/rand"
 	"database/sql"
 	"encoding/base64"
+	"errors"
 	"fmt"
 	"net/url"
 	"sync"
 
-	// Blank import as specified by the driver
-	_ "github.com/SAP/go-hdb/driver"
+	"github.com/SAP/go-hdb/driver"
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 	"github.com/crossplane/crossplane-runtime/pkg/logging"
 	"golang.org/x/crypto/argon2"
 
+	apisv1alpha1 "github.com/SAP/crossplane-provider-hana/apis/v1alpha1"
+	"github.com/SAP/crossplane-provider-hana/internal/clients/proxy"
 	"github.com/SAP/crossplane-provider-hana/internal/clients/xsql"
+	"github.com/SAP/crossplane-provider-hana/internal/utils"
 )
 
 type hanaDB struct {
 	dbs    sync.Map
 	logger logging.Logger
 	salt   []byte
+	// abort cancels the statements of a draining pool, nil if the pool
+	// does not drain
+	abort context.Context
 }
 
 // New returns a new Connector backed by a pool of HANA connections.
-func New(logger logging.Logger) xsql.Connector {
+func New(logger logging.Logger, opts ...Option) xsql.Connector {
 	salt := make([]byte, 16)
 	_, _ = rand.Read(salt)
-	return &hanaDB{
+	h := &hanaDB{
 		dbs:    sync.Map{},
 		logger: logger,
 		salt:   salt,
 	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
 }
 
 func (h *hanaDB) Connect(ctx context.Context, creds map[string][]byte) (xsql.DB, error) {
@@ -40,52 +50,102 @@ func (h *hanaDB) Connect(ctx context.Context, creds map[string][]byte) (xsql.DB,
 	port := string(creds[xpv1.ResourceCredentialsSecretPortKey])
 	username := string(creds[xpv1.ResourceCredentialsSecretUserKey])
 	password := string(creds[xpv1.ResourceCredentialsSecretPasswordKey])
-	dsn := DSN(username, password, endpoint, port)
 
-	hashBytes := argon2.IDKey([]byte(dsn), h.salt, 1, 64*1024, 4, 32)
+	// Connections with different TLS, proxy, failover or pool settings must not share a pool
+	key := DSN(username, password, endpoint, port)
+	for _, k := range []string{CredentialsKeyTLSRootCA, CredentialsKeyTLSServerName, CredentialsKeyTLSInsecureSkipVerify, CredentialsKeyTLSMinVersion, CredentialsKeyProxyURL, CredentialsKeyFailoverEndpoints, CredentialsKeyExecutionUser} {
+		key += "\x00" + string(creds[k])
+	}
+	hashBytes := argon2.IDKey([]byte(key), h.salt, 1, 64*1024, 4, 32)
 	dsnHash := base64.RawStdEncoding.EncodeToString(hashBytes)
 
 	if val, ok := h.dbs.Load(dsnHash); ok {
-		if db, ok := val.(*sql.DB); ok {
+		if db, ok := val.(*endpointDB); ok {
 			if err := db.PingContext(ctx); err == nil {
 				return db, nil
 			}
 		}
 	}
 
-	db, err := sql.Open("hdb", dsn)
-	if err != nil {
-		return nil, fmt.Errorf("failed to open HANA DB connection: %w", err)
+	// Fail over to the next endpoint on connection errors, starting with the
+	// primary again once the active endpoint is lost
+	var errs []error
+	for _, ep := range failoverEndpoints(creds) {
+		db, err := h.open(ctx, creds, username, password, ep)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		prev, loaded := h.dbs.Swap(dsnHash, db)
+		if loaded {
+			if pdb, ok := prev.(*endpointDB); ok {
+				go pdb.Close() // nolint:errcheck
+			} else {
+				h.logger.Info("Warning: sync.Map loaded value that is not *endpointDB", "type", fmt.Sprintf("%T", prev))
+			}
+		}
+		return db, nil
 	}
+	return nil, errors.Join(errs...)
+}
 
-	if err := db.PingContext(ctx); err != nil {
-		go db.Close() // nolint:errcheck
-		return nil, fmt.Errorf("failed to ping HANA DB: %w", err)
+// open opens and pings a connection pool to a single endpoint.
+func (h *hanaDB) open(ctx context.Context, creds map[string][]byte, username, password string, ep hostPort) (*endpointDB, error) {
+	tlsCfg, err := tlsConfig(creds, ep.host)
+	if err != nil {
+		return nil, fmt.Errorf("invalid TLS configuration: %w", err)
 	}
 
-	prev, loaded := h.dbs.Swap(dsnHash, db)
-	if loaded {
-		if pdb, ok := prev.(*sql.DB); ok {
-			go pdb.Close() // nolint:errcheck
-		} else {
-			h.logger.Info("Warning: sync.Map loaded value that is not *sql.DB", "type", fmt.Sprintf("%T", prev))
+	connector, err := driver.NewDSNConnector(DSN(username, password, ep.host, ep.port))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open HANA DB connection: %w", err)
+	}
+	// The CA certificate is recorded while connecting, e.g. to publish it
+	// to Users
+	edb := &endpointDB{endpoint: ep.String(), abort: h.abort}
+	tlsCfg.VerifyConnection = edb.recordServerCA
+	connector.SetTLSConfig(tlsCfg)
+	if proxyURL := string(creds[CredentialsKeyProxyURL]); proxyURL != "" {
+		u, err := url.Parse(proxyURL)
+		if err != nil {
+			// the parse error would contain the proxy password
+			return nil, errors.New("invalid proxy URL")
+		}
+		dialer, err := proxy.NewDialer(u)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy configuration: %w", err)
 		}
+		connector.SetDialer(dialer)
+	}
+	db := sql.OpenDB(connector)
+	if len(creds[CredentialsKeyExecutionUser]) > 0 {
+		db.SetMaxOpenConns(executionUserMaxOpenConns)
+		db.SetMaxIdleConns(executionUserMaxIdleConns)
 	}
 
-	return db, nil
+	if err := db.PingContext(ctx); err != nil {
+		go db.Close() // nolint:errcheck
+		return nil, fmt.Errorf("failed to ping HANA DB at %s: %w", ep, err)
+	}
+	// The version selects the SQL dialect of the clients. Statements are
+	// not adapted if it cannot be read.
+	edb.version = queryVersion(ctx, db)
+	edb.DB = db
+	return edb, nil
 }
 
 func (h *hanaDB) Disconnect() error {
 	var wg sync.WaitGroup
 
 	h.dbs.Range(func(_, val any) bool {
-		db, ok := val.(*sql.DB)
+		db, ok := val.(*endpointDB)
 		if ok {
 			wg.Go(func() {
 				_ = db.Close()
 			})
 		} else {
-			h.logger.Info("Warning: sync.Map loaded value that is not *sql.DB", "type", fmt.Sprintf("%T", val))
+			h.logger.Info("Warning: sync.Map loaded value that is not *endpointDB", "type", fmt.Sprintf("%T", val))
 		}
 		return true
 	})
@@ -108,6 +168,17 @@ func DSN(username string, password string, endpoint string, port string) string
 	return u.String()
 }
 
+// FoldIdentifier returns the name HANA stores for an identifier input of a
+// managed resource. Unquoted identifiers are only folded to uppercase if the
+// identifierCase of the ProviderConfig is Upper, otherwise the input is kept
+// as written.
+func FoldIdentifier(identifierCase, identifier string) string {
+	if identifierCase != apisv1alpha1.IdentifierCaseUpper {
+		return identifier
+	}
+	return utils.FoldUnquotedIdentifier(identifier)
+}
+
 // QueryClient defines the base methods for a query client with typed parameters
 // P is the parameters type, O is the observation type
 type QueryClient[P any, O any] interface {
@@ -0,0 +1,72 @@
+/*
+Copyright 2026 SAP SE or an SAP affiliate company and contributors.
+*/
+
+package monitoringuser
+
+import (
+	"context"
+
+	"github.com/SAP/crossplane-provider-hana/apis/admin/v1alpha1"
+	"github.com/SAP/crossplane-provider-hana/internal/clients/hana"
+	"github.com/SAP/crossplane-provider-hana/internal/clients/hana/statements"
+	"github.com/SAP/crossplane-provider-hana/internal/clients/hana/user"
+	"github.com/SAP/crossplane-provider-hana/internal/clients/xsql"
+)
+
+// The monitoring bundle. CATALOG READ shows the catalog and the monitoring
+// views of all users, the MONITORING role grants reading the monitoring
+// views and SELECT on _SYS_STATISTICS reading the alerts and the history of
+// the statistics service.
+const (
+	privilegeCatalogRead = "CATALOG READ"
+	privilegeStatistics  = "SELECT ON SCHEMA _SYS_STATISTICS"
+	roleMonitoring       = "MONITORING"
+)
+
+// A Bundle selects the grants of the monitoring bundle that the HANA version
+// of a database has. The zero Bundle is for an unknown version and selects
+// all of them.
+type Bundle struct {
+	dialect statements.Dialect
+}
+
+// NewBundle returns the monitoring bundle of the HANA version db runs.
+func NewBundle(db xsql.DB) Bundle {
+	return Bundle{dialect: statements.NewDialect(hana.ServerVersion(db))}
+}
+
+// Grants returns the privileges and roles of the monitoring bundle, with the
+// privileges of the statistics service if statistics is set.
+func (b Bundle) Grants(statistics bool) (privileges, roles []string) {
+	privileges = append(privileges, privilegeCatalogRead)
+	if statistics && b.dialect.Supports(statements.StatisticsSchema) {
+		privileges = append(privileges, privilegeStatistics)
+	}
+	if b.dialect.Supports(statements.MonitoringRole) {
+		roles = append(roles, roleMonitoring)
+	}
+	return privileges, roles
+}
+
+// ManagedPrivileges returns all privileges the monitoring bundle may grant.
+// Those no longer desired are revoked, any others are left alone.
+func (b Bundle) ManagedPrivileges() []string {
+	privileges, _ := b.Grants(true)
+	return privileges
+}
+
+// MonitoringUserClient defines the interface for monitoring user client
+// operations. Monitoring users are users, so they are managed through the
+// statements of the user client.
+type MonitoringUserClient interface {
+	Read(ctx context.Context, parameters *v1alpha1.UserParameters, password string) (*v1alpha1.UserObservation, error)
+	Create(ctx context.Context, parameters *v1alpha1.UserParameters, password string, providers []user.ResolvedUserMapping) error
+	Delete(ctx context.Context, parameters *v1alpha1.UserParameters) error
+	UpdatePrivileges(ctx context.Context, grantee string, toGrant, toRevoke []string) error
+	UpdateRoles(ctx context.Context, grantee string, toGrant, toRevoke []string) error
+	UpdateUsergroup(ctx context.Context, username, usergroup string) error
+	UpdatePassword(ctx context.Context, username, password string, forceFirstPasswordChange bool) error
+	UpdatePasswordLifetimeCheck(ctx context.Context, username string, isPasswordLifetimeCheckEnabled bool) error
+	GetDefaultSchema() string
+}
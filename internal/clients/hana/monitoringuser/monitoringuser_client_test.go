@@ -0,0 +1,110 @@
+/*
+Copyright 2026 SAP SE or an SAP affiliate company and contributors.
+*/
+
+package monitoringuser
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/SAP/crossplane-provider-hana/internal/clients/hana/statements"
+)
+
+func TestBundleGrants(t *testing.T) {
+	type want struct {
+		privileges []string
+		roles      []string
+	}
+
+	cases := map[string]struct {
+		reason     string
+		version    string
+		statistics bool
+		want       want
+	}{
+		"Unknown": {
+			reason:     "An unknown version should get the whole bundle",
+			version:    "",
+			statistics: true,
+			want: want{
+				privileges: []string{"CATALOG READ", "SELECT ON SCHEMA _SYS_STATISTICS"},
+				roles:      []string{"MONITORING"},
+			},
+		},
+		"Cloud": {
+			reason:     "HANA Cloud should get the whole bundle",
+			version:    "4.00.000.00.1712345678",
+			statistics: true,
+			want: want{
+				privileges: []string{"CATALOG READ", "SELECT ON SCHEMA _SYS_STATISTICS"},
+				roles:      []string{"MONITORING"},
+			},
+		},
+		"HANA2": {
+			reason:     "HANA 2.0 should get the whole bundle",
+			version:    "2.00.059.00.1636466430",
+			statistics: true,
+			want: want{
+				privileges: []string{"CATALOG READ", "SELECT ON SCHEMA _SYS_STATISTICS"},
+				roles:      []string{"MONITORING"},
+			},
+		},
+		"HANA2WithoutStatistics": {
+			reason:  "The statistics privileges should only be granted if statistics is set",
+			version: "2.00.059.00.1636466430",
+			want: want{
+				privileges: []string{"CATALOG READ"},
+				roles:      []string{"MONITORING"},
+			},
+		},
+		"HANA1": {
+			reason:     "Versions without the MONITORING role and the _SYS_STATISTICS schema should only get CATALOG READ",
+			version:    "1.00.122.00.1500000000",
+			statistics: true,
+			want: want{
+				privileges: []string{"CATALOG READ"},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			b := Bundle{dialect: statements.NewDialect(tc.version)}
+			privileges, roles := b.Grants(tc.statistics)
+			got := want{privileges: privileges, roles: roles}
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("\n%s\nb.Grants(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestBundleManagedPrivileges(t *testing.T) {
+	cases := map[string]struct {
+		reason  string
+		version string
+		want    []string
+	}{
+		"HANA2": {
+			reason:  "The statistics privileges should be managed where the version has them",
+			version: "2.00.059.00.1636466430",
+			want:    []string{"CATALOG READ", "SELECT ON SCHEMA _SYS_STATISTICS"},
+		},
+		"HANA1": {
+			reason:  "Only CATALOG READ should be managed where the version has no _SYS_STATISTICS schema",
+			version: "1.00.122.00.1500000000",
+			want:    []string{"CATALOG READ"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := Bundle{dialect: statements.NewDialect(tc.version)}.ManagedPrivileges()
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nb.ManagedPrivileges(): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
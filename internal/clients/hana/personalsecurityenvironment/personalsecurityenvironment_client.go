@@ -2,12 +2,13 @@ package personalsecurityenvironment
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"fmt"
-	"strconv"
-	"strings"
 
 	"github.com/SAP/crossplane-provider-hana/apis/admin/v1alpha1"
+	"github.com/SAP/crossplane-provider-hana/internal/clients/hana"
+	"github.com/SAP/crossplane-provider-hana/internal/clients/hana/statements"
 	"github.com/SAP/crossplane-provider-hana/internal/clients/xsql"
 )
 
@@ -17,6 +18,26 @@ type PersonalSecurityEnvironmentClient interface {
 	Create(ctx context.Context, parameters *v1alpha1.PersonalSecurityEnvironmentParameters, providerName string) error
 	Delete(ctx context.Context, parameters *v1alpha1.PersonalSecurityEnvironmentParameters) error
 	Update(ctx context.Context, pseName string, toAdd, toRemove []v1alpha1.CertificateRef, providerName string) error
+	AddCRL(ctx context.Context, pseName, crl string) error
+	DropCRL(ctx context.Context, pseName string) error
+	Purposes(ctx context.Context, pseName string) ([]Purpose, error)
+	UnsetPurposes(ctx context.Context, pseName string, purposes []Purpose) error
+}
+
+// Purpose is a purpose set for a PSE, such as X509 for an X.509 provider.
+type Purpose struct {
+	// Purpose is the purpose, such as X509 or REMOTE SOURCE.
+	Purpose string
+	// Object is the provider or remote source the purpose is set for, if any.
+	Object string
+}
+
+// String returns the purpose and its object, e.g. X509 FOR idp.
+func (p Purpose) String() string {
+	if p.Object == "" {
+		return p.Purpose
+	}
+	return p.Purpose + " FOR " + p.Object
 }
 
 const errQueryRow = "error querying row: %w"
@@ -24,12 +45,14 @@ const errQueryRow = "error querying row: %w"
 // Client struct holds the connection to the db
 type Client struct {
 	xsql.DB
+	dialect statements.Dialect
 }
 
 // New creates a new db client
 func New(db xsql.DB) Client {
 	return Client{
-		DB: db,
+		DB:      db,
+		dialect: statements.NewDialect(hana.ServerVersion(db)),
 	}
 }
 
@@ -63,8 +86,7 @@ func (c Client) Read(ctx context.Context, parameters *v1alpha1.PersonalSecurityE
 }
 
 func (c Client) Create(ctx context.Context, parameters *v1alpha1.PersonalSecurityEnvironmentParameters, providerName string) error {
-	createQuery := fmt.Sprintf("CREATE PSE %s", parameters.Name)
-	if _, err := c.ExecContext(ctx, createQuery); err != nil {
+	if _, err := c.ExecContext(ctx, statements.CreatePSE(parameters.Name)); err != nil {
 		return err
 	}
 
@@ -117,44 +139,84 @@ func (c Client) Update(ctx context.Context, pseName string, toAdd, toRemove []v1
 }
 
 func (c Client) Delete(ctx context.Context, parameters *v1alpha1.PersonalSecurityEnvironmentParameters) error {
-	query := fmt.Sprintf("DROP PSE %s", parameters.Name)
-
-	if _, err := c.ExecContext(ctx, query); err != nil {
+	if _, err := c.ExecContext(ctx, statements.DropPSE(parameters.Name)); err != nil {
 		return err
 	}
 
 	return nil
 }
 
+// AddCRL adds the PEM encoded certificate revocation list to the PSE.
+func (c Client) AddCRL(ctx context.Context, pseName, crl string) error {
+	if _, err := c.ExecContext(ctx, statements.AlterPSE(pseName).AddCRL(crl)); err != nil {
+		return fmt.Errorf("failed to add CRL: %w", err)
+	}
+	return nil
+}
+
+// DropCRL drops the certificate revocation list of the PSE.
+func (c Client) DropCRL(ctx context.Context, pseName string) error {
+	if _, err := c.ExecContext(ctx, statements.AlterPSE(pseName).DropCRL()); err != nil {
+		return fmt.Errorf("failed to drop CRL: %w", err)
+	}
+	return nil
+}
+
+// Purposes returns the purposes set for the PSE. HANA refuses to drop a PSE
+// while any of them is set.
+func (c Client) Purposes(ctx context.Context, pseName string) ([]Purpose, error) {
+	rows, err := c.QueryContext(ctx, "SELECT PURPOSE, PURPOSE_OBJECT FROM PSE_PURPOSE_OBJECTS WHERE PSE_NAME = ?", pseName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query purposes: %w", err)
+	}
+	defer rows.Close() //nolint:errcheck
+
+	var purposes []Purpose
+	for rows.Next() {
+		var purpose string
+		var object sql.NullString
+		if err := rows.Scan(&purpose, &object); err != nil {
+			return nil, fmt.Errorf("failed to query purposes: %w", err)
+		}
+		purposes = append(purposes, Purpose{Purpose: purpose, Object: object.String})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to query purposes: %w", err)
+	}
+	return purposes, nil
+}
+
+// UnsetPurposes removes the purposes from the PSE.
+func (c Client) UnsetPurposes(ctx context.Context, pseName string, purposes []Purpose) error {
+	for _, p := range purposes {
+		if _, err := c.ExecContext(ctx, statements.UnsetPSEPurpose(pseName, p.Purpose, p.Object)); err != nil {
+			return fmt.Errorf("failed to unset purpose %s: %w", p, err)
+		}
+	}
+	return nil
+}
+
 func (c Client) setPSEPurpose(ctx context.Context, identifier string, providerName string, ch chan error) {
 	if providerName == "" {
 		ch <- errors.New("provider name is empty")
 		return
 	}
+	if err := c.dialect.Require(statements.X509Providers); err != nil {
+		ch <- err
+		return
+	}
 
-	setPurposeQuery := fmt.Sprintf(
-		"SET PSE %s PURPOSE X509 FOR PROVIDER %s",
-		identifier,
-		providerName,
-	)
-	_, err := c.ExecContext(ctx, setPurposeQuery)
+	_, err := c.ExecContext(ctx, statements.SetPSEPurposeX509(identifier, providerName))
 	ch <- err
 }
 
 func (c Client) updateCertificatesForPSE(ctx context.Context, add bool, pseName string, certRefs []v1alpha1.CertificateRef, ch chan error) {
-	var query string
-
-	if add {
-		query = "ALTER PSE %s ADD CERTIFICATE %s"
-	} else {
-		query = "ALTER PSE %s DROP CERTIFICATE %s"
-	}
-
-	var certNames, certIDs []string
+	var certNames []string
+	var certIDs []int
 	for _, certRef := range certRefs {
 		switch {
 		case certRef.ID != nil:
-			certIDs = append(certIDs, strconv.Itoa(*certRef.ID))
+			certIDs = append(certIDs, *certRef.ID)
 		case certRef.Name != nil:
 			certNames = append(certNames, *certRef.Name)
 		default:
@@ -163,12 +225,18 @@ func (c Client) updateCertificatesForPSE(ctx context.Context, add bool, pseName
 		}
 	}
 
+	alter := statements.AlterPSE(pseName)
+	byIDs, byNames := alter.AddCertificateIDs, alter.AddCertificates
+	if !add {
+		byIDs, byNames = alter.DropCertificateIDs, alter.DropCertificates
+	}
+
 	var queries []string
 	if len(certIDs) > 0 {
-		queries = append(queries, fmt.Sprintf(query, pseName, strings.Join(certIDs, ", ")))
+		queries = append(queries, byIDs(certIDs))
 	}
 	if len(certNames) > 0 {
-		queries = append(queries, fmt.Sprintf(query, pseName, `"`+strings.Join(certNames, `", "`)+`"`))
+		queries = append(queries, byNames(certNames))
 	}
 
 	for _, q := range queries {
@@ -184,7 +252,7 @@ func (c Client) updateCertificatesForPSE(ctx context.Context, add bool, pseName
 func (c Client) selectPSE(ctx context.Context, identifier string, observed *v1alpha1.PersonalSecurityEnvironmentObservation, ch chan error) {
 	selectQuery := "SELECT NAME FROM PSES WHERE NAME = ? AND PURPOSE = 'X509'"
 
-	if err := c.QueryRowContext(ctx, selectQuery, identifier).Scan(&observed.Name); err != nil {
+	if err := xsql.ScanRow(ctx, c.QueryRowContext(ctx, selectQuery, identifier), &observed.Name); err != nil {
 		ch <- fmt.Errorf(errQueryRow, err)
 		return
 	}
@@ -223,7 +291,7 @@ func (c Client) selectPSECertificates(ctx context.Context, identifier string, ob
 
 func (c Client) selectPSEPurpose(ctx context.Context, identifier string, observed *v1alpha1.PersonalSecurityEnvironmentObservation, ch chan error) {
 	psePurposeQuery := "SELECT PURPOSE_OBJECT FROM PSE_PURPOSE_OBJECTS WHERE PSE_NAME = ? AND PURPOSE = 'X509'"
-	if err := c.QueryRowContext(ctx, psePurposeQuery, identifier).Scan(&observed.X509ProviderName); xsql.IsNoRows(err) {
+	if err := xsql.ScanRow(ctx, c.QueryRowContext(ctx, psePurposeQuery, identifier), &observed.X509ProviderName); xsql.IsNoRows(err) {
 		// No provider set
 		observed.X509ProviderName = ""
 		ch <- nil
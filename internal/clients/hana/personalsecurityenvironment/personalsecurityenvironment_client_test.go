@@ -242,7 +242,11 @@ func TestRead(t *testing.T) {
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
 			c := Client{DB: tc.fields.db}
-			got, err := c.Read(tc.args.ctx, tc.args.parameters)
+			ctx := tc.args.ctx
+			if ctx == nil {
+				ctx = context.Background()
+			}
+			got, err := c.Read(ctx, tc.args.parameters)
 			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
 				t.Errorf("\n%s\ne.Read(...): -want error, +got error:\n%s\n", tc.reason, diff)
 			}
@@ -300,7 +304,7 @@ func TestCreate(t *testing.T) {
 			fields: fields{
 				db: fake.MockDB{
 					MockExecContext: func(ctx context.Context, query string, args ...any) (sql.Result, error) {
-						expectedQuery := "CREATE PSE test-pse"
+						expectedQuery := `CREATE PSE "test-pse"`
 						if query != expectedQuery {
 							return nil, fmt.Errorf("unexpected query: got %s, want %s", query, expectedQuery)
 						}
@@ -478,7 +482,7 @@ func TestUpdate(t *testing.T) {
 			fields: fields{
 				db: fake.MockDB{
 					MockExecContext: func(ctx context.Context, query string, args ...any) (sql.Result, error) {
-						expectedQuery := "ALTER PSE test-pse ADD CERTIFICATE 1, 2"
+						expectedQuery := `ALTER PSE "test-pse" ADD CERTIFICATE 1, 2`
 						if query != expectedQuery {
 							return nil, fmt.Errorf("unexpected query: got %s, want %s", query, expectedQuery)
 						}
@@ -502,7 +506,7 @@ func TestUpdate(t *testing.T) {
 			fields: fields{
 				db: fake.MockDB{
 					MockExecContext: func(ctx context.Context, query string, args ...any) (sql.Result, error) {
-						expectedQuery := `ALTER PSE test-pse DROP CERTIFICATE "cert1", "cert2"`
+						expectedQuery := `ALTER PSE "test-pse" DROP CERTIFICATE "cert1", "cert2"`
 						if query != expectedQuery {
 							return nil, fmt.Errorf("unexpected query: got %s, want %s", query, expectedQuery)
 						}
@@ -521,12 +525,36 @@ func TestUpdate(t *testing.T) {
 				err: nil,
 			},
 		},
+		"SuccessQuotedCertificateNames": {
+			reason: "Should take mixed case certificate names verbatim and escape embedded quotes",
+			fields: fields{
+				db: fake.MockDB{
+					MockExecContext: func(ctx context.Context, query string, args ...any) (sql.Result, error) {
+						expectedQuery := `ALTER PSE "Test.Pse" ADD CERTIFICATE "Root-CA", "cert""1"`
+						if query != expectedQuery {
+							return nil, fmt.Errorf("unexpected query: got %s, want %s", query, expectedQuery)
+						}
+						return nil, nil
+					},
+				},
+			},
+			args: args{
+				pseName: "Test.Pse",
+				toAdd: []v1alpha1.CertificateRef{
+					{Name: new("Root-CA")},
+					{Name: new(`cert"1`)},
+				},
+			},
+			want: want{
+				err: nil,
+			},
+		},
 		"SuccessUpdateProvider": {
 			reason: "Should successfully update X509 provider for PersonalSecurityEnvironment",
 			fields: fields{
 				db: fake.MockDB{
 					MockExecContext: func(ctx context.Context, query string, args ...any) (sql.Result, error) {
-						expectedQuery := "SET PSE test-pse PURPOSE X509 FOR PROVIDER new-provider"
+						expectedQuery := `SET PSE "test-pse" PURPOSE X509 FOR PROVIDER "new-provider"`
 						if query != expectedQuery {
 							return nil, fmt.Errorf("unexpected query: got %s, want %s", query, expectedQuery)
 						}
@@ -645,7 +673,7 @@ func TestDelete(t *testing.T) {
 			fields: fields{
 				db: fake.MockDB{
 					MockExecContext: func(ctx context.Context, query string, args ...any) (sql.Result, error) {
-						expectedQuery := "DROP PSE test-pse"
+						expectedQuery := `DROP PSE "test-pse"`
 						if query != expectedQuery {
 							return nil, fmt.Errorf("unexpected query: got %s, want %s", query, expectedQuery)
 						}
@@ -667,7 +695,7 @@ func TestDelete(t *testing.T) {
 			fields: fields{
 				db: fake.MockDB{
 					MockExecContext: func(ctx context.Context, query string, args ...any) (sql.Result, error) {
-						expectedQuery := "DROP PSE complex-pse-name"
+						expectedQuery := `DROP PSE "complex-pse-name"`
 						if query != expectedQuery {
 							return nil, fmt.Errorf("unexpected query: got %s, want %s", query, expectedQuery)
 						}
@@ -684,6 +712,28 @@ func TestDelete(t *testing.T) {
 				err: nil,
 			},
 		},
+		"SuccessQuotedName": {
+			reason: "Should take dots and mixed case verbatim and escape embedded quotes of the name",
+			fields: fields{
+				db: fake.MockDB{
+					MockExecContext: func(ctx context.Context, query string, args ...any) (sql.Result, error) {
+						expectedQuery := `DROP PSE "My.Pse""; DROP USER x"`
+						if query != expectedQuery {
+							return nil, fmt.Errorf("unexpected query: got %s, want %s", query, expectedQuery)
+						}
+						return nil, nil
+					},
+				},
+			},
+			args: args{
+				parameters: &v1alpha1.PersonalSecurityEnvironmentParameters{
+					Name: `My.Pse"; DROP USER x`,
+				},
+			},
+			want: want{
+				err: nil,
+			},
+		},
 	}
 
 	for name, tc := range cases {
@@ -696,3 +746,184 @@ func TestDelete(t *testing.T) {
 		})
 	}
 }
+
+func TestAddCRL(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	cases := map[string]struct {
+		reason  string
+		db      fake.MockDB
+		pseName string
+		crl     string
+		want    error
+	}{
+		"ErrAddCRL": {
+			reason:  "Any errors encountered while adding the CRL should be returned",
+			db:      fake.MockDB{MockExecContext: func(ctx context.Context, query string, args ...any) (sql.Result, error) { return nil, errBoom }},
+			pseName: "test-pse",
+			crl:     "crl",
+			want:    fmt.Errorf("failed to add CRL: %w", errBoom),
+		},
+		"Success": {
+			reason: "The CRL should be added as an escaped string literal",
+			db: fake.MockDB{
+				MockExecContext: func(ctx context.Context, query string, args ...any) (sql.Result, error) {
+					expectedQuery := `ALTER PSE "test-pse" ADD CRL '-----BEGIN X509 CRL-----''x'''`
+					if query != expectedQuery {
+						return nil, fmt.Errorf("unexpected query: got %s, want %s", query, expectedQuery)
+					}
+					return nil, nil
+				},
+			},
+			pseName: "test-pse",
+			crl:     "-----BEGIN X509 CRL-----'x'",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			c := Client{DB: tc.db}
+			err := c.AddCRL(context.Background(), tc.pseName, tc.crl)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nc.AddCRL(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDropCRL(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	cases := map[string]struct {
+		reason  string
+		db      fake.MockDB
+		pseName string
+		want    error
+	}{
+		"ErrDropCRL": {
+			reason:  "Any errors encountered while dropping the CRL should be returned",
+			db:      fake.MockDB{MockExecContext: func(ctx context.Context, query string, args ...any) (sql.Result, error) { return nil, errBoom }},
+			pseName: "test-pse",
+			want:    fmt.Errorf("failed to drop CRL: %w", errBoom),
+		},
+		"Success": {
+			reason: "The CRL of the PSE should be dropped",
+			db: fake.MockDB{
+				MockExecContext: func(ctx context.Context, query string, args ...any) (sql.Result, error) {
+					expectedQuery := `ALTER PSE "test-pse" DROP CRL`
+					if query != expectedQuery {
+						return nil, fmt.Errorf("unexpected query: got %s, want %s", query, expectedQuery)
+					}
+					return nil, nil
+				},
+			},
+			pseName: "test-pse",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			c := Client{DB: tc.db}
+			err := c.DropCRL(context.Background(), tc.pseName)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nc.DropCRL(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestPurposes(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	cases := map[string]struct {
+		reason string
+		db     fake.MockDB
+		want   []Purpose
+		err    error
+	}{
+		"ErrQuery": {
+			reason: "Any errors encountered while querying the purposes should be returned",
+			db: fake.MockDB{
+				MockQueryContext: func(ctx context.Context, query string, args ...any) (*sql.Rows, error) { return nil, errBoom },
+			},
+			err: fmt.Errorf("failed to query purposes: %w", errBoom),
+		},
+		"Success": {
+			reason: "All purposes of the PSE should be returned, with an empty object for purposes without one",
+			db: fake.MockDB{
+				MockQueryContext: func(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+					return fake.MockRowsToSQLRows(sqlmock.NewRows([]string{"PURPOSE", "PURPOSE_OBJECT"}).
+						AddRow("X509", "idp").
+						AddRow("REMOTE SOURCE", "rs").
+						AddRow("SSL", nil)), nil
+				},
+			},
+			want: []Purpose{
+				{Purpose: "X509", Object: "idp"},
+				{Purpose: "REMOTE SOURCE", Object: "rs"},
+				{Purpose: "SSL"},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			c := Client{DB: tc.db}
+			got, err := c.Purposes(context.Background(), "test-pse")
+			if diff := cmp.Diff(tc.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nc.Purposes(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nc.Purposes(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUnsetPurposes(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	cases := map[string]struct {
+		reason   string
+		purposes []Purpose
+		execErr  error
+		want     []string
+		err      error
+	}{
+		"ErrUnset": {
+			reason:   "Any errors encountered while unsetting a purpose should be returned",
+			purposes: []Purpose{{Purpose: "X509", Object: "idp"}, {Purpose: "SSL"}},
+			execErr:  errBoom,
+			want:     []string{`UNSET PSE "test-pse" PURPOSE X509 FOR PROVIDER "idp"`},
+			err:      fmt.Errorf("failed to unset purpose X509 FOR idp: %w", errBoom),
+		},
+		"Success": {
+			reason:   "Each purpose should be unset with its object",
+			purposes: []Purpose{{Purpose: "X509", Object: "idp"}, {Purpose: "REMOTE SOURCE", Object: "rs"}, {Purpose: "SSL"}},
+			want: []string{
+				`UNSET PSE "test-pse" PURPOSE X509 FOR PROVIDER "idp"`,
+				`UNSET PSE "test-pse" PURPOSE REMOTE SOURCE FOR REMOTE SOURCE "rs"`,
+				`UNSET PSE "test-pse" PURPOSE SSL`,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var got []string
+			c := Client{DB: fake.MockDB{
+				MockExecContext: func(ctx context.Context, query string, args ...any) (sql.Result, error) {
+					got = append(got, query)
+					return nil, tc.execErr
+				},
+			}}
+			err := c.UnsetPurposes(context.Background(), "test-pse", tc.purposes)
+			if diff := cmp.Diff(tc.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nc.UnsetPurposes(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nc.UnsetPurposes(...): -want statements, +got statements:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
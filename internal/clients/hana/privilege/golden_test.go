@@ -0,0 +1,53 @@
+package privilege
+
+import (
+	"context"
+	"testing"
+
+	"github.com/SAP/crossplane-provider-hana/internal/clients/fake"
+)
+
+// TestGoldenSQL records the statements emitted for representative specs in
+// testdata, so that changes to the generated SQL show up in review.
+func TestGoldenSQL(t *testing.T) {
+	cases := map[string]func(ctx context.Context, c *PrivilegeClient) error{
+		"grant_privileges": func(ctx context.Context, c *PrivilegeClient) error {
+			return c.GrantPrivileges(ctx, "DEFAULT_SCHEMA", `"DEMO_USER"`, []string{
+				"CATALOG READ",
+				"USER ADMIN WITH ADMIN OPTION",
+				`SELECT ON SCHEMA "APP"`,
+				`INSERT ON SCHEMA "APP"`,
+				`SELECT ON SCHEMA "APP" WITH GRANT OPTION`,
+				`SELECT ON "APP"."ORDERS"`,
+				`UPDATE ON "APP"."ORDERS"`,
+				`SELECT ON "ORDERS"`,
+				"LINKED DATABASE ON REMOTE SOURCE REMOTE",
+				"USERGROUP OPERATOR ON USERGROUP GROUP",
+				"CPU ON PSE MY_PSE",
+			})
+		},
+		"revoke_privileges": func(ctx context.Context, c *PrivilegeClient) error {
+			return c.RevokePrivileges(ctx, "DEFAULT_SCHEMA", `"DEMO_USER"`, []string{
+				"CATALOG READ",
+				`SELECT ON SCHEMA "APP" WITH GRANT OPTION`,
+				`INSERT ON SCHEMA "APP"`,
+			})
+		},
+		"grant_roles": func(ctx context.Context, c *PrivilegeClient) error {
+			return c.GrantRoles(ctx, "DEFAULT_SCHEMA", `"DEMO_USER"`, []string{"MONITORING", `"data::access_g" WITH ADMIN OPTION`, "PUBLIC"})
+		},
+		"revoke_roles": func(ctx context.Context, c *PrivilegeClient) error {
+			return c.RevokeRoles(ctx, "DEFAULT_SCHEMA", `"DEMO_USER"`, []string{"MONITORING", `"data::access_g" WITH ADMIN OPTION`})
+		},
+	}
+
+	for name, emit := range cases {
+		t.Run(name, func(t *testing.T) {
+			db := &fake.SQLRecorder{}
+			if err := emit(context.Background(), &PrivilegeClient{DB: db}); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			fake.AssertGolden(t, name, db.Statements)
+		})
+	}
+}
@@ -0,0 +1,43 @@
+package privilege
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+const errLockGrantee = "cannot lock grants of %s: %w"
+
+// granteeLocks serializes the grants and revokes of each grantee within the
+// provider, as concurrent GRANT and REVOKE statements on the same grantee,
+// e.g. of a User and a UserReplication targeting it, can deadlock in HANA.
+// Only the leader reconciles, so an in-process lock is sufficient.
+var granteeLocks sync.Map // map[string]chan struct{}
+
+// LockGrantee locks the grants and revokes of the grantee until the returned
+// function is called, or returns an error once ctx is done. Hold it for a
+// whole sequence of grants and revokes, so that those of other resources do
+// not interleave with it. The lock is not reentrant.
+func LockGrantee(ctx context.Context, grantee Grantee) (unlock func(), err error) {
+	v, _ := granteeLocks.LoadOrStore(granteeKey(grantee), make(chan struct{}, 1))
+	lock := v.(chan struct{})
+	unlock = func() { <-lock }
+	select {
+	case lock <- struct{}{}:
+		return unlock, nil
+	default:
+	}
+	select {
+	case lock <- struct{}{}:
+		return unlock, nil
+	case <-ctx.Done():
+		return nil, fmt.Errorf(errLockGrantee, grantee, ctx.Err())
+	}
+}
+
+// granteeKey identifies the grantee regardless of whether its name is quoted,
+// e.g. "DEMO_USER" and DEMO_USER.
+func granteeKey(grantee Grantee) string {
+	return strings.ReplaceAll(grantee, `"`, "")
+}
@@ -0,0 +1,34 @@
+package privilege
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestLockGrantee(t *testing.T) {
+	unlock, err := LockGrantee(context.Background(), `"LOCK_USER"`)
+	if err != nil {
+		t.Fatalf("LockGrantee(...): %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if _, err := LockGrantee(ctx, "LOCK_USER"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("LockGrantee(...) of a locked grantee = %v, want %v", err, context.DeadlineExceeded)
+	}
+
+	other, err := LockGrantee(context.Background(), `"OTHER_USER"`)
+	if err != nil {
+		t.Fatalf("LockGrantee(...) of another grantee: %v", err)
+	}
+	other()
+
+	unlock()
+	again, err := LockGrantee(context.Background(), `"LOCK_USER"`)
+	if err != nil {
+		t.Fatalf("LockGrantee(...) after unlock: %v", err)
+	}
+	again()
+}
@@ -0,0 +1,127 @@
+package privilege
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	apisv1alpha1 "github.com/SAP/crossplane-provider-hana/apis/v1alpha1"
+)
+
+// ErrForbiddenGrant is returned when a grant is rejected by the grant policy
+// of the ProviderConfig.
+var ErrForbiddenGrant = errors.New("forbidden by ProviderConfig grant policy")
+
+// CheckGrantPolicy returns an error wrapping ErrForbiddenGrant if any of the
+// given privileges or roles is forbidden by the policy. A nil policy allows
+// every grant.
+func CheckGrantPolicy(policy *apisv1alpha1.GrantPolicy, privilegeStrings, roleStrings []string, defaultSchema DefaultSchema) error {
+	if policy == nil {
+		return nil
+	}
+
+	privileges, err := MatchPrivileges(privilegeStrings, policy.ForbiddenPrivileges, defaultSchema)
+	if err != nil {
+		return err
+	}
+	roles, err := MatchRoles(roleStrings, policy.ForbiddenRoles)
+	if err != nil {
+		return err
+	}
+
+	var forbidden []string
+	if len(privileges) > 0 {
+		forbidden = append(forbidden, "privileges "+strings.Join(privileges, ", "))
+	}
+	if len(roles) > 0 {
+		forbidden = append(forbidden, "roles "+strings.Join(roles, ", "))
+	}
+	if len(forbidden) > 0 {
+		return fmt.Errorf("%w: %s", ErrForbiddenGrant, strings.Join(forbidden, "; "))
+	}
+	return nil
+}
+
+// MatchPrivileges returns the privileges that match an entry of the pattern
+// list. A pattern without a grant or admin option also matches the grantable
+// variant of the privilege.
+func MatchPrivileges(privilegeStrings, patternStrings []string, defaultSchema DefaultSchema) ([]string, error) {
+	if len(privilegeStrings) == 0 || len(patternStrings) == 0 {
+		return nil, nil
+	}
+
+	patterns, err := parsePrivilegeStrings(patternStrings, defaultSchema)
+	if err != nil {
+		return nil, err
+	}
+	privileges, err := parsePrivilegeStrings(privilegeStrings, defaultSchema)
+	if err != nil {
+		return nil, err
+	}
+
+	var res []string
+	for _, priv := range privileges {
+		for _, f := range patterns {
+			if priv.Type == f.Type &&
+				strings.EqualFold(priv.baseString(), f.baseString()) &&
+				(!f.IsGrantable || priv.IsGrantable) {
+				res = append(res, priv.String())
+				break
+			}
+		}
+	}
+	return res, nil
+}
+
+// MatchRoles returns the roles that match an entry of the pattern list, with
+// names compared case-insensitively. A pattern without the admin option also
+// matches the grantable variant of the role.
+func MatchRoles(roleStrings, patternStrings []string) ([]string, error) {
+	if len(roleStrings) == 0 || len(patternStrings) == 0 {
+		return nil, nil
+	}
+
+	patterns := make([]Role, 0, len(patternStrings))
+	for _, pStr := range patternStrings {
+		p, err := parseRoleString(pStr)
+		if err != nil {
+			return nil, err
+		}
+		patterns = append(patterns, p)
+	}
+
+	var res []string
+	for _, rStr := range roleStrings {
+		role, err := parseRoleString(rStr)
+		if err != nil {
+			return nil, err
+		}
+		for _, f := range patterns {
+			if strings.EqualFold(role.clean().quotedName(), f.clean().quotedName()) &&
+				(!f.IsGrantable || role.IsGrantable) {
+				res = append(res, role.clean().String())
+				break
+			}
+		}
+	}
+	return res, nil
+}
+
+// HighRiskGrants returns the privileges and roles that the policy marks as
+// high-risk and that therefore need a second person's approval. A nil policy
+// marks nothing as high-risk.
+func HighRiskGrants(policy *apisv1alpha1.GrantPolicy, privilegeStrings, roleStrings []string, defaultSchema DefaultSchema) ([]string, error) {
+	if policy == nil {
+		return nil, nil
+	}
+
+	privileges, err := MatchPrivileges(privilegeStrings, policy.HighRiskPrivileges, defaultSchema)
+	if err != nil {
+		return nil, err
+	}
+	roles, err := MatchRoles(roleStrings, policy.HighRiskRoles)
+	if err != nil {
+		return nil, err
+	}
+	return append(privileges, roles...), nil
+}
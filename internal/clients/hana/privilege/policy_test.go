@@ -0,0 +1,148 @@
+package privilege
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	apisv1alpha1 "github.com/SAP/crossplane-provider-hana/apis/v1alpha1"
+)
+
+func TestMatchPrivileges(t *testing.T) {
+	cases := map[string]struct {
+		reason     string
+		privileges []string
+		forbidden  []string
+		want       []string
+	}{
+		"NoPolicy": {
+			reason:     "Nothing is forbidden without forbidden entries",
+			privileges: []string{"USER ADMIN"},
+			want:       nil,
+		},
+		"SystemPrivilege": {
+			reason:     "A forbidden system privilege should match case-insensitively",
+			privileges: []string{"user admin", "AUDIT READ"},
+			forbidden:  []string{"USER ADMIN"},
+			want:       []string{"user admin"},
+		},
+		"PlainForbidsGrantable": {
+			reason:     "A forbidden privilege without admin option should also forbid the grantable variant",
+			privileges: []string{"USER ADMIN WITH ADMIN OPTION"},
+			forbidden:  []string{"USER ADMIN"},
+			want:       []string{"USER ADMIN WITH ADMIN OPTION"},
+		},
+		"GrantableOnly": {
+			reason:     "A forbidden privilege with admin option should not forbid the plain variant",
+			privileges: []string{"CATALOG READ", "CATALOG READ WITH ADMIN OPTION"},
+			forbidden:  []string{"CATALOG READ WITH ADMIN OPTION"},
+			want:       []string{"CATALOG READ WITH ADMIN OPTION"},
+		},
+		"SchemaPrivilege": {
+			reason:     "Schema privileges should match on the schema",
+			privileges: []string{"SELECT ON SCHEMA SYS", "SELECT ON SCHEMA OTHER"},
+			forbidden:  []string{`SELECT ON SCHEMA "SYS"`},
+			want:       []string{`SELECT ON SCHEMA "SYS"`},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := MatchPrivileges(tc.privileges, tc.forbidden, "DEFAULT_SCHEMA")
+			if err != nil {
+				t.Fatalf("\n%s\nMatchPrivileges(...): unexpected error: %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nMatchPrivileges(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestMatchRoles(t *testing.T) {
+	cases := map[string]struct {
+		reason    string
+		roles     []string
+		forbidden []string
+		want      []string
+	}{
+		"Match": {
+			reason:    "A forbidden role should match regardless of quoting and case",
+			roles:     []string{`"content_admin"`, "PUBLIC"},
+			forbidden: []string{"CONTENT_ADMIN"},
+			want:      []string{`"content_admin"`},
+		},
+		"PlainForbidsGrantable": {
+			reason:    "A forbidden role without admin option should also forbid the grantable variant",
+			roles:     []string{"CONTENT_ADMIN WITH ADMIN OPTION"},
+			forbidden: []string{"CONTENT_ADMIN"},
+			want:      []string{`"CONTENT_ADMIN" WITH ADMIN OPTION`},
+		},
+		"GrantableOnly": {
+			reason:    "A forbidden role with admin option should not forbid the plain variant",
+			roles:     []string{"CONTENT_ADMIN"},
+			forbidden: []string{"CONTENT_ADMIN WITH ADMIN OPTION"},
+			want:      nil,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := MatchRoles(tc.roles, tc.forbidden)
+			if err != nil {
+				t.Fatalf("\n%s\nMatchRoles(...): unexpected error: %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nMatchRoles(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCheckGrantPolicy(t *testing.T) {
+	policy := &apisv1alpha1.GrantPolicy{
+		ForbiddenPrivileges: []string{"USER ADMIN"},
+		ForbiddenRoles:      []string{"CONTENT_ADMIN"},
+	}
+
+	cases := map[string]struct {
+		reason     string
+		policy     *apisv1alpha1.GrantPolicy
+		privileges []string
+		roles      []string
+		wantErr    bool
+	}{
+		"NilPolicy": {
+			reason:     "A nil policy should allow every grant",
+			privileges: []string{"USER ADMIN"},
+		},
+		"Allowed": {
+			reason:     "Grants not on the forbidden lists should be allowed",
+			policy:     policy,
+			privileges: []string{"AUDIT READ"},
+			roles:      []string{"PUBLIC"},
+		},
+		"ForbiddenPrivilege": {
+			reason:     "A forbidden privilege should be rejected",
+			policy:     policy,
+			privileges: []string{"USER ADMIN"},
+			wantErr:    true,
+		},
+		"ForbiddenRole": {
+			reason:  "A forbidden role should be rejected",
+			policy:  policy,
+			roles:   []string{"CONTENT_ADMIN"},
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := CheckGrantPolicy(tc.policy, tc.privileges, tc.roles, "DEFAULT_SCHEMA")
+			if got := errors.Is(err, ErrForbiddenGrant); got != tc.wantErr {
+				t.Errorf("\n%s\nCheckGrantPolicy(...): want forbidden %t, got error %v", tc.reason, tc.wantErr, err)
+			}
+		})
+	}
+}
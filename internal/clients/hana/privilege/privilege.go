@@ -10,6 +10,7 @@ import (
 	"strings"
 
 	"github.com/SAP/crossplane-provider-hana/apis/admin/v1alpha1"
+	"github.com/SAP/crossplane-provider-hana/internal/clients/hana/statements"
 	"github.com/SAP/crossplane-provider-hana/internal/clients/xsql"
 	"github.com/SAP/crossplane-provider-hana/internal/utils"
 )
@@ -18,6 +19,7 @@ const (
 	errUnknownPrivilege                 = "unknown type of privilege: %s"
 	errParsePrivilege                   = "failed to parse privilege %s: %w"
 	ErrUnknownPrivilegeManagementPolicy = "unknown privilege management policy: %s"
+	ErrInvalidPrivilegeSelector         = "invalid privilege selector regex %q: %w"
 	ErrObservationNil                   = "observed user observation cannot be nil"
 	errUnknownRole                      = "failed to parse role: %s"
 	errRoleInvalidGrantOption           = "failed to parse role with grantable option: %s"
@@ -25,6 +27,28 @@ const (
 	errPrivilegeInvalidAdminOption      = "failed to parse privilege with admin option: %s"
 )
 
+// ErrParse is matched by the errors of privilege and role strings that cannot
+// be parsed.
+var ErrParse = errors.New("cannot parse privilege or role")
+
+// parseError is an error of a privilege or role string that cannot be parsed.
+type parseError struct {
+	msg string
+}
+
+func parseErrorf(format string, args ...any) error {
+	return &parseError{msg: fmt.Sprintf(format, args...)}
+}
+
+func (e *parseError) Error() string {
+	return e.msg
+}
+
+// Is makes parse errors match ErrParse.
+func (e *parseError) Is(target error) bool {
+	return target == ErrParse
+}
+
 type DefaultSchema = string
 type Grantee = string
 type GranteeType string
@@ -40,6 +64,7 @@ type Client interface {
 	RevokePrivileges(context.Context, DefaultSchema, Grantee, []string) error
 	RevokeRoles(context.Context, DefaultSchema, Grantee, []string) error
 	QueryPrivileges(context.Context, Grantee, GranteeType) ([]string, error)
+	QueryPrivilegeGrants(context.Context, Grantee, GranteeType) ([]PrivilegeGrant, error)
 	QueryRoles(context.Context, Grantee, GranteeType) ([]string, error)
 }
 
@@ -57,22 +82,45 @@ func (c *PrivilegeClient) GrantPrivileges(ctx context.Context, grantor DefaultSc
 		return err
 	}
 
+	var failures []GrantFailure
 	for _, g := range groupedObjects {
-		query := fmt.Sprintf("GRANT %s TO %s", g.Body, grantee)
-		if g.IsGrantable {
-			if g.Type == SystemPrivilegeType {
-				query += " WITH ADMIN OPTION"
-			} else {
-				query += " WITH GRANT OPTION"
-			}
-		}
-		if _, err := c.ExecContext(ctx, query); err != nil {
-			return err
+		if _, err := c.ExecContext(ctx, grantQuery(g.Body, g.Type, g.IsGrantable, grantee)); err != nil {
+			failures = append(failures, c.grantIndividually(ctx, grantee, g, err)...)
 		}
 	}
+	if len(failures) > 0 {
+		return &GrantError{Failures: failures}
+	}
 	return nil
 }
 
+// grantIndividually retries the privileges of a group whose grouped GRANT
+// failed one by one, so a single bad privilege does not hold back the others
+// and the failures name the privileges that caused them.
+func (c *PrivilegeClient) grantIndividually(ctx context.Context, grantee Grantee, g PrivilegeGroup, groupErr error) []GrantFailure {
+	if len(g.Privileges) == 1 {
+		return []GrantFailure{{Privilege: g.Privileges[0].String(), Err: groupErr}}
+	}
+	var failures []GrantFailure
+	for _, p := range g.Privileges {
+		if _, err := c.ExecContext(ctx, grantQuery(p.baseString(), p.Type, p.IsGrantable, grantee)); err != nil {
+			failures = append(failures, GrantFailure{Privilege: p.String(), Err: err})
+		}
+	}
+	return failures
+}
+
+func grantQuery(body string, pType PrivilegeType, isGrantable bool, grantee Grantee) string {
+	option := statements.NoOption
+	if isGrantable {
+		option = statements.WithGrantOption
+		if pType == SystemPrivilegeType {
+			option = statements.WithAdminOption
+		}
+	}
+	return statements.Grant(body, grantee, option)
+}
+
 func (c *PrivilegeClient) GrantRoles(ctx context.Context, _ DefaultSchema, grantee Grantee, roleNames []string) error {
 	if len(roleNames) == 0 {
 		return nil
@@ -87,7 +135,7 @@ func (c *PrivilegeClient) GrantRoles(ctx context.Context, _ DefaultSchema, grant
 		if err != nil {
 			return err
 		}
-		normalized := Role{Name: cleanIdentifier(role.Name), IsGrantable: role.IsGrantable}
+		normalized := role.clean()
 		if normalized.IsGrantable {
 			adminRoles = append(adminRoles, normalized.quotedName())
 		} else {
@@ -96,14 +144,12 @@ func (c *PrivilegeClient) GrantRoles(ctx context.Context, _ DefaultSchema, grant
 	}
 
 	if len(normalRoles) > 0 {
-		query := fmt.Sprintf("GRANT %s TO %s", strings.Join(normalRoles, ", "), grantee)
-		if _, err := c.ExecContext(ctx, query); err != nil {
+		if _, err := c.ExecContext(ctx, statements.Grant(strings.Join(normalRoles, ", "), grantee, statements.NoOption)); err != nil {
 			return err
 		}
 	}
 	if len(adminRoles) > 0 {
-		query := fmt.Sprintf("GRANT %s TO %s WITH ADMIN OPTION", strings.Join(adminRoles, ", "), grantee)
-		if _, err := c.ExecContext(ctx, query); err != nil {
+		if _, err := c.ExecContext(ctx, statements.Grant(strings.Join(adminRoles, ", "), grantee, statements.WithAdminOption)); err != nil {
 			return err
 		}
 	}
@@ -118,8 +164,7 @@ func (c *PrivilegeClient) RevokePrivileges(ctx context.Context, defaultSchema De
 
 	for _, g := range groupedObjects {
 		// Revoke statement does not use WITH OPTION suffix
-		query := fmt.Sprintf("REVOKE %s FROM %s", g.Body, grantee)
-		if _, err := c.ExecContext(ctx, query); err != nil {
+		if _, err := c.ExecContext(ctx, statements.Revoke(g.Body, grantee)); err != nil {
 			return err
 		}
 	}
@@ -137,15 +182,63 @@ func (c *PrivilegeClient) RevokeRoles(ctx context.Context, _ DefaultSchema, gran
 		if err != nil {
 			return err
 		}
-		normalized := Role{Name: cleanIdentifier(role.Name)}
-		namesToRevoke = append(namesToRevoke, normalized.quotedName())
+		namesToRevoke = append(namesToRevoke, role.clean().quotedName())
 	}
 
-	query := fmt.Sprintf("REVOKE %s FROM %s", strings.Join(namesToRevoke, ", "), grantee)
-	_, err := c.ExecContext(ctx, query)
+	_, err := c.ExecContext(ctx, statements.Revoke(strings.Join(namesToRevoke, ", "), grantee))
 	return err
 }
 
+// SameRole returns whether two role strings name the same role, with or
+// without the admin option.
+func SameRole(a, b string) bool {
+	roleA, errA := parseRoleString(a)
+	roleB, errB := parseRoleString(b)
+	return errA == nil && errB == nil && roleA.clean().quotedName() == roleB.clean().quotedName()
+}
+
+// SplitAdminOptionChanges separates the roles whose grant only changes in the
+// admin option from the roles to grant and revoke. A role that gains the admin
+// option remains to be granted, as granting it again adds the option to the
+// existing grant. A role that loses the admin option is returned in downgrade,
+// since HANA can only remove the option by revoking and regranting the role.
+func SplitAdminOptionChanges(toGrant, toRevoke []string) (grant, revoke, downgrade []string, err error) {
+	granted := make(map[string]Role, len(toGrant))
+	for _, rStr := range toGrant {
+		role, err := parseRoleString(rStr)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		granted[role.clean().quotedName()] = role
+	}
+
+	downgraded := map[string]bool{}
+	for _, rStr := range toRevoke {
+		role, err := parseRoleString(rStr)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		name := role.clean().quotedName()
+		g, ok := granted[name]
+		switch {
+		case !ok:
+			revoke = append(revoke, rStr)
+		case role.IsGrantable && !g.IsGrantable:
+			downgraded[name] = true
+		}
+	}
+
+	for _, rStr := range toGrant {
+		role, _ := parseRoleString(rStr)
+		if downgraded[role.clean().quotedName()] {
+			downgrade = append(downgrade, rStr)
+		} else {
+			grant = append(grant, rStr)
+		}
+	}
+	return grant, revoke, downgrade, nil
+}
+
 func addGranteeQuery(query string, grantee string, granteeType GranteeType) (string, []any) {
 	queryArgs := []any{granteeType}
 	query += " AND GRANTEE = ?"
@@ -158,7 +251,7 @@ func addGranteeQuery(query string, grantee string, granteeType GranteeType) (str
 	return query, queryArgs
 }
 
-// QueryPrivileges TODO: Test to query CLIENTSIDE ENCRYPTION COLUMN KEY and STRUCTURED PRIVILEGE types in HANA instance
+// QueryPrivileges TODO: Test to query CLIENTSIDE ENCRYPTION COLUMN KEY types in HANA instance
 // Reference: https://help.sap.com/docs/SAP_HANA_PLATFORM/4fe29514fd584807ac9f2a04f6754767/20f674e1751910148a8b990d33efbdc5.html?locale=en-US
 func (c *PrivilegeClient) QueryPrivileges(ctx context.Context, grantee Grantee, granteeType GranteeType) ([]string, error) {
 	observed := []string{}
@@ -183,6 +276,45 @@ func (c *PrivilegeClient) QueryPrivileges(ctx context.Context, grantee Grantee,
 	return observed, nil
 }
 
+// PrivilegeGrant is a privilege granted to a grantee, and the user who
+// granted it.
+type PrivilegeGrant struct {
+	Privilege string
+	Grantor   string
+}
+
+// QueryPrivilegeGrants returns the privileges granted to the grantee like
+// QueryPrivileges, together with their grantors. A privilege granted by
+// several users is returned once for each of them.
+func (c *PrivilegeClient) QueryPrivilegeGrants(ctx context.Context, grantee Grantee, granteeType GranteeType) ([]PrivilegeGrant, error) {
+	observed := []PrivilegeGrant{}
+	query := "SELECT OBJECT_TYPE, PRIVILEGE, SCHEMA_NAME, OBJECT_NAME, IS_GRANTABLE, GRANTOR FROM GRANTED_PRIVILEGES WHERE GRANTEE_TYPE = ?"
+	query, queryArgs := addGranteeQuery(query, grantee, granteeType)
+
+	rows, err := c.QueryContext(ctx, query, queryArgs...)
+	if err != nil {
+		return observed, err
+	}
+	defer rows.Close() //nolint:errcheck
+	for rows.Next() {
+		var objectType, name, grantor string
+		var isGrantable bool
+		var schemaName, objectName sql.NullString
+		if err := rows.Scan(&objectType, &name, &schemaName, &objectName, &isGrantable, &grantor); err != nil {
+			return observed, err
+		}
+		privilege, err := grantedPrivilege(objectType, name, schemaName, objectName, isGrantable)
+		if err != nil {
+			return observed, err
+		}
+		observed = append(observed, PrivilegeGrant{Privilege: privilege.String(), Grantor: grantor})
+	}
+	if err := rows.Err(); err != nil {
+		return observed, err
+	}
+	return observed, nil
+}
+
 func (c *PrivilegeClient) QueryRoles(ctx context.Context, grantee Grantee, granteeType GranteeType) ([]string, error) {
 	observed := []string{}
 	query := "SELECT ROLE_SCHEMA_NAME, ROLE_NAME, IS_GRANTABLE FROM GRANTED_ROLES WHERE GRANTEE_TYPE = ?"
@@ -199,11 +331,7 @@ func (c *PrivilegeClient) QueryRoles(ctx context.Context, grantee Grantee, grant
 		if err := roleRows.Scan(&roleSchemaName, &roleName, &isGrantable); err != nil {
 			return observed, err
 		}
-		fullName := roleName
-		if roleSchemaName.Valid {
-			fullName = fmt.Sprintf("%s.%s", roleSchemaName.String, roleName)
-		}
-		r := Role{Name: fullName, IsGrantable: isGrantable}
+		r := Role{Schema: roleSchemaName.String, Name: roleName, IsGrantable: isGrantable}
 		observed = append(observed, r.String())
 	}
 	if err := roleRows.Err(); err != nil {
@@ -220,7 +348,10 @@ type Privilege struct {
 	IsGrantable   bool
 }
 
+// Role is a role, optionally qualified by the schema it is defined in, e.g.
+// the schema of an HDI container.
 type Role struct {
+	Schema      string
 	Name        string
 	IsGrantable bool
 }
@@ -233,12 +364,31 @@ func (r Role) String() string {
 	return name
 }
 
-// quotedName wraps the role name in double quotes unconditionally.
+// quotedName wraps the role name, and the schema of schema-qualified roles,
+// in double quotes unconditionally.
 // In HANA SQL, quoting is always safe for identifiers and ensures correct handling
 // of special characters. The result is used both in Role.String() for canonical
 // comparison and in GrantRoles/RevokeRoles for SQL generation.
 func (r Role) quotedName() string {
-	return fmt.Sprintf(`"%s"`, utils.EscapeDoubleQuotes(r.Name))
+	if r.Schema != "" {
+		return statements.Identifier(r.Schema) + "." + statements.Identifier(r.Name)
+	}
+	return statements.Identifier(r.Name)
+}
+
+// clean returns the role with outer quotes removed from its schema and name.
+func (r Role) clean() Role {
+	return Role{Schema: cleanIdentifier(r.Schema), Name: cleanIdentifier(r.Name), IsGrantable: r.IsGrantable}
+}
+
+// fold returns the role with its unquoted schema and name folded to
+// uppercase, as HANA does in SQL.
+func (r Role) fold() Role {
+	folded := Role{Name: utils.FoldUnquotedIdentifier(r.Name), IsGrantable: r.IsGrantable}
+	if r.Schema != "" {
+		folded.Schema = utils.FoldUnquotedIdentifier(r.Schema)
+	}
+	return folded
 }
 
 // PrivilegeGroup holds aggregated names to build optimized SQL: GRANT Name1, Name2 ON ...
@@ -246,6 +396,34 @@ type PrivilegeGroup struct {
 	Body        string
 	IsGrantable bool
 	Type        PrivilegeType
+	Privileges  []Privilege
+}
+
+// GrantFailure is a privilege that could not be granted.
+type GrantFailure struct {
+	Privilege string
+	Err       error
+}
+
+// GrantError lists the privileges that could not be granted and why.
+type GrantError struct {
+	Failures []GrantFailure
+}
+
+func (e *GrantError) Error() string {
+	msgs := make([]string, 0, len(e.Failures))
+	for _, f := range e.Failures {
+		msgs = append(msgs, fmt.Sprintf("%s: %v", f.Privilege, f.Err))
+	}
+	return strings.Join(msgs, "; ")
+}
+
+func (e *GrantError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Failures))
+	for _, f := range e.Failures {
+		errs = append(errs, f.Err)
+	}
+	return errs
 }
 
 // Common regex suffix for grantable options
@@ -258,6 +436,21 @@ const (
 // Updated to handle special identifiers with embedded quotes like "SCHE""M'A"
 var roleRegex = regexp.MustCompile(`(?i)^\s*(` + identifierPattern + `(?:\.` + identifierPattern + `)?)` + adminOptionRegex + `\s*$`)
 
+// quotedRoleRegex matches roles whose name is quoted, which HANA also accepts
+// without whitespace before the admin option, e.g. "SCHEMA"."ROLE"WITH ADMIN
+// OPTION.
+var quotedRoleRegex = regexp.MustCompile(`(?i)^\s*((?:` + identifierPattern + `\.)?"(?:[^"]|"")*")(\s*WITH\s+ADMIN\s+OPTION)?\s*$`)
+
+// qualifiedRoleRegex splits a role name matched by roleRegex into its schema
+// and role, e.g. MY_HDI.my.app::admin or "MY_HDI"."my.app::admin".
+var qualifiedRoleRegex = regexp.MustCompile(`^(` + schemaIdentifierPattern + `)\.(` + identifierPattern + `)$`)
+
+// containerSchemaRegex matches the unquoted schemas that qualify roles of
+// the form <schema>.<namespace>::<role>. HANA folds unquoted schemas to
+// uppercase, while repository roles such as sap.hana.xs.admin.roles::Viewer
+// start with a lowercase package and are not schema-qualified.
+var containerSchemaRegex = regexp.MustCompile(`^[A-Z_][A-Z0-9_#$]*$`)
+
 type PrivilegeType int
 
 const (
@@ -336,7 +529,12 @@ func (p Privilege) baseString() string {
 	case ColumnKeyPrivilegeType:
 		return fmt.Sprintf("%s ON CLIENTSIDE ENCRYPTION COLUMN KEY %s", p.Name, p.Identifier)
 	case StructuredPrivilegeType:
-		return fmt.Sprintf("%s %s", p.Name, p.Identifier)
+		// Structured privileges of HDI containers are qualified by the
+		// container schema, repository ones such as pkg::AP are not
+		if p.SubIdentifier != "" {
+			return fmt.Sprintf("%s %s.%s", p.Name, statements.Identifier(p.Identifier), statements.Identifier(p.SubIdentifier))
+		}
+		return fmt.Sprintf("%s %s", p.Name, statements.Identifier(p.Identifier))
 	default:
 		return "unknown"
 	}
@@ -365,11 +563,7 @@ func FormatRoleStrings(roleStrings []string) ([]string, error) {
 		if err != nil {
 			return nil, err
 		}
-		normalized := Role{
-			Name:        cleanIdentifier(role.Name),
-			IsGrantable: role.IsGrantable,
-		}
-		res = append(res, normalized.String())
+		res = append(res, role.clean().String())
 	}
 	return res, nil
 }
@@ -403,6 +597,119 @@ func FormatPrivilegeStringsWithPreprocessing(privilegeStrings []string, username
 	return res, nil
 }
 
+// NormalizePrivilegeStrings formats privilege strings to their canonical form
+// without resolving a default schema. Object privileges that do not name a
+// schema are kept as written, since their schema is only known once the
+// connecting user is. Duplicates are removed while preserving order.
+func NormalizePrivilegeStrings(privilegeStrings []string) ([]string, error) {
+	res := make([]string, 0, len(privilegeStrings))
+	for _, privStr := range privilegeStrings {
+		priv, err := parsePrivilegeString(privStr, "")
+		if err != nil {
+			return nil, fmt.Errorf(errParsePrivilege, privStr, err)
+		}
+		normalized := priv.String()
+		if priv.Type == ObjectPrivilegeType && priv.Identifier == "" {
+			normalized = strings.TrimSpace(privStr)
+		}
+		if !slices.Contains(res, normalized) {
+			res = append(res, normalized)
+		}
+	}
+	return res, nil
+}
+
+// FromTyped returns the privilege given by its parts. Its identifiers are
+// taken as written.
+func FromTyped(t v1alpha1.TypedPrivilege) Privilege {
+	priv := Privilege{Name: strings.ToUpper(t.Name), IsGrantable: t.Grantable}
+	switch t.Type {
+	case v1alpha1.PrivilegeTypeSchema:
+		priv.Type, priv.Identifier = SchemaPrivilegeType, t.Schema
+	case v1alpha1.PrivilegeTypeObject:
+		priv.Type, priv.Identifier, priv.SubIdentifier = ObjectPrivilegeType, t.Schema, t.Object
+		// The schema of object privileges that do not name one is only
+		// known once the connecting user is
+		if t.Schema == "" {
+			priv.Identifier, priv.SubIdentifier = t.Object, ""
+		}
+	case v1alpha1.PrivilegeTypeRemoteSource:
+		priv.Type, priv.Identifier = SourcePrivilegeType, t.Object
+	default:
+		priv.Type = SystemPrivilegeType
+	}
+	return priv
+}
+
+// TypedPrivilegeStrings formats typed privileges to their canonical strings,
+// so they are granted and compared like the privilege strings of a spec.
+func TypedPrivilegeStrings(typed []v1alpha1.TypedPrivilege) []string {
+	res := make([]string, 0, len(typed))
+	for _, t := range typed {
+		res = append(res, FromTyped(t).String())
+	}
+	return res
+}
+
+// WithTypedPrivileges returns the privilege strings followed by the strings of
+// the typed privileges that are not among them.
+func WithTypedPrivileges(privilegeStrings []string, typed []v1alpha1.TypedPrivilege) []string {
+	if len(typed) == 0 {
+		return privilegeStrings
+	}
+	res := slices.Clone(privilegeStrings)
+	for _, p := range TypedPrivilegeStrings(typed) {
+		if !slices.Contains(res, p) {
+			res = append(res, p)
+		}
+	}
+	return res
+}
+
+// FoldPrivilegeStrings folds the unquoted identifiers in privilege strings to
+// uppercase, as HANA does in SQL, and formats the privileges to their
+// canonical form, so identifiers in double quotes keep their case. Strings
+// that cannot be parsed are kept as written, granting them reports the error.
+func FoldPrivilegeStrings(privilegeStrings []string) []string {
+	if len(privilegeStrings) == 0 {
+		return privilegeStrings
+	}
+	res := make([]string, 0, len(privilegeStrings))
+	for _, privStr := range privilegeStrings {
+		priv, err := parsePrivilegeStringWith(privStr, "", utils.FoldUnquotedIdentifier)
+		if err != nil {
+			res = append(res, privStr)
+			continue
+		}
+		// The schema of object privileges that do not name one is only
+		// known once the connecting user is
+		if priv.Type == ObjectPrivilegeType && priv.Identifier == "" {
+			priv.Identifier, priv.SubIdentifier = priv.SubIdentifier, ""
+		}
+		res = append(res, priv.String())
+	}
+	return res
+}
+
+// FoldRoleStrings folds unquoted role names to uppercase, as HANA does in
+// SQL, and formats the roles to their canonical form. Strings that cannot be
+// parsed are kept as written, granting them reports the error.
+func FoldRoleStrings(roleStrings []string) []string {
+	if len(roleStrings) == 0 {
+		return roleStrings
+	}
+	res := make([]string, 0, len(roleStrings))
+	for _, rStr := range roleStrings {
+		role, err := parseRoleString(rStr)
+		if err != nil {
+			res = append(res, rStr)
+			continue
+		}
+		res = append(res, role.fold().String())
+	}
+	return res
+}
+
 func groupPrivilegesByType(privilegeStrings []string, defaultSchema DefaultSchema) ([]PrivilegeGroup, error) {
 	privileges, err := parsePrivilegeStrings(privilegeStrings, defaultSchema)
 	if err != nil {
@@ -426,18 +733,49 @@ func parsePrivilegeStrings(privilegeStrings []string, defaultSchema DefaultSchem
 
 func parseRoleString(roleStr string) (Role, error) {
 	m := roleRegex.FindStringSubmatch(roleStr)
+	if m == nil {
+		m = quotedRoleRegex.FindStringSubmatch(roleStr)
+	}
 	if m != nil {
+		schema, name := splitQualifiedName(m[1])
 		return Role{
-			Name:        m[1],
+			Schema:      schema,
+			Name:        name,
 			IsGrantable: m[2] != "",
 		}, nil
 	}
 	// Check for invalid grant option usage
 	upperStr := strings.ToUpper(strings.TrimSpace(roleStr))
 	if strings.HasSuffix(upperStr, "WITH GRANT OPTION") {
-		return Role{}, fmt.Errorf(errRoleInvalidGrantOption, roleStr)
+		return Role{}, parseErrorf(errRoleInvalidGrantOption, roleStr)
 	}
-	return Role{}, fmt.Errorf(errUnknownRole, roleStr)
+	return Role{}, parseErrorf(errUnknownRole, roleStr)
+}
+
+// splitQualifiedName returns the schema and name of a role or structured
+// privilege name as written. Names without a schema, repository names and
+// quoted names containing dots are returned with an empty schema.
+func splitQualifiedName(qualified string) (schema, name string) {
+	m := qualifiedRoleRegex.FindStringSubmatch(qualified)
+	if m == nil {
+		return "", qualified
+	}
+	schema, name = m[1], m[2]
+	if !strings.HasPrefix(schema, `"`) && !strings.HasPrefix(name, `"`) &&
+		strings.Contains(name, "::") && !containerSchemaRegex.MatchString(schema) {
+		return "", qualified
+	}
+	return schema, name
+}
+
+// cleanRepositoryName turns name into a name with clean, except for unquoted
+// names of the form <namespace>::<name>. They are not valid identifiers, so
+// they are taken as written instead of being folded to uppercase.
+func cleanRepositoryName(name string, clean func(string) string) string {
+	if strings.Contains(name, "::") {
+		return cleanIdentifier(name)
+	}
+	return clean(name)
 }
 
 // identifierPattern matches both simple identifiers and special identifiers with embedded quotes
@@ -445,6 +783,11 @@ func parseRoleString(roleStr string) (Role, error) {
 // Simple identifiers: Much more permissive to handle system identifiers and edge cases
 const identifierPattern = `(?:"(?:[^"]|"")*"|[^\s]+)`
 
+// schemaIdentifierPattern matches the schema of a qualified object name. An
+// unquoted schema ends at the first dot, so repository objects such as
+// _SYS_BIC.pkg.sub::calcview keep their package in the object name.
+const schemaIdentifierPattern = `(?:"(?:[^"]|"")*"|[^\s."]+)`
+
 // cleanIdentifier removes outer quotes from an identifier and unescapes inner quotes
 func cleanIdentifier(identifier string) string {
 	if len(identifier) >= 2 && identifier[0] == '"' && identifier[len(identifier)-1] == '"' {
@@ -456,7 +799,7 @@ func cleanIdentifier(identifier string) string {
 
 type privilegePattern struct {
 	re    *regexp.Regexp
-	build func(m []string, defaultSchema DefaultSchema) Privilege
+	build func(m []string, defaultSchema DefaultSchema, clean func(string) string) Privilege
 }
 
 // Parts of single privilege statements specified in https://help.sap.com/docs/SAP_HANA_PLATFORM/4fe29514fd584807ac9f2a04f6754767/20f674e1751910148a8b990d33efbdc5.html?locale=en-US:
@@ -471,78 +814,85 @@ var privilegePatterns = []privilegePattern{
 	// USERGROUP OPERATOR ON USERGROUP <name>
 	{
 		re: regexp.MustCompile(`(?i)^\s*(USERGROUP\s+OPERATOR)\s+ON\s+USERGROUP\s+(` + identifierPattern + `)` + grantOptionRegex + `\s*$`),
-		build: func(m []string, _ DefaultSchema) Privilege {
-			return Privilege{Type: UserGroupPrivilegeType, Name: m[1], Identifier: cleanIdentifier(m[2]), IsGrantable: m[3] != ""}
+		build: func(m []string, _ DefaultSchema, clean func(string) string) Privilege {
+			return Privilege{Type: UserGroupPrivilegeType, Name: m[1], Identifier: clean(m[2]), IsGrantable: m[3] != ""}
 		},
 	},
 	// Column key privilege: USAGE ON CLIENTSIDE ENCRYPTION COLUMN KEY <name>, currently only USAGE is supported.
 	{
 		re: regexp.MustCompile(`(?i)^\s*(USAGE)\b\s+ON\s+CLIENTSIDE\s+ENCRYPTION\s+COLUMN\s+KEY\s+(` + identifierPattern + `)` + grantOptionRegex + `\s*$`),
-		build: func(m []string, _ DefaultSchema) Privilege {
-			return Privilege{Type: ColumnKeyPrivilegeType, Name: "USAGE", Identifier: cleanIdentifier(m[2]), IsGrantable: m[3] != ""}
+		build: func(m []string, _ DefaultSchema, clean func(string) string) Privilege {
+			return Privilege{Type: ColumnKeyPrivilegeType, Name: "USAGE", Identifier: clean(m[2]), IsGrantable: m[3] != ""}
 		},
 	},
 	// PSE privilege: <privilege> ON PSE <name> (treated as object privilege)
 	{
 		re: regexp.MustCompile(`(?i)^\s*([A-Za-z](?:[A-Za-z\s]*?[A-Za-z])?)\s+ON\s+PSE\s+(` + identifierPattern + `)` + grantOptionRegex + `\s*$`),
-		build: func(m []string, _ DefaultSchema) Privilege {
-			return Privilege{Type: ObjectPrivilegeType, Name: m[1], Identifier: "PSE " + cleanIdentifier(m[2]), IsGrantable: m[3] != ""}
+		build: func(m []string, _ DefaultSchema, clean func(string) string) Privilege {
+			return Privilege{Type: ObjectPrivilegeType, Name: m[1], Identifier: "PSE " + clean(m[2]), IsGrantable: m[3] != ""}
 		},
 	},
 	// JWT PROVIDER privilege: <privilege> ON JWT PROVIDER <name> (treated as object privilege)
 	{
 		re: regexp.MustCompile(`(?i)^\s*([A-Za-z](?:[A-Za-z\s]*?[A-Za-z])?)\s+ON\s+JWT\s+PROVIDER\s+(` + identifierPattern + `)` + grantOptionRegex + `\s*$`),
-		build: func(m []string, _ DefaultSchema) Privilege {
-			return Privilege{Type: ObjectPrivilegeType, Name: m[1], Identifier: "JWT PROVIDER " + cleanIdentifier(m[2]), IsGrantable: m[3] != ""}
+		build: func(m []string, _ DefaultSchema, clean func(string) string) Privilege {
+			return Privilege{Type: ObjectPrivilegeType, Name: m[1], Identifier: "JWT PROVIDER " + clean(m[2]), IsGrantable: m[3] != ""}
 		},
 	},
 	// SAML PROVIDER privilege: <privilege> ON SAML PROVIDER <name> (treated as object privilege)
 	{
 		re: regexp.MustCompile(`(?i)^\s*([A-Za-z](?:[A-Za-z\s]*?[A-Za-z])?)\s+ON\s+SAML\s+PROVIDER\s+(` + identifierPattern + `)` + grantOptionRegex + `\s*$`),
-		build: func(m []string, _ DefaultSchema) Privilege {
-			return Privilege{Type: ObjectPrivilegeType, Name: m[1], Identifier: "SAML PROVIDER " + cleanIdentifier(m[2]), IsGrantable: m[3] != ""}
+		build: func(m []string, _ DefaultSchema, clean func(string) string) Privilege {
+			return Privilege{Type: ObjectPrivilegeType, Name: m[1], Identifier: "SAML PROVIDER " + clean(m[2]), IsGrantable: m[3] != ""}
 		},
 	},
 	// X509 PROVIDER privilege: <privilege> ON X509 PROVIDER <name> (treated as object privilege)
 	{
 		re: regexp.MustCompile(`(?i)^\s*([A-Za-z](?:[A-Za-z\s]*?[A-Za-z])?)\s+ON\s+X509\s+PROVIDER\s+(` + identifierPattern + `)` + grantOptionRegex + `\s*$`),
-		build: func(m []string, _ DefaultSchema) Privilege {
-			return Privilege{Type: ObjectPrivilegeType, Name: m[1], Identifier: "X509 PROVIDER " + cleanIdentifier(m[2]), IsGrantable: m[3] != ""}
+		build: func(m []string, _ DefaultSchema, clean func(string) string) Privilege {
+			return Privilege{Type: ObjectPrivilegeType, Name: m[1], Identifier: "X509 PROVIDER " + clean(m[2]), IsGrantable: m[3] != ""}
 		},
 	},
 	// Remote source privilege
 	{
 		re: regexp.MustCompile(`(?i)^\s*([A-Za-z](?:[A-Za-z\s]*?[A-Za-z])?)\s+ON\s+REMOTE\s+SOURCE\s+(` + identifierPattern + `)` + grantOptionRegex + `\s*$`),
-		build: func(m []string, _ DefaultSchema) Privilege {
-			return Privilege{Type: SourcePrivilegeType, Name: m[1], Identifier: cleanIdentifier(m[2]), IsGrantable: m[3] != ""}
+		build: func(m []string, _ DefaultSchema, clean func(string) string) Privilege {
+			return Privilege{Type: SourcePrivilegeType, Name: m[1], Identifier: clean(m[2]), IsGrantable: m[3] != ""}
 		},
 	},
 	// Schema privilege
 	{
 		re: regexp.MustCompile(`(?i)^\s*([A-Za-z](?:[A-Za-z\s]*?[A-Za-z])?)\s+ON\s+SCHEMA\s+(` + identifierPattern + `)` + grantOptionRegex + `\s*$`),
-		build: func(m []string, _ DefaultSchema) Privilege {
-			return Privilege{Type: SchemaPrivilegeType, Name: m[1], Identifier: cleanIdentifier(m[2]), IsGrantable: m[3] != ""}
+		build: func(m []string, _ DefaultSchema, clean func(string) string) Privilege {
+			return Privilege{Type: SchemaPrivilegeType, Name: m[1], Identifier: clean(m[2]), IsGrantable: m[3] != ""}
 		},
 	},
 	// Object privilege with schema qualification
 	{
-		re: regexp.MustCompile(`(?i)^\s*([A-Za-z](?:[A-Za-z\s]*?[A-Za-z])?)\s+ON\s+(` + identifierPattern + `)\.(` + identifierPattern + `)` + grantOptionRegex + `\s*$`),
-		build: func(m []string, _ DefaultSchema) Privilege {
-			return Privilege{Type: ObjectPrivilegeType, Name: m[1], Identifier: cleanIdentifier(m[2]), SubIdentifier: cleanIdentifier(m[3]), IsGrantable: m[4] != ""}
+		re: regexp.MustCompile(`(?i)^\s*([A-Za-z](?:[A-Za-z\s]*?[A-Za-z])?)\s+ON\s+(` + schemaIdentifierPattern + `)\.(` + identifierPattern + `)` + grantOptionRegex + `\s*$`),
+		build: func(m []string, _ DefaultSchema, clean func(string) string) Privilege {
+			return Privilege{Type: ObjectPrivilegeType, Name: m[1], Identifier: clean(m[2]), SubIdentifier: clean(m[3]), IsGrantable: m[4] != ""}
 		},
 	},
 	// Object privilege without schema (use default schema)
 	{
 		re: regexp.MustCompile(`(?i)^\s*([A-Za-z](?:[A-Za-z\s]*?[A-Za-z])?)\s+ON\s+(` + identifierPattern + `)` + grantOptionRegex + `\s*$`),
-		build: func(m []string, defaultSchema DefaultSchema) Privilege {
-			return Privilege{Type: ObjectPrivilegeType, Name: m[1], Identifier: defaultSchema, SubIdentifier: cleanIdentifier(m[2]), IsGrantable: m[3] != ""}
+		build: func(m []string, defaultSchema DefaultSchema, clean func(string) string) Privilege {
+			return Privilege{Type: ObjectPrivilegeType, Name: m[1], Identifier: defaultSchema, SubIdentifier: clean(m[2]), IsGrantable: m[3] != ""}
 		},
 	},
-	// Structured privilege: STRUCTURED PRIVILEGE <name>
+	// Structured privilege: STRUCTURED PRIVILEGE [<schema>.]<name>
 	{
-		re: regexp.MustCompile(`(?i)^\s*STRUCTURED\s+PRIVILEGE\s+(` + identifierPattern + `)` + grantOptionRegex + `\s*$`),
-		build: func(m []string, _ DefaultSchema) Privilege {
-			return Privilege{Type: StructuredPrivilegeType, Name: "STRUCTURED PRIVILEGE", Identifier: cleanIdentifier(m[1]), IsGrantable: m[2] != ""}
+		re: regexp.MustCompile(`(?i)^\s*STRUCTURED\s+PRIVILEGE\s+(` + identifierPattern + `(?:\.` + identifierPattern + `)?)` + grantOptionRegex + `\s*$`),
+		build: func(m []string, _ DefaultSchema, clean func(string) string) Privilege {
+			priv := Privilege{Type: StructuredPrivilegeType, Name: "STRUCTURED PRIVILEGE", IsGrantable: m[2] != ""}
+			schema, name := splitQualifiedName(m[1])
+			if schema == "" {
+				priv.Identifier = cleanRepositoryName(name, clean)
+			} else {
+				priv.Identifier, priv.SubIdentifier = clean(schema), cleanRepositoryName(name, clean)
+			}
+			return priv
 		},
 	},
 	// System privilege (standalone)
@@ -550,20 +900,26 @@ var privilegePatterns = []privilegePattern{
 		// Changed [A-Za-z\s]* to [A-Za-z\s]*? (added a question mark to enable non-greedy matching)
 		// Extended character class to [A-Za-z0-9_\s] to support privilege names with digits and underscores (e.g. AFL__SYS_AFL_AFLPAL_EXECUTE)
 		re: regexp.MustCompile(`(?i)^\s*([A-Za-z](?:[A-Za-z0-9_\s]*?[A-Za-z0-9_])?(?:\.[A-Za-z][A-Za-z0-9_]*)?)` + adminOptionRegex + `\s*$`),
-		build: func(m []string, _ DefaultSchema) Privilege {
+		build: func(m []string, _ DefaultSchema, _ func(string) string) Privilege {
 			return Privilege{Type: SystemPrivilegeType, Name: m[1], IsGrantable: m[2] != ""}
 		},
 	},
 }
 
 func parsePrivilegeString(privStr string, defaultSchema DefaultSchema) (Privilege, error) {
+	return parsePrivilegeStringWith(privStr, defaultSchema, cleanIdentifier)
+}
+
+// parsePrivilegeStringWith parses a privilege string, turning the identifiers
+// it names into names with clean.
+func parsePrivilegeStringWith(privStr string, defaultSchema DefaultSchema, clean func(string) string) (Privilege, error) {
 	upper := strings.ToUpper(strings.TrimSpace(privStr))
 	// - System privilege: no " ON " clause, and suffix is WITH ADMIN OPTION.
 	// - Non-system privilege: has " ON " and suffix is WITH GRANT OPTION.
 	hasOn := strings.Contains(upper, " ON ")
 	for _, pp := range privilegePatterns {
 		if m := pp.re.FindStringSubmatch(privStr); m != nil {
-			priv := pp.build(m, defaultSchema)
+			priv := pp.build(m, defaultSchema, clean)
 			// semantic validation
 			if priv.Type == SystemPrivilegeType {
 				// system privilege must NOT have ON
@@ -572,7 +928,7 @@ func parsePrivilegeString(privStr string, defaultSchema DefaultSchema) (Privileg
 				}
 				// system privilege must NOT use GRANT OPTION
 				if strings.HasSuffix(upper, "WITH GRANT OPTION") {
-					return Privilege{}, fmt.Errorf(errPrivilegeInvalidGrantOption, privStr)
+					return Privilege{}, parseErrorf(errPrivilegeInvalidGrantOption, privStr)
 				}
 			}
 			return priv, nil
@@ -580,81 +936,132 @@ func parsePrivilegeString(privStr string, defaultSchema DefaultSchema) (Privileg
 	}
 	// fallback suffix validation
 	if !hasOn && strings.HasSuffix(upper, "WITH GRANT OPTION") {
-		return Privilege{}, fmt.Errorf(errPrivilegeInvalidAdminOption, privStr)
+		return Privilege{}, parseErrorf(errPrivilegeInvalidAdminOption, privStr)
 	}
 	if hasOn && strings.HasSuffix(upper, "WITH ADMIN OPTION") {
-		return Privilege{}, fmt.Errorf(errPrivilegeInvalidAdminOption, privStr)
+		return Privilege{}, parseErrorf(errPrivilegeInvalidAdminOption, privStr)
 	}
 
-	return Privilege{}, fmt.Errorf(errUnknownPrivilege, privStr)
+	return Privilege{}, parseErrorf(errUnknownPrivilege, privStr)
 }
 
 // groupPrivilegesByTypeAndIdentifier groups by Type, Identifier, and NOW IsGrantable status
 func groupPrivilegesByTypeAndIdentifier(privileges []Privilege) []PrivilegeGroup {
 	type groupKey struct {
-		pType       PrivilegeType
-		identifier  string
-		isGrantable bool
+		pType         PrivilegeType
+		identifier    string
+		subIdentifier string
+		isGrantable   bool
 	}
 
 	groupsMap := make(map[groupKey][]string)
+	members := make(map[groupKey][]Privilege)
+	// keys keeps the groups in the order of the privileges, so the emitted
+	// statements do not change between reconciles
+	var keys []groupKey
 	for _, p := range privileges {
-		// For object privileges, use the full object reference as the identifier
-		identifier := p.Identifier
-		if p.Type == ObjectPrivilegeType && p.SubIdentifier != "" {
-			identifier = p.Identifier + "." + p.SubIdentifier
+		// Object and structured privileges are grouped by the full object
+		// reference
+		key := groupKey{p.Type, p.Identifier, "", p.IsGrantable}
+		if p.Type == ObjectPrivilegeType || p.Type == StructuredPrivilegeType {
+			key.subIdentifier = p.SubIdentifier
+		}
+		if _, ok := groupsMap[key]; !ok {
+			keys = append(keys, key)
 		}
-
-		key := groupKey{p.Type, identifier, p.IsGrantable}
 		groupsMap[key] = append(groupsMap[key], p.Name)
+		members[key] = append(members[key], p)
 	}
 
 	res := make([]PrivilegeGroup, 0, len(groupsMap))
-	for key, names := range groupsMap {
+	for _, key := range keys {
+		names := groupsMap[key]
 		// Generate the base string (e.g. "SELECT, INSERT ON SCHEMA X")
-		var temp Privilege
-		if key.pType == ObjectPrivilegeType && strings.Contains(key.identifier, ".") {
-			// Split back to Identifier and SubIdentifier for object privileges
-			parts := strings.SplitN(key.identifier, ".", 2)
-			temp = Privilege{Type: key.pType, Name: strings.Join(names, ", "), Identifier: parts[0], SubIdentifier: parts[1]}
-		} else {
-			temp = Privilege{Type: key.pType, Name: strings.Join(names, ", "), Identifier: key.identifier}
-		}
+		temp := Privilege{Type: key.pType, Name: strings.Join(names, ", "), Identifier: key.identifier, SubIdentifier: key.subIdentifier}
 		res = append(res, PrivilegeGroup{
 			Body:        temp.baseString(),
 			IsGrantable: key.isGrantable,
 			Type:        key.pType,
+			Privileges:  members[key],
 		})
 	}
 	return res
 }
 
+// DefaultPrivilegeTemplate is the privilege a user is expected to hold on its
+// own schema unless the ProviderConfig configures another one. ${USERNAME} is
+// replaced by the username.
+const DefaultPrivilegeTemplate = `CREATE ANY ON SCHEMA "${USERNAME}" WITH GRANT OPTION`
+
 func GetDefaultPrivilege(defaultSchema string) string {
-	return fmt.Sprintf(`CREATE ANY ON SCHEMA "%s" WITH GRANT OPTION`, defaultSchema)
+	return ExpandDefaultPrivilege("", defaultSchema)
 }
 
-// FilterManagedPrivileges filters the observed privileges based on the management policy
-func FilterManagedPrivileges(observed *v1alpha1.UserObservation, specPrivileges []string, prevPrivileges []string, policy, defaultSchema string) (*v1alpha1.UserObservation, error) {
+// ExpandDefaultPrivilege returns the default privilege of a user from a
+// template, or from the DefaultPrivilegeTemplate if it is empty.
+func ExpandDefaultPrivilege(template, username string) string {
+	if template == "" {
+		template = DefaultPrivilegeTemplate
+	}
+	return strings.ReplaceAll(template, "${USERNAME}", username)
+}
+
+// FilterManagedPrivileges filters the observed privileges based on the management policy.
+// Under the selector policy, the privileges matched by the selectors are managed
+// in addition to those of the spec.
+func FilterManagedPrivileges(observed *v1alpha1.UserObservation, specPrivileges []string, prevPrivileges []string, policy, defaultSchema string, selectors ...v1alpha1.PrivilegeSelector) (*v1alpha1.UserObservation, error) {
 	if observed == nil {
 		return nil, errors.New(ErrObservationNil)
 	}
 
+	var selected func(string) bool
 	switch policy {
 	case "strict":
 		return observed, nil
 	case "lax":
-		defaultPrivilege := GetDefaultPrivilege(defaultSchema)
-		managedPrivs := make([]string, 0, len(observed.Privileges))
-		for _, p := range observed.Privileges {
-			if p != defaultPrivilege && (slices.Contains(specPrivileges, p) || slices.Contains(prevPrivileges, p)) {
-				managedPrivs = append(managedPrivs, p)
-			}
+		selected = func(string) bool { return false }
+	case "selector":
+		var err error
+		if selected, err = compileSelectors(selectors); err != nil {
+			return observed, err
 		}
-		observed.Privileges = managedPrivs
-		return observed, nil
 	default:
 		return observed, fmt.Errorf(ErrUnknownPrivilegeManagementPolicy, policy)
 	}
+
+	defaultPrivilege := GetDefaultPrivilege(defaultSchema)
+	managedPrivs := make([]string, 0, len(observed.Privileges))
+	for _, p := range observed.Privileges {
+		if p != defaultPrivilege && (slices.Contains(specPrivileges, p) || slices.Contains(prevPrivileges, p) || selected(p)) {
+			managedPrivs = append(managedPrivs, p)
+		}
+	}
+	observed.Privileges = managedPrivs
+	return observed, nil
+}
+
+// compileSelectors returns a function reporting whether a privilege is
+// matched by any of the selectors.
+func compileSelectors(selectors []v1alpha1.PrivilegeSelector) (func(string) bool, error) {
+	prefixes := make([]string, 0, len(selectors))
+	regexes := make([]*regexp.Regexp, 0, len(selectors))
+	for _, s := range selectors {
+		if s.Prefix != "" {
+			prefixes = append(prefixes, s.Prefix)
+		}
+		if s.Regex == "" {
+			continue
+		}
+		re, err := regexp.Compile(s.Regex)
+		if err != nil {
+			return nil, fmt.Errorf(ErrInvalidPrivilegeSelector, s.Regex, err)
+		}
+		regexes = append(regexes, re)
+	}
+	return func(privilege string) bool {
+		return slices.ContainsFunc(prefixes, func(prefix string) bool { return strings.HasPrefix(privilege, prefix) }) ||
+			slices.ContainsFunc(regexes, func(re *regexp.Regexp) bool { return re.MatchString(privilege) })
+	}, nil
 }
 
 // createSystemPrivilege creates a system privilege
@@ -704,7 +1111,11 @@ func handlePrivilegeRows(privRows *sql.Rows) (Privilege, error) {
 	if err := privRows.Scan(&objectType, &privilege, &schemaName, &objectName, &isGrantable); err != nil {
 		return Privilege{}, err
 	}
+	return grantedPrivilege(objectType, privilege, schemaName, objectName, isGrantable)
+}
 
+// grantedPrivilege returns the privilege of a row of GRANTED_PRIVILEGES.
+func grantedPrivilege(objectType, privilege string, schemaName, objectName sql.NullString, isGrantable bool) (Privilege, error) {
 	switch objectType {
 	case "SYSTEMPRIVILEGE":
 		return createSystemPrivilege(privilege, isGrantable), nil
@@ -732,6 +1143,17 @@ func handlePrivilegeRows(privRows *sql.Rows) (Privilege, error) {
 			IsGrantable: isGrantable,
 		}, nil
 	case "STRUCTURED_PRIVILEGE":
+		// Structured privileges of HDI containers report the container
+		// schema, repository ones report none
+		if schemaName.String != "" {
+			return Privilege{
+				Type:          StructuredPrivilegeType,
+				Name:          "STRUCTURED PRIVILEGE",
+				Identifier:    schemaName.String,
+				SubIdentifier: objectName.String,
+				IsGrantable:   isGrantable,
+			}, nil
+		}
 		return Privilege{
 			Type:        StructuredPrivilegeType,
 			Name:        "STRUCTURED PRIVILEGE",
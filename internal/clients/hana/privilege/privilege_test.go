@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"reflect"
 	"regexp"
+	"slices"
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
@@ -32,7 +33,7 @@ func TestPrivilegeClient_Grant(t *testing.T) {
 				MockExecContext: func(ctx context.Context, query string, args ...any) (sql.Result, error) { return nil, errBoom },
 			},
 			input:   []string{"SELECT"},
-			wantErr: errBoom,
+			wantErr: &GrantError{Failures: []GrantFailure{{Privilege: "SELECT", Err: errBoom}}},
 		},
 		"GrantSuccess": {
 			reason: "Should successfully grant single privilege",
@@ -80,6 +81,99 @@ func TestPrivilegeClient_Grant(t *testing.T) {
 	}
 }
 
+// TestStructuredPrivilegeRoundTrip verifies that structured privileges of
+// the spec are granted by their full name and observed as written in the
+// spec, so they do not drift after being granted.
+func TestStructuredPrivilegeRoundTrip(t *testing.T) {
+	cases := map[string]struct {
+		spec      string
+		schema    sql.NullString
+		object    string
+		wantGrant string
+	}{
+		"Repository": {
+			spec:      `STRUCTURED PRIVILEGE "sap.hana.demo::AP_SALES"`,
+			object:    "sap.hana.demo::AP_SALES",
+			wantGrant: `GRANT STRUCTURED PRIVILEGE "sap.hana.demo::AP_SALES" TO USER1`,
+		},
+		"Container": {
+			spec:      `STRUCTURED PRIVILEGE MY_HDI."com.acme::SP_REGION"`,
+			schema:    sql.NullString{String: "MY_HDI", Valid: true},
+			object:    "com.acme::SP_REGION",
+			wantGrant: `GRANT STRUCTURED PRIVILEGE "MY_HDI"."com.acme::SP_REGION" TO USER1`,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var granted []string
+			db := fake.MockDB{
+				MockExecContext: func(ctx context.Context, query string, args ...any) (sql.Result, error) {
+					granted = append(granted, query)
+					return nil, nil
+				},
+				MockQueryContext: func(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+					return fake.MockRowsToSQLRows(sqlmock.NewRows([]string{"OBJECT_TYPE", "PRIVILEGE", "SCHEMA_NAME", "OBJECT_NAME", "IS_GRANTABLE"}).
+						AddRow("STRUCTURED_PRIVILEGE", "STRUCTURED PRIVILEGE", tc.schema, sql.NullString{String: tc.object, Valid: true}, false)), nil
+				},
+			}
+			c := &PrivilegeClient{DB: db}
+			if err := c.GrantPrivileges(context.Background(), "defaultschema", "USER1", []string{tc.spec}); err != nil {
+				t.Fatalf("GrantPrivileges(): %v", err)
+			}
+			if diff := cmp.Diff([]string{tc.wantGrant}, granted); diff != "" {
+				t.Errorf("GrantPrivileges(): -want, +got:\n%s", diff)
+			}
+
+			observed, err := c.QueryPrivileges(context.Background(), "USER1", GranteeTypeUser)
+			if err != nil {
+				t.Fatalf("QueryPrivileges(): %v", err)
+			}
+			desired, err := FormatPrivilegeStrings([]string{tc.spec}, "defaultschema")
+			if err != nil {
+				t.Fatalf("FormatPrivilegeStrings(): %v", err)
+			}
+			if diff := cmp.Diff(desired, observed); diff != "" {
+				t.Errorf("observed privileges differ from the spec: -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(FoldPrivilegeStrings([]string{tc.spec}), observed); diff != "" {
+				t.Errorf("observed privileges differ from the folded spec: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestPrivilegeClient_GrantIsolatesFailures(t *testing.T) {
+	errBoom := errors.New("boom")
+	var granted []string
+	db := fake.MockDB{
+		MockExecContext: func(ctx context.Context, query string, args ...any) (sql.Result, error) {
+			if regexp.MustCompile(`\bINSERT\b`).MatchString(query) {
+				return nil, errBoom
+			}
+			granted = append(granted, query)
+			return nil, nil
+		},
+	}
+
+	c := &PrivilegeClient{DB: db}
+	err := c.GrantPrivileges(context.Background(), "defaultschema", "USER1", []string{"SELECT ON SCHEMA myschema", "INSERT ON SCHEMA myschema", "CATALOG READ"})
+
+	var grantErr *GrantError
+	if !errors.As(err, &grantErr) {
+		t.Fatalf("GrantPrivileges(...): want *GrantError, got %v", err)
+	}
+	want := []GrantFailure{{Privilege: `INSERT ON SCHEMA "myschema"`, Err: errBoom}}
+	if diff := cmp.Diff(want, grantErr.Failures, cmpopts.EquateErrors()); diff != "" {
+		t.Errorf("GrantPrivileges(...): -want failures, +got failures:\n%s", diff)
+	}
+	if !errors.Is(err, errBoom) {
+		t.Errorf("GrantPrivileges(...): want error to wrap %v", errBoom)
+	}
+	if !slices.Contains(granted, `GRANT SELECT ON SCHEMA "myschema" TO USER1`) || !slices.Contains(granted, "GRANT CATALOG READ TO USER1") {
+		t.Errorf("GrantPrivileges(...): want remaining privileges granted, got %v", granted)
+	}
+}
+
 func TestPrivilegeClient_Revoke(t *testing.T) {
 	errBoom := errors.New("boom")
 	cases := map[string]struct {
@@ -181,6 +275,14 @@ func TestPrivilegeClient_QueryPrivileges(t *testing.T) {
 			want:    []string{"SELECT ON SCHEMA \"SCHEMA1\" WITH GRANT OPTION", "LINKED DATABASE ON REMOTE SOURCE \"myremotesys\""},
 			wantErr: false,
 		},
+		"StructuredPrivileges": {
+			reason: "Should qualify structured privileges of HDI containers with their schema and keep repository names as written",
+			mockRows: sqlmock.NewRows([]string{"OBJECT_TYPE", "PRIVILEGE", "SCHEMA_NAME", "OBJECT_NAME", "IS_GRANTABLE"}).
+				AddRow("STRUCTURED_PRIVILEGE", "STRUCTURED PRIVILEGE", sql.NullString{String: "MY_HDI", Valid: true}, sql.NullString{String: "com.acme::SP_REGION", Valid: true}, false).
+				AddRow("STRUCTURED_PRIVILEGE", "STRUCTURED PRIVILEGE", sql.NullString{Valid: false}, sql.NullString{String: "sap.hana.demo::AP_SALES", Valid: true}, true),
+			want:    []string{`STRUCTURED PRIVILEGE "MY_HDI"."com.acme::SP_REGION"`, `STRUCTURED PRIVILEGE "sap.hana.demo::AP_SALES" WITH GRANT OPTION`},
+			wantErr: false,
+		},
 		"QueryError": {
 			reason:   "Should return error when database query fails",
 			mockRows: nil,
@@ -211,6 +313,62 @@ func TestPrivilegeClient_QueryPrivileges(t *testing.T) {
 	}
 }
 
+func TestPrivilegeClient_QueryPrivilegeGrants(t *testing.T) {
+	columns := []string{"OBJECT_TYPE", "PRIVILEGE", "SCHEMA_NAME", "OBJECT_NAME", "IS_GRANTABLE", "GRANTOR"}
+
+	cases := map[string]struct {
+		reason   string
+		mockRows *sqlmock.Rows
+		mockErr  error
+		want     []PrivilegeGrant
+		wantErr  bool
+	}{
+		"NoRows": {
+			reason:   "Should return empty slice when user has no privileges",
+			mockRows: sqlmock.NewRows(columns),
+			want:     []PrivilegeGrant{},
+		},
+		"Grantors": {
+			reason: "Should return each grant of a privilege with its grantor",
+			mockRows: sqlmock.NewRows(columns).
+				AddRow("SYSTEMPRIVILEGE", "CATALOG READ", sql.NullString{}, sql.NullString{}, false, "CROSSPLANE").
+				AddRow("SYSTEMPRIVILEGE", "CATALOG READ", sql.NullString{}, sql.NullString{}, false, "ADMIN").
+				AddRow("SCHEMA", "SELECT", sql.NullString{String: "SALES", Valid: true}, sql.NullString{}, true, "ADMIN"),
+			want: []PrivilegeGrant{
+				{Privilege: "CATALOG READ", Grantor: "CROSSPLANE"},
+				{Privilege: "CATALOG READ", Grantor: "ADMIN"},
+				{Privilege: `SELECT ON SCHEMA "SALES" WITH GRANT OPTION`, Grantor: "ADMIN"},
+			},
+		},
+		"QueryError": {
+			reason:  "Should return error when database query fails",
+			mockErr: errors.New("boom"),
+			want:    []PrivilegeGrant{},
+			wantErr: true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			db := fake.MockDB{
+				MockQueryContext: func(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+					if tc.mockErr != nil {
+						return nil, tc.mockErr
+					}
+					return fake.MockRowsToSQLRows(tc.mockRows), nil
+				},
+			}
+			c := &PrivilegeClient{DB: db}
+			got, err := c.QueryPrivilegeGrants(context.Background(), "USER1", GranteeTypeUser)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("\n%s\nQueryPrivilegeGrants() error = %v, wantErr %v", tc.reason, err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nQueryPrivilegeGrants(): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
 func TestPrivilegeClient_QueryRoles(t *testing.T) {
 	cases := map[string]struct {
 		reason   string
@@ -230,7 +388,14 @@ func TestPrivilegeClient_QueryRoles(t *testing.T) {
 			mockRows: sqlmock.NewRows([]string{"ROLE_SCHEMA_NAME", "ROLE_NAME", "IS_GRANTABLE"}).
 				AddRow(sql.NullString{String: "SCHEMA1", Valid: true}, "ROLE1", true).
 				AddRow(sql.NullString{String: "SCHEMA2", Valid: true}, "ROLE2", false),
-			want:    []string{`"SCHEMA1.ROLE1" WITH ADMIN OPTION`, `"SCHEMA2.ROLE2"`},
+			want:    []string{`"SCHEMA1"."ROLE1" WITH ADMIN OPTION`, `"SCHEMA2"."ROLE2"`},
+			wantErr: false,
+		},
+		"ContainerRoles": {
+			reason: "Should quote the schema and name of roles of HDI containers separately",
+			mockRows: sqlmock.NewRows([]string{"ROLE_SCHEMA_NAME", "ROLE_NAME", "IS_GRANTABLE"}).
+				AddRow(sql.NullString{String: "MY_HDI", Valid: true}, "my.app::admin", false),
+			want:    []string{`"MY_HDI"."my.app::admin"`},
 			wantErr: false,
 		},
 		"UnqualifiedRoles": {
@@ -362,6 +527,24 @@ func Test_stringToPrivilege(t *testing.T) {
 			want: Privilege{Type: StructuredPrivilegeType, Name: "STRUCTURED PRIVILEGE", Identifier: "mystruct"},
 			ok:   true,
 		},
+		{
+			name: "RepositoryStructuredPrivilege",
+			in:   "STRUCTURED PRIVILEGE sap.hana.demo::AP_SALES",
+			want: Privilege{Type: StructuredPrivilegeType, Name: "STRUCTURED PRIVILEGE", Identifier: "sap.hana.demo::AP_SALES"},
+			ok:   true,
+		},
+		{
+			name: "QuotedRepositoryStructuredPrivilege",
+			in:   `STRUCTURED PRIVILEGE "sap.hana.demo::AP_SALES" WITH GRANT OPTION`,
+			want: Privilege{Type: StructuredPrivilegeType, Name: "STRUCTURED PRIVILEGE", Identifier: "sap.hana.demo::AP_SALES", IsGrantable: true},
+			ok:   true,
+		},
+		{
+			name: "ContainerStructuredPrivilege",
+			in:   `STRUCTURED PRIVILEGE MY_HDI."com.acme::SP_REGION"`,
+			want: Privilege{Type: StructuredPrivilegeType, Name: "STRUCTURED PRIVILEGE", Identifier: "MY_HDI", SubIdentifier: "com.acme::SP_REGION"},
+			ok:   true,
+		},
 		{
 			name: "EmptyString",
 			in:   "",
@@ -548,7 +731,7 @@ func Test_groupPrivilegesByType(t *testing.T) {
 		regexp.MustCompile(`USAGE ON CLIENTSIDE ENCRYPTION COLUMN KEY my_cek`),
 		regexp.MustCompile(`LINKED DATABASE ON REMOTE SOURCE "myremotesys"`),
 		regexp.MustCompile(`USERGROUP OPERATOR ON USERGROUP "mygroup"`),
-		regexp.MustCompile(`STRUCTURED PRIVILEGE mystruct`),
+		regexp.MustCompile(`STRUCTURED PRIVILEGE "mystruct"`),
 	}
 	for _, pattern := range expectPatterns {
 		found := false
@@ -583,7 +766,7 @@ func Test_groupPrivilegesByTypeAndIdentifier(t *testing.T) {
 		regexp.MustCompile(`USAGE ON CLIENTSIDE ENCRYPTION COLUMN KEY my_cek`),
 		regexp.MustCompile(`LINKED DATABASE ON REMOTE SOURCE "myremotesys"`),
 		regexp.MustCompile(`USERGROUP OPERATOR ON USERGROUP "mygroup"`),
-		regexp.MustCompile(`STRUCTURED PRIVILEGE mystruct`),
+		regexp.MustCompile(`STRUCTURED PRIVILEGE "mystruct"`),
 	}
 	for _, pattern := range expectPatterns {
 		found := false
@@ -599,6 +782,23 @@ func Test_groupPrivilegesByTypeAndIdentifier(t *testing.T) {
 	}
 }
 
+func Test_groupPrivilegesByTypeAndIdentifier_DottedNames(t *testing.T) {
+	privs := []Privilege{
+		{Type: ObjectPrivilegeType, Name: "SELECT", Identifier: "my.app", SubIdentifier: "pkg.sub::calcview"},
+		{Type: ObjectPrivilegeType, Name: "SELECT", Identifier: "my", SubIdentifier: "app.pkg.sub::calcview"},
+	}
+	got := groupPrivilegesByTypeAndIdentifier(privs)
+	want := []string{`SELECT ON "my.app"."pkg.sub::calcview"`, `SELECT ON "my"."app.pkg.sub::calcview"`}
+	if len(got) != len(want) {
+		t.Fatalf("groupPrivilegesByTypeAndIdentifier() = %v, want bodies %v", got, want)
+	}
+	for i, g := range got {
+		if g.Body != want[i] {
+			t.Errorf("groupPrivilegesByTypeAndIdentifier()[%d].Body = %q, want %q", i, g.Body, want[i])
+		}
+	}
+}
+
 func Test_groupPrivilegesByTypeAndIdentifier_GrantableSplit(t *testing.T) {
 	privs := []Privilege{
 		{Type: ObjectPrivilegeType, Name: "SELECT", Identifier: "S1", SubIdentifier: "T1", IsGrantable: true},
@@ -651,6 +851,7 @@ func TestFilterManagedPrivileges(t *testing.T) {
 		specPrivileges []string
 		prevPrivileges []string
 		policy         string
+		selectors      []v1alpha1.PrivilegeSelector
 	}
 
 	type want struct {
@@ -915,11 +1116,57 @@ func TestFilterManagedPrivileges(t *testing.T) {
 				err: nil,
 			},
 		},
+		"SelectorPolicy": {
+			reason: "Selector policy should manage the privileges of the spec and those matched by the selectors",
+			args: args{
+				observed: &v1alpha1.UserObservation{
+					Username: new("test_user"),
+					Privileges: []string{
+						GetDefaultPrivilege("test_user"),
+						"CATALOG READ",
+						"TRACE ADMIN",
+						`SELECT ON SCHEMA "APP_1"`,
+						`INSERT ON SCHEMA "APP_2"`,
+						`SELECT ON SCHEMA "OTHER"`,
+					},
+				},
+				specPrivileges: []string{"CATALOG READ"},
+				policy:         "selector",
+				selectors: []v1alpha1.PrivilegeSelector{
+					{Regex: `ON SCHEMA "APP_[^"]*"$`},
+					{Prefix: "TRACE"},
+				},
+			},
+			want: want{
+				result: &v1alpha1.UserObservation{
+					Username:   new("test_user"),
+					Privileges: []string{"CATALOG READ", "TRACE ADMIN", `SELECT ON SCHEMA "APP_1"`, `INSERT ON SCHEMA "APP_2"`},
+				},
+			},
+		},
+		"SelectorPolicyInvalidRegex": {
+			reason: "Selector policy should return an error for an invalid regex",
+			args: args{
+				observed: &v1alpha1.UserObservation{
+					Username:   new("test_user"),
+					Privileges: []string{"SELECT"},
+				},
+				policy:    "selector",
+				selectors: []v1alpha1.PrivilegeSelector{{Regex: "APP_("}},
+			},
+			want: want{
+				result: &v1alpha1.UserObservation{
+					Username:   new("test_user"),
+					Privileges: []string{"SELECT"},
+				},
+				err: fmt.Errorf(ErrInvalidPrivilegeSelector, "APP_(", errors.New("error parsing regexp: missing closing ): `APP_(`")),
+			},
+		},
 	}
 
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
-			got, err := FilterManagedPrivileges(tc.args.observed, tc.args.specPrivileges, tc.args.prevPrivileges, tc.args.policy, "test_user")
+			got, err := FilterManagedPrivileges(tc.args.observed, tc.args.specPrivileges, tc.args.prevPrivileges, tc.args.policy, "test_user", tc.args.selectors...)
 
 			if tc.want.err != nil {
 				if err == nil {
@@ -1049,7 +1296,7 @@ func TestFormatPrivilegeStrings_WithGrantableOptions(t *testing.T) {
 		"SELECT ON SCHEMA myschema WITH GRANT OPTION",
 		"INSERT ON myobj WITH GRANT OPTION",
 		"CREATE SCHEMA WITH ADMIN OPTION",
-		"STRUCTURED PRIVILEGE mystruct WITH GRANT OPTION",
+		`STRUCTURED PRIVILEGE "mystruct" WITH GRANT OPTION`,
 		"USAGE ON CLIENTSIDE ENCRYPTION COLUMN KEY my_cek WITH GRANT OPTION",
 		"USERGROUP OPERATOR ON USERGROUP mygroup WITH GRANT OPTION",
 		"ROLE ADMIN WITH ADMIN OPTION",
@@ -1062,7 +1309,7 @@ func TestFormatPrivilegeStrings_WithGrantableOptions(t *testing.T) {
 		`SELECT ON SCHEMA "myschema" WITH GRANT OPTION`,
 		`INSERT ON "S1"."myobj" WITH GRANT OPTION`,
 		"CREATE SCHEMA WITH ADMIN OPTION",
-		"STRUCTURED PRIVILEGE mystruct WITH GRANT OPTION",
+		`STRUCTURED PRIVILEGE "mystruct" WITH GRANT OPTION`,
 		"USAGE ON CLIENTSIDE ENCRYPTION COLUMN KEY my_cek WITH GRANT OPTION",
 		`USERGROUP OPERATOR ON USERGROUP "mygroup" WITH GRANT OPTION`,
 		"ROLE ADMIN WITH ADMIN OPTION",
@@ -1098,7 +1345,33 @@ func TestParseRoleString_WithOptions(t *testing.T) {
 		{
 			name: "SchemaQualifiedRoleWithAdmin",
 			in:   "MYSCHEMA.ROLE1 WITH ADMIN OPTION",
-			want: Role{Name: "MYSCHEMA.ROLE1", IsGrantable: true},
+			want: Role{Schema: "MYSCHEMA", Name: "ROLE1", IsGrantable: true},
+		},
+		// Roles of HDI containers are qualified by the schema of the container
+		{
+			name: "ContainerRole",
+			in:   "MY_HDI.my.app::admin WITH ADMIN OPTION",
+			want: Role{Schema: "MY_HDI", Name: "my.app::admin", IsGrantable: true},
+		},
+		{
+			name: "QuotedContainerRole",
+			in:   `"my_hdi"."my.app::admin"`,
+			want: Role{Schema: `"my_hdi"`, Name: `"my.app::admin"`},
+		},
+		{
+			name: "ContainerRoleQuotedName",
+			in:   `my_hdi."admin"`,
+			want: Role{Schema: "my_hdi", Name: `"admin"`},
+		},
+		{
+			name: "QuotedContainerRoleAdminOptionWithoutSpace",
+			in:   `"my_hdi"."my.app::admin"WITH ADMIN OPTION`,
+			want: Role{Schema: `"my_hdi"`, Name: `"my.app::admin"`, IsGrantable: true},
+		},
+		{
+			name: "QuotedRoleWithDot",
+			in:   `"my.role"`,
+			want: Role{Name: `"my.role"`},
 		},
 		// Special character role name tests (e.g., HANA namespace-style roles)
 		{
@@ -1549,6 +1822,19 @@ func TestGrantRevokeRoles_SpecialCharRoleName(t *testing.T) {
 			grantee:   "TESTUSER",
 			wantSQL:   `GRANT "my_role" TO TESTUSER`,
 		},
+		{
+			name:      "GrantContainerRole",
+			roleNames: []string{"MY_HDI.my.app::admin WITH ADMIN OPTION"},
+			grantee:   "TESTUSER",
+			wantSQL:   `GRANT "MY_HDI"."my.app::admin" TO TESTUSER WITH ADMIN OPTION`,
+		},
+		{
+			name:      "RevokeQuotedContainerRole",
+			roleNames: []string{`"MY_HDI"."my.app::admin"`},
+			grantee:   "TESTUSER",
+			isRevoke:  true,
+			wantSQL:   `REVOKE "MY_HDI"."my.app::admin" FROM TESTUSER`,
+		},
 	}
 
 	for _, tc := range cases {
@@ -1644,7 +1930,17 @@ func TestFormatRoleStrings(t *testing.T) {
 		{
 			name:  "SchemaQualifiedRole",
 			input: []string{"MYSCHEMA.ROLE1 WITH ADMIN OPTION"},
-			want:  []string{`"MYSCHEMA.ROLE1" WITH ADMIN OPTION`},
+			want:  []string{`"MYSCHEMA"."ROLE1" WITH ADMIN OPTION`},
+		},
+		{
+			name:  "ContainerRole",
+			input: []string{`MY_HDI.my.app::admin`, `"MY_HDI"."my.app::admin"`, `MY_HDI."my.app::admin"`},
+			want:  []string{`"MY_HDI"."my.app::admin"`, `"MY_HDI"."my.app::admin"`, `"MY_HDI"."my.app::admin"`},
+		},
+		{
+			name:  "RepositoryRole",
+			input: []string{"sap.hana.xs.admin.roles::RuntimeConfAdministrator"},
+			want:  []string{`"sap.hana.xs.admin.roles::RuntimeConfAdministrator"`},
 		},
 		{
 			name:    "InvalidRoleString",
@@ -1670,3 +1966,318 @@ func TestFormatRoleStrings(t *testing.T) {
 		})
 	}
 }
+
+func TestNormalizePrivilegeStrings(t *testing.T) {
+	cases := []struct {
+		name    string
+		input   []string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name:  "SchemaPrivilegeQuoted",
+			input: []string{"SELECT ON SCHEMA myschema"},
+			want:  []string{`SELECT ON SCHEMA "myschema"`},
+		},
+		{
+			name:  "QualifiedObjectPrivilegeQuoted",
+			input: []string{"SELECT ON myschema.mytable WITH GRANT OPTION"},
+			want:  []string{`SELECT ON "myschema"."mytable" WITH GRANT OPTION`},
+		},
+		{
+			name:  "UnqualifiedObjectPrivilegeKept",
+			input: []string{" SELECT ON mytable "},
+			want:  []string{"SELECT ON mytable"},
+		},
+		{
+			name:  "RepositoryProcedure",
+			input: []string{"EXECUTE ON myschema.pkg::proc"},
+			want:  []string{`EXECUTE ON "myschema"."pkg::proc"`},
+		},
+		{
+			name:  "RepositoryObjectWithPackage",
+			input: []string{"SELECT ON _SYS_BIC.pkg.sub::calcview", "SELECT ON _SYS_BIC.pkg.sub/CV_SALES"},
+			want:  []string{`SELECT ON "_SYS_BIC"."pkg.sub::calcview"`, `SELECT ON "_SYS_BIC"."pkg.sub/CV_SALES"`},
+		},
+		{
+			name:  "RepositoryObjectQuotedAfterUnquotedSchema",
+			input: []string{`SELECT ON _SYS_BIC."pkg.sub::calcview" WITH GRANT OPTION`},
+			want:  []string{`SELECT ON "_SYS_BIC"."pkg.sub::calcview" WITH GRANT OPTION`},
+		},
+		{
+			name:  "DuplicatesRemoved",
+			input: []string{"CATALOG READ", "SELECT ON SCHEMA \"S\"", "SELECT ON SCHEMA S", "CATALOG READ"},
+			want:  []string{"CATALOG READ", `SELECT ON SCHEMA "S"`},
+		},
+		{
+			name:    "InvalidPrivilege",
+			input:   []string{"CATALOG READ WITH GRANT OPTION"},
+			wantErr: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := NormalizePrivilegeStrings(tc.input)
+			if tc.wantErr {
+				if !errors.Is(err, ErrParse) {
+					t.Fatalf("expected parse error, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !cmp.Equal(tc.want, got) {
+				t.Errorf("NormalizePrivilegeStrings() got = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestFoldPrivilegeStrings(t *testing.T) {
+	cases := []struct {
+		name  string
+		input []string
+		want  []string
+	}{
+		{
+			name:  "UnquotedFolded",
+			input: []string{"select on schema myschema", "SELECT ON myschema.mytable WITH GRANT OPTION"},
+			want:  []string{`select ON SCHEMA "MYSCHEMA"`, `SELECT ON "MYSCHEMA"."MYTABLE" WITH GRANT OPTION`},
+		},
+		{
+			name:  "QuotedKept",
+			input: []string{`SELECT ON SCHEMA "mySchema"`, `SELECT ON "mySchema".mytable`},
+			want:  []string{`SELECT ON SCHEMA "mySchema"`, `SELECT ON "mySchema"."MYTABLE"`},
+		},
+		{
+			name:  "UnqualifiedObjectKeepsDefaultSchema",
+			input: []string{"SELECT ON mytable"},
+			want:  []string{`SELECT ON "MYTABLE"`},
+		},
+		{
+			name:  "StructuredRepositoryKept",
+			input: []string{"STRUCTURED PRIVILEGE sap.hana.demo::AP_SALES", "STRUCTURED PRIVILEGE my_ap"},
+			want:  []string{`STRUCTURED PRIVILEGE "sap.hana.demo::AP_SALES"`, `STRUCTURED PRIVILEGE "MY_AP"`},
+		},
+		{
+			name:  "StructuredContainer",
+			input: []string{`STRUCTURED PRIVILEGE my_hdi."com.acme::SP_REGION"`},
+			want:  []string{`STRUCTURED PRIVILEGE "MY_HDI"."com.acme::SP_REGION"`},
+		},
+		{
+			name:  "SystemPrivilegeKept",
+			input: []string{"CATALOG READ WITH ADMIN OPTION"},
+			want:  []string{"CATALOG READ WITH ADMIN OPTION"},
+		},
+		{
+			name:  "InvalidKept",
+			input: []string{"CATALOG READ WITH GRANT OPTION"},
+			want:  []string{"CATALOG READ WITH GRANT OPTION"},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := FoldPrivilegeStrings(tc.input)
+			if !cmp.Equal(tc.want, got) {
+				t.Errorf("FoldPrivilegeStrings() got = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestFoldRoleStrings(t *testing.T) {
+	got := FoldRoleStrings([]string{"reader", `"Writer" WITH ADMIN OPTION`, `my_hdi."my.app::admin"`})
+	want := []string{`"READER"`, `"Writer" WITH ADMIN OPTION`, `"MY_HDI"."my.app::admin"`}
+	if !cmp.Equal(want, got) {
+		t.Errorf("FoldRoleStrings() got = %v, want %v", got, want)
+	}
+}
+
+func TestWithTypedPrivileges(t *testing.T) {
+	typed := []v1alpha1.TypedPrivilege{
+		{Type: v1alpha1.PrivilegeTypeSystem, Name: "catalog read", Grantable: true},
+		{Type: v1alpha1.PrivilegeTypeSchema, Name: "SELECT", Schema: "mySchema"},
+		{Type: v1alpha1.PrivilegeTypeObject, Name: "INSERT", Schema: "S", Object: "my.table", Grantable: true},
+		{Type: v1alpha1.PrivilegeTypeObject, Name: "SELECT", Object: "T"},
+		{Type: v1alpha1.PrivilegeTypeRemoteSource, Name: "CREATE VIRTUAL TABLE", Object: "src"},
+	}
+	got := WithTypedPrivileges([]string{`SELECT ON SCHEMA "mySchema"`, "AUDIT ADMIN"}, typed)
+	want := []string{
+		`SELECT ON SCHEMA "mySchema"`,
+		"AUDIT ADMIN",
+		"CATALOG READ WITH ADMIN OPTION",
+		`INSERT ON "S"."my.table" WITH GRANT OPTION`,
+		`SELECT ON "T"`,
+		`CREATE VIRTUAL TABLE ON REMOTE SOURCE "src"`,
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("WithTypedPrivileges() -want, +got:\n%s", diff)
+	}
+
+	// The strings of typed privileges are in their canonical form already
+	normalized, err := NormalizePrivilegeStrings(got)
+	if err != nil {
+		t.Fatalf("NormalizePrivilegeStrings(): %v", err)
+	}
+	if diff := cmp.Diff(want, normalized); diff != "" {
+		t.Errorf("NormalizePrivilegeStrings() -want, +got:\n%s", diff)
+	}
+}
+
+func TestSplitAdminOptionChanges(t *testing.T) {
+	type want struct {
+		grant     []string
+		revoke    []string
+		downgrade []string
+	}
+	cases := map[string]struct {
+		reason   string
+		toGrant  []string
+		toRevoke []string
+		want     want
+	}{
+		"GrantAndRevoke": {
+			reason:   "Roles with different names should be granted and revoked",
+			toGrant:  []string{`"A"`},
+			toRevoke: []string{`"B" WITH ADMIN OPTION`},
+			want:     want{grant: []string{`"A"`}, revoke: []string{`"B" WITH ADMIN OPTION`}},
+		},
+		"Upgrade": {
+			reason:   "A role gaining the admin option should only be granted",
+			toGrant:  []string{`"A" WITH ADMIN OPTION`},
+			toRevoke: []string{`"A"`},
+			want:     want{grant: []string{`"A" WITH ADMIN OPTION`}},
+		},
+		"Downgrade": {
+			reason:   "A role losing the admin option should be neither granted nor revoked on its own",
+			toGrant:  []string{`"A"`, `"C"`},
+			toRevoke: []string{`"A" WITH ADMIN OPTION`},
+			want:     want{grant: []string{`"C"`}, downgrade: []string{`"A"`}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			grant, revoke, downgrade, err := SplitAdminOptionChanges(tc.toGrant, tc.toRevoke)
+			if err != nil {
+				t.Fatalf("SplitAdminOptionChanges(...): unexpected error: %v", err)
+			}
+			got := want{grant: grant, revoke: revoke, downgrade: downgrade}
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("\n%s\nSplitAdminOptionChanges(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestExpandDefaultPrivilege(t *testing.T) {
+	cases := map[string]struct {
+		reason   string
+		template string
+		want     string
+	}{
+		"Default": {
+			reason: "The default template should be used without a template",
+			want:   `CREATE ANY ON SCHEMA "DEMO_USER" WITH GRANT OPTION`,
+		},
+		"WithoutGrantOption": {
+			reason:   "The username should be substituted into the template",
+			template: `CREATE ANY ON SCHEMA "${USERNAME}"`,
+			want:     `CREATE ANY ON SCHEMA "DEMO_USER"`,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := ExpandDefaultPrivilege(tc.template, "DEMO_USER"); got != tc.want {
+				t.Errorf("\n%s\nExpandDefaultPrivilege(...): want %s, got %s", tc.reason, tc.want, got)
+			}
+		})
+	}
+}
+
+// TestGrantRoles_SchemaLocalAdminOption verifies that the schema and the name
+// of schema-local roles are quoted each on their own, also when they are
+// granted with the admin option together with other roles.
+func TestGrantRoles_SchemaLocalAdminOption(t *testing.T) {
+	cases := map[string]struct {
+		reason    string
+		roleNames []string
+		want      []string
+	}{
+		"QuotedSchema": {
+			reason:    "A quoted schema should be kept, the unquoted role quoted",
+			roleNames: []string{`"MY SCHEMA".READER WITH ADMIN OPTION`},
+			want:      []string{`GRANT "MY SCHEMA"."READER" TO "DEMO_USER" WITH ADMIN OPTION`},
+		},
+		"QuotedRole": {
+			reason:    "A quoted role should be kept, the unquoted schema quoted",
+			roleNames: []string{`APP."read role" WITH ADMIN OPTION`},
+			want:      []string{`GRANT "APP"."read role" TO "DEMO_USER" WITH ADMIN OPTION`},
+		},
+		"QuotedSchemaAndRole": {
+			reason:    "Quoted schemas and roles should be kept as written",
+			roleNames: []string{`"MY SCHEMA"."read role" WITH ADMIN OPTION`},
+			want:      []string{`GRANT "MY SCHEMA"."read role" TO "DEMO_USER" WITH ADMIN OPTION`},
+		},
+		"EscapedQuotes": {
+			reason:    "Quotes within quoted schemas and roles should stay escaped",
+			roleNames: []string{`"SCHE""MA"."RO""LE" WITH ADMIN OPTION`},
+			want:      []string{`GRANT "SCHE""MA"."RO""LE" TO "DEMO_USER" WITH ADMIN OPTION`},
+		},
+		"DotInQuotedSchema": {
+			reason:    "A dot within a quoted schema should not split it",
+			roleNames: []string{`"MY.SCHEMA"."READER" WITH ADMIN OPTION`},
+			want:      []string{`GRANT "MY.SCHEMA"."READER" TO "DEMO_USER" WITH ADMIN OPTION`},
+		},
+		"OptionInQuotedRole": {
+			reason:    "An admin option within a quoted role should be part of its name",
+			roleNames: []string{`"APP"."READER WITH ADMIN OPTION"`},
+			want:      []string{`GRANT "APP"."READER WITH ADMIN OPTION" TO "DEMO_USER"`},
+		},
+		"NoSpaceBeforeOption": {
+			reason:    "The admin option may directly follow a quoted role, as HANA accepts it",
+			roleNames: []string{`"APP"."READER"WITH ADMIN OPTION`},
+			want:      []string{`GRANT "APP"."READER" TO "DEMO_USER" WITH ADMIN OPTION`},
+		},
+		"LowercaseOption": {
+			reason:    "The admin option should be recognized in any case and spacing",
+			roleNames: []string{`"APP"."READER"  with  admin  option`},
+			want:      []string{`GRANT "APP"."READER" TO "DEMO_USER" WITH ADMIN OPTION`},
+		},
+		"Mixed": {
+			reason:    "Roles with and without the admin option should be granted in separate statements",
+			roleNames: []string{`"APP"."READER" WITH ADMIN OPTION`, `"APP"."WRITER"`, `"MY SCHEMA".ADMIN WITH ADMIN OPTION`, "PUBLIC"},
+			want: []string{
+				`GRANT "APP"."WRITER", "PUBLIC" TO "DEMO_USER"`,
+				`GRANT "APP"."READER", "MY SCHEMA"."ADMIN" TO "DEMO_USER" WITH ADMIN OPTION`,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			db := &fake.SQLRecorder{}
+			c := &PrivilegeClient{DB: db}
+			if err := c.GrantRoles(context.Background(), "", `"DEMO_USER"`, tc.roleNames); err != nil {
+				t.Fatalf("\n%s\nGrantRoles(...): %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want, db.Statements); diff != "" {
+				t.Errorf("\n%s\nGrantRoles(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+
+			// Roles are observed in their quoted form, which must parse back
+			// to the same roles
+			for _, r := range tc.roleNames {
+				role, err := parseRoleString(r)
+				if err != nil {
+					t.Fatalf("\n%s\nparseRoleString(%q): %v", tc.reason, r, err)
+				}
+				if !SameRole(r, role.clean().String()) {
+					t.Errorf("\n%s\nSameRole(%q, %q) = false, want true", tc.reason, r, role.clean().String())
+				}
+			}
+		})
+	}
+}
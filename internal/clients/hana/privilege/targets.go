@@ -0,0 +1,75 @@
+package privilege
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/SAP/crossplane-provider-hana/internal/clients/xsql"
+	"github.com/SAP/crossplane-provider-hana/internal/utils"
+)
+
+// MissingTarget is a privilege on a schema or object that does not exist.
+type MissingTarget struct {
+	Privilege string
+	Object    string
+}
+
+// MissingTargets returns the schema and object privileges whose schema or
+// object does not exist in the catalog. Other privilege types are not checked.
+func MissingTargets(ctx context.Context, db xsql.DB, privilegeStrings []string, defaultSchema DefaultSchema) ([]MissingTarget, error) {
+	privileges, err := parsePrivilegeStrings(privilegeStrings, defaultSchema)
+	if err != nil {
+		return nil, err
+	}
+
+	exists := map[string]bool{}
+	var missing []MissingTarget
+	for i, p := range privileges {
+		var object, query string
+		var args []any
+		switch {
+		case p.Type == SchemaPrivilegeType:
+			object = fmt.Sprintf(`SCHEMA "%s"`, utils.EscapeDoubleQuotes(p.Identifier))
+			query = "SELECT SCHEMA_NAME FROM SYS.SCHEMAS WHERE SCHEMA_NAME = ?"
+			args = []any{p.Identifier}
+		case p.Type == ObjectPrivilegeType && p.SubIdentifier != "":
+			object = fmt.Sprintf(`"%s"."%s"`, utils.EscapeDoubleQuotes(p.Identifier), utils.EscapeDoubleQuotes(p.SubIdentifier))
+			query = "SELECT OBJECT_NAME FROM SYS.OBJECTS WHERE SCHEMA_NAME = ? AND OBJECT_NAME = ?"
+			args = []any{p.Identifier, p.SubIdentifier}
+		default:
+			continue
+		}
+
+		found, ok := exists[object]
+		if !ok {
+			var name string
+			err := xsql.ScanRow(ctx, db.QueryRowContext(ctx, query, args...), &name)
+			if err != nil && !xsql.IsNoRows(err) {
+				return nil, err
+			}
+			found = err == nil
+			exists[object] = found
+		}
+		if !found {
+			missing = append(missing, MissingTarget{Privilege: privilegeStrings[i], Object: object})
+		}
+	}
+	return missing, nil
+}
+
+// ReferencesSchema reports whether any of the privileges is granted on the
+// schema or on an object in it. Privileges that cannot be parsed are ignored.
+func ReferencesSchema(privilegeStrings []string, schema string) bool {
+	for _, privStr := range privilegeStrings {
+		p, err := parsePrivilegeString(privStr, "")
+		if err != nil {
+			continue
+		}
+		switch {
+		case p.Type == SchemaPrivilegeType && p.Identifier == schema,
+			p.Type == ObjectPrivilegeType && p.SubIdentifier != "" && p.Identifier == schema:
+			return true
+		}
+	}
+	return false
+}
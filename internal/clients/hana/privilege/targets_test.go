@@ -0,0 +1,87 @@
+package privilege
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/SAP/crossplane-provider-hana/internal/clients/fake"
+)
+
+// nolint: contextcheck
+func TestMissingTargets(t *testing.T) {
+	// existing holds the schemas and objects of the catalog
+	existing := map[string]bool{
+		"APP":              true,
+		"APP.ORDERS":       true,
+		"DEFAULT_SCHEMA.T": true,
+	}
+
+	queries := 0
+	db := fake.MockDB{
+		MockQueryRowContext: func(ctx context.Context, query string, args ...any) *sql.Row {
+			queries++
+			key := args[0].(string)
+			if len(args) == 2 {
+				key += "." + args[1].(string)
+			}
+			db, mock, _ := sqlmock.New()
+			rows := sqlmock.NewRows([]string{"NAME"})
+			if existing[key] {
+				rows.AddRow(key)
+			}
+			mock.ExpectQuery("SELECT").WillReturnRows(rows)
+			return db.QueryRowContext(context.Background(), "SELECT")
+		},
+	}
+
+	privileges := []string{
+		"CATALOG READ",
+		`SELECT ON SCHEMA "APP"`,
+		`SELECT ON SCHEMA "STAGING"`,
+		`INSERT ON SCHEMA "STAGING"`,
+		`SELECT ON "APP"."ORDERS"`,
+		`SELECT ON "APP"."INVOICES"`,
+		`SELECT ON "T"`,
+		`CPU ON PSE "MY_PSE"`,
+	}
+	want := []MissingTarget{
+		{Privilege: `SELECT ON SCHEMA "STAGING"`, Object: `SCHEMA "STAGING"`},
+		{Privilege: `INSERT ON SCHEMA "STAGING"`, Object: `SCHEMA "STAGING"`},
+		{Privilege: `SELECT ON "APP"."INVOICES"`, Object: `"APP"."INVOICES"`},
+	}
+
+	got, err := MissingTargets(context.Background(), db, privileges, "DEFAULT_SCHEMA")
+	if err != nil {
+		t.Fatalf("MissingTargets(...): unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("MissingTargets(...): -want, +got:\n%s\n", diff)
+	}
+	if queries != 5 {
+		t.Errorf("MissingTargets(...): want each target queried once, got %d queries", queries)
+	}
+}
+
+func TestReferencesSchema(t *testing.T) {
+	cases := map[string]struct {
+		privileges []string
+		want       bool
+	}{
+		"SchemaPrivilege": {privileges: []string{"CATALOG READ", `SELECT ON SCHEMA "APP"`}, want: true},
+		"ObjectPrivilege": {privileges: []string{`SELECT ON "APP"."ORDERS"`}, want: true},
+		"OtherSchema":     {privileges: []string{`SELECT ON SCHEMA "STAGING"`, `SELECT ON "STAGING"."APP"`}},
+		"NoPrivileges":    {},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := ReferencesSchema(tc.privileges, "APP"); got != tc.want {
+				t.Errorf("ReferencesSchema(...): want %t, got %t", tc.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,9 @@
+GRANT CATALOG READ TO "DEMO_USER"
+GRANT USER ADMIN TO "DEMO_USER" WITH ADMIN OPTION
+GRANT SELECT, INSERT ON SCHEMA "APP" TO "DEMO_USER"
+GRANT SELECT ON SCHEMA "APP" TO "DEMO_USER" WITH GRANT OPTION
+GRANT SELECT, UPDATE ON "APP"."ORDERS" TO "DEMO_USER"
+GRANT SELECT ON "DEFAULT_SCHEMA"."ORDERS" TO "DEMO_USER"
+GRANT LINKED DATABASE ON REMOTE SOURCE "REMOTE" TO "DEMO_USER"
+GRANT USERGROUP OPERATOR ON USERGROUP "GROUP" TO "DEMO_USER"
+GRANT CPU ON PSE MY_PSE TO "DEMO_USER"
@@ -0,0 +1,2 @@
+GRANT "MONITORING", "PUBLIC" TO "DEMO_USER"
+GRANT "data::access_g" TO "DEMO_USER" WITH ADMIN OPTION
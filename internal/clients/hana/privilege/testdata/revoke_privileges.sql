@@ -0,0 +1,3 @@
+REVOKE CATALOG READ FROM "DEMO_USER"
+REVOKE SELECT ON SCHEMA "APP" FROM "DEMO_USER"
+REVOKE INSERT ON SCHEMA "APP" FROM "DEMO_USER"
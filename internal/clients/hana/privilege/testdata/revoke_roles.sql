@@ -0,0 +1 @@
+REVOKE "MONITORING", "data::access_g" FROM "DEMO_USER"
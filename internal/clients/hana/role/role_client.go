@@ -52,7 +52,7 @@ func (c Client) Read(ctx context.Context, parameters *v1alpha1.RoleParameters) (
 	query := "SELECT ROLE_SCHEMA_NAME, ROLE_NAME, ROLEGROUP_NAME FROM SYS.ROLES WHERE ROLE_NAME = ?"
 
 	var err error
-	if err = c.QueryRowContext(ctx, query, parameters.RoleName).Scan(&schema, &observed.RoleName, &rolegroupName); xsql.IsNoRows(err) {
+	if err = xsql.ScanRow(ctx, c.QueryRowContext(ctx, query, parameters.RoleName), &schema, &observed.RoleName, &rolegroupName); xsql.IsNoRows(err) {
 		return observed, nil
 	} else if err != nil {
 		return observed, err
@@ -120,6 +120,11 @@ func (c Client) Create(ctx context.Context, parameters *v1alpha1.RoleParameters)
 
 	grantee := getRoleName(parameters.Schema, parameters.RoleName)
 	if len(parameters.Privileges) > 0 {
+		unlock, err := privilege.LockGrantee(ctx, grantee)
+		if err != nil {
+			return err
+		}
+		defer unlock()
 		if err := c.GrantPrivileges(ctx, c.username, grantee, parameters.Privileges); err != nil {
 			return fmt.Errorf("failed to grant privileges: %w", err)
 		}
@@ -180,6 +185,15 @@ func (c Client) UpdateRolegroup(ctx context.Context, parameters *v1alpha1.RolePa
 func (c Client) UpdatePrivileges(ctx context.Context, parameters *v1alpha1.RoleParameters, toGrant, toRevoke []string) error {
 
 	grantee := getRoleName(parameters.Schema, parameters.RoleName)
+	if len(toGrant) == 0 && len(toRevoke) == 0 {
+		return nil
+	}
+	unlock, err := privilege.LockGrantee(ctx, grantee)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
 	if len(toGrant) > 0 {
 		if err := c.GrantPrivileges(ctx, c.username, grantee, toGrant); err != nil {
 			return fmt.Errorf("failed to grant privileges: %w", err)
@@ -38,7 +38,7 @@ func (c Client) Read(ctx context.Context, parameters *v1alpha1.RolegroupParamete
 
 	var isRoleAdminEnabled string
 	query := "SELECT ROLEGROUP_NAME, IS_ROLE_ADMIN_ENABLED FROM SYS.ROLEGROUPS WHERE ROLEGROUP_NAME = ?"
-	if err := c.QueryRowContext(ctx, query, parameters.RolegroupName).Scan(&observed.RolegroupName, &isRoleAdminEnabled); xsql.IsNoRows(err) {
+	if err := xsql.ScanRow(ctx, c.QueryRowContext(ctx, query, parameters.RolegroupName), &observed.RolegroupName, &isRoleAdminEnabled); xsql.IsNoRows(err) {
 		return observed, nil
 	} else if err != nil {
 		return observed, err
@@ -0,0 +1,54 @@
+package hana
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+const (
+	errServiceKeyNotFound = "key %s not found in connection secret"
+	errParseServiceKey    = "cannot parse service key: %w"
+	errServiceKeyNoHost   = "service key has no host"
+)
+
+// serviceKey is the part of a HANA Cloud service key the provider connects
+// with. Service bindings nest it under credentials.
+type serviceKey struct {
+	Host        string          `json:"host"`
+	Port        json.RawMessage `json:"port"`
+	User        string          `json:"user"`
+	Password    string          `json:"password"`
+	Certificate string          `json:"certificate"`
+
+	Credentials *serviceKey `json:"credentials"`
+}
+
+// serviceKeyCredentials returns the connection credentials of a service key.
+// The port may be given as a number or a string.
+func serviceKeyCredentials(data []byte) (map[string][]byte, error) {
+	key := &serviceKey{}
+	if err := json.Unmarshal(data, key); err != nil {
+		return nil, fmt.Errorf(errParseServiceKey, err)
+	}
+	if key.Host == "" && key.Credentials != nil {
+		key = key.Credentials
+	}
+	if key.Host == "" {
+		return nil, errors.New(errServiceKeyNoHost)
+	}
+
+	creds := map[string][]byte{
+		xpv1.ResourceCredentialsSecretEndpointKey: []byte(key.Host),
+		xpv1.ResourceCredentialsSecretPortKey:     []byte(strings.Trim(string(key.Port), `"`)),
+		xpv1.ResourceCredentialsSecretUserKey:     []byte(key.User),
+		xpv1.ResourceCredentialsSecretPasswordKey: []byte(key.Password),
+	}
+	if key.Certificate != "" {
+		creds[CredentialsKeyTLSRootCA] = []byte(key.Certificate)
+	}
+	return creds, nil
+}
@@ -0,0 +1,248 @@
+/*
+Copyright 2026 SAP SE or an SAP affiliate company and contributors.
+*/
+
+package simulator
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+)
+
+// adminPrivileges are the system privileges of users connecting to the
+// simulator for the first time, enough to manage all simulated objects.
+var adminPrivileges = []string{
+	"AUDIT ADMIN",
+	"CATALOG READ",
+	"CREATE SCHEMA",
+	"ROLE ADMIN",
+	"TRUST ADMIN",
+	"USER ADMIN",
+	"USERGROUP ADMIN",
+}
+
+// defaultPasswordLifetime is the maximum_password_lifetime of the instance
+// password policy, in days.
+const defaultPasswordLifetime = "182"
+
+// A Database is the in-memory catalog of a simulated HANA instance. It is
+// safe for concurrent use, statements are applied one after the other.
+type Database struct {
+	mu  sync.Mutex
+	now func() time.Time
+
+	users      map[string]*user
+	roles      map[objectName]*role
+	rolegroups map[string]*rolegroup
+	schemas    map[string]*schema
+	usergroups map[string]*usergroup
+	providers  map[string]*x509Provider
+
+	privileges []privilegeGrant
+	roleGrants []roleGrant
+
+	// authErrors are the internal error codes of failed authentications,
+	// by correlation ID
+	authErrors map[string]string
+	nextID     int
+}
+
+// NewDatabase returns an empty Database.
+func NewDatabase() *Database {
+	return &Database{
+		now:        func() time.Time { return time.Now().UTC().Truncate(time.Second) },
+		users:      map[string]*user{},
+		roles:      map[objectName]*role{},
+		rolegroups: map[string]*rolegroup{},
+		schemas:    map[string]*schema{},
+		usergroups: map[string]*usergroup{},
+		providers:  map[string]*x509Provider{},
+		authErrors: map[string]string{},
+	}
+}
+
+// objectName is the name of a catalog object that may be qualified by a
+// schema, such as a role or a grantee.
+type objectName struct {
+	schema string
+	name   string
+}
+
+func (n objectName) String() string {
+	if n.schema == "" {
+		return n.name
+	}
+	return n.schema + "." + n.name
+}
+
+type user struct {
+	name                   string
+	usergroup              string
+	password               string
+	created                time.Time
+	passwordChanged        time.Time
+	restricted             bool
+	clientConnect          bool
+	passwordLifetime       bool
+	passwordEnabled        bool
+	validFrom              *time.Time
+	validUntil             *time.Time
+	deactivated            bool
+	parameters             map[string]string
+	identities             []identity
+	comment                *string
+	invalidConnectAttempts int64
+}
+
+type identity struct {
+	provider string
+	subject  string
+}
+
+type role struct {
+	objectName
+	rolegroup  string
+	ldapGroups []string
+	comment    *string
+}
+
+type rolegroup struct {
+	name      string
+	roleAdmin bool
+}
+
+type schema struct {
+	name    string
+	owner   string
+	comment *string
+}
+
+type usergroup struct {
+	name       string
+	userAdmin  bool
+	parameters map[string]string
+	// enabledSets are the parameter sets enabled for the usergroup
+	enabledSets map[string]bool
+}
+
+type x509Provider struct {
+	name     string
+	issuer   string
+	priority *int64
+	rules    []string
+}
+
+// privilegeGrant is a row of GRANTED_PRIVILEGES.
+type privilegeGrant struct {
+	grantee     objectName
+	granteeType string
+	objectType  string
+	privilege   string
+	schema      string
+	object      string
+	grantable   bool
+	grantor     string
+}
+
+// roleGrant is a row of GRANTED_ROLES.
+type roleGrant struct {
+	grantee     objectName
+	granteeType string
+	role        objectName
+	grantable   bool
+}
+
+// login authenticates a user connecting with the password. Users that do not
+// exist yet are created with administrative privileges, and their schema.
+func (db *Database) login(username, password string) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	u, ok := db.users[username]
+	if !ok {
+		now := db.now()
+		db.users[username] = &user{
+			name:             username,
+			password:         password,
+			created:          now,
+			passwordChanged:  now,
+			clientConnect:    true,
+			passwordLifetime: true,
+			passwordEnabled:  true,
+			parameters:       map[string]string{},
+		}
+		db.schemas[username] = &schema{name: username, owner: username}
+		for _, p := range adminPrivileges {
+			db.privileges = append(db.privileges, privilegeGrant{
+				grantee:     objectName{name: username},
+				granteeType: "USER",
+				objectType:  "SYSTEMPRIVILEGE",
+				privilege:   p,
+				grantable:   true,
+				grantor:     "SYSTEM",
+			})
+		}
+		return nil
+	}
+	if u.deactivated || !u.clientConnect || !u.passwordEnabled || u.password != password {
+		return newError(errCodeAuthFailed, "authentication failed")
+	}
+	return nil
+}
+
+// correlate records the internal error code of a failed authentication and
+// returns the correlation ID to look it up by.
+func (db *Database) correlate(code string) string {
+	db.nextID++
+	id := fmt.Sprintf("%08X", db.nextID)
+	db.authErrors[id] = code
+	return id
+}
+
+// grantee returns the user or role a privilege or role is granted to.
+func (db *Database) grantee(n objectName) (string, error) {
+	if n.schema == "" {
+		if _, ok := db.users[n.name]; ok {
+			return "USER", nil
+		}
+	}
+	if _, ok := db.roles[n]; ok {
+		return "ROLE", nil
+	}
+	return "", newError(errCodeInvalidUserName, "invalid user name: "+n.String())
+}
+
+// revokeFrom removes all privileges and roles granted to the grantee.
+func (db *Database) revokeFrom(grantee objectName) {
+	db.privileges = slices.DeleteFunc(db.privileges, func(g privilegeGrant) bool { return g.grantee == grantee })
+	db.roleGrants = slices.DeleteFunc(db.roleGrants, func(g roleGrant) bool { return g.grantee == grantee })
+}
+
+// effectivePrivileges returns the system privileges the user has, granted
+// directly or through roles.
+func (db *Database) effectivePrivileges(username string) []string {
+	var privileges []string
+	seen := map[objectName]bool{}
+	queue := []objectName{{name: username}}
+	for len(queue) > 0 {
+		grantee := queue[0]
+		queue = queue[1:]
+		if seen[grantee] {
+			continue
+		}
+		seen[grantee] = true
+		for _, g := range db.privileges {
+			if g.grantee == grantee && g.objectType == "SYSTEMPRIVILEGE" {
+				privileges = append(privileges, g.privilege)
+			}
+		}
+		for _, g := range db.roleGrants {
+			if g.grantee == grantee {
+				queue = append(queue, g.role)
+			}
+		}
+	}
+	return privileges
+}
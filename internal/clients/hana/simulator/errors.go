@@ -0,0 +1,90 @@
+/*
+Copyright 2026 SAP SE or an SAP affiliate company and contributors.
+*/
+
+package simulator
+
+import (
+	"fmt"
+
+	"github.com/SAP/go-hdb/driver"
+)
+
+// Error codes of the simulator, the ones HANA returns for the same errors.
+const (
+	errCodeFeatureNotSupported = 7
+	errCodeAuthFailed          = 10
+	errCodeValidityPeriod      = 20
+	errCodeSQLSyntax           = 257
+	errCodeInvalidTableName    = 259
+	errCodeInvalidColumnName   = 260
+	errCodeDuplicateName       = 301
+	errCodeDuplicateUserName   = 331
+	errCodeInvalidUserName     = 332
+	errCodeInvalidSchemaName   = 362
+	errCodeDuplicateSchemaName = 386
+	errCodeDuplicateRoleName   = 387
+	errCodeInvalidRoleName     = 389
+	errCodeInvalidObjectName   = 397
+	errCodeUserDeactivated     = 415
+	errCodeRestrict            = 419
+)
+
+var _ driver.Error = (*Error)(nil)
+
+// Error is an error returned by the simulator. Like the errors of the HANA
+// driver, it implements driver.Error, so that clients tell errors apart by
+// their HANA error code.
+type Error struct {
+	code int
+	text string
+}
+
+func newError(code int, text string) *Error {
+	return &Error{code: code, text: text}
+}
+
+func syntaxError(text string) *Error {
+	return newError(errCodeSQLSyntax, "sql syntax error: "+text)
+}
+
+func notSupported(what string) *Error {
+	return newError(errCodeFeatureNotSupported, what+" is not supported by the simulator")
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("SQL Error %d - %s", e.code, e.text)
+}
+
+// StmtNo returns the statement number of the error, always 0.
+func (e *Error) StmtNo() int { return 0 }
+
+// Code returns the HANA error code.
+func (e *Error) Code() int { return e.code }
+
+// Position returns the position of the error in the statement, always 0.
+func (e *Error) Position() int { return 0 }
+
+// Level returns the error level, always that of errors.
+func (e *Error) Level() int { return 1 }
+
+// Text returns the error description.
+func (e *Error) Text() string { return e.text }
+
+// IsWarning returns false, the simulator does not return warnings.
+func (e *Error) IsWarning() bool { return false }
+
+// IsError returns true.
+func (e *Error) IsError() bool { return true }
+
+// IsFatal returns false.
+func (e *Error) IsFatal() bool { return false }
+
+// NumError returns 1, the simulator returns single errors.
+func (e *Error) NumError() int { return 1 }
+
+// Unwrap returns nil, the simulator returns single errors.
+func (e *Error) Unwrap() []error { return nil }
+
+// SetIdx does nothing, the simulator returns single errors.
+func (e *Error) SetIdx(int) {}
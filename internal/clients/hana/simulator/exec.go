@@ -0,0 +1,1056 @@
+/*
+Copyright 2026 SAP SE or an SAP affiliate company and contributors.
+*/
+
+package simulator
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// statement runs a statement of the session user on the catalog.
+type statement func(db *Database, p *parser, session string) error
+
+// statements are the supported statements, by their leading keywords, in the
+// order they are tried.
+var statements = []struct {
+	keywords []string
+	run      statement
+}{
+	{[]string{"CREATE", "RESTRICTED", "USER"}, createUser(true)},
+	{[]string{"CREATE", "USER"}, createUser(false)},
+	{[]string{"ALTER", "USER"}, alterUser},
+	{[]string{"DROP", "USER"}, dropUser},
+	{[]string{"VALIDATE", "USER"}, validateUser},
+	{[]string{"CREATE", "ROLE"}, createRole},
+	{[]string{"ALTER", "ROLE"}, alterRole},
+	{[]string{"DROP", "ROLE"}, dropRole},
+	{[]string{"CREATE", "ROLEGROUP"}, createRolegroup},
+	{[]string{"ALTER", "ROLEGROUP"}, alterRolegroup},
+	{[]string{"DROP", "ROLEGROUP"}, dropRolegroup},
+	{[]string{"CREATE", "SCHEMA"}, createSchema},
+	{[]string{"DROP", "SCHEMA"}, dropSchema},
+	{[]string{"CREATE", "USERGROUP"}, createUsergroup},
+	{[]string{"ALTER", "USERGROUP"}, alterUsergroup},
+	{[]string{"DROP", "USERGROUP"}, dropUsergroup},
+	{[]string{"CREATE", "X509", "PROVIDER"}, createX509Provider},
+	{[]string{"ALTER", "X509", "PROVIDER"}, alterX509Provider},
+	{[]string{"DROP", "X509", "PROVIDER"}, dropX509Provider},
+	{[]string{"GRANT"}, grant},
+	{[]string{"REVOKE"}, revoke},
+	{[]string{"COMMENT", "ON"}, comment},
+	// Sessions are not simulated, there is none to disconnect
+	{[]string{"ALTER", "SYSTEM", "DISCONNECT", "SESSION"}, func(_ *Database, p *parser, _ string) error {
+		_, err := p.str()
+		return err
+	}},
+}
+
+// exec runs a statement other than a query.
+func (db *Database) exec(session, query string, args []driver.NamedValue) error {
+	p, err := newParser(query, args)
+	if err != nil {
+		return err
+	}
+	for _, s := range statements {
+		if !p.keyword(s.keywords...) {
+			continue
+		}
+		if err := s.run(db, p, session); err != nil {
+			return err
+		}
+		if !p.done() {
+			return p.unexpected("the end of the statement")
+		}
+		return nil
+	}
+
+	var words []string
+	for _, t := range p.toks[:min(len(p.toks), 2)] {
+		words = append(words, t.text)
+	}
+	return notSupported(strings.Join(words, " "))
+}
+
+func createUser(restricted bool) statement {
+	return func(db *Database, p *parser, _ string) error {
+		name, err := p.name()
+		if err != nil {
+			return err
+		}
+		if _, ok := db.users[name]; ok {
+			return newError(errCodeDuplicateUserName, "cannot use duplicate user name: "+name)
+		}
+		now := db.now()
+		u := &user{
+			name:             name,
+			created:          now,
+			passwordChanged:  now,
+			restricted:       restricted,
+			clientConnect:    !restricted,
+			passwordLifetime: true,
+			parameters:       map[string]string{},
+		}
+		if p.keyword("PASSWORD") {
+			if u.password, err = p.name(); err != nil {
+				return err
+			}
+			u.passwordEnabled = true
+			p.keyword("NO", "FORCE_FIRST_PASSWORD_CHANGE")
+		}
+		if p.keyword("SET", "PARAMETER") {
+			if u.parameters, err = p.parameters(); err != nil {
+				return err
+			}
+		}
+		if p.keyword("SET", "USERGROUP") {
+			if u.usergroup, err = p.name(); err != nil {
+				return err
+			}
+			if _, ok := db.usergroups[u.usergroup]; !ok {
+				return invalidUsergroup(u.usergroup)
+			}
+		}
+		if !p.done() {
+			return p.unexpected("PASSWORD, SET PARAMETER or SET USERGROUP")
+		}
+
+		db.users[name] = u
+		// Restricted users have no schema of their own
+		if !restricted {
+			db.schemas[name] = &schema{name: name, owner: name}
+		}
+		return nil
+	}
+}
+
+func alterUser(db *Database, p *parser, _ string) error {
+	name, err := p.name()
+	if err != nil {
+		return err
+	}
+	u, ok := db.users[name]
+	if !ok {
+		return invalidUser(name)
+	}
+
+	if enabled, ok := p.enable(); ok {
+		switch {
+		case p.keyword("PASSWORD", "LIFETIME"):
+			u.passwordLifetime = enabled
+		case p.keyword("CLIENT", "CONNECT"):
+			u.clientConnect = enabled
+		case p.keyword("PASSWORD"):
+			u.passwordEnabled = enabled
+		default:
+			return p.unexpected("PASSWORD LIFETIME, CLIENT CONNECT or PASSWORD")
+		}
+		return nil
+	}
+
+	switch {
+	case p.keyword("PASSWORD"):
+		if u.password, err = p.name(); err != nil {
+			return err
+		}
+		u.passwordChanged = db.now()
+		p.keyword("NO", "FORCE_FIRST_PASSWORD_CHANGE")
+	case p.keyword("SET", "PARAMETER"):
+		set, err := p.parameters()
+		if err != nil {
+			return err
+		}
+		for k, v := range set {
+			u.parameters[k] = v
+		}
+		// SET PARAMETER may be followed by CLEAR PARAMETER
+		if p.keyword("CLEAR", "PARAMETER") {
+			return p.clearParameters(u.parameters)
+		}
+	case p.keyword("CLEAR", "PARAMETER"):
+		return p.clearParameters(u.parameters)
+	case p.keyword("SET", "USERGROUP", "DEFAULT"):
+		u.usergroup = ""
+	case p.keyword("SET", "USERGROUP"):
+		g, err := p.name()
+		if err != nil {
+			return err
+		}
+		if _, ok := db.usergroups[g]; !ok {
+			return invalidUsergroup(g)
+		}
+		u.usergroup = g
+	case p.keyword("UNSET", "USERGROUP"):
+		u.usergroup = ""
+	case p.keyword("VALID", "FROM"):
+		if u.validFrom, err = p.timestamp("NOW"); err != nil {
+			return err
+		}
+		if err := p.expect("UNTIL"); err != nil {
+			return err
+		}
+		if u.validUntil, err = p.timestamp("FOREVER"); err != nil {
+			return err
+		}
+	case p.keyword("ADD", "IDENTITY"):
+		i, err := p.identity(db)
+		if err != nil {
+			return err
+		}
+		if !slices.Contains(u.identities, i) {
+			u.identities = append(u.identities, i)
+		}
+	case p.keyword("DROP", "IDENTITY"):
+		i, err := p.identity(db)
+		if err != nil {
+			return err
+		}
+		u.identities = slices.DeleteFunc(u.identities, func(o identity) bool { return o == i })
+	case p.keyword("DEACTIVATE", "USER", "NOW"):
+		u.deactivated = true
+	case p.keyword("ACTIVATE", "USER", "NOW"):
+		u.deactivated = false
+		u.invalidConnectAttempts = 0
+	default:
+		return p.unexpected("a supported ALTER USER clause")
+	}
+	return nil
+}
+
+func dropUser(db *Database, p *parser, _ string) error {
+	name, err := p.name()
+	if err != nil {
+		return err
+	}
+	if _, ok := db.users[name]; !ok {
+		return invalidUser(name)
+	}
+	p.keyword("CASCADE")
+	delete(db.users, name)
+	db.revokeFrom(objectName{name: name})
+	if s, ok := db.schemas[name]; ok && s.owner == name {
+		db.dropSchema(name)
+	}
+	return nil
+}
+
+func validateUser(db *Database, p *parser, _ string) error {
+	name, err := p.name()
+	if err != nil {
+		return err
+	}
+	if err := p.expect("PASSWORD"); err != nil {
+		return err
+	}
+	password, err := p.name()
+	if err != nil {
+		return err
+	}
+	u, ok := db.users[name]
+	if !ok {
+		return invalidUser(name)
+	}
+
+	now := db.now()
+	switch {
+	case u.deactivated:
+		return newError(errCodeUserDeactivated, "user is deactivated")
+	case u.validFrom != nil && now.Before(*u.validFrom), u.validUntil != nil && !now.Before(*u.validUntil):
+		return newError(errCodeValidityPeriod, "user is outside of its validity period")
+	case !u.passwordEnabled || u.password != password:
+		u.invalidConnectAttempts++
+		id := db.correlate("A10")
+		return newError(errCodeAuthFailed, fmt.Sprintf("authentication failed; correlation ID '%s'", id))
+	}
+	u.invalidConnectAttempts = 0
+	return nil
+}
+
+func createRole(db *Database, p *parser, session string) error {
+	schemaName, name, err := p.qualifiedName()
+	if err != nil {
+		return err
+	}
+	n := objectName{schema: schemaName, name: name}
+	if _, ok := db.roles[n]; ok {
+		return newError(errCodeDuplicateRoleName, "cannot use duplicate role name: "+n.String())
+	}
+	if _, ok := db.schemas[schemaName]; schemaName != "" && !ok {
+		return invalidSchema(schemaName)
+	}
+	r := &role{objectName: n}
+	if p.keyword("LDAP", "GROUP") {
+		if r.ldapGroups, err = p.strs(); err != nil {
+			return err
+		}
+	}
+	noGrant := p.keyword("NO", "GRANT", "TO", "CREATOR")
+	if p.keyword("SET", "ROLEGROUP") {
+		if r.rolegroup, err = p.name(); err != nil {
+			return err
+		}
+		if _, ok := db.rolegroups[r.rolegroup]; !ok {
+			return invalidObject("rolegroup", r.rolegroup)
+		}
+	}
+
+	db.roles[n] = r
+	if !noGrant {
+		db.roleGrants = append(db.roleGrants, roleGrant{grantee: objectName{name: session}, granteeType: "USER", role: n, grantable: true})
+	}
+	return nil
+}
+
+func alterRole(db *Database, p *parser, _ string) error {
+	schemaName, name, err := p.qualifiedName()
+	if err != nil {
+		return err
+	}
+	r, ok := db.roles[objectName{schema: schemaName, name: name}]
+	if !ok {
+		return invalidRole(objectName{schema: schemaName, name: name})
+	}
+
+	switch {
+	case p.keyword("ADD", "LDAP", "GROUP"):
+		groups, err := p.strs()
+		if err != nil {
+			return err
+		}
+		for _, g := range groups {
+			if !slices.Contains(r.ldapGroups, g) {
+				r.ldapGroups = append(r.ldapGroups, g)
+			}
+		}
+	case p.keyword("DROP", "LDAP", "GROUP"):
+		groups, err := p.strs()
+		if err != nil {
+			return err
+		}
+		r.ldapGroups = slices.DeleteFunc(r.ldapGroups, func(g string) bool { return slices.Contains(groups, g) })
+	case p.keyword("SET", "ROLEGROUP"):
+		g, err := p.name()
+		if err != nil {
+			return err
+		}
+		if _, ok := db.rolegroups[g]; !ok {
+			return invalidObject("rolegroup", g)
+		}
+		r.rolegroup = g
+	case p.keyword("UNSET", "ROLEGROUP"):
+		r.rolegroup = ""
+	default:
+		return p.unexpected("a supported ALTER ROLE clause")
+	}
+	return nil
+}
+
+func dropRole(db *Database, p *parser, _ string) error {
+	schemaName, name, err := p.qualifiedName()
+	if err != nil {
+		return err
+	}
+	n := objectName{schema: schemaName, name: name}
+	if _, ok := db.roles[n]; !ok {
+		return invalidRole(n)
+	}
+	db.dropRole(n)
+	return nil
+}
+
+// dropRole removes the role, and revokes it from its grantees.
+func (db *Database) dropRole(n objectName) {
+	delete(db.roles, n)
+	db.revokeFrom(n)
+	db.roleGrants = slices.DeleteFunc(db.roleGrants, func(g roleGrant) bool { return g.role == n })
+}
+
+func createRolegroup(db *Database, p *parser, _ string) error {
+	name, err := p.name()
+	if err != nil {
+		return err
+	}
+	if _, ok := db.rolegroups[name]; ok {
+		return duplicateObject("rolegroup", name)
+	}
+	p.keyword("FOR", "GRANTS", "ON", "TENANT", "OBJECTS")
+	p.keyword("NO", "GRANT", "TO", "CREATOR")
+	db.rolegroups[name] = &rolegroup{name: name, roleAdmin: p.keyword("ENABLE", "ROLE", "ADMIN")}
+	return nil
+}
+
+func alterRolegroup(db *Database, p *parser, _ string) error {
+	name, err := p.name()
+	if err != nil {
+		return err
+	}
+	g, ok := db.rolegroups[name]
+	if !ok {
+		return invalidObject("rolegroup", name)
+	}
+	switch {
+	case p.keyword("ENABLE", "ROLE", "ADMIN"):
+		g.roleAdmin = true
+	case p.keyword("DISABLE", "ROLE", "ADMIN"):
+		g.roleAdmin = false
+	default:
+		return p.unexpected("ENABLE ROLE ADMIN or DISABLE ROLE ADMIN")
+	}
+	return nil
+}
+
+func dropRolegroup(db *Database, p *parser, _ string) error {
+	name, err := p.name()
+	if err != nil {
+		return err
+	}
+	if _, ok := db.rolegroups[name]; !ok {
+		return invalidObject("rolegroup", name)
+	}
+	delete(db.rolegroups, name)
+	for _, r := range db.roles {
+		if r.rolegroup == name {
+			r.rolegroup = ""
+		}
+	}
+	return nil
+}
+
+func createSchema(db *Database, p *parser, session string) error {
+	name, err := p.name()
+	if err != nil {
+		return err
+	}
+	if _, ok := db.schemas[name]; ok {
+		return newError(errCodeDuplicateSchemaName, "cannot use duplicate schema name: "+name)
+	}
+	owner := session
+	if p.keyword("OWNED", "BY") {
+		if owner, err = p.name(); err != nil {
+			return err
+		}
+		if _, ok := db.users[owner]; !ok {
+			return invalidUser(owner)
+		}
+	}
+	db.schemas[name] = &schema{name: name, owner: owner}
+	return nil
+}
+
+func dropSchema(db *Database, p *parser, _ string) error {
+	name, err := p.name()
+	if err != nil {
+		return err
+	}
+	if _, ok := db.schemas[name]; !ok {
+		return invalidSchema(name)
+	}
+	if !p.keyword("CASCADE") {
+		p.keyword("RESTRICT")
+		for n := range db.roles {
+			if n.schema == name {
+				return restrict("schema", name)
+			}
+		}
+	}
+	db.dropSchema(name)
+	return nil
+}
+
+// dropSchema removes the schema with the roles in it, and revokes the
+// privileges on it.
+func (db *Database) dropSchema(name string) {
+	delete(db.schemas, name)
+	for n := range db.roles {
+		if n.schema == name {
+			db.dropRole(n)
+		}
+	}
+	db.privileges = slices.DeleteFunc(db.privileges, func(g privilegeGrant) bool { return g.schema == name })
+}
+
+func createUsergroup(db *Database, p *parser, session string) error {
+	name, err := p.name()
+	if err != nil {
+		return err
+	}
+	if _, ok := db.usergroups[name]; ok {
+		return duplicateObject("usergroup", name)
+	}
+	g := &usergroup{name: name, userAdmin: !p.keyword("DISABLE", "USER", "ADMIN"), parameters: map[string]string{}, enabledSets: map[string]bool{}}
+	noGrant := p.keyword("NO", "GRANT", "TO", "CREATOR")
+	if p.keyword("SET", "PARAMETER") {
+		if g.parameters, err = p.parameters(); err != nil {
+			return err
+		}
+	}
+	if p.keyword("ENABLE", "PARAMETER", "SET") {
+		set, err := p.str()
+		if err != nil {
+			return err
+		}
+		g.enabledSets[set] = true
+	}
+
+	db.usergroups[name] = g
+	if !noGrant {
+		db.privileges = append(db.privileges, privilegeGrant{
+			grantee:     objectName{name: session},
+			granteeType: "USER",
+			objectType:  "USERGROUP",
+			privilege:   "USERGROUP OPERATOR",
+			object:      name,
+			grantable:   true,
+			grantor:     "SYS",
+		})
+	}
+	return nil
+}
+
+func alterUsergroup(db *Database, p *parser, _ string) error {
+	name, err := p.name()
+	if err != nil {
+		return err
+	}
+	g, ok := db.usergroups[name]
+	if !ok {
+		return invalidUsergroup(name)
+	}
+	if enabled, ok := p.enable(); ok {
+		switch {
+		case p.keyword("USER", "ADMIN"):
+			g.userAdmin = enabled
+		case p.keyword("PARAMETER", "SET"):
+			set, err := p.str()
+			if err != nil {
+				return err
+			}
+			g.enabledSets[set] = enabled
+		default:
+			return p.unexpected("USER ADMIN or PARAMETER SET")
+		}
+		return nil
+	}
+
+	switch {
+	case p.keyword("SET", "PARAMETER"):
+		set, err := p.parameters()
+		if err != nil {
+			return err
+		}
+		for k, v := range set {
+			g.parameters[k] = v
+		}
+	case p.keyword("CLEAR", "PARAMETER"):
+		return p.clearParameters(g.parameters)
+	default:
+		return p.unexpected("a supported ALTER USERGROUP clause")
+	}
+	return nil
+}
+
+func dropUsergroup(db *Database, p *parser, _ string) error {
+	name, err := p.name()
+	if err != nil {
+		return err
+	}
+	if _, ok := db.usergroups[name]; !ok {
+		return invalidUsergroup(name)
+	}
+	for _, u := range db.users {
+		if u.usergroup == name {
+			return restrict("usergroup", name)
+		}
+	}
+	delete(db.usergroups, name)
+	db.privileges = slices.DeleteFunc(db.privileges, func(g privilegeGrant) bool {
+		return g.objectType == "USERGROUP" && g.object == name
+	})
+	return nil
+}
+
+func createX509Provider(db *Database, p *parser, _ string) error {
+	name, err := p.name()
+	if err != nil {
+		return err
+	}
+	if _, ok := db.providers[name]; ok {
+		return duplicateObject("X509 provider", name)
+	}
+	if err := p.expect("WITH", "ISSUER"); err != nil {
+		return err
+	}
+	issuer, err := p.str()
+	if err != nil {
+		return err
+	}
+	db.providers[name] = &x509Provider{name: name, issuer: issuer}
+	return nil
+}
+
+func alterX509Provider(db *Database, p *parser, _ string) error {
+	name, err := p.name()
+	if err != nil {
+		return err
+	}
+	provider, ok := db.providers[name]
+	if !ok {
+		return invalidObject("X509 provider", name)
+	}
+	switch {
+	case p.keyword("SET", "ISSUER"):
+		if provider.issuer, err = p.str(); err != nil {
+			return err
+		}
+	case p.keyword("SET", "PRIORITY"):
+		s, err := p.str()
+		if err != nil {
+			return err
+		}
+		priority, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return syntaxError("invalid priority " + s)
+		}
+		provider.priority = &priority
+	case p.keyword("UNSET", "PRIORITY"):
+		provider.priority = nil
+	case p.keyword("SET", "MATCHING", "RULES"):
+		if provider.rules, err = p.strs(); err != nil {
+			return err
+		}
+	case p.keyword("UNSET", "MATCHING", "RULES"):
+		provider.rules = nil
+	default:
+		return p.unexpected("a supported ALTER X509 PROVIDER clause")
+	}
+	return nil
+}
+
+func dropX509Provider(db *Database, p *parser, _ string) error {
+	name, err := p.name()
+	if err != nil {
+		return err
+	}
+	if _, ok := db.providers[name]; !ok {
+		return invalidObject("X509 provider", name)
+	}
+	cascade := p.keyword("CASCADE")
+	for _, u := range db.users {
+		mapped := func(i identity) bool { return i.provider == name }
+		if !slices.ContainsFunc(u.identities, mapped) {
+			continue
+		}
+		if !cascade {
+			return restrict("X509 provider", name)
+		}
+		u.identities = slices.DeleteFunc(u.identities, mapped)
+	}
+	delete(db.providers, name)
+	return nil
+}
+
+// grantable is what a GRANT or REVOKE statement grants or revokes: roles
+// and system privileges, or privileges on an object.
+type grantable struct {
+	roles      []objectName
+	system     []string
+	privileges []string
+	target     privilegeGrant
+}
+
+// grantable parses the privileges or roles of a GRANT or REVOKE statement
+// up to the keyword introducing the grantee.
+func (p *parser) grantable(db *Database, grantee string) (grantable, error) {
+	var g grantable
+	var items [][]token
+	var item []token
+	on := false
+	for !p.done() {
+		if p.keyword("ON") {
+			on = true
+			break
+		}
+		if t := p.peek(); t.kind == tokWord && t.text == grantee {
+			break
+		}
+		if p.symbol(",") {
+			items = append(items, item)
+			item = nil
+			continue
+		}
+		item = append(item, p.toks[p.pos])
+		p.pos++
+	}
+	items = append(items, item)
+
+	for _, item := range items {
+		words := make([]string, 0, len(item))
+		for _, t := range item {
+			if t.kind != tokWord {
+				words = nil
+				break
+			}
+			words = append(words, t.text)
+		}
+		switch {
+		case len(item) == 0:
+			return g, p.unexpected("a privilege or role")
+		case words == nil && on:
+			return g, syntaxError("invalid privilege")
+		case on:
+			g.privileges = append(g.privileges, strings.Join(words, " "))
+		case len(words) >= 2 && words[0] == "STRUCTURED" && words[1] == "PRIVILEGE":
+			return g, notSupported("granting structured privileges")
+		case words != nil:
+			g.system = append(g.system, strings.Join(words, " "))
+		default:
+			n, err := roleName(item)
+			if err != nil {
+				return g, err
+			}
+			if _, ok := db.roles[n]; !ok {
+				return g, invalidRole(n)
+			}
+			g.roles = append(g.roles, n)
+		}
+	}
+	if !on {
+		return g, nil
+	}
+
+	var err error
+	switch {
+	case p.keyword("SCHEMA"):
+		g.target.objectType = "SCHEMA"
+		if g.target.schema, err = p.name(); err != nil {
+			return g, err
+		}
+		if _, ok := db.schemas[g.target.schema]; !ok {
+			return g, invalidSchema(g.target.schema)
+		}
+	case p.keyword("USERGROUP"):
+		g.target.objectType = "USERGROUP"
+		if g.target.object, err = p.name(); err != nil {
+			return g, err
+		}
+		if _, ok := db.usergroups[g.target.object]; !ok {
+			return g, invalidUsergroup(g.target.object)
+		}
+	case p.keyword("X509", "PROVIDER"):
+		g.target.objectType = "X509 PROVIDER"
+		if g.target.object, err = p.name(); err != nil {
+			return g, err
+		}
+		if _, ok := db.providers[g.target.object]; !ok {
+			return g, invalidObject("X509 provider", g.target.object)
+		}
+	case p.keyword("REMOTE", "SOURCE"), p.keyword("PSE"), p.keyword("JWT", "PROVIDER"), p.keyword("SAML", "PROVIDER"),
+		p.keyword("CLIENTSIDE", "ENCRYPTION", "COLUMN", "KEY"):
+		return g, notSupported("granting privileges on " + p.toks[p.pos-1].text + " objects")
+	default:
+		schemaName, object, err := p.qualifiedName()
+		if err != nil {
+			return g, err
+		}
+		if _, ok := db.schemas[schemaName]; schemaName != "" && !ok {
+			return g, invalidSchema(schemaName)
+		}
+		// Tables and views are not simulated
+		return g, newError(errCodeInvalidTableName, "invalid table name: "+objectName{schema: schemaName, name: object}.String())
+	}
+	return g, nil
+}
+
+// roleName returns the role an item of a GRANT or REVOKE statement names.
+func roleName(item []token) (objectName, error) {
+	switch {
+	case len(item) == 1 && item[0].kind == tokQuoted:
+		return objectName{name: item[0].text}, nil
+	case len(item) == 3 && item[0].kind == tokQuoted && item[1].text == "." && item[2].kind == tokQuoted:
+		return objectName{schema: item[0].text, name: item[2].text}, nil
+	default:
+		return objectName{}, syntaxError("invalid privilege or role")
+	}
+}
+
+func grant(db *Database, p *parser, session string) error {
+	g, err := p.grantable(db, "TO")
+	if err != nil {
+		return err
+	}
+	if err := p.expect("TO"); err != nil {
+		return err
+	}
+	schemaName, name, err := p.qualifiedName()
+	if err != nil {
+		return err
+	}
+	grantee := objectName{schema: schemaName, name: name}
+	granteeType, err := db.grantee(grantee)
+	if err != nil {
+		return err
+	}
+	withOption := p.keyword("WITH", "GRANT", "OPTION") || p.keyword("WITH", "ADMIN", "OPTION")
+
+	for _, r := range g.roles {
+		i := slices.IndexFunc(db.roleGrants, func(o roleGrant) bool { return o.grantee == grantee && o.role == r })
+		if i < 0 {
+			db.roleGrants = append(db.roleGrants, roleGrant{grantee: grantee, granteeType: granteeType, role: r, grantable: withOption})
+			continue
+		}
+		db.roleGrants[i].grantable = db.roleGrants[i].grantable || withOption
+	}
+
+	privileges := make([]privilegeGrant, 0, len(g.system)+len(g.privileges))
+	for _, s := range g.system {
+		privileges = append(privileges, privilegeGrant{objectType: "SYSTEMPRIVILEGE", privilege: s})
+	}
+	for _, s := range g.privileges {
+		pg := g.target
+		pg.privilege = s
+		privileges = append(privileges, pg)
+	}
+	for _, pg := range privileges {
+		pg.grantee, pg.granteeType, pg.grantable, pg.grantor = grantee, granteeType, withOption, session
+		i := slices.IndexFunc(db.privileges, func(o privilegeGrant) bool { return o.samePrivilege(pg) })
+		if i < 0 {
+			db.privileges = append(db.privileges, pg)
+			continue
+		}
+		db.privileges[i].grantable = db.privileges[i].grantable || withOption
+	}
+	return nil
+}
+
+func revoke(db *Database, p *parser, _ string) error {
+	g, err := p.grantable(db, "FROM")
+	if err != nil {
+		return err
+	}
+	if err := p.expect("FROM"); err != nil {
+		return err
+	}
+	schemaName, name, err := p.qualifiedName()
+	if err != nil {
+		return err
+	}
+	grantee := objectName{schema: schemaName, name: name}
+	if _, err := db.grantee(grantee); err != nil {
+		return err
+	}
+
+	db.roleGrants = slices.DeleteFunc(db.roleGrants, func(o roleGrant) bool {
+		return o.grantee == grantee && slices.Contains(g.roles, o.role)
+	})
+	db.privileges = slices.DeleteFunc(db.privileges, func(o privilegeGrant) bool {
+		if o.grantee != grantee {
+			return false
+		}
+		if o.objectType == "SYSTEMPRIVILEGE" {
+			return slices.Contains(g.system, o.privilege)
+		}
+		return o.objectType == g.target.objectType && o.schema == g.target.schema && o.object == g.target.object &&
+			slices.Contains(g.privileges, o.privilege)
+	})
+	return nil
+}
+
+// samePrivilege returns whether both grant the same privilege to the same
+// grantee by the same grantor, whether grantable or not.
+func (g privilegeGrant) samePrivilege(o privilegeGrant) bool {
+	g.grantable, o.grantable = false, false
+	return g == o
+}
+
+func comment(db *Database, p *parser, _ string) error {
+	var target **string
+	switch {
+	case p.keyword("USER"):
+		name, err := p.name()
+		if err != nil {
+			return err
+		}
+		u, ok := db.users[name]
+		if !ok {
+			return invalidUser(name)
+		}
+		target = &u.comment
+	case p.keyword("ROLE"):
+		schemaName, name, err := p.qualifiedName()
+		if err != nil {
+			return err
+		}
+		r, ok := db.roles[objectName{schema: schemaName, name: name}]
+		if !ok {
+			return invalidRole(objectName{schema: schemaName, name: name})
+		}
+		target = &r.comment
+	case p.keyword("SCHEMA"):
+		name, err := p.name()
+		if err != nil {
+			return err
+		}
+		s, ok := db.schemas[name]
+		if !ok {
+			return invalidSchema(name)
+		}
+		target = &s.comment
+	default:
+		return notSupported("commenting on " + p.peek().text)
+	}
+	if err := p.expect("IS"); err != nil {
+		return err
+	}
+	c, err := p.str()
+	if err != nil {
+		return err
+	}
+	*target = nil
+	if c != "" {
+		*target = &c
+	}
+	return nil
+}
+
+// parameters reads a list of parameters, key = 'value', whose keys may be
+// identifiers or string literals.
+func (p *parser) parameters() (map[string]string, error) {
+	parameters := map[string]string{}
+	for {
+		var key string
+		var err error
+		if t := p.peek(); t.kind == tokString {
+			key, err = p.str()
+		} else {
+			key, err = p.name()
+		}
+		if err != nil {
+			return nil, err
+		}
+		if !p.symbol("=") {
+			return nil, p.unexpected("=")
+		}
+		if parameters[key], err = p.str(); err != nil {
+			return nil, err
+		}
+		if !p.symbol(",") {
+			return parameters, nil
+		}
+	}
+}
+
+// clearParameters reads a comma-separated list of parameter keys, and
+// removes them from the parameters.
+func (p *parser) clearParameters(parameters map[string]string) error {
+	for {
+		var key string
+		var err error
+		if t := p.peek(); t.kind == tokString {
+			key, err = p.str()
+		} else {
+			key, err = p.name()
+		}
+		if err != nil {
+			return err
+		}
+		delete(parameters, key)
+		if !p.symbol(",") {
+			return nil
+		}
+	}
+}
+
+// identity reads the subject and X.509 provider of a user identity.
+func (p *parser) identity(db *Database) (identity, error) {
+	subject, err := p.str()
+	if err != nil {
+		return identity{}, err
+	}
+	if err := p.expect("FOR", "X509", "PROVIDER"); err != nil {
+		return identity{}, err
+	}
+	provider, err := p.name()
+	if err != nil {
+		return identity{}, err
+	}
+	if _, ok := db.providers[provider]; !ok {
+		return identity{}, invalidObject("X509 provider", provider)
+	}
+	return identity{provider: provider, subject: subject}, nil
+}
+
+// enable reads ENABLE or DISABLE, if next.
+func (p *parser) enable() (enabled, ok bool) {
+	switch {
+	case p.keyword("ENABLE"):
+		return true, true
+	case p.keyword("DISABLE"):
+		return false, true
+	default:
+		return false, false
+	}
+}
+
+// strs reads a comma-separated list of string literals.
+func (p *parser) strs() ([]string, error) {
+	var list []string
+	for {
+		s, err := p.str()
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, s)
+		if !p.symbol(",") {
+			return list, nil
+		}
+	}
+}
+
+// timestamp reads a timestamp literal, or nil for the keyword meaning none.
+func (p *parser) timestamp(none string) (*time.Time, error) {
+	if p.keyword(none) {
+		return nil, nil
+	}
+	s, err := p.str()
+	if err != nil {
+		return nil, err
+	}
+	t, err := time.Parse(timestampLayout, s)
+	if err != nil {
+		return nil, syntaxError("invalid timestamp " + s)
+	}
+	return &t, nil
+}
+
+func invalidUser(name string) error {
+	return newError(errCodeInvalidUserName, "invalid user name: "+name)
+}
+
+func invalidRole(n objectName) error {
+	return newError(errCodeInvalidRoleName, "invalid role name: "+n.String())
+}
+
+func invalidSchema(name string) error {
+	return newError(errCodeInvalidSchemaName, "invalid schema name: "+name)
+}
+
+func invalidUsergroup(name string) error {
+	return invalidObject("usergroup", name)
+}
+
+func invalidObject(kind, name string) error {
+	return newError(errCodeInvalidObjectName, fmt.Sprintf("invalid %s name: %s", kind, name))
+}
+
+func duplicateObject(kind, name string) error {
+	return newError(errCodeDuplicateName, fmt.Sprintf("cannot use duplicate %s name: %s", kind, name))
+}
+
+func restrict(kind, name string) error {
+	return newError(errCodeRestrict, fmt.Sprintf("cannot drop %s %s with RESTRICT specification, objects depend on it", kind, name))
+}
@@ -0,0 +1,212 @@
+/*
+Copyright 2026 SAP SE or an SAP affiliate company and contributors.
+*/
+
+package simulator
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+type tokenKind int
+
+const (
+	// tokWord is an unquoted identifier or keyword, folded to uppercase.
+	tokWord tokenKind = iota
+	// tokQuoted is a double quoted identifier, or password, as written.
+	tokQuoted
+	// tokString is a single quoted string literal.
+	tokString
+	// tokNumber is an unsigned integer literal.
+	tokNumber
+	// tokParam is a ? placeholder.
+	tokParam
+	// tokSymbol is any other character, or the <> operator.
+	tokSymbol
+)
+
+type token struct {
+	kind tokenKind
+	text string
+}
+
+// tokenize splits a statement into tokens.
+func tokenize(query string) ([]token, error) {
+	var toks []token
+	rs := []rune(query)
+	for i := 0; i < len(rs); {
+		r := rs[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '"' || r == '\'':
+			var b strings.Builder
+			j := i + 1
+			for ; j < len(rs); j++ {
+				if rs[j] == r {
+					if j+1 < len(rs) && rs[j+1] == r {
+						b.WriteRune(r)
+						j++
+						continue
+					}
+					break
+				}
+				b.WriteRune(rs[j])
+			}
+			if j >= len(rs) {
+				return nil, fmt.Errorf("unterminated literal at position %d", i)
+			}
+			kind := tokQuoted
+			if r == '\'' {
+				kind = tokString
+			}
+			toks = append(toks, token{kind: kind, text: b.String()})
+			i = j + 1
+		case unicode.IsDigit(r):
+			j := i
+			for j < len(rs) && unicode.IsDigit(rs[j]) {
+				j++
+			}
+			toks = append(toks, token{kind: tokNumber, text: string(rs[i:j])})
+			i = j
+		case unicode.IsLetter(r) || r == '_' || r == '#' || r == '$':
+			j := i
+			for j < len(rs) && (unicode.IsLetter(rs[j]) || unicode.IsDigit(rs[j]) || rs[j] == '_' || rs[j] == '#' || rs[j] == '$') {
+				j++
+			}
+			toks = append(toks, token{kind: tokWord, text: strings.ToUpper(string(rs[i:j]))})
+			i = j
+		case r == '?':
+			toks = append(toks, token{kind: tokParam, text: "?"})
+			i++
+		case r == '<' && i+1 < len(rs) && rs[i+1] == '>':
+			toks = append(toks, token{kind: tokSymbol, text: "<>"})
+			i += 2
+		default:
+			toks = append(toks, token{kind: tokSymbol, text: string(r)})
+			i++
+		}
+	}
+	return toks, nil
+}
+
+// parser reads the tokens of a statement, binding its ? placeholders to args
+// in order.
+type parser struct {
+	toks []token
+	pos  int
+	args []driver.NamedValue
+	arg  int
+}
+
+func newParser(query string, args []driver.NamedValue) (*parser, error) {
+	toks, err := tokenize(query)
+	if err != nil {
+		return nil, err
+	}
+	return &parser{toks: toks, args: args}, nil
+}
+
+func (p *parser) done() bool {
+	return p.pos >= len(p.toks)
+}
+
+func (p *parser) peek() token {
+	if p.done() {
+		return token{kind: tokSymbol}
+	}
+	return p.toks[p.pos]
+}
+
+// keyword consumes the words if the next tokens are these unquoted words.
+func (p *parser) keyword(words ...string) bool {
+	if p.pos+len(words) > len(p.toks) {
+		return false
+	}
+	for i, w := range words {
+		if t := p.toks[p.pos+i]; t.kind != tokWord || t.text != w {
+			return false
+		}
+	}
+	p.pos += len(words)
+	return true
+}
+
+func (p *parser) expect(words ...string) error {
+	if !p.keyword(words...) {
+		return p.unexpected(strings.Join(words, " "))
+	}
+	return nil
+}
+
+// symbol consumes the symbol if it is next.
+func (p *parser) symbol(s string) bool {
+	if t := p.peek(); t.kind == tokSymbol && t.text == s {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *parser) unexpected(want string) error {
+	if p.done() {
+		return syntaxError(fmt.Sprintf("expected %s at the end of the statement", want))
+	}
+	return syntaxError(fmt.Sprintf("expected %s, found %q", want, p.peek().text))
+}
+
+// name reads an identifier, as HANA stores it.
+func (p *parser) name() (string, error) {
+	t := p.peek()
+	if t.kind != tokWord && t.kind != tokQuoted {
+		return "", p.unexpected("an identifier")
+	}
+	p.pos++
+	return t.text, nil
+}
+
+// qualifiedName reads a name that may be qualified by a schema.
+func (p *parser) qualifiedName() (schema, name string, err error) {
+	if name, err = p.name(); err != nil {
+		return "", "", err
+	}
+	if p.symbol(".") {
+		schema = name
+		if name, err = p.name(); err != nil {
+			return "", "", err
+		}
+	}
+	return schema, name, nil
+}
+
+// value reads a string literal, number or placeholder.
+func (p *parser) value() (any, error) {
+	t := p.peek()
+	switch t.kind {
+	case tokString, tokNumber:
+		p.pos++
+		return t.text, nil
+	case tokParam:
+		p.pos++
+		if p.arg >= len(p.args) {
+			return nil, syntaxError("missing argument of placeholder")
+		}
+		v := p.args[p.arg].Value
+		p.arg++
+		return v, nil
+	default:
+		return nil, p.unexpected("a literal")
+	}
+}
+
+// str reads a string literal or placeholder.
+func (p *parser) str() (string, error) {
+	v, err := p.value()
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprint(v), nil
+}
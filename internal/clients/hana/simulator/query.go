@@ -0,0 +1,539 @@
+/*
+Copyright 2026 SAP SE or an SAP affiliate company and contributors.
+*/
+
+package simulator
+
+import (
+	"cmp"
+	"database/sql/driver"
+	"fmt"
+	"io"
+	"maps"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// table is the result of a catalog view or query.
+type table struct {
+	columns []string
+	rows    [][]driver.Value
+}
+
+// views are the catalog views the simulator computes from its catalog, by
+// name without the SYS schema. Views the simulator keeps no data for, such as
+// the audit log, are empty.
+var views = map[string]func(db *Database) table{
+	"USERS": func(db *Database) table {
+		t := table{columns: []string{"USER_NAME", "USERGROUP_NAME", "CREATE_TIME", "LAST_PASSWORD_CHANGE_TIME", "IS_RESTRICTED", "IS_CLIENT_CONNECT_ENABLED", "IS_PASSWORD_LIFETIME_CHECK_ENABLED", "IS_PASSWORD_ENABLED", "VALID_FROM", "VALID_UNTIL", "LAST_SUCCESSFUL_CONNECT", "INVALID_CONNECT_ATTEMPTS", "USER_DEACTIVATED", "EXTERNAL_IDENTITY", "COMMENTS"}}
+		for _, u := range sortedValues(db.users) {
+			t.rows = append(t.rows, []driver.Value{u.name, nullable(u.usergroup), u.created, u.passwordChanged, u.restricted, u.clientConnect, u.passwordLifetime, u.passwordEnabled, nullableTime(u.validFrom), nullableTime(u.validUntil), nil, u.invalidConnectAttempts, u.deactivated, nil, nullableString(u.comment)})
+		}
+		return t
+	},
+	"USER_PARAMETERS": func(db *Database) table {
+		t := table{columns: []string{"USER_NAME", "PARAMETER", "VALUE"}}
+		for _, u := range sortedValues(db.users) {
+			for _, k := range slices.Sorted(maps.Keys(u.parameters)) {
+				t.rows = append(t.rows, []driver.Value{u.name, k, u.parameters[k]})
+			}
+		}
+		return t
+	},
+	"X509_USER_MAPPINGS": func(db *Database) table {
+		t := table{columns: []string{"USER_NAME", "X509_PROVIDER_NAME", "SUBJECT_NAME"}}
+		for _, u := range sortedValues(db.users) {
+			for _, i := range u.identities {
+				t.rows = append(t.rows, []driver.Value{u.name, i.provider, i.subject})
+			}
+		}
+		return t
+	},
+	"AUTHENTICATION_ERROR_DETAILS": func(db *Database) table {
+		t := table{columns: []string{"CORRELATION_ID", "INTERNAL_ERROR_CODE"}}
+		for _, id := range slices.Sorted(maps.Keys(db.authErrors)) {
+			t.rows = append(t.rows, []driver.Value{id, db.authErrors[id]})
+		}
+		return t
+	},
+	"AUDIT_LOG": func(*Database) table {
+		return table{columns: []string{"TIMESTAMP", "USER_NAME", "EVENT_ACTION", "EVENT_STATUS", "COMMENT"}}
+	},
+	"M_CONNECTIONS": func(*Database) table {
+		return table{columns: []string{"CONNECTION_ID", "USER_NAME", "CONNECTION_STATUS"}}
+	},
+	"M_PASSWORD_POLICY": func(*Database) table {
+		return table{
+			columns: []string{"PROPERTY", "VALUE"},
+			rows:    [][]driver.Value{{"maximum_password_lifetime", defaultPasswordLifetime}},
+		}
+	},
+	"ROLES": func(db *Database) table {
+		t := table{columns: []string{"ROLE_SCHEMA_NAME", "ROLE_NAME", "ROLEGROUP_NAME", "COMMENTS"}}
+		for _, r := range sortedRoles(db.roles) {
+			t.rows = append(t.rows, []driver.Value{nullable(r.schema), r.name, nullable(r.rolegroup), nullableString(r.comment)})
+		}
+		return t
+	},
+	"ROLE_LDAP_GROUPS": func(db *Database) table {
+		t := table{columns: []string{"ROLE_SCHEMA_NAME", "ROLE_NAME", "LDAP_GROUP_NAME"}}
+		for _, r := range sortedRoles(db.roles) {
+			for _, g := range r.ldapGroups {
+				t.rows = append(t.rows, []driver.Value{nullable(r.schema), r.name, g})
+			}
+		}
+		return t
+	},
+	"ROLEGROUPS": func(db *Database) table {
+		t := table{columns: []string{"ROLEGROUP_NAME", "IS_ROLE_ADMIN_ENABLED"}}
+		for _, g := range sortedValues(db.rolegroups) {
+			t.rows = append(t.rows, []driver.Value{g.name, flag(g.roleAdmin)})
+		}
+		return t
+	},
+	"SCHEMAS": func(db *Database) table {
+		t := table{columns: []string{"SCHEMA_NAME", "SCHEMA_OWNER", "COMMENTS"}}
+		for _, s := range sortedValues(db.schemas) {
+			t.rows = append(t.rows, []driver.Value{s.name, s.owner, nullableString(s.comment)})
+		}
+		return t
+	},
+	"OBJECTS": func(*Database) table {
+		return table{columns: []string{"SCHEMA_NAME", "OBJECT_NAME", "OBJECT_TYPE"}}
+	},
+	"USERGROUPS": func(db *Database) table {
+		t := table{columns: []string{"USERGROUP_NAME", "IS_USER_ADMIN_ENABLED"}}
+		for _, g := range sortedValues(db.usergroups) {
+			t.rows = append(t.rows, []driver.Value{g.name, flag(g.userAdmin)})
+		}
+		return t
+	},
+	"USERGROUP_PARAMETERS": func(db *Database) table {
+		t := table{columns: []string{"USERGROUP_NAME", "PARAMETER_SET_NAME", "PARAMETER_NAME", "PARAMETER_VALUE", "IS_PARAMETER_SET_ENABLED"}}
+		for _, g := range sortedValues(db.usergroups) {
+			for _, k := range slices.Sorted(maps.Keys(g.parameters)) {
+				t.rows = append(t.rows, []driver.Value{g.name, passwordPolicy, k, g.parameters[k], flag(g.enabledSets[passwordPolicy])})
+			}
+		}
+		return t
+	},
+	"GRANTED_PRIVILEGES": func(db *Database) table {
+		t := table{columns: []string{"GRANTEE_SCHEMA_NAME", "GRANTEE", "GRANTEE_TYPE", "OBJECT_TYPE", "PRIVILEGE", "SCHEMA_NAME", "OBJECT_NAME", "IS_GRANTABLE", "GRANTOR"}}
+		for _, g := range db.privileges {
+			t.rows = append(t.rows, []driver.Value{nullable(g.grantee.schema), g.grantee.name, g.granteeType, g.objectType, g.privilege, nullable(g.schema), nullable(g.object), g.grantable, g.grantor})
+		}
+		return t
+	},
+	"GRANTED_ROLES": func(db *Database) table {
+		t := table{columns: []string{"GRANTEE_SCHEMA_NAME", "GRANTEE", "GRANTEE_TYPE", "ROLE_SCHEMA_NAME", "ROLE_NAME", "IS_GRANTABLE"}}
+		for _, g := range db.roleGrants {
+			t.rows = append(t.rows, []driver.Value{nullable(g.grantee.schema), g.grantee.name, g.granteeType, nullable(g.role.schema), g.role.name, g.grantable})
+		}
+		return t
+	},
+	"EFFECTIVE_PRIVILEGES": func(db *Database) table {
+		t := table{columns: []string{"USER_NAME", "OBJECT_TYPE", "PRIVILEGE"}}
+		for _, u := range sortedValues(db.users) {
+			for _, p := range db.effectivePrivileges(u.name) {
+				t.rows = append(t.rows, []driver.Value{u.name, "SYSTEMPRIVILEGE", p})
+			}
+		}
+		return t
+	},
+	"X509_PROVIDERS": func(db *Database) table {
+		t := table{columns: []string{"X509_PROVIDER_NAME", "ISSUER_NAME", "PRIORITY"}}
+		for _, p := range sortedValues(db.providers) {
+			var priority driver.Value
+			if p.priority != nil {
+				priority = *p.priority
+			}
+			t.rows = append(t.rows, []driver.Value{p.name, p.issuer, priority})
+		}
+		return t
+	},
+	"X509_PROVIDER_RULES": func(db *Database) table {
+		t := table{columns: []string{"X509_PROVIDER_NAME", "MATCHING_RULE", "POSITION"}}
+		for _, p := range sortedValues(db.providers) {
+			for i, rule := range p.rules {
+				t.rows = append(t.rows, []driver.Value{p.name, rule, int64(i + 1)})
+			}
+		}
+		return t
+	},
+	"DUMMY": func(*Database) table {
+		return table{columns: []string{"DUMMY"}, rows: [][]driver.Value{{"X"}}}
+	},
+}
+
+// passwordPolicy is the usergroup parameter set of password policy
+// parameters, the only one the simulator knows.
+const passwordPolicy = "password policy"
+
+// passwordLifetimeQuery starts the query for the maximum password lifetime
+// of a usergroup, falling back to that of the instance.
+const passwordLifetimeQuery = "SELECT COALESCE("
+
+// condition is a comparison of a column in a WHERE clause.
+type condition struct {
+	column string
+	op     string
+	value  string
+}
+
+// query runs a SELECT statement of the form
+//
+//	SELECT [DISTINCT] [TOP n] columns FROM view [WHERE conditions] [ORDER BY columns]
+//
+// where the conditions are joined by AND, and compare a column with =, <> or
+// LIKE to a literal, a placeholder or CURRENT_USER.
+func (db *Database) query(session, query string, args []driver.NamedValue) (*rows, error) {
+	if strings.HasPrefix(query, passwordLifetimeQuery) {
+		return db.queryPasswordLifetime(args)
+	}
+
+	p, err := newParser(query, args)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expect("SELECT"); err != nil {
+		return nil, err
+	}
+	distinct := p.keyword("DISTINCT")
+	top := -1
+	if p.keyword("TOP") {
+		n, err := strconv.Atoi(p.peek().text)
+		if err != nil {
+			return nil, p.unexpected("a number")
+		}
+		p.pos++
+		top = n
+	}
+
+	var selected []string
+	for {
+		if p.keyword("NULL") {
+			selected = append(selected, "")
+		} else {
+			column, err := p.name()
+			if err != nil {
+				return nil, err
+			}
+			selected = append(selected, column)
+		}
+		if !p.symbol(",") {
+			break
+		}
+	}
+
+	if err := p.expect("FROM"); err != nil {
+		return nil, err
+	}
+	schemaName, viewName, err := p.qualifiedName()
+	if err != nil {
+		return nil, err
+	}
+	view, ok := views[viewName]
+	if !ok || (schemaName != "" && schemaName != "SYS") {
+		return nil, notSupported("querying " + objectName{schema: schemaName, name: viewName}.String())
+	}
+
+	var conditions []condition
+	if p.keyword("WHERE") {
+		for {
+			c, err := p.condition(session)
+			if err != nil {
+				return nil, err
+			}
+			conditions = append(conditions, c)
+			if !p.keyword("AND") {
+				break
+			}
+		}
+	}
+
+	type order struct {
+		column string
+		desc   bool
+	}
+	var orders []order
+	if p.keyword("ORDER", "BY") {
+		for {
+			column, err := p.name()
+			if err != nil {
+				return nil, err
+			}
+			desc := p.keyword("DESC")
+			if !desc {
+				p.keyword("ASC")
+			}
+			orders = append(orders, order{column: column, desc: desc})
+			if !p.symbol(",") {
+				break
+			}
+		}
+	}
+	if !p.done() {
+		return nil, p.unexpected("the end of the statement")
+	}
+
+	t := view(db)
+	index := func(column string) (int, error) {
+		if i := slices.Index(t.columns, column); i >= 0 {
+			return i, nil
+		}
+		return 0, newError(errCodeInvalidColumnName, "invalid column name: "+column)
+	}
+
+	filtered := make([][]driver.Value, 0, len(t.rows))
+	for _, row := range t.rows {
+		match := true
+		for _, c := range conditions {
+			i, err := index(c.column)
+			if err != nil {
+				return nil, err
+			}
+			if match, err = c.matches(row[i]); err != nil {
+				return nil, err
+			}
+			if !match {
+				break
+			}
+		}
+		if match {
+			filtered = append(filtered, row)
+		}
+	}
+
+	for _, o := range slices.Backward(orders) {
+		i, err := index(o.column)
+		if err != nil {
+			return nil, err
+		}
+		slices.SortStableFunc(filtered, func(a, b []driver.Value) int {
+			if o.desc {
+				return compare(b[i], a[i])
+			}
+			return compare(a[i], b[i])
+		})
+	}
+
+	result := &rows{columns: selected}
+	seen := map[string]bool{}
+	for _, row := range filtered {
+		if top >= 0 && len(result.values) >= top {
+			break
+		}
+		projected := make([]driver.Value, len(selected))
+		for j, column := range selected {
+			if column == "" {
+				continue
+			}
+			i, err := index(column)
+			if err != nil {
+				return nil, err
+			}
+			projected[j] = row[i]
+		}
+		if distinct {
+			var key strings.Builder
+			for _, v := range projected {
+				key.WriteString(text(v) + "\x00")
+			}
+			if seen[key.String()] {
+				continue
+			}
+			seen[key.String()] = true
+		}
+		result.values = append(result.values, projected)
+	}
+	return result, nil
+}
+
+// queryPasswordLifetime answers the query for the maximum password lifetime
+// of the usergroup, which is that of its enabled password policy or else
+// that of the instance, and whether the usergroup has its own.
+func (db *Database) queryPasswordLifetime(args []driver.NamedValue) (*rows, error) {
+	if len(args) != 2 {
+		return nil, syntaxError("expected the usergroup as arguments")
+	}
+	lifetime, own := defaultPasswordLifetime, int64(0)
+	if g, ok := db.usergroups[fmt.Sprint(args[0].Value)]; ok && g.enabledSets[passwordPolicy] {
+		if v, ok := g.parameters["maximum_password_lifetime"]; ok {
+			lifetime, own = v, 1
+		}
+	}
+	return &rows{columns: []string{"COALESCE", "COUNT"}, values: [][]driver.Value{{lifetime, own}}}, nil
+}
+
+func (p *parser) condition(session string) (condition, error) {
+	column, err := p.name()
+	if err != nil {
+		return condition{}, err
+	}
+	c := condition{column: column}
+	switch {
+	case p.symbol("="):
+		c.op = "="
+	case p.symbol("<>"):
+		c.op = "<>"
+	case p.keyword("LIKE"):
+		c.op = "LIKE"
+	default:
+		return condition{}, p.unexpected("=, <> or LIKE")
+	}
+	if p.keyword("CURRENT_USER") {
+		c.value = session
+		return c, nil
+	}
+	c.value, err = p.str()
+	return c, err
+}
+
+// matches returns whether the column value satisfies the condition. NULL
+// satisfies no condition.
+func (c condition) matches(v driver.Value) (bool, error) {
+	if v == nil {
+		return false, nil
+	}
+	s := text(v)
+	switch c.op {
+	case "=":
+		return s == c.value, nil
+	case "<>":
+		return s != c.value, nil
+	default:
+		re, err := likePattern(c.value)
+		if err != nil {
+			return false, err
+		}
+		return re.MatchString(s), nil
+	}
+}
+
+// likePattern translates a LIKE pattern into a regular expression.
+func likePattern(pattern string) (*regexp.Regexp, error) {
+	var b strings.Builder
+	b.WriteString("^")
+	for _, r := range pattern {
+		switch r {
+		case '%':
+			b.WriteString(".*")
+		case '_':
+			b.WriteString(".")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	b.WriteString("$")
+	return regexp.Compile(b.String())
+}
+
+// text returns a column value as SQL compares it with a string.
+func text(v driver.Value) string {
+	switch v := v.(type) {
+	case bool:
+		return flag(v)
+	case time.Time:
+		return v.Format(timestampLayout)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// compare orders column values, NULL first.
+func compare(a, b driver.Value) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return -1
+	case b == nil:
+		return 1
+	}
+	switch a := a.(type) {
+	case int64:
+		if b, ok := b.(int64); ok {
+			return cmp.Compare(a, b)
+		}
+	case time.Time:
+		if b, ok := b.(time.Time); ok {
+			return a.Compare(b)
+		}
+	}
+	return strings.Compare(text(a), text(b))
+}
+
+// timestampLayout is the layout of timestamp literals.
+const timestampLayout = "2006-01-02 15:04:05"
+
+func flag(b bool) string {
+	if b {
+		return "TRUE"
+	}
+	return "FALSE"
+}
+
+func nullable(s string) driver.Value {
+	if s == "" {
+		return nil
+	}
+	return s
+}
+
+func nullableString(s *string) driver.Value {
+	if s == nil {
+		return nil
+	}
+	return *s
+}
+
+func nullableTime(t *time.Time) driver.Value {
+	if t == nil {
+		return nil
+	}
+	return *t
+}
+
+// sortedValues returns the values of the map ordered by key, so that
+// queries return rows in a stable order.
+func sortedValues[K cmp.Ordered, V any](m map[K]V) []V {
+	keys := slices.Sorted(maps.Keys(m))
+	values := make([]V, len(keys))
+	for i, k := range keys {
+		values[i] = m[k]
+	}
+	return values
+}
+
+func sortedRoles(m map[objectName]*role) []*role {
+	return slices.SortedFunc(maps.Values(m), func(a, b *role) int {
+		return cmp.Or(cmp.Compare(a.schema, b.schema), cmp.Compare(a.name, b.name))
+	})
+}
+
+// rows are the rows of a query result.
+type rows struct {
+	columns []string
+	values  [][]driver.Value
+	pos     int
+}
+
+func (r *rows) Columns() []string {
+	return r.columns
+}
+
+func (r *rows) Close() error {
+	return nil
+}
+
+func (r *rows) Next(dest []driver.Value) error {
+	if r.pos >= len(r.values) {
+		return io.EOF
+	}
+	copy(dest, r.values[r.pos])
+	r.pos++
+	return nil
+}
@@ -0,0 +1,143 @@
+/*
+Copyright 2026 SAP SE or an SAP affiliate company and contributors.
+*/
+
+// Package simulator is an in-memory stand-in for a HANA database, so that the
+// controllers can run without a HANA instance, e.g. for local development and
+// in CI. It understands the subset of SQL the clients send for users, roles,
+// rolegroups, schemas, usergroups, X.509 providers, privileges and comments,
+// and answers their queries of the catalog views from its own catalog.
+// Statements and views beyond that subset, e.g. of PSEs or audit policies,
+// fail as not supported by the simulator.
+package simulator
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"sync"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	"github.com/SAP/crossplane-provider-hana/internal/clients/xsql"
+)
+
+// New returns a Connector to a new, empty simulated database. Users that
+// connect for the first time are created with administrative privileges, so
+// that any technical user can manage the simulated database.
+func New() xsql.Connector {
+	return NewConnector(NewDatabase())
+}
+
+// NewConnector returns a Connector to the simulated database.
+func NewConnector(db *Database) xsql.Connector {
+	return &connector{db: db}
+}
+
+type connector struct {
+	db  *Database
+	mu  sync.Mutex
+	dbs map[string]*sql.DB
+}
+
+// Connect returns a DB of the user of the credentials. Like HANA, the
+// simulator rejects passwords other than that of the user.
+func (c *connector) Connect(_ context.Context, creds map[string][]byte) (xsql.DB, error) {
+	username := string(creds[xpv1.ResourceCredentialsSecretUserKey])
+	password := string(creds[xpv1.ResourceCredentialsSecretPasswordKey])
+	if username == "" {
+		return nil, newError(errCodeAuthFailed, "authentication failed: no user")
+	}
+	if err := c.db.login(username, password); err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if db, ok := c.dbs[username]; ok {
+		return db, nil
+	}
+	if c.dbs == nil {
+		c.dbs = map[string]*sql.DB{}
+	}
+	db := sql.OpenDB(sessionConnector{db: c.db, user: username})
+	c.dbs[username] = db
+	return db, nil
+}
+
+// Disconnect closes the DBs of all users. The simulated database is kept.
+func (c *connector) Disconnect() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var errs []error
+	for _, db := range c.dbs {
+		errs = append(errs, db.Close())
+	}
+	c.dbs = nil
+	return errors.Join(errs...)
+}
+
+// sessionConnector opens connections of a user to the simulated database.
+type sessionConnector struct {
+	db   *Database
+	user string
+}
+
+func (c sessionConnector) Connect(context.Context) (driver.Conn, error) {
+	return &conn{db: c.db, user: c.user}, nil
+}
+
+func (c sessionConnector) Driver() driver.Driver {
+	return simulatorDriver{}
+}
+
+// simulatorDriver only opens connections through a sessionConnector.
+type simulatorDriver struct{}
+
+func (simulatorDriver) Open(string) (driver.Conn, error) {
+	return nil, notSupported("opening connections by DSN")
+}
+
+// conn is a connection of a user to the simulated database. Statements are
+// run right away, prepared statements and transactions are not supported.
+type conn struct {
+	db   *Database
+	user string
+}
+
+var (
+	_ driver.ExecerContext  = (*conn)(nil)
+	_ driver.QueryerContext = (*conn)(nil)
+)
+
+func (c *conn) ExecContext(_ context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	c.db.mu.Lock()
+	defer c.db.mu.Unlock()
+	if err := c.db.exec(c.user, query, args); err != nil {
+		return nil, err
+	}
+	return driver.RowsAffected(0), nil
+}
+
+func (c *conn) QueryContext(_ context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	c.db.mu.Lock()
+	defer c.db.mu.Unlock()
+	r, err := c.db.query(c.user, query, args)
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (c *conn) Prepare(string) (driver.Stmt, error) {
+	return nil, notSupported("preparing statements")
+}
+
+func (c *conn) Begin() (driver.Tx, error) {
+	return nil, notSupported("transactions")
+}
+
+func (c *conn) Close() error {
+	return nil
+}
@@ -0,0 +1,251 @@
+/*
+Copyright 2026 SAP SE or an SAP affiliate company and contributors.
+*/
+
+package simulator
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/SAP/go-hdb/driver"
+
+	"github.com/SAP/crossplane-provider-hana/apis/admin/v1alpha1"
+	schemav1alpha1 "github.com/SAP/crossplane-provider-hana/apis/schema/v1alpha1"
+	"github.com/SAP/crossplane-provider-hana/internal/clients/hana/dbschema"
+	roleclient "github.com/SAP/crossplane-provider-hana/internal/clients/hana/role"
+	userclient "github.com/SAP/crossplane-provider-hana/internal/clients/hana/user"
+	usergroupclient "github.com/SAP/crossplane-provider-hana/internal/clients/hana/usergroup"
+	"github.com/SAP/crossplane-provider-hana/internal/clients/xsql"
+)
+
+const admin = "ADMIN"
+
+func connect(t *testing.T, c xsql.Connector, username, password string) xsql.DB {
+	t.Helper()
+	db, err := c.Connect(context.Background(), map[string][]byte{
+		xpv1.ResourceCredentialsSecretUserKey:     []byte(username),
+		xpv1.ResourceCredentialsSecretPasswordKey: []byte(password),
+	})
+	if err != nil {
+		t.Fatalf("Connect(%s): %v", username, err)
+	}
+	return db
+}
+
+func TestConnect(t *testing.T) {
+	c := New()
+	db := connect(t, c, admin, "Secret1")
+
+	rows, err := db.QueryContext(context.Background(), "SELECT DISTINCT PRIVILEGE FROM SYS.EFFECTIVE_PRIVILEGES WHERE USER_NAME = CURRENT_USER AND OBJECT_TYPE = 'SYSTEMPRIVILEGE'")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close() //nolint:errcheck
+	var privileges []string
+	for rows.Next() {
+		var p string
+		if err := rows.Scan(&p); err != nil {
+			t.Fatal(err)
+		}
+		privileges = append(privileges, p)
+	}
+	if diff := cmp.Diff(adminPrivileges, privileges, cmpopts.SortSlices(func(a, b string) bool { return a < b })); diff != "" {
+		t.Errorf("users connecting first should be administrators: -want, +got:\n%s", diff)
+	}
+
+	_, err = c.Connect(context.Background(), map[string][]byte{
+		xpv1.ResourceCredentialsSecretUserKey:     []byte(admin),
+		xpv1.ResourceCredentialsSecretPasswordKey: []byte("wrong"),
+	})
+	var dbErr driver.DBError
+	if !errors.As(err, &dbErr) || dbErr.Code() != errCodeAuthFailed {
+		t.Errorf("Connect(...) with a wrong password: want authentication error, got %v", err)
+	}
+}
+
+func TestUserLifecycle(t *testing.T) {
+	ctx := context.Background()
+	db := connect(t, New(), admin, "Secret1")
+
+	if err := dbschema.New(db).Create(ctx, &schemav1alpha1.DbSchemaParameters{SchemaName: "APP"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := roleclient.New(db, admin).Create(ctx, &v1alpha1.RoleParameters{
+		RoleName:   "READER",
+		LdapGroups: []string{"cn=readers"},
+		Privileges: []string{"SELECT ON SCHEMA APP"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := usergroupclient.New(db).Create(ctx, &v1alpha1.UsergroupParameters{
+		UsergroupName:      "APPS",
+		Parameters:         map[string]string{"maximum_password_lifetime": "30"},
+		EnableParameterSet: "password policy",
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	params := &v1alpha1.UserParameters{
+		Username:       "ALICE",
+		Authentication: v1alpha1.Authentication{Password: &v1alpha1.Password{PasswordSecretRef: &xpv1.SecretKeySelector{}}},
+		Privileges:     []string{"CATALOG READ"},
+		Roles:          []string{"READER"},
+		Parameters:     map[string]string{"CLIENT": "100"},
+		Usergroup:      "APPS",
+
+		IsPasswordLifetimeCheckEnabled: true,
+	}
+	client := userclient.New(db, admin)
+	if err := client.Create(ctx, params, "Initial1", nil); err != nil {
+		t.Fatalf("Create(...): %v", err)
+	}
+
+	observed, err := client.Read(ctx, params, "Initial1")
+	if err != nil {
+		t.Fatalf("Read(...): %v", err)
+	}
+	want := struct {
+		Username         string
+		Usergroup        string
+		Privileges       []string
+		Roles            []string
+		Parameters       map[string]string
+		PasswordUpToDate bool
+	}{"ALICE", "APPS", []string{"CATALOG READ"}, []string{`"READER"`}, map[string]string{"CLIENT": "100"}, true}
+	got := want
+	got.Username, got.Usergroup = *observed.Username, *observed.Usergroup
+	got.Privileges, got.Roles, got.Parameters = observed.Privileges, observed.Roles, observed.Parameters
+	got.PasswordUpToDate = observed.PasswordUpToDate != nil && *observed.PasswordUpToDate
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Read(...): -want, +got:\n%s", diff)
+	}
+	if e := observed.PasswordExpiresAt; e == nil || !e.Equal(&metav1.Time{Time: observed.LastPasswordChangeTime.AddDate(0, 0, 30)}) {
+		t.Errorf("Read(...): want the password to expire by the usergroup policy after 30 days, got %v", e)
+	}
+
+	// A changed password is detected through the authentication error details
+	observed, err = client.Read(ctx, params, "Changed1")
+	if err != nil {
+		t.Fatalf("Read(...): %v", err)
+	}
+	if observed.PasswordUpToDate == nil || *observed.PasswordUpToDate {
+		t.Errorf("Read(...): want the password to be outdated, got %v", observed.PasswordUpToDate)
+	}
+
+	if err := client.Delete(ctx, params); err != nil {
+		t.Fatalf("Delete(...): %v", err)
+	}
+	observed, err = client.Read(ctx, params, "Initial1")
+	if err != nil {
+		t.Fatalf("Read(...): %v", err)
+	}
+	if observed.Username != nil {
+		t.Errorf("Read(...): want the deleted user not to be observed, got %s", *observed.Username)
+	}
+}
+
+func TestExec(t *testing.T) {
+	type want struct {
+		code int
+	}
+
+	cases := map[string]struct {
+		reason string
+		query  string
+		want   want
+	}{
+		"Supported": {
+			reason: "Supported statements should be applied",
+			query:  `CREATE USER "BOB" PASSWORD "Secret1" NO FORCE_FIRST_PASSWORD_CHANGE SET PARAMETER CLIENT = '100'`,
+		},
+		"DuplicateUser": {
+			reason: "Existing users should not be created again",
+			query:  `CREATE USER "ADMIN"`,
+			want:   want{code: errCodeDuplicateUserName},
+		},
+		"InvalidUser": {
+			reason: "Statements on unknown users should fail as in HANA",
+			query:  `ALTER USER "NOBODY" DISABLE CLIENT CONNECT`,
+			want:   want{code: errCodeInvalidUserName},
+		},
+		"InvalidRole": {
+			reason: "Unknown roles should not be granted",
+			query:  `GRANT "NOROLE" TO "ADMIN"`,
+			want:   want{code: errCodeInvalidRoleName},
+		},
+		"Tables": {
+			reason: "Privileges on tables should fail, as tables are not simulated",
+			query:  `GRANT SELECT ON "ADMIN"."T" TO "ADMIN"`,
+			want:   want{code: errCodeInvalidTableName},
+		},
+		"NotSupported": {
+			reason: "Statements beyond the simulated subset should fail as not supported",
+			query:  `CREATE PSE "P"`,
+			want:   want{code: errCodeFeatureNotSupported},
+		},
+		"Syntax": {
+			reason: "Malformed statements should fail as syntax errors",
+			query:  `CREATE USER "BOB" PASSWORD`,
+			want:   want{code: errCodeSQLSyntax},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			db := connect(t, New(), admin, "Secret1")
+			_, err := db.ExecContext(context.Background(), tc.query)
+			code := 0
+			var dbErr driver.DBError
+			if errors.As(err, &dbErr) {
+				code = dbErr.Code()
+			} else if err != nil {
+				t.Fatalf("ExecContext(...): want a HANA error, got %v", err)
+			}
+			if diff := cmp.Diff(tc.want.code, code); diff != "" {
+				t.Errorf("\n%s\nExecContext(...): -want error code, +got error code:\n%s\n%v", tc.reason, diff, err)
+			}
+		})
+	}
+}
+
+func TestQuery(t *testing.T) {
+	ctx := context.Background()
+	db := connect(t, New(), admin, "Secret1")
+	for _, stmt := range []string{
+		`CREATE USER "BOB_1" PASSWORD "Secret1"`,
+		`CREATE USER "BOB_2" PASSWORD "Secret1"`,
+		`CREATE RESTRICTED USER "CAROL"`,
+	} {
+		if _, err := db.ExecContext(ctx, stmt); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	rows, err := db.QueryContext(ctx, "SELECT TOP 1 USER_NAME FROM SYS.USERS WHERE USER_NAME LIKE ? AND IS_RESTRICTED = 'FALSE' ORDER BY USER_NAME DESC", "BOB%")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close() //nolint:errcheck
+	var got []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, name)
+	}
+	if diff := cmp.Diff([]string{"BOB_2"}, got); diff != "" {
+		t.Errorf("QueryContext(...): -want, +got:\n%s", diff)
+	}
+
+	if _, err := db.QueryContext(ctx, "SELECT PSE_NAME FROM PSES"); err == nil {
+		t.Errorf("QueryContext(...): want views beyond the simulated subset to fail")
+	}
+}
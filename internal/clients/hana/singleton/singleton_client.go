@@ -0,0 +1,57 @@
+/*
+Copyright 2026 SAP SE or an SAP affiliate company and contributors.
+*/
+
+package singleton
+
+import (
+	"context"
+
+	"github.com/SAP/crossplane-provider-hana/apis/admin/v1alpha1"
+	"github.com/SAP/crossplane-provider-hana/internal/clients/hana"
+	"github.com/SAP/crossplane-provider-hana/internal/clients/xsql"
+)
+
+// SingletonClient defines the interface for singleton client operations
+type SingletonClient = hana.QueryClient[v1alpha1.SingletonParameters, v1alpha1.SingletonObservation]
+
+// Client struct holds the connection to the db
+type Client struct {
+	xsql.DB
+}
+
+// New creates a new db client
+func New(db xsql.DB) Client {
+	return Client{
+		DB: db,
+	}
+}
+
+// Read runs the observation query. The artifact exists if it returns a row.
+func (c Client) Read(ctx context.Context, parameters *v1alpha1.SingletonParameters) (*v1alpha1.SingletonObservation, error) {
+	rows, err := c.QueryContext(ctx, parameters.ObservationQuery)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close() //nolint:errcheck
+	exists := rows.Next()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return &v1alpha1.SingletonObservation{Exists: exists}, nil
+}
+
+// Create runs the create statement
+func (c Client) Create(ctx context.Context, parameters *v1alpha1.SingletonParameters) error {
+	_, err := c.ExecContext(ctx, parameters.CreateStatement)
+	return err
+}
+
+// Delete runs the drop statement, if any
+func (c Client) Delete(ctx context.Context, parameters *v1alpha1.SingletonParameters) error {
+	if parameters.DropStatement == "" {
+		return nil
+	}
+	_, err := c.ExecContext(ctx, parameters.DropStatement)
+	return err
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2026 SAP SE or an SAP affiliate company and contributors.
+*/
+
+package singleton
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/SAP/crossplane-provider-hana/apis/admin/v1alpha1"
+	"github.com/SAP/crossplane-provider-hana/internal/clients/fake"
+)
+
+const observationQuery = "SELECT 1 FROM SYS.ST_SPATIAL_REFERENCE_SYSTEMS WHERE SRS_ID = 3857"
+
+func TestRead(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type want struct {
+		observed *v1alpha1.SingletonObservation
+		err      error
+	}
+
+	cases := map[string]struct {
+		reason string
+		rows   *sqlmock.Rows
+		err    error
+		want   want
+	}{
+		"ErrRead": {
+			reason: "Any errors encountered while running the observation query should be returned",
+			err:    errBoom,
+			want:   want{err: errBoom},
+		},
+		"Missing": {
+			reason: "The artifact should not exist if the observation query returns no rows",
+			rows:   sqlmock.NewRows([]string{"1"}),
+			want:   want{observed: &v1alpha1.SingletonObservation{}},
+		},
+		"Exists": {
+			reason: "The artifact should exist if the observation query returns any row, whatever its columns",
+			rows:   sqlmock.NewRows([]string{"SRS_ID", "SRS_NAME"}).AddRow(3857, "WGS 84 / Pseudo-Mercator"),
+			want:   want{observed: &v1alpha1.SingletonObservation{Exists: true}},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			db := fake.MockDB{
+				MockQueryContext: func(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+					db, mock, _ := sqlmock.New()
+					q := mock.ExpectQuery("SELECT 1 FROM SYS.ST_SPATIAL_REFERENCE_SYSTEMS")
+					if tc.err != nil {
+						q.WillReturnError(tc.err)
+					} else {
+						q.WillReturnRows(tc.rows)
+					}
+					return db.QueryContext(ctx, query, args...)
+				},
+			}
+			got, err := New(db).Read(context.Background(), &v1alpha1.SingletonParameters{ObservationQuery: observationQuery})
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nRead(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.observed, got); diff != "" {
+				t.Errorf("\n%s\nRead(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCreateDelete(t *testing.T) {
+	parameters := &v1alpha1.SingletonParameters{
+		CreateStatement: "CREATE SPATIAL REFERENCE SYSTEM ...",
+		DropStatement:   `DROP SPATIAL REFERENCE SYSTEM "WGS 84 / Pseudo-Mercator"`,
+	}
+
+	cases := map[string]struct {
+		reason     string
+		op         func(Client, *v1alpha1.SingletonParameters) error
+		parameters *v1alpha1.SingletonParameters
+		want       []string
+	}{
+		"Create": {
+			reason:     "The create statement should be run as written",
+			op:         func(c Client, p *v1alpha1.SingletonParameters) error { return c.Create(context.Background(), p) },
+			parameters: parameters,
+			want:       []string{parameters.CreateStatement},
+		},
+		"Delete": {
+			reason:     "The drop statement should be run as written",
+			op:         func(c Client, p *v1alpha1.SingletonParameters) error { return c.Delete(context.Background(), p) },
+			parameters: parameters,
+			want:       []string{parameters.DropStatement},
+		},
+		"Keep": {
+			reason:     "Nothing should be run on deletion without a drop statement",
+			op:         func(c Client, p *v1alpha1.SingletonParameters) error { return c.Delete(context.Background(), p) },
+			parameters: &v1alpha1.SingletonParameters{CreateStatement: parameters.CreateStatement},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var got []string
+			db := fake.MockDB{
+				MockExecContext: func(ctx context.Context, query string, args ...any) (sql.Result, error) {
+					got = append(got, query)
+					return nil, nil
+				},
+			}
+			if err := tc.op(New(db), tc.parameters); err != nil {
+				t.Fatalf("\n%s\n%v", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\n-want statements, +got statements:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,140 @@
+/*
+Copyright 2026 SAP SE or an SAP affiliate company and contributors.
+*/
+
+package statements
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrUnsupported is returned for statements the server version does not
+// support.
+var ErrUnsupported = errors.New("not supported by the HANA version")
+
+const errUnsupported = "%w: %s requires %s, the server runs %s"
+
+// cloudMajor is the major version of HANA Cloud, HANA 2.0 is major version 2.
+const cloudMajor = 4
+
+// A Feature is syntax that not all HANA versions support.
+type Feature struct {
+	name string
+	// minRevision is the first HANA 2.0 revision supporting the feature,
+	// or zero if only HANA Cloud supports it.
+	minRevision int
+	// allRevisions marks features that all HANA 2.0 revisions support.
+	allRevisions bool
+}
+
+func (f Feature) String() string {
+	return f.name
+}
+
+// Features that differ between HANA versions.
+var (
+	// Usergroups are supported as of HANA 2.0 SPS 03.
+	Usergroups = Feature{name: "usergroups", minRevision: 30}
+	// X509Providers, and PSEs with the purpose X509 for a provider, are
+	// supported as of HANA 2.0 SPS 04.
+	X509Providers = Feature{name: "X.509 providers", minRevision: 40}
+	// UsergroupDefault moves users into the default usergroup with SET
+	// USERGROUP DEFAULT. Other versions use UNSET USERGROUP.
+	UsergroupDefault = Feature{name: "SET USERGROUP DEFAULT"}
+	// MonitoringRole is the predefined MONITORING role granting read access
+	// to the monitoring views, shipped by HANA 2.0 and HANA Cloud.
+	MonitoringRole = Feature{name: "the MONITORING role", allRevisions: true}
+	// StatisticsSchema is the _SYS_STATISTICS schema of the embedded
+	// statistics service, the only statistics service of HANA 2.0 and HANA
+	// Cloud.
+	StatisticsSchema = Feature{name: "the _SYS_STATISTICS schema", allRevisions: true}
+)
+
+// Version is a HANA server version, e.g. 2.00.059.00.1636466430 for HANA 2.0
+// SPS 05 revision 59 or 4.00.000.00.1712345678 for HANA Cloud.
+type Version struct {
+	Major    int
+	Revision int
+	raw      string
+}
+
+// ParseVersion parses the VERSION of SYS.M_DATABASE.
+func ParseVersion(v string) (Version, error) {
+	parts := strings.Split(strings.TrimSpace(v), ".")
+	if len(parts) < 3 {
+		return Version{}, fmt.Errorf("invalid HANA version %q", v)
+	}
+	major, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return Version{}, fmt.Errorf("invalid HANA version %q: %w", v, err)
+	}
+	revision, err := strconv.Atoi(parts[2])
+	if err != nil {
+		return Version{}, fmt.Errorf("invalid HANA version %q: %w", v, err)
+	}
+	return Version{Major: major, Revision: revision, raw: v}, nil
+}
+
+// Cloud returns whether the version is HANA Cloud.
+func (v Version) Cloud() bool {
+	return v.Major >= cloudMajor
+}
+
+func (v Version) String() string {
+	if v.Cloud() {
+		return "HANA Cloud " + v.raw
+	}
+	return fmt.Sprintf("HANA %d.0 SPS %02d revision %d", v.Major, v.Revision/10, v.Revision)
+}
+
+// A Dialect selects the statements for a HANA version. The zero Dialect is
+// for an unknown version and assumes every feature is supported, so the
+// server rejects what it does not support.
+type Dialect struct {
+	version *Version
+}
+
+// NewDialect returns the dialect of the version reported by the server. An
+// empty or invalid version results in the dialect of an unknown version.
+func NewDialect(version string) Dialect {
+	v, err := ParseVersion(version)
+	if err != nil {
+		return Dialect{}
+	}
+	return Dialect{version: &v}
+}
+
+// Known returns whether the version of the server is known.
+func (d Dialect) Known() bool {
+	return d.version != nil
+}
+
+// Supports returns whether the server supports the feature.
+func (d Dialect) Supports(f Feature) bool {
+	switch {
+	case d.version == nil, d.version.Cloud():
+		return true
+	case f.minRevision == 0 && !f.allRevisions, d.version.Major < 2:
+		return false
+	default:
+		return d.version.Major > 2 || d.version.Revision >= f.minRevision
+	}
+}
+
+// Require returns an error wrapping ErrUnsupported if the server does not
+// support the feature.
+func (d Dialect) Require(f Feature) error {
+	if d.Supports(f) {
+		return nil
+	}
+	required := "HANA Cloud"
+	if f.allRevisions {
+		required = "HANA 2.0 or HANA Cloud"
+	} else if f.minRevision > 0 {
+		required = fmt.Sprintf("HANA 2.0 SPS %02d or HANA Cloud", f.minRevision/10)
+	}
+	return fmt.Errorf(errUnsupported, ErrUnsupported, f, required, d.version)
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2026 SAP SE or an SAP affiliate company and contributors.
+*/
+
+package statements
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseVersion(t *testing.T) {
+	cases := map[string]struct {
+		version string
+		want    Version
+		wantErr bool
+	}{
+		"HANA2":   {version: "2.00.059.00.1636466430", want: Version{Major: 2, Revision: 59, raw: "2.00.059.00.1636466430"}},
+		"Cloud":   {version: "4.00.000.00.1712345678", want: Version{Major: 4, Revision: 0, raw: "4.00.000.00.1712345678"}},
+		"Short":   {version: "2.00", wantErr: true},
+		"Invalid": {version: "x.00.059", wantErr: true},
+		"Empty":   {version: "", wantErr: true},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := ParseVersion(tc.version)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("ParseVersion(%q) error = %v, wantErr %v", tc.version, err, tc.wantErr)
+			}
+			if got != tc.want {
+				t.Errorf("ParseVersion(%q) = %+v, want %+v", tc.version, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestDialectSupports(t *testing.T) {
+	cases := map[string]struct {
+		version string
+		feature Feature
+		want    bool
+	}{
+		"UnknownUsergroupDefault": {version: "", feature: UsergroupDefault, want: true},
+		"CloudUsergroupDefault":   {version: "4.00.000.00.1712345678", feature: UsergroupDefault, want: true},
+		"HANA2UsergroupDefault":   {version: "2.00.059.00.1636466430", feature: UsergroupDefault, want: false},
+		"SPS02Usergroups":         {version: "2.00.024.00.1520000000", feature: Usergroups, want: false},
+		"SPS03Usergroups":         {version: "2.00.030.00.1520000000", feature: Usergroups, want: true},
+		"SPS03X509Providers":      {version: "2.00.037.00.1520000000", feature: X509Providers, want: false},
+		"SPS04X509Providers":      {version: "2.00.040.00.1560000000", feature: X509Providers, want: true},
+		"HANA1Usergroups":         {version: "1.00.122.00.1500000000", feature: Usergroups, want: false},
+		"HANA2MonitoringRole":     {version: "2.00.000.00.1479000000", feature: MonitoringRole, want: true},
+		"HANA1MonitoringRole":     {version: "1.00.122.00.1500000000", feature: MonitoringRole, want: false},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := NewDialect(tc.version).Supports(tc.feature); got != tc.want {
+				t.Errorf("Supports(%s) on %q = %v, want %v", tc.feature, tc.version, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestDialectRequire(t *testing.T) {
+	if err := NewDialect("").Require(UsergroupDefault); err != nil {
+		t.Errorf("Require on unknown version: %v", err)
+	}
+
+	err := NewDialect("2.00.024.00.1520000000").Require(Usergroups)
+	if !errors.Is(err, ErrUnsupported) {
+		t.Fatalf("Require(Usergroups) = %v, want %v", err, ErrUnsupported)
+	}
+	want := "not supported by the HANA version: usergroups requires HANA 2.0 SPS 03 or HANA Cloud, the server runs HANA 2.0 SPS 02 revision 24"
+	if err.Error() != want {
+		t.Errorf("Require(Usergroups) = %q, want %q", err, want)
+	}
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2026 SAP SE or an SAP affiliate company and contributors.
+*/
+
+package statements
+
+import (
+	"strconv"
+	"strings"
+)
+
+// CreatePSE returns a CREATE PSE statement.
+func CreatePSE(name string) string {
+	return "CREATE PSE " + Identifier(name)
+}
+
+// DropPSE returns a DROP PSE statement.
+func DropPSE(name string) string {
+	return "DROP PSE " + Identifier(name)
+}
+
+// SetPSEPurposeX509 returns a statement making the PSE hold the certificates
+// of the X.509 provider.
+func SetPSEPurposeX509(pse, provider string) string {
+	return "SET PSE " + Identifier(pse) + " PURPOSE X509 FOR PROVIDER " + Identifier(provider)
+}
+
+// UnsetPSEPurpose returns a statement removing the purpose from the PSE. The
+// object is the provider, or the remote source for the REMOTE SOURCE purpose,
+// the purpose is set for; purposes without an object leave it empty.
+func UnsetPSEPurpose(pse, purpose, object string) string {
+	stmt := "UNSET PSE " + Identifier(pse) + " PURPOSE " + purpose
+	switch {
+	case object == "":
+		return stmt
+	case purpose == "REMOTE SOURCE":
+		return stmt + " FOR REMOTE SOURCE " + Identifier(object)
+	default:
+		return stmt + " FOR PROVIDER " + Identifier(object)
+	}
+}
+
+// AlterPSEStatement builds ALTER PSE statements of a PSE.
+type AlterPSEStatement struct {
+	prefix string
+}
+
+// AlterPSE returns a builder of ALTER PSE statements for the PSE.
+func AlterPSE(name string) AlterPSEStatement {
+	return AlterPSEStatement{prefix: "ALTER PSE " + Identifier(name)}
+}
+
+// AddCertificateIDs adds the certificates with the ids.
+func (s AlterPSEStatement) AddCertificateIDs(ids []int) string {
+	return s.prefix + " ADD CERTIFICATE " + idList(ids)
+}
+
+// AddCertificates adds the certificates with the names.
+func (s AlterPSEStatement) AddCertificates(names []string) string {
+	return s.prefix + " ADD CERTIFICATE " + Identifiers(names)
+}
+
+// DropCertificateIDs drops the certificates with the ids.
+func (s AlterPSEStatement) DropCertificateIDs(ids []int) string {
+	return s.prefix + " DROP CERTIFICATE " + idList(ids)
+}
+
+// DropCertificates drops the certificates with the names.
+func (s AlterPSEStatement) DropCertificates(names []string) string {
+	return s.prefix + " DROP CERTIFICATE " + Identifiers(names)
+}
+
+// AddCRL adds the PEM encoded certificate revocation list.
+func (s AlterPSEStatement) AddCRL(crl string) string {
+	return s.prefix + " ADD CRL " + String(crl)
+}
+
+// DropCRL drops the certificate revocation list.
+func (s AlterPSEStatement) DropCRL() string {
+	return s.prefix + " DROP CRL"
+}
+
+func idList(ids []int) string {
+	list := make([]string, len(ids))
+	for i, id := range ids {
+		list[i] = strconv.Itoa(id)
+	}
+	return strings.Join(list, ", ")
+}
@@ -0,0 +1,28 @@
+/*
+Copyright 2026 SAP SE or an SAP affiliate company and contributors.
+*/
+
+package statements
+
+import (
+	"testing"
+)
+
+func TestPSEStatements(t *testing.T) {
+	p := AlterPSE("My.Pse")
+
+	check(t, map[string]struct{ got, want string }{
+		"CreatePSE":          {CreatePSE(`a"b`), `CREATE PSE "a""b"`},
+		"DropPSE":            {DropPSE("PSE"), `DROP PSE "PSE"`},
+		"SetPurpose":         {SetPSEPurposeX509("PSE", "idp"), `SET PSE "PSE" PURPOSE X509 FOR PROVIDER "idp"`},
+		"UnsetPurpose":       {UnsetPSEPurpose("PSE", "X509", "idp"), `UNSET PSE "PSE" PURPOSE X509 FOR PROVIDER "idp"`},
+		"UnsetRemoteSource":  {UnsetPSEPurpose("PSE", "REMOTE SOURCE", "rs"), `UNSET PSE "PSE" PURPOSE REMOTE SOURCE FOR REMOTE SOURCE "rs"`},
+		"UnsetSSL":           {UnsetPSEPurpose("PSE", "SSL", ""), `UNSET PSE "PSE" PURPOSE SSL`},
+		"AddCertificateIDs":  {p.AddCertificateIDs([]int{1, 23}), `ALTER PSE "My.Pse" ADD CERTIFICATE 1, 23`},
+		"AddCertificates":    {p.AddCertificates([]string{"Root CA", `x"y`}), `ALTER PSE "My.Pse" ADD CERTIFICATE "Root CA", "x""y"`},
+		"DropCertificateIDs": {p.DropCertificateIDs([]int{7}), `ALTER PSE "My.Pse" DROP CERTIFICATE 7`},
+		"DropCertificates":   {p.DropCertificates([]string{"Old"}), `ALTER PSE "My.Pse" DROP CERTIFICATE "Old"`},
+		"AddCRL":             {p.AddCRL("-----BEGIN X509 CRL-----'"), `ALTER PSE "My.Pse" ADD CRL '-----BEGIN X509 CRL-----'''`},
+		"DropCRL":            {p.DropCRL(), `ALTER PSE "My.Pse" DROP CRL`},
+	})
+}
@@ -0,0 +1,72 @@
+/*
+Copyright 2026 SAP SE or an SAP affiliate company and contributors.
+*/
+
+// Package statements builds the SQL statements the HANA clients execute.
+// Values taken from managed resources are quoted and escaped here only, so
+// that they can never change the structure of a statement.
+package statements
+
+import (
+	"strings"
+
+	"github.com/SAP/crossplane-provider-hana/internal/utils"
+)
+
+// Identifier returns name as a quoted identifier, taken as written by HANA.
+func Identifier(name string) string {
+	return utils.QuoteIdentifier(name)
+}
+
+// String returns s as a string literal.
+func String(s string) string {
+	return "'" + utils.EscapeSingleQuotes(s) + "'"
+}
+
+// Password returns password as it is written after PASSWORD.
+func Password(password string) string {
+	return `"` + utils.EscapeDoubleQuotes(password) + `"`
+}
+
+// Identifiers returns names as a comma-separated list of quoted identifiers.
+func Identifiers(names []string) string {
+	quoted := make([]string, len(names))
+	for i, name := range names {
+		quoted[i] = Identifier(name)
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// Grant options.
+const (
+	NoOption GrantOption = iota
+	// WithGrantOption lets the grantee grant object privileges further.
+	WithGrantOption
+	// WithAdminOption lets the grantee grant system privileges and roles
+	// further.
+	WithAdminOption
+)
+
+// GrantOption is the option a privilege or role is granted with.
+type GrantOption int
+
+// Grant returns a GRANT statement. what and grantee are rendered SQL, e.g.
+// privileges and roles normalized by the privilege package and a quoted
+// grantee.
+func Grant(what, grantee string, option GrantOption) string {
+	query := "GRANT " + what + " TO " + grantee
+	switch option {
+	case WithGrantOption:
+		query += " WITH GRANT OPTION"
+	case WithAdminOption:
+		query += " WITH ADMIN OPTION"
+	case NoOption:
+	}
+	return query
+}
+
+// Revoke returns a REVOKE statement. what and grantee are rendered SQL, like
+// for Grant.
+func Revoke(what, grantee string) string {
+	return "REVOKE " + what + " FROM " + grantee
+}
@@ -0,0 +1,39 @@
+/*
+Copyright 2026 SAP SE or an SAP affiliate company and contributors.
+*/
+
+package statements
+
+import (
+	"testing"
+)
+
+// check compares the statements built by each case with the expected ones.
+func check(t *testing.T, cases map[string]struct{ got, want string }) {
+	t.Helper()
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if tc.got != tc.want {
+				t.Errorf("got  %s\nwant %s", tc.got, tc.want)
+			}
+		})
+	}
+}
+
+func TestQuoting(t *testing.T) {
+	check(t, map[string]struct{ got, want string }{
+		"Identifier":         {Identifier("MyUser"), `"MyUser"`},
+		"IdentifierEscaped":  {Identifier(`a"; DROP USER x; --`), `"a""; DROP USER x; --"`},
+		"String":             {String("CN=Test"), `'CN=Test'`},
+		"StringEscaped":      {String(`O'Reilly'; DROP USER x; --`), `'O''Reilly''; DROP USER x; --'`},
+		"Password":           {Password("Secret1"), `"Secret1"`},
+		"PasswordEscaped":    {Password(`Pa"ss`), `"Pa""ss"`},
+		"Identifiers":        {Identifiers([]string{"A", `b"c`}), `"A", "b""c"`},
+		"IdentifiersEmpty":   {Identifiers(nil), ``},
+		"Grant":              {Grant(`SELECT ON SCHEMA "S"`, `"U"`, NoOption), `GRANT SELECT ON SCHEMA "S" TO "U"`},
+		"GrantWithGrant":     {Grant(`SELECT ON SCHEMA "S"`, `"U"`, WithGrantOption), `GRANT SELECT ON SCHEMA "S" TO "U" WITH GRANT OPTION`},
+		"GrantWithAdmin":     {Grant(`CATALOG READ`, `"U"`, WithAdminOption), `GRANT CATALOG READ TO "U" WITH ADMIN OPTION`},
+		"Revoke":             {Revoke(`"R1", "R2"`, `"U"`), `REVOKE "R1", "R2" FROM "U"`},
+		"RevokeNoGrantQuote": {Revoke(`CATALOG READ`, `"U"`), `REVOKE CATALOG READ FROM "U"`},
+	})
+}
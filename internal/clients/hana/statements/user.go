@@ -0,0 +1,201 @@
+/*
+Copyright 2026 SAP SE or an SAP affiliate company and contributors.
+*/
+
+package statements
+
+import (
+	"maps"
+	"slices"
+	"strings"
+	"time"
+)
+
+// timestampLayout is the layout of timestamp literals.
+const timestampLayout = "2006-01-02 15:04:05"
+
+// CreateUserStatement builds a CREATE USER statement.
+type CreateUserStatement struct {
+	username   string
+	restricted bool
+	password   *string
+	noForce    bool
+	parameters map[string]string
+	usergroup  string
+}
+
+// CreateUser returns a builder of a CREATE USER statement for username.
+func CreateUser(username string) *CreateUserStatement {
+	return &CreateUserStatement{username: username}
+}
+
+// Restricted creates a restricted user.
+func (s *CreateUserStatement) Restricted(restricted bool) *CreateUserStatement {
+	s.restricted = restricted
+	return s
+}
+
+// Password sets the password of the user. Unless forceFirstPasswordChange is
+// set, the user is not forced to change it on first logon.
+func (s *CreateUserStatement) Password(password string, forceFirstPasswordChange bool) *CreateUserStatement {
+	s.password = &password
+	s.noForce = !forceFirstPasswordChange
+	return s
+}
+
+// Parameters sets user parameters. Keys must be known parameter names, they
+// are written as they are.
+func (s *CreateUserStatement) Parameters(parameters map[string]string) *CreateUserStatement {
+	s.parameters = parameters
+	return s
+}
+
+// Usergroup creates the user in usergroup.
+func (s *CreateUserStatement) Usergroup(usergroup string) *CreateUserStatement {
+	s.usergroup = usergroup
+	return s
+}
+
+func (s *CreateUserStatement) String() string {
+	query := "CREATE USER " + Identifier(s.username)
+	if s.restricted {
+		query = "CREATE RESTRICTED USER " + Identifier(s.username)
+	}
+	if s.password != nil {
+		query += " PASSWORD " + Password(*s.password)
+		if s.noForce {
+			query += " NO FORCE_FIRST_PASSWORD_CHANGE"
+		}
+	}
+	if len(s.parameters) > 0 {
+		query += " SET PARAMETER " + parameterList(s.parameters)
+	}
+	if s.usergroup != "" {
+		query += " SET USERGROUP " + Identifier(s.usergroup)
+	}
+	return query
+}
+
+// AlterUserStatement builds ALTER USER statements of a user.
+type AlterUserStatement struct {
+	prefix string
+}
+
+// AlterUser returns a builder of ALTER USER statements for username.
+func AlterUser(username string) AlterUserStatement {
+	return AlterUserStatement{prefix: "ALTER USER " + Identifier(username)}
+}
+
+// Password changes the password of the user.
+func (s AlterUserStatement) Password(password string, forceFirstPasswordChange bool) string {
+	query := s.prefix + " PASSWORD " + Password(password)
+	if !forceFirstPasswordChange {
+		query += " NO FORCE_FIRST_PASSWORD_CHANGE"
+	}
+	return query
+}
+
+// Parameters sets and clears user parameters in one statement. Keys must be
+// known parameter names, like for CreateUserStatement.Parameters.
+func (s AlterUserStatement) Parameters(set map[string]string, clear []string) string {
+	query := s.prefix
+	if len(set) > 0 {
+		query += " SET PARAMETER " + parameterList(set)
+	}
+	if len(clear) > 0 {
+		query += " CLEAR PARAMETER " + strings.Join(slices.Sorted(slices.Values(clear)), ", ")
+	}
+	return query
+}
+
+// SetUsergroup moves the user into usergroup.
+func (s AlterUserStatement) SetUsergroup(usergroup string) string {
+	return s.prefix + " SET USERGROUP " + Identifier(usergroup)
+}
+
+// SetUsergroupDefault moves the user into the default usergroup.
+func (s AlterUserStatement) SetUsergroupDefault() string {
+	return s.prefix + " SET USERGROUP DEFAULT"
+}
+
+// UnsetUsergroup moves the user out of its usergroup, on versions that do not
+// support SetUsergroupDefault.
+func (s AlterUserStatement) UnsetUsergroup() string {
+	return s.prefix + " UNSET USERGROUP"
+}
+
+// PasswordLifetime enables or disables the password lifetime check.
+func (s AlterUserStatement) PasswordLifetime(enabled bool) string {
+	return s.prefix + enable(enabled) + " PASSWORD LIFETIME"
+}
+
+// ClientConnect allows or forbids connecting with SQL clients.
+func (s AlterUserStatement) ClientConnect(enabled bool) string {
+	return s.prefix + enable(enabled) + " CLIENT CONNECT"
+}
+
+// PasswordAuthentication enables or disables authentication with a password.
+func (s AlterUserStatement) PasswordAuthentication(enabled bool) string {
+	return s.prefix + enable(enabled) + " PASSWORD"
+}
+
+// Validity sets the validity period of the user. A nil from is now, a nil
+// until is forever.
+func (s AlterUserStatement) Validity(from, until *time.Time) string {
+	f, u := "NOW", "FOREVER"
+	if from != nil {
+		f = String(from.UTC().Format(timestampLayout))
+	}
+	if until != nil {
+		u = String(until.UTC().Format(timestampLayout))
+	}
+	return s.prefix + " VALID FROM " + f + " UNTIL " + u
+}
+
+// AddIdentity maps the certificate subject of the X.509 provider to the user.
+func (s AlterUserStatement) AddIdentity(subject, provider string) string {
+	return s.prefix + " ADD IDENTITY " + String(subject) + " FOR X509 PROVIDER " + Identifier(provider)
+}
+
+// DropIdentity removes a mapping added with AddIdentity.
+func (s AlterUserStatement) DropIdentity(subject, provider string) string {
+	return s.prefix + " DROP IDENTITY " + String(subject) + " FOR X509 PROVIDER " + Identifier(provider)
+}
+
+// DeactivateNow deactivates the user, so it cannot open new sessions.
+func (s AlterUserStatement) DeactivateNow() string {
+	return s.prefix + " DEACTIVATE USER NOW"
+}
+
+// DropUser returns a DROP USER statement.
+func DropUser(username string) string {
+	return "DROP USER " + Identifier(username)
+}
+
+// ValidateUser returns a VALIDATE USER statement checking the password of the
+// user.
+func ValidateUser(username, password string) string {
+	return "VALIDATE USER " + Identifier(username) + " PASSWORD " + Password(password)
+}
+
+// DisconnectSession returns a statement disconnecting the session with the
+// connection id.
+func DisconnectSession(id string) string {
+	return "ALTER SYSTEM DISCONNECT SESSION " + String(id)
+}
+
+// parameterList returns the parameters sorted by key, as KEY = 'value'.
+func parameterList(parameters map[string]string) string {
+	list := make([]string, 0, len(parameters))
+	for _, key := range slices.Sorted(maps.Keys(parameters)) {
+		list = append(list, key+" = "+String(parameters[key]))
+	}
+	return strings.Join(list, ", ")
+}
+
+func enable(enabled bool) string {
+	if enabled {
+		return " ENABLE"
+	}
+	return " DISABLE"
+}
@@ -0,0 +1,75 @@
+/*
+Copyright 2026 SAP SE or an SAP affiliate company and contributors.
+*/
+
+package statements
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCreateUser(t *testing.T) {
+	check(t, map[string]struct{ got, want string }{
+		"Minimal": {
+			CreateUser("APP").String(),
+			`CREATE USER "APP"`,
+		},
+		"Restricted": {
+			CreateUser("app").Restricted(true).String(),
+			`CREATE RESTRICTED USER "app"`,
+		},
+		"Password": {
+			CreateUser("APP").Password(`Pa"ss'`, false).String(),
+			`CREATE USER "APP" PASSWORD "Pa""ss'" NO FORCE_FIRST_PASSWORD_CHANGE`,
+		},
+		"PasswordForceChange": {
+			CreateUser("APP").Password("Secret1", true).String(),
+			`CREATE USER "APP" PASSWORD "Secret1"`,
+		},
+		"Full": {
+			CreateUser("APP").
+				Password("Secret1", false).
+				Parameters(map[string]string{"LOCALE": "en_US", "CLIENT": "1'00"}).
+				Usergroup("APP_USERS").
+				String(),
+			`CREATE USER "APP" PASSWORD "Secret1" NO FORCE_FIRST_PASSWORD_CHANGE SET PARAMETER CLIENT = '1''00', LOCALE = 'en_US' SET USERGROUP "APP_USERS"`,
+		},
+	})
+}
+
+func TestAlterUser(t *testing.T) {
+	from := time.Date(2026, 1, 2, 3, 4, 5, 0, time.FixedZone("CET", 3600))
+	until := time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)
+	u := AlterUser(`a"b`)
+
+	check(t, map[string]struct{ got, want string }{
+		"Password":              {u.Password(`x"y`, false), `ALTER USER "a""b" PASSWORD "x""y" NO FORCE_FIRST_PASSWORD_CHANGE`},
+		"PasswordForceChange":   {u.Password("x", true), `ALTER USER "a""b" PASSWORD "x"`},
+		"SetParameters":         {u.Parameters(map[string]string{"LOCALE": "de'DE"}, nil), `ALTER USER "a""b" SET PARAMETER LOCALE = 'de''DE'`},
+		"ClearParameters":       {u.Parameters(nil, []string{"LOCALE", "CLIENT"}), `ALTER USER "a""b" CLEAR PARAMETER CLIENT, LOCALE`},
+		"SetAndClearParameters": {u.Parameters(map[string]string{"CLIENT": "100"}, []string{"LOCALE"}), `ALTER USER "a""b" SET PARAMETER CLIENT = '100' CLEAR PARAMETER LOCALE`},
+		"SetUsergroup":          {u.SetUsergroup("G"), `ALTER USER "a""b" SET USERGROUP "G"`},
+		"SetUsergroupDefault":   {u.SetUsergroupDefault(), `ALTER USER "a""b" SET USERGROUP DEFAULT`},
+		"UnsetUsergroup":        {u.UnsetUsergroup(), `ALTER USER "a""b" UNSET USERGROUP`},
+		"EnableLifetime":        {u.PasswordLifetime(true), `ALTER USER "a""b" ENABLE PASSWORD LIFETIME`},
+		"DisableLifetime":       {u.PasswordLifetime(false), `ALTER USER "a""b" DISABLE PASSWORD LIFETIME`},
+		"EnableClientConnect":   {u.ClientConnect(true), `ALTER USER "a""b" ENABLE CLIENT CONNECT`},
+		"DisableClientConnect":  {u.ClientConnect(false), `ALTER USER "a""b" DISABLE CLIENT CONNECT`},
+		"EnablePassword":        {u.PasswordAuthentication(true), `ALTER USER "a""b" ENABLE PASSWORD`},
+		"DisablePassword":       {u.PasswordAuthentication(false), `ALTER USER "a""b" DISABLE PASSWORD`},
+		"ValidityOpen":          {u.Validity(nil, nil), `ALTER USER "a""b" VALID FROM NOW UNTIL FOREVER`},
+		"Validity":              {u.Validity(&from, &until), `ALTER USER "a""b" VALID FROM '2026-01-02 02:04:05' UNTIL '2027-01-01 00:00:00'`},
+		"AddIdentity":           {u.AddIdentity("CN=O'Brien", "IDP"), `ALTER USER "a""b" ADD IDENTITY 'CN=O''Brien' FOR X509 PROVIDER "IDP"`},
+		"DropIdentity":          {u.DropIdentity("ANY", "idp"), `ALTER USER "a""b" DROP IDENTITY 'ANY' FOR X509 PROVIDER "idp"`},
+		"DeactivateNow":         {u.DeactivateNow(), `ALTER USER "a""b" DEACTIVATE USER NOW`},
+	})
+}
+
+func TestUserStatements(t *testing.T) {
+	check(t, map[string]struct{ got, want string }{
+		"DropUser":          {DropUser("APP"), `DROP USER "APP"`},
+		"ValidateUser":      {ValidateUser("APP", `p"w`), `VALIDATE USER "APP" PASSWORD "p""w"`},
+		"DisconnectSession": {DisconnectSession("400123"), `ALTER SYSTEM DISCONNECT SESSION '400123'`},
+	})
+}
@@ -0,0 +1,55 @@
+/*
+Copyright 2026 SAP SE or an SAP affiliate company and contributors.
+*/
+
+package statements
+
+import (
+	"strconv"
+	"strings"
+)
+
+// CreateX509Provider returns a CREATE X509 PROVIDER statement.
+func CreateX509Provider(name, issuer string) string {
+	return "CREATE X509 PROVIDER " + Identifier(name) + " WITH ISSUER " + String(issuer)
+}
+
+// DropX509Provider returns a DROP X509 PROVIDER statement.
+func DropX509Provider(name string) string {
+	return "DROP X509 PROVIDER " + Identifier(name)
+}
+
+// AlterX509ProviderStatement builds ALTER X509 PROVIDER statements of a
+// provider.
+type AlterX509ProviderStatement struct {
+	prefix string
+}
+
+// AlterX509Provider returns a builder of ALTER X509 PROVIDER statements for
+// the provider.
+func AlterX509Provider(name string) AlterX509ProviderStatement {
+	return AlterX509ProviderStatement{prefix: "ALTER X509 PROVIDER " + Identifier(name)}
+}
+
+// SetIssuer changes the issuer of the provider.
+func (s AlterX509ProviderStatement) SetIssuer(issuer string) string {
+	return s.prefix + " SET ISSUER " + String(issuer)
+}
+
+// SetPriority changes the priority of the provider.
+func (s AlterX509ProviderStatement) SetPriority(priority int) string {
+	return s.prefix + " SET PRIORITY " + strconv.Itoa(priority)
+}
+
+// SetMatchingRules replaces the matching rules of the provider. Without
+// rules, the matching rules are unset.
+func (s AlterX509ProviderStatement) SetMatchingRules(rules []string) string {
+	if len(rules) == 0 {
+		return s.prefix + " UNSET MATCHING RULES"
+	}
+	list := make([]string, len(rules))
+	for i, rule := range rules {
+		list[i] = String(rule)
+	}
+	return s.prefix + " SET MATCHING RULES " + strings.Join(list, ", ")
+}
@@ -0,0 +1,22 @@
+/*
+Copyright 2026 SAP SE or an SAP affiliate company and contributors.
+*/
+
+package statements
+
+import (
+	"testing"
+)
+
+func TestX509ProviderStatements(t *testing.T) {
+	p := AlterX509Provider("idp")
+
+	check(t, map[string]struct{ got, want string }{
+		"Create":             {CreateX509Provider("idp", "CN=O'Brien CA"), `CREATE X509 PROVIDER "idp" WITH ISSUER 'CN=O''Brien CA'`},
+		"Drop":               {DropX509Provider(`a"b`), `DROP X509 PROVIDER "a""b"`},
+		"SetIssuer":          {p.SetIssuer("CN=New CA"), `ALTER X509 PROVIDER "idp" SET ISSUER 'CN=New CA'`},
+		"SetPriority":        {p.SetPriority(2), `ALTER X509 PROVIDER "idp" SET PRIORITY 2`},
+		"SetMatchingRules":   {p.SetMatchingRules([]string{"CN=*", "O=it's"}), `ALTER X509 PROVIDER "idp" SET MATCHING RULES 'CN=*', 'O=it''s'`},
+		"UnsetMatchingRules": {p.SetMatchingRules(nil), `ALTER X509 PROVIDER "idp" UNSET MATCHING RULES`},
+	})
+}
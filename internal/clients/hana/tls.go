@@ -0,0 +1,185 @@
+package hana
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apisv1alpha1 "github.com/SAP/crossplane-provider-hana/apis/v1alpha1"
+	"github.com/SAP/crossplane-provider-hana/internal/clients/proxy"
+	"github.com/SAP/crossplane-provider-hana/internal/clients/xsql"
+)
+
+// Connection credential keys carrying the connection settings of a
+// ProviderConfig. They may also be set directly in the connection secret.
+const (
+	CredentialsKeyTLSRootCA             = "tlsRootCA"
+	CredentialsKeyTLSServerName         = "tlsServerName"
+	CredentialsKeyTLSInsecureSkipVerify = "tlsInsecureSkipVerify"
+	CredentialsKeyTLSMinVersion         = "tlsMinVersion"
+	CredentialsKeyProxyURL              = "proxyURL"
+	CredentialsKeyFailoverEndpoints     = "failoverEndpoints"
+)
+
+const (
+	errGetRootCASecret   = "cannot get root CA secret: %w"
+	errRootCAKeyNotFound = "key %s not found in root CA secret %s/%s"
+	errParseRootCA       = "cannot parse root CA: no PEM certificates found"
+	errUnknownTLSVersion = "unknown TLS version: %s"
+)
+
+var tlsVersions = map[string]uint16{
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// ConnectionCredentials returns the connection credentials of a
+// ProviderConfig with its service key, TLS, proxy and failover settings
+// merged into them. The supplied credentials are not modified.
+func ConnectionCredentials(ctx context.Context, kube client.Client, pc *apisv1alpha1.ProviderConfig, creds map[string][]byte) (map[string][]byte, error) {
+	if pc.Spec.Credentials.ServiceKey == "" && pc.Spec.TLS == nil && pc.Spec.Proxy == nil && len(pc.Spec.FailoverEndpoints) == 0 {
+		return creds, nil
+	}
+
+	res := make(map[string][]byte, len(creds)+6)
+	for k, v := range creds {
+		res[k] = v
+	}
+
+	if key := pc.Spec.Credentials.ServiceKey; key != "" {
+		data, ok := creds[key]
+		if !ok {
+			return nil, fmt.Errorf(errServiceKeyNotFound, key)
+		}
+		sk, err := serviceKeyCredentials(data)
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range sk {
+			res[k] = v
+		}
+	}
+
+	if len(pc.Spec.FailoverEndpoints) > 0 {
+		res[CredentialsKeyFailoverEndpoints] = []byte(strings.Join(pc.Spec.FailoverEndpoints, ","))
+	}
+
+	u, err := proxy.URL(ctx, kube, pc.Spec.Proxy)
+	if err != nil {
+		return nil, err
+	}
+	if u != nil {
+		res[CredentialsKeyProxyURL] = []byte(u.String())
+	}
+
+	cfg := pc.Spec.TLS
+	if cfg == nil {
+		return res, nil
+	}
+	if ref := cfg.RootCASecretRef; ref != nil {
+		s := &corev1.Secret{}
+		if err := kube.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, s); err != nil {
+			return nil, fmt.Errorf(errGetRootCASecret, err)
+		}
+		ca, ok := s.Data[ref.Key]
+		if !ok {
+			return nil, fmt.Errorf(errRootCAKeyNotFound, ref.Key, ref.Namespace, ref.Name)
+		}
+		res[CredentialsKeyTLSRootCA] = ca
+	}
+	if cfg.ValidateCertificate != nil {
+		res[CredentialsKeyTLSInsecureSkipVerify] = []byte(strconv.FormatBool(!*cfg.ValidateCertificate))
+	}
+	if cfg.ServerName != "" {
+		res[CredentialsKeyTLSServerName] = []byte(cfg.ServerName)
+	}
+	if cfg.MinVersion != "" {
+		res[CredentialsKeyTLSMinVersion] = []byte(cfg.MinVersion)
+	}
+	return res, nil
+}
+
+// tlsConfig builds the TLS configuration for the connection described by
+// creds, verifying the server certificate against endpoint by default.
+func tlsConfig(creds map[string][]byte, endpoint string) (*tls.Config, error) {
+	cfg := &tls.Config{
+		ServerName: endpoint,
+		MinVersion: tls.VersionTLS12,
+	}
+
+	if serverName := string(creds[CredentialsKeyTLSServerName]); serverName != "" {
+		cfg.ServerName = serverName
+	}
+
+	if v := string(creds[CredentialsKeyTLSInsecureSkipVerify]); v != "" {
+		skip, err := strconv.ParseBool(v)
+		if err != nil {
+			return nil, err
+		}
+		cfg.InsecureSkipVerify = skip //nolint:gosec // explicitly requested through the ProviderConfig
+	}
+
+	if v := string(creds[CredentialsKeyTLSMinVersion]); v != "" {
+		version, ok := tlsVersions[v]
+		if !ok {
+			return nil, fmt.Errorf(errUnknownTLSVersion, v)
+		}
+		cfg.MinVersion = version
+	}
+
+	if ca := creds[CredentialsKeyTLSRootCA]; len(ca) > 0 {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(ca) {
+			return nil, errors.New(errParseRootCA)
+		}
+		cfg.RootCAs = pool
+	}
+
+	return cfg, nil
+}
+
+// serverCA returns the PEM encoded CA certificate of a TLS connection: the
+// root of the verified chain, or the last certificate the server presented
+// if the chain was not verified.
+func serverCA(cs tls.ConnectionState) []byte {
+	chain := cs.PeerCertificates
+	if len(cs.VerifiedChains) > 0 {
+		chain = cs.VerifiedChains[0]
+	}
+	if len(chain) == 0 {
+		return nil
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: chain[len(chain)-1].Raw})
+}
+
+// recordServerCA records the CA certificate of every connection the pool
+// opens, so it is known without a separate handshake.
+func (e *endpointDB) recordServerCA(cs tls.ConnectionState) error {
+	if ca := serverCA(cs); ca != nil {
+		e.ca.Store(&ca)
+	}
+	return nil
+}
+
+// ServerCA returns the PEM encoded CA certificate of the endpoint db is
+// connected to, or nil if it is not known.
+func ServerCA(db xsql.DB) []byte {
+	if edb, ok := asEndpointDB(db); ok {
+		if ca := edb.ca.Load(); ca != nil {
+			return *ca
+		}
+	}
+	return nil
+}
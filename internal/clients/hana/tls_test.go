@@ -0,0 +1,272 @@
+package hana
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apisv1alpha1 "github.com/SAP/crossplane-provider-hana/apis/v1alpha1"
+)
+
+func testCAPEM(t *testing.T) []byte {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+func TestConnectionCredentials(t *testing.T) {
+	ca := testCAPEM(t)
+	creds := map[string][]byte{xpv1.ResourceCredentialsSecretEndpointKey: []byte("hana.example.com")}
+
+	cases := map[string]struct {
+		reason     string
+		creds      map[string][]byte
+		serviceKey string
+		tls        *apisv1alpha1.TLSConfig
+		proxy      *apisv1alpha1.ProxyConfig
+		failover   []string
+		kube       client.Client
+		want       map[string][]byte
+		wantErr    bool
+	}{
+		"NoTLS": {
+			reason: "Credentials should be returned unchanged without TLS settings",
+			want:   creds,
+		},
+		"AllSettings": {
+			reason: "All TLS settings should be merged into the credentials",
+			tls: &apisv1alpha1.TLSConfig{
+				RootCASecretRef:     &xpv1.SecretKeySelector{SecretReference: xpv1.SecretReference{Name: "ca", Namespace: "default"}, Key: "ca.crt"},
+				ValidateCertificate: new(false),
+				ServerName:          "override.example.com",
+				MinVersion:          "1.3",
+			},
+			kube: &test.MockClient{
+				MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+					obj.(*corev1.Secret).Data = map[string][]byte{"ca.crt": ca}
+					return nil
+				}),
+			},
+			want: map[string][]byte{
+				xpv1.ResourceCredentialsSecretEndpointKey: []byte("hana.example.com"),
+				CredentialsKeyTLSRootCA:                   ca,
+				CredentialsKeyTLSInsecureSkipVerify:       []byte("true"),
+				CredentialsKeyTLSServerName:               []byte("override.example.com"),
+				CredentialsKeyTLSMinVersion:               []byte("1.3"),
+			},
+		},
+		"Proxy": {
+			reason: "The proxy should be merged into the credentials as URL",
+			proxy:  &apisv1alpha1.ProxyConfig{Type: apisv1alpha1.ProxyTypeHTTPConnect, Address: "proxy.example.com:3128"},
+			want: map[string][]byte{
+				xpv1.ResourceCredentialsSecretEndpointKey: []byte("hana.example.com"),
+				CredentialsKeyProxyURL:                    []byte("http://proxy.example.com:3128"),
+			},
+		},
+		"FailoverEndpoints": {
+			reason:   "The failover endpoints should be merged into the credentials",
+			failover: []string{"hana-2.example.com", "hana-3.example.com:30015"},
+			want: map[string][]byte{
+				xpv1.ResourceCredentialsSecretEndpointKey: []byte("hana.example.com"),
+				CredentialsKeyFailoverEndpoints:           []byte("hana-2.example.com,hana-3.example.com:30015"),
+			},
+		},
+		"ServiceKey": {
+			reason:     "The host, port, user, password and certificate of a service key should be used",
+			creds:      map[string][]byte{"key.json": []byte(`{"host":"abc.hana.prod-eu10.hanacloud.ondemand.com","port":443,"user":"ADMIN","password":"secret","certificate":"PEM","url":"jdbc:sap://abc"}`)},
+			serviceKey: "key.json",
+			want: map[string][]byte{
+				"key.json": []byte(`{"host":"abc.hana.prod-eu10.hanacloud.ondemand.com","port":443,"user":"ADMIN","password":"secret","certificate":"PEM","url":"jdbc:sap://abc"}`),
+				xpv1.ResourceCredentialsSecretEndpointKey: []byte("abc.hana.prod-eu10.hanacloud.ondemand.com"),
+				xpv1.ResourceCredentialsSecretPortKey:     []byte("443"),
+				xpv1.ResourceCredentialsSecretUserKey:     []byte("ADMIN"),
+				xpv1.ResourceCredentialsSecretPasswordKey: []byte("secret"),
+				CredentialsKeyTLSRootCA:                   []byte("PEM"),
+			},
+		},
+		"ServiceBinding": {
+			reason:     "The credentials of a service binding should be used, with the port given as a string",
+			creds:      map[string][]byte{"binding": []byte(`{"credentials":{"host":"abc.example.com","port":"443","user":"ADMIN","password":"secret"}}`)},
+			serviceKey: "binding",
+			want: map[string][]byte{
+				"binding": []byte(`{"credentials":{"host":"abc.example.com","port":"443","user":"ADMIN","password":"secret"}}`),
+				xpv1.ResourceCredentialsSecretEndpointKey: []byte("abc.example.com"),
+				xpv1.ResourceCredentialsSecretPortKey:     []byte("443"),
+				xpv1.ResourceCredentialsSecretUserKey:     []byte("ADMIN"),
+				xpv1.ResourceCredentialsSecretPasswordKey: []byte("secret"),
+			},
+		},
+		"ErrServiceKeyNotFound": {
+			reason:     "A service key missing from the secret should be an error",
+			serviceKey: "key.json",
+			wantErr:    true,
+		},
+		"ErrServiceKeyNoHost": {
+			reason:     "A service key without host should be an error",
+			creds:      map[string][]byte{"key.json": []byte(`{"user":"ADMIN"}`)},
+			serviceKey: "key.json",
+			wantErr:    true,
+		},
+		"ErrRootCAKeyNotFound": {
+			reason: "A missing key in the root CA secret should be an error",
+			tls: &apisv1alpha1.TLSConfig{
+				RootCASecretRef: &xpv1.SecretKeySelector{SecretReference: xpv1.SecretReference{Name: "ca", Namespace: "default"}, Key: "ca.crt"},
+			},
+			kube:    &test.MockClient{MockGet: test.NewMockGetFn(nil)},
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			pc := &apisv1alpha1.ProviderConfig{Spec: apisv1alpha1.ProviderConfigSpec{
+				Credentials:       apisv1alpha1.ProviderCredentials{ServiceKey: tc.serviceKey},
+				TLS:               tc.tls,
+				Proxy:             tc.proxy,
+				FailoverEndpoints: tc.failover,
+			}}
+			in := creds
+			if tc.creds != nil {
+				in = tc.creds
+			}
+			got, err := ConnectionCredentials(context.Background(), tc.kube, pc, in)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("\n%s\nConnectionCredentials(...): want error %t, got %v", tc.reason, tc.wantErr, err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nConnectionCredentials(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestTLSConfig(t *testing.T) {
+	ca := testCAPEM(t)
+
+	cases := map[string]struct {
+		reason         string
+		creds          map[string][]byte
+		wantServerName string
+		wantSkip       bool
+		wantMinVersion uint16
+		wantRootCAs    bool
+		wantErr        bool
+	}{
+		"Defaults": {
+			reason:         "The endpoint should be verified with TLS 1.2 by default",
+			creds:          map[string][]byte{},
+			wantServerName: "hana.example.com",
+			wantMinVersion: tls.VersionTLS12,
+		},
+		"Overrides": {
+			reason: "All TLS settings should be applied",
+			creds: map[string][]byte{
+				CredentialsKeyTLSRootCA:             ca,
+				CredentialsKeyTLSServerName:         []byte("override.example.com"),
+				CredentialsKeyTLSInsecureSkipVerify: []byte("true"),
+				CredentialsKeyTLSMinVersion:         []byte("1.3"),
+			},
+			wantServerName: "override.example.com",
+			wantSkip:       true,
+			wantMinVersion: tls.VersionTLS13,
+			wantRootCAs:    true,
+		},
+		"ErrRootCA": {
+			reason:  "A root CA without PEM certificates should be an error",
+			creds:   map[string][]byte{CredentialsKeyTLSRootCA: []byte("not a certificate")},
+			wantErr: true,
+		},
+		"ErrMinVersion": {
+			reason:  "An unknown TLS version should be an error",
+			creds:   map[string][]byte{CredentialsKeyTLSMinVersion: []byte("1.0")},
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := tlsConfig(tc.creds, "hana.example.com")
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("\n%s\ntlsConfig(...): want error %t, got %v", tc.reason, tc.wantErr, err)
+			}
+			if err != nil {
+				return
+			}
+			if got.ServerName != tc.wantServerName || got.InsecureSkipVerify != tc.wantSkip || got.MinVersion != tc.wantMinVersion || (got.RootCAs != nil) != tc.wantRootCAs {
+				t.Errorf("\n%s\ntlsConfig(...): got ServerName=%q InsecureSkipVerify=%t MinVersion=%x RootCAs=%t", tc.reason, got.ServerName, got.InsecureSkipVerify, got.MinVersion, got.RootCAs != nil)
+			}
+		})
+	}
+}
+
+func TestServerCA(t *testing.T) {
+	leaf := &x509.Certificate{Raw: []byte("leaf")}
+	intermediate := &x509.Certificate{Raw: []byte("intermediate")}
+	root := &x509.Certificate{Raw: []byte("root")}
+
+	encode := func(c *x509.Certificate) []byte {
+		return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: c.Raw})
+	}
+
+	cases := map[string]struct {
+		reason string
+		cs     tls.ConnectionState
+		want   []byte
+	}{
+		"VerifiedChain": {
+			reason: "The root of the verified chain should be the CA certificate",
+			cs: tls.ConnectionState{
+				PeerCertificates: []*x509.Certificate{leaf, intermediate},
+				VerifiedChains:   [][]*x509.Certificate{{leaf, intermediate, root}},
+			},
+			want: encode(root),
+		},
+		"NotVerified": {
+			reason: "The last certificate presented should be the CA certificate if the chain was not verified",
+			cs:     tls.ConnectionState{PeerCertificates: []*x509.Certificate{leaf, intermediate}},
+			want:   encode(intermediate),
+		},
+		"NoCertificates": {
+			reason: "No CA certificate should be recorded without certificates",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			db := &endpointDB{}
+			if err := db.recordServerCA(tc.cs); err != nil {
+				t.Fatalf("\n%s\nrecordServerCA(...): unexpected error: %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want, ServerCA(db)); diff != "" {
+				t.Errorf("\n%s\nServerCA(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
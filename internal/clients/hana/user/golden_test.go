@@ -0,0 +1,49 @@
+package user
+
+import (
+	"context"
+	"testing"
+
+	"github.com/SAP/crossplane-provider-hana/apis/admin/v1alpha1"
+	"github.com/SAP/crossplane-provider-hana/internal/clients/fake"
+)
+
+// TestGoldenSQL records the statements emitted for representative specs in
+// testdata, so that changes to the generated SQL show up in review.
+func TestGoldenSQL(t *testing.T) {
+	cases := map[string]func(ctx context.Context, c Client) error{
+		"create_user": func(ctx context.Context, c Client) error {
+			return c.Create(ctx, &v1alpha1.UserParameters{
+				Username:      "DEMO_USER",
+				Usergroup:     "APP_USERS",
+				Privileges:    []string{"CATALOG READ", `SELECT ON SCHEMA "APP"`, `INSERT ON SCHEMA "APP" WITH GRANT OPTION`},
+				Roles:         []string{"MONITORING", `"APP_ADMIN" WITH ADMIN OPTION`},
+				Parameters:    map[string]string{"LOCALE": "en_US", "CLIENT": "100"},
+				ClientConnect: new(false),
+			}, "Pa$$w0rd", []ResolvedUserMapping{{Name: "IDP", SubjectName: "CN=demo"}})
+		},
+		"create_restricted_user": func(ctx context.Context, c Client) error {
+			return c.Create(ctx, &v1alpha1.UserParameters{
+				Username:                       "DEMO_USER",
+				RestrictedUser:                 true,
+				IsPasswordLifetimeCheckEnabled: true,
+			}, "Pa$$w0rd", nil)
+		},
+		"update_roles": func(ctx context.Context, c Client) error {
+			return c.UpdateRoles(ctx, "DEMO_USER", []string{`"A" WITH ADMIN OPTION`, `"B"`, `"C"`}, []string{`"A"`, `"B" WITH ADMIN OPTION`, `"D"`})
+		},
+		"update_parameters": func(ctx context.Context, c Client) error {
+			return c.UpdateParameters(ctx, "DEMO_USER", map[string]string{"LOCALE": "de_DE", "CLIENT": "200"}, map[string]string{"TIME ZONE": "", "EMAIL ADDRESS": ""})
+		},
+	}
+
+	for name, emit := range cases {
+		t.Run(name, func(t *testing.T) {
+			db := &fake.SQLRecorder{}
+			if err := emit(context.Background(), New(db, "ADMIN")); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			fake.AssertGolden(t, name, db.Statements)
+		})
+	}
+}
@@ -0,0 +1 @@
+CREATE RESTRICTED USER "DEMO_USER"
@@ -0,0 +1,9 @@
+CREATE USER "DEMO_USER" SET PARAMETER CLIENT = '100', LOCALE = 'en_US' SET USERGROUP "APP_USERS"
+ALTER USER "DEMO_USER" ADD IDENTITY 'CN=demo' FOR X509 PROVIDER "IDP"
+GRANT CATALOG READ TO "DEMO_USER"
+GRANT SELECT ON SCHEMA "APP" TO "DEMO_USER"
+GRANT INSERT ON SCHEMA "APP" TO "DEMO_USER" WITH GRANT OPTION
+GRANT "MONITORING" TO "DEMO_USER"
+GRANT "APP_ADMIN" TO "DEMO_USER" WITH ADMIN OPTION
+ALTER USER "DEMO_USER" DISABLE PASSWORD LIFETIME
+ALTER USER "DEMO_USER" DISABLE CLIENT CONNECT
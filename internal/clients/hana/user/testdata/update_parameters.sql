@@ -0,0 +1 @@
+ALTER USER "DEMO_USER" SET PARAMETER CLIENT = '200', LOCALE = 'de_DE' CLEAR PARAMETER EMAIL ADDRESS, TIME ZONE
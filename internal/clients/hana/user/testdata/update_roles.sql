@@ -0,0 +1,5 @@
+GRANT "C" TO "DEMO_USER"
+GRANT "A" TO "DEMO_USER" WITH ADMIN OPTION
+REVOKE "D" FROM "DEMO_USER"
+REVOKE "B" FROM "DEMO_USER"
+GRANT "B" TO "DEMO_USER"
@@ -6,14 +6,18 @@ import (
 	"errors"
 	"fmt"
 	"slices"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/SAP/go-hdb/driver"
+	"golang.org/x/sync/errgroup"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/SAP/crossplane-provider-hana/apis/admin/v1alpha1"
+	"github.com/SAP/crossplane-provider-hana/internal/clients/hana"
 	"github.com/SAP/crossplane-provider-hana/internal/clients/hana/privilege"
+	"github.com/SAP/crossplane-provider-hana/internal/clients/hana/statements"
 	"github.com/SAP/crossplane-provider-hana/internal/clients/xsql"
 	"github.com/SAP/crossplane-provider-hana/internal/utils"
 )
@@ -30,19 +34,30 @@ const (
 	errGrantRoles                      = "failed to grant roles: %w"
 	errQueryPrivileges                 = "failed to query privileges: %w"
 	errQueryRoles                      = "failed to query roles: %w"
+	errQueryPasswordLifetime           = "failed to query password lifetime: %w"
+	errQueryLastAuthentication         = "failed to query last authentication: %w"
 	ErrUpdateUserPassword              = "cannot update user password: %w"
 	ErrUpdateUserParameters            = "cannot update user parameters: %w"
 	ErrUpdateUserUsergroup             = "cannot update user usergroup: %w"
 	ErrUpdateUserPasswordLifetimeCheck = "cannot update user password lifetime check: %w"
 	ErrUpdateUserX509Providers         = "cannot update user X.509 providers: %w"
+	ErrUpdateUserClientConnect         = "cannot update user client connect: %w"
+	ErrUpdateUserValidity              = "cannot update user validity: %w"
+	ErrUpdateUserResourceTag           = "cannot tag user with its resource: %w"
 	ErrGetCorrelationID                = "cannot extract correlation ID from error message: %w"
 	ErrCorrIDNotFound                  = "cannot get internal error code for correlation ID %s: %w"
 	ErrUnknownInternalErrorCode        = "unknown internal error code %s for correlation ID %s"
-
-	errCodeAuthFailed      = 10
-	errCodeValidityPeriod  = 20
-	errCodeUserDeactivated = 415
-	errCodeUserLocked      = 416
+	errDeactivateUser                  = "cannot deactivate user: %w"
+	errQuerySessions                   = "cannot query sessions of user: %w"
+	errDisconnectSession               = "cannot disconnect session %s: %w"
+
+	errCodeAuthFailed            = 10
+	errCodeInsufficientPrivilege = 258
+	errCodeValidityPeriod        = 20
+	errCodeUserDeactivated       = 415
+	errCodeUserLocked            = 416
+	errCodeInvalidUserName       = 332
+	errCodeSQLSyntax             = 257
 
 	errIntWrongPassword   = "A10"
 	errIntValidityPeriod  = "U03"
@@ -50,6 +65,25 @@ const (
 	errIntUserLocked      = "U06"
 )
 
+// readConcurrency is the number of queries a Read runs at the same time, so
+// that observing many users does not exhaust the connections of the pool.
+const readConcurrency = 3
+
+// usergroupDefault is the usergroup of users created without one.
+const usergroupDefault = "DEFAULT"
+
+// ResourceParameter is the user parameter the provider tags the users it
+// manages with. Its value is the name of the User.
+const ResourceParameter = "CROSSPLANE_RESOURCE"
+
+// Parameters of the application identity of a user, set up by XS advanced or
+// SAP Analytics Cloud instead of the provider.
+const (
+	applicationParameterPrefix    = "XS_"
+	applicationUserParameter      = "XS_APPLICATIONUSER"
+	roleCollectionParameterPrefix = "XS_RC_"
+)
+
 var validParams = []string{"CLIENT", "LOCALE", "TIME ZONE", "EMAIL ADDRESS", "STATEMENT MEMORY LIMIT", "STATEMENT THREAD LIMIT"}
 
 // ResolvedUserMapping contains resolved X509 provider mapping information
@@ -71,6 +105,10 @@ type UserClient interface {
 	UpdatePasswordLifetimeCheck(ctx context.Context, username string, isPasswordLifetimeCheckEnabled bool) error
 	UpdateX509Providers(ctx context.Context, username string, toAdd, toRemove []ResolvedUserMapping) error
 	TogglePasswordAuthentication(ctx context.Context, username string, isPasswordEnabled bool) error
+	UpdateClientConnect(ctx context.Context, username string, enabled bool) error
+	UpdateValidity(ctx context.Context, username string, restrictions *v1alpha1.ConnectionRestrictions) error
+	UpdateResourceTag(ctx context.Context, username, resource string) error
+	MissingTargets(ctx context.Context, privileges []string) ([]privilege.MissingTarget, error)
 	GetDefaultSchema() string
 }
 
@@ -78,42 +116,95 @@ type UserClient interface {
 type Client struct {
 	xsql.DB
 	privilege.Client
-	username string
+	username          string
+	operatorUsergroup string
+	catalogRead       bool
+	dialect           statements.Dialect
 }
 
+// Fields of a user that may be left unobserved in usergroup operator mode.
+const (
+	FieldParameters     = "parameters"
+	FieldPrivileges     = "privileges"
+	FieldRoles          = "roles"
+	FieldPassword       = "password"
+	FieldX509Providers  = "x509Providers"
+	FieldPasswordExpiry = "passwordExpiry"
+)
+
 // New creates a new db client
 func New(db xsql.DB, username string) Client {
 	return Client{
 		DB:       db,
 		Client:   &privilege.PrivilegeClient{DB: db},
 		username: username,
+		dialect:  statements.NewDialect(hana.ServerVersion(db)),
 	}
 }
 
+// ForUsergroupOperator returns a copy of the client for a technical user that
+// only holds USERGROUP OPERATOR on usergroup. Catalog views it cannot read are
+// left unobserved instead of failing Read.
+func (c Client) ForUsergroupOperator(usergroup string) Client {
+	c.operatorUsergroup = usergroup
+	return c
+}
+
+// WithCatalogRead returns a copy of the client for a technical user that holds
+// CATALOG READ, so that the catalog views are trusted to show all grants.
+func (c Client) WithCatalogRead() Client {
+	c.catalogRead = true
+	return c
+}
+
 // Read checks the state of the user
 func (c Client) Read(ctx context.Context, parameters *v1alpha1.UserParameters, password string) (*v1alpha1.UserObservation, error) {
-	var username, usergroup string
+	var username string
+	var usergroup sql.NullString
 	var createdAt, lastPasswordChangeTime time.Time
-	var restrictedUser, isPasswordLifetimeCheckEnabled, isPasswordEnabled bool
+	var validFrom, validUntil, lastSuccessfulConnect sql.NullTime
+	var invalidConnectAttempts sql.NullInt32
+	var externalIdentity sql.NullString
+	var restrictedUser, isClientConnectEnabled, isPasswordLifetimeCheckEnabled, isPasswordEnabled, userDeactivated bool
+
+	// Versions without usergroups have no USERGROUP_NAME column
+	usergroupColumn := "USERGROUP_NAME, "
+	if !c.dialect.Supports(statements.Usergroups) {
+		usergroupColumn = "NULL, "
+	}
 
 	query := "SELECT USER_NAME, " +
-		"USERGROUP_NAME, " +
+		usergroupColumn +
 		"CREATE_TIME, " +
 		"LAST_PASSWORD_CHANGE_TIME, " +
 		"IS_RESTRICTED, " +
+		"IS_CLIENT_CONNECT_ENABLED, " +
 		"IS_PASSWORD_LIFETIME_CHECK_ENABLED, " +
-		"IS_PASSWORD_ENABLED " +
+		"IS_PASSWORD_ENABLED, " +
+		"VALID_FROM, " +
+		"VALID_UNTIL, " +
+		"LAST_SUCCESSFUL_CONNECT, " +
+		"INVALID_CONNECT_ATTEMPTS, " +
+		"USER_DEACTIVATED, " +
+		"EXTERNAL_IDENTITY " +
 		"FROM SYS.USERS " +
 		"WHERE USER_NAME = ?"
 
-	err := c.QueryRowContext(ctx, query, parameters.Username).Scan(
+	err := xsql.ScanRow(ctx, c.QueryRowContext(ctx, query, parameters.Username),
 		&username,
 		&usergroup,
 		&createdAt,
 		&lastPasswordChangeTime,
 		&restrictedUser,
+		&isClientConnectEnabled,
 		&isPasswordLifetimeCheckEnabled,
 		&isPasswordEnabled,
+		&validFrom,
+		&validUntil,
+		&lastSuccessfulConnect,
+		&invalidConnectAttempts,
+		&userDeactivated,
+		&externalIdentity,
 	)
 
 	if xsql.IsNoRows(err) {
@@ -122,48 +213,298 @@ func (c Client) Read(ctx context.Context, parameters *v1alpha1.UserParameters, p
 		return &v1alpha1.UserObservation{}, err
 	}
 
+	// Users outside of any usergroup are in the default usergroup, on
+	// versions without a DEFAULT usergroup their usergroup is NULL
+	if !usergroup.Valid || usergroup.String == "" {
+		usergroup.String = usergroupDefault
+	}
+
 	observed := &v1alpha1.UserObservation{
 		Username:                       &username,
-		Usergroup:                      &usergroup,
+		Usergroup:                      &usergroup.String,
 		CreatedAt:                      metav1.NewTime(createdAt),
 		LastPasswordChangeTime:         metav1.NewTime(lastPasswordChangeTime),
 		RestrictedUser:                 &restrictedUser,
+		IsClientConnectEnabled:         &isClientConnectEnabled,
 		IsPasswordLifetimeCheckEnabled: &isPasswordLifetimeCheckEnabled,
 		IsPasswordEnabled:              &isPasswordEnabled,
+		ValidFrom:                      nullTime(validFrom),
+		ValidUntil:                     nullTime(validUntil),
+		LastSuccessfulConnect:          nullTime(lastSuccessfulConnect),
+		InvalidConnectAttempts:         &invalidConnectAttempts.Int32,
+		IsLocked:                       &userDeactivated,
+		ExternalIdentity:               externalIdentity.String,
 	}
 
-	observed.Parameters, err = c.queryParameters(ctx, parameters.Username)
-	if err != nil {
+	// The aspects of the user are independent of each other, so they are
+	// queried concurrently instead of one round trip after the other. Each
+	// query keeps its error for the checks below, and fails the group only if
+	// it fails the Read, so that the queries still running are cancelled
+	var (
+		params           map[string]string
+		privileges       []privilege.PrivilegeGrant
+		roles            []string
+		passwordUpToDate *bool
+		providers        []v1alpha1.X509UserMapping
+		lastConnect      *connectEvent
+		lifetime         passwordLifetime
+
+		paramsErr, privilegesErr, rolesErr, passwordErr, providersErr, lastConnectErr, lifetimeErr error
+	)
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(readConcurrency)
+	g.Go(func() error {
+		params, paramsErr = c.queryParameters(gctx, parameters.Username)
+		return c.readError(paramsErr)
+	})
+	g.Go(func() error {
+		privileges, privilegesErr = c.QueryPrivilegeGrants(gctx, parameters.Username, privilege.GranteeTypeUser)
+		if privilegesErr != nil {
+			privilegesErr = fmt.Errorf(errQueryPrivileges, privilegesErr)
+		}
+		return c.readError(privilegesErr)
+	})
+	g.Go(func() error {
+		roles, rolesErr = c.QueryRoles(gctx, parameters.Username, privilege.GranteeTypeUser)
+		if rolesErr != nil {
+			rolesErr = fmt.Errorf(errQueryRoles, rolesErr)
+		}
+		return c.readError(rolesErr)
+	})
+	g.Go(func() error {
+		passwordUpToDate, passwordErr = c.queryPasswordAuthentication(gctx, parameters, isPasswordEnabled, password)
+		if isCredentialError(passwordErr) {
+			return nil
+		}
+		return c.readError(passwordErr)
+	})
+	g.Go(func() error {
+		providers, providersErr = c.queryX509Providers(gctx, parameters.Username)
+		return c.readError(providersErr)
+	})
+	g.Go(func() error {
+		lastConnect, lastConnectErr = c.queryLastConnect(gctx, parameters.Username)
+		if lastConnectErr != nil {
+			lastConnectErr = fmt.Errorf(errQueryLastAuthentication, lastConnectErr)
+		}
+		return lastConnectErr
+	})
+	g.Go(func() error {
+		if !isPasswordEnabled || !isPasswordLifetimeCheckEnabled {
+			return nil
+		}
+		lifetime.days, lifetime.policy, lifetimeErr = c.queryMaximumPasswordLifetime(gctx, usergroup.String)
+		if lifetimeErr != nil {
+			lifetimeErr = fmt.Errorf(errQueryPasswordLifetime, lifetimeErr)
+		}
+		return c.readError(lifetimeErr)
+	})
+	if err := g.Wait(); err != nil {
 		return observed, err
 	}
 
-	observed.Privileges, err = c.QueryPrivileges(ctx, parameters.Username, privilege.GranteeTypeUser)
-	if err != nil {
-		return observed, fmt.Errorf(errQueryPrivileges, err)
+	// Results are taken in a fixed order, so that the unobserved fields are
+	// always listed in the same order
+	observed.Parameters = params
+	if c.unobservable(observed, FieldParameters, paramsErr) {
+		observed.Parameters = nil
+	}
+	if tag, ok := observed.Parameters[ResourceParameter]; ok {
+		observed.ManagedResource = tag
+		delete(observed.Parameters, ResourceParameter)
 	}
+	observed.ApplicationUser = applicationUser(observed.Parameters)
 
-	observed.Roles, err = c.QueryRoles(ctx, parameters.Username, privilege.GranteeTypeUser)
-	if err != nil {
-		return observed, fmt.Errorf(errQueryRoles, err)
+	observed.Privileges, observed.ForeignGrants = c.grantedPrivileges(privileges)
+	if c.unobservable(observed, FieldPrivileges, privilegesErr) {
+		observed.Privileges, observed.ForeignGrants = nil, nil
 	}
 
-	if passwordUpToDate, err := c.queryPasswordAuthentication(ctx, parameters, isPasswordEnabled, password); err != nil {
-		return observed, err
-	} else {
-		observed.PasswordUpToDate = passwordUpToDate
+	observed.Roles = roles
+	if c.unobservable(observed, FieldRoles, rolesErr) {
+		observed.Roles = nil
 	}
 
-	observed.X509Providers, err = c.queryX509Providers(ctx, parameters.Username)
-	if err != nil {
-		return observed, err
+	if c.grantsHidden(observed) {
+		if !slices.Contains(observed.UnobservedFields, FieldPrivileges) {
+			observed.UnobservedFields = append(observed.UnobservedFields, FieldPrivileges)
+		}
+		observed.UnobservedFields = append(observed.UnobservedFields, FieldRoles)
+		observed.Privileges, observed.Roles, observed.ForeignGrants = nil, nil, nil
+	}
+
+	if c.unobservable(observed, FieldPassword, passwordErr) {
+		passwordUpToDate = nil
+	} else if passwordErr != nil {
+		return observed, passwordErr
+	}
+	observed.PasswordUpToDate = passwordUpToDate
+
+	observed.X509Providers = providers
+	if c.unobservable(observed, FieldX509Providers, providersErr) {
+		observed.X509Providers = nil
+	}
+
+	observed.LastAuthentication = lastAuthentication(lastConnect, observed.X509Providers)
+
+	if c.unobservable(observed, FieldPasswordExpiry, lifetimeErr) {
+		lifetime = passwordLifetime{}
+	}
+	if lifetime.days > 0 {
+		expiresAt := metav1.NewTime(lastPasswordChangeTime.AddDate(0, 0, lifetime.days))
+		observed.PasswordExpiresAt = &expiresAt
+	}
+	observed.PasswordPolicy = lifetime.policy
+	if isPasswordEnabled && !isPasswordLifetimeCheckEnabled {
+		observed.PasswordPolicy = v1alpha1.PasswordPolicyNeverExpires
+	}
+
+	return observed, nil
+}
+
+// grantorSYS is the grantor of the privileges HANA grants implicitly, such as
+// those of the owner of a schema.
+const grantorSYS = "SYS"
+
+// grantedPrivileges returns the privileges of the grants, each once, and the
+// grants made by users other than the technical user and SYS.
+func (c Client) grantedPrivileges(grants []privilege.PrivilegeGrant) ([]string, []v1alpha1.PrivilegeGrantObservation) {
+	privileges := make([]string, 0, len(grants))
+	var foreign []v1alpha1.PrivilegeGrantObservation
+	for _, g := range grants {
+		if !slices.Contains(privileges, g.Privilege) {
+			privileges = append(privileges, g.Privilege)
+		}
+		if g.Grantor != c.username && g.Grantor != grantorSYS {
+			foreign = append(foreign, v1alpha1.PrivilegeGrantObservation{Privilege: g.Privilege, Grantor: g.Grantor})
+		}
+	}
+	return privileges, foreign
+}
+
+// passwordLifetime is the maximum password lifetime in days that applies to
+// a user, and the password policy it is taken from.
+type passwordLifetime struct {
+	days   int
+	policy string
+}
+
+// queryMaximumPasswordLifetime returns the maximum password lifetime in days
+// that applies to users of usergroup, and the password policy it is taken
+// from: the password policy of the usergroup if it has its own, or else the
+// one of the instance. Zero means passwords do not expire.
+func (c Client) queryMaximumPasswordLifetime(ctx context.Context, usergroup string) (int, string, error) {
+	if !c.dialect.Supports(statements.Usergroups) {
+		lifetime, err := c.queryInstancePasswordLifetime(ctx)
+		return lifetime, v1alpha1.PasswordPolicyInstance, err
+	}
+
+	usergroupLifetime := "FROM SYS.USERGROUP_PARAMETERS " +
+		"WHERE USERGROUP_NAME = ? AND PARAMETER_SET_NAME = 'password policy' AND IS_PARAMETER_SET_ENABLED = 'TRUE' " +
+		"AND PARAMETER_NAME = 'maximum_password_lifetime'"
+	query := "SELECT COALESCE(" +
+		"(SELECT MAX(PARAMETER_VALUE) " + usergroupLifetime + "), " +
+		"(SELECT VALUE FROM SYS.M_PASSWORD_POLICY WHERE PROPERTY = 'maximum_password_lifetime')), " +
+		"(SELECT COUNT(*) " + usergroupLifetime + ") " +
+		"FROM DUMMY"
+
+	var lifetime sql.NullString
+	var own int
+	if err := c.QueryRowContext(ctx, query, usergroup, usergroup).Scan(&lifetime, &own); err != nil {
+		return 0, "", err
+	}
+	policy := v1alpha1.PasswordPolicyInstance
+	if own > 0 {
+		policy = v1alpha1.PasswordPolicyUsergroup
+	}
+	days, err := parseLifetime(lifetime)
+	return days, policy, err
+}
+
+// queryInstancePasswordLifetime returns the maximum password lifetime in days
+// of the instance, on versions without usergroups.
+func (c Client) queryInstancePasswordLifetime(ctx context.Context) (int, error) {
+	query := "SELECT VALUE FROM SYS.M_PASSWORD_POLICY WHERE PROPERTY = 'maximum_password_lifetime'"
+
+	var lifetime sql.NullString
+	if err := xsql.ScanRow(ctx, c.QueryRowContext(ctx, query), &lifetime); err != nil && !xsql.IsNoRows(err) {
+		return 0, err
+	}
+	return parseLifetime(lifetime)
+}
+
+func parseLifetime(lifetime sql.NullString) (int, error) {
+	if !lifetime.Valid || lifetime.String == "" {
+		return 0, nil
 	}
+	return strconv.Atoi(strings.TrimSpace(lifetime.String))
+}
+
+// unobservable reports whether err only means that the catalog view behind
+// field cannot be read in usergroup operator mode, and records the field as
+// unobserved if so.
+func (c Client) unobservable(observed *v1alpha1.UserObservation, field string, err error) bool {
+	if c.operatorUsergroup == "" || !IsInsufficientPrivilege(err) {
+		return false
+	}
+	observed.UnobservedFields = append(observed.UnobservedFields, field)
+	return true
+}
+
+// readError returns err unless it only leaves a field unobserved, as
+// unobservable would record it.
+func (c Client) readError(err error) error {
+	if c.operatorUsergroup != "" && IsInsufficientPrivilege(err) {
+		return nil
+	}
+	return err
+}
+
+// isCredentialError reports whether err is a state of the credentials of a
+// user, which Read returns along with the observation.
+func isCredentialError(err error) bool {
+	return errors.Is(err, ErrValidityPeriod) || errors.Is(err, ErrUserDeactivated) || errors.Is(err, ErrUserLocked)
+}
+
+// grantsHidden reports whether the catalog hides the grants of a user instead
+// of the user holding none. Every standard user holds PUBLIC, so no roles at
+// all for a user of a usergroup, e.g. one created with NO GRANT TO CREATOR,
+// means the technical user cannot see them without CATALOG READ.
+func (c Client) grantsHidden(observed *v1alpha1.UserObservation) bool {
+	return !c.catalogRead &&
+		len(observed.Roles) == 0 && !slices.Contains(observed.UnobservedFields, FieldRoles) &&
+		!*observed.RestrictedUser &&
+		*observed.Usergroup != "" && *observed.Usergroup != usergroupDefault
+}
 
-	return observed, err
+// UnrevokableRolesError lists the roles that stay granted, since the
+// technical user lacks the privilege to revoke them, e.g. roles owned by
+// _SYS_REPO or an HDI container. All other role changes were applied.
+type UnrevokableRolesError struct {
+	Roles []string
+}
+
+func (e *UnrevokableRolesError) Error() string {
+	return "insufficient privilege to revoke roles: " + strings.Join(e.Roles, ", ")
+}
+
+// IsInsufficientPrivilege returns true if err was caused by HANA rejecting a
+// statement for missing privileges.
+func IsInsufficientPrivilege(err error) bool {
+	var dbError driver.DBError
+	return errors.As(err, &dbError) && dbError.Code() == errCodeInsufficientPrivilege
+}
+
+// IsInvalidUserName returns true if err was caused by HANA not knowing the
+// user of a statement.
+func IsInvalidUserName(err error) bool {
+	var dbError driver.DBError
+	return errors.As(err, &dbError) && dbError.Code() == errCodeInvalidUserName
 }
 
 func (c Client) queryPasswordAuthentication(ctx context.Context, parameters *v1alpha1.UserParameters, isPasswordEnabled bool, password string) (*bool, error) {
 	switch {
-	case parameters.Authentication.Password != nil && parameters.Authentication.Password.PasswordSecretRef != nil:
+	case parameters.Authentication.Password != nil && (parameters.Authentication.Password.PasswordSecretRef != nil || parameters.Authentication.Password.PasswordStoreRef != nil):
 		if isPasswordEnabled {
 			passwordUpToDate, err := c.validateCredentials(ctx, parameters.Username, password)
 			if err != nil {
@@ -216,6 +557,94 @@ func (c Client) queryX509Providers(ctx context.Context, username string) ([]v1al
 	return x509Providers, nil
 }
 
+// connectEvent is a successful connect of a user recorded in the audit log.
+type connectEvent struct {
+	time    time.Time
+	comment string
+}
+
+// queryLastConnect returns the last successful connect of the user recorded
+// in the audit log, nil if none is recorded or the technical user may not
+// read the audit log.
+func (c Client) queryLastConnect(ctx context.Context, username string) (*connectEvent, error) {
+	query := "SELECT TOP 1 TIMESTAMP, COMMENT FROM SYS.AUDIT_LOG " +
+		"WHERE USER_NAME = ? AND EVENT_ACTION = 'CONNECT' AND EVENT_STATUS = 'SUCCESSFUL' " +
+		"ORDER BY TIMESTAMP DESC"
+	rows, err := c.QueryContext(ctx, query, username)
+	if IsInsufficientPrivilege(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close() //nolint:errcheck
+
+	if !rows.Next() {
+		return nil, rows.Err()
+	}
+	var at time.Time
+	var comment sql.NullString
+	if err := rows.Scan(&at, &comment); err != nil {
+		return nil, err
+	}
+	return &connectEvent{time: at, comment: comment.String}, rows.Err()
+}
+
+// authenticationMethods are the authentication methods HANA names in the
+// comments of CONNECT events, longest first so that a method is not taken
+// for one it contains.
+var authenticationMethods = []string{
+	"SAP ASSERTION TICKET",
+	"SAP LOGON TICKET",
+	"SESSION COOKIE",
+	"KERBEROS",
+	"PASSWORD",
+	"SAML",
+	"X509",
+	"JWT",
+}
+
+// lastAuthentication describes a connect event. The certificate of an X.509
+// login matched the mapping whose subject the comment names; mappings of ANY
+// subject cannot be told apart and are not reported.
+func lastAuthentication(event *connectEvent, mappings []v1alpha1.X509UserMapping) *v1alpha1.UserAuthenticationObservation {
+	if event == nil {
+		return nil
+	}
+	observed := &v1alpha1.UserAuthenticationObservation{Time: metav1.NewTime(event.time)}
+	comment := normalizeDN(event.comment)
+	for _, method := range authenticationMethods {
+		if strings.Contains(comment, method) {
+			observed.Method = method
+			break
+		}
+	}
+	for _, m := range mappings {
+		if m.SubjectName == "" || m.SubjectName == "ANY" {
+			continue
+		}
+		if strings.Contains(comment, normalizeDN(m.SubjectName)) {
+			observed.Method = "X509"
+			observed.X509Provider = m.Name
+			observed.SubjectName = m.SubjectName
+			break
+		}
+	}
+	return observed
+}
+
+// normalizeDN uppercases s and drops the spaces after the separators of
+// distinguished names, which HANA writes either way.
+func normalizeDN(s string) string {
+	s = strings.ToUpper(s)
+	for _, sep := range []string{",", ";", "+"} {
+		for strings.Contains(s, sep+" ") {
+			s = strings.ReplaceAll(s, sep+" ", sep)
+		}
+	}
+	return s
+}
+
 func (c Client) queryParameters(ctx context.Context, username string) (map[string]string, error) {
 	observed := make(map[string]string)
 	query := "SELECT USER_NAME, " +
@@ -242,9 +671,34 @@ func (c Client) queryParameters(ctx context.Context, username string) (map[strin
 	return observed, nil
 }
 
+// applicationUser moves the XS_ parameters out of parameters into the
+// application identity they describe. It returns nil if there are none.
+func applicationUser(parameters map[string]string) *v1alpha1.ApplicationUserObservation {
+	var app *v1alpha1.ApplicationUserObservation
+	for key, value := range parameters {
+		if !strings.HasPrefix(key, applicationParameterPrefix) {
+			continue
+		}
+		if app == nil {
+			app = &v1alpha1.ApplicationUserObservation{Parameters: map[string]string{}}
+		}
+		app.Parameters[key] = value
+		delete(parameters, key)
+		switch {
+		case key == applicationUserParameter:
+			app.IsApplicationUser = strings.EqualFold(value, "TRUE")
+		case strings.HasPrefix(key, roleCollectionParameterPrefix):
+			app.RoleCollections = append(app.RoleCollections, value)
+		}
+	}
+	if app != nil {
+		slices.Sort(app.RoleCollections)
+	}
+	return app
+}
+
 func (c Client) validateCredentials(ctx context.Context, username string, password string) (bool, error) {
-	query := fmt.Sprintf(`VALIDATE USER %s PASSWORD "%s"`, username, password)
-	_, err := c.ExecContext(ctx, query)
+	_, err := c.ExecContext(ctx, statements.ValidateUser(username, password))
 	var dbError driver.Error
 	if errors.As(err, &dbError) {
 		switch dbError.Code() {
@@ -263,6 +717,17 @@ func (c Client) validateCredentials(ctx context.Context, username string, passwo
 
 // Create a new user
 func (c Client) Create(ctx context.Context, parameters *v1alpha1.UserParameters, password string, providers []ResolvedUserMapping) error {
+	if parameters.Usergroup != "" && parameters.Usergroup != usergroupDefault {
+		if err := c.dialect.Require(statements.Usergroups); err != nil {
+			return err
+		}
+	}
+	if len(providers) > 0 {
+		if err := c.dialect.Require(statements.X509Providers); err != nil {
+			return err
+		}
+	}
+
 	query, err := generateCreateQuery(parameters, password)
 	if err != nil {
 		return err
@@ -276,11 +741,17 @@ func (c Client) Create(ctx context.Context, parameters *v1alpha1.UserParameters,
 		return err
 	}
 
-	if err := c.GrantPrivileges(ctx, c.username, parameters.Username, parameters.Privileges); err != nil {
+	unlock, err := privilege.LockGrantee(ctx, utils.QuoteIdentifier(parameters.Username))
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	if err := c.GrantPrivileges(ctx, c.username, utils.QuoteIdentifier(parameters.Username), parameters.Privileges); err != nil {
 		return fmt.Errorf(errGrantPrivileges, err)
 	}
 
-	if err := c.GrantRoles(ctx, c.username, parameters.Username, parameters.Roles); err != nil {
+	if err := c.GrantRoles(ctx, c.username, utils.QuoteIdentifier(parameters.Username), parameters.Roles); err != nil {
 		return fmt.Errorf(errGrantRoles, err)
 	}
 
@@ -291,45 +762,59 @@ func (c Client) Create(ctx context.Context, parameters *v1alpha1.UserParameters,
 		}
 	}
 
+	if parameters.ClientConnect != nil {
+		if err := c.UpdateClientConnect(ctx, parameters.Username, *parameters.ClientConnect); err != nil {
+			return err
+		}
+	}
+
+	if parameters.ConnectionRestrictions != nil {
+		if err := c.UpdateValidity(ctx, parameters.Username, parameters.ConnectionRestrictions); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
-func setParameters(query string, parameters map[string]string) string {
-	newParams := make([]string, 0, len(parameters))
+// knownParameters returns the parameters with known names, with the names
+// uppercased. Only known names are written into statements.
+func knownParameters(parameters map[string]string) map[string]string {
+	known := make(map[string]string, len(parameters))
 	for key, value := range parameters {
-		upperKey := strings.ToUpper(key)
-		if slices.Contains(validParams, upperKey) {
-			newParams = append(newParams, fmt.Sprintf("%s = '%s'", upperKey, utils.EscapeSingleQuotes(value)))
+		if key = strings.ToUpper(key); slices.Contains(validParams, key) {
+			known[key] = value
 		}
 	}
-	if len(newParams) == 0 {
-		return query
-	}
-	return query + " SET PARAMETER " + strings.Join(newParams, ", ")
+	return known
 }
 
 // UpdatePassword returns an error about not being able to update the password
 func (c Client) UpdatePassword(ctx context.Context, username string, password string, forceFirstPasswordChange bool) error {
-	query := fmt.Sprintf(`ALTER USER %s PASSWORD "%s"`, username, password)
-	if !forceFirstPasswordChange {
-		query += " NO FORCE_FIRST_PASSWORD_CHANGE"
-	}
-
-	if _, err := c.ExecContext(ctx, query); err != nil {
+	if _, err := c.ExecContext(ctx, statements.AlterUser(username).Password(password, forceFirstPasswordChange)); err != nil {
 		return fmt.Errorf(ErrUpdateUserPassword, err)
 	}
 	return nil
 }
 
 func (c Client) UpdatePrivileges(ctx context.Context, grantee string, toGrant, toRevoke []string) error {
+	if len(toGrant) == 0 && len(toRevoke) == 0 {
+		return nil
+	}
+	unlock, err := privilege.LockGrantee(ctx, utils.QuoteIdentifier(grantee))
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
 	if len(toGrant) > 0 {
-		if err := c.GrantPrivileges(ctx, c.username, grantee, toGrant); err != nil {
+		if err := c.GrantPrivileges(ctx, c.username, utils.QuoteIdentifier(grantee), toGrant); err != nil {
 			return err
 		}
 	}
 
 	if len(toRevoke) > 0 {
-		if err := c.RevokePrivileges(ctx, c.username, grantee, toRevoke); err != nil {
+		if err := c.RevokePrivileges(ctx, c.username, utils.QuoteIdentifier(grantee), toRevoke); err != nil {
 			return err
 		}
 	}
@@ -338,88 +823,193 @@ func (c Client) UpdatePrivileges(ctx context.Context, grantee string, toGrant, t
 }
 
 func (c Client) UpdateRoles(ctx context.Context, grantee string, toGrant, toRevoke []string) error {
+	toGrant, toRevoke, downgrade, err := privilege.SplitAdminOptionChanges(toGrant, toRevoke)
+	if err != nil {
+		return err
+	}
+	if len(toGrant) == 0 && len(toRevoke) == 0 && len(downgrade) == 0 {
+		return nil
+	}
+	unlock, err := privilege.LockGrantee(ctx, utils.QuoteIdentifier(grantee))
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
 	if len(toGrant) > 0 {
-		if err := c.GrantRoles(ctx, c.username, grantee, toGrant); err != nil {
+		if err := c.GrantRoles(ctx, c.username, utils.QuoteIdentifier(grantee), toGrant); err != nil {
 			return err
 		}
 	}
 
+	var unrevokable []string
 	if len(toRevoke) > 0 {
-		if err := c.RevokeRoles(ctx, c.username, grantee, toRevoke); err != nil {
+		if unrevokable, err = c.revokeRoles(ctx, grantee, toRevoke); err != nil {
 			return err
 		}
 	}
 
+	// The admin option cannot be revoked on its own, so the role is regranted
+	// right after it was revoked. The statements are not atomic: the user
+	// lacks the role in between, and the revoke cascades to the grants the
+	// user made with the admin option, which are not restored
+	for _, role := range downgrade {
+		held, err := c.revokeRoles(ctx, grantee, []string{role})
+		if err != nil {
+			return err
+		}
+		if len(held) > 0 {
+			unrevokable = append(unrevokable, role)
+			continue
+		}
+		if err := c.GrantRoles(ctx, c.username, utils.QuoteIdentifier(grantee), []string{role}); err != nil {
+			return err
+		}
+	}
+
+	if len(unrevokable) > 0 {
+		return &UnrevokableRolesError{Roles: unrevokable}
+	}
 	return nil
 }
 
-// UpdateParameters updates the parameters of the user
-func (c Client) UpdateParameters(ctx context.Context, username string, parametersToSet map[string]string, parametersToClear map[string]string) error {
-	query := fmt.Sprintf("ALTER USER %s", username)
-
-	if len(parametersToSet) > 0 {
-		query += " SET PARAMETER"
-		for key, value := range parametersToSet {
-			key = strings.ToUpper(key)
-			if slices.Contains(validParams, key) {
-				query += fmt.Sprintf(" %s = '%s',", key, value)
+// revokeRoles revokes the roles and returns those the technical user lacks
+// the privilege to revoke. If revoking all roles at once is not permitted,
+// they are revoked one by one, so the others are still revoked.
+func (c Client) revokeRoles(ctx context.Context, grantee string, roles []string) ([]string, error) {
+	err := c.RevokeRoles(ctx, c.username, utils.QuoteIdentifier(grantee), roles)
+	if err == nil || !IsInsufficientPrivilege(err) {
+		return nil, err
+	}
+	if len(roles) == 1 {
+		return roles, nil
+	}
+	var unrevokable []string
+	for _, role := range roles {
+		if err := c.RevokeRoles(ctx, c.username, utils.QuoteIdentifier(grantee), []string{role}); err != nil {
+			if !IsInsufficientPrivilege(err) {
+				return nil, err
 			}
+			unrevokable = append(unrevokable, role)
 		}
-		query = strings.TrimSuffix(query, ",")
 	}
+	return unrevokable, nil
+}
 
-	if len(parametersToClear) > 0 {
-		query += " CLEAR PARAMETER"
-		for key := range parametersToClear {
-			key = strings.ToUpper(key)
-			if slices.Contains(validParams, key) {
-				query += fmt.Sprintf(" %s,", key)
-			}
-		}
-		query = strings.TrimSuffix(query, ",")
+// UpdateParameters updates the parameters of the user
+func (c Client) UpdateParameters(ctx context.Context, username string, parametersToSet map[string]string, parametersToClear map[string]string) error {
+	toSet := knownParameters(parametersToSet)
+	toClear := utils.SortedKeys(knownParameters(parametersToClear))
+	if len(toSet) == 0 && len(toClear) == 0 {
+		return nil
 	}
 
-	if _, err := c.ExecContext(ctx, query); err != nil {
+	if _, err := c.ExecContext(ctx, statements.AlterUser(username).Parameters(toSet, toClear)); err != nil {
 		return fmt.Errorf(ErrUpdateUserParameters, err)
 	}
 	return nil
 }
 
-// UpdateUsergroup updates the usergroup of the user
+// UpdateUsergroup moves the user into usergroup. An empty usergroup or
+// DEFAULT moves the user out of its usergroup into the default one, with
+// UNSET USERGROUP on versions that do not know SET USERGROUP DEFAULT.
 func (c Client) UpdateUsergroup(ctx context.Context, username string, usergroup string) error {
-	query := fmt.Sprintf("ALTER USER %s", username)
+	alter := statements.AlterUser(username)
 
-	if usergroup != "" {
-		query += fmt.Sprintf(" SET USERGROUP %s", usergroup)
-	} else {
-		query += " UNSET USERGROUP"
+	if usergroup != "" && usergroup != usergroupDefault {
+		if err := c.dialect.Require(statements.Usergroups); err != nil {
+			return fmt.Errorf(ErrUpdateUserUsergroup, err)
+		}
+		if _, err := c.ExecContext(ctx, alter.SetUsergroup(usergroup)); err != nil {
+			return fmt.Errorf(ErrUpdateUserUsergroup, err)
+		}
+		return nil
 	}
 
-	if _, err := c.ExecContext(ctx, query); err != nil {
+	// Users are in no usergroup on versions without usergroups
+	if !c.dialect.Supports(statements.Usergroups) {
+		return nil
+	}
+	if !c.dialect.Supports(statements.UsergroupDefault) {
+		if _, err := c.ExecContext(ctx, alter.UnsetUsergroup()); err != nil {
+			return fmt.Errorf(ErrUpdateUserUsergroup, err)
+		}
+		return nil
+	}
+
+	// The version may be unknown, fall back if the syntax is rejected
+	_, err := c.ExecContext(ctx, alter.SetUsergroupDefault())
+	if isSQLSyntaxError(err) {
+		_, err = c.ExecContext(ctx, alter.UnsetUsergroup())
+	}
+	if err != nil {
 		return fmt.Errorf(ErrUpdateUserUsergroup, err)
 	}
 	return nil
 }
 
+// isSQLSyntaxError reports whether HANA rejected a statement it cannot parse.
+func isSQLSyntaxError(err error) bool {
+	var dbError driver.DBError
+	return errors.As(err, &dbError) && dbError.Code() == errCodeSQLSyntax
+}
+
 func (c Client) UpdatePasswordLifetimeCheck(ctx context.Context, username string, isPasswordLifetimeCheckEnabled bool) error {
-	var query string
-	if isPasswordLifetimeCheckEnabled {
-		query = fmt.Sprintf("ALTER USER %s ENABLE PASSWORD LIFETIME", username)
-	} else {
-		query = fmt.Sprintf("ALTER USER %s DISABLE PASSWORD LIFETIME", username)
+	if _, err := c.ExecContext(ctx, statements.AlterUser(username).PasswordLifetime(isPasswordLifetimeCheckEnabled)); err != nil {
+		return fmt.Errorf(ErrUpdateUserPasswordLifetimeCheck, err)
 	}
+	return nil
+}
+
+// UpdateClientConnect allows or forbids the user to connect with SQL clients
+func (c Client) UpdateClientConnect(ctx context.Context, username string, enabled bool) error {
+	if _, err := c.ExecContext(ctx, statements.AlterUser(username).ClientConnect(enabled)); err != nil {
+		return fmt.Errorf(ErrUpdateUserClientConnect, err)
+	}
+	return nil
+}
+
+// UpdateValidity sets the validity period of the user
+func (c Client) UpdateValidity(ctx context.Context, username string, restrictions *v1alpha1.ConnectionRestrictions) error {
+	var from, until *time.Time
+	if restrictions.ValidFrom != nil {
+		from = &restrictions.ValidFrom.Time
+	}
+	if restrictions.ValidUntil != nil {
+		until = &restrictions.ValidUntil.Time
+	}
+
+	if _, err := c.ExecContext(ctx, statements.AlterUser(username).Validity(from, until)); err != nil {
+		return fmt.Errorf(ErrUpdateUserValidity, err)
+	}
+	return nil
+}
+
+// UpdateResourceTag tags the user with the name of its User
+func (c Client) UpdateResourceTag(ctx context.Context, username, resource string) error {
+	query := statements.AlterUser(username).Parameters(map[string]string{ResourceParameter: resource}, nil)
 
 	if _, err := c.ExecContext(ctx, query); err != nil {
-		return fmt.Errorf(ErrUpdateUserPasswordLifetimeCheck, err)
+		return fmt.Errorf(ErrUpdateUserResourceTag, err)
 	}
 	return nil
 }
 
+func nullTime(t sql.NullTime) *metav1.Time {
+	if !t.Valid {
+		return nil
+	}
+	mt := metav1.NewTime(t.Time)
+	return &mt
+}
+
 func (c Client) UpdateX509Providers(ctx context.Context, username string, toAdd, toRemove []ResolvedUserMapping) error {
 	if len(toAdd) > 0 {
+		if err := c.dialect.Require(statements.X509Providers); err != nil {
+			return err
+		}
 		for _, provider := range toAdd {
-			addProviderQuery := fmt.Sprintf(`ALTER USER %s ADD IDENTITY '%s' FOR X509 PROVIDER %s`, username, provider.SubjectName, provider.Name)
-			if _, err := c.ExecContext(ctx, addProviderQuery); err != nil {
+			if _, err := c.ExecContext(ctx, statements.AlterUser(username).AddIdentity(provider.SubjectName, provider.Name)); err != nil {
 				return err
 			}
 		}
@@ -427,8 +1017,7 @@ func (c Client) UpdateX509Providers(ctx context.Context, username string, toAdd,
 
 	if len(toRemove) > 0 {
 		for _, provider := range toRemove {
-			removeProviderQuery := fmt.Sprintf(`ALTER USER %s DROP IDENTITY '%s' FOR X509 PROVIDER %s`, username, provider.SubjectName, provider.Name)
-			if _, err := c.ExecContext(ctx, removeProviderQuery); err != nil {
+			if _, err := c.ExecContext(ctx, statements.AlterUser(username).DropIdentity(provider.SubjectName, provider.Name)); err != nil {
 				return err
 			}
 		}
@@ -439,31 +1028,68 @@ func (c Client) UpdateX509Providers(ctx context.Context, username string, toAdd,
 
 // Delete deletes the user
 func (c Client) Delete(ctx context.Context, parameters *v1alpha1.UserParameters) error {
+	if parameters.TerminateSessionsOnDelete {
+		if err := c.terminateSessions(ctx, parameters.Username); err != nil {
+			return err
+		}
+	}
 
-	query := fmt.Sprintf("DROP USER %s", parameters.Username)
-
-	if _, err := c.ExecContext(ctx, query); err != nil {
+	if _, err := c.ExecContext(ctx, statements.DropUser(parameters.Username)); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func (c Client) TogglePasswordAuthentication(ctx context.Context, username string, isPasswordEnabled bool) error {
-	var query string
-	if isPasswordEnabled {
-		query = fmt.Sprintf("ALTER USER %s DISABLE PASSWORD", username)
-	} else {
-		query = fmt.Sprintf("ALTER USER %s ENABLE PASSWORD", username)
+// terminateSessions deactivates the user, so that it cannot open new sessions,
+// and disconnects its active sessions. Sessions without a status are already
+// disconnected.
+func (c Client) terminateSessions(ctx context.Context, username string) error {
+	if _, err := c.ExecContext(ctx, statements.AlterUser(username).DeactivateNow()); err != nil {
+		return fmt.Errorf(errDeactivateUser, err)
 	}
 
-	if _, err := c.ExecContext(ctx, query); err != nil {
+	rows, err := c.QueryContext(ctx, "SELECT CONNECTION_ID FROM M_CONNECTIONS WHERE USER_NAME = ? AND CONNECTION_STATUS <> ''", username)
+	if err != nil {
+		return fmt.Errorf(errQuerySessions, err)
+	}
+	defer rows.Close() //nolint:errcheck
+
+	var sessions []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return fmt.Errorf(errQuerySessions, err)
+		}
+		sessions = append(sessions, id)
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf(errQuerySessions, err)
+	}
+
+	for _, id := range sessions {
+		if _, err := c.ExecContext(ctx, statements.DisconnectSession(id)); err != nil {
+			return fmt.Errorf(errDisconnectSession, id, err)
+		}
+	}
+	return nil
+}
+
+func (c Client) TogglePasswordAuthentication(ctx context.Context, username string, isPasswordEnabled bool) error {
+	// isPasswordEnabled is the current state, which is toggled
+	if _, err := c.ExecContext(ctx, statements.AlterUser(username).PasswordAuthentication(!isPasswordEnabled)); err != nil {
 		return fmt.Errorf("failed to enable/disable password: %w", err)
 	}
 
 	return nil
 }
 
+// MissingTargets returns the privileges on schemas and objects that do not
+// exist yet.
+func (c Client) MissingTargets(ctx context.Context, privileges []string) ([]privilege.MissingTarget, error) {
+	return privilege.MissingTargets(ctx, c.DB, privileges, c.GetDefaultSchema())
+}
+
 // GetDefaultSchema returns the default schema for the user
 func (c Client) GetDefaultSchema() string {
 	// The default schema for a user is always the same as the username
@@ -518,28 +1144,20 @@ func (c Client) handleAuthenticationError(ctx context.Context, err error) (bool,
 }
 
 func generateCreateQuery(parameters *v1alpha1.UserParameters, password string) (string, error) {
-	query := "CREATE USER %s"
-	if parameters.RestrictedUser {
-		query = "CREATE RESTRICTED USER %s"
-	}
-	query = fmt.Sprintf(query, parameters.Username)
+	query := statements.CreateUser(parameters.Username).
+		Restricted(parameters.RestrictedUser).
+		Parameters(knownParameters(parameters.Parameters))
 
-	if pw := parameters.Authentication.Password; pw != nil && pw.PasswordSecretRef != nil {
+	if pw := parameters.Authentication.Password; pw != nil && (pw.PasswordSecretRef != nil || pw.PasswordStoreRef != nil) {
 		if password == "" {
 			return "", errors.New("cannot get user password")
 		}
-		query += fmt.Sprintf(` PASSWORD "%s"`, password)
-		if !parameters.Authentication.Password.ForceFirstPasswordChange {
-			query += " NO FORCE_FIRST_PASSWORD_CHANGE"
-		}
-	}
-
-	if len(parameters.Parameters) > 0 {
-		query = setParameters(query, parameters.Parameters)
+		query.Password(password, pw.ForceFirstPasswordChange)
 	}
 
-	if parameters.Usergroup != "" {
-		query += fmt.Sprintf(" SET USERGROUP %s", parameters.Usergroup)
+	// Users are created in the default usergroup unless they name another
+	if parameters.Usergroup != "" && parameters.Usergroup != usergroupDefault {
+		query.Usergroup(parameters.Usergroup)
 	}
-	return query, nil
+	return query.String(), nil
 }
@@ -6,7 +6,9 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
@@ -17,6 +19,7 @@ import (
 	"github.com/SAP/crossplane-provider-hana/apis/admin/v1alpha1"
 	"github.com/SAP/crossplane-provider-hana/internal/clients/fake"
 	"github.com/SAP/crossplane-provider-hana/internal/clients/hana/privilege"
+	"github.com/SAP/crossplane-provider-hana/internal/clients/hana/statements"
 )
 
 var testTime = metav1.Now()
@@ -24,6 +27,8 @@ var testTime = metav1.Now()
 // nolint: contextcheck
 func TestRead(t *testing.T) {
 	errBoom := errors.New("boom")
+	timedOut, cancel := context.WithDeadline(context.Background(), time.Now())
+	defer cancel()
 
 	type fields struct {
 		db fake.MockDB
@@ -107,14 +112,36 @@ func TestRead(t *testing.T) {
 				err: nil,
 			},
 		},
+		"ErrReadInterrupted": {
+			reason: "A user query cut short by a timeout should return an error instead of reporting the user as missing",
+			fields: fields{
+				db: fake.MockDB{
+					MockQueryRowContext: func(ctx context.Context, query string, args ...any) *sql.Row {
+						db, mock, _ := sqlmock.New()
+						mock.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows([]string{"USER_NAME"}))
+						return db.QueryRowContext(context.Background(), "SELECT")
+					},
+				},
+			},
+			args: args{
+				ctx: timedOut,
+				parameters: &v1alpha1.UserParameters{
+					Username: "DEMO_USER",
+				},
+			},
+			want: want{
+				observed: &v1alpha1.UserObservation{},
+				err:      fmt.Errorf("query interrupted: %w", context.DeadlineExceeded),
+			},
+		},
 		"SuccessWithCompleteUserData": {
 			reason: "Should successfully read user with complete data including privileges and roles",
 			fields: fields{
 				db: fake.MockDB{
 					MockQueryRowContext: func(ctx context.Context, query string, args ...any) *sql.Row {
 						db, mock, _ := sqlmock.New()
-						rows := sqlmock.NewRows([]string{"USER_NAME", "USERGROUP_NAME", "CREATE_TIME", "LAST_PASSWORD_CHANGE_TIME", "IS_RESTRICTED", "IS_PASSWORD_LIFETIME_CHECK_ENABLED", "IS_PASSWORD_ENABLED"}).
-							AddRow("TEST_USER", "TEST_GROUP", testTime.Time, testTime.Time, false, false, true)
+						rows := sqlmock.NewRows([]string{"USER_NAME", "USERGROUP_NAME", "CREATE_TIME", "LAST_PASSWORD_CHANGE_TIME", "IS_RESTRICTED", "IS_CLIENT_CONNECT_ENABLED", "IS_PASSWORD_LIFETIME_CHECK_ENABLED", "IS_PASSWORD_ENABLED", "VALID_FROM", "VALID_UNTIL", "LAST_SUCCESSFUL_CONNECT", "INVALID_CONNECT_ATTEMPTS", "USER_DEACTIVATED", "EXTERNAL_IDENTITY"}).
+							AddRow("TEST_USER", "TEST_GROUP", testTime.Time, testTime.Time, false, true, false, true, nil, nil, testTime.Time, 2, true, "test-user@EXAMPLE.COM")
 						mock.ExpectQuery("SELECT").WillReturnRows(rows)
 						return db.QueryRowContext(context.Background(), "SELECT")
 					},
@@ -123,7 +150,11 @@ func TestRead(t *testing.T) {
 						if len(args) > 0 && args[0] == "TEST_USER" && strings.Contains(query, "USER_PARAMETERS") {
 							return fake.MockRowsToSQLRows(sqlmock.NewRows([]string{"USER_NAME", "PARAMETER", "VALUE"}).
 								AddRow("TEST_USER", "LOCALE", "en_US").
-								AddRow("TEST_USER", "TIME ZONE", "UTC")), nil
+								AddRow("TEST_USER", "TIME ZONE", "UTC").
+								AddRow("TEST_USER", "XS_APPLICATIONUSER", "TRUE").
+								AddRow("TEST_USER", "XS_RC_XS_USER_DISPLAY", "XS_USER_DISPLAY").
+								AddRow("TEST_USER", "XS_RC_SAC_VIEWER", "SAC_VIEWER").
+								AddRow("TEST_USER", ResourceParameter, "test-user")), nil
 						}
 						// Mock privileges query - needs 4 columns: OBJECT_TYPE, PRIVILEGE, SCHEMA_NAME, OBJECT_NAME
 						if strings.Contains(query, "GRANTED_PRIVILEGES") {
@@ -155,17 +186,33 @@ func TestRead(t *testing.T) {
 			},
 			want: want{
 				observed: &v1alpha1.UserObservation{
-					Username:                       new("TEST_USER"),
-					RestrictedUser:                 new(false),
-					LastPasswordChangeTime:         testTime,
-					CreatedAt:                      testTime,
-					Privileges:                     make([]string, 0),
-					Roles:                          make([]string, 0),
-					Parameters:                     map[string]string{"LOCALE": "en_US", "TIME ZONE": "UTC"},
+					Username:               new("TEST_USER"),
+					RestrictedUser:         new(false),
+					IsClientConnectEnabled: new(true),
+					LastPasswordChangeTime: testTime,
+					CreatedAt:              testTime,
+					Privileges:             make([]string, 0),
+					Roles:                  make([]string, 0),
+					Parameters:             map[string]string{"LOCALE": "en_US", "TIME ZONE": "UTC"},
+					ManagedResource:        "test-user",
+					ApplicationUser: &v1alpha1.ApplicationUserObservation{
+						IsApplicationUser: true,
+						RoleCollections:   []string{"SAC_VIEWER", "XS_USER_DISPLAY"},
+						Parameters: map[string]string{
+							"XS_APPLICATIONUSER":    "TRUE",
+							"XS_RC_XS_USER_DISPLAY": "XS_USER_DISPLAY",
+							"XS_RC_SAC_VIEWER":      "SAC_VIEWER",
+						},
+					},
 					Usergroup:                      new("TEST_GROUP"),
 					PasswordUpToDate:               new(true),
 					IsPasswordLifetimeCheckEnabled: new(false),
+					PasswordPolicy:                 v1alpha1.PasswordPolicyNeverExpires,
 					IsPasswordEnabled:              new(true),
+					LastSuccessfulConnect:          &testTime,
+					InvalidConnectAttempts:         new(int32(2)),
+					IsLocked:                       new(true),
+					ExternalIdentity:               "test-user@EXAMPLE.COM",
 				},
 				err: nil,
 			},
@@ -176,8 +223,8 @@ func TestRead(t *testing.T) {
 				db: fake.MockDB{
 					MockQueryRowContext: func(ctx context.Context, query string, args ...any) *sql.Row {
 						db, mock, _ := sqlmock.New()
-						rows := sqlmock.NewRows([]string{"USER_NAME", "USERGROUP_NAME", "CREATE_TIME", "LAST_PASSWORD_CHANGE_TIME", "IS_RESTRICTED", "IS_PASSWORD_LIFETIME_CHECK_ENABLED", "IS_PASSWORD_ENABLED"}).
-							AddRow("POWER_USER", "", testTime.Time, testTime.Time, false, false, true)
+						rows := sqlmock.NewRows([]string{"USER_NAME", "USERGROUP_NAME", "CREATE_TIME", "LAST_PASSWORD_CHANGE_TIME", "IS_RESTRICTED", "IS_CLIENT_CONNECT_ENABLED", "IS_PASSWORD_LIFETIME_CHECK_ENABLED", "IS_PASSWORD_ENABLED", "VALID_FROM", "VALID_UNTIL", "LAST_SUCCESSFUL_CONNECT", "INVALID_CONNECT_ATTEMPTS", "USER_DEACTIVATED", "EXTERNAL_IDENTITY"}).
+							AddRow("POWER_USER", "", testTime.Time, testTime.Time, false, true, false, true, nil, nil, nil, 0, false, nil)
 						mock.ExpectQuery("SELECT").WillReturnRows(rows)
 						return db.QueryRowContext(context.Background(), "SELECT")
 					},
@@ -206,15 +253,19 @@ func TestRead(t *testing.T) {
 				observed: &v1alpha1.UserObservation{
 					Username:                       new("POWER_USER"),
 					RestrictedUser:                 new(false),
+					IsClientConnectEnabled:         new(true),
 					LastPasswordChangeTime:         testTime,
 					CreatedAt:                      testTime,
 					Privileges:                     make([]string, 0),
 					Roles:                          make([]string, 0),
 					Parameters:                     make(map[string]string),
-					Usergroup:                      new(""),
+					Usergroup:                      new("DEFAULT"),
 					PasswordUpToDate:               new(true),
 					IsPasswordLifetimeCheckEnabled: new(false),
+					PasswordPolicy:                 v1alpha1.PasswordPolicyNeverExpires,
 					IsPasswordEnabled:              new(true),
+					InvalidConnectAttempts:         new(int32(0)),
+					IsLocked:                       new(false),
 				},
 				err: nil,
 			},
@@ -225,8 +276,8 @@ func TestRead(t *testing.T) {
 				db: fake.MockDB{
 					MockQueryRowContext: func(ctx context.Context, query string, args ...any) *sql.Row {
 						db, mock, _ := sqlmock.New()
-						rows := sqlmock.NewRows([]string{"USER_NAME", "USERGROUP_NAME", "CREATE_TIME", "LAST_PASSWORD_CHANGE_TIME", "IS_RESTRICTED", "IS_PASSWORD_LIFETIME_CHECK_ENABLED", "IS_PASSWORD_ENABLED"}).
-							AddRow("RESTRICTED_USER", "", testTime.Time, testTime.Time, true, false, true)
+						rows := sqlmock.NewRows([]string{"USER_NAME", "USERGROUP_NAME", "CREATE_TIME", "LAST_PASSWORD_CHANGE_TIME", "IS_RESTRICTED", "IS_CLIENT_CONNECT_ENABLED", "IS_PASSWORD_LIFETIME_CHECK_ENABLED", "IS_PASSWORD_ENABLED", "VALID_FROM", "VALID_UNTIL", "LAST_SUCCESSFUL_CONNECT", "INVALID_CONNECT_ATTEMPTS", "USER_DEACTIVATED", "EXTERNAL_IDENTITY"}).
+							AddRow("RESTRICTED_USER", nil, testTime.Time, testTime.Time, true, false, false, true, nil, nil, nil, 0, false, nil)
 						mock.ExpectQuery("SELECT").WillReturnRows(rows)
 						return db.QueryRowContext(context.Background(), "SELECT")
 					},
@@ -254,15 +305,19 @@ func TestRead(t *testing.T) {
 				observed: &v1alpha1.UserObservation{
 					Username:                       new("RESTRICTED_USER"),
 					RestrictedUser:                 new(true),
+					IsClientConnectEnabled:         new(false),
 					LastPasswordChangeTime:         testTime,
 					CreatedAt:                      testTime,
 					Privileges:                     make([]string, 0),
 					Roles:                          make([]string, 0),
 					Parameters:                     make(map[string]string),
-					Usergroup:                      new(""),
+					Usergroup:                      new("DEFAULT"),
 					PasswordUpToDate:               new(true),
 					IsPasswordLifetimeCheckEnabled: new(false),
+					PasswordPolicy:                 v1alpha1.PasswordPolicyNeverExpires,
 					IsPasswordEnabled:              new(true),
+					InvalidConnectAttempts:         new(int32(0)),
+					IsLocked:                       new(false),
 				},
 				err: nil,
 			},
@@ -273,8 +328,8 @@ func TestRead(t *testing.T) {
 				db: fake.MockDB{
 					MockQueryRowContext: func(ctx context.Context, query string, args ...any) *sql.Row {
 						db, mock, _ := sqlmock.New()
-						rows := sqlmock.NewRows([]string{"USER_NAME", "USERGROUP_NAME", "CREATE_TIME", "LAST_PASSWORD_CHANGE_TIME", "IS_RESTRICTED", "IS_PASSWORD_LIFETIME_CHECK_ENABLED", "IS_PASSWORD_ENABLED"}).
-							AddRow("X509_USER", "X509_GROUP", testTime.Time, testTime.Time, false, true, false)
+						rows := sqlmock.NewRows([]string{"USER_NAME", "USERGROUP_NAME", "CREATE_TIME", "LAST_PASSWORD_CHANGE_TIME", "IS_RESTRICTED", "IS_CLIENT_CONNECT_ENABLED", "IS_PASSWORD_LIFETIME_CHECK_ENABLED", "IS_PASSWORD_ENABLED", "VALID_FROM", "VALID_UNTIL", "LAST_SUCCESSFUL_CONNECT", "INVALID_CONNECT_ATTEMPTS", "USER_DEACTIVATED", "EXTERNAL_IDENTITY"}).
+							AddRow("X509_USER", "X509_GROUP", testTime.Time, testTime.Time, false, true, true, false, nil, nil, nil, 0, false, nil)
 						mock.ExpectQuery("SELECT").WillReturnRows(rows)
 						return db.QueryRowContext(context.Background(), "SELECT")
 					},
@@ -315,6 +370,7 @@ func TestRead(t *testing.T) {
 				observed: &v1alpha1.UserObservation{
 					Username:                       new("X509_USER"),
 					RestrictedUser:                 new(false),
+					IsClientConnectEnabled:         new(true),
 					LastPasswordChangeTime:         testTime,
 					CreatedAt:                      testTime,
 					Privileges:                     make([]string, 0),
@@ -324,6 +380,8 @@ func TestRead(t *testing.T) {
 					PasswordUpToDate:               nil,
 					IsPasswordLifetimeCheckEnabled: new(true),
 					IsPasswordEnabled:              new(false),
+					InvalidConnectAttempts:         new(int32(0)),
+					IsLocked:                       new(false),
 					X509Providers: []v1alpha1.X509UserMapping{
 						{
 							X509ProviderRef: v1alpha1.X509ProviderRef{Name: "TEST_PROVIDER"},
@@ -344,8 +402,13 @@ func TestRead(t *testing.T) {
 				db: fake.MockDB{
 					MockQueryRowContext: func(ctx context.Context, query string, args ...any) *sql.Row {
 						db, mock, _ := sqlmock.New()
-						rows := sqlmock.NewRows([]string{"USER_NAME", "USERGROUP_NAME", "CREATE_TIME", "LAST_PASSWORD_CHANGE_TIME", "IS_RESTRICTED", "IS_PASSWORD_LIFETIME_CHECK_ENABLED", "IS_PASSWORD_ENABLED"}).
-							AddRow("HYBRID_USER", "HYBRID_GROUP", testTime.Time, testTime.Time, false, true, true)
+						// Password lifetime of the password policy
+						if strings.Contains(query, "M_PASSWORD_POLICY") {
+							mock.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows([]string{"LIFETIME", "OWN"}).AddRow("182", 1))
+							return db.QueryRowContext(context.Background(), "SELECT")
+						}
+						rows := sqlmock.NewRows([]string{"USER_NAME", "USERGROUP_NAME", "CREATE_TIME", "LAST_PASSWORD_CHANGE_TIME", "IS_RESTRICTED", "IS_CLIENT_CONNECT_ENABLED", "IS_PASSWORD_LIFETIME_CHECK_ENABLED", "IS_PASSWORD_ENABLED", "VALID_FROM", "VALID_UNTIL", "LAST_SUCCESSFUL_CONNECT", "INVALID_CONNECT_ATTEMPTS", "USER_DEACTIVATED", "EXTERNAL_IDENTITY"}).
+							AddRow("HYBRID_USER", "HYBRID_GROUP", testTime.Time, testTime.Time, false, true, true, true, nil, nil, nil, 0, false, nil)
 						mock.ExpectQuery("SELECT").WillReturnRows(rows)
 						return db.QueryRowContext(context.Background(), "SELECT")
 					},
@@ -385,6 +448,7 @@ func TestRead(t *testing.T) {
 				observed: &v1alpha1.UserObservation{
 					Username:                       new("HYBRID_USER"),
 					RestrictedUser:                 new(false),
+					IsClientConnectEnabled:         new(true),
 					LastPasswordChangeTime:         testTime,
 					CreatedAt:                      testTime,
 					Privileges:                     make([]string, 0),
@@ -392,8 +456,12 @@ func TestRead(t *testing.T) {
 					Parameters:                     make(map[string]string),
 					Usergroup:                      new("HYBRID_GROUP"),
 					PasswordUpToDate:               new(true),
+					PasswordExpiresAt:              &metav1.Time{Time: testTime.AddDate(0, 0, 182)},
 					IsPasswordLifetimeCheckEnabled: new(true),
+					PasswordPolicy:                 v1alpha1.PasswordPolicyUsergroup,
 					IsPasswordEnabled:              new(true),
+					InvalidConnectAttempts:         new(int32(0)),
+					IsLocked:                       new(false),
 					X509Providers: []v1alpha1.X509UserMapping{
 						{
 							X509ProviderRef: v1alpha1.X509ProviderRef{Name: "MAIN_PROVIDER"},
@@ -410,8 +478,8 @@ func TestRead(t *testing.T) {
 				db: fake.MockDB{
 					MockQueryRowContext: func(ctx context.Context, query string, args ...any) *sql.Row {
 						db, mock, _ := sqlmock.New()
-						rows := sqlmock.NewRows([]string{"USER_NAME", "USERGROUP_NAME", "CREATE_TIME", "LAST_PASSWORD_CHANGE_TIME", "IS_RESTRICTED", "IS_PASSWORD_LIFETIME_CHECK_ENABLED", "IS_PASSWORD_ENABLED"}).
-							AddRow("ERROR_USER", "", testTime.Time, testTime.Time, false, false, true)
+						rows := sqlmock.NewRows([]string{"USER_NAME", "USERGROUP_NAME", "CREATE_TIME", "LAST_PASSWORD_CHANGE_TIME", "IS_RESTRICTED", "IS_CLIENT_CONNECT_ENABLED", "IS_PASSWORD_LIFETIME_CHECK_ENABLED", "IS_PASSWORD_ENABLED", "VALID_FROM", "VALID_UNTIL", "LAST_SUCCESSFUL_CONNECT", "INVALID_CONNECT_ATTEMPTS", "USER_DEACTIVATED", "EXTERNAL_IDENTITY"}).
+							AddRow("ERROR_USER", "", testTime.Time, testTime.Time, false, true, false, true, nil, nil, nil, 0, false, nil)
 						mock.ExpectQuery("SELECT").WillReturnRows(rows)
 						return db.QueryRowContext(context.Background(), "SELECT")
 					},
@@ -437,15 +505,14 @@ func TestRead(t *testing.T) {
 				observed: &v1alpha1.UserObservation{
 					Username:                       new("ERROR_USER"),
 					RestrictedUser:                 new(false),
+					IsClientConnectEnabled:         new(true),
 					LastPasswordChangeTime:         testTime,
 					CreatedAt:                      testTime,
-					Privileges:                     make([]string, 0),
-					Roles:                          make([]string, 0),
-					Parameters:                     make(map[string]string),
-					Usergroup:                      new(""),
-					PasswordUpToDate:               new(false),
+					Usergroup:                      new("DEFAULT"),
 					IsPasswordLifetimeCheckEnabled: new(false),
 					IsPasswordEnabled:              new(true),
+					InvalidConnectAttempts:         new(int32(0)),
+					IsLocked:                       new(false),
 				},
 				err: fmt.Errorf("failed to query x509 providers: %w", errBoom),
 			},
@@ -453,11 +520,17 @@ func TestRead(t *testing.T) {
 	}
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
+			// The mocked catalog shows all grants
 			c := Client{
-				DB:     tc.fields.db,
-				Client: &privilege.PrivilegeClient{DB: tc.fields.db},
+				DB:          tc.fields.db,
+				Client:      &privilege.PrivilegeClient{DB: tc.fields.db},
+				catalogRead: true,
+			}
+			ctx := tc.args.ctx
+			if ctx == nil {
+				ctx = context.Background()
 			}
-			got, err := c.Read(tc.args.ctx, tc.args.parameters, tc.args.password)
+			got, err := c.Read(ctx, tc.args.parameters, tc.args.password)
 			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
 				t.Errorf("\n%s\ne.Read(...): -want error, +got error:\n%s\n", tc.reason, diff)
 			}
@@ -468,6 +541,262 @@ func TestRead(t *testing.T) {
 	}
 }
 
+// insufficientPrivilegeError mimics the HANA error for missing privileges.
+type insufficientPrivilegeError struct{}
+
+func (insufficientPrivilegeError) Error() string   { return "SQL Error 258 - insufficient privilege" }
+func (insufficientPrivilegeError) StmtNo() int     { return 0 }
+func (insufficientPrivilegeError) Code() int       { return errCodeInsufficientPrivilege }
+func (insufficientPrivilegeError) Position() int   { return 0 }
+func (insufficientPrivilegeError) Level() int      { return 1 }
+func (insufficientPrivilegeError) Text() string    { return "insufficient privilege" }
+func (insufficientPrivilegeError) IsWarning() bool { return false }
+func (insufficientPrivilegeError) IsError() bool   { return true }
+func (insufficientPrivilegeError) IsFatal() bool   { return false }
+
+// nolint: contextcheck
+// TestReadConcurrently verifies that the aspects of a user are queried
+// concurrently: the queries of privileges and roles only return once both
+// were issued.
+func TestReadConcurrently(t *testing.T) {
+	var arrived sync.WaitGroup
+	arrived.Add(2)
+	both := make(chan struct{})
+	go func() {
+		arrived.Wait()
+		close(both)
+	}()
+	wait := func(columns ...string) (*sql.Rows, error) {
+		arrived.Done()
+		select {
+		case <-both:
+			return fake.MockRowsToSQLRows(sqlmock.NewRows(columns)), nil
+		case <-time.After(5 * time.Second):
+			return nil, errors.New("queries were not issued concurrently")
+		}
+	}
+
+	db := fake.MockDB{
+		MockQueryRowContext: func(ctx context.Context, query string, args ...any) *sql.Row {
+			db, mock, _ := sqlmock.New()
+			rows := sqlmock.NewRows([]string{"USER_NAME", "USERGROUP_NAME", "CREATE_TIME", "LAST_PASSWORD_CHANGE_TIME", "IS_RESTRICTED", "IS_CLIENT_CONNECT_ENABLED", "IS_PASSWORD_LIFETIME_CHECK_ENABLED", "IS_PASSWORD_ENABLED", "VALID_FROM", "VALID_UNTIL", "LAST_SUCCESSFUL_CONNECT", "INVALID_CONNECT_ATTEMPTS", "USER_DEACTIVATED", "EXTERNAL_IDENTITY"}).
+				AddRow("TEST_USER", nil, time.Time{}, time.Time{}, false, true, false, false, nil, nil, nil, 0, false, nil)
+			mock.ExpectQuery("SELECT").WillReturnRows(rows)
+			return db.QueryRowContext(context.Background(), "SELECT")
+		},
+		MockQueryContext: func(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+			switch {
+			case strings.Contains(query, "GRANTED_PRIVILEGES"):
+				return wait("OBJECT_TYPE", "PRIVILEGE", "SCHEMA_NAME", "OBJECT_NAME", "IS_GRANTABLE")
+			case strings.Contains(query, "GRANTED_ROLES"):
+				return wait("ROLE_SCHEMA_NAME", "ROLE_NAME", "IS_GRANTABLE")
+			}
+			return fake.MockRowsToSQLRows(sqlmock.NewRows([]string{})), nil
+		},
+	}
+
+	c := Client{DB: db, Client: &privilege.PrivilegeClient{DB: db}}
+	if _, err := c.Read(context.Background(), &v1alpha1.UserParameters{Username: "TEST_USER"}, ""); err != nil {
+		t.Errorf("Read(...): %v", err)
+	}
+}
+
+func TestReadLastAuthentication(t *testing.T) {
+	cases := map[string]struct {
+		reason  string
+		audit   func() (*sql.Rows, error)
+		want    *v1alpha1.UserAuthenticationObservation
+		wantErr bool
+	}{
+		"X509": {
+			reason: "The mapping whose subject the audit log names should be reported",
+			audit: func() (*sql.Rows, error) {
+				return fake.MockRowsToSQLRows(sqlmock.NewRows([]string{"TIMESTAMP", "COMMENT"}).
+					AddRow(testTime.Time, "authentication method X509, subject CN=John Doe, O=Acme Corp")), nil
+			},
+			want: &v1alpha1.UserAuthenticationObservation{
+				Time:         testTime,
+				Method:       "X509",
+				X509Provider: "TEST_PROVIDER",
+				SubjectName:  "CN=John Doe,O=Acme Corp",
+			},
+		},
+		"Password": {
+			reason: "The authentication method should be reported without a mapping",
+			audit: func() (*sql.Rows, error) {
+				return fake.MockRowsToSQLRows(sqlmock.NewRows([]string{"TIMESTAMP", "COMMENT"}).
+					AddRow(testTime.Time, "authentication method PASSWORD")), nil
+			},
+			want: &v1alpha1.UserAuthenticationObservation{Time: testTime, Method: "PASSWORD"},
+		},
+		"NotAudited": {
+			reason: "Nothing should be reported if no connect was audited",
+			audit: func() (*sql.Rows, error) {
+				return fake.MockRowsToSQLRows(sqlmock.NewRows([]string{"TIMESTAMP", "COMMENT"})), nil
+			},
+		},
+		"InsufficientPrivilege": {
+			reason: "Nothing should be reported if the technical user may not read the audit log",
+			audit: func() (*sql.Rows, error) {
+				return nil, insufficientPrivilegeError{}
+			},
+		},
+		"ErrAudit": {
+			reason: "Any other errors encountered while reading the audit log should be returned",
+			audit: func() (*sql.Rows, error) {
+				return nil, errors.New("boom")
+			},
+			wantErr: true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			db := fake.MockDB{
+				MockQueryRowContext: func(ctx context.Context, query string, args ...any) *sql.Row {
+					db, mock, _ := sqlmock.New()
+					rows := sqlmock.NewRows([]string{"USER_NAME", "USERGROUP_NAME", "CREATE_TIME", "LAST_PASSWORD_CHANGE_TIME", "IS_RESTRICTED", "IS_CLIENT_CONNECT_ENABLED", "IS_PASSWORD_LIFETIME_CHECK_ENABLED", "IS_PASSWORD_ENABLED", "VALID_FROM", "VALID_UNTIL", "LAST_SUCCESSFUL_CONNECT", "INVALID_CONNECT_ATTEMPTS", "USER_DEACTIVATED", "EXTERNAL_IDENTITY"}).
+						AddRow("X509_USER", nil, testTime.Time, testTime.Time, false, true, false, false, nil, nil, nil, 0, false, nil)
+					mock.ExpectQuery("SELECT").WillReturnRows(rows)
+					return db.QueryRowContext(context.Background(), "SELECT")
+				},
+				MockQueryContext: func(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+					switch {
+					case strings.Contains(query, "AUDIT_LOG"):
+						return tc.audit()
+					case strings.Contains(query, "X509_USER_MAPPINGS"):
+						return fake.MockRowsToSQLRows(sqlmock.NewRows([]string{"X509_PROVIDER_NAME", "SUBJECT_NAME"}).
+							AddRow("ANY_PROVIDER", sql.NullString{}).
+							AddRow("TEST_PROVIDER", "CN=John Doe,O=Acme Corp")), nil
+					}
+					return fake.MockRowsToSQLRows(sqlmock.NewRows([]string{})), nil
+				},
+			}
+			c := Client{DB: db, Client: &privilege.PrivilegeClient{DB: db}}
+			got, err := c.Read(context.Background(), &v1alpha1.UserParameters{Username: "X509_USER"}, "")
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("\n%s\nc.Read(...): want error %t, got %v", tc.reason, tc.wantErr, err)
+			}
+			if tc.wantErr {
+				return
+			}
+			if diff := cmp.Diff(tc.want, got.LastAuthentication); diff != "" {
+				t.Errorf("\n%s\nc.Read(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestReadUsergroupOperator(t *testing.T) {
+	db := fake.MockDB{
+		MockQueryRowContext: func(ctx context.Context, query string, args ...any) *sql.Row {
+			db, mock, _ := sqlmock.New()
+			rows := sqlmock.NewRows([]string{"USER_NAME", "USERGROUP_NAME", "CREATE_TIME", "LAST_PASSWORD_CHANGE_TIME", "IS_RESTRICTED", "IS_CLIENT_CONNECT_ENABLED", "IS_PASSWORD_LIFETIME_CHECK_ENABLED", "IS_PASSWORD_ENABLED", "VALID_FROM", "VALID_UNTIL", "LAST_SUCCESSFUL_CONNECT", "INVALID_CONNECT_ATTEMPTS", "USER_DEACTIVATED", "EXTERNAL_IDENTITY"}).
+				AddRow("OP_USER", "OPS", testTime.Time, testTime.Time, false, true, true, false, nil, nil, nil, 0, false, nil)
+			mock.ExpectQuery("SELECT").WillReturnRows(rows)
+			return db.QueryRowContext(context.Background(), "SELECT")
+		},
+		MockQueryContext: func(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+			if strings.Contains(query, "GRANTED_PRIVILEGES") || strings.Contains(query, "GRANTED_ROLES") {
+				return nil, insufficientPrivilegeError{}
+			}
+			return fake.MockRowsToSQLRows(sqlmock.NewRows([]string{})), nil
+		},
+	}
+	parameters := &v1alpha1.UserParameters{Username: "OP_USER"}
+
+	cases := map[string]struct {
+		reason    string
+		usergroup string
+		want      []string
+		wantErr   bool
+	}{
+		"Degrades": {
+			reason:    "Catalog views the usergroup operator cannot read should be left unobserved",
+			usergroup: "OPS",
+			want:      []string{FieldPrivileges, FieldRoles},
+		},
+		"UserAdminFails": {
+			reason:  "Missing privileges should fail Read outside of usergroup operator mode",
+			wantErr: true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			c := Client{DB: db, Client: &privilege.PrivilegeClient{DB: db}}
+			if tc.usergroup != "" {
+				c = c.ForUsergroupOperator(tc.usergroup)
+			}
+			got, err := c.Read(context.Background(), parameters, "")
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("\n%s\nc.Read(...): want error %t, got %v", tc.reason, tc.wantErr, err)
+			}
+			if tc.wantErr {
+				return
+			}
+			if diff := cmp.Diff(tc.want, got.UnobservedFields); diff != "" {
+				t.Errorf("\n%s\nc.Read(...): -want unobserved, +got unobserved:\n%s\n", tc.reason, diff)
+			}
+			if got.Privileges != nil || got.Roles != nil {
+				t.Errorf("\n%s\nc.Read(...): unobserved privileges and roles should be nil", tc.reason)
+			}
+		})
+	}
+}
+
+// nolint: contextcheck
+func TestReadHiddenGrants(t *testing.T) {
+	newDB := func(usergroup string) fake.MockDB {
+		return fake.MockDB{
+			MockQueryRowContext: func(ctx context.Context, query string, args ...any) *sql.Row {
+				db, mock, _ := sqlmock.New()
+				rows := sqlmock.NewRows([]string{"USER_NAME", "USERGROUP_NAME", "CREATE_TIME", "LAST_PASSWORD_CHANGE_TIME", "IS_RESTRICTED", "IS_CLIENT_CONNECT_ENABLED", "IS_PASSWORD_LIFETIME_CHECK_ENABLED", "IS_PASSWORD_ENABLED", "VALID_FROM", "VALID_UNTIL", "LAST_SUCCESSFUL_CONNECT", "INVALID_CONNECT_ATTEMPTS", "USER_DEACTIVATED", "EXTERNAL_IDENTITY"}).
+					AddRow("DEMO_USER", usergroup, testTime.Time, testTime.Time, false, true, true, false, nil, nil, nil, 0, false, nil)
+				mock.ExpectQuery("SELECT").WillReturnRows(rows)
+				return db.QueryRowContext(context.Background(), "SELECT")
+			},
+		}
+	}
+	parameters := &v1alpha1.UserParameters{Username: "DEMO_USER"}
+
+	cases := map[string]struct {
+		reason      string
+		usergroup   string
+		catalogRead bool
+		want        []string
+	}{
+		"Hidden": {
+			reason:    "A user of a usergroup without any visible role should leave its grants unobserved",
+			usergroup: "NO_GRANT_GROUP",
+			want:      []string{FieldPrivileges, FieldRoles},
+		},
+		"DefaultUsergroup": {
+			reason:    "Users of the DEFAULT usergroup are always visible",
+			usergroup: "DEFAULT",
+		},
+		"CatalogRead": {
+			reason:      "A technical user with CATALOG READ should trust the catalog",
+			usergroup:   "NO_GRANT_GROUP",
+			catalogRead: true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			db := newDB(tc.usergroup)
+			c := Client{DB: db, Client: &privilege.PrivilegeClient{DB: db}}
+			if tc.catalogRead {
+				c = c.WithCatalogRead()
+			}
+			got, err := c.Read(context.Background(), parameters, "")
+			if err != nil {
+				t.Fatalf("\n%s\nc.Read(...): unexpected error: %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want, got.UnobservedFields); diff != "" {
+				t.Errorf("\n%s\nc.Read(...): -want unobserved, +got unobserved:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
 func TestCreate(t *testing.T) {
 	errBoom := errors.New("boom")
 
@@ -661,7 +990,7 @@ func TestCreate(t *testing.T) {
 				db: fake.MockDB{
 					MockExecContext: func(ctx context.Context, query string, args ...any) (sql.Result, error) {
 						// First call (CREATE USER) succeeds, second call (GRANT) fails
-						if query == "CREATE USER PRIV_ERROR_USER" {
+						if query == `CREATE USER "PRIV_ERROR_USER"` {
 							return nil, nil
 						}
 						return nil, errBoom
@@ -675,7 +1004,7 @@ func TestCreate(t *testing.T) {
 				},
 			},
 			want: want{
-				err: fmt.Errorf(errGrantPrivileges, errBoom),
+				err: fmt.Errorf(errGrantPrivileges, &privilege.GrantError{Failures: []privilege.GrantFailure{{Privilege: "SELECT", Err: errBoom}}}),
 			},
 		},
 		"RoleGrantError": {
@@ -684,7 +1013,7 @@ func TestCreate(t *testing.T) {
 				db: fake.MockDB{
 					MockExecContext: func(ctx context.Context, query string, args ...any) (sql.Result, error) {
 						// First call (CREATE USER) succeeds, second call (GRANT ROLE) fails
-						if query == "CREATE USER ROLE_ERROR_USER" {
+						if query == `CREATE USER "ROLE_ERROR_USER"` {
 							return nil, nil
 						}
 						return nil, errBoom
@@ -819,6 +1148,71 @@ func TestDelete(t *testing.T) {
 				err: nil,
 			},
 		},
+		"TerminateSessions": {
+			reason: "Should deactivate the user and disconnect its sessions before dropping it",
+			fields: fields{
+				db: func() fake.MockDB {
+					expected := []string{
+						`ALTER USER "SESSION_USER" DEACTIVATE USER NOW`,
+						`ALTER SYSTEM DISCONNECT SESSION '200123'`,
+						`ALTER SYSTEM DISCONNECT SESSION '200456'`,
+						`DROP USER "SESSION_USER"`,
+					}
+					return fake.MockDB{
+						MockExecContext: func(ctx context.Context, query string, args ...any) (sql.Result, error) {
+							if len(expected) == 0 || query != expected[0] {
+								return nil, fmt.Errorf("unexpected query: %s", query)
+							}
+							expected = expected[1:]
+							return nil, nil
+						},
+						MockQueryContext: func(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+							if !strings.Contains(query, "M_CONNECTIONS") || args[0] != "SESSION_USER" {
+								return nil, fmt.Errorf("unexpected query: %s", query)
+							}
+							return fake.MockRowsToSQLRows(sqlmock.NewRows([]string{"CONNECTION_ID"}).AddRow("200123").AddRow("200456")), nil
+						},
+					}
+				}(),
+			},
+			args: args{
+				parameters: &v1alpha1.UserParameters{
+					Username:                  "SESSION_USER",
+					TerminateSessionsOnDelete: true,
+				},
+			},
+			want: want{
+				err: nil,
+			},
+		},
+		"ErrDisconnectSession": {
+			reason: "Should not drop the user if a session cannot be disconnected",
+			fields: fields{
+				db: fake.MockDB{
+					MockExecContext: func(ctx context.Context, query string, args ...any) (sql.Result, error) {
+						if strings.Contains(query, "DISCONNECT SESSION") {
+							return nil, errBoom
+						}
+						if strings.HasPrefix(query, "DROP USER") {
+							return nil, errors.New("user should not be dropped")
+						}
+						return nil, nil
+					},
+					MockQueryContext: func(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+						return fake.MockRowsToSQLRows(sqlmock.NewRows([]string{"CONNECTION_ID"}).AddRow("200123")), nil
+					},
+				},
+			},
+			args: args{
+				parameters: &v1alpha1.UserParameters{
+					Username:                  "SESSION_USER",
+					TerminateSessionsOnDelete: true,
+				},
+			},
+			want: want{
+				err: fmt.Errorf("cannot disconnect session 200123: %w", errBoom),
+			},
+		},
 		"NonExistentUser": {
 			reason: "Should handle deletion of non-existent user gracefully",
 			fields: fields{
@@ -934,7 +1328,7 @@ func TestUpdatePasswordLifetimeCheck(t *testing.T) {
 			fields: fields{
 				db: fake.MockDB{
 					MockExecContext: func(ctx context.Context, query string, args ...any) (sql.Result, error) {
-						expectedQuery := "ALTER USER DEMO_USER ENABLE PASSWORD LIFETIME"
+						expectedQuery := "ALTER USER \"DEMO_USER\" ENABLE PASSWORD LIFETIME"
 						if query != expectedQuery {
 							return nil, errors.New("unexpected query")
 						}
@@ -955,7 +1349,7 @@ func TestUpdatePasswordLifetimeCheck(t *testing.T) {
 			fields: fields{
 				db: fake.MockDB{
 					MockExecContext: func(ctx context.Context, query string, args ...any) (sql.Result, error) {
-						expectedQuery := "ALTER USER DEMO_USER DISABLE PASSWORD LIFETIME"
+						expectedQuery := "ALTER USER \"DEMO_USER\" DISABLE PASSWORD LIFETIME"
 						if query != expectedQuery {
 							return nil, errors.New("unexpected query")
 						}
@@ -984,6 +1378,395 @@ func TestUpdatePasswordLifetimeCheck(t *testing.T) {
 	}
 }
 
+// syntaxError mimics the HANA error for statements it cannot parse.
+type syntaxError struct{}
+
+func (syntaxError) Error() string   { return "SQL Error 257 - sql syntax error" }
+func (syntaxError) StmtNo() int     { return 0 }
+func (syntaxError) Code() int       { return errCodeSQLSyntax }
+func (syntaxError) Position() int   { return 0 }
+func (syntaxError) Level() int      { return 1 }
+func (syntaxError) Text() string    { return "sql syntax error" }
+func (syntaxError) IsWarning() bool { return false }
+func (syntaxError) IsError() bool   { return true }
+func (syntaxError) IsFatal() bool   { return false }
+
+func TestUpdateUsergroup(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	// execs returns a database that expects the statements in order and
+	// fails those mapped to an error.
+	execs := func(expected []string, errs map[string]error) fake.MockDB {
+		return fake.MockDB{
+			MockExecContext: func(ctx context.Context, query string, args ...any) (sql.Result, error) {
+				if len(expected) == 0 || query != expected[0] {
+					return nil, fmt.Errorf("unexpected query: %s", query)
+				}
+				expected = expected[1:]
+				return nil, errs[query]
+			},
+		}
+	}
+
+	cases := map[string]struct {
+		reason    string
+		db        fake.MockDB
+		version   string
+		usergroup string
+		want      error
+	}{
+		"SetUsergroup": {
+			reason:    "A named usergroup should be set",
+			db:        execs([]string{`ALTER USER "DEMO_USER" SET USERGROUP "SALES"`}, nil),
+			usergroup: "SALES",
+		},
+		"SetDefault": {
+			reason:    "DEFAULT should move the user into the default usergroup",
+			db:        execs([]string{`ALTER USER "DEMO_USER" SET USERGROUP DEFAULT`}, nil),
+			usergroup: "DEFAULT",
+		},
+		"EmptyIsDefault": {
+			reason:    "An empty usergroup should move the user into the default usergroup",
+			db:        execs([]string{`ALTER USER "DEMO_USER" SET USERGROUP DEFAULT`}, nil),
+			usergroup: "",
+		},
+		"UnsetUsergroup": {
+			reason: "Versions without SET USERGROUP DEFAULT should unset the usergroup instead",
+			db: execs([]string{`ALTER USER "DEMO_USER" SET USERGROUP DEFAULT`, `ALTER USER "DEMO_USER" UNSET USERGROUP`},
+				map[string]error{`ALTER USER "DEMO_USER" SET USERGROUP DEFAULT`: syntaxError{}}),
+			usergroup: "DEFAULT",
+		},
+		"ErrSetDefault": {
+			reason: "Errors other than syntax errors should be returned without unsetting the usergroup",
+			db: execs([]string{`ALTER USER "DEMO_USER" SET USERGROUP DEFAULT`},
+				map[string]error{`ALTER USER "DEMO_USER" SET USERGROUP DEFAULT`: errBoom}),
+			usergroup: "DEFAULT",
+			want:      fmt.Errorf(ErrUpdateUserUsergroup, errBoom),
+		},
+		"UnsetUsergroupHANA2": {
+			reason:    "HANA 2.0 should unset the usergroup without trying SET USERGROUP DEFAULT",
+			db:        execs([]string{`ALTER USER "DEMO_USER" UNSET USERGROUP`}, nil),
+			version:   "2.00.059.00.1636466430",
+			usergroup: "DEFAULT",
+		},
+		"NoUsergroups": {
+			reason:    "Versions without usergroups should not move the user into the default usergroup",
+			db:        execs(nil, nil),
+			version:   "2.00.024.00.1520000000",
+			usergroup: "DEFAULT",
+		},
+		"ErrNoUsergroups": {
+			reason:    "Setting a usergroup on versions without usergroups should fail without a statement",
+			db:        execs(nil, nil),
+			version:   "2.00.024.00.1520000000",
+			usergroup: "SALES",
+			want:      fmt.Errorf(ErrUpdateUserUsergroup, statements.NewDialect("2.00.024.00.1520000000").Require(statements.Usergroups)),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			c := Client{DB: tc.db, dialect: statements.NewDialect(tc.version)}
+			err := c.UpdateUsergroup(context.Background(), "DEMO_USER", tc.usergroup)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nc.UpdateUsergroup(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpdateClientConnect(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		db fake.MockDB
+	}
+
+	type args struct {
+		ctx      context.Context
+		username string
+		enabled  bool
+	}
+
+	type want struct {
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrUpdateClientConnect": {
+			reason: "Any errors encountered while updating client connect should be returned",
+			fields: fields{
+				db: fake.MockDB{
+					MockExecContext: func(ctx context.Context, query string, args ...any) (sql.Result, error) {
+						return nil, errBoom
+					},
+				},
+			},
+			args: args{
+				username: "DEMO_USER",
+				enabled:  true,
+			},
+			want: want{
+				err: fmt.Errorf(ErrUpdateUserClientConnect, errBoom),
+			},
+		},
+		"SuccessEnable": {
+			reason: "No error should be returned when we successfully enable client connect",
+			fields: fields{
+				db: fake.MockDB{
+					MockExecContext: func(ctx context.Context, query string, args ...any) (sql.Result, error) {
+						if query != `ALTER USER "DEMO_USER" ENABLE CLIENT CONNECT` {
+							return nil, errors.New("unexpected query")
+						}
+						return nil, nil
+					},
+				},
+			},
+			args: args{
+				username: "DEMO_USER",
+				enabled:  true,
+			},
+		},
+		"SuccessDisable": {
+			reason: "No error should be returned when we successfully disable client connect",
+			fields: fields{
+				db: fake.MockDB{
+					MockExecContext: func(ctx context.Context, query string, args ...any) (sql.Result, error) {
+						if query != `ALTER USER "DEMO_USER" DISABLE CLIENT CONNECT` {
+							return nil, errors.New("unexpected query")
+						}
+						return nil, nil
+					},
+				},
+			},
+			args: args{
+				username: "DEMO_USER",
+				enabled:  false,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			c := Client{DB: tc.fields.db}
+			err := c.UpdateClientConnect(tc.args.ctx, tc.args.username, tc.args.enabled)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nc.UpdateClientConnect(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpdateValidity(t *testing.T) {
+	errBoom := errors.New("boom")
+	until := metav1.NewTime(time.Date(2027, 3, 31, 23, 59, 59, 0, time.UTC))
+	from := metav1.NewTime(time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	type fields struct {
+		db fake.MockDB
+	}
+
+	type args struct {
+		ctx          context.Context
+		username     string
+		restrictions *v1alpha1.ConnectionRestrictions
+	}
+
+	type want struct {
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrUpdateValidity": {
+			reason: "Any errors encountered while updating the validity should be returned",
+			fields: fields{
+				db: fake.MockDB{
+					MockExecContext: func(ctx context.Context, query string, args ...any) (sql.Result, error) {
+						return nil, errBoom
+					},
+				},
+			},
+			args: args{
+				username:     "DEMO_USER",
+				restrictions: &v1alpha1.ConnectionRestrictions{ValidUntil: &until},
+			},
+			want: want{
+				err: fmt.Errorf(ErrUpdateUserValidity, errBoom),
+			},
+		},
+		"SuccessValidUntil": {
+			reason: "An unset start of the validity period should default to now",
+			fields: fields{
+				db: fake.MockDB{
+					MockExecContext: func(ctx context.Context, query string, args ...any) (sql.Result, error) {
+						if query != `ALTER USER "DEMO_USER" VALID FROM NOW UNTIL '2027-03-31 23:59:59'` {
+							return nil, errors.New("unexpected query")
+						}
+						return nil, nil
+					},
+				},
+			},
+			args: args{
+				username:     "DEMO_USER",
+				restrictions: &v1alpha1.ConnectionRestrictions{ValidUntil: &until},
+			},
+		},
+		"SuccessValidFrom": {
+			reason: "An unset end of the validity period should default to forever",
+			fields: fields{
+				db: fake.MockDB{
+					MockExecContext: func(ctx context.Context, query string, args ...any) (sql.Result, error) {
+						if query != `ALTER USER "DEMO_USER" VALID FROM '2027-01-01 00:00:00' UNTIL FOREVER` {
+							return nil, errors.New("unexpected query")
+						}
+						return nil, nil
+					},
+				},
+			},
+			args: args{
+				username:     "DEMO_USER",
+				restrictions: &v1alpha1.ConnectionRestrictions{ValidFrom: &from},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			c := Client{DB: tc.fields.db}
+			err := c.UpdateValidity(tc.args.ctx, tc.args.username, tc.args.restrictions)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nc.UpdateValidity(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpdateResourceTag(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	cases := map[string]struct {
+		reason string
+		db     fake.MockDB
+		want   error
+	}{
+		"ErrUpdateResourceTag": {
+			reason: "Any errors encountered while tagging the user should be returned",
+			db: fake.MockDB{
+				MockExecContext: func(ctx context.Context, query string, args ...any) (sql.Result, error) {
+					return nil, errBoom
+				},
+			},
+			want: fmt.Errorf(ErrUpdateUserResourceTag, errBoom),
+		},
+		"Success": {
+			reason: "The user should be tagged with the name of its User",
+			db: fake.MockDB{
+				MockExecContext: func(ctx context.Context, query string, args ...any) (sql.Result, error) {
+					if query != `ALTER USER "DEMO_USER" SET PARAMETER CROSSPLANE_RESOURCE = 'demo-user'` {
+						return nil, errors.New("unexpected query")
+					}
+					return nil, nil
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			c := Client{DB: tc.db}
+			err := c.UpdateResourceTag(context.Background(), "DEMO_USER", "demo-user")
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nc.UpdateResourceTag(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpdateRoles(t *testing.T) {
+	cases := map[string]struct {
+		reason      string
+		toGrant     []string
+		toRevoke    []string
+		unrevokable []string
+		want        []string
+		wantErr     error
+	}{
+		"AddAdminOption": {
+			reason:   "Adding the admin option should grant the role again without revoking it",
+			toGrant:  []string{`"R" WITH ADMIN OPTION`},
+			toRevoke: []string{`"R"`},
+			want:     []string{`GRANT "R" TO "DEMO_USER" WITH ADMIN OPTION`},
+		},
+		"RemoveAdminOption": {
+			reason:   "Removing the admin option should regrant the role right after revoking it",
+			toGrant:  []string{`"R"`, `"S"`},
+			toRevoke: []string{`"R" WITH ADMIN OPTION`, `"T"`},
+			want: []string{
+				`GRANT "S" TO "DEMO_USER"`,
+				`REVOKE "T" FROM "DEMO_USER"`,
+				`REVOKE "R" FROM "DEMO_USER"`,
+				`GRANT "R" TO "DEMO_USER"`,
+			},
+		},
+		"RevokeNotPermitted": {
+			reason:      "Roles that cannot be revoked should be reported while the other roles are still revoked",
+			toGrant:     []string{`"S"`},
+			toRevoke:    []string{`"T"`, `"hdi::access"`},
+			unrevokable: []string{`"hdi::access"`},
+			want: []string{
+				`GRANT "S" TO "DEMO_USER"`,
+				`REVOKE "T", "hdi::access" FROM "DEMO_USER"`,
+				`REVOKE "T" FROM "DEMO_USER"`,
+				`REVOKE "hdi::access" FROM "DEMO_USER"`,
+			},
+			wantErr: &UnrevokableRolesError{Roles: []string{`"hdi::access"`}},
+		},
+		"RemoveAdminOptionNotPermitted": {
+			reason:      "A role that cannot lose its admin option should be reported and not be granted again",
+			toGrant:     []string{`"hdi::access"`},
+			toRevoke:    []string{`"hdi::access" WITH ADMIN OPTION`},
+			unrevokable: []string{`"hdi::access"`},
+			want:        []string{`REVOKE "hdi::access" FROM "DEMO_USER"`},
+			wantErr:     &UnrevokableRolesError{Roles: []string{`"hdi::access"`}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var got []string
+			db := fake.MockDB{
+				MockExecContext: func(ctx context.Context, query string, args ...any) (sql.Result, error) {
+					got = append(got, query)
+					for _, role := range tc.unrevokable {
+						if strings.HasPrefix(query, "REVOKE") && strings.Contains(query, role) {
+							return nil, insufficientPrivilegeError{}
+						}
+					}
+					return nil, nil
+				},
+			}
+			c := New(db, "ADMIN")
+			err := c.UpdateRoles(context.Background(), "DEMO_USER", tc.toGrant, tc.toRevoke)
+			if diff := cmp.Diff(tc.wantErr, err); diff != "" {
+				t.Errorf("\n%s\nc.UpdateRoles(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nc.UpdateRoles(...): -want queries, +got queries:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
 func TestUpdateX509Providers(t *testing.T) {
 	errBoom := errors.New("boom")
 
@@ -1057,7 +1840,7 @@ func TestUpdateX509Providers(t *testing.T) {
 			fields: fields{
 				db: fake.MockDB{
 					MockExecContext: func(ctx context.Context, query string, args ...any) (sql.Result, error) {
-						expectedQuery := "ALTER USER TEST_USER ADD IDENTITY 'CN=Test User,O=Acme Corp' FOR X509 PROVIDER TEST_PROVIDER"
+						expectedQuery := "ALTER USER \"TEST_USER\" ADD IDENTITY 'CN=Test User,O=Acme Corp' FOR X509 PROVIDER \"TEST_PROVIDER\""
 						if query != expectedQuery {
 							return nil, fmt.Errorf("unexpected query: got %s, want %s", query, expectedQuery)
 						}
@@ -1080,7 +1863,7 @@ func TestUpdateX509Providers(t *testing.T) {
 			fields: fields{
 				db: fake.MockDB{
 					MockExecContext: func(ctx context.Context, query string, args ...any) (sql.Result, error) {
-						expectedQuery := "ALTER USER TEST_USER DROP IDENTITY 'CN=Old User' FOR X509 PROVIDER OLD_PROVIDER"
+						expectedQuery := "ALTER USER \"TEST_USER\" DROP IDENTITY 'CN=Old User' FOR X509 PROVIDER \"OLD_PROVIDER\""
 						if query != expectedQuery {
 							return nil, fmt.Errorf("unexpected query: got %s, want %s", query, expectedQuery)
 						}
@@ -1195,7 +1978,7 @@ func TestUpdateX509Providers(t *testing.T) {
 			fields: fields{
 				db: fake.MockDB{
 					MockExecContext: func(ctx context.Context, query string, args ...any) (sql.Result, error) {
-						expectedQuery := "ALTER USER ANY_USER ADD IDENTITY 'ANY' FOR X509 PROVIDER ANY_PROVIDER"
+						expectedQuery := "ALTER USER \"ANY_USER\" ADD IDENTITY 'ANY' FOR X509 PROVIDER \"ANY_PROVIDER\""
 						if query != expectedQuery {
 							return nil, fmt.Errorf("unexpected query: got %s, want %s", query, expectedQuery)
 						}
@@ -1271,7 +2054,7 @@ func TestTogglePasswordAuthentication(t *testing.T) {
 			fields: fields{
 				db: fake.MockDB{
 					MockExecContext: func(ctx context.Context, query string, args ...any) (sql.Result, error) {
-						expectedQuery := "ALTER USER TEST_USER DISABLE PASSWORD"
+						expectedQuery := "ALTER USER \"TEST_USER\" DISABLE PASSWORD"
 						if query != expectedQuery {
 							return nil, fmt.Errorf("unexpected query: got %s, want %s", query, expectedQuery)
 						}
@@ -1292,7 +2075,7 @@ func TestTogglePasswordAuthentication(t *testing.T) {
 			fields: fields{
 				db: fake.MockDB{
 					MockExecContext: func(ctx context.Context, query string, args ...any) (sql.Result, error) {
-						expectedQuery := "ALTER USER TEST_USER ENABLE PASSWORD"
+						expectedQuery := "ALTER USER \"TEST_USER\" ENABLE PASSWORD"
 						if query != expectedQuery {
 							return nil, fmt.Errorf("unexpected query: got %s, want %s", query, expectedQuery)
 						}
@@ -1313,7 +2096,7 @@ func TestTogglePasswordAuthentication(t *testing.T) {
 			fields: fields{
 				db: fake.MockDB{
 					MockExecContext: func(ctx context.Context, query string, args ...any) (sql.Result, error) {
-						expectedQuery := "ALTER USER COMPLEX_USER_NAME DISABLE PASSWORD"
+						expectedQuery := "ALTER USER \"COMPLEX_USER_NAME\" DISABLE PASSWORD"
 						if query != expectedQuery {
 							return nil, fmt.Errorf("unexpected query: got %s, want %s", query, expectedQuery)
 						}
@@ -1341,3 +2124,21 @@ func TestTogglePasswordAuthentication(t *testing.T) {
 		})
 	}
 }
+
+func TestGrantedPrivileges(t *testing.T) {
+	grants := []privilege.PrivilegeGrant{
+		{Privilege: "CATALOG READ", Grantor: "CROSSPLANE"},
+		{Privilege: "CATALOG READ", Grantor: "ADMIN"},
+		{Privilege: `CREATE ANY ON SCHEMA "APP"`, Grantor: "SYS"},
+	}
+
+	c := Client{username: "CROSSPLANE"}
+	privileges, foreign := c.grantedPrivileges(grants)
+	if diff := cmp.Diff([]string{"CATALOG READ", `CREATE ANY ON SCHEMA "APP"`}, privileges); diff != "" {
+		t.Errorf("grantedPrivileges(...): each privilege should be reported once, -want, +got:\n%s", diff)
+	}
+	want := []v1alpha1.PrivilegeGrantObservation{{Privilege: "CATALOG READ", Grantor: "ADMIN"}}
+	if diff := cmp.Diff(want, foreign); diff != "" {
+		t.Errorf("grantedPrivileges(...): only grants of other users than the technical user and SYS should be foreign, -want, +got:\n%s", diff)
+	}
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/SAP/crossplane-provider-hana/apis/admin/v1alpha1"
 	"github.com/SAP/crossplane-provider-hana/internal/clients/hana"
+	"github.com/SAP/crossplane-provider-hana/internal/clients/hana/statements"
 	"github.com/SAP/crossplane-provider-hana/internal/clients/xsql"
 	"github.com/SAP/crossplane-provider-hana/internal/utils"
 )
@@ -21,12 +22,14 @@ type UsergroupClient interface {
 // Client struct holds the connection to the db
 type Client struct {
 	xsql.DB
+	dialect statements.Dialect
 }
 
 // New creates a new db client
 func New(db xsql.DB) Client {
 	return Client{
-		DB: db,
+		DB:      db,
+		dialect: statements.NewDialect(hana.ServerVersion(db)),
 	}
 }
 
@@ -41,7 +44,7 @@ func (c Client) Read(ctx context.Context, parameters *v1alpha1.UsergroupParamete
 
 	var disableUserAdminString string
 	query := "SELECT USERGROUP_NAME, IS_USER_ADMIN_ENABLED FROM SYS.USERGROUPS WHERE USERGROUP_NAME = ?"
-	if err := c.QueryRowContext(ctx, query, parameters.UsergroupName).Scan(&observed.UsergroupName, &disableUserAdminString); xsql.IsNoRows(err) {
+	if err := xsql.ScanRow(ctx, c.QueryRowContext(ctx, query, parameters.UsergroupName), &observed.UsergroupName, &disableUserAdminString); xsql.IsNoRows(err) {
 		return observed, nil
 	} else if err != nil {
 		return observed, err
@@ -70,6 +73,9 @@ func (c Client) Read(ctx context.Context, parameters *v1alpha1.UsergroupParamete
 
 // Create creates a usergroup
 func (c Client) Create(ctx context.Context, parameters *v1alpha1.UsergroupParameters) error {
+	if err := c.dialect.Require(statements.Usergroups); err != nil {
+		return err
+	}
 
 	query := fmt.Sprintf(`CREATE USERGROUP "%s"`, utils.EscapeDoubleQuotes(parameters.UsergroupName))
 
@@ -0,0 +1,33 @@
+/*
+Copyright 2026 SAP SE or an SAP affiliate company and contributors.
+*/
+
+package hana
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/SAP/crossplane-provider-hana/internal/clients/xsql"
+)
+
+const versionQuery = "SELECT VERSION FROM SYS.M_DATABASE"
+
+// queryVersion returns the version of the server db is connected to, or an
+// empty string if it cannot be read.
+func queryVersion(ctx context.Context, db *sql.DB) string {
+	var version string
+	if err := db.QueryRowContext(ctx, versionQuery).Scan(&version); err != nil {
+		return ""
+	}
+	return version
+}
+
+// ServerVersion returns the version of the server db is connected to, e.g.
+// 2.00.059.00.1636466430, or an empty string if it is not known.
+func ServerVersion(db xsql.DB) string {
+	if edb, ok := asEndpointDB(db); ok {
+		return edb.version
+	}
+	return ""
+}
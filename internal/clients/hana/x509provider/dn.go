@@ -0,0 +1,246 @@
+/*
+Copyright 2026 SAP SE or an SAP affiliate company and contributors.
+*/
+
+package x509provider
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+const (
+	errDNEmpty         = "distinguished name is empty"
+	errDNAttributeType = "invalid attribute type %q at position %d"
+	errDNMissingEquals = "missing '=' after attribute type %q at position %d"
+	errDNUnexpected    = "unexpected %q at position %d"
+	errDNEscape        = "invalid escape sequence at position %d"
+	errDNQuote         = "unterminated quoted value at position %d"
+	errDNHexString     = "invalid hex string at position %d"
+	errDNTrailing      = "missing attribute after separator at position %d"
+)
+
+// specials must be escaped in unquoted attribute values, see RFC 2253,
+// section 3.
+const specials = ",=+<>#;"
+
+// An attribute is an attribute type and value pair of a distinguished name.
+type attribute struct {
+	typ   string
+	value string
+}
+
+func (a attribute) String() string {
+	return a.typ + "=" + a.value
+}
+
+// NormalizeIssuer parses issuer as an RFC 2253 distinguished name and returns
+// it in a canonical form: attribute types are uppercased, the attributes of
+// multi-valued RDNs are sorted by type and RDNs are separated by ", ". The
+// order of the RDNs and the attribute values are kept as written.
+func NormalizeIssuer(issuer string) (string, error) {
+	rdns, err := parseDN(issuer)
+	if err != nil {
+		return "", err
+	}
+	parts := make([]string, len(rdns))
+	for i, rdn := range rdns {
+		slices.SortStableFunc(rdn, func(a, b attribute) int { return strings.Compare(a.typ, b.typ) })
+		attributes := make([]string, len(rdn))
+		for j, a := range rdn {
+			attributes[j] = a.String()
+		}
+		parts[i] = strings.Join(attributes, "+")
+	}
+	return strings.Join(parts, ", "), nil
+}
+
+// parseDN parses s into its RDNs. Spaces around separators are accepted, as
+// RFC 2253 recommends, and ";" is accepted as RDN separator.
+func parseDN(s string) ([][]attribute, error) {
+	p := dnParser{s: s}
+	p.skipSpaces()
+	if p.done() {
+		return nil, errors.New(errDNEmpty)
+	}
+
+	rdns := [][]attribute{{}}
+	for {
+		a, err := p.attribute()
+		if err != nil {
+			return nil, err
+		}
+		rdns[len(rdns)-1] = append(rdns[len(rdns)-1], a)
+
+		if p.done() {
+			return rdns, nil
+		}
+		switch c := p.s[p.i]; c {
+		case '+':
+		case ',', ';':
+			rdns = append(rdns, []attribute{})
+		default:
+			return nil, fmt.Errorf(errDNUnexpected, c, p.i)
+		}
+		p.i++
+		p.skipSpaces()
+		if p.done() {
+			return nil, fmt.Errorf(errDNTrailing, p.i)
+		}
+	}
+}
+
+type dnParser struct {
+	s string
+	i int
+}
+
+func (p *dnParser) done() bool {
+	return p.i >= len(p.s)
+}
+
+func (p *dnParser) skipSpaces() {
+	for !p.done() && p.s[p.i] == ' ' {
+		p.i++
+	}
+}
+
+// attribute parses an attribute type and value and the spaces following it.
+func (p *dnParser) attribute() (attribute, error) {
+	start := p.i
+	for !p.done() && p.s[p.i] != '=' && p.s[p.i] != ' ' {
+		p.i++
+	}
+	typ := p.s[start:p.i]
+	if !isAttributeType(typ) {
+		return attribute{}, fmt.Errorf(errDNAttributeType, typ, start)
+	}
+	p.skipSpaces()
+	if p.done() || p.s[p.i] != '=' {
+		return attribute{}, fmt.Errorf(errDNMissingEquals, typ, start)
+	}
+	p.i++
+	p.skipSpaces()
+
+	value, err := p.value()
+	if err != nil {
+		return attribute{}, err
+	}
+	p.skipSpaces()
+
+	if strings.HasPrefix(strings.ToUpper(typ), "OID.") {
+		typ = typ[len("OID."):]
+	}
+	return attribute{typ: strings.ToUpper(typ), value: value}, nil
+}
+
+// value parses a quoted, hex or plain attribute value. Escape sequences are
+// validated but kept as written.
+func (p *dnParser) value() (string, error) {
+	start := p.i
+	switch {
+	case p.done():
+		return "", nil
+	case p.s[p.i] == '"':
+		p.i++
+		for !p.done() && p.s[p.i] != '"' {
+			if err := p.char(); err != nil {
+				return "", err
+			}
+		}
+		if p.done() {
+			return "", fmt.Errorf(errDNQuote, start)
+		}
+		p.i++
+		return p.s[start:p.i], nil
+	case p.s[p.i] == '#':
+		p.i++
+		for !p.done() && isHex(p.s[p.i]) {
+			p.i++
+		}
+		if n := p.i - start - 1; n == 0 || n%2 != 0 {
+			return "", fmt.Errorf(errDNHexString, start)
+		}
+		return p.s[start:p.i], nil
+	}
+
+	// Unescaped trailing spaces are not part of the value.
+	end := p.i
+	for !p.done() && !strings.ContainsRune(",+;", rune(p.s[p.i])) {
+		c := p.s[p.i]
+		if c == '"' || strings.IndexByte(specials, c) >= 0 {
+			return "", fmt.Errorf(errDNUnexpected, c, p.i)
+		}
+		if err := p.char(); err != nil {
+			return "", err
+		}
+		if c != ' ' {
+			end = p.i
+		}
+	}
+	return p.s[start:end], nil
+}
+
+// char consumes a single character or escape sequence of a value.
+func (p *dnParser) char() error {
+	if p.s[p.i] != '\\' {
+		p.i++
+		return nil
+	}
+	if p.i+1 >= len(p.s) {
+		return fmt.Errorf(errDNEscape, p.i)
+	}
+	switch c := p.s[p.i+1]; {
+	case strings.IndexByte(specials, c) >= 0, c == '\\', c == '"', c == ' ':
+		p.i += 2
+	case p.i+2 < len(p.s) && isHex(c) && isHex(p.s[p.i+2]):
+		p.i += 3
+	default:
+		return fmt.Errorf(errDNEscape, p.i)
+	}
+	return nil
+}
+
+// isAttributeType reports whether s is a keyword, e.g. CN, or an OID, e.g.
+// 2.5.4.3, optionally prefixed with "OID.".
+func isAttributeType(s string) bool {
+	if s == "" {
+		return false
+	}
+	if strings.HasPrefix(strings.ToUpper(s), "OID.") {
+		return isOID(s[len("OID."):])
+	}
+	if s[0] >= '0' && s[0] <= '9' {
+		return isOID(s)
+	}
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if !isAlpha(c) && (i == 0 || (!isDigit(c) && c != '-')) {
+			return false
+		}
+	}
+	return true
+}
+
+func isOID(s string) bool {
+	for part := range strings.SplitSeq(s, ".") {
+		if part == "" || strings.IndexFunc(part, func(r rune) bool { return r < '0' || r > '9' }) >= 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func isAlpha(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isHex(c byte) bool {
+	return isDigit(c) || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F')
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2026 SAP SE or an SAP affiliate company and contributors.
+*/
+
+package x509provider
+
+import (
+	"testing"
+)
+
+func TestNormalizeIssuer(t *testing.T) {
+	cases := map[string]struct {
+		reason  string
+		issuer  string
+		want    string
+		wantErr bool
+	}{
+		"Canonical": {
+			reason: "A canonical DN should be kept as is",
+			issuer: "CN=SAP Cloud Root CA, O=SAP SE, L=Walldorf, C=DE",
+			want:   "CN=SAP Cloud Root CA, O=SAP SE, L=Walldorf, C=DE",
+		},
+		"Spacing": {
+			reason: "Spaces around separators should be normalized and the RDN order kept",
+			issuer: "  C = DE ;O=SAP SE,CN=Test CA  ",
+			want:   "C=DE, O=SAP SE, CN=Test CA",
+		},
+		"AttributeTypes": {
+			reason: "Attribute types should be uppercased and OID prefixes dropped",
+			issuer: "cn=Test,OID.2.5.4.10=SAP",
+			want:   "CN=Test, 2.5.4.10=SAP",
+		},
+		"MultiValued": {
+			reason: "The attributes of a multi-valued RDN should be sorted by type",
+			issuer: "OU=Sales+CN=J. Smith,O=Widget Inc.,C=US",
+			want:   "CN=J. Smith+OU=Sales, O=Widget Inc., C=US",
+		},
+		"Escaped": {
+			reason: "Escape sequences, including escaped trailing spaces, should be kept",
+			issuer: `CN=L. Eagle\, Ltd\ , O=Sue\2C Grabbit`,
+			want:   `CN=L. Eagle\, Ltd\ , O=Sue\2C Grabbit`,
+		},
+		"QuotedAndHex": {
+			reason: "Quoted and hex values should be accepted",
+			issuer: `CN="Test, CA",1.3.6.1.4.1.1466.0=#04024869`,
+			want:   `CN="Test, CA", 1.3.6.1.4.1.1466.0=#04024869`,
+		},
+		"Empty": {
+			reason:  "An empty DN should be rejected",
+			issuer:  "   ",
+			wantErr: true,
+		},
+		"NoAttributeType": {
+			reason:  "A value without attribute type should be rejected",
+			issuer:  "SAP Cloud Root CA",
+			wantErr: true,
+		},
+		"InvalidAttributeType": {
+			reason:  "An attribute type with invalid characters should be rejected",
+			issuer:  "C_N=Test",
+			wantErr: true,
+		},
+		"TrailingSeparator": {
+			reason:  "A separator without a following RDN should be rejected",
+			issuer:  "CN=Test,",
+			wantErr: true,
+		},
+		"UnescapedSpecial": {
+			reason:  "An unescaped special character should be rejected",
+			issuer:  "CN=a=b",
+			wantErr: true,
+		},
+		"InvalidEscape": {
+			reason:  "An invalid escape sequence should be rejected",
+			issuer:  `CN=Test\q`,
+			wantErr: true,
+		},
+		"UnterminatedQuote": {
+			reason:  "An unterminated quoted value should be rejected",
+			issuer:  `CN="Test`,
+			wantErr: true,
+		},
+		"OddHexString": {
+			reason:  "A hex value of odd length should be rejected",
+			issuer:  "CN=#123",
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := NormalizeIssuer(tc.issuer)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("\n%s\nNormalizeIssuer(%q): error = %v, wantErr %v", tc.reason, tc.issuer, err, tc.wantErr)
+			}
+			if got != tc.want {
+				t.Errorf("\n%s\nNormalizeIssuer(%q) = %q, want %q", tc.reason, tc.issuer, got, tc.want)
+			}
+		})
+	}
+}
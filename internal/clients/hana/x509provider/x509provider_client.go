@@ -2,12 +2,12 @@ package x509provider
 
 import (
 	"context"
-	"fmt"
+	"database/sql"
 	"slices"
-	"strings"
 
 	"github.com/SAP/crossplane-provider-hana/apis/admin/v1alpha1"
 	"github.com/SAP/crossplane-provider-hana/internal/clients/hana"
+	"github.com/SAP/crossplane-provider-hana/internal/clients/hana/statements"
 	"github.com/SAP/crossplane-provider-hana/internal/clients/xsql"
 )
 
@@ -20,12 +20,14 @@ type X509ProviderClient interface {
 // Client struct holds the connection to the db
 type Client struct {
 	xsql.DB
+	dialect statements.Dialect
 }
 
 // New creates a new db client
 func New(db xsql.DB) Client {
 	return Client{
-		DB: db,
+		DB:      db,
+		dialect: statements.NewDialect(hana.ServerVersion(db)),
 	}
 }
 
@@ -33,7 +35,7 @@ func (c Client) Read(ctx context.Context, parameters *v1alpha1.X509ProviderParam
 	observation := &v1alpha1.X509ProviderObservation{}
 
 	issuerCh := make(chan error, 1)
-	go c.readIssuer(ctx, parameters.Name, observation, issuerCh)
+	go c.readProvider(ctx, parameters.Name, observation, issuerCh)
 
 	matchingRulesCh := make(chan error, 1)
 	go c.readMatchingRules(ctx, parameters.Name, observation, matchingRulesCh)
@@ -54,13 +56,10 @@ func (c Client) Read(ctx context.Context, parameters *v1alpha1.X509ProviderParam
 }
 
 func (c Client) Create(ctx context.Context, parameters *v1alpha1.X509ProviderParameters) error {
-	query := fmt.Sprintf(
-		"CREATE X509 PROVIDER %s WITH ISSUER '%s'",
-		parameters.Name,
-		parameters.Issuer,
-	)
-
-	_, err := c.ExecContext(ctx, query)
+	if err := c.dialect.Require(statements.X509Providers); err != nil {
+		return err
+	}
+	_, err := c.ExecContext(ctx, statements.CreateX509Provider(parameters.Name, parameters.Issuer))
 
 	return err
 }
@@ -91,19 +90,27 @@ func (c Client) Update(ctx context.Context, parameters *v1alpha1.X509ProviderPar
 	}
 	observation.MatchingRules = parameters.MatchingRules
 
+	// The priority is left as it is unless it is set
+	if parameters.Priority != nil && (observation.Priority == nil || *observation.Priority != *parameters.Priority) {
+		if _, err := c.ExecContext(ctx, statements.AlterX509Provider(parameters.Name).SetPriority(*parameters.Priority)); err != nil {
+			return err
+		}
+		observation.Priority = parameters.Priority
+	}
+
 	return nil
 }
 
 func (c Client) Delete(ctx context.Context, parameters *v1alpha1.X509ProviderParameters) error {
-	query := fmt.Sprintf("DROP X509 PROVIDER %s", parameters.Name)
-	_, err := c.ExecContext(ctx, query)
+	_, err := c.ExecContext(ctx, statements.DropX509Provider(parameters.Name))
 	return err
 }
 
-func (c Client) readIssuer(ctx context.Context, name string, observation *v1alpha1.X509ProviderObservation, ch chan error) {
-	query := "SELECT ISSUER_NAME FROM X509_PROVIDERS WHERE X509_PROVIDER_NAME = ?"
+func (c Client) readProvider(ctx context.Context, name string, observation *v1alpha1.X509ProviderObservation, ch chan error) {
+	query := "SELECT ISSUER_NAME, PRIORITY FROM X509_PROVIDERS WHERE X509_PROVIDER_NAME = ?"
 	var issuer string
-	if err := c.QueryRowContext(ctx, query, name).Scan(&issuer); xsql.IsNoRows(err) {
+	var priority sql.NullInt64
+	if err := xsql.ScanRow(ctx, c.QueryRowContext(ctx, query, name), &issuer, &priority); xsql.IsNoRows(err) {
 		ch <- nil
 		return
 	} else if err != nil {
@@ -113,6 +120,9 @@ func (c Client) readIssuer(ctx context.Context, name string, observation *v1alph
 
 	observation.Name = &name
 	observation.Issuer = &issuer
+	if priority.Valid {
+		observation.Priority = new(int(priority.Int64))
+	}
 	ch <- nil
 }
 
@@ -143,24 +153,11 @@ func (c Client) readMatchingRules(ctx context.Context, name string, observation
 }
 
 func (c Client) updateIssuer(ctx context.Context, name, issuer string, ch chan error) {
-	query := fmt.Sprintf(
-		"ALTER X509 PROVIDER %s SET ISSUER '%s'",
-		name,
-		issuer,
-	)
-	_, err := c.ExecContext(ctx, query)
+	_, err := c.ExecContext(ctx, statements.AlterX509Provider(name).SetIssuer(issuer))
 	ch <- err
 }
 
 func (c Client) updateMatchingRules(ctx context.Context, name string, rules []string, ch chan error) {
-	var query string
-	if len(rules) == 0 {
-		query = fmt.Sprintf("ALTER X509 PROVIDER %s UNSET MATCHING RULES", name)
-	} else {
-		ruleString := strings.Join(rules, "', '")
-		query = fmt.Sprintf("ALTER X509 PROVIDER %s SET MATCHING RULES '%s'", name, ruleString)
-	}
-
-	_, err := c.ExecContext(ctx, query)
+	_, err := c.ExecContext(ctx, statements.AlterX509Provider(name).SetMatchingRules(rules))
 	ch <- err
 }
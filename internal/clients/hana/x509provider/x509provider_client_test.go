@@ -104,8 +104,8 @@ func TestRead(t *testing.T) {
 					MockQueryRowContext: func(ctx context.Context, query string, args ...any) *sql.Row {
 						// Mock issuer query
 						db, mock, _ := sqlmock.New()
-						rows := sqlmock.NewRows([]string{"ISSUER_NAME"}).
-							AddRow("CN=Test CA")
+						rows := sqlmock.NewRows([]string{"ISSUER_NAME", "PRIORITY"}).
+							AddRow("CN=Test CA", nil)
 						mock.ExpectQuery("SELECT").WillReturnRows(rows)
 						return db.QueryRowContext(context.Background(), "SELECT")
 					},
@@ -132,14 +132,14 @@ func TestRead(t *testing.T) {
 			},
 		},
 		"SuccessWithoutMatchingRules": {
-			reason: "Should successfully read X509Provider without matching rules",
+			reason: "Should successfully read X509Provider without matching rules, with its priority",
 			fields: fields{
 				db: fake.MockDB{
 					MockQueryRowContext: func(ctx context.Context, query string, args ...any) *sql.Row {
 						// Mock issuer query
 						db, mock, _ := sqlmock.New()
-						rows := sqlmock.NewRows([]string{"ISSUER_NAME"}).
-							AddRow("CN=Simple CA")
+						rows := sqlmock.NewRows([]string{"ISSUER_NAME", "PRIORITY"}).
+							AddRow("CN=Simple CA", 5)
 						mock.ExpectQuery("SELECT").WillReturnRows(rows)
 						return db.QueryRowContext(context.Background(), "SELECT")
 					},
@@ -159,6 +159,7 @@ func TestRead(t *testing.T) {
 					Name:          new("simple-provider"),
 					Issuer:        new("CN=Simple CA"),
 					MatchingRules: nil,
+					Priority:      new(5),
 				},
 				err: nil,
 			},
@@ -170,8 +171,8 @@ func TestRead(t *testing.T) {
 					MockQueryRowContext: func(ctx context.Context, query string, args ...any) *sql.Row {
 						// Mock successful issuer query
 						db, mock, _ := sqlmock.New()
-						rows := sqlmock.NewRows([]string{"ISSUER_NAME"}).
-							AddRow("CN=Test CA")
+						rows := sqlmock.NewRows([]string{"ISSUER_NAME", "PRIORITY"}).
+							AddRow("CN=Test CA", nil)
 						mock.ExpectQuery("SELECT").WillReturnRows(rows)
 						return db.QueryRowContext(context.Background(), "SELECT")
 					},
@@ -196,7 +197,11 @@ func TestRead(t *testing.T) {
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
 			c := Client{DB: tc.fields.db}
-			got, err := c.Read(tc.args.ctx, tc.args.parameters)
+			ctx := tc.args.ctx
+			if ctx == nil {
+				ctx = context.Background()
+			}
+			got, err := c.Read(ctx, tc.args.parameters)
 			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
 				t.Errorf("\n%s\ne.Read(...): -want error, +got error:\n%s\n", tc.reason, diff)
 			}
@@ -253,7 +258,7 @@ func TestCreate(t *testing.T) {
 			fields: fields{
 				db: fake.MockDB{
 					MockExecContext: func(ctx context.Context, query string, args ...any) (sql.Result, error) {
-						expectedQuery := "CREATE X509 PROVIDER test-provider WITH ISSUER 'CN=Test CA'"
+						expectedQuery := "CREATE X509 PROVIDER \"test-provider\" WITH ISSUER 'CN=Test CA'"
 						if query != expectedQuery {
 							return nil, fmt.Errorf("unexpected query: got %s, want %s", query, expectedQuery)
 						}
@@ -276,7 +281,7 @@ func TestCreate(t *testing.T) {
 			fields: fields{
 				db: fake.MockDB{
 					MockExecContext: func(ctx context.Context, query string, args ...any) (sql.Result, error) {
-						expectedQuery := "CREATE X509 PROVIDER complex-provider WITH ISSUER 'CN=Test CA, O=Acme Corp, C=US'"
+						expectedQuery := "CREATE X509 PROVIDER \"complex-provider\" WITH ISSUER 'CN=Test CA, O=Acme Corp, C=US'"
 						if query != expectedQuery {
 							return nil, fmt.Errorf("unexpected query: got %s, want %s", query, expectedQuery)
 						}
@@ -389,7 +394,7 @@ func TestUpdate(t *testing.T) {
 			fields: fields{
 				db: fake.MockDB{
 					MockExecContext: func(ctx context.Context, query string, args ...any) (sql.Result, error) {
-						expectedQuery := "ALTER X509 PROVIDER test-provider SET ISSUER 'CN=New CA'"
+						expectedQuery := "ALTER X509 PROVIDER \"test-provider\" SET ISSUER 'CN=New CA'"
 						if query != expectedQuery {
 							return nil, fmt.Errorf("unexpected query: got %s, want %s", query, expectedQuery)
 						}
@@ -418,7 +423,7 @@ func TestUpdate(t *testing.T) {
 			fields: fields{
 				db: fake.MockDB{
 					MockExecContext: func(ctx context.Context, query string, args ...any) (sql.Result, error) {
-						expectedQuery := "ALTER X509 PROVIDER test-provider SET MATCHING RULES 'new-rule1', 'new-rule2'"
+						expectedQuery := "ALTER X509 PROVIDER \"test-provider\" SET MATCHING RULES 'new-rule1', 'new-rule2'"
 						if query != expectedQuery {
 							return nil, fmt.Errorf("unexpected query: got %s, want %s", query, expectedQuery)
 						}
@@ -475,7 +480,7 @@ func TestUpdate(t *testing.T) {
 			fields: fields{
 				db: fake.MockDB{
 					MockExecContext: func(ctx context.Context, query string, args ...any) (sql.Result, error) {
-						expectedQuery := "ALTER X509 PROVIDER test-provider UNSET MATCHING RULES"
+						expectedQuery := "ALTER X509 PROVIDER \"test-provider\" UNSET MATCHING RULES"
 						if query != expectedQuery {
 							return nil, fmt.Errorf("unexpected query: got %s, want %s", query, expectedQuery)
 						}
@@ -499,6 +504,59 @@ func TestUpdate(t *testing.T) {
 				err: nil,
 			},
 		},
+		"SuccessUpdatePriority": {
+			reason: "Should set the priority if it differs",
+			fields: fields{
+				db: fake.MockDB{
+					MockExecContext: func(ctx context.Context, query string, args ...any) (sql.Result, error) {
+						expectedQuery := "ALTER X509 PROVIDER \"test-provider\" SET PRIORITY 2"
+						if query != expectedQuery {
+							return nil, fmt.Errorf("unexpected query: got %s, want %s", query, expectedQuery)
+						}
+						return nil, nil
+					},
+				},
+			},
+			args: args{
+				parameters: &v1alpha1.X509ProviderParameters{
+					Name:     "test-provider",
+					Issuer:   "CN=Test CA",
+					Priority: new(2),
+				},
+				observation: &v1alpha1.X509ProviderObservation{
+					Name:     new("test-provider"),
+					Issuer:   new("CN=Test CA"),
+					Priority: new(1),
+				},
+			},
+			want: want{
+				err: nil,
+			},
+		},
+		"SuccessPriorityUnset": {
+			reason: "Should leave the priority as it is if it is not set",
+			fields: fields{
+				db: fake.MockDB{
+					MockExecContext: func(ctx context.Context, query string, args ...any) (sql.Result, error) {
+						return nil, fmt.Errorf("no queries should be executed when no changes are needed")
+					},
+				},
+			},
+			args: args{
+				parameters: &v1alpha1.X509ProviderParameters{
+					Name:   "test-provider",
+					Issuer: "CN=Test CA",
+				},
+				observation: &v1alpha1.X509ProviderObservation{
+					Name:     new("test-provider"),
+					Issuer:   new("CN=Test CA"),
+					Priority: new(1),
+				},
+			},
+			want: want{
+				err: nil,
+			},
+		},
 		"SuccessNoChanges": {
 			reason: "Should successfully handle case when no changes are needed",
 			fields: fields{
@@ -582,7 +640,7 @@ func TestDelete(t *testing.T) {
 			fields: fields{
 				db: fake.MockDB{
 					MockExecContext: func(ctx context.Context, query string, args ...any) (sql.Result, error) {
-						expectedQuery := "DROP X509 PROVIDER test-provider"
+						expectedQuery := "DROP X509 PROVIDER \"test-provider\""
 						if query != expectedQuery {
 							return nil, fmt.Errorf("unexpected query: got %s, want %s", query, expectedQuery)
 						}
@@ -604,7 +662,7 @@ func TestDelete(t *testing.T) {
 			fields: fields{
 				db: fake.MockDB{
 					MockExecContext: func(ctx context.Context, query string, args ...any) (sql.Result, error) {
-						expectedQuery := "DROP X509 PROVIDER complex-provider-name"
+						expectedQuery := "DROP X509 PROVIDER \"complex-provider-name\""
 						if query != expectedQuery {
 							return nil, fmt.Errorf("unexpected query: got %s, want %s", query, expectedQuery)
 						}
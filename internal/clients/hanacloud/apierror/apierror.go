@@ -0,0 +1,105 @@
+/*
+Copyright 2026 SAP SE or an SAP affiliate company and contributors.
+*/
+
+// Package apierror maps the error responses of the HANA Cloud Admin API to
+// typed errors. The correlation ID of a response is part of the error
+// message, so that it shows up in the conditions of the resource and can be
+// passed on to SAP support.
+package apierror
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// correlationHeaders carry the correlation ID of a response, in the order
+// they are looked up if the body does not contain one.
+var correlationHeaders = []string{"X-Correlation-Id", "X-Correlationid", "X-Vcap-Request-Id"}
+
+// An Error is an error response of the Admin API.
+type Error struct {
+	// StatusCode of the response.
+	StatusCode int
+	// Code and Message of a structured error body.
+	Code    string
+	Message string
+	// CorrelationID identifies the request in the logs of SAP.
+	CorrelationID string
+	// Body is the raw response body if it is not a structured error.
+	Body string
+}
+
+func (e *Error) Error() string {
+	msg := fmt.Sprintf("API returned status %d", e.StatusCode)
+	switch {
+	case e.Code != "" && e.Message != "":
+		msg += fmt.Sprintf(": %s: %s", e.Code, e.Message)
+	case e.Message != "":
+		msg += ": " + e.Message
+	case e.Code != "":
+		msg += ": " + e.Code
+	case e.Body != "":
+		msg += ": " + e.Body
+	}
+	if e.CorrelationID != "" {
+		msg += fmt.Sprintf(" (correlation ID %s)", e.CorrelationID)
+	}
+	return msg
+}
+
+// payload is an error body, either nested in an error object or at the top
+// level. The code is a string or a number.
+type payload struct {
+	Code          any    `json:"code"`
+	Message       string `json:"message"`
+	CorrelationID string `json:"correlationId"`
+}
+
+// FromResponse returns the error of a response with an unexpected status and
+// its body.
+func FromResponse(resp *http.Response, body []byte) *Error {
+	e := &Error{StatusCode: resp.StatusCode}
+
+	var top struct {
+		payload
+		Error *payload `json:"error"`
+	}
+	if err := json.Unmarshal(body, &top); err == nil {
+		p := top.payload
+		if top.Error != nil {
+			p = *top.Error
+			if p.CorrelationID == "" {
+				p.CorrelationID = top.CorrelationID
+			}
+		}
+		if p.Code != nil {
+			e.Code = fmt.Sprint(p.Code)
+		}
+		e.Message, e.CorrelationID = p.Message, p.CorrelationID
+	}
+	if e.Code == "" && e.Message == "" {
+		e.Body = strings.TrimSpace(string(body))
+	}
+
+	for _, h := range correlationHeaders {
+		if e.CorrelationID != "" {
+			break
+		}
+		e.CorrelationID = resp.Header.Get(h)
+	}
+	return e
+}
+
+// CorrelationID returns the correlation ID of err, or an empty string if err
+// is not an Error or has none.
+func CorrelationID(err error) string {
+	var e *Error
+	if errors.As(err, &e) {
+		return e.CorrelationID
+	}
+	return ""
+}
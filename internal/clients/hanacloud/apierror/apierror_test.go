@@ -0,0 +1,81 @@
+/*
+Copyright 2026 SAP SE or an SAP affiliate company and contributors.
+*/
+
+package apierror
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestFromResponse(t *testing.T) {
+	cases := map[string]struct {
+		reason  string
+		status  int
+		header  http.Header
+		body    string
+		want    *Error
+		wantMsg string
+	}{
+		"Nested": {
+			reason:  "A nested error object should be mapped with its correlation ID",
+			status:  http.StatusConflict,
+			body:    `{"error":{"code":"INSTANCE_BUSY","message":"another operation is running","correlationId":"c0ffee"}}`,
+			want:    &Error{StatusCode: http.StatusConflict, Code: "INSTANCE_BUSY", Message: "another operation is running", CorrelationID: "c0ffee"},
+			wantMsg: "API returned status 409: INSTANCE_BUSY: another operation is running (correlation ID c0ffee)",
+		},
+		"TopLevel": {
+			reason:  "A top-level error with a numeric code should be mapped",
+			status:  http.StatusBadRequest,
+			body:    `{"code":400,"message":"invalid parameter","correlationId":"c0ffee"}`,
+			want:    &Error{StatusCode: http.StatusBadRequest, Code: "400", Message: "invalid parameter", CorrelationID: "c0ffee"},
+			wantMsg: "API returned status 400: 400: invalid parameter (correlation ID c0ffee)",
+		},
+		"CorrelationHeader": {
+			reason:  "The correlation ID should be taken from the headers if the body has none",
+			status:  http.StatusForbidden,
+			header:  http.Header{"X-Correlation-Id": []string{"from-header"}},
+			body:    `{"error":{"code":"FORBIDDEN","message":"missing scope"}}`,
+			want:    &Error{StatusCode: http.StatusForbidden, Code: "FORBIDDEN", Message: "missing scope", CorrelationID: "from-header"},
+			wantMsg: "API returned status 403: FORBIDDEN: missing scope (correlation ID from-header)",
+		},
+		"Unstructured": {
+			reason:  "An unstructured body should be kept as it is",
+			status:  http.StatusBadGateway,
+			header:  http.Header{"X-Vcap-Request-Id": []string{"vcap"}},
+			body:    "upstream unavailable\n",
+			want:    &Error{StatusCode: http.StatusBadGateway, Body: "upstream unavailable", CorrelationID: "vcap"},
+			wantMsg: "API returned status 502: upstream unavailable (correlation ID vcap)",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			header := tc.header
+			if header == nil {
+				header = http.Header{}
+			}
+			got := FromResponse(&http.Response{StatusCode: tc.status, Header: header}, []byte(tc.body))
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nFromResponse(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if got.Error() != tc.wantMsg {
+				t.Errorf("\n%s\nError() = %q, want %q", tc.reason, got.Error(), tc.wantMsg)
+			}
+		})
+	}
+}
+
+func TestCorrelationID(t *testing.T) {
+	err := fmt.Errorf("cannot get instance: %w", &Error{StatusCode: http.StatusInternalServerError, CorrelationID: "c0ffee"})
+	if got := CorrelationID(err); got != "c0ffee" {
+		t.Errorf("CorrelationID(...) = %q, want %q", got, "c0ffee")
+	}
+	if got := CorrelationID(fmt.Errorf("dial tcp: i/o timeout")); got != "" {
+		t.Errorf("CorrelationID(...) = %q, want none", got)
+	}
+}
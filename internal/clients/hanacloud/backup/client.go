@@ -0,0 +1,83 @@
+package backup
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+
+	"github.com/SAP/crossplane-provider-hana/internal/clients/hanacloud/jsonapi"
+	"github.com/SAP/crossplane-provider-hana/internal/clients/hanacloud/operation"
+)
+
+// Schedule is the schedule of the automatic backups of a HANA Cloud instance
+type Schedule struct {
+	Enabled   *bool  `json:"enabled,omitempty"`
+	StartTime string `json:"startTime,omitempty"`
+}
+
+// Configuration is the backup configuration of a HANA Cloud instance. In
+// updates, settings that are not set keep their value.
+type Configuration struct {
+	RetentionDays *int32    `json:"retentionDays,omitempty"`
+	Schedule      *Schedule `json:"schedule,omitempty"`
+}
+
+// Client is the interface for backup configuration operations
+type Client interface {
+	Get(ctx context.Context, serviceInstanceID string) (Configuration, error)
+	Update(ctx context.Context, serviceInstanceID string, cfg Configuration) (string, error)
+	operation.Client
+}
+
+type backupClient struct {
+	operation.Client
+	baseURL    string
+	httpClient *http.Client
+	logger     logging.Logger
+}
+
+// NewClient creates a new backup configuration client
+func NewClient(baseURL string, httpClient *http.Client, logger logging.Logger) Client {
+	return &backupClient{
+		Client:     operation.NewClient(baseURL, httpClient, logger),
+		baseURL:    baseURL,
+		httpClient: httpClient,
+		logger:     logger,
+	}
+}
+
+// Get retrieves the backup configuration of a service instance
+func (c *backupClient) Get(ctx context.Context, serviceInstanceID string) (Configuration, error) {
+	apiURL := fmt.Sprintf("https://%s/inventory/v2/serviceInstances/%s/backup/configuration",
+		c.baseURL, serviceInstanceID)
+
+	var response Configuration
+	if err := jsonapi.Do(ctx, c.httpClient, http.MethodGet, apiURL, nil, &response, http.StatusOK); err != nil {
+		return Configuration{}, err
+	}
+	return response, nil
+}
+
+// Update requests a change of the given settings and returns the ID of the
+// operation applying it
+func (c *backupClient) Update(ctx context.Context, serviceInstanceID string, cfg Configuration) (string, error) {
+	apiURL := fmt.Sprintf("https://%s/inventory/v2/serviceInstances/%s/backup/configuration",
+		c.baseURL, serviceInstanceID)
+
+	var accepted operation.Accepted
+	if err := jsonapi.Do(ctx, c.httpClient, http.MethodPatch, apiURL, cfg, &accepted, http.StatusAccepted); err != nil {
+		return "", err
+	}
+	operationID, err := accepted.OperationIDOrError()
+	if err != nil {
+		return "", err
+	}
+
+	c.logger.Debug("Requested backup configuration update",
+		"serviceInstanceID", serviceInstanceID,
+		"operationID", operationID)
+
+	return operationID, nil
+}
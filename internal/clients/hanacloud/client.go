@@ -5,18 +5,28 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
 	"sync"
 
 	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"golang.org/x/oauth2"
 	"golang.org/x/oauth2/clientcredentials"
 
+	"github.com/SAP/crossplane-provider-hana/internal/clients/hanacloud/backup"
+	"github.com/SAP/crossplane-provider-hana/internal/clients/hanacloud/configuration"
+	"github.com/SAP/crossplane-provider-hana/internal/clients/hanacloud/instance"
 	"github.com/SAP/crossplane-provider-hana/internal/clients/hanacloud/instancemapping"
+	"github.com/SAP/crossplane-provider-hana/internal/clients/proxy"
 )
 
 // AdminAPICredentials contains the credentials for HANA Cloud Admin API
 type AdminAPICredentials struct {
 	BaseURL string    `json:"baseurl"`
 	UAA     UAAConfig `json:"uaa"`
+
+	// ProxyURL optionally routes all requests through an HTTP CONNECT or
+	// SOCKS5 proxy
+	ProxyURL *url.URL `json:"-"`
 }
 
 // UAAConfig contains UAA/OAuth2 configuration
@@ -30,6 +40,9 @@ type UAAConfig struct {
 type Client interface {
 	Connect(ctx context.Context, creds AdminAPICredentials) error
 	InstanceMapping() instancemapping.Client
+	Configuration() configuration.Client
+	Backup() backup.Client
+	Instance() instance.Client
 	Disconnect() error
 }
 
@@ -37,6 +50,9 @@ type hanaCloudClient struct {
 	baseURL    string
 	httpClient *http.Client
 	imClient   instancemapping.Client
+	cfgClient  configuration.Client
+	bkpClient  backup.Client
+	instClient instance.Client
 	logger     logging.Logger
 	mu         sync.RWMutex
 }
@@ -74,13 +90,29 @@ func (c *hanaCloudClient) Connect(ctx context.Context, creds AdminAPICredentials
 		TokenURL:     creds.UAA.URL + "/oauth/token",
 	}
 
-	// Create HTTP client with OAuth2 token source
+	// Token and API requests share the proxied transport
+	if creds.ProxyURL != nil {
+		ctx = context.WithValue(ctx, oauth2.HTTPClient, &http.Client{Transport: proxy.Transport(creds.ProxyURL)})
+	}
+
+	// Create HTTP client with OAuth2 token source, rate limited and retried
+	// when the Admin API throttles
 	c.httpClient = oauth2Config.Client(ctx)
+	c.httpClient.Transport = newRetryTransport(c.httpClient.Transport)
 	c.baseURL = creds.BaseURL
 
 	// Initialize instance mapping client
 	c.imClient = instancemapping.NewClient(c.baseURL, c.httpClient, c.logger)
 
+	// Initialize instance configuration client
+	c.cfgClient = configuration.NewClient(c.baseURL, c.httpClient, c.logger)
+
+	// Initialize backup configuration client
+	c.bkpClient = backup.NewClient(c.baseURL, c.httpClient, c.logger)
+
+	// Initialize instance client
+	c.instClient = instance.NewClient(c.baseURL, c.httpClient, c.logger)
+
 	return nil
 }
 
@@ -91,6 +123,27 @@ func (c *hanaCloudClient) InstanceMapping() instancemapping.Client {
 	return c.imClient
 }
 
+// Configuration returns the instance configuration client
+func (c *hanaCloudClient) Configuration() configuration.Client {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.cfgClient
+}
+
+// Backup returns the backup configuration client
+func (c *hanaCloudClient) Backup() backup.Client {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.bkpClient
+}
+
+// Instance returns the instance client
+func (c *hanaCloudClient) Instance() instance.Client {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.instClient
+}
+
 // Disconnect closes the connection (currently a no-op as HTTP client handles cleanup)
 func (c *hanaCloudClient) Disconnect() error {
 	c.mu.Lock()
@@ -98,6 +151,9 @@ func (c *hanaCloudClient) Disconnect() error {
 
 	c.httpClient = nil
 	c.imClient = nil
+	c.cfgClient = nil
+	c.bkpClient = nil
+	c.instClient = nil
 	c.baseURL = ""
 
 	return nil
@@ -0,0 +1,85 @@
+package configuration
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+
+	"github.com/SAP/crossplane-provider-hana/internal/clients/hanacloud/jsonapi"
+	"github.com/SAP/crossplane-provider-hana/internal/clients/hanacloud/operation"
+)
+
+// Parameter is a single ini-like configuration parameter of a HANA Cloud instance
+type Parameter struct {
+	File    string `json:"file"`
+	Section string `json:"section"`
+	Key     string `json:"key"`
+	Value   string `json:"value"`
+}
+
+// parametersBody wraps the parameters in requests and responses
+// (the API uses {"parameters": [...]})
+type parametersBody struct {
+	Parameters []Parameter `json:"parameters"`
+}
+
+// Client is the interface for instance configuration operations
+type Client interface {
+	Get(ctx context.Context, serviceInstanceID string) ([]Parameter, error)
+	Update(ctx context.Context, serviceInstanceID string, params []Parameter) (string, error)
+	operation.Client
+}
+
+type configurationClient struct {
+	operation.Client
+	baseURL    string
+	httpClient *http.Client
+	logger     logging.Logger
+}
+
+// NewClient creates a new instance configuration client
+func NewClient(baseURL string, httpClient *http.Client, logger logging.Logger) Client {
+	return &configurationClient{
+		Client:     operation.NewClient(baseURL, httpClient, logger),
+		baseURL:    baseURL,
+		httpClient: httpClient,
+		logger:     logger,
+	}
+}
+
+// Get retrieves the configuration parameters of a service instance
+func (c *configurationClient) Get(ctx context.Context, serviceInstanceID string) ([]Parameter, error) {
+	apiURL := fmt.Sprintf("https://%s/inventory/v2/serviceInstances/%s/configuration/parameters",
+		c.baseURL, serviceInstanceID)
+
+	var response parametersBody
+	if err := jsonapi.Do(ctx, c.httpClient, http.MethodGet, apiURL, nil, &response, http.StatusOK); err != nil {
+		return nil, err
+	}
+	return response.Parameters, nil
+}
+
+// Update requests a change of the given parameters and returns the ID of the
+// operation applying it. Parameters that are not passed keep their value.
+func (c *configurationClient) Update(ctx context.Context, serviceInstanceID string, params []Parameter) (string, error) {
+	apiURL := fmt.Sprintf("https://%s/inventory/v2/serviceInstances/%s/configuration/parameters",
+		c.baseURL, serviceInstanceID)
+
+	var accepted operation.Accepted
+	if err := jsonapi.Do(ctx, c.httpClient, http.MethodPatch, apiURL, parametersBody{Parameters: params}, &accepted, http.StatusAccepted); err != nil {
+		return "", err
+	}
+	operationID, err := accepted.OperationIDOrError()
+	if err != nil {
+		return "", err
+	}
+
+	c.logger.Debug("Requested instance configuration update",
+		"serviceInstanceID", serviceInstanceID,
+		"operationID", operationID,
+		"parameters", len(params))
+
+	return operationID, nil
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2026 SAP SE.
+*/
+
+package configuration
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/google/go-cmp/cmp"
+)
+
+// MockLogger is a mock implementation of logging.Logger
+type MockLogger struct{}
+
+func (l *MockLogger) Debug(_ string, _ ...interface{}) {}
+func (l *MockLogger) Info(_ string, _ ...interface{})  {}
+func (l *MockLogger) WithValues(_ ...interface{}) logging.Logger {
+	return l
+}
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) Client {
+	t.Helper()
+	server := httptest.NewTLSServer(handler)
+	t.Cleanup(server.Close)
+
+	// Extract host from server URL (strip https://)
+	return NewClient(strings.TrimPrefix(server.URL, "https://"), server.Client(), &MockLogger{})
+}
+
+func TestGet(t *testing.T) {
+	params := []Parameter{
+		{File: "indexserver.ini", Section: "json", Key: "enabled", Value: "true"},
+	}
+
+	cases := map[string]struct {
+		handler http.HandlerFunc
+		want    []Parameter
+		wantErr bool
+	}{
+		"Success": {
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodGet {
+					t.Errorf("expected GET, got %s", r.Method)
+				}
+				if r.URL.Path != "/inventory/v2/serviceInstances/test-instance-id/configuration/parameters" {
+					t.Errorf("unexpected path: %s", r.URL.Path)
+				}
+				if err := json.NewEncoder(w).Encode(parametersBody{Parameters: params}); err != nil {
+					t.Errorf("failed to encode response: %v", err)
+				}
+			},
+			want: params,
+		},
+		"Error404": {
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusNotFound)
+			},
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := newTestClient(t, tc.handler).Get(context.Background(), "test-instance-id")
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("Get() error = %v, wantErr %t", err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Get() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	params := []Parameter{
+		{File: "indexserver.ini", Section: "json", Key: "enabled", Value: "true"},
+	}
+
+	cases := map[string]struct {
+		handler http.HandlerFunc
+		want    string
+		wantErr bool
+	}{
+		"Accepted": {
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPatch {
+					t.Errorf("expected PATCH, got %s", r.Method)
+				}
+				var body parametersBody
+				if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+					t.Errorf("failed to decode request: %v", err)
+				}
+				if diff := cmp.Diff(params, body.Parameters); diff != "" {
+					t.Errorf("request body mismatch (-want +got):\n%s", diff)
+				}
+				w.WriteHeader(http.StatusAccepted)
+				_, _ = w.Write([]byte(`{"operationID":"op-1"}`))
+			},
+			want: "op-1",
+		},
+		"MissingOperationID": {
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusAccepted)
+				_, _ = w.Write([]byte(`{}`))
+			},
+			wantErr: true,
+		},
+		"Error400": {
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(`{"error":"unknown parameter"}`))
+			},
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := newTestClient(t, tc.handler).Update(context.Background(), "test-instance-id", params)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("Update() error = %v, wantErr %t", err, tc.wantErr)
+			}
+			if got != tc.want {
+				t.Errorf("Update() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
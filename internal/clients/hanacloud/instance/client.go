@@ -0,0 +1,89 @@
+package instance
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+
+	"github.com/SAP/crossplane-provider-hana/internal/clients/hanacloud/apierror"
+)
+
+// Endpoint is an endpoint clients connect to a HANA Cloud instance through
+type Endpoint struct {
+	Type string `json:"type"`
+	Host string `json:"host"`
+	Port int32  `json:"port"`
+}
+
+// Instance is the state of a HANA Cloud instance as reported by the Admin API
+type Instance struct {
+	Status    string     `json:"status"`
+	Version   string     `json:"productVersion"`
+	MemoryGB  *int32     `json:"memory,omitempty"`
+	VCPU      *int32     `json:"vcpu,omitempty"`
+	StorageGB *int32     `json:"storage,omitempty"`
+	Endpoints []Endpoint `json:"endpoints,omitempty"`
+}
+
+// Client is the interface for reading HANA Cloud instances
+type Client interface {
+	Get(ctx context.Context, serviceInstanceID string) (Instance, error)
+}
+
+type instanceClient struct {
+	baseURL    string
+	httpClient *http.Client
+	logger     logging.Logger
+}
+
+// NewClient creates a new instance client
+func NewClient(baseURL string, httpClient *http.Client, logger logging.Logger) Client {
+	return &instanceClient{
+		baseURL:    baseURL,
+		httpClient: httpClient,
+		logger:     logger,
+	}
+}
+
+// Get retrieves the state of a service instance
+func (c *instanceClient) Get(ctx context.Context, serviceInstanceID string) (Instance, error) {
+	apiURL := fmt.Sprintf("https://%s/inventory/v2/serviceInstances/%s",
+		c.baseURL, serviceInstanceID)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL, nil)
+	if err != nil {
+		return Instance{}, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req) //nolint:gosec // G704: URL is constructed from validated service instance ID
+	if err != nil {
+		return Instance{}, fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return Instance{}, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return Instance{}, apierror.FromResponse(resp, respBody)
+	}
+
+	var instance Instance
+	if err := json.Unmarshal(respBody, &instance); err != nil {
+		return Instance{}, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	c.logger.Debug("Read service instance",
+		"serviceInstanceID", serviceInstanceID,
+		"status", instance.Status)
+
+	return instance, nil
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2026 SAP SE.
+*/
+
+package instance
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/SAP/crossplane-provider-hana/internal/clients/hanacloud/apierror"
+)
+
+// MockLogger is a mock implementation of logging.Logger
+type MockLogger struct{}
+
+func (l *MockLogger) Debug(_ string, _ ...interface{}) {}
+func (l *MockLogger) Info(_ string, _ ...interface{})  {}
+func (l *MockLogger) WithValues(_ ...interface{}) logging.Logger {
+	return l
+}
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) Client {
+	t.Helper()
+	server := httptest.NewTLSServer(handler)
+	t.Cleanup(server.Close)
+
+	// Extract host from server URL (strip https://)
+	return NewClient(strings.TrimPrefix(server.URL, "https://"), server.Client(), &MockLogger{})
+}
+
+func TestGet(t *testing.T) {
+	cases := map[string]struct {
+		handler http.HandlerFunc
+		want    Instance
+		wantErr bool
+	}{
+		"Success": {
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodGet {
+					t.Errorf("expected GET, got %s", r.Method)
+				}
+				if r.URL.Path != "/inventory/v2/serviceInstances/test-instance-id" {
+					t.Errorf("unexpected path: %s", r.URL.Path)
+				}
+				_, _ = w.Write([]byte(`{
+					"status": "RUNNING",
+					"productVersion": "2025.2.4",
+					"memory": 32,
+					"vcpu": 2,
+					"storage": 120,
+					"endpoints": [{"type": "database", "host": "abc.hana.prod.eu10.hanacloud.ondemand.com", "port": 443}],
+					"region": "eu10"
+				}`))
+			},
+			want: Instance{
+				Status:    "RUNNING",
+				Version:   "2025.2.4",
+				MemoryGB:  new(int32(32)),
+				VCPU:      new(int32(2)),
+				StorageGB: new(int32(120)),
+				Endpoints: []Endpoint{{Type: "database", Host: "abc.hana.prod.eu10.hanacloud.ondemand.com", Port: 443}},
+			},
+		},
+		"Error404": {
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusNotFound)
+			},
+			wantErr: true,
+		},
+		"InvalidJSON": {
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				_, _ = w.Write([]byte(`{`))
+			},
+			wantErr: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := newTestClient(t, tc.handler).Get(context.Background(), "test-instance-id")
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("Get() error = %v, wantErr %t", err, tc.wantErr)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("Get() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestGetAPIError(t *testing.T) {
+	_, err := newTestClient(t, func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("X-Correlation-Id", "c0ffee")
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"error":{"code":"FORBIDDEN","message":"missing scope"}}`))
+	}).Get(context.Background(), "test-instance-id")
+
+	want := &apierror.Error{StatusCode: http.StatusForbidden, Code: "FORBIDDEN", Message: "missing scope", CorrelationID: "c0ffee"}
+	var got *apierror.Error
+	if !errors.As(err, &got) {
+		t.Fatalf("Get() error = %v, want %v", err, want)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Get() error mismatch (-want +got):\n%s", diff)
+	}
+}
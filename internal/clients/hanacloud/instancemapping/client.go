@@ -10,6 +10,9 @@ import (
 	"net/url"
 
 	"github.com/crossplane/crossplane-runtime/pkg/logging"
+
+	"github.com/SAP/crossplane-provider-hana/internal/clients/hanacloud/apierror"
+	"github.com/SAP/crossplane-provider-hana/internal/clients/hanacloud/operation"
 )
 
 // InstanceMapping represents a mapping between a HANA instance and a Kubernetes namespace
@@ -33,14 +36,18 @@ type CreateMappingRequest struct {
 	IsDefault   bool    `json:"isDefault"`
 }
 
-// Client is the interface for instance mapping operations
+// Client is the interface for instance mapping operations. Create and Delete
+// return the ID of the operation applying the change if the API accepted it
+// asynchronously, and an empty ID if the change has already been applied.
 type Client interface {
 	List(ctx context.Context, serviceInstanceID string) ([]InstanceMapping, error)
-	Create(ctx context.Context, serviceInstanceID string, req CreateMappingRequest) error
-	Delete(ctx context.Context, serviceInstanceID, primaryID, secondaryID string) error
+	Create(ctx context.Context, serviceInstanceID string, req CreateMappingRequest) (string, error)
+	Delete(ctx context.Context, serviceInstanceID, primaryID, secondaryID string) (string, error)
+	operation.Client
 }
 
 type instanceMappingClient struct {
+	operation.Client
 	baseURL    string
 	httpClient *http.Client
 	logger     logging.Logger
@@ -49,6 +56,7 @@ type instanceMappingClient struct {
 // NewClient creates a new instance mapping client
 func NewClient(baseURL string, httpClient *http.Client, logger logging.Logger) Client {
 	return &instanceMappingClient{
+		Client:     operation.NewClient(baseURL, httpClient, logger),
 		baseURL:    baseURL,
 		httpClient: httpClient,
 		logger:     logger,
@@ -81,7 +89,7 @@ func (c *instanceMappingClient) List(ctx context.Context, serviceInstanceID stri
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("API returned status %d: %s", resp.StatusCode, string(body))
+		return nil, apierror.FromResponse(resp, body)
 	}
 
 	// Read the response body
@@ -100,32 +108,49 @@ func (c *instanceMappingClient) List(ctx context.Context, serviceInstanceID stri
 }
 
 // Create creates a new instance mapping
-func (c *instanceMappingClient) Create(ctx context.Context, serviceInstanceID string, req CreateMappingRequest) error {
+func (c *instanceMappingClient) Create(ctx context.Context, serviceInstanceID string, req CreateMappingRequest) (string, error) {
 	apiURL := fmt.Sprintf("https://%s/inventory/v2/serviceInstances/%s/instanceMappings",
 		c.baseURL, serviceInstanceID)
 
 	bodyBytes, err := json.Marshal(req)
 	if err != nil {
-		return fmt.Errorf("failed to marshal request: %w", err)
+		return "", fmt.Errorf("failed to marshal request: %w", err)
 	}
 
 	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, apiURL, bytes.NewReader(bodyBytes))
 	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
+		return "", fmt.Errorf("failed to create request: %w", err)
 	}
 	httpReq.Header.Set("Content-Type", "application/json")
 
 	resp, err := c.httpClient.Do(httpReq) //nolint:gosec // G704: URL is constructed from validated service instance ID
 	if err != nil {
-		return fmt.Errorf("failed to execute request: %w", err)
+		return "", fmt.Errorf("failed to execute request: %w", err)
 	}
 	defer func() {
 		_ = resp.Body.Close()
 	}()
 
+	if resp.StatusCode == http.StatusAccepted {
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return "", fmt.Errorf("failed to read response body: %w", err)
+		}
+		operationID, err := operation.ParseAccepted(body)
+		if err != nil {
+			return "", err
+		}
+		c.logger.Debug("Instance mapping creation accepted",
+			"serviceInstanceID", serviceInstanceID,
+			"primaryID", req.PrimaryID,
+			"secondaryID", req.SecondaryID,
+			"operationID", operationID)
+		return operationID, nil
+	}
+
 	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
 		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("API returned status %d: %s", resp.StatusCode, string(body))
+		return "", apierror.FromResponse(resp, body)
 	}
 
 	c.logger.Debug("Successfully created instance mapping",
@@ -133,11 +158,11 @@ func (c *instanceMappingClient) Create(ctx context.Context, serviceInstanceID st
 		"primaryID", req.PrimaryID,
 		"secondaryID", req.SecondaryID)
 
-	return nil
+	return "", nil
 }
 
 // Delete removes an instance mapping
-func (c *instanceMappingClient) Delete(ctx context.Context, serviceInstanceID, primaryID, secondaryID string) error {
+func (c *instanceMappingClient) Delete(ctx context.Context, serviceInstanceID, primaryID, secondaryID string) (string, error) {
 	// Build URL with query parameters
 	apiURL := fmt.Sprintf("https://%s/inventory/v2/serviceInstances/%s/instanceMappings",
 		c.baseURL, serviceInstanceID)
@@ -150,12 +175,12 @@ func (c *instanceMappingClient) Delete(ctx context.Context, serviceInstanceID, p
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, apiURL, nil)
 	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
+		return "", fmt.Errorf("failed to create request: %w", err)
 	}
 
 	resp, err := c.httpClient.Do(req) //nolint:gosec // G704: URL is constructed from validated service instance ID
 	if err != nil {
-		return fmt.Errorf("failed to execute request: %w", err)
+		return "", fmt.Errorf("failed to execute request: %w", err)
 	}
 	defer func() {
 		_ = resp.Body.Close()
@@ -167,12 +192,20 @@ func (c *instanceMappingClient) Delete(ctx context.Context, serviceInstanceID, p
 			"serviceInstanceID", serviceInstanceID,
 			"primaryID", primaryID,
 			"secondaryID", secondaryID)
-		return nil
+		return "", nil
+	}
+
+	if resp.StatusCode == http.StatusAccepted {
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return "", fmt.Errorf("failed to read response body: %w", err)
+		}
+		return operation.ParseAccepted(body)
 	}
 
 	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
 		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("API returned status %d: %s", resp.StatusCode, string(body))
+		return "", apierror.FromResponse(resp, body)
 	}
 
 	c.logger.Debug("Successfully deleted instance mapping",
@@ -180,5 +213,5 @@ func (c *instanceMappingClient) Delete(ctx context.Context, serviceInstanceID, p
 		"primaryID", primaryID,
 		"secondaryID", secondaryID)
 
-	return nil
+	return "", nil
 }
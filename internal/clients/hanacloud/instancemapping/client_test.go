@@ -149,10 +149,32 @@ func TestCreate(t *testing.T) {
 	secondaryID := "test-namespace"
 
 	cases := map[string]struct {
-		handler http.HandlerFunc
-		req     CreateMappingRequest
-		wantErr bool
+		handler  http.HandlerFunc
+		req      CreateMappingRequest
+		wantOpID string
+		wantErr  bool
 	}{
+		"Accepted202ReturnsOperation": {
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusAccepted)
+				_, _ = w.Write([]byte(`{"operationID": "op-1"}`))
+			},
+			req: CreateMappingRequest{
+				Platform:  "kubernetes",
+				PrimaryID: "cluster-1",
+			},
+			wantOpID: "op-1",
+		},
+		"Accepted202WithoutOperation": {
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusAccepted)
+			},
+			req: CreateMappingRequest{
+				Platform:  "kubernetes",
+				PrimaryID: "cluster-1",
+			},
+			wantErr: true,
+		},
 		"Success201Created": {
 			handler: func(w http.ResponseWriter, r *http.Request) {
 				if r.Method != http.MethodPost {
@@ -260,7 +282,7 @@ func TestCreate(t *testing.T) {
 			baseURL := strings.TrimPrefix(server.URL, "https://")
 			client := NewClient(baseURL, server.Client(), &MockLogger{})
 
-			err := client.Create(ctx, "test-instance-id", tc.req)
+			opID, err := client.Create(ctx, "test-instance-id", tc.req)
 
 			if tc.wantErr {
 				if err == nil {
@@ -272,6 +294,9 @@ func TestCreate(t *testing.T) {
 			if err != nil {
 				t.Errorf("unexpected error: %v", err)
 			}
+			if opID != tc.wantOpID {
+				t.Errorf("Create() operation = %q, want %q", opID, tc.wantOpID)
+			}
 		})
 	}
 }
@@ -283,8 +308,18 @@ func TestDelete(t *testing.T) {
 		handler     http.HandlerFunc
 		primaryID   string
 		secondaryID string
+		wantOpID    string
 		wantErr     bool
 	}{
+		"Accepted202ReturnsOperation": {
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusAccepted)
+				_, _ = w.Write([]byte(`{"operationID": "op-2"}`))
+			},
+			primaryID:   "cluster-1",
+			secondaryID: "test-namespace",
+			wantOpID:    "op-2",
+		},
 		"Success204NoContent": {
 			handler: func(w http.ResponseWriter, r *http.Request) {
 				if r.Method != http.MethodDelete {
@@ -355,7 +390,7 @@ func TestDelete(t *testing.T) {
 			baseURL := strings.TrimPrefix(server.URL, "https://")
 			client := NewClient(baseURL, server.Client(), &MockLogger{})
 
-			err := client.Delete(ctx, "test-instance-id", tc.primaryID, tc.secondaryID)
+			opID, err := client.Delete(ctx, "test-instance-id", tc.primaryID, tc.secondaryID)
 
 			if tc.wantErr {
 				if err == nil {
@@ -367,6 +402,9 @@ func TestDelete(t *testing.T) {
 			if err != nil {
 				t.Errorf("unexpected error: %v", err)
 			}
+			if opID != tc.wantOpID {
+				t.Errorf("Delete() operation = %q, want %q", opID, tc.wantOpID)
+			}
 		})
 	}
 }
@@ -0,0 +1,60 @@
+/*
+Copyright 2026 SAP SE or an SAP affiliate company and contributors.
+*/
+
+// Package jsonapi sends the JSON requests of the HANA Cloud Admin API clients.
+package jsonapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/SAP/crossplane-provider-hana/internal/clients/hanacloud/apierror"
+)
+
+// Do sends a request with an optional JSON body and decodes the JSON response
+// into out if the API answers with the expected status
+func Do(ctx context.Context, httpClient *http.Client, method, apiURL string, in, out any, expected int) error {
+	var body io.Reader
+	if in != nil {
+		bodyBytes, err := json.Marshal(in)
+		if err != nil {
+			return fmt.Errorf("failed to marshal request: %w", err)
+		}
+		body = bytes.NewReader(bodyBytes)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, apiURL, body)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := httpClient.Do(req) //nolint:gosec // G704: URL is constructed from validated service instance ID
+	if err != nil {
+		return fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if resp.StatusCode != expected {
+		return apierror.FromResponse(resp, respBody)
+	}
+
+	if err := json.Unmarshal(respBody, out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2026 SAP SE or an SAP affiliate company and contributors.
+*/
+
+package jsonapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/SAP/crossplane-provider-hana/internal/clients/hanacloud/apierror"
+)
+
+type body struct {
+	Value string `json:"value"`
+}
+
+func TestDo(t *testing.T) {
+	cases := map[string]struct {
+		reason   string
+		method   string
+		in       any
+		handler  http.HandlerFunc
+		expected int
+		want     body
+		wantErr  bool
+	}{
+		"Get": {
+			reason: "A request without body should be sent without content type, and the response decoded",
+			method: http.MethodGet,
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				if ct := r.Header.Get("Content-Type"); ct != "" {
+					t.Errorf("unexpected Content-Type: %s", ct)
+				}
+				_ = json.NewEncoder(w).Encode(body{Value: "out"})
+			},
+			expected: http.StatusOK,
+			want:     body{Value: "out"},
+		},
+		"Patch": {
+			reason: "A request body should be sent as JSON",
+			method: http.MethodPatch,
+			in:     body{Value: "in"},
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				if ct := r.Header.Get("Content-Type"); ct != "application/json" {
+					t.Errorf("unexpected Content-Type: %s", ct)
+				}
+				var in body
+				if err := json.NewDecoder(r.Body).Decode(&in); err != nil || in.Value != "in" {
+					t.Errorf("unexpected request body %+v: %v", in, err)
+				}
+				w.WriteHeader(http.StatusAccepted)
+				_ = json.NewEncoder(w).Encode(body{Value: "out"})
+			},
+			expected: http.StatusAccepted,
+			want:     body{Value: "out"},
+		},
+		"UnexpectedStatus": {
+			reason: "A response with another status should be returned as an API error",
+			method: http.MethodGet,
+			handler: func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusNotFound)
+			},
+			expected: http.StatusOK,
+			wantErr:  true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			t.Cleanup(server.Close)
+
+			var got body
+			err := Do(context.Background(), server.Client(), tc.method, server.URL, tc.in, &got, tc.expected)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("\n%s\nDo(...) error = %v, wantErr %t", tc.reason, err, tc.wantErr)
+			}
+			var apiErr *apierror.Error
+			if tc.wantErr && !errors.As(err, &apiErr) {
+				t.Errorf("\n%s\nDo(...) error = %v, want an *apierror.Error", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nDo(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,154 @@
+package operation
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/SAP/crossplane-provider-hana/apis/inventory/v1alpha1"
+	"github.com/SAP/crossplane-provider-hana/internal/clients/hanacloud/apierror"
+)
+
+// Operation states reported by the Admin API. StateTimedOut is set by the
+// provider when an operation did not finish in time.
+const (
+	StateInProgress = "IN_PROGRESS"
+	StateSucceeded  = "SUCCEEDED"
+	StateFailed     = "FAILED"
+	StateTimedOut   = "TIMED_OUT"
+)
+
+// DefaultTimeout is how long an operation may run before it is given up
+const DefaultTimeout = 30 * time.Minute
+
+// Operation is an asynchronous change of a service instance
+type Operation struct {
+	ID      string `json:"id"`
+	State   string `json:"state"`
+	Message string `json:"message,omitempty"`
+}
+
+// Accepted is the body of a 202 Accepted response, returned when the API
+// applies a change asynchronously
+type Accepted struct {
+	OperationID string `json:"operationID"`
+}
+
+// OperationIDOrError returns the operation ID, or an error if the API did not
+// return one
+func (a Accepted) OperationIDOrError() (string, error) {
+	if a.OperationID == "" {
+		return "", fmt.Errorf("API accepted the request without an operation ID")
+	}
+	return a.OperationID, nil
+}
+
+// Client is the interface for reading the state of operations
+type Client interface {
+	GetOperation(ctx context.Context, serviceInstanceID, operationID string) (Operation, error)
+}
+
+type operationClient struct {
+	baseURL    string
+	httpClient *http.Client
+	logger     logging.Logger
+}
+
+// NewClient creates a new operation client
+func NewClient(baseURL string, httpClient *http.Client, logger logging.Logger) Client {
+	return &operationClient{
+		baseURL:    baseURL,
+		httpClient: httpClient,
+		logger:     logger,
+	}
+}
+
+// GetOperation retrieves the state of an operation on a service instance
+func (c *operationClient) GetOperation(ctx context.Context, serviceInstanceID, operationID string) (Operation, error) {
+	apiURL := fmt.Sprintf("https://%s/inventory/v2/serviceInstances/%s/operations/%s",
+		c.baseURL, serviceInstanceID, operationID)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL, nil)
+	if err != nil {
+		return Operation{}, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.httpClient.Do(req) //nolint:gosec // G704: URL is constructed from validated service instance ID
+	if err != nil {
+		return Operation{}, fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return Operation{}, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return Operation{}, apierror.FromResponse(resp, body)
+	}
+
+	var op Operation
+	if err := json.Unmarshal(body, &op); err != nil {
+		return Operation{}, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return op, nil
+}
+
+// ParseAccepted returns the operation ID of a 202 Accepted response body
+func ParseAccepted(body []byte) (string, error) {
+	var accepted Accepted
+	if err := json.Unmarshal(body, &accepted); err != nil {
+		return "", fmt.Errorf("failed to decode response: %w", err)
+	}
+	return accepted.OperationIDOrError()
+}
+
+// Start records a newly accepted operation in the status of a resource
+func Start(status *v1alpha1.OperationObservation, operationID string, now time.Time) {
+	*status = v1alpha1.OperationObservation{
+		ID:        operationID,
+		State:     StateInProgress,
+		StartTime: &metav1.Time{Time: now},
+	}
+}
+
+// Pending reports whether the operation recorded in status is still running
+func Pending(status v1alpha1.OperationObservation) bool {
+	return status.ID != "" && status.State == StateInProgress
+}
+
+// Resume polls the operation recorded in status and records its state. It
+// returns true while the operation is still running. Operations running
+// longer than timeout are given up and recorded as timed out.
+func Resume(ctx context.Context, c Client, serviceInstanceID string, status *v1alpha1.OperationObservation, timeout time.Duration, now time.Time) (bool, error) {
+	if !Pending(*status) {
+		return false, nil
+	}
+
+	op, err := c.GetOperation(ctx, serviceInstanceID, status.ID)
+	if err != nil {
+		return false, fmt.Errorf("cannot get state of operation %s: %w", status.ID, err)
+	}
+
+	if op.State == StateInProgress {
+		if status.StartTime == nil || now.Sub(status.StartTime.Time) <= timeout {
+			return true, nil
+		}
+		status.State = StateTimedOut
+		status.Message = fmt.Sprintf("operation did not finish within %s", timeout)
+		return false, nil
+	}
+
+	status.State = op.State
+	status.Message = op.Message
+	return false, nil
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2026 SAP SE.
+*/
+
+package operation
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/SAP/crossplane-provider-hana/apis/inventory/v1alpha1"
+)
+
+// MockLogger is a mock implementation of logging.Logger
+type MockLogger struct{}
+
+func (l *MockLogger) Debug(_ string, _ ...interface{}) {}
+func (l *MockLogger) Info(_ string, _ ...interface{})  {}
+func (l *MockLogger) WithValues(_ ...interface{}) logging.Logger {
+	return l
+}
+
+func TestGetOperation(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/inventory/v2/serviceInstances/test-instance-id/operations/op-1" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		_, _ = w.Write([]byte(`{"id":"op-1","state":"FAILED","message":"restart failed"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(strings.TrimPrefix(server.URL, "https://"), server.Client(), &MockLogger{})
+	got, err := client.GetOperation(context.Background(), "test-instance-id", "op-1")
+	if err != nil {
+		t.Fatalf("GetOperation() unexpected error: %v", err)
+	}
+	want := Operation{ID: "op-1", State: StateFailed, Message: "restart failed"}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("GetOperation() mismatch (-want +got):\n%s", diff)
+	}
+}
+
+// mockClient returns a fixed operation state
+type mockClient struct {
+	op  Operation
+	err error
+}
+
+func (m *mockClient) GetOperation(_ context.Context, _, operationID string) (Operation, error) {
+	m.op.ID = operationID
+	return m.op, m.err
+}
+
+func TestResume(t *testing.T) {
+	errBoom := errors.New("boom")
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	started := &metav1.Time{Time: now.Add(-10 * time.Minute)}
+	running := v1alpha1.OperationObservation{ID: "op-1", State: StateInProgress, StartTime: started}
+
+	type want struct {
+		pending bool
+		status  v1alpha1.OperationObservation
+		err     bool
+	}
+
+	cases := map[string]struct {
+		reason string
+		client *mockClient
+		status v1alpha1.OperationObservation
+		want   want
+	}{
+		"NothingPending": {
+			reason: "A finished operation should not be polled again",
+			status: v1alpha1.OperationObservation{ID: "op-1", State: StateSucceeded},
+			want: want{
+				status: v1alpha1.OperationObservation{ID: "op-1", State: StateSucceeded},
+			},
+		},
+		"StillRunning": {
+			reason: "An operation running within the timeout should stay pending",
+			client: &mockClient{op: Operation{State: StateInProgress}},
+			status: running,
+			want:   want{pending: true, status: running},
+		},
+		"TimedOut": {
+			reason: "An operation running longer than the timeout should be given up",
+			client: &mockClient{op: Operation{State: StateInProgress}},
+			status: v1alpha1.OperationObservation{ID: "op-1", State: StateInProgress, StartTime: &metav1.Time{Time: now.Add(-time.Hour)}},
+			want: want{
+				status: v1alpha1.OperationObservation{
+					ID: "op-1", State: StateTimedOut, Message: "operation did not finish within 30m0s",
+					StartTime: &metav1.Time{Time: now.Add(-time.Hour)},
+				},
+			},
+		},
+		"Failed": {
+			reason: "The final state and message of an operation should be recorded",
+			client: &mockClient{op: Operation{State: StateFailed, Message: "restart failed"}},
+			status: running,
+			want: want{
+				status: v1alpha1.OperationObservation{ID: "op-1", State: StateFailed, Message: "restart failed", StartTime: started},
+			},
+		},
+		"ErrGetOperation": {
+			reason: "An error getting the operation should keep it pending in the status",
+			client: &mockClient{err: errBoom},
+			status: running,
+			want:   want{status: running, err: true},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			status := tc.status
+			pending, err := Resume(context.Background(), tc.client, "test-instance-id", &status, DefaultTimeout, now)
+			if (err != nil) != tc.want.err {
+				t.Errorf("\n%s\nResume(...): error = %v, want error %t", tc.reason, err, tc.want.err)
+			}
+			if pending != tc.want.pending {
+				t.Errorf("\n%s\nResume(...): pending = %t, want %t", tc.reason, pending, tc.want.pending)
+			}
+			if diff := cmp.Diff(tc.want.status, status); diff != "" {
+				t.Errorf("\n%s\nResume(...): -want status, +got status:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package hanacloud
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/time/rate"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const (
+	// adminAPIRate and adminAPIBurst limit the requests to the HANA Cloud
+	// Admin API across all reconciles of the provider.
+	adminAPIRate  = 5
+	adminAPIBurst = 10
+
+	maxRetries   = 4
+	retryBackoff = time.Second
+	maxBackoff   = 30 * time.Second
+)
+
+// adminAPILimiter is shared by all clients, so a large fleet of mappings is
+// throttled on the provider side before the Admin API throttles it.
+var adminAPILimiter = rate.NewLimiter(adminAPIRate, adminAPIBurst)
+
+var (
+	throttledResponses = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "hana_cloud_admin_api_throttled_responses_total",
+		Help: "Responses of the HANA Cloud Admin API asking the provider to back off, by status code.",
+	}, []string{"code"})
+
+	retriedRequests = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "hana_cloud_admin_api_retried_requests_total",
+		Help: "Requests to the HANA Cloud Admin API that were retried after a throttled response.",
+	})
+)
+
+func init() {
+	metrics.Registry.MustRegister(throttledResponses, retriedRequests)
+}
+
+// retryTransport rate limits requests and retries throttled ones, honoring
+// the Retry-After header and backing off exponentially otherwise.
+type retryTransport struct {
+	next    http.RoundTripper
+	limiter *rate.Limiter
+	sleep   func(req *http.Request, d time.Duration) error
+}
+
+func newRetryTransport(next http.RoundTripper) *retryTransport {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &retryTransport{next: next, limiter: adminAPILimiter, sleep: sleep}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		if err := t.limiter.Wait(req.Context()); err != nil {
+			return nil, err
+		}
+
+		resp, err := t.next.RoundTrip(req)
+		if err != nil || !isThrottled(resp.StatusCode) {
+			return resp, err
+		}
+		throttledResponses.WithLabelValues(strconv.Itoa(resp.StatusCode)).Inc()
+
+		if attempt == maxRetries || (req.Body != nil && req.GetBody == nil) {
+			return resp, nil
+		}
+		delay := retryAfter(resp, attempt)
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+
+		if err := t.sleep(req, delay); err != nil {
+			return nil, err
+		}
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+		retriedRequests.Inc()
+	}
+}
+
+func isThrottled(code int) bool {
+	return code == http.StatusTooManyRequests || code == http.StatusServiceUnavailable
+}
+
+// retryAfter returns the delay the response asks for, or an exponential
+// backoff if it does not.
+func retryAfter(resp *http.Response, attempt int) time.Duration {
+	if v := resp.Header.Get("Retry-After"); v != "" {
+		if seconds, err := strconv.Atoi(v); err == nil && seconds >= 0 {
+			return min(time.Duration(seconds)*time.Second, maxBackoff)
+		}
+		if at, err := http.ParseTime(v); err == nil {
+			return min(max(time.Until(at), 0), maxBackoff)
+		}
+	}
+	return min(retryBackoff<<attempt, maxBackoff)
+}
+
+func sleep(req *http.Request, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-req.Context().Done():
+		return req.Context().Err()
+	case <-timer.C:
+		return nil
+	}
+}
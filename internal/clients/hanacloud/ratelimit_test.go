@@ -0,0 +1,136 @@
+package hanacloud
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"golang.org/x/time/rate"
+)
+
+func TestRetryTransport(t *testing.T) {
+	cases := map[string]struct {
+		reason     string
+		responses  []int
+		retryAfter string
+		wantCode   int
+		wantSleeps []time.Duration
+	}{
+		"Success": {
+			reason:    "A successful response should be returned without retrying",
+			responses: []int{http.StatusOK},
+			wantCode:  http.StatusOK,
+		},
+		"Backoff": {
+			reason:     "Throttled requests should be retried with exponential backoff",
+			responses:  []int{http.StatusTooManyRequests, http.StatusServiceUnavailable, http.StatusOK},
+			wantCode:   http.StatusOK,
+			wantSleeps: []time.Duration{time.Second, 2 * time.Second},
+		},
+		"RetryAfter": {
+			reason:     "The delay of the Retry-After header should be honored",
+			responses:  []int{http.StatusTooManyRequests, http.StatusCreated},
+			retryAfter: "7",
+			wantCode:   http.StatusCreated,
+			wantSleeps: []time.Duration{7 * time.Second},
+		},
+		"GiveUp": {
+			reason:     "The throttled response should be returned once the retries are exhausted",
+			responses:  []int{429, 429, 429, 429, 429, 429},
+			wantCode:   http.StatusTooManyRequests,
+			wantSleeps: []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var bodies []string
+			calls := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				b, _ := io.ReadAll(r.Body)
+				bodies = append(bodies, string(b))
+				if tc.retryAfter != "" {
+					w.Header().Set("Retry-After", tc.retryAfter)
+				}
+				w.WriteHeader(tc.responses[calls])
+				calls++
+			}))
+			defer server.Close()
+
+			var sleeps []time.Duration
+			transport := &retryTransport{
+				next:    http.DefaultTransport,
+				limiter: rate.NewLimiter(rate.Inf, 0),
+				sleep: func(_ *http.Request, d time.Duration) error {
+					sleeps = append(sleeps, d)
+					return nil
+				},
+			}
+
+			req, _ := http.NewRequest(http.MethodPost, server.URL, strings.NewReader("mapping"))
+			resp, err := (&http.Client{Transport: transport}).Do(req)
+			if err != nil {
+				t.Fatalf("\n%s\nDo(...): unexpected error: %v", tc.reason, err)
+			}
+			_ = resp.Body.Close()
+
+			if resp.StatusCode != tc.wantCode {
+				t.Errorf("\n%s\nDo(...): want status %d, got %d", tc.reason, tc.wantCode, resp.StatusCode)
+			}
+			if diff := cmp.Diff(tc.wantSleeps, sleeps); diff != "" {
+				t.Errorf("\n%s\nDo(...): -want sleeps, +got sleeps:\n%s\n", tc.reason, diff)
+			}
+			for _, b := range bodies {
+				if b != "mapping" {
+					t.Errorf("\n%s\nDo(...): want body %q on every attempt, got %q", tc.reason, "mapping", b)
+				}
+			}
+		})
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	cases := map[string]struct {
+		reason  string
+		header  string
+		attempt int
+		want    time.Duration
+	}{
+		"Seconds": {
+			reason: "A delay in seconds should be honored",
+			header: "3",
+			want:   3 * time.Second,
+		},
+		"Capped": {
+			reason: "A delay beyond the maximum backoff should be capped",
+			header: "3600",
+			want:   maxBackoff,
+		},
+		"Past": {
+			reason: "A date in the past should not delay the retry",
+			header: time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat),
+			want:   0,
+		},
+		"Exponential": {
+			reason:  "Without a header the backoff should grow exponentially",
+			attempt: 3,
+			want:    8 * time.Second,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			resp := &http.Response{Header: http.Header{}}
+			if tc.header != "" {
+				resp.Header.Set("Retry-After", tc.header)
+			}
+			if got := retryAfter(resp, tc.attempt); got != tc.want {
+				t.Errorf("\n%s\nretryAfter(...): want %s, got %s", tc.reason, tc.want, got)
+			}
+		})
+	}
+}
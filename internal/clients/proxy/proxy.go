@@ -0,0 +1,177 @@
+/*
+Copyright 2026 SAP SE or an SAP affiliate company and contributors.
+*/
+
+// Package proxy tunnels connections through HTTP CONNECT and SOCKS5 proxies,
+// e.g. to reach on-premise systems through the SAP Cloud Connector.
+package proxy
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/SAP/go-hdb/driver/dial"
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	xproxy "golang.org/x/net/proxy"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	apisv1alpha1 "github.com/SAP/crossplane-provider-hana/apis/v1alpha1"
+)
+
+const (
+	schemeHTTP   = "http"
+	schemeSOCKS5 = "socks5"
+)
+
+const (
+	errGetCredentialsSecret = "cannot get proxy credentials secret: %w"
+	errUnknownProxyType     = "unknown proxy type: %s"
+	errUnknownProxyScheme   = "unknown proxy scheme: %s"
+	errConnectProxy         = "cannot connect to proxy: %w"
+	errProxyConnect         = "proxy refused CONNECT to %s: %s"
+)
+
+// URL returns the URL of the proxy described by cfg, including the
+// credentials of the referenced Secret. A nil cfg returns a nil URL.
+func URL(ctx context.Context, kube client.Client, cfg *apisv1alpha1.ProxyConfig) (*url.URL, error) {
+	if cfg == nil {
+		return nil, nil
+	}
+
+	u := &url.URL{Host: cfg.Address}
+	switch cfg.Type {
+	case apisv1alpha1.ProxyTypeSOCKS5, "":
+		u.Scheme = schemeSOCKS5
+	case apisv1alpha1.ProxyTypeHTTPConnect:
+		u.Scheme = schemeHTTP
+	default:
+		return nil, fmt.Errorf(errUnknownProxyType, cfg.Type)
+	}
+
+	if ref := cfg.CredentialsSecretRef; ref != nil {
+		s := &corev1.Secret{}
+		if err := kube.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, s); err != nil {
+			return nil, fmt.Errorf(errGetCredentialsSecret, err)
+		}
+		u.User = url.UserPassword(
+			string(s.Data[xpv1.ResourceCredentialsSecretUserKey]),
+			string(s.Data[xpv1.ResourceCredentialsSecretPasswordKey]),
+		)
+	}
+	return u, nil
+}
+
+// NewDialer returns a go-hdb dialer that tunnels connections through the
+// proxy at u.
+func NewDialer(u *url.URL) (dial.Dialer, error) {
+	switch u.Scheme {
+	case schemeSOCKS5, schemeHTTP:
+		return &dialer{proxy: u}, nil
+	default:
+		return nil, fmt.Errorf(errUnknownProxyScheme, u.Scheme)
+	}
+}
+
+type dialer struct {
+	proxy *url.URL
+}
+
+func (d *dialer) DialContext(ctx context.Context, address string, options dial.DialerOptions) (net.Conn, error) {
+	forward := &net.Dialer{Timeout: options.Timeout, KeepAlive: options.TCPKeepAlive, KeepAliveConfig: options.TCPKeepAliveConfig}
+
+	if d.proxy.Scheme == schemeHTTP {
+		return dialHTTPConnect(ctx, forward, d.proxy, address)
+	}
+
+	var auth *xproxy.Auth
+	if d.proxy.User != nil {
+		password, _ := d.proxy.User.Password()
+		auth = &xproxy.Auth{User: d.proxy.User.Username(), Password: password}
+	}
+	socks, err := xproxy.SOCKS5("tcp", d.proxy.Host, auth, forward)
+	if err != nil {
+		return nil, fmt.Errorf(errConnectProxy, err)
+	}
+	// The SOCKS5 dialer of x/net always implements ContextDialer.
+	return socks.(xproxy.ContextDialer).DialContext(ctx, "tcp", address) //nolint:forcetypeassert
+}
+
+// dialHTTPConnect opens a tunnel to address through an HTTP CONNECT proxy.
+func dialHTTPConnect(ctx context.Context, forward *net.Dialer, proxy *url.URL, address string) (net.Conn, error) {
+	conn, err := forward.DialContext(ctx, "tcp", proxy.Host)
+	if err != nil {
+		return nil, fmt.Errorf(errConnectProxy, err)
+	}
+
+	req := &http.Request{
+		Method: http.MethodConnect,
+		URL:    &url.URL{Opaque: address},
+		Host:   address,
+		Header: http.Header{},
+	}
+	if proxy.User != nil {
+		password, _ := proxy.User.Password()
+		auth := base64.StdEncoding.EncodeToString([]byte(proxy.User.Username() + ":" + password))
+		req.Header.Set("Proxy-Authorization", "Basic "+auth)
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+		defer conn.SetDeadline(time.Time{}) //nolint:errcheck
+	}
+
+	if err := req.Write(conn); err != nil {
+		_ = conn.Close()
+		return nil, fmt.Errorf(errConnectProxy, err)
+	}
+	br := bufio.NewReader(conn)
+	resp, err := http.ReadResponse(br, req)
+	if err != nil {
+		_ = conn.Close()
+		return nil, fmt.Errorf(errConnectProxy, err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		_ = conn.Close()
+		return nil, fmt.Errorf(errProxyConnect, address, resp.Status)
+	}
+
+	if br.Buffered() > 0 {
+		return &bufferedConn{Conn: conn, r: br}, nil
+	}
+	return conn, nil
+}
+
+// bufferedConn is a net.Conn whose reads first drain data the proxy sent
+// along with its CONNECT response.
+type bufferedConn struct {
+	net.Conn
+	r *bufio.Reader
+}
+
+func (c *bufferedConn) Read(b []byte) (int, error) {
+	return c.r.Read(b)
+}
+
+// Transport returns a clone of the default HTTP transport that sends
+// requests through the proxy at u. A nil u returns the default transport.
+func Transport(u *url.URL) http.RoundTripper {
+	if u == nil {
+		return http.DefaultTransport
+	}
+	t, ok := http.DefaultTransport.(*http.Transport)
+	if !ok {
+		return http.DefaultTransport
+	}
+	t = t.Clone()
+	t.Proxy = http.ProxyURL(u)
+	return t
+}
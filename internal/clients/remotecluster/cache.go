@@ -0,0 +1,85 @@
+/*
+Copyright 2026 SAP SE or an SAP affiliate company and contributors.
+*/
+
+package remotecluster
+
+import (
+	"context"
+	"crypto/sha256"
+	"sync"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// DefaultCacheTTL is how long a remote client is reused before it is created
+// again from its kubeconfig.
+const DefaultCacheTTL = 10 * time.Minute
+
+// A Cache reuses the remote clients created from the same kubeconfig, so that
+// reconciles do not repeat the TLS handshake and discovery of the remote API
+// server. Clients are keyed by the hash of the kubeconfig, so a changed
+// kubeconfig gets a new client, and are evicted once they are older than the
+// TTL. A nil Cache creates a new client on every call.
+type Cache struct {
+	ttl    time.Duration
+	now    func() time.Time
+	create func(ctx context.Context, kubeconfigData []byte) (client.Client, error)
+
+	mu      sync.Mutex
+	entries map[[sha256.Size]byte]cacheEntry
+}
+
+type cacheEntry struct {
+	client  client.Client
+	created time.Time
+}
+
+// NewCache returns a Cache evicting clients after ttl.
+func NewCache(ttl time.Duration) *Cache {
+	return &Cache{
+		ttl:     ttl,
+		now:     time.Now,
+		create:  CreateRemoteClient,
+		entries: map[[sha256.Size]byte]cacheEntry{},
+	}
+}
+
+// Get returns the cached client for the kubeconfig, or creates one.
+func (c *Cache) Get(ctx context.Context, kubeconfigData []byte) (client.Client, error) {
+	if c == nil {
+		return CreateRemoteClient(ctx, kubeconfigData)
+	}
+
+	key := sha256.Sum256(kubeconfigData)
+	c.mu.Lock()
+	c.evict()
+	e, ok := c.entries[key]
+	c.mu.Unlock()
+	if ok {
+		return e.client, nil
+	}
+
+	// Clients are created without holding the lock, so that a slow remote
+	// cluster does not block the others
+	cl, err := c.create(ctx, kubeconfigData)
+	if err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	c.entries[key] = cacheEntry{client: cl, created: c.now()}
+	c.mu.Unlock()
+	return cl, nil
+}
+
+// evict removes the clients older than the TTL, including those of
+// kubeconfigs that are no longer used. The lock must be held.
+func (c *Cache) evict() {
+	now := c.now()
+	for key, e := range c.entries {
+		if now.Sub(e.created) >= c.ttl {
+			delete(c.entries, key)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+/*
+Copyright 2026 SAP SE or an SAP affiliate company and contributors.
+*/
+
+package remotecluster
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestCache(t *testing.T) {
+	now := time.Now()
+	created := 0
+	c := NewCache(time.Minute)
+	c.now = func() time.Time { return now }
+	c.create = func(_ context.Context, kubeconfigData []byte) (client.Client, error) {
+		if string(kubeconfigData) == "invalid" {
+			return nil, errors.New("invalid kubeconfig")
+		}
+		created++
+		return fake.NewClientBuilder().Build(), nil
+	}
+
+	first, err := c.Get(context.Background(), []byte("kubeconfig-a"))
+	if err != nil {
+		t.Fatalf("Get(...): %v", err)
+	}
+	if again, _ := c.Get(context.Background(), []byte("kubeconfig-a")); again != first || created != 1 {
+		t.Errorf("Get(...) of the same kubeconfig should reuse the client, created %d clients", created)
+	}
+
+	if other, _ := c.Get(context.Background(), []byte("kubeconfig-b")); other == first || created != 2 {
+		t.Errorf("Get(...) of another kubeconfig should create a new client, created %d clients", created)
+	}
+
+	if _, err := c.Get(context.Background(), []byte("invalid")); err == nil {
+		t.Errorf("Get(...) of an invalid kubeconfig should fail")
+	}
+
+	now = now.Add(time.Minute)
+	if expired, _ := c.Get(context.Background(), []byte("kubeconfig-a")); expired == first || created != 3 {
+		t.Errorf("Get(...) after the TTL should create a new client, created %d clients", created)
+	}
+	if len(c.entries) != 1 {
+		t.Errorf("Get(...) after the TTL should evict expired clients, %d cached", len(c.entries))
+	}
+}
+
+func TestNilCache(t *testing.T) {
+	var c *Cache
+	if _, err := c.Get(context.Background(), validKubeconfig()); err != nil {
+		t.Errorf("Get(...) on a nil Cache: %v", err)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
 )
 
 // DB is the query interface satisfied by *sql.DB and used by clients.
@@ -23,3 +24,16 @@ type Connector interface {
 func IsNoRows(err error) bool {
 	return errors.Is(err, sql.ErrNoRows)
 }
+
+// ScanRow copies the columns of the single row of a query into dest, like
+// (*sql.Row).Scan. A query cut short by its context, e.g. on a timeout, may
+// report no rows, so missing rows are only reported as sql.ErrNoRows while
+// ctx is alive. Otherwise a missing row would be taken for a missing object
+// and the object created again.
+func ScanRow(ctx context.Context, row *sql.Row, dest ...any) error {
+	err := row.Scan(dest...)
+	if IsNoRows(err) && ctx.Err() != nil {
+		return fmt.Errorf("query interrupted: %w", ctx.Err())
+	}
+	return err
+}
@@ -0,0 +1,54 @@
+package xsql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestScanRow(t *testing.T) {
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	cases := map[string]struct {
+		reason string
+		ctx    context.Context
+		rows   *sqlmock.Rows
+		want   error
+	}{
+		"Row": {
+			reason: "The row should be scanned",
+			ctx:    context.Background(),
+			rows:   sqlmock.NewRows([]string{"NAME"}).AddRow("DEMO"),
+		},
+		"NoRows": {
+			reason: "Missing rows of a completed query should be reported as no rows",
+			ctx:    context.Background(),
+			rows:   sqlmock.NewRows([]string{"NAME"}),
+			want:   sql.ErrNoRows,
+		},
+		"Interrupted": {
+			reason: "Missing rows of a query cut short by its context should be reported as an error, not as no rows",
+			ctx:    canceled,
+			rows:   sqlmock.NewRows([]string{"NAME"}),
+			want:   fmt.Errorf("query interrupted: %w", context.Canceled),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			db, mock, _ := sqlmock.New()
+			mock.ExpectQuery("SELECT").WillReturnRows(tc.rows)
+			var name string
+			err := ScanRow(tc.ctx, db.QueryRowContext(context.Background(), "SELECT"), &name)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nScanRow(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+/*
+Copyright 2026 SAP SE or an SAP affiliate company and contributors.
+*/
+
+package approval
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	apisv1alpha1 "github.com/SAP/crossplane-provider-hana/apis/v1alpha1"
+	"github.com/SAP/crossplane-provider-hana/internal/clients/hana/privilege"
+)
+
+// ErrApprovalPending is returned while high-risk grants wait for a second
+// identity's approval.
+var ErrApprovalPending = errors.New("high-risk grants need approval by a second identity")
+
+// ErrApprovalUnverified is returned for high-risk grants of a resource whose
+// approval annotations are not stamped by an admission webhook. Anyone who
+// can edit the resource could set them, so they are not trusted.
+var ErrApprovalUnverified = fmt.Errorf("%w, which cannot be verified without the admission webhook", ErrApprovalPending)
+
+// Check returns an error wrapping ErrApprovalPending if the given privileges or
+// roles contain high-risk grants that have not been approved by an identity
+// other than the requester. The approval annotations are only trusted if they
+// are stamped, i.e. an admission webhook for the kind of the resource records
+// who set them; otherwise high-risk grants stay pending. The outcome is
+// recorded in the Approval condition of the managed resource.
+func Check(mg resource.Managed, stamped bool, policy *apisv1alpha1.GrantPolicy, privileges, roles []string, defaultSchema string) error {
+	highRisk, err := privilege.HighRiskGrants(policy, privileges, roles, defaultSchema)
+	if err != nil {
+		return err
+	}
+	if len(highRisk) == 0 {
+		return nil
+	}
+
+	if !stamped {
+		err := fmt.Errorf("%w: %s", ErrApprovalUnverified, strings.Join(highRisk, ", "))
+		mg.SetConditions(apisv1alpha1.ApprovalPending(err))
+		return err
+	}
+
+	annotations := mg.GetAnnotations()
+	requester := annotations[apisv1alpha1.AnnotationRequestedBy]
+	approver := annotations[apisv1alpha1.AnnotationApprovedBy]
+	if requester == "" || approver == "" || approver == requester {
+		err := fmt.Errorf("%w: %s", ErrApprovalPending, strings.Join(highRisk, ", "))
+		mg.SetConditions(apisv1alpha1.ApprovalPending(err))
+		return err
+	}
+
+	mg.SetConditions(apisv1alpha1.Approved(approver))
+	return nil
+}
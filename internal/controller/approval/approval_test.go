@@ -0,0 +1,90 @@
+/*
+Copyright 2026 SAP SE or an SAP affiliate company and contributors.
+*/
+
+package approval
+
+import (
+	"errors"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/SAP/crossplane-provider-hana/apis/admin/v1alpha1"
+	apisv1alpha1 "github.com/SAP/crossplane-provider-hana/apis/v1alpha1"
+)
+
+func TestCheck(t *testing.T) {
+	policy := &apisv1alpha1.GrantPolicy{
+		HighRiskPrivileges: []string{"USER ADMIN"},
+		HighRiskRoles:      []string{"CONTENT_ADMIN"},
+	}
+
+	cases := map[string]struct {
+		reason      string
+		policy      *apisv1alpha1.GrantPolicy
+		annotations map[string]string
+		unstamped   bool
+		privileges  []string
+		roles       []string
+		wantPending bool
+		wantStatus  corev1.ConditionStatus
+	}{
+		"NoPolicy": {
+			reason:     "Without a policy nothing needs approval",
+			privileges: []string{"USER ADMIN"},
+		},
+		"NotHighRisk": {
+			reason:     "Grants that are not high-risk need no approval",
+			policy:     policy,
+			privileges: []string{"AUDIT READ"},
+			roles:      []string{"PUBLIC"},
+		},
+		"Pending": {
+			reason:      "High-risk grants without approval should be held back",
+			policy:      policy,
+			annotations: map[string]string{apisv1alpha1.AnnotationRequestedBy: "alice"},
+			privileges:  []string{"USER ADMIN"},
+			wantPending: true,
+			wantStatus:  corev1.ConditionFalse,
+		},
+		"SelfApproved": {
+			reason:      "The requester should not be able to approve their own grants",
+			policy:      policy,
+			annotations: map[string]string{apisv1alpha1.AnnotationRequestedBy: "alice", apisv1alpha1.AnnotationApprovedBy: "alice"},
+			roles:       []string{"CONTENT_ADMIN"},
+			wantPending: true,
+			wantStatus:  corev1.ConditionFalse,
+		},
+		"Approved": {
+			reason:      "High-risk grants approved by a second identity should proceed",
+			policy:      policy,
+			annotations: map[string]string{apisv1alpha1.AnnotationRequestedBy: "alice", apisv1alpha1.AnnotationApprovedBy: "bob"},
+			privileges:  []string{"USER ADMIN"},
+			wantStatus:  corev1.ConditionTrue,
+		},
+		"Unstamped": {
+			reason:      "Approvals should not be trusted if no admission webhook stamps the annotations",
+			policy:      policy,
+			annotations: map[string]string{apisv1alpha1.AnnotationRequestedBy: "alice", apisv1alpha1.AnnotationApprovedBy: "bob"},
+			unstamped:   true,
+			privileges:  []string{"USER ADMIN"},
+			wantPending: true,
+			wantStatus:  corev1.ConditionFalse,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cr := &v1alpha1.User{ObjectMeta: metav1.ObjectMeta{Annotations: tc.annotations}}
+			err := Check(cr, !tc.unstamped, tc.policy, tc.privileges, tc.roles, "DEFAULT_SCHEMA")
+			if got := errors.Is(err, ErrApprovalPending); got != tc.wantPending {
+				t.Errorf("\n%s\nCheck(...): want pending %t, got error %v", tc.reason, tc.wantPending, err)
+			}
+			if got := cr.GetCondition(apisv1alpha1.TypeApproval).Status; tc.wantStatus != "" && got != tc.wantStatus {
+				t.Errorf("\n%s\nCheck(...): want Approval condition %s, got %s", tc.reason, tc.wantStatus, got)
+			}
+		})
+	}
+}
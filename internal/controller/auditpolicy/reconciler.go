@@ -9,7 +9,6 @@ import (
 	"strings"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
-	corev1 "k8s.io/api/core/v1"
 
 	"github.com/pkg/errors"
 	"k8s.io/apimachinery/pkg/types"
@@ -24,20 +23,24 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 
+	"github.com/SAP/crossplane-provider-hana/internal/clients/hana"
 	"github.com/SAP/crossplane-provider-hana/internal/clients/hana/auditpolicy"
 	"github.com/SAP/crossplane-provider-hana/internal/clients/xsql"
 	"github.com/SAP/crossplane-provider-hana/internal/utils"
 
 	"github.com/SAP/crossplane-provider-hana/apis/admin/v1alpha1"
 	apisv1alpha1 "github.com/SAP/crossplane-provider-hana/apis/v1alpha1"
+	"github.com/SAP/crossplane-provider-hana/internal/controller/capability"
+	"github.com/SAP/crossplane-provider-hana/internal/controller/externalname"
 	"github.com/SAP/crossplane-provider-hana/internal/controller/features"
+	"github.com/SAP/crossplane-provider-hana/internal/controller/outcome"
+	"github.com/SAP/crossplane-provider-hana/internal/controller/poll"
 )
 
 const (
 	errNotAuditPolicy = "managed resource is not a AuditPolicy custom resource"
 	errTrackPCUsage   = "cannot track ProviderConfig usage"
-	errGetSecret      = "cannot get credentials Secret"
-	errNoSecretRef    = "ProviderConfig does not reference a credentials Secret"
+	errGetTLS         = "cannot get TLS configuration"
 	errGetPC          = "cannot get ProviderConfig"
 	errGetCreds       = "cannot get credentials"
 	errSelectPolicy   = "cannot select audit policy"
@@ -63,7 +66,7 @@ func Setup(mgr ctrl.Manager, o controller.Options, db xsql.Connector) error {
 	log := o.Logger.WithValues("controller", name)
 	r := managed.NewReconciler(mgr,
 		resource.ManagedKind(v1alpha1.AuditPolicyGroupVersionKind),
-		managed.WithExternalConnecter(&connector{
+		outcome.WithExternalConnecter(name, &connector{
 			kube:      mgr.GetClient(),
 			usage:     resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1alpha1.ProviderConfigUsage{}),
 			newClient: auditpolicy.New,
@@ -72,6 +75,7 @@ func Setup(mgr ctrl.Manager, o controller.Options, db xsql.Connector) error {
 		}),
 		managed.WithLogger(o.Logger.WithValues("controller", name)),
 		managed.WithPollInterval(o.PollInterval),
+		poll.WithJitterHook(v1alpha1.AuditPolicyKind, nil),
 		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
 		managed.WithConnectionPublishers(cps...),
 		features.ConfigureBetaManagementPolicies(o))
@@ -113,24 +117,32 @@ func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 		return nil, errors.Wrap(err, errGetPC)
 	}
 
-	ref := pc.Spec.Credentials.ConnectionSecretRef
-	if ref == nil {
-		return nil, errors.New(errNoSecretRef)
-	}
-
-	s := &corev1.Secret{}
-	if err := c.kube.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, s); err != nil {
-		return nil, errors.Wrap(err, errGetSecret)
+	data, err := hana.Credentials(ctx, c.kube, pc)
+	if err != nil {
+		return nil, err
 	}
 
 	c.log.Info("Connecting to auditpolicy resource", "name", cr.Name)
 
-	conn, err := c.db.Connect(ctx, s.Data)
+	creds, err := hana.ConnectionCredentials(ctx, c.kube, pc, data)
+	if err != nil {
+		return nil, errors.Wrap(err, errGetTLS)
+	}
+
+	conn, err := c.db.Connect(ctx, creds)
 	if err != nil {
 		c.log.Info("Error connecting to hana in auditpolicy", "name", cr.Name, "error", err)
 		return nil, errors.Wrap(err, errDbFail)
 	}
 
+	if err := hana.ReportActiveEndpoint(ctx, c.kube, pc, conn); err != nil {
+		c.log.Info("Cannot report active endpoint", "providerConfig", pc.Name, "error", err)
+	}
+
+	if err := hana.Require(ctx, conn, hana.ManageAuditPolicies); err != nil {
+		return capability.Disabled(err), nil
+	}
+
 	return &external{
 		client: c.newClient(conn),
 		kube:   c.kube,
@@ -180,6 +192,7 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 	cr.Status.AtProvider.AuditTrailRetention = observed.AuditTrailRetention
 	cr.Status.AtProvider.Enabled = observed.Enabled
 	cr.Status.AtProvider.AuditActions = observed.AuditActions
+	cr.Status.AtProvider.ExternalID = observed.PolicyName
 
 	cr.SetConditions(xpv1.Available())
 
@@ -190,8 +203,9 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 		"upToDate", isUpToDate)
 
 	return managed.ExternalObservation{
-		ResourceExists:   true,
-		ResourceUpToDate: isUpToDate,
+		ResourceExists:    true,
+		ResourceUpToDate:  isUpToDate,
+		ConnectionDetails: externalname.ConnectionDetails(cr.Status.AtProvider.ExternalID),
 	}, nil
 }
 
@@ -6,6 +6,7 @@ package auditpolicy
 
 import (
 	"context"
+	"fmt"
 	"testing"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
@@ -20,6 +21,7 @@ import (
 
 	"github.com/SAP/crossplane-provider-hana/apis/admin/v1alpha1"
 	apisv1alpha1 "github.com/SAP/crossplane-provider-hana/apis/v1alpha1"
+	"github.com/SAP/crossplane-provider-hana/internal/clients/hana"
 	"github.com/SAP/crossplane-provider-hana/internal/clients/hana/auditpolicy"
 	"github.com/SAP/crossplane-provider-hana/internal/clients/xsql"
 )
@@ -145,7 +147,7 @@ func TestConnect(t *testing.T) {
 					},
 				},
 			},
-			want: errors.New(errNoSecretRef),
+			want: fmt.Errorf(hana.ErrNoSecretRef),
 		},
 		"ErrGetConnectionSecret": {
 			reason: "An error should be returned if we can't get our ProviderConfig's connection secret",
@@ -172,7 +174,7 @@ func TestConnect(t *testing.T) {
 					},
 				},
 			},
-			want: errors.Wrap(errBoom, errGetSecret),
+			want: fmt.Errorf(hana.ErrGetSecret, errBoom),
 		},
 	}
 
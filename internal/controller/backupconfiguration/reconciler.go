@@ -0,0 +1,286 @@
+/*
+Copyright 2026 SAP SE.
+*/
+
+package backupconfiguration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/SAP/crossplane-provider-hana/apis/inventory/v1alpha1"
+	"github.com/SAP/crossplane-provider-hana/internal/clients/hanacloud"
+	"github.com/SAP/crossplane-provider-hana/internal/clients/hanacloud/backup"
+	"github.com/SAP/crossplane-provider-hana/internal/clients/hanacloud/operation"
+	"github.com/SAP/crossplane-provider-hana/internal/clients/proxy"
+	"github.com/SAP/crossplane-provider-hana/internal/controller/features"
+	"github.com/SAP/crossplane-provider-hana/internal/controller/outcome"
+	"github.com/SAP/crossplane-provider-hana/internal/controller/poll"
+)
+
+const (
+	errNotBackupConfiguration = "managed resource is not a BackupConfiguration custom resource"
+	errGetCredentialsSecret   = "cannot get admin credentials secret: %w"
+	errMissingCredentialsKey  = "credentials key %q not found in secret"
+	errParseCredentials       = "cannot parse admin API credentials: %w"
+	errGetProxy               = "cannot get proxy configuration: %w"
+	errConnectHANACloud       = "cannot connect to HANA Cloud API: %w"
+	errGetBackupConfiguration = "cannot get backup configuration: %w"
+	errUpdateBackup           = "cannot update backup configuration: %w"
+
+	msgOperationInProgress = "operation %s is applying the backup configuration"
+)
+
+// ClientFactory creates a backup.Client from credentials.
+// This allows injecting mock clients for testing.
+type ClientFactory func(ctx context.Context, creds hanacloud.AdminAPICredentials, log logging.Logger) (backup.Client, error)
+
+// DefaultClientFactory creates a real HANA Cloud client.
+func DefaultClientFactory(ctx context.Context, creds hanacloud.AdminAPICredentials, log logging.Logger) (backup.Client, error) {
+	client := hanacloud.New(log)
+	if err := client.Connect(ctx, creds); err != nil {
+		return nil, err
+	}
+	return client.Backup(), nil
+}
+
+// Setup adds a controller that reconciles BackupConfiguration managed resources.
+func Setup(mgr ctrl.Manager, o controller.Options) error {
+	name := managed.ControllerName(v1alpha1.BackupConfigurationGroupKind)
+
+	log := o.Logger.WithValues("controller", name)
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1alpha1.BackupConfigurationGroupVersionKind),
+		outcome.WithExternalConnecter(name, NewConnector(mgr.GetClient(), log, nil)),
+		managed.WithLogger(log),
+		managed.WithPollInterval(o.PollInterval),
+		poll.WithJitterHook(v1alpha1.BackupConfigurationKind, nil),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+		features.ConfigureBetaManagementPolicies(o),
+	)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		For(&v1alpha1.BackupConfiguration{}).
+		Complete(r)
+}
+
+// Connector produces an ExternalClient when its Connect method is called.
+// Connector is exported for testing.
+type Connector struct {
+	kube          client.Client
+	log           logging.Logger
+	clientFactory ClientFactory
+}
+
+// NewConnector creates a Connector with the given client factory.
+// If factory is nil, DefaultClientFactory is used.
+func NewConnector(kube client.Client, log logging.Logger, factory ClientFactory) *Connector {
+	if factory == nil {
+		factory = DefaultClientFactory
+	}
+	return &Connector{
+		kube:          kube,
+		log:           log,
+		clientFactory: factory,
+	}
+}
+
+// Connect establishes a connection to the HANA Cloud Admin API using credentials
+// from the referenced Secret.
+func (c *Connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1alpha1.BackupConfiguration)
+	if !ok {
+		return nil, errors.New(errNotBackupConfiguration)
+	}
+
+	secretRef := cr.Spec.ForProvider.AdminCredentialsSecretRef
+	secret := &corev1.Secret{}
+	if err := c.kube.Get(ctx, types.NamespacedName{
+		Namespace: secretRef.Namespace,
+		Name:      secretRef.Name,
+	}, secret); err != nil {
+		return nil, fmt.Errorf(errGetCredentialsSecret, err)
+	}
+
+	credentialsJSON, ok := secret.Data[secretRef.Key]
+	if !ok {
+		return nil, fmt.Errorf(errMissingCredentialsKey, secretRef.Key)
+	}
+
+	creds, err := hanacloud.ParseAdminAPICredentials(credentialsJSON)
+	if err != nil {
+		return nil, fmt.Errorf(errParseCredentials, err)
+	}
+
+	creds.ProxyURL, err = proxy.URL(ctx, c.kube, cr.Spec.ForProvider.Proxy)
+	if err != nil {
+		return nil, fmt.Errorf(errGetProxy, err)
+	}
+
+	bkpClient, err := c.clientFactory(ctx, creds, c.log.WithValues("backupconfiguration", cr.Name))
+	if err != nil {
+		return nil, fmt.Errorf(errConnectHANACloud, err)
+	}
+
+	return &external{
+		client:  bkpClient,
+		timeout: operation.DefaultTimeout,
+		log:     c.log,
+	}, nil
+}
+
+// external observes and updates the backup configuration of a HANA Cloud
+// instance.
+type external struct {
+	client  backup.Client
+	timeout time.Duration
+	log     logging.Logger
+}
+
+func (e *external) Disconnect(_ context.Context) error {
+	return nil
+}
+
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.BackupConfiguration)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotBackupConfiguration)
+	}
+
+	// Every instance has a backup configuration, there is nothing to delete
+	if meta.WasDeleted(cr) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	params := cr.Spec.ForProvider
+	status := &cr.Status.AtProvider
+
+	// Wait for the last update to finish before comparing, the settings
+	// only change once the operation has been applied
+	pending, err := operation.Resume(ctx, e.client, params.ServiceInstanceID, &status.Operation, e.timeout, time.Now())
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	if pending {
+		cr.SetConditions(xpv1.Unavailable().WithMessage(fmt.Sprintf(msgOperationInProgress, status.Operation.ID)))
+		return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, nil
+	}
+
+	current, err := e.client.Get(ctx, params.ServiceInstanceID)
+	if err != nil {
+		return managed.ExternalObservation{}, fmt.Errorf(errGetBackupConfiguration, err)
+	}
+
+	status.BackupSettings = observed(current)
+	_, differs := changed(params.BackupSettings, current)
+	if !differs {
+		cr.SetConditions(xpv1.Available())
+	}
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: !differs,
+	}, nil
+}
+
+func (e *external) Create(_ context.Context, _ resource.Managed) (managed.ExternalCreation, error) {
+	// The backup configuration of an instance always exists - nothing to create
+	return managed.ExternalCreation{}, nil
+}
+
+func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.BackupConfiguration)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotBackupConfiguration)
+	}
+
+	params := cr.Spec.ForProvider
+
+	current, err := e.client.Get(ctx, params.ServiceInstanceID)
+	if err != nil {
+		return managed.ExternalUpdate{}, fmt.Errorf(errGetBackupConfiguration, err)
+	}
+
+	toUpdate, differs := changed(params.BackupSettings, current)
+	if !differs {
+		return managed.ExternalUpdate{}, nil
+	}
+
+	e.log.Info("Updating backup configuration",
+		"name", cr.Name,
+		"serviceInstanceID", params.ServiceInstanceID)
+
+	opID, err := e.client.Update(ctx, params.ServiceInstanceID, toUpdate)
+	if err != nil {
+		return managed.ExternalUpdate{}, fmt.Errorf(errUpdateBackup, err)
+	}
+
+	operation.Start(&cr.Status.AtProvider.Operation, opID, time.Now())
+	cr.SetConditions(xpv1.Unavailable().WithMessage(fmt.Sprintf(msgOperationInProgress, opID)))
+	return managed.ExternalUpdate{}, nil
+}
+
+func (e *external) Delete(_ context.Context, _ resource.Managed) (managed.ExternalDelete, error) {
+	// Backups stay configured as they are, the Admin API has no way to
+	// restore the previous settings
+	return managed.ExternalDelete{}, nil
+}
+
+// changed returns the desired settings that differ from the current ones, and
+// whether there are any. Settings that are not desired are left out.
+func changed(desired v1alpha1.BackupSettings, current backup.Configuration) (backup.Configuration, bool) {
+	var out backup.Configuration
+	differs := false
+
+	if desired.RetentionDays != nil && (current.RetentionDays == nil || *current.RetentionDays != *desired.RetentionDays) {
+		out.RetentionDays = desired.RetentionDays
+		differs = true
+	}
+
+	if s := desired.Schedule; s != nil {
+		cur := current.Schedule
+		if cur == nil {
+			cur = &backup.Schedule{}
+		}
+		var schedule backup.Schedule
+		if s.Enabled != nil && (cur.Enabled == nil || *cur.Enabled != *s.Enabled) {
+			schedule.Enabled = s.Enabled
+		}
+		if s.StartTime != "" && cur.StartTime != s.StartTime {
+			schedule.StartTime = s.StartTime
+		}
+		if schedule != (backup.Schedule{}) {
+			out.Schedule = &schedule
+			differs = true
+		}
+	}
+
+	return out, differs
+}
+
+// observed returns the current settings of the instance.
+func observed(current backup.Configuration) v1alpha1.BackupSettings {
+	out := v1alpha1.BackupSettings{RetentionDays: current.RetentionDays}
+	if current.Schedule != nil {
+		out.Schedule = &v1alpha1.BackupSchedule{
+			Enabled:   current.Schedule.Enabled,
+			StartTime: current.Schedule.StartTime,
+		}
+	}
+	return out
+}
@@ -0,0 +1,283 @@
+/*
+Copyright 2026 SAP SE.
+*/
+
+package backupconfiguration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/SAP/crossplane-provider-hana/apis/inventory/v1alpha1"
+	"github.com/SAP/crossplane-provider-hana/internal/clients/hanacloud/backup"
+	"github.com/SAP/crossplane-provider-hana/internal/clients/hanacloud/operation"
+)
+
+// MockLogger is a mock implementation of logging.Logger
+type MockLogger struct{}
+
+func (l *MockLogger) Debug(_ string, _ ...interface{}) {}
+func (l *MockLogger) Info(_ string, _ ...interface{})  {}
+func (l *MockLogger) WithValues(_ ...interface{}) logging.Logger {
+	return l
+}
+
+// mockBackupClient mocks the backup.Client interface
+type mockBackupClient struct {
+	MockGet          func(ctx context.Context, serviceInstanceID string) (backup.Configuration, error)
+	MockUpdate       func(ctx context.Context, serviceInstanceID string, cfg backup.Configuration) (string, error)
+	MockGetOperation func(ctx context.Context, serviceInstanceID, operationID string) (operation.Operation, error)
+}
+
+func (m *mockBackupClient) Get(ctx context.Context, serviceInstanceID string) (backup.Configuration, error) {
+	return m.MockGet(ctx, serviceInstanceID)
+}
+
+func (m *mockBackupClient) Update(ctx context.Context, serviceInstanceID string, cfg backup.Configuration) (string, error) {
+	return m.MockUpdate(ctx, serviceInstanceID, cfg)
+}
+
+func (m *mockBackupClient) GetOperation(ctx context.Context, serviceInstanceID, operationID string) (operation.Operation, error) {
+	return m.MockGetOperation(ctx, serviceInstanceID, operationID)
+}
+
+var started = &metav1.Time{Time: time.Now()}
+
+func current(retentionDays int32, enabled bool, startTime string) func(context.Context, string) (backup.Configuration, error) {
+	return func(_ context.Context, _ string) (backup.Configuration, error) {
+		return backup.Configuration{
+			RetentionDays: &retentionDays,
+			Schedule:      &backup.Schedule{Enabled: &enabled, StartTime: startTime},
+		}, nil
+	}
+}
+
+func runningOperation(opID string) v1alpha1.OperationObservation {
+	return v1alpha1.OperationObservation{ID: opID, State: operation.StateInProgress, StartTime: started}
+}
+
+func backupConfiguration(opID string) *v1alpha1.BackupConfiguration {
+	var op v1alpha1.OperationObservation
+	if opID != "" {
+		op = runningOperation(opID)
+	}
+	return &v1alpha1.BackupConfiguration{
+		Spec: v1alpha1.BackupConfigurationSpec{
+			ForProvider: v1alpha1.BackupConfigurationParameters{
+				ServiceInstanceID: "test-instance-id",
+				BackupSettings: v1alpha1.BackupSettings{
+					RetentionDays: new(int32(30)),
+					Schedule:      &v1alpha1.BackupSchedule{StartTime: "02:00"},
+				},
+			},
+		},
+		Status: v1alpha1.BackupConfigurationStatus{
+			AtProvider: v1alpha1.BackupConfigurationObservation{Operation: op},
+		},
+	}
+}
+
+func settings(retentionDays int32, enabled bool, startTime string) v1alpha1.BackupSettings {
+	return v1alpha1.BackupSettings{
+		RetentionDays: &retentionDays,
+		Schedule:      &v1alpha1.BackupSchedule{Enabled: &enabled, StartTime: startTime},
+	}
+}
+
+func TestObserve(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type want struct {
+		o      managed.ExternalObservation
+		status v1alpha1.BackupConfigurationObservation
+		err    error
+	}
+
+	cases := map[string]struct {
+		reason string
+		client backup.Client
+		mg     resource.Managed
+		want   want
+	}{
+		"ErrNotBackupConfiguration": {
+			reason: "An error should be returned if the managed resource is not a *BackupConfiguration",
+			want: want{
+				err: errors.New(errNotBackupConfiguration),
+			},
+		},
+		"ErrGet": {
+			reason: "Any error getting the backup configuration should be returned",
+			client: &mockBackupClient{
+				MockGet: func(_ context.Context, _ string) (backup.Configuration, error) {
+					return backup.Configuration{}, errBoom
+				},
+			},
+			mg: backupConfiguration(""),
+			want: want{
+				err: fmt.Errorf(errGetBackupConfiguration, errBoom),
+			},
+		},
+		"UpToDate": {
+			reason: "The resource should be up to date if all desired settings have their value, whatever the other settings are",
+			client: &mockBackupClient{MockGet: current(30, false, "02:00")},
+			mg:     backupConfiguration(""),
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				status: v1alpha1.BackupConfigurationObservation{
+					BackupSettings: settings(30, false, "02:00"),
+				},
+			},
+		},
+		"RetentionDiffers": {
+			reason: "The resource should not be up to date if the retention differs",
+			client: &mockBackupClient{MockGet: current(14, true, "02:00")},
+			mg:     backupConfiguration(""),
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+				status: v1alpha1.BackupConfigurationObservation{
+					BackupSettings: settings(14, true, "02:00"),
+				},
+			},
+		},
+		"ScheduleDiffers": {
+			reason: "The resource should not be up to date if the schedule differs",
+			client: &mockBackupClient{MockGet: current(30, true, "23:00")},
+			mg:     backupConfiguration(""),
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+				status: v1alpha1.BackupConfigurationObservation{
+					BackupSettings: settings(30, true, "23:00"),
+				},
+			},
+		},
+		"OperationInProgress": {
+			reason: "A pending operation should be waited for without comparing the settings",
+			client: &mockBackupClient{
+				MockGetOperation: func(_ context.Context, _, opID string) (operation.Operation, error) {
+					return operation.Operation{ID: opID, State: operation.StateInProgress}, nil
+				},
+			},
+			mg: backupConfiguration("op-1"),
+			want: want{
+				o:      managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				status: v1alpha1.BackupConfigurationObservation{Operation: runningOperation("op-1")},
+			},
+		},
+		"OperationFailed": {
+			reason: "A failed operation should be recorded and the settings compared again",
+			client: &mockBackupClient{
+				MockGetOperation: func(_ context.Context, _, opID string) (operation.Operation, error) {
+					return operation.Operation{ID: opID, State: operation.StateFailed, Message: "retention out of range"}, nil
+				},
+				MockGet: current(14, true, "02:00"),
+			},
+			mg: backupConfiguration("op-1"),
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+				status: v1alpha1.BackupConfigurationObservation{
+					BackupSettings: settings(14, true, "02:00"),
+					Operation: v1alpha1.OperationObservation{
+						ID: "op-1", State: operation.StateFailed, Message: "retention out of range", StartTime: started,
+					},
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{client: tc.client, timeout: operation.DefaultTimeout, log: &MockLogger{}}
+			got, err := e.Observe(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if cr, ok := tc.mg.(*v1alpha1.BackupConfiguration); ok {
+				if diff := cmp.Diff(tc.want.status, cr.Status.AtProvider); diff != "" {
+					t.Errorf("\n%s\ne.Observe(...): -want status, +got status:\n%s\n", tc.reason, diff)
+				}
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type want struct {
+		updated *backup.Configuration
+		opID    string
+		err     error
+	}
+
+	cases := map[string]struct {
+		reason    string
+		get       func(context.Context, string) (backup.Configuration, error)
+		updateErr error
+		want      want
+	}{
+		"OnlyChangedSettings": {
+			reason: "Only the settings that differ should be sent and the operation recorded",
+			get:    current(30, true, "23:00"),
+			want: want{
+				updated: &backup.Configuration{Schedule: &backup.Schedule{StartTime: "02:00"}},
+				opID:    "op-1",
+			},
+		},
+		"NothingChanged": {
+			reason: "No update should be requested if all settings have their value",
+			get:    current(30, true, "02:00"),
+		},
+		"ErrUpdate": {
+			reason:    "Any error updating the backup configuration should be returned",
+			get:       current(14, true, "02:00"),
+			updateErr: errBoom,
+			want: want{
+				updated: &backup.Configuration{RetentionDays: new(int32(30))},
+				err:     fmt.Errorf(errUpdateBackup, errBoom),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var updated *backup.Configuration
+			e := &external{
+				client: &mockBackupClient{
+					MockGet: tc.get,
+					MockUpdate: func(_ context.Context, _ string, cfg backup.Configuration) (string, error) {
+						updated = &cfg
+						if tc.updateErr != nil {
+							return "", tc.updateErr
+						}
+						return "op-1", nil
+					},
+				},
+				log: &MockLogger{},
+			}
+			cr := backupConfiguration("")
+			_, err := e.Update(context.Background(), cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.updated, updated); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want settings, +got settings:\n%s\n", tc.reason, diff)
+			}
+			if got := cr.Status.AtProvider.Operation.ID; got != tc.want.opID {
+				t.Errorf("\n%s\ne.Update(...): want operation %q, got %q\n", tc.reason, tc.want.opID, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+/*
+Copyright 2026 SAP SE or an SAP affiliate company and contributors.
+*/
+
+// Package capability disables the management of resources the technical
+// user lacks the system privileges for, or that need a service the database
+// has not enabled.
+package capability
+
+import (
+	"context"
+	"errors"
+
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/SAP/crossplane-provider-hana/apis/v1alpha1"
+	"github.com/SAP/crossplane-provider-hana/internal/clients/hana"
+)
+
+// Disabled returns an ExternalClient for resources the technical user lacks
+// the privileges to manage, or whose service is not enabled, as reported by
+// err. It leaves the database untouched and reports the resources as
+// unavailable with the missing privilege or service, instead of failing each
+// reconcile with insufficient privilege errors. Deleting a resource fails
+// with err, as it cannot be dropped.
+func Disabled(err error) managed.ExternalClient {
+	return &disabled{err: err}
+}
+
+type disabled struct {
+	err error
+}
+
+func (d *disabled) Observe(_ context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	if meta.WasDeleted(mg) {
+		return managed.ExternalObservation{ResourceExists: true}, nil
+	}
+	if errors.Is(d.err, hana.ErrMissingService) {
+		mg.SetConditions(v1alpha1.MissingService(d.err))
+	} else {
+		mg.SetConditions(v1alpha1.MissingPrivilege(d.err))
+	}
+	return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, nil
+}
+
+func (d *disabled) Create(context.Context, resource.Managed) (managed.ExternalCreation, error) {
+	return managed.ExternalCreation{}, d.err
+}
+
+func (d *disabled) Update(context.Context, resource.Managed) (managed.ExternalUpdate, error) {
+	return managed.ExternalUpdate{}, d.err
+}
+
+func (d *disabled) Delete(context.Context, resource.Managed) (managed.ExternalDelete, error) {
+	return managed.ExternalDelete{}, d.err
+}
+
+func (d *disabled) Disconnect(context.Context) error {
+	return nil
+}
@@ -0,0 +1,79 @@
+/*
+Copyright 2026 SAP SE or an SAP affiliate company and contributors.
+*/
+
+package capability
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/SAP/crossplane-provider-hana/apis/admin/v1alpha1"
+	apisv1alpha1 "github.com/SAP/crossplane-provider-hana/apis/v1alpha1"
+	"github.com/SAP/crossplane-provider-hana/internal/clients/hana"
+)
+
+func TestDisabledObserve(t *testing.T) {
+	errMissing := errors.New("missing privilege: managing roles requires ROLE ADMIN, which the technical user does not hold")
+	errService := fmt.Errorf("%w: managing collections requires the docstore service, which is not enabled on the database", hana.ErrMissingService)
+
+	cases := map[string]struct {
+		reason    string
+		err       error
+		deleted   bool
+		want      managed.ExternalObservation
+		wantReady *xpv1.Condition
+	}{
+		"Disabled": {
+			reason:    "Resources should be reported up to date and unavailable with the missing privilege",
+			err:       errMissing,
+			want:      managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			wantReady: new(apisv1alpha1.MissingPrivilege(errMissing)),
+		},
+		"MissingService": {
+			reason:    "Resources should be reported unavailable with the missing service",
+			err:       errService,
+			want:      managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			wantReady: new(apisv1alpha1.MissingService(errService)),
+		},
+		"Deleted": {
+			reason:  "Deleted resources should be reported existing, so that deleting them fails with the missing privilege",
+			err:     errMissing,
+			deleted: true,
+			want:    managed.ExternalObservation{ResourceExists: true},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cr := &v1alpha1.Role{}
+			if tc.deleted {
+				cr.SetDeletionTimestamp(new(metav1.Now()))
+			}
+			e := Disabled(tc.err)
+			got, err := e.Observe(context.Background(), cr)
+			if err != nil {
+				t.Fatalf("\n%s\nObserve(...): %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nObserve(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if tc.wantReady != nil {
+				if diff := cmp.Diff(*tc.wantReady, cr.GetCondition(xpv1.TypeReady), test.EquateConditions()); diff != "" {
+					t.Errorf("\n%s\nObserve(...): -want condition, +got condition:\n%s\n", tc.reason, diff)
+				}
+			}
+			if _, err := e.Delete(context.Background(), cr); !errors.Is(err, tc.err) {
+				t.Errorf("\n%s\nDelete(...) = %v, want %v", tc.reason, err, tc.err)
+			}
+		})
+	}
+}
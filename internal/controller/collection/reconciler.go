@@ -0,0 +1,254 @@
+/*
+Copyright 2026 SAP SE or an SAP affiliate company and contributors.
+*/
+
+package collection
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/connection"
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/SAP/crossplane-provider-hana/internal/clients/hana"
+	"github.com/SAP/crossplane-provider-hana/internal/clients/hana/collection"
+	"github.com/SAP/crossplane-provider-hana/internal/clients/xsql"
+
+	"github.com/SAP/crossplane-provider-hana/apis/schema/v1alpha1"
+	apisv1alpha1 "github.com/SAP/crossplane-provider-hana/apis/v1alpha1"
+	"github.com/SAP/crossplane-provider-hana/internal/controller/capability"
+	"github.com/SAP/crossplane-provider-hana/internal/controller/externalname"
+	"github.com/SAP/crossplane-provider-hana/internal/controller/features"
+	"github.com/SAP/crossplane-provider-hana/internal/controller/outcome"
+	"github.com/SAP/crossplane-provider-hana/internal/controller/poll"
+)
+
+const (
+	errNotCollection    = "managed resource is not a Collection custom resource"
+	errTrackPCUsage     = "cannot track ProviderConfig usage: %w"
+	errGetPC            = "cannot get ProviderConfig: %w"
+	errGetTLS           = "cannot get TLS configuration: %w"
+	errGetSchema        = "cannot get referenced DbSchema: %w"
+	errSchemaNotReady   = "referenced DbSchema %s is not observed yet"
+	errSelectCollection = "cannot select collection: %w"
+	errCreateCollection = "cannot create collection: %w"
+	errDropCollection   = "cannot drop collection: %w"
+)
+
+// Setup adds a controller that reconciles Collection managed resources.
+func Setup(mgr ctrl.Manager, o controller.Options, db xsql.Connector) error {
+	name := managed.ControllerName(v1alpha1.CollectionGroupKind)
+
+	cps := []managed.ConnectionPublisher{managed.NewAPISecretPublisher(mgr.GetClient(), mgr.GetScheme())}
+	if o.Features.Enabled(features.EnableAlphaExternalSecretStores) {
+		cps = append(cps, connection.NewDetailsManager(mgr.GetClient(), apisv1alpha1.StoreConfigGroupVersionKind))
+	}
+
+	log := o.Logger.WithValues("controller", name)
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1alpha1.CollectionGroupVersionKind),
+		outcome.WithExternalConnecter(name, &connector{
+			kube:      mgr.GetClient(),
+			usage:     resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1alpha1.ProviderConfigUsage{}),
+			newClient: collection.New,
+			log:       log,
+			db:        db}),
+		managed.WithLogger(log),
+		managed.WithPollInterval(o.PollInterval),
+		poll.WithJitterHook(v1alpha1.CollectionKind, nil),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+		managed.WithConnectionPublishers(cps...),
+		features.ConfigureBetaManagementPolicies(o))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		For(&v1alpha1.Collection{}).
+		Complete(r)
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube      client.Client
+	usage     resource.Tracker
+	newClient func(db xsql.DB) collection.Client
+	log       logging.Logger
+	db        xsql.Connector
+}
+
+// Connect produces an ExternalClient for the database of the ProviderConfig
+// of the Collection. Collections are left unreconciled, as reported by their
+// Ready condition, unless the database has the document store enabled.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1alpha1.Collection)
+	if !ok {
+		return nil, errors.New(errNotCollection)
+	}
+
+	if err := c.usage.Track(ctx, mg); err != nil {
+		return nil, fmt.Errorf(errTrackPCUsage, err)
+	}
+
+	pc := &apisv1alpha1.ProviderConfig{}
+	if err := c.kube.Get(ctx, types.NamespacedName{Name: cr.GetProviderConfigReference().Name}, pc); err != nil {
+		return nil, fmt.Errorf(errGetPC, err)
+	}
+
+	data, err := hana.Credentials(ctx, c.kube, pc)
+	if err != nil {
+		return nil, err
+	}
+
+	creds, err := hana.ConnectionCredentials(ctx, c.kube, pc, data)
+	if err != nil {
+		return nil, fmt.Errorf(errGetTLS, err)
+	}
+
+	conn, err := c.db.Connect(ctx, creds)
+	if err != nil {
+		return nil, fmt.Errorf("cannot connect to HANA DB: %w", err)
+	}
+
+	if err := hana.ReportActiveEndpoint(ctx, c.kube, pc, conn); err != nil {
+		c.log.Info("Cannot report active endpoint", "providerConfig", pc.Name, "error", err)
+	}
+
+	if err := hana.Require(ctx, conn, hana.ManageCollections); err != nil {
+		return capability.Disabled(err), nil
+	}
+
+	return &external{
+		client:         c.newClient(conn),
+		kube:           c.kube,
+		log:            c.log,
+		identifierCase: pc.Spec.IdentifierCase,
+	}, nil
+}
+
+// An ExternalClient observes, then either creates or deletes a collection.
+// Collections have nothing to update.
+type external struct {
+	client         collection.CollectionClient
+	kube           client.Client
+	log            logging.Logger
+	identifierCase string
+}
+
+func (c *external) Disconnect(ctx context.Context) error {
+	return nil
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.Collection)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotCollection)
+	}
+
+	parameters, err := c.parameters(ctx, cr)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
+	observed, err := c.client.Read(ctx, parameters)
+	if err != nil {
+		return managed.ExternalObservation{}, fmt.Errorf(errSelectCollection, err)
+	}
+
+	if observed.CollectionName != parameters.CollectionName {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	cr.Status.AtProvider.CollectionName = observed.CollectionName
+	cr.Status.AtProvider.Schema = observed.Schema
+	cr.Status.AtProvider.ExternalID = externalname.Qualified(observed.Schema, observed.CollectionName)
+	cr.SetConditions(xpv1.Available())
+
+	return managed.ExternalObservation{
+		ResourceExists:    true,
+		ResourceUpToDate:  true,
+		ConnectionDetails: externalname.ConnectionDetails(cr.Status.AtProvider.ExternalID),
+	}, nil
+}
+
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.Collection)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotCollection)
+	}
+
+	parameters, err := c.parameters(ctx, cr)
+	if err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
+	cr.SetConditions(xpv1.Creating())
+
+	if err := c.client.Create(ctx, parameters); err != nil {
+		return managed.ExternalCreation{}, fmt.Errorf(errCreateCollection, err)
+	}
+
+	c.log.Info("Created collection", "name", cr.Name, "schema", parameters.Schema, "collectionName", parameters.CollectionName)
+	return managed.ExternalCreation{}, nil
+}
+
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	if _, ok := mg.(*v1alpha1.Collection); !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotCollection)
+	}
+	return managed.ExternalUpdate{}, nil
+}
+
+func (c *external) Delete(ctx context.Context, mg resource.Managed) (managed.ExternalDelete, error) {
+	cr, ok := mg.(*v1alpha1.Collection)
+	if !ok {
+		return managed.ExternalDelete{}, errors.New(errNotCollection)
+	}
+
+	parameters, err := c.parameters(ctx, cr)
+	if err != nil {
+		return managed.ExternalDelete{}, err
+	}
+
+	cr.SetConditions(xpv1.Deleting())
+
+	if err := c.client.Delete(ctx, parameters); err != nil {
+		return managed.ExternalDelete{}, fmt.Errorf(errDropCollection, err)
+	}
+
+	c.log.Info("Dropped collection", "name", cr.Name, "schema", parameters.Schema, "collectionName", parameters.CollectionName)
+	return managed.ExternalDelete{}, nil
+}
+
+// parameters returns the parameters of the collection with the identifiers
+// HANA stores, and the schema resolved from the referenced DbSchema if the
+// schema is not set.
+func (c *external) parameters(ctx context.Context, cr *v1alpha1.Collection) (*v1alpha1.CollectionParameters, error) {
+	schema := hana.FoldIdentifier(c.identifierCase, cr.Spec.ForProvider.Schema)
+	if ref := cr.Spec.ForProvider.SchemaRef; schema == "" && ref != nil {
+		dbSchema := &v1alpha1.DbSchema{}
+		if err := c.kube.Get(ctx, types.NamespacedName{Name: ref.Name}, dbSchema); err != nil {
+			return nil, fmt.Errorf(errGetSchema, err)
+		}
+		// The external ID is the name HANA stores for the schema
+		schema = dbSchema.Status.AtProvider.ExternalID
+		if schema == "" {
+			return nil, fmt.Errorf(errSchemaNotReady, ref.Name)
+		}
+	}
+	return &v1alpha1.CollectionParameters{
+		CollectionName: hana.FoldIdentifier(c.identifierCase, cr.Spec.ForProvider.CollectionName),
+		Schema:         schema,
+	}, nil
+}
@@ -0,0 +1,227 @@
+/*
+Copyright 2026 SAP SE or an SAP affiliate company and contributors.
+*/
+
+package collection
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/SAP/crossplane-provider-hana/apis/schema/v1alpha1"
+	apisv1alpha1 "github.com/SAP/crossplane-provider-hana/apis/v1alpha1"
+	"github.com/SAP/crossplane-provider-hana/internal/controller/externalname"
+)
+
+type mockClient struct {
+	MockRead   func(ctx context.Context, parameters *v1alpha1.CollectionParameters) (*v1alpha1.CollectionObservation, error)
+	MockCreate func(ctx context.Context, parameters *v1alpha1.CollectionParameters) error
+	MockDelete func(ctx context.Context, parameters *v1alpha1.CollectionParameters) error
+}
+
+func (m mockClient) Read(ctx context.Context, parameters *v1alpha1.CollectionParameters) (*v1alpha1.CollectionObservation, error) {
+	return m.MockRead(ctx, parameters)
+}
+
+func (m mockClient) Create(ctx context.Context, parameters *v1alpha1.CollectionParameters) error {
+	return m.MockCreate(ctx, parameters)
+}
+
+func (m mockClient) Delete(ctx context.Context, parameters *v1alpha1.CollectionParameters) error {
+	return m.MockDelete(ctx, parameters)
+}
+
+func newCollection(p v1alpha1.CollectionParameters) *v1alpha1.Collection {
+	return &v1alpha1.Collection{Spec: v1alpha1.CollectionSpec{ForProvider: p}}
+}
+
+// schemaGetter returns a client getting the DbSchema with the external ID.
+func schemaGetter(externalID string) client.Client {
+	return &test.MockClient{
+		MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+			if s, ok := obj.(*v1alpha1.DbSchema); ok {
+				s.Status.AtProvider.ExternalID = externalID
+			}
+			return nil
+		}),
+	}
+}
+
+func TestObserve(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		kube           client.Client
+		identifierCase string
+		observed       *v1alpha1.CollectionObservation
+		err            error
+	}
+
+	type want struct {
+		parameters *v1alpha1.CollectionParameters
+		o          managed.ExternalObservation
+		atProvider v1alpha1.CollectionObservation
+		err        error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		cr     *v1alpha1.Collection
+		want   want
+	}{
+		"ErrRead": {
+			reason: "Any errors encountered while reading the collection should be returned",
+			fields: fields{err: errBoom},
+			cr:     newCollection(v1alpha1.CollectionParameters{Schema: "APP", CollectionName: "Orders"}),
+			want: want{
+				parameters: &v1alpha1.CollectionParameters{Schema: "APP", CollectionName: "Orders"},
+				err:        fmt.Errorf(errSelectCollection, errBoom),
+			},
+		},
+		"NotFound": {
+			reason: "A collection that is not observed should not exist",
+			fields: fields{observed: &v1alpha1.CollectionObservation{}},
+			cr:     newCollection(v1alpha1.CollectionParameters{Schema: "APP", CollectionName: "Orders"}),
+			want: want{
+				parameters: &v1alpha1.CollectionParameters{Schema: "APP", CollectionName: "Orders"},
+			},
+		},
+		"Exists": {
+			reason: "An existing collection should be up to date and publish its qualified external ID",
+			fields: fields{observed: &v1alpha1.CollectionObservation{Schema: "APP", CollectionName: "Orders"}},
+			cr:     newCollection(v1alpha1.CollectionParameters{Schema: "APP", CollectionName: "Orders"}),
+			want: want{
+				parameters: &v1alpha1.CollectionParameters{Schema: "APP", CollectionName: "Orders"},
+				o:          managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true, ConnectionDetails: externalname.ConnectionDetails("APP.Orders")},
+				atProvider: v1alpha1.CollectionObservation{ExternalID: "APP.Orders", Schema: "APP", CollectionName: "Orders"},
+			},
+		},
+		"Folded": {
+			reason: "Identifiers should be folded as the ProviderConfig configures",
+			fields: fields{identifierCase: apisv1alpha1.IdentifierCaseUpper, observed: &v1alpha1.CollectionObservation{}},
+			cr:     newCollection(v1alpha1.CollectionParameters{Schema: "app", CollectionName: `"Orders"`}),
+			want: want{
+				parameters: &v1alpha1.CollectionParameters{Schema: "APP", CollectionName: "Orders"},
+			},
+		},
+		"SchemaRef": {
+			reason: "The schema of a referenced DbSchema should be the one HANA stores for it",
+			fields: fields{kube: schemaGetter("APP"), observed: &v1alpha1.CollectionObservation{}},
+			cr:     newCollection(v1alpha1.CollectionParameters{SchemaRef: &xpv1.Reference{Name: "app"}, CollectionName: "Orders"}),
+			want: want{
+				parameters: &v1alpha1.CollectionParameters{Schema: "APP", CollectionName: "Orders"},
+			},
+		},
+		"ErrSchemaNotReady": {
+			reason: "A referenced DbSchema that was not observed yet should fail the reconcile",
+			fields: fields{kube: schemaGetter("")},
+			cr:     newCollection(v1alpha1.CollectionParameters{SchemaRef: &xpv1.Reference{Name: "app"}, CollectionName: "Orders"}),
+			want: want{
+				err: fmt.Errorf(errSchemaNotReady, "app"),
+			},
+		},
+		"ErrGetSchema": {
+			reason: "Any errors encountered while getting the referenced DbSchema should be returned",
+			fields: fields{kube: &test.MockClient{MockGet: test.NewMockGetFn(errBoom)}},
+			cr:     newCollection(v1alpha1.CollectionParameters{SchemaRef: &xpv1.Reference{Name: "app"}, CollectionName: "Orders"}),
+			want: want{
+				err: fmt.Errorf(errGetSchema, errBoom),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var got *v1alpha1.CollectionParameters
+			e := external{
+				client: mockClient{
+					MockRead: func(_ context.Context, parameters *v1alpha1.CollectionParameters) (*v1alpha1.CollectionObservation, error) {
+						got = parameters
+						return tc.fields.observed, tc.fields.err
+					},
+				},
+				kube:           tc.fields.kube,
+				log:            logging.NewNopLogger(),
+				identifierCase: tc.fields.identifierCase,
+			}
+			o, err := e.Observe(context.Background(), tc.cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, o); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.parameters, got); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want parameters, +got parameters:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.atProvider, tc.cr.Status.AtProvider); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want atProvider, +got atProvider:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCreateDelete(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	cases := map[string]struct {
+		reason string
+		err    error
+		want   error
+	}{
+		"Success": {
+			reason: "Collections should be created and dropped in their schema",
+		},
+		"Err": {
+			reason: "Any errors encountered while creating or dropping the collection should be returned",
+			err:    errBoom,
+			want:   errBoom,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var created, dropped *v1alpha1.CollectionParameters
+			e := external{
+				client: mockClient{
+					MockCreate: func(_ context.Context, parameters *v1alpha1.CollectionParameters) error {
+						created = parameters
+						return tc.err
+					},
+					MockDelete: func(_ context.Context, parameters *v1alpha1.CollectionParameters) error {
+						dropped = parameters
+						return tc.err
+					},
+				},
+				log: logging.NewNopLogger(),
+			}
+			want := &v1alpha1.CollectionParameters{Schema: "APP", CollectionName: "Orders"}
+
+			_, err := e.Create(context.Background(), newCollection(*want))
+			if !errors.Is(err, tc.want) {
+				t.Errorf("\n%s\ne.Create(...) = %v, want %v", tc.reason, err, tc.want)
+			}
+			if diff := cmp.Diff(want, created); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want parameters, +got parameters:\n%s\n", tc.reason, diff)
+			}
+
+			_, err = e.Delete(context.Background(), newCollection(*want))
+			if !errors.Is(err, tc.want) {
+				t.Errorf("\n%s\ne.Delete(...) = %v, want %v", tc.reason, err, tc.want)
+			}
+			if diff := cmp.Diff(want, dropped); diff != "" {
+				t.Errorf("\n%s\ne.Delete(...): -want parameters, +got parameters:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,159 @@
+/*
+Copyright 2026 SAP SE or an SAP affiliate company and contributors.
+*/
+
+package connectionpropagation
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	apisv1alpha1 "github.com/SAP/crossplane-provider-hana/apis/v1alpha1"
+)
+
+// Kind names the controller, as it reconciles Deployments rather than a kind
+// of the provider.
+const Kind = "ConnectionPropagation"
+
+const (
+	errGetDeployment   = "cannot get Deployment: %w"
+	errGetSecret       = "cannot get connection secret %s/%s: %w"
+	errListDeployments = "cannot list Deployments: %w"
+	errPatchDeployment = "cannot patch Deployment: %w"
+)
+
+// Setup adds a controller that restarts the Deployments annotated to consume
+// connection secrets when the data of the secrets changes.
+func Setup(mgr ctrl.Manager, o controller.Options) error {
+	name := strings.ToLower(Kind)
+	log := o.Logger.WithValues("controller", name)
+
+	r := &Reconciler{kube: mgr.GetClient(), log: log}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(o.ForControllerRuntime()).
+		For(&appsv1.Deployment{}, builder.WithPredicates(predicate.NewPredicateFuncs(func(obj client.Object) bool {
+			return len(secretNames(obj)) > 0
+		}))).
+		Watches(&corev1.Secret{}, handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []reconcile.Request {
+			return r.consumers(ctx, obj)
+		})).
+		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
+}
+
+// A Reconciler sets the hash of the connection secrets a Deployment consumes
+// on its pod template, so that changing secrets roll its pods out.
+type Reconciler struct {
+	kube client.Client
+	log  logging.Logger
+}
+
+// Reconcile updates the connection secret hash of a Deployment.
+func (r *Reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	d := &appsv1.Deployment{}
+	if err := r.kube.Get(ctx, req.NamespacedName, d); err != nil {
+		if kerrors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, fmt.Errorf(errGetDeployment, err)
+	}
+	names := secretNames(d)
+	if len(names) == 0 || d.GetDeletionTimestamp() != nil {
+		return reconcile.Result{}, nil
+	}
+
+	hash, err := r.hash(ctx, d.GetNamespace(), names)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	if d.Spec.Template.GetAnnotations()[apisv1alpha1.AnnotationConnectionSecretHash] == hash {
+		return reconcile.Result{}, nil
+	}
+
+	patch := client.MergeFrom(d.DeepCopy())
+	if d.Spec.Template.Annotations == nil {
+		d.Spec.Template.Annotations = map[string]string{}
+	}
+	d.Spec.Template.Annotations[apisv1alpha1.AnnotationConnectionSecretHash] = hash
+	if err := r.kube.Patch(ctx, d, patch); err != nil {
+		return reconcile.Result{}, fmt.Errorf(errPatchDeployment, err)
+	}
+
+	r.log.Info("Restarting Deployment for changed connection secrets", "namespace", d.GetNamespace(), "name", d.GetName(), "secrets", names)
+	return reconcile.Result{}, nil
+}
+
+// hash returns the hash of the data of the named secrets. Missing secrets are
+// hashed as empty, so that the Deployment restarts once they are created.
+func (r *Reconciler) hash(ctx context.Context, namespace string, names []string) (string, error) {
+	h := sha256.New()
+	for _, name := range names {
+		s := &corev1.Secret{}
+		if err := r.kube.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, s); err != nil {
+			if !kerrors.IsNotFound(err) {
+				return "", fmt.Errorf(errGetSecret, namespace, name, err)
+			}
+		}
+		keys := make([]string, 0, len(s.Data))
+		for k := range s.Data {
+			keys = append(keys, k)
+		}
+		slices.Sort(keys)
+		fmt.Fprintf(h, "%s\x00%d\x00", name, len(keys))
+		for _, k := range keys {
+			fmt.Fprintf(h, "%s\x00%d\x00", k, len(s.Data[k]))
+			h.Write(s.Data[k])
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// consumers enqueues the Deployments in the namespace of a secret that are
+// annotated to consume it.
+func (r *Reconciler) consumers(ctx context.Context, obj client.Object) []reconcile.Request {
+	deployments := &appsv1.DeploymentList{}
+	if err := r.kube.List(ctx, deployments, client.InNamespace(obj.GetNamespace())); err != nil {
+		r.log.Info("Cannot enqueue consumers of connection secret", "namespace", obj.GetNamespace(), "name", obj.GetName(), "error", fmt.Errorf(errListDeployments, err))
+		return nil
+	}
+	var reqs []reconcile.Request
+	for i := range deployments.Items {
+		d := &deployments.Items[i]
+		if slices.Contains(secretNames(d), obj.GetName()) {
+			reqs = append(reqs, reconcile.Request{NamespacedName: types.NamespacedName{Namespace: d.GetNamespace(), Name: d.GetName()}})
+		}
+	}
+	return reqs
+}
+
+// secretNames returns the sorted names of the connection secrets an object is
+// annotated to consume.
+func secretNames(obj client.Object) []string {
+	var names []string
+	for _, name := range strings.Split(obj.GetAnnotations()[apisv1alpha1.AnnotationRestartOnSecretChange], ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	return slices.Compact(names)
+}
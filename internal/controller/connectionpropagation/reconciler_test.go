@@ -0,0 +1,160 @@
+/*
+Copyright 2026 SAP SE or an SAP affiliate company and contributors.
+*/
+
+package connectionpropagation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	apisv1alpha1 "github.com/SAP/crossplane-provider-hana/apis/v1alpha1"
+)
+
+func newDeployment(secrets, hash string) *appsv1.Deployment {
+	d := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Namespace: "app", Name: "api"}}
+	if secrets != "" {
+		d.SetAnnotations(map[string]string{apisv1alpha1.AnnotationRestartOnSecretChange: secrets})
+	}
+	if hash != "" {
+		d.Spec.Template.SetAnnotations(map[string]string{apisv1alpha1.AnnotationConnectionSecretHash: hash})
+	}
+	return d
+}
+
+// kube returns a client holding the Deployment and the secrets, which records
+// the hash the Deployment is patched with.
+func kube(d *appsv1.Deployment, secrets map[string]map[string][]byte, patched *string) client.Client {
+	return &test.MockClient{
+		MockGet: func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+			switch o := obj.(type) {
+			case *appsv1.Deployment:
+				d.DeepCopyInto(o)
+			case *corev1.Secret:
+				data, ok := secrets[key.Name]
+				if !ok {
+					return kerrors.NewNotFound(schema.GroupResource{Resource: "secrets"}, key.Name)
+				}
+				o.Data = data
+			}
+			return nil
+		},
+		MockPatch: func(_ context.Context, obj client.Object, _ client.Patch, _ ...client.PatchOption) error {
+			*patched = obj.(*appsv1.Deployment).Spec.Template.GetAnnotations()[apisv1alpha1.AnnotationConnectionSecretHash]
+			return nil
+		},
+	}
+}
+
+func TestReconcile(t *testing.T) {
+	secrets := map[string]map[string][]byte{"db": {"user": []byte("APP"), "password": []byte("Secret1")}}
+	rotated := map[string]map[string][]byte{"db": {"user": []byte("APP"), "password": []byte("Secret2")}}
+
+	hash := func(secrets map[string]map[string][]byte, names ...string) string {
+		h, err := (&Reconciler{kube: kube(nil, secrets, nil)}).hash(context.Background(), "app", names)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return h
+	}
+
+	cases := map[string]struct {
+		reason  string
+		d       *appsv1.Deployment
+		secrets map[string]map[string][]byte
+		want    string
+	}{
+		"NotAnnotated": {
+			reason:  "A Deployment not consuming connection secrets should not be patched",
+			d:       newDeployment("", ""),
+			secrets: secrets,
+		},
+		"FirstHash": {
+			reason:  "The hash of the secrets should be set on the pod template of a newly annotated Deployment",
+			d:       newDeployment("db", ""),
+			secrets: secrets,
+			want:    hash(secrets, "db"),
+		},
+		"Unchanged": {
+			reason:  "A Deployment should not be restarted if its secrets did not change",
+			d:       newDeployment("db", hash(secrets, "db")),
+			secrets: secrets,
+		},
+		"Rotated": {
+			reason:  "A Deployment should be restarted if its secrets changed",
+			d:       newDeployment("db", hash(secrets, "db")),
+			secrets: rotated,
+			want:    hash(rotated, "db"),
+		},
+		"MissingSecret": {
+			reason:  "A missing secret should be hashed as empty",
+			d:       newDeployment("db, cache", hash(secrets, "db")),
+			secrets: secrets,
+			want:    hash(secrets, "cache", "db"),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var patched string
+			r := &Reconciler{kube: kube(tc.d, tc.secrets, &patched), log: logging.NewNopLogger()}
+			if _, err := r.Reconcile(context.Background(), reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "app", Name: "api"}}); err != nil {
+				t.Fatalf("\n%s\nr.Reconcile(...): unexpected error: %v\n", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want, patched); diff != "" {
+				t.Errorf("\n%s\nr.Reconcile(...): -want patched hash, +got patched hash:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestReconcileGetSecretError(t *testing.T) {
+	errBoom := errors.New("boom")
+	r := &Reconciler{
+		kube: &test.MockClient{MockGet: func(_ context.Context, _ client.ObjectKey, obj client.Object) error {
+			if d, ok := obj.(*appsv1.Deployment); ok {
+				newDeployment("db", "").DeepCopyInto(d)
+				return nil
+			}
+			return errBoom
+		}},
+		log: logging.NewNopLogger(),
+	}
+	_, err := r.Reconcile(context.Background(), reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "app", Name: "api"}})
+	if diff := cmp.Diff(fmt.Errorf(errGetSecret, "app", "db", errBoom), err, test.EquateErrors()); diff != "" {
+		t.Errorf("r.Reconcile(...): -want error, +got error:\n%s\n", diff)
+	}
+}
+
+func TestConsumers(t *testing.T) {
+	r := &Reconciler{
+		kube: &test.MockClient{MockList: func(_ context.Context, list client.ObjectList, _ ...client.ListOption) error {
+			list.(*appsv1.DeploymentList).Items = []appsv1.Deployment{
+				*newDeployment("db,cache", ""),
+				{ObjectMeta: metav1.ObjectMeta{Namespace: "app", Name: "worker", Annotations: map[string]string{apisv1alpha1.AnnotationRestartOnSecretChange: "cache"}}},
+				{ObjectMeta: metav1.ObjectMeta{Namespace: "app", Name: "web"}},
+			}
+			return nil
+		}},
+		log: logging.NewNopLogger(),
+	}
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "app", Name: "db"}}
+	want := []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: "app", Name: "api"}}}
+	if diff := cmp.Diff(want, r.consumers(context.Background(), secret)); diff != "" {
+		t.Errorf("r.consumers(...): -want, +got:\n%s\n", diff)
+	}
+}
@@ -8,9 +8,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
-	corev1 "k8s.io/api/core/v1"
 
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -23,12 +23,20 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 
+	"github.com/SAP/crossplane-provider-hana/internal/clients/hana"
 	"github.com/SAP/crossplane-provider-hana/internal/clients/hana/dbschema"
+	"github.com/SAP/crossplane-provider-hana/internal/clients/hana/privilege"
 	"github.com/SAP/crossplane-provider-hana/internal/clients/xsql"
+	"github.com/SAP/crossplane-provider-hana/internal/utils"
 
+	adminv1alpha1 "github.com/SAP/crossplane-provider-hana/apis/admin/v1alpha1"
 	"github.com/SAP/crossplane-provider-hana/apis/schema/v1alpha1"
 	apisv1alpha1 "github.com/SAP/crossplane-provider-hana/apis/v1alpha1"
+	"github.com/SAP/crossplane-provider-hana/internal/controller/capability"
+	"github.com/SAP/crossplane-provider-hana/internal/controller/externalname"
 	"github.com/SAP/crossplane-provider-hana/internal/controller/features"
+	"github.com/SAP/crossplane-provider-hana/internal/controller/outcome"
+	"github.com/SAP/crossplane-provider-hana/internal/controller/poll"
 )
 
 const (
@@ -36,12 +44,15 @@ const (
 	errTrackPCUsage = "cannot track ProviderConfig usage: %w"
 	errGetPC        = "cannot get ProviderConfig: %w"
 	errGetCreds     = "cannot get credentials: %w"
-	errGetSecret    = "cannot get credentials Secret: %w"
-	errNoSecretRef  = "ProviderConfig does not reference a credentials Secret"
+	errGetTLS       = "cannot get TLS configuration: %w"
 	errNewClient    = "cannot create new Service: %w"
 	errSelectSchema = "cannot select schema: %w"
 	errCreateSchema = "cannot create schema: %w"
 	errDropSchema   = "cannot drop schema: %w"
+	errReadComment  = "cannot read schema comment: %w"
+	errSetComment   = "cannot set schema comment: %w"
+	errDependents   = "cannot list resources depending on the schema: %w"
+	errBlocked      = "schema is still used by %s"
 )
 
 // A NoOpService does nothing.
@@ -59,7 +70,7 @@ func Setup(mgr ctrl.Manager, o controller.Options, db xsql.Connector) error {
 	log := o.Logger.WithValues("controller", name)
 	r := managed.NewReconciler(mgr,
 		resource.ManagedKind(v1alpha1.DbSchemaGroupVersionKind),
-		managed.WithExternalConnecter(&connector{
+		outcome.WithExternalConnecter(name, &connector{
 			kube:      mgr.GetClient(),
 			usage:     resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1alpha1.ProviderConfigUsage{}),
 			newClient: dbschema.New,
@@ -67,6 +78,7 @@ func Setup(mgr ctrl.Manager, o controller.Options, db xsql.Connector) error {
 			db:        db}),
 		managed.WithLogger(log),
 		managed.WithPollInterval(o.PollInterval),
+		poll.WithJitterHook(v1alpha1.DbSchemaKind, nil),
 		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
 		managed.WithConnectionPublishers(cps...),
 		features.ConfigureBetaManagementPolicies(o))
@@ -107,27 +119,38 @@ func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 		return nil, fmt.Errorf(errGetPC, err)
 	}
 
-	ref := pc.Spec.Credentials.ConnectionSecretRef
-	if ref == nil {
-		return nil, errors.New(errNoSecretRef)
-	}
-
-	s := &corev1.Secret{}
-	if err := c.kube.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, s); err != nil {
-		return nil, fmt.Errorf(errGetSecret, err)
+	data, err := hana.Credentials(ctx, c.kube, pc)
+	if err != nil {
+		return nil, err
 	}
 
 	c.log.Info("Connecting to dbschema resource", "name", cr.Name)
 
-	conn, err := c.db.Connect(ctx, s.Data)
+	creds, err := hana.ConnectionCredentials(ctx, c.kube, pc, data)
+	if err != nil {
+		return nil, fmt.Errorf(errGetTLS, err)
+	}
+
+	conn, err := c.db.Connect(ctx, creds)
 	if err != nil {
 		return nil, fmt.Errorf("cannot connect to HANA DB: %w", err)
 	}
 
+	if err := hana.ReportActiveEndpoint(ctx, c.kube, pc, conn); err != nil {
+		c.log.Info("Cannot report active endpoint", "providerConfig", pc.Name, "error", err)
+	}
+
+	if err := hana.Require(ctx, conn, hana.ManageSchemas); err != nil {
+		return capability.Disabled(err), nil
+	}
+
 	return &external{
-		client: c.newClient(conn),
-		kube:   c.kube,
-		log:    c.log,
+		client:         c.newClient(conn),
+		kube:           c.kube,
+		log:            c.log,
+		comments:       pc.Spec.ObjectComments,
+		identifierCase: pc.Spec.IdentifierCase,
+		db:             conn,
 	}, nil
 }
 
@@ -138,9 +161,12 @@ func (c *external) Disconnect(ctx context.Context) error {
 // An ExternalClient observes, then either creates, updates, or deletes an
 // external resource to ensure it reflects the managed resource's desired state.
 type external struct {
-	client dbschema.DbSchemaClient
-	kube   client.Client
-	log    logging.Logger
+	client         dbschema.DbSchemaClient
+	kube           client.Client
+	log            logging.Logger
+	comments       *apisv1alpha1.ObjectCommentsConfig
+	identifierCase string
+	db             xsql.DB
 }
 
 func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
@@ -152,9 +178,9 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 	c.log.Info("Observing dbschema resource", "name", cr.Name)
 
 	// Preserve the original case for schema name because HANA uses double-quoted
-	// identifiers which are case-sensitive
+	// identifiers which are case-sensitive, unless the ProviderConfig folds them
 	parameters := &v1alpha1.DbSchemaParameters{
-		SchemaName: cr.Spec.ForProvider.SchemaName,
+		SchemaName: c.schemaName(cr),
 	}
 
 	observed, err := c.client.Read(ctx, parameters)
@@ -169,16 +195,24 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 		return managed.ExternalObservation{ResourceExists: false}, nil
 	}
 
+	cr.Status.AtProvider.ExternalID = observed.SchemaName
 	cr.SetConditions(xpv1.Available())
 
+	isUpToDate, err := c.isCommentUpToDate(ctx, cr)
+	if err != nil {
+		c.log.Info("Error reading dbschema comment", "name", cr.Name, "error", err)
+		return managed.ExternalObservation{}, fmt.Errorf(errReadComment, err)
+	}
+
 	c.log.Info("Observed dbschema resource",
 		"name", cr.Name,
 		"schemaName", parameters.SchemaName,
-		"upToDate", true)
+		"upToDate", isUpToDate)
 
 	return managed.ExternalObservation{
-		ResourceExists:   true,
-		ResourceUpToDate: true,
+		ResourceExists:    true,
+		ResourceUpToDate:  isUpToDate,
+		ConnectionDetails: externalname.ConnectionDetails(cr.Status.AtProvider.ExternalID),
 	}, nil
 }
 
@@ -191,8 +225,8 @@ func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 	c.log.Info("Creating dbschema resource", "name", cr.Name, "schemaName", cr.Spec.ForProvider.SchemaName)
 
 	parameters := &v1alpha1.DbSchemaParameters{
-		SchemaName: cr.Spec.ForProvider.SchemaName,
-		Owner:      cr.Spec.ForProvider.Owner,
+		SchemaName: c.schemaName(cr),
+		Owner:      hana.FoldIdentifier(c.identifierCase, cr.Spec.ForProvider.Owner),
 	}
 
 	c.log.Info("Creating dbschema with parameters",
@@ -208,6 +242,11 @@ func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 		return managed.ExternalCreation{}, fmt.Errorf(errCreateSchema, err)
 	}
 
+	// The schema exists now, so a missing comment is left to the next update
+	if err := c.updateComment(ctx, cr); err != nil {
+		c.log.Info("Error setting dbschema comment", "name", cr.Name, "error", err)
+	}
+
 	c.log.Info("Successfully created dbschema resource", "name", cr.Name, "schemaName", parameters.SchemaName)
 	return managed.ExternalCreation{}, nil
 }
@@ -223,6 +262,11 @@ func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.Ext
 	// Replace the fmt.Printf with proper logging
 	c.log.Info("Update details", "resource", cr)
 
+	if err := c.updateComment(ctx, cr); err != nil {
+		c.log.Info("Error setting dbschema comment", "name", cr.Name, "error", err)
+		return managed.ExternalUpdate{}, err
+	}
+
 	c.log.Info("Successfully updated dbschema resource", "name", cr.Name, "schemaName", cr.Spec.ForProvider.SchemaName)
 	return managed.ExternalUpdate{
 		// Optionally return any details that may be required to connect to the
@@ -240,11 +284,22 @@ func (c *external) Delete(ctx context.Context, mg resource.Managed) (managed.Ext
 	c.log.Info("Deleting dbschema resource", "name", cr.Name, "schemaName", cr.Spec.ForProvider.SchemaName)
 
 	parameters := &v1alpha1.DbSchemaParameters{
-		SchemaName: cr.Spec.ForProvider.SchemaName,
+		SchemaName: c.schemaName(cr),
 	}
 
 	cr.SetConditions(xpv1.Deleting())
 
+	if cr.GetAnnotations()[apisv1alpha1.AnnotationDeletionPolicy] != apisv1alpha1.DeletionPolicyCascade {
+		dependents, err := c.dependents(ctx, cr.Name, parameters.SchemaName)
+		if err != nil {
+			return managed.ExternalDelete{}, fmt.Errorf(errDependents, err)
+		}
+		if len(dependents) > 0 {
+			cr.SetConditions(apisv1alpha1.DeletionBlocked(dependents))
+			return managed.ExternalDelete{}, fmt.Errorf(errBlocked, strings.Join(dependents, ", "))
+		}
+	}
+
 	err := c.client.Delete(ctx, parameters)
 
 	if err != nil {
@@ -255,3 +310,78 @@ func (c *external) Delete(ctx context.Context, mg resource.Managed) (managed.Ext
 	c.log.Info("Successfully deleted dbschema resource", "name", cr.Name, "schemaName", parameters.SchemaName)
 	return managed.ExternalDelete{}, err
 }
+
+// schemaName returns the name HANA stores for the schema.
+func (c *external) schemaName(cr *v1alpha1.DbSchema) string {
+	return hana.FoldIdentifier(c.identifierCase, cr.Spec.ForProvider.SchemaName)
+}
+
+// privileges returns the privileges of a dependent with the identifiers HANA
+// stores.
+func (c *external) privileges(privilegeStrings []string) []string {
+	if c.identifierCase != apisv1alpha1.IdentifierCaseUpper {
+		return privilegeStrings
+	}
+	return privilege.FoldPrivilegeStrings(privilegeStrings)
+}
+
+// dependents returns the managed Users and Roles that are granted privileges
+// on the schema or on objects in it, and the managed Collections in it.
+func (c *external) dependents(ctx context.Context, name, schemaName string) ([]string, error) {
+	var dependents []string
+
+	users := &adminv1alpha1.UserList{}
+	if err := c.kube.List(ctx, users); err != nil {
+		return nil, err
+	}
+	for _, u := range users.Items {
+		if privilege.ReferencesSchema(c.privileges(privilege.WithTypedPrivileges(u.Spec.ForProvider.Privileges, u.Spec.ForProvider.TypedPrivileges)), schemaName) {
+			dependents = append(dependents, adminv1alpha1.UserKind+"/"+u.Name)
+		}
+	}
+
+	roles := &adminv1alpha1.RoleList{}
+	if err := c.kube.List(ctx, roles); err != nil {
+		return nil, err
+	}
+	for _, r := range roles.Items {
+		if privilege.ReferencesSchema(c.privileges(privilege.WithTypedPrivileges(r.Spec.ForProvider.Privileges, r.Spec.ForProvider.TypedPrivileges)), schemaName) {
+			dependents = append(dependents, adminv1alpha1.RoleKind+"/"+r.Name)
+		}
+	}
+
+	collections := &v1alpha1.CollectionList{}
+	if err := c.kube.List(ctx, collections); err != nil {
+		return nil, err
+	}
+	for _, col := range collections.Items {
+		p := col.Spec.ForProvider
+		if hana.FoldIdentifier(c.identifierCase, p.Schema) == schemaName || (p.Schema == "" && p.SchemaRef != nil && p.SchemaRef.Name == name) {
+			dependents = append(dependents, v1alpha1.CollectionKind+"/"+col.Name)
+		}
+	}
+	return dependents, nil
+}
+
+// isCommentUpToDate ignores the comment unless the ProviderConfig maintains
+// comments.
+func (c *external) isCommentUpToDate(ctx context.Context, cr *v1alpha1.DbSchema) (bool, error) {
+	comment := hana.ManagedComment(c.comments, v1alpha1.DbSchemaKind, cr.Name)
+	if comment == "" {
+		return true, nil
+	}
+	observed, err := hana.ReadComment(ctx, c.db, hana.CommentOnSchema, c.schemaName(cr))
+	return observed == comment, err
+}
+
+// updateComment sets the comment if the ProviderConfig maintains comments.
+func (c *external) updateComment(ctx context.Context, cr *v1alpha1.DbSchema) error {
+	comment := hana.ManagedComment(c.comments, v1alpha1.DbSchemaKind, cr.Name)
+	if comment == "" {
+		return nil
+	}
+	if err := hana.SetComment(ctx, c.db, hana.CommentOnSchema, utils.QuoteIdentifier(c.schemaName(cr)), comment); err != nil {
+		return fmt.Errorf(errSetComment, err)
+	}
+	return nil
+}
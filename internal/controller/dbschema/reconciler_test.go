@@ -6,10 +6,12 @@ package dbschema
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"fmt"
 	"testing"
 
+	"github.com/DATA-DOG/go-sqlmock"
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 	"github.com/crossplane/crossplane-runtime/pkg/logging"
 	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
@@ -17,12 +19,17 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/test"
 	"github.com/google/go-cmp/cmp"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	adminv1alpha1 "github.com/SAP/crossplane-provider-hana/apis/admin/v1alpha1"
 	"github.com/SAP/crossplane-provider-hana/apis/schema/v1alpha1"
 	apisv1alpha1 "github.com/SAP/crossplane-provider-hana/apis/v1alpha1"
+	"github.com/SAP/crossplane-provider-hana/internal/clients/fake"
+	"github.com/SAP/crossplane-provider-hana/internal/clients/hana"
 	"github.com/SAP/crossplane-provider-hana/internal/clients/hana/dbschema"
 	"github.com/SAP/crossplane-provider-hana/internal/clients/xsql"
+	"github.com/SAP/crossplane-provider-hana/internal/controller/externalname"
 )
 
 // MockLogger is a mock implementation of logging.Logger
@@ -131,7 +138,7 @@ func TestConnect(t *testing.T) {
 					},
 				},
 			},
-			want: errors.New(errNoSecretRef),
+			want: errors.New(hana.ErrNoSecretRef),
 		},
 		"ErrGetConnectionSecret": {
 			reason: "An error should be returned if we can't get our ProviderConfig's connection secret",
@@ -158,7 +165,7 @@ func TestConnect(t *testing.T) {
 					},
 				},
 			},
-			want: fmt.Errorf(errGetSecret, errBoom),
+			want: fmt.Errorf(hana.ErrGetSecret, errBoom),
 		},
 	}
 
@@ -271,6 +278,57 @@ func TestObserve(t *testing.T) {
 	}
 }
 
+func TestObserveComment(t *testing.T) {
+	cases := map[string]struct {
+		reason  string
+		comment string
+		want    managed.ExternalObservation
+	}{
+		"CommentUpToDate": {
+			reason:  "A schema with the managed comment should be up to date",
+			comment: "managed by Crossplane, DbSchema demo, cluster prod",
+			want:    managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true, ConnectionDetails: externalname.ConnectionDetails("DEMO_SCHEMA")},
+		},
+		"CommentDrift": {
+			reason:  "A schema whose comment was changed should need an update",
+			comment: "changed by a DBA",
+			want:    managed.ExternalObservation{ResourceExists: true, ConnectionDetails: externalname.ConnectionDetails("DEMO_SCHEMA")},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{
+				client: mockClient{
+					MockRead: func(ctx context.Context, parameters *v1alpha1.DbSchemaParameters) (*v1alpha1.DbSchemaObservation, error) {
+						return &v1alpha1.DbSchemaObservation{SchemaName: parameters.SchemaName}, nil
+					},
+				},
+				log:      &MockLogger{},
+				comments: &apisv1alpha1.ObjectCommentsConfig{ClusterName: "prod"},
+				db: fake.MockDB{
+					MockQueryRowContext: func(ctx context.Context, query string, args ...any) *sql.Row {
+						db, mock, _ := sqlmock.New()
+						mock.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows([]string{"COMMENTS"}).AddRow(tc.comment))
+						return db.QueryRowContext(context.Background(), "SELECT")
+					},
+				},
+			}
+			cr := &v1alpha1.DbSchema{
+				ObjectMeta: metav1.ObjectMeta{Name: "demo"},
+				Spec:       v1alpha1.DbSchemaSpec{ForProvider: v1alpha1.DbSchemaParameters{SchemaName: "DEMO_SCHEMA"}},
+			}
+			got, err := e.Observe(context.Background(), cr)
+			if err != nil {
+				t.Fatalf("\n%s\ne.Observe(...): unexpected error: %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
 func TestCreate(t *testing.T) {
 	errBoom := errors.New("boom")
 
@@ -371,9 +429,23 @@ func TestDelete(t *testing.T) {
 
 	type fields struct {
 		client dbschema.DbSchemaClient
+		kube   client.Client
 		log    logging.Logger
 	}
 
+	noDependents := &test.MockClient{MockList: test.NewMockListFn(nil)}
+	rolesOnSchema := &test.MockClient{
+		MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
+			if roles, ok := obj.(*adminv1alpha1.RoleList); ok {
+				roles.Items = []adminv1alpha1.Role{{
+					ObjectMeta: metav1.ObjectMeta{Name: "demo-role"},
+					Spec:       adminv1alpha1.RoleSpec{ForProvider: adminv1alpha1.RoleParameters{Privileges: []string{`SELECT ON SCHEMA "DEMO_SCHEMA"`}}},
+				}}
+			}
+			return nil
+		}),
+	}
+
 	type args struct {
 		ctx context.Context
 		mg  resource.Managed
@@ -406,7 +478,8 @@ func TestDelete(t *testing.T) {
 						return errBoom
 					},
 				},
-				log: &MockLogger{},
+				kube: noDependents,
+				log:  &MockLogger{},
 			},
 			args: args{
 				mg: &v1alpha1.DbSchema{
@@ -421,6 +494,92 @@ func TestDelete(t *testing.T) {
 				err: fmt.Errorf(errDropSchema, errBoom),
 			},
 		},
+		"ErrDependents": {
+			reason: "The deletion should wait while managed Roles are granted privileges on the schema",
+			fields: fields{
+				kube: rolesOnSchema,
+				log:  &MockLogger{},
+			},
+			args: args{
+				mg: &v1alpha1.DbSchema{
+					Spec: v1alpha1.DbSchemaSpec{
+						ForProvider: v1alpha1.DbSchemaParameters{
+							SchemaName: "DEMO_SCHEMA",
+						},
+					},
+				},
+			},
+			want: want{
+				err: fmt.Errorf(errBlocked, "Role/demo-role"),
+			},
+		},
+		"ErrCollections": {
+			reason: "The deletion should wait while managed Collections are in the schema",
+			fields: fields{
+				kube: &test.MockClient{
+					MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
+						if collections, ok := obj.(*v1alpha1.CollectionList); ok {
+							collections.Items = []v1alpha1.Collection{
+								{
+									ObjectMeta: metav1.ObjectMeta{Name: "by-name"},
+									Spec:       v1alpha1.CollectionSpec{ForProvider: v1alpha1.CollectionParameters{Schema: "DEMO_SCHEMA"}},
+								},
+								{
+									ObjectMeta: metav1.ObjectMeta{Name: "by-ref"},
+									Spec:       v1alpha1.CollectionSpec{ForProvider: v1alpha1.CollectionParameters{SchemaRef: &xpv1.Reference{Name: "demo"}}},
+								},
+								{
+									ObjectMeta: metav1.ObjectMeta{Name: "elsewhere"},
+									Spec:       v1alpha1.CollectionSpec{ForProvider: v1alpha1.CollectionParameters{Schema: "OTHER"}},
+								},
+							}
+						}
+						return nil
+					}),
+				},
+				log: &MockLogger{},
+			},
+			args: args{
+				mg: &v1alpha1.DbSchema{
+					ObjectMeta: metav1.ObjectMeta{Name: "demo"},
+					Spec: v1alpha1.DbSchemaSpec{
+						ForProvider: v1alpha1.DbSchemaParameters{
+							SchemaName: "DEMO_SCHEMA",
+						},
+					},
+				},
+			},
+			want: want{
+				err: fmt.Errorf(errBlocked, "Collection/by-name, Collection/by-ref"),
+			},
+		},
+		"Cascade": {
+			reason: "The schema should be dropped despite dependents with the cascade deletion policy",
+			fields: fields{
+				client: mockClient{
+					MockDelete: func(ctx context.Context, parameters *v1alpha1.DbSchemaParameters) error {
+						return nil
+					},
+				},
+				kube: rolesOnSchema,
+				log:  &MockLogger{},
+			},
+			args: args{
+				mg: &v1alpha1.DbSchema{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{apisv1alpha1.AnnotationDeletionPolicy: apisv1alpha1.DeletionPolicyCascade},
+					},
+					Spec: v1alpha1.DbSchemaSpec{
+						ForProvider: v1alpha1.DbSchemaParameters{
+							SchemaName: "DEMO_SCHEMA",
+						},
+					},
+				},
+			},
+			want: want{
+				err: nil,
+			},
+		},
 		"Success": {
 			reason: "No error should be returned when we successfully delete a schema",
 			fields: fields{
@@ -429,7 +588,8 @@ func TestDelete(t *testing.T) {
 						return nil
 					},
 				},
-				log: &MockLogger{},
+				kube: noDependents,
+				log:  &MockLogger{},
 			},
 			args: args{
 				mg: &v1alpha1.DbSchema{
@@ -448,7 +608,7 @@ func TestDelete(t *testing.T) {
 
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
-			e := external{client: tc.fields.client, log: tc.fields.log}
+			e := external{client: tc.fields.client, kube: tc.fields.kube, log: tc.fields.log}
 			_, err := e.Delete(tc.args.ctx, tc.args.mg)
 			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
 				t.Errorf("\n%s\ne.Delete(...): -want error, +got error:\n%s\n", tc.reason, diff)
@@ -459,8 +619,9 @@ func TestDelete(t *testing.T) {
 
 func TestObserve_CaseSensitivity(t *testing.T) {
 	type fields struct {
-		client dbschema.DbSchemaClient
-		log    logging.Logger
+		client         dbschema.DbSchemaClient
+		log            logging.Logger
+		identifierCase string
 	}
 
 	type args struct {
@@ -506,8 +667,9 @@ func TestObserve_CaseSensitivity(t *testing.T) {
 				// Currently this will FAIL because Observe converts to uppercase
 				// and compares "MY_LOWERCASE_SCHEMA" != "my_lowercase_schema"
 				c: managed.ExternalObservation{
-					ResourceExists:   true,
-					ResourceUpToDate: true,
+					ResourceExists:    true,
+					ResourceUpToDate:  true,
+					ConnectionDetails: externalname.ConnectionDetails("my_lowercase_schema"),
 				},
 				err: nil,
 			},
@@ -538,8 +700,9 @@ func TestObserve_CaseSensitivity(t *testing.T) {
 				// Currently this will FAIL because Observe converts to uppercase
 				// and compares "MYMIXEDCASESCHEMA" != "MyMixedCaseSchema"
 				c: managed.ExternalObservation{
-					ResourceExists:   true,
-					ResourceUpToDate: true,
+					ResourceExists:    true,
+					ResourceUpToDate:  true,
+					ConnectionDetails: externalname.ConnectionDetails("MyMixedCaseSchema"),
 				},
 				err: nil,
 			},
@@ -569,8 +732,9 @@ func TestObserve_CaseSensitivity(t *testing.T) {
 			want: want{
 				// This should pass because uppercase stays uppercase
 				c: managed.ExternalObservation{
-					ResourceExists:   true,
-					ResourceUpToDate: true,
+					ResourceExists:    true,
+					ResourceUpToDate:  true,
+					ConnectionDetails: externalname.ConnectionDetails("UPPERCASE_SCHEMA"),
 				},
 				err: nil,
 			},
@@ -600,8 +764,41 @@ func TestObserve_CaseSensitivity(t *testing.T) {
 			want: want{
 				// Currently this will FAIL because Observe converts to uppercase
 				c: managed.ExternalObservation{
-					ResourceExists:   true,
-					ResourceUpToDate: true,
+					ResourceExists:    true,
+					ResourceUpToDate:  true,
+					ConnectionDetails: externalname.ConnectionDetails("sap.hana::my_schema"),
+				},
+				err: nil,
+			},
+		},
+		"FoldedSchemaName_ShouldBeFound": {
+			reason: "An unquoted schema name should be folded to uppercase if the ProviderConfig folds identifiers",
+			fields: fields{
+				client: mockClient{
+					MockRead: func(ctx context.Context, parameters *v1alpha1.DbSchemaParameters) (observed *v1alpha1.DbSchemaObservation, err error) {
+						return &v1alpha1.DbSchemaObservation{
+							SchemaName: "MY_SCHEMA",
+							Owner:      "SYSTEM",
+						}, nil
+					},
+				},
+				log:            &MockLogger{},
+				identifierCase: apisv1alpha1.IdentifierCaseUpper,
+			},
+			args: args{
+				mg: &v1alpha1.DbSchema{
+					Spec: v1alpha1.DbSchemaSpec{
+						ForProvider: v1alpha1.DbSchemaParameters{
+							SchemaName: "my_schema",
+						},
+					},
+				},
+			},
+			want: want{
+				c: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  true,
+					ConnectionDetails: externalname.ConnectionDetails("MY_SCHEMA"),
 				},
 				err: nil,
 			},
@@ -610,7 +807,7 @@ func TestObserve_CaseSensitivity(t *testing.T) {
 
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
-			e := external{client: tc.fields.client, log: tc.fields.log}
+			e := external{client: tc.fields.client, log: tc.fields.log, identifierCase: tc.fields.identifierCase}
 			got, err := e.Observe(tc.args.ctx, tc.args.mg)
 			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
 				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
@@ -0,0 +1,314 @@
+/*
+Copyright 2026 SAP SE or an SAP affiliate company and contributors.
+*/
+
+package driftreport
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/SAP/crossplane-provider-hana/apis/admin/v1alpha1"
+	apisv1alpha1 "github.com/SAP/crossplane-provider-hana/apis/v1alpha1"
+	"github.com/SAP/crossplane-provider-hana/internal/clients/hana"
+	"github.com/SAP/crossplane-provider-hana/internal/clients/hana/driftreport"
+	"github.com/SAP/crossplane-provider-hana/internal/clients/xsql"
+	"github.com/SAP/crossplane-provider-hana/internal/controller/features"
+	"github.com/SAP/crossplane-provider-hana/internal/controller/outcome"
+	"github.com/SAP/crossplane-provider-hana/internal/controller/poll"
+	"github.com/SAP/crossplane-provider-hana/internal/controller/role"
+	"github.com/SAP/crossplane-provider-hana/internal/controller/user"
+	"github.com/SAP/crossplane-provider-hana/internal/utils"
+)
+
+const (
+	errNotDriftReport = "managed resource is not a DriftReport custom resource"
+	errTrackPCUsage   = "cannot track ProviderConfig usage: %w"
+	errGetPC          = "cannot get ProviderConfig: %w"
+	errGetTLS         = "cannot get TLS configuration: %w"
+
+	errListUsers        = "cannot list Users: %w"
+	errListRoles        = "cannot list Roles: %w"
+	errListCatalogUsers = "cannot list catalog users: %w"
+	errListTaggedUsers  = "cannot list tagged users: %w"
+)
+
+// Setup adds a controller that reconciles DriftReport managed resources.
+func Setup(mgr ctrl.Manager, o controller.Options, db xsql.Connector) error {
+	name := managed.ControllerName(v1alpha1.DriftReportGroupKind)
+
+	log := o.Logger.WithValues("controller", name)
+	t := resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1alpha1.ProviderConfigUsage{})
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1alpha1.DriftReportGroupVersionKind),
+		outcome.WithExternalConnecter(name, &connector{
+			kube:      mgr.GetClient(),
+			usage:     t,
+			newClient: driftreport.New,
+			log:       log,
+			db:        db,
+		}),
+		managed.WithLogger(log),
+		managed.WithPollInterval(o.PollInterval),
+		poll.WithJitterHook(v1alpha1.DriftReportKind, pollInterval),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+		features.ConfigureBetaManagementPolicies(o))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		For(&v1alpha1.DriftReport{}).
+		Complete(r)
+}
+
+// pollInterval reports at the interval of the DriftReport if it is set.
+func pollInterval(mg resource.Managed, d time.Duration) time.Duration {
+	if cr, ok := mg.(*v1alpha1.DriftReport); ok && cr.Spec.ForProvider.Interval != nil {
+		return cr.Spec.ForProvider.Interval.Duration
+	}
+	return d
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube      client.Client
+	usage     resource.Tracker
+	newClient func(xsql.DB) driftreport.Client
+	log       logging.Logger
+	db        xsql.Connector
+}
+
+// Connect typically produces an ExternalClient by:
+// 1. Tracking that the managed resource is using a ProviderConfig.
+// 2. Getting the managed resource's ProviderConfig.
+// 3. Getting the credentials specified by the ProviderConfig.
+// 4. Using the credentials to form a client.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1alpha1.DriftReport)
+	if !ok {
+		return nil, errors.New(errNotDriftReport)
+	}
+
+	if err := c.usage.Track(ctx, mg); err != nil {
+		return nil, fmt.Errorf(errTrackPCUsage, err)
+	}
+
+	pc := &apisv1alpha1.ProviderConfig{}
+	if err := c.kube.Get(ctx, types.NamespacedName{Name: cr.GetProviderConfigReference().Name}, pc); err != nil {
+		return nil, fmt.Errorf(errGetPC, err)
+	}
+
+	data, err := hana.Credentials(ctx, c.kube, pc)
+	if err != nil {
+		return nil, err
+	}
+
+	c.log.Info("Connecting to drift report resource", "name", cr.Name)
+
+	creds, err := hana.ConnectionCredentials(ctx, c.kube, pc, data)
+	if err != nil {
+		return nil, fmt.Errorf(errGetTLS, err)
+	}
+
+	conn, err := c.db.Connect(ctx, creds)
+	if err != nil {
+		return nil, fmt.Errorf("cannot connect to HANA DB: %w", err)
+	}
+
+	if err := hana.ReportActiveEndpoint(ctx, c.kube, pc, conn); err != nil {
+		c.log.Info("Cannot report active endpoint", "providerConfig", pc.Name, "error", err)
+	}
+
+	return &external{
+		client:         c.newClient(conn),
+		kube:           c.kube,
+		users:          user.NewObserver(c.kube, conn, pc, creds, c.log),
+		roles:          role.NewObserver(c.kube, conn, pc, creds, c.log),
+		log:            c.log,
+		identifierCase: pc.Spec.IdentifierCase,
+	}, nil
+}
+
+// An observer observes a managed resource without changing it.
+type observer interface {
+	Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error)
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes an
+// external resource to ensure it reflects the managed resource's desired state.
+type external struct {
+	client         driftreport.DriftReportClient
+	kube           client.Client
+	users          observer
+	roles          observer
+	log            logging.Logger
+	identifierCase string
+}
+
+func (c *external) Disconnect(ctx context.Context) error {
+	return nil
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.DriftReport)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotDriftReport)
+	}
+
+	// A report has nothing to delete in the catalog
+	if meta.WasDeleted(cr) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	c.log.Info("Observing drift report resource", "name", cr.Name)
+
+	pcName := cr.GetProviderConfigReference().Name
+	report := v1alpha1.DriftReportObservation{}
+
+	users := &v1alpha1.UserList{}
+	if err := c.kube.List(ctx, users); err != nil {
+		return managed.ExternalObservation{}, fmt.Errorf(errListUsers, err)
+	}
+	managedUsernames := map[string]bool{}
+	userResources := map[string]string{}
+	for i := range users.Items {
+		u := &users.Items[i]
+		username := utils.FoldIdentifier(u.Spec.ForProvider.Username, u.Spec.ForProvider.CaseSensitive || c.identifierCase == apisv1alpha1.IdentifierCasePreserve)
+		userResources[u.Name] = username
+		if !usesProviderConfig(u, pcName) {
+			continue
+		}
+		report.ManagedUsers++
+		managedUsernames[username] = true
+		if drifted := c.observe(ctx, c.users, u, &report.MissingUsers, &report.DriftedUsers, &report.FailedObservations); hasForeignGrants(drifted) {
+			report.ForeignGrantUsers = append(report.ForeignGrantUsers, u.Name)
+		}
+	}
+
+	roles := &v1alpha1.RoleList{}
+	if err := c.kube.List(ctx, roles); err != nil {
+		return managed.ExternalObservation{}, fmt.Errorf(errListRoles, err)
+	}
+	for i := range roles.Items {
+		r := &roles.Items[i]
+		if !usesProviderConfig(r, pcName) {
+			continue
+		}
+		report.ManagedRoles++
+		c.observe(ctx, c.roles, r, &report.MissingRoles, &report.DriftedRoles, &report.FailedObservations)
+	}
+
+	catalogUsers, err := c.client.ListUsers(ctx, cr.Spec.ForProvider.UnmanagedUserPattern)
+	if err != nil {
+		c.log.Info("Error listing catalog users", "name", cr.Name, "error", err)
+		return managed.ExternalObservation{}, fmt.Errorf(errListCatalogUsers, err)
+	}
+	for _, name := range catalogUsers {
+		if !managedUsernames[name] {
+			report.UnmanagedUsers++
+		}
+	}
+
+	taggedUsers, err := c.client.ListTaggedUsers(ctx)
+	if err != nil {
+		c.log.Info("Error listing tagged users", "name", cr.Name, "error", err)
+		return managed.ExternalObservation{}, fmt.Errorf(errListTaggedUsers, err)
+	}
+	report.OrphanedUsers = orphanedUsers(taggedUsers, userResources)
+
+	now := metav1.Now()
+	report.LastReportTime = &now
+	cr.Status.AtProvider = report
+
+	cr.SetConditions(xpv1.Available())
+
+	c.log.Info("Observed drift report resource",
+		"name", cr.Name,
+		"managedUsers", report.ManagedUsers,
+		"driftedUsers", len(report.DriftedUsers),
+		"foreignGrantUsers", len(report.ForeignGrantUsers),
+		"unmanagedUsers", report.UnmanagedUsers,
+		"orphanedUsers", len(report.OrphanedUsers),
+		"managedRoles", report.ManagedRoles,
+		"driftedRoles", len(report.DriftedRoles))
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: true,
+	}, nil
+}
+
+// observe records the managed resource as missing, drifted or failed. It
+// observes a copy so that the managed resource itself is left untouched, and
+// returns the observed copy if the resource drifted.
+func (c *external) observe(ctx context.Context, o observer, mg resource.Managed, missing, drifted, failed *[]string) resource.Managed {
+	observed := mg.DeepCopyObject().(resource.Managed)
+	obs, err := o.Observe(ctx, observed)
+	switch {
+	case err != nil:
+		c.log.Info("Error observing resource for drift report", "name", mg.GetName(), "error", err)
+		*failed = append(*failed, mg.GetName())
+	case !obs.ResourceExists:
+		*missing = append(*missing, mg.GetName())
+	case !obs.ResourceUpToDate:
+		*drifted = append(*drifted, mg.GetName())
+		return observed
+	}
+	return nil
+}
+
+// hasForeignGrants returns whether the observed User holds privileges outside
+// its spec that users other than the technical user granted.
+func hasForeignGrants(mg resource.Managed) bool {
+	u, ok := mg.(*v1alpha1.User)
+	return ok && u.Status.AtProvider.PendingRevocations != nil && len(u.Status.AtProvider.PendingRevocations.ForeignPrivileges) > 0
+}
+
+// orphanedUsers returns the tagged users whose User no longer exists or no
+// longer manages them, sorted by name.
+func orphanedUsers(tagged map[string]string, userResources map[string]string) []string {
+	var orphaned []string
+	for username, resource := range tagged {
+		if userResources[resource] != username {
+			orphaned = append(orphaned, username)
+		}
+	}
+	slices.Sort(orphaned)
+	return orphaned
+}
+
+func usesProviderConfig(mg resource.Managed, name string) bool {
+	ref := mg.GetProviderConfigReference()
+	return ref != nil && ref.Name == name
+}
+
+// Create does nothing because a report has nothing to create in the catalog.
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	return managed.ExternalCreation{}, nil
+}
+
+// Update does nothing because a report never changes the catalog.
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	return managed.ExternalUpdate{}, nil
+}
+
+// Delete does nothing because a report has nothing to delete in the catalog.
+func (c *external) Delete(ctx context.Context, mg resource.Managed) (managed.ExternalDelete, error) {
+	return managed.ExternalDelete{}, nil
+}
@@ -0,0 +1,294 @@
+/*
+Copyright 2026 SAP SE or an SAP affiliate company and contributors.
+*/
+
+package driftreport
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/SAP/crossplane-provider-hana/apis/admin/v1alpha1"
+)
+
+// MockLogger is a mock implementation of logging.Logger
+type MockLogger struct{}
+
+// Debug logs debug messages.
+func (l *MockLogger) Debug(_ string, _ ...any) {}
+
+// Info logs info messages.
+func (l *MockLogger) Info(_ string, _ ...any) {}
+
+// WithValues returns a logger with the specified key-value pairs.
+func (l *MockLogger) WithValues(_ ...any) logging.Logger { return l }
+
+type mockClient struct {
+	MockListUsers       func(ctx context.Context, pattern string) ([]string, error)
+	MockListTaggedUsers func(ctx context.Context) (map[string]string, error)
+}
+
+func (m mockClient) ListTaggedUsers(ctx context.Context) (map[string]string, error) {
+	return m.MockListTaggedUsers(ctx)
+}
+
+func (m mockClient) ListUsers(ctx context.Context, pattern string) ([]string, error) {
+	return m.MockListUsers(ctx, pattern)
+}
+
+// mockObserver reports the observation keyed by the name of the managed
+// resource.
+type mockObserver map[string]managed.ExternalObservation
+
+func (m mockObserver) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	obs, ok := m[mg.GetName()]
+	if !ok {
+		return managed.ExternalObservation{}, errors.New("boom")
+	}
+	return obs, nil
+}
+
+// foreignGrantObserver reports the Users it names as drifted by privileges
+// another user granted.
+type foreignGrantObserver map[string]bool
+
+func (m foreignGrantObserver) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	if !m[mg.GetName()] {
+		return mockObserver{}.Observe(ctx, mg)
+	}
+	u := mg.(*v1alpha1.User)
+	u.Status.AtProvider.PendingRevocations = &v1alpha1.PendingRevocations{
+		Count:             1,
+		Privileges:        []string{"CATALOG READ"},
+		ForeignPrivileges: []string{"CATALOG READ"},
+	}
+	return managed.ExternalObservation{ResourceExists: true}, nil
+}
+
+func withProviderConfig(name string) xpv1.ResourceSpec {
+	return xpv1.ResourceSpec{ProviderConfigReference: &xpv1.Reference{Name: name}}
+}
+
+func newUser(name, username, pc string) v1alpha1.User {
+	return v1alpha1.User{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec: v1alpha1.UserSpec{
+			ResourceSpec: withProviderConfig(pc),
+			ForProvider:  v1alpha1.UserParameters{Username: username},
+		},
+	}
+}
+
+func newRole(name, pc string) v1alpha1.Role {
+	return v1alpha1.Role{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec:       v1alpha1.RoleSpec{ResourceSpec: withProviderConfig(pc)},
+	}
+}
+
+func TestObserve(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	list := func(users []v1alpha1.User, roles []v1alpha1.Role) test.MockListFn {
+		return func(_ context.Context, obj client.ObjectList, _ ...client.ListOption) error {
+			switch l := obj.(type) {
+			case *v1alpha1.UserList:
+				l.Items = users
+			case *v1alpha1.RoleList:
+				l.Items = roles
+			}
+			return nil
+		}
+	}
+
+	type fields struct {
+		client mockClient
+		kube   client.Client
+		users  observer
+		roles  observer
+	}
+
+	type want struct {
+		o      managed.ExternalObservation
+		report v1alpha1.DriftReportObservation
+		err    error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		want   want
+	}{
+		"ErrListUsers": {
+			reason: "Any errors encountered while listing Users should be returned",
+			fields: fields{
+				kube: &test.MockClient{MockList: test.NewMockListFn(errBoom)},
+			},
+			want: want{
+				err: fmt.Errorf(errListUsers, errBoom),
+			},
+		},
+		"ErrListCatalogUsers": {
+			reason: "Any errors encountered while listing catalog users should be returned",
+			fields: fields{
+				client: mockClient{
+					MockListUsers: func(ctx context.Context, pattern string) ([]string, error) {
+						return nil, errBoom
+					},
+				},
+				kube: &test.MockClient{MockList: list(nil, nil)},
+			},
+			want: want{
+				err: fmt.Errorf(errListCatalogUsers, errBoom),
+			},
+		},
+		"Report": {
+			reason: "Users and Roles of the ProviderConfig should be reported as missing, drifted or failed and unmanaged users counted",
+			fields: fields{
+				client: mockClient{
+					MockListUsers: func(ctx context.Context, pattern string) ([]string, error) {
+						if pattern != "APP_%" {
+							return nil, errors.New("unexpected pattern")
+						}
+						return []string{"APP_SYNCED", "APP_DRIFTED", "APP_UNMANAGED"}, nil
+					},
+					MockListTaggedUsers: func(ctx context.Context) (map[string]string, error) {
+						return map[string]string{
+							"APP_SYNCED":    "synced",
+							"APP_ORPHAN":    "deleted",
+							"APP_RENAMED":   "drifted",
+							"APP_UNMANAGED": "other",
+						}, nil
+					},
+				},
+				kube: &test.MockClient{MockList: list(
+					[]v1alpha1.User{
+						newUser("synced", "app_synced", "example"),
+						newUser("drifted", "APP_DRIFTED", "example"),
+						newUser("missing", "APP_MISSING", "example"),
+						newUser("failed", "APP_FAILED", "example"),
+						newUser("other", "APP_UNMANAGED", "other"),
+					},
+					[]v1alpha1.Role{
+						newRole("synced-role", "example"),
+						newRole("drifted-role", "example"),
+						newRole("other-role", "other"),
+					},
+				)},
+				users: mockObserver{
+					"synced":  {ResourceExists: true, ResourceUpToDate: true},
+					"drifted": {ResourceExists: true},
+					"missing": {},
+				},
+				roles: mockObserver{
+					"synced-role":  {ResourceExists: true, ResourceUpToDate: true},
+					"drifted-role": {ResourceExists: true},
+				},
+			},
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				report: v1alpha1.DriftReportObservation{
+					ManagedUsers:       4,
+					DriftedUsers:       []string{"drifted"},
+					MissingUsers:       []string{"missing"},
+					UnmanagedUsers:     1,
+					OrphanedUsers:      []string{"APP_ORPHAN", "APP_RENAMED"},
+					ManagedRoles:       2,
+					DriftedRoles:       []string{"drifted-role"},
+					FailedObservations: []string{"failed"},
+				},
+			},
+		},
+		"ForeignGrants": {
+			reason: "Users drifted by privileges another user granted should be reported as such",
+			fields: fields{
+				client: mockClient{
+					MockListUsers: func(ctx context.Context, pattern string) ([]string, error) {
+						return []string{"APP_FOREIGN"}, nil
+					},
+					MockListTaggedUsers: func(ctx context.Context) (map[string]string, error) {
+						return map[string]string{"APP_FOREIGN": "foreign"}, nil
+					},
+				},
+				kube:  &test.MockClient{MockList: list([]v1alpha1.User{newUser("foreign", "APP_FOREIGN", "example")}, nil)},
+				users: foreignGrantObserver{"foreign": true},
+			},
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				report: v1alpha1.DriftReportObservation{
+					ManagedUsers:      1,
+					DriftedUsers:      []string{"foreign"},
+					ForeignGrantUsers: []string{"foreign"},
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{
+				client: tc.fields.client,
+				kube:   tc.fields.kube,
+				users:  tc.fields.users,
+				roles:  tc.fields.roles,
+				log:    &MockLogger{},
+			}
+			cr := &v1alpha1.DriftReport{
+				Spec: v1alpha1.DriftReportSpec{
+					ResourceSpec: withProviderConfig("example"),
+					ForProvider:  v1alpha1.DriftReportParameters{UnmanagedUserPattern: "APP_%"},
+				},
+			}
+			got, err := e.Observe(context.Background(), cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.report, cr.Status.AtProvider, cmpopts.IgnoreFields(v1alpha1.DriftReportObservation{}, "LastReportTime")); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want report, +got report:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestPollInterval(t *testing.T) {
+	cases := map[string]struct {
+		reason   string
+		interval *metav1.Duration
+		want     time.Duration
+	}{
+		"Default": {
+			reason: "The poll interval of the provider should be used without an interval",
+			want:   time.Minute,
+		},
+		"Interval": {
+			reason:   "The interval of the DriftReport should be used if it is set",
+			interval: &metav1.Duration{Duration: time.Hour},
+			want:     time.Hour,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cr := &v1alpha1.DriftReport{Spec: v1alpha1.DriftReportSpec{ForProvider: v1alpha1.DriftReportParameters{Interval: tc.interval}}}
+			if got := pollInterval(cr, time.Minute); got != tc.want {
+				t.Errorf("\n%s\npollInterval(...): want %s, got %s", tc.reason, tc.want, got)
+			}
+		})
+	}
+}
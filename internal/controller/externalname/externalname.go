@@ -0,0 +1,86 @@
+/*
+Copyright 2026 SAP SE or an SAP affiliate company and contributors.
+*/
+
+// Package externalname maps the crossplane.io/external-name annotation to the
+// field naming the HANA object of a managed resource, and publishes the stable
+// identifier of that object.
+package externalname
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const errUpdateManaged = "cannot update managed resource: %w"
+
+// ConnectionDetailExternalID is the connection detail holding the stable
+// identifier of the external object of a managed resource, the same as its
+// status.atProvider.externalID.
+const ConnectionDetailExternalID = "externalID"
+
+// ConnectionDetails returns the connection details publishing the external ID.
+func ConnectionDetails(id string) managed.ConnectionDetails {
+	return managed.ConnectionDetails{ConnectionDetailExternalID: []byte(id)}
+}
+
+// Qualified returns the external ID of an object that lives in a schema, e.g.
+// SCHEMA.ROLE, or the name alone if the object has no schema.
+func Qualified(schema, name string) string {
+	if schema == "" {
+		return name
+	}
+	return schema + "." + name
+}
+
+// A NameField returns the field of a managed resource naming its HANA object,
+// or nil if the managed resource is of an unexpected kind.
+type NameField func(mg resource.Managed) *string
+
+// An Initializer keeps the external-name annotation of a managed resource and
+// the field naming its HANA object in sync. It replaces the default
+// initializer of crossplane-runtime, which uses the name of the Kubernetes
+// object as external name.
+type Initializer struct {
+	client client.Client
+	field  NameField
+}
+
+// NewInitializer returns an Initializer for the supplied name field.
+func NewInitializer(c client.Client, field NameField) *Initializer {
+	return &Initializer{client: c, field: field}
+}
+
+// Initialize the external name of the managed resource:
+//   - A name field that is set wins, the external name follows it. This also
+//     moves resources initialized with their Kubernetes name over.
+//   - Without a name field, the HANA object of the external name is adopted.
+//   - Without either, the HANA object is named after the Kubernetes object.
+func (i *Initializer) Initialize(ctx context.Context, mg resource.Managed) error {
+	name := i.field(mg)
+	if name == nil {
+		return nil
+	}
+
+	switch external := meta.GetExternalName(mg); {
+	case *name != "" && *name != external:
+		meta.SetExternalName(mg, *name)
+	case *name == "" && external != "":
+		*name = external
+	case *name == "":
+		*name = mg.GetName()
+		meta.SetExternalName(mg, *name)
+	default:
+		return nil
+	}
+
+	if err := i.client.Update(ctx, mg); err != nil {
+		return fmt.Errorf(errUpdateManaged, err)
+	}
+	return nil
+}
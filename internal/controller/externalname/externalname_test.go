@@ -0,0 +1,125 @@
+/*
+Copyright 2026 SAP SE or an SAP affiliate company and contributors.
+*/
+
+package externalname
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/SAP/crossplane-provider-hana/apis/admin/v1alpha1"
+)
+
+func username(mg resource.Managed) *string {
+	cr, ok := mg.(*v1alpha1.User)
+	if !ok {
+		return nil
+	}
+	return &cr.Spec.ForProvider.Username
+}
+
+func TestInitialize(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type args struct {
+		externalName string
+		username     string
+		updateErr    error
+	}
+
+	type want struct {
+		externalName string
+		username     string
+		updated      bool
+		err          error
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"InSync": {
+			reason: "Nothing should be updated if the external name matches the username",
+			args:   args{externalName: "DEMO_USER", username: "DEMO_USER"},
+			want:   want{externalName: "DEMO_USER", username: "DEMO_USER"},
+		},
+		"Username": {
+			reason: "The external name should follow the username, also if it was initialized with the name of the object",
+			args:   args{externalName: "demo-user", username: "DEMO_USER"},
+			want:   want{externalName: "DEMO_USER", username: "DEMO_USER", updated: true},
+		},
+		"Adopt": {
+			reason: "The user of the external name should be adopted without a username",
+			args:   args{externalName: "EXISTING_USER"},
+			want:   want{externalName: "EXISTING_USER", username: "EXISTING_USER", updated: true},
+		},
+		"ObjectName": {
+			reason: "The user should be named after the object without a username or external name",
+			want:   want{externalName: "demo-user", username: "demo-user", updated: true},
+		},
+		"ErrUpdate": {
+			reason: "Any errors encountered while updating the managed resource should be returned",
+			args:   args{username: "DEMO_USER", updateErr: errBoom},
+			want:   want{externalName: "DEMO_USER", username: "DEMO_USER", updated: true, err: fmt.Errorf(errUpdateManaged, errBoom)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cr := &v1alpha1.User{ObjectMeta: metav1.ObjectMeta{Name: "demo-user"}}
+			cr.Spec.ForProvider.Username = tc.args.username
+			if tc.args.externalName != "" {
+				meta.SetExternalName(cr, tc.args.externalName)
+			}
+
+			updated := false
+			kube := &test.MockClient{MockUpdate: func(_ context.Context, _ client.Object, _ ...client.UpdateOption) error {
+				updated = true
+				return tc.args.updateErr
+			}}
+
+			err := NewInitializer(kube, username).Initialize(context.Background(), cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ni.Initialize(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.externalName, meta.GetExternalName(cr)); diff != "" {
+				t.Errorf("\n%s\ni.Initialize(...): -want external name, +got external name:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.username, cr.Spec.ForProvider.Username); diff != "" {
+				t.Errorf("\n%s\ni.Initialize(...): -want username, +got username:\n%s\n", tc.reason, diff)
+			}
+			if updated != tc.want.updated {
+				t.Errorf("\n%s\ni.Initialize(...): want updated %t, got %t", tc.reason, tc.want.updated, updated)
+			}
+		})
+	}
+}
+
+func TestQualified(t *testing.T) {
+	cases := map[string]struct {
+		schema, name string
+		want         string
+	}{
+		"Global":    {name: "ROLE1", want: "ROLE1"},
+		"Schema":    {schema: "SCHEMA1", name: "ROLE1", want: "SCHEMA1.ROLE1"},
+		"Container": {schema: "C1#DI", name: "c1::role", want: "C1#DI.c1::role"},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := Qualified(tc.schema, tc.name); got != tc.want {
+				t.Errorf("Qualified(%q, %q) = %q, want %q", tc.schema, tc.name, got, tc.want)
+			}
+		})
+	}
+}
@@ -5,6 +5,10 @@ Copyright 2026 SAP SE or an SAP affiliate company and contributors.
 package features
 
 import (
+	"fmt"
+	"sort"
+	"strings"
+
 	"github.com/crossplane/crossplane-runtime/pkg/controller"
 	"github.com/crossplane/crossplane-runtime/pkg/feature"
 	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
@@ -21,8 +25,112 @@ const (
 	// Management Policies. See the below design for more details.
 	// https://github.com/crossplane/crossplane/blob/master/design/design-doc-observe-only-resources.md
 	EnableAlphaManagementPolicies feature.Flag = "EnableAlphaManagementPolicies"
+
+	// EnableAlphaInstanceConfiguration enables the controller managing
+	// HANA Cloud instance parameters through InstanceConfiguration resources.
+	EnableAlphaInstanceConfiguration feature.Flag = "EnableAlphaInstanceConfiguration"
+
+	// EnableAlphaBackupConfiguration enables the controller managing the
+	// backup settings of HANA Cloud instances through BackupConfiguration
+	// resources.
+	EnableAlphaBackupConfiguration feature.Flag = "EnableAlphaBackupConfiguration"
+
+	// EnableAlphaHanaCloudInstanceInfo enables the controller reporting the
+	// state of HANA Cloud instances through HanaCloudInstanceInfo resources.
+	EnableAlphaHanaCloudInstanceInfo feature.Flag = "EnableAlphaHanaCloudInstanceInfo"
+
+	// EnableAlphaSingleton enables the controller running the SQL of
+	// Singleton resources. It is opt-in, as Singletons run arbitrary SQL as
+	// the technical user.
+	EnableAlphaSingleton feature.Flag = "EnableAlphaSingleton"
+
+	// EnableAlphaConnectionPropagation enables the controller restarting the
+	// Deployments annotated to consume connection secrets when the secrets
+	// change. It is opt-in, as the provider then needs to watch and patch
+	// Deployments.
+	EnableAlphaConnectionPropagation feature.Flag = "EnableAlphaConnectionPropagation"
 )
 
+// AdmissionWebhooks is enabled by the provider, rather than requested, when
+// the admission webhooks are registered. Controllers only trust the
+// annotations the webhooks stamp, such as approvals, if it is enabled.
+const AdmissionWebhooks feature.Flag = "AdmissionWebhooks"
+
+// Definition describes a feature flag that can be enabled per installation.
+type Definition struct {
+	Flag feature.Flag
+	// Default flags are enabled without being requested.
+	Default     bool
+	Description string
+}
+
+// Definitions are the feature flags known to the provider.
+var Definitions = []Definition{
+	{Flag: EnableAlphaExternalSecretStores, Description: "Support for External Secret Stores."},
+	{Flag: EnableAlphaManagementPolicies, Default: true, Description: "Support for Management Policies."},
+	{Flag: EnableAlphaInstanceConfiguration, Description: "Manage HANA Cloud instance parameters with InstanceConfiguration resources."},
+	{Flag: EnableAlphaBackupConfiguration, Description: "Manage HANA Cloud backup retention and schedule with BackupConfiguration resources."},
+	{Flag: EnableAlphaHanaCloudInstanceInfo, Description: "Report the state of HANA Cloud instances with HanaCloudInstanceInfo resources."},
+	{Flag: EnableAlphaSingleton, Description: "Create artifacts such as spatial reference systems with the SQL of Singleton resources."},
+	{Flag: EnableAlphaConnectionPropagation, Description: "Restart the Deployments consuming connection secrets when the secrets change."},
+}
+
+// Names returns the names of all known feature flags, sorted.
+func Names() []string {
+	names := make([]string, 0, len(Definitions))
+	for _, d := range Definitions {
+		names = append(names, string(d.Flag))
+	}
+	sort.Strings(names)
+	return names
+}
+
+// lookup finds a feature flag by name. The "Enable" prefix may be omitted,
+// e.g. AlphaManagementPolicies selects EnableAlphaManagementPolicies.
+func lookup(name string) (Definition, bool) {
+	for _, d := range Definitions {
+		if string(d.Flag) == name || string(d.Flag) == "Enable"+name {
+			return d, true
+		}
+	}
+	return Definition{}, false
+}
+
+// Enable enables the default feature flags and the requested ones in flags and
+// returns the enabled flags. Each requested value may hold a comma-separated
+// list of names. Unknown names are rejected so that typos do not silently
+// leave a feature disabled.
+func Enable(flags *feature.Flags, requested []string) ([]feature.Flag, error) {
+	enabled := map[feature.Flag]bool{}
+	for _, d := range Definitions {
+		if d.Default {
+			enabled[d.Flag] = true
+		}
+	}
+	for _, value := range requested {
+		for _, name := range strings.Split(value, ",") {
+			name = strings.TrimSpace(name)
+			if name == "" {
+				continue
+			}
+			d, ok := lookup(name)
+			if !ok {
+				return nil, fmt.Errorf("unknown feature flag %q, known flags are %s", name, strings.Join(Names(), ", "))
+			}
+			enabled[d.Flag] = true
+		}
+	}
+
+	out := make([]feature.Flag, 0, len(enabled))
+	for _, d := range Definitions {
+		if enabled[d.Flag] {
+			flags.Enable(d.Flag)
+			out = append(out, d.Flag)
+		}
+	}
+	return out, nil
+}
+
 // ConfigureBetaManagementPolicies configures the management policies feature.
 func ConfigureBetaManagementPolicies(o controller.Options) managed.ReconcilerOption {
 	return func(r *managed.Reconciler) {
@@ -0,0 +1,57 @@
+/*
+Copyright 2026 SAP SE or an SAP affiliate company and contributors.
+*/
+
+package features
+
+import (
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/feature"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestEnable(t *testing.T) {
+	cases := map[string]struct {
+		reason    string
+		requested []string
+		want      []feature.Flag
+		wantErr   bool
+	}{
+		"Defaults": {
+			reason: "Default flags should be enabled without being requested",
+			want:   []feature.Flag{EnableAlphaManagementPolicies},
+		},
+		"CommaSeparatedAndRepeated": {
+			reason:    "Flags should be accepted comma-separated, repeated, and without the Enable prefix",
+			requested: []string{"AlphaInstanceConfiguration, EnableAlphaManagementPolicies", "EnableAlphaExternalSecretStores"},
+			want:      []feature.Flag{EnableAlphaExternalSecretStores, EnableAlphaManagementPolicies, EnableAlphaInstanceConfiguration},
+		},
+		"Unknown": {
+			reason:    "Unknown flags should be rejected",
+			requested: []string{"EnableAlphaManagementPolicy"},
+			wantErr:   true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			flags := &feature.Flags{}
+			got, err := Enable(flags, tc.requested)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("\n%s\nEnable(...): error = %v, want error %t", tc.reason, err, tc.wantErr)
+			}
+			if tc.wantErr {
+				return
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nEnable(...): -want, +got:\n%s", tc.reason, diff)
+			}
+			for _, f := range tc.want {
+				if !flags.Enabled(f) {
+					t.Errorf("\n%s\nEnable(...): want %s enabled", tc.reason, f)
+				}
+			}
+		})
+	}
+}
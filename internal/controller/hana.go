@@ -5,47 +5,240 @@ Copyright 2026 SAP SE or an SAP affiliate company and contributors.
 package controller
 
 import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
 	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/feature"
 	ctrl "sigs.k8s.io/controller-runtime"
 
+	adminv1alpha1 "github.com/SAP/crossplane-provider-hana/apis/admin/v1alpha1"
+	inventoryv1alpha1 "github.com/SAP/crossplane-provider-hana/apis/inventory/v1alpha1"
+	schemav1alpha1 "github.com/SAP/crossplane-provider-hana/apis/schema/v1alpha1"
 	"github.com/SAP/crossplane-provider-hana/internal/clients/xsql"
 	"github.com/SAP/crossplane-provider-hana/internal/controller/auditpolicy"
+	"github.com/SAP/crossplane-provider-hana/internal/controller/backupconfiguration"
+	"github.com/SAP/crossplane-provider-hana/internal/controller/collection"
+	"github.com/SAP/crossplane-provider-hana/internal/controller/connectionpropagation"
 	"github.com/SAP/crossplane-provider-hana/internal/controller/dbschema"
+	"github.com/SAP/crossplane-provider-hana/internal/controller/driftreport"
+	"github.com/SAP/crossplane-provider-hana/internal/controller/features"
+	"github.com/SAP/crossplane-provider-hana/internal/controller/hanacloudinstanceinfo"
+	"github.com/SAP/crossplane-provider-hana/internal/controller/instanceconfiguration"
 	"github.com/SAP/crossplane-provider-hana/internal/controller/instancemapping"
 	"github.com/SAP/crossplane-provider-hana/internal/controller/kymainstancemapping"
+	"github.com/SAP/crossplane-provider-hana/internal/controller/monitoringuser"
 	"github.com/SAP/crossplane-provider-hana/internal/controller/personalsecurityenvironment"
+	"github.com/SAP/crossplane-provider-hana/internal/controller/poll"
 	"github.com/SAP/crossplane-provider-hana/internal/controller/role"
 	"github.com/SAP/crossplane-provider-hana/internal/controller/rolegroup"
+	"github.com/SAP/crossplane-provider-hana/internal/controller/singleton"
 	"github.com/SAP/crossplane-provider-hana/internal/controller/user"
 	"github.com/SAP/crossplane-provider-hana/internal/controller/usergroup"
+	"github.com/SAP/crossplane-provider-hana/internal/controller/userreplication"
 	"github.com/SAP/crossplane-provider-hana/internal/controller/x509provider"
 )
 
-// Setup creates all HANA controllers with the supplied logger and adds
-// them to the supplied manager.
-func Setup(mgr ctrl.Manager, o controller.Options, db xsql.Connector) error {
-	// SQL-based controllers
-	for _, setup := range []func(ctrl.Manager, controller.Options, xsql.Connector) error{
-		role.Setup,
-		rolegroup.Setup,
-		usergroup.Setup,
-		dbschema.Setup,
-		auditpolicy.Setup,
-		user.Setup,
-		x509provider.Setup,
-		personalsecurityenvironment.Setup,
-	} {
-		if err := setup(mgr, o, db); err != nil {
-			return err
+// Controller groups that can be selected as a whole.
+const (
+	// GroupSQL are the controllers managing HANA database objects over SQL.
+	GroupSQL = "sql"
+	// GroupInventory are the controllers managing HANA Cloud instances
+	// through the HANA Cloud Admin API.
+	GroupInventory = "inventory"
+)
+
+// Settings configure controllers beyond the controller.Options all of them
+// share.
+type Settings struct {
+	// UserSecretNamespaces restricts the password Secrets whose changes
+	// enqueue Users to these namespaces. Secrets in all namespaces are watched
+	// if it is empty.
+	UserSecretNamespaces []string
+}
+
+type setupFunc func(ctrl.Manager, controller.Options, xsql.Connector, Settings) error
+
+// withoutSettings adapts the setup of a controller that does not use any
+// Settings.
+func withoutSettings(setup func(ctrl.Manager, controller.Options, xsql.Connector) error) setupFunc {
+	return func(mgr ctrl.Manager, o controller.Options, db xsql.Connector, _ Settings) error {
+		return setup(mgr, o, db)
+	}
+}
+
+// withoutDB adapts the setup of a controller that does not use the database.
+func withoutDB(setup func(ctrl.Manager, controller.Options) error) setupFunc {
+	return func(mgr ctrl.Manager, o controller.Options, _ xsql.Connector, _ Settings) error {
+		return setup(mgr, o)
+	}
+}
+
+func setupUser(mgr ctrl.Manager, o controller.Options, db xsql.Connector, st Settings) error {
+	return user.Setup(mgr, o, db, st.UserSecretNamespaces)
+}
+
+// entry is a controller that Setup may add.
+type entry struct {
+	kind  string
+	group string
+	// flag gates the controller behind a feature flag, if set.
+	flag  feature.Flag
+	setup setupFunc
+}
+
+var controllers = []entry{
+	{kind: adminv1alpha1.RoleKind, group: GroupSQL, setup: withoutSettings(role.Setup)},
+	{kind: adminv1alpha1.RolegroupKind, group: GroupSQL, setup: withoutSettings(rolegroup.Setup)},
+	{kind: adminv1alpha1.UsergroupKind, group: GroupSQL, setup: withoutSettings(usergroup.Setup)},
+	{kind: schemav1alpha1.DbSchemaKind, group: GroupSQL, setup: withoutSettings(dbschema.Setup)},
+	{kind: schemav1alpha1.CollectionKind, group: GroupSQL, setup: withoutSettings(collection.Setup)},
+	{kind: adminv1alpha1.AuditPolicyKind, group: GroupSQL, setup: withoutSettings(auditpolicy.Setup)},
+	{kind: adminv1alpha1.UserKind, group: GroupSQL, setup: setupUser},
+	{kind: adminv1alpha1.UserReplicationKind, group: GroupSQL, setup: withoutSettings(userreplication.Setup)},
+	{kind: adminv1alpha1.MonitoringUserKind, group: GroupSQL, setup: withoutSettings(monitoringuser.Setup)},
+	{kind: adminv1alpha1.X509ProviderKind, group: GroupSQL, setup: withoutSettings(x509provider.Setup)},
+	{kind: adminv1alpha1.PersonalSecurityEnvironmentKind, group: GroupSQL, setup: withoutSettings(personalsecurityenvironment.Setup)},
+	{kind: adminv1alpha1.DriftReportKind, group: GroupSQL, setup: withoutSettings(driftreport.Setup)},
+	{kind: adminv1alpha1.SingletonKind, group: GroupSQL, flag: features.EnableAlphaSingleton, setup: withoutSettings(singleton.Setup)},
+	{kind: connectionpropagation.Kind, group: GroupSQL, flag: features.EnableAlphaConnectionPropagation, setup: withoutDB(connectionpropagation.Setup)},
+	{kind: inventoryv1alpha1.InstanceMappingKind, group: GroupInventory, setup: withoutDB(instancemapping.Setup)},
+	{kind: inventoryv1alpha1.KymaInstanceMappingKind, group: GroupInventory, setup: withoutDB(kymainstancemapping.Setup)},
+	{kind: inventoryv1alpha1.InstanceConfigurationKind, group: GroupInventory, flag: features.EnableAlphaInstanceConfiguration, setup: withoutDB(instanceconfiguration.Setup)},
+	{kind: inventoryv1alpha1.BackupConfigurationKind, group: GroupInventory, flag: features.EnableAlphaBackupConfiguration, setup: withoutDB(backupconfiguration.Setup)},
+	{kind: inventoryv1alpha1.HanaCloudInstanceInfoKind, group: GroupInventory, flag: features.EnableAlphaHanaCloudInstanceInfo, setup: withoutDB(hanacloudinstanceinfo.Setup)},
+}
+
+// Selection is the set of controllers a provider instance runs. The zero
+// value selects all controllers.
+type Selection struct {
+	names []string
+	kinds map[string]bool
+}
+
+// Select returns the selection of the requested controller groups and kinds.
+// Each requested value may hold a comma-separated list of names, which are
+// matched case-insensitively. Nothing requested selects all controllers.
+// Unknown names are rejected so that typos do not silently leave resources
+// unreconciled.
+func Select(requested []string) (Selection, error) {
+	s := Selection{}
+	for _, value := range requested {
+		for _, name := range strings.Split(value, ",") {
+			name = strings.ToLower(strings.TrimSpace(name))
+			if name == "" {
+				continue
+			}
+			matched := false
+			for _, c := range controllers {
+				if c.group == name || strings.ToLower(c.kind) == name {
+					if s.kinds == nil {
+						s.kinds = map[string]bool{}
+					}
+					s.kinds[c.kind] = true
+					matched = true
+				}
+			}
+			if !matched {
+				return Selection{}, fmt.Errorf("unknown controller %q, known controllers are %s", name, strings.Join(Names(), ", "))
+			}
+			s.names = append(s.names, name)
 		}
 	}
-	// Non SQL controllers
-	if err := instancemapping.Setup(mgr, o); err != nil {
-		return err
+	slices.Sort(s.names)
+	s.names = slices.Compact(s.names)
+	return s, nil
+}
+
+// Names returns the names of all controller groups and kinds that can be
+// selected.
+func Names() []string {
+	kinds := make([]string, 0, len(controllers))
+	for _, c := range controllers {
+		kinds = append(kinds, c.kind)
 	}
-	if err := kymainstancemapping.Setup(mgr, o); err != nil {
-		return err
+	sort.Strings(kinds)
+	return append([]string{GroupSQL, GroupInventory}, kinds...)
+}
+
+// All returns whether all controllers are selected.
+func (s Selection) All() bool {
+	return s.kinds == nil
+}
+
+// Includes returns whether the controller of the kind is selected.
+func (s Selection) Includes(kind string) bool {
+	return s.All() || s.kinds[kind]
+}
+
+// Names returns the requested groups and kinds, lower-cased and sorted.
+func (s Selection) Names() []string {
+	return s.names
+}
+
+// ConfigurePollJitter sets the jitter of the poll interval of the
+// controllers. Each requested value may hold a comma-separated list of
+// durations applying to all controllers, or of <name>=<duration> for a
+// controller group or kind, matched case-insensitively. The
+// jitter of a kind takes precedence over the one of its group, which takes
+// precedence over the one of all controllers.
+func ConfigurePollJitter(requested []string) error {
+	var all time.Duration
+	named := map[string]time.Duration{}
+	for _, value := range requested {
+		for _, v := range strings.Split(value, ",") {
+			if strings.TrimSpace(v) == "" {
+				continue
+			}
+			name, d, found := strings.Cut(v, "=")
+			if !found {
+				name, d = "", v
+			}
+			name = strings.ToLower(strings.TrimSpace(name))
+			jitter, err := time.ParseDuration(strings.TrimSpace(d))
+			if err != nil || jitter < 0 {
+				return fmt.Errorf("invalid poll jitter %q, want a non-negative duration", v)
+			}
+			if name == "" {
+				all = jitter
+				continue
+			}
+			if !slices.ContainsFunc(Names(), func(n string) bool { return strings.ToLower(n) == name }) {
+				return fmt.Errorf("unknown controller %q, known controllers are %s", name, strings.Join(Names(), ", "))
+			}
+			named[name] = jitter
+		}
 	}
 
+	for _, c := range controllers {
+		jitter := all
+		if j, ok := named[c.group]; ok {
+			jitter = j
+		}
+		if j, ok := named[strings.ToLower(c.kind)]; ok {
+			jitter = j
+		}
+		poll.SetJitter(c.kind, jitter)
+	}
+	return nil
+}
+
+// Setup creates the selected HANA controllers with the supplied logger and
+// settings, and adds them to the supplied manager.
+func Setup(mgr ctrl.Manager, o controller.Options, db xsql.Connector, s Selection, st Settings) error {
+	for _, c := range controllers {
+		if !s.Includes(c.kind) {
+			continue
+		}
+		if c.flag != "" && !o.Features.Enabled(c.flag) {
+			continue
+		}
+		if err := c.setup(mgr, o, db, st); err != nil {
+			return err
+		}
+	}
 	return nil
 }
@@ -0,0 +1,129 @@
+/*
+Copyright 2026 SAP SE or an SAP affiliate company and contributors.
+*/
+
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/SAP/crossplane-provider-hana/internal/controller/poll"
+)
+
+func TestSelect(t *testing.T) {
+	type want struct {
+		names    []string
+		included []string
+		excluded []string
+		err      bool
+	}
+
+	cases := map[string]struct {
+		reason    string
+		requested []string
+		want      want
+	}{
+		"All": {
+			reason: "Nothing requested should select all controllers",
+			want: want{
+				included: []string{"User", "InstanceMapping", "BackupConfiguration"},
+			},
+		},
+		"Group": {
+			reason:    "A group should select all of its controllers and no others",
+			requested: []string{"inventory"},
+			want: want{
+				names:    []string{"inventory"},
+				included: []string{"InstanceMapping", "KymaInstanceMapping", "InstanceConfiguration", "BackupConfiguration", "HanaCloudInstanceInfo"},
+				excluded: []string{"User", "Role", "DbSchema"},
+			},
+		},
+		"KindsCommaSeparatedAndRepeated": {
+			reason:    "Kinds should be matched case-insensitively, comma-separated or repeated",
+			requested: []string{"User, role", "User"},
+			want: want{
+				names:    []string{"role", "user"},
+				included: []string{"User", "Role"},
+				excluded: []string{"Usergroup", "InstanceMapping"},
+			},
+		},
+		"Unknown": {
+			reason:    "Unknown controllers should be rejected",
+			requested: []string{"sql,Users"},
+			want: want{
+				err: true,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got, err := Select(tc.requested)
+			if (err != nil) != tc.want.err {
+				t.Fatalf("\n%s\nSelect(...): error = %v, want error %t", tc.reason, err, tc.want.err)
+			}
+			if tc.want.err {
+				return
+			}
+			if diff := cmp.Diff(tc.want.names, got.Names()); diff != "" {
+				t.Errorf("\n%s\nSelect(...): -want names, +got names:\n%s", tc.reason, diff)
+			}
+			for _, kind := range tc.want.included {
+				if !got.Includes(kind) {
+					t.Errorf("\n%s\nSelect(...): want %s included", tc.reason, kind)
+				}
+			}
+			for _, kind := range tc.want.excluded {
+				if got.Includes(kind) {
+					t.Errorf("\n%s\nSelect(...): want %s excluded", tc.reason, kind)
+				}
+			}
+		})
+	}
+}
+
+func TestConfigurePollJitter(t *testing.T) {
+	cases := map[string]struct {
+		reason    string
+		requested []string
+		want      map[string]time.Duration
+		wantErr   bool
+	}{
+		"None": {
+			reason: "Nothing requested should configure no jitter",
+			want:   map[string]time.Duration{"User": 0, "InstanceMapping": 0},
+		},
+		"Precedence": {
+			reason:    "A kind should take precedence over its group, which takes precedence over all controllers",
+			requested: []string{"user=1m, 10s", "SQL=30s"},
+			want:      map[string]time.Duration{"User": time.Minute, "Role": 30 * time.Second, "InstanceMapping": 10 * time.Second},
+		},
+		"UnknownController": {
+			reason:    "Unknown controllers should be rejected",
+			requested: []string{"users=1m"},
+			wantErr:   true,
+		},
+		"Negative": {
+			reason:    "Negative durations should be rejected",
+			requested: []string{"-1s"},
+			wantErr:   true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := ConfigurePollJitter(tc.requested)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("\n%s\nConfigurePollJitter(...): error = %v, want error %t", tc.reason, err, tc.wantErr)
+			}
+			for kind, want := range tc.want {
+				if got := poll.Jitter(kind); got != want {
+					t.Errorf("\n%s\nConfigurePollJitter(...): jitter of %s = %s, want %s", tc.reason, kind, got, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,218 @@
+/*
+Copyright 2026 SAP SE.
+*/
+
+package hanacloudinstanceinfo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/SAP/crossplane-provider-hana/apis/inventory/v1alpha1"
+	"github.com/SAP/crossplane-provider-hana/internal/clients/hanacloud"
+	"github.com/SAP/crossplane-provider-hana/internal/clients/hanacloud/instance"
+	"github.com/SAP/crossplane-provider-hana/internal/clients/proxy"
+	"github.com/SAP/crossplane-provider-hana/internal/controller/features"
+	"github.com/SAP/crossplane-provider-hana/internal/controller/outcome"
+	"github.com/SAP/crossplane-provider-hana/internal/controller/poll"
+)
+
+const (
+	errNotHanaCloudInstanceInfo = "managed resource is not a HanaCloudInstanceInfo custom resource"
+	errGetCredentialsSecret     = "cannot get admin credentials secret: %w"
+	errMissingCredentialsKey    = "credentials key %q not found in secret"
+	errParseCredentials         = "cannot parse admin API credentials: %w"
+	errGetProxy                 = "cannot get proxy configuration: %w"
+	errConnectHANACloud         = "cannot connect to HANA Cloud API: %w"
+	errGetInstance              = "cannot get service instance: %w"
+
+	msgNotRunning = "instance is %s"
+
+	// statusRunning is the status of an instance that accepts connections.
+	statusRunning = "RUNNING"
+)
+
+// ClientFactory creates an instance.Client from credentials.
+// This allows injecting mock clients for testing.
+type ClientFactory func(ctx context.Context, creds hanacloud.AdminAPICredentials, log logging.Logger) (instance.Client, error)
+
+// DefaultClientFactory creates a real HANA Cloud client.
+func DefaultClientFactory(ctx context.Context, creds hanacloud.AdminAPICredentials, log logging.Logger) (instance.Client, error) {
+	client := hanacloud.New(log)
+	if err := client.Connect(ctx, creds); err != nil {
+		return nil, err
+	}
+	return client.Instance(), nil
+}
+
+// Setup adds a controller that reconciles HanaCloudInstanceInfo managed resources.
+func Setup(mgr ctrl.Manager, o controller.Options) error {
+	name := managed.ControllerName(v1alpha1.HanaCloudInstanceInfoGroupKind)
+
+	log := o.Logger.WithValues("controller", name)
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1alpha1.HanaCloudInstanceInfoGroupVersionKind),
+		outcome.WithExternalConnecter(name, NewConnector(mgr.GetClient(), log, nil)),
+		managed.WithLogger(log),
+		managed.WithPollInterval(o.PollInterval),
+		poll.WithJitterHook(v1alpha1.HanaCloudInstanceInfoKind, nil),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+		features.ConfigureBetaManagementPolicies(o),
+	)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		For(&v1alpha1.HanaCloudInstanceInfo{}).
+		Complete(r)
+}
+
+// Connector produces an ExternalClient when its Connect method is called.
+// Connector is exported for testing.
+type Connector struct {
+	kube          client.Client
+	log           logging.Logger
+	clientFactory ClientFactory
+}
+
+// NewConnector creates a Connector with the given client factory.
+// If factory is nil, DefaultClientFactory is used.
+func NewConnector(kube client.Client, log logging.Logger, factory ClientFactory) *Connector {
+	if factory == nil {
+		factory = DefaultClientFactory
+	}
+	return &Connector{
+		kube:          kube,
+		log:           log,
+		clientFactory: factory,
+	}
+}
+
+// Connect establishes a connection to the HANA Cloud Admin API using credentials
+// from the referenced Secret.
+func (c *Connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1alpha1.HanaCloudInstanceInfo)
+	if !ok {
+		return nil, errors.New(errNotHanaCloudInstanceInfo)
+	}
+
+	secretRef := cr.Spec.ForProvider.AdminCredentialsSecretRef
+	secret := &corev1.Secret{}
+	if err := c.kube.Get(ctx, types.NamespacedName{
+		Namespace: secretRef.Namespace,
+		Name:      secretRef.Name,
+	}, secret); err != nil {
+		return nil, fmt.Errorf(errGetCredentialsSecret, err)
+	}
+
+	credentialsJSON, ok := secret.Data[secretRef.Key]
+	if !ok {
+		return nil, fmt.Errorf(errMissingCredentialsKey, secretRef.Key)
+	}
+
+	creds, err := hanacloud.ParseAdminAPICredentials(credentialsJSON)
+	if err != nil {
+		return nil, fmt.Errorf(errParseCredentials, err)
+	}
+
+	creds.ProxyURL, err = proxy.URL(ctx, c.kube, cr.Spec.ForProvider.Proxy)
+	if err != nil {
+		return nil, fmt.Errorf(errGetProxy, err)
+	}
+
+	instClient, err := c.clientFactory(ctx, creds, c.log.WithValues("hanacloudinstanceinfo", cr.Name))
+	if err != nil {
+		return nil, fmt.Errorf(errConnectHANACloud, err)
+	}
+
+	return &external{
+		client: instClient,
+		log:    c.log,
+	}, nil
+}
+
+// external observes a HANA Cloud instance without ever changing it.
+type external struct {
+	client instance.Client
+	log    logging.Logger
+}
+
+func (e *external) Disconnect(_ context.Context) error {
+	return nil
+}
+
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.HanaCloudInstanceInfo)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotHanaCloudInstanceInfo)
+	}
+
+	// The instance is not owned by the resource, there is nothing to delete
+	if meta.WasDeleted(cr) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	current, err := e.client.Get(ctx, cr.Spec.ForProvider.ServiceInstanceID)
+	if err != nil {
+		return managed.ExternalObservation{}, fmt.Errorf(errGetInstance, err)
+	}
+
+	cr.Status.AtProvider = observed(current)
+	if current.Status == statusRunning {
+		cr.SetConditions(xpv1.Available())
+	} else {
+		cr.SetConditions(xpv1.Unavailable().WithMessage(fmt.Sprintf(msgNotRunning, current.Status)))
+	}
+
+	// Nothing is desired, the resource is always up to date
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: true,
+	}, nil
+}
+
+func (e *external) Create(_ context.Context, _ resource.Managed) (managed.ExternalCreation, error) {
+	// Instances are created elsewhere - nothing to create
+	return managed.ExternalCreation{}, nil
+}
+
+func (e *external) Update(_ context.Context, _ resource.Managed) (managed.ExternalUpdate, error) {
+	// The resource only observes the instance - nothing to update
+	return managed.ExternalUpdate{}, nil
+}
+
+func (e *external) Delete(_ context.Context, _ resource.Managed) (managed.ExternalDelete, error) {
+	// The instance stays as it is when the resource is deleted
+	return managed.ExternalDelete{}, nil
+}
+
+// observed returns the observation of the instance.
+func observed(current instance.Instance) v1alpha1.HanaCloudInstanceInfoObservation {
+	out := v1alpha1.HanaCloudInstanceInfoObservation{
+		Status:    current.Status,
+		Version:   current.Version,
+		MemoryGB:  current.MemoryGB,
+		VCPU:      current.VCPU,
+		StorageGB: current.StorageGB,
+	}
+	for _, ep := range current.Endpoints {
+		out.Endpoints = append(out.Endpoints, v1alpha1.InstanceEndpoint{
+			Type: ep.Type,
+			Host: ep.Host,
+			Port: ep.Port,
+		})
+	}
+	return out
+}
@@ -0,0 +1,165 @@
+/*
+Copyright 2026 SAP SE.
+*/
+
+package hanacloudinstanceinfo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/SAP/crossplane-provider-hana/apis/inventory/v1alpha1"
+	"github.com/SAP/crossplane-provider-hana/internal/clients/hanacloud/instance"
+)
+
+// MockLogger is a mock implementation of logging.Logger
+type MockLogger struct{}
+
+func (l *MockLogger) Debug(_ string, _ ...interface{}) {}
+func (l *MockLogger) Info(_ string, _ ...interface{})  {}
+func (l *MockLogger) WithValues(_ ...interface{}) logging.Logger {
+	return l
+}
+
+// mockInstanceClient mocks the instance.Client interface
+type mockInstanceClient struct {
+	MockGet func(ctx context.Context, serviceInstanceID string) (instance.Instance, error)
+}
+
+func (m *mockInstanceClient) Get(ctx context.Context, serviceInstanceID string) (instance.Instance, error) {
+	return m.MockGet(ctx, serviceInstanceID)
+}
+
+func current(status string) func(context.Context, string) (instance.Instance, error) {
+	return func(_ context.Context, _ string) (instance.Instance, error) {
+		return instance.Instance{
+			Status:    status,
+			Version:   "2025.2.4",
+			MemoryGB:  new(int32(32)),
+			VCPU:      new(int32(2)),
+			StorageGB: new(int32(120)),
+			Endpoints: []instance.Endpoint{{Type: "database", Host: "abc.hana.example.com", Port: 443}},
+		}, nil
+	}
+}
+
+func observation(status string) v1alpha1.HanaCloudInstanceInfoObservation {
+	return v1alpha1.HanaCloudInstanceInfoObservation{
+		Status:    status,
+		Version:   "2025.2.4",
+		MemoryGB:  new(int32(32)),
+		VCPU:      new(int32(2)),
+		StorageGB: new(int32(120)),
+		Endpoints: []v1alpha1.InstanceEndpoint{{Type: "database", Host: "abc.hana.example.com", Port: 443}},
+	}
+}
+
+func instanceInfo() *v1alpha1.HanaCloudInstanceInfo {
+	return &v1alpha1.HanaCloudInstanceInfo{
+		Spec: v1alpha1.HanaCloudInstanceInfoSpec{
+			ForProvider: v1alpha1.HanaCloudInstanceInfoParameters{
+				ServiceInstanceID: "test-instance-id",
+			},
+		},
+	}
+}
+
+func TestObserve(t *testing.T) {
+	errBoom := errors.New("boom")
+	now := metav1.Now()
+
+	type want struct {
+		o         managed.ExternalObservation
+		status    v1alpha1.HanaCloudInstanceInfoObservation
+		condition xpv1.Condition
+		err       error
+	}
+
+	cases := map[string]struct {
+		reason string
+		client instance.Client
+		mg     resource.Managed
+		want   want
+	}{
+		"ErrNotHanaCloudInstanceInfo": {
+			reason: "An error should be returned if the managed resource is not a *HanaCloudInstanceInfo",
+			want: want{
+				err: errors.New(errNotHanaCloudInstanceInfo),
+			},
+		},
+		"ErrGet": {
+			reason: "Any error getting the instance should be returned",
+			client: &mockInstanceClient{
+				MockGet: func(_ context.Context, _ string) (instance.Instance, error) {
+					return instance.Instance{}, errBoom
+				},
+			},
+			mg: instanceInfo(),
+			want: want{
+				err: fmt.Errorf(errGetInstance, errBoom),
+			},
+		},
+		"Deleted": {
+			reason: "A deleted resource should be reported as gone without reading the instance",
+			mg: func() resource.Managed {
+				cr := instanceInfo()
+				cr.SetDeletionTimestamp(&now)
+				return cr
+			}(),
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: false},
+			},
+		},
+		"Running": {
+			reason: "A running instance should be mirrored into the status and make the resource available",
+			client: &mockInstanceClient{MockGet: current("RUNNING")},
+			mg:     instanceInfo(),
+			want: want{
+				o:         managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				status:    observation("RUNNING"),
+				condition: xpv1.Available(),
+			},
+		},
+		"Stopped": {
+			reason: "A stopped instance should be mirrored into the status and make the resource unavailable",
+			client: &mockInstanceClient{MockGet: current("STOPPED")},
+			mg:     instanceInfo(),
+			want: want{
+				o:         managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				status:    observation("STOPPED"),
+				condition: xpv1.Unavailable().WithMessage(fmt.Sprintf(msgNotRunning, "STOPPED")),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{client: tc.client, log: &MockLogger{}}
+			got, err := e.Observe(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if cr, ok := tc.mg.(*v1alpha1.HanaCloudInstanceInfo); ok {
+				if diff := cmp.Diff(tc.want.status, cr.Status.AtProvider); diff != "" {
+					t.Errorf("\n%s\ne.Observe(...): -want status, +got status:\n%s\n", tc.reason, diff)
+				}
+				if tc.want.condition.Type != "" && !tc.want.condition.Equal(cr.GetCondition(xpv1.TypeReady)) {
+					t.Errorf("\n%s\ne.Observe(...): want condition %v, got %v\n", tc.reason, tc.want.condition, cr.GetCondition(xpv1.TypeReady))
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,281 @@
+/*
+Copyright 2026 SAP SE.
+*/
+
+package instanceconfiguration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/SAP/crossplane-provider-hana/apis/inventory/v1alpha1"
+	"github.com/SAP/crossplane-provider-hana/internal/clients/hanacloud"
+	cfgclient "github.com/SAP/crossplane-provider-hana/internal/clients/hanacloud/configuration"
+	"github.com/SAP/crossplane-provider-hana/internal/clients/hanacloud/operation"
+	"github.com/SAP/crossplane-provider-hana/internal/clients/proxy"
+	"github.com/SAP/crossplane-provider-hana/internal/controller/features"
+	"github.com/SAP/crossplane-provider-hana/internal/controller/outcome"
+	"github.com/SAP/crossplane-provider-hana/internal/controller/poll"
+)
+
+const (
+	errNotInstanceConfiguration = "managed resource is not an InstanceConfiguration custom resource"
+	errGetCredentialsSecret     = "cannot get admin credentials secret: %w"
+	errMissingCredentialsKey    = "credentials key %q not found in secret"
+	errParseCredentials         = "cannot parse admin API credentials: %w"
+	errGetProxy                 = "cannot get proxy configuration: %w"
+	errConnectHANACloud         = "cannot connect to HANA Cloud API: %w"
+	errGetParameters            = "cannot get instance configuration: %w"
+	errUpdateParameters         = "cannot update instance configuration: %w"
+
+	msgOperationInProgress = "operation %s is applying the configuration"
+)
+
+// ClientFactory creates a configuration.Client from credentials.
+// This allows injecting mock clients for testing.
+type ClientFactory func(ctx context.Context, creds hanacloud.AdminAPICredentials, log logging.Logger) (cfgclient.Client, error)
+
+// DefaultClientFactory creates a real HANA Cloud client.
+func DefaultClientFactory(ctx context.Context, creds hanacloud.AdminAPICredentials, log logging.Logger) (cfgclient.Client, error) {
+	client := hanacloud.New(log)
+	if err := client.Connect(ctx, creds); err != nil {
+		return nil, err
+	}
+	return client.Configuration(), nil
+}
+
+// Setup adds a controller that reconciles InstanceConfiguration managed resources.
+func Setup(mgr ctrl.Manager, o controller.Options) error {
+	name := managed.ControllerName(v1alpha1.InstanceConfigurationGroupKind)
+
+	log := o.Logger.WithValues("controller", name)
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1alpha1.InstanceConfigurationGroupVersionKind),
+		outcome.WithExternalConnecter(name, NewConnector(mgr.GetClient(), log, nil)),
+		managed.WithLogger(log),
+		managed.WithPollInterval(o.PollInterval),
+		poll.WithJitterHook(v1alpha1.InstanceConfigurationKind, nil),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+		features.ConfigureBetaManagementPolicies(o),
+	)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		For(&v1alpha1.InstanceConfiguration{}).
+		Complete(r)
+}
+
+// Connector produces an ExternalClient when its Connect method is called.
+// Connector is exported for testing.
+type Connector struct {
+	kube          client.Client
+	log           logging.Logger
+	clientFactory ClientFactory
+}
+
+// NewConnector creates a Connector with the given client factory.
+// If factory is nil, DefaultClientFactory is used.
+func NewConnector(kube client.Client, log logging.Logger, factory ClientFactory) *Connector {
+	if factory == nil {
+		factory = DefaultClientFactory
+	}
+	return &Connector{
+		kube:          kube,
+		log:           log,
+		clientFactory: factory,
+	}
+}
+
+// Connect establishes a connection to the HANA Cloud Admin API using credentials
+// from the referenced Secret.
+func (c *Connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1alpha1.InstanceConfiguration)
+	if !ok {
+		return nil, errors.New(errNotInstanceConfiguration)
+	}
+
+	secretRef := cr.Spec.ForProvider.AdminCredentialsSecretRef
+	secret := &corev1.Secret{}
+	if err := c.kube.Get(ctx, types.NamespacedName{
+		Namespace: secretRef.Namespace,
+		Name:      secretRef.Name,
+	}, secret); err != nil {
+		return nil, fmt.Errorf(errGetCredentialsSecret, err)
+	}
+
+	credentialsJSON, ok := secret.Data[secretRef.Key]
+	if !ok {
+		return nil, fmt.Errorf(errMissingCredentialsKey, secretRef.Key)
+	}
+
+	creds, err := hanacloud.ParseAdminAPICredentials(credentialsJSON)
+	if err != nil {
+		return nil, fmt.Errorf(errParseCredentials, err)
+	}
+
+	creds.ProxyURL, err = proxy.URL(ctx, c.kube, cr.Spec.ForProvider.Proxy)
+	if err != nil {
+		return nil, fmt.Errorf(errGetProxy, err)
+	}
+
+	cfgClient, err := c.clientFactory(ctx, creds, c.log.WithValues("instanceconfiguration", cr.Name))
+	if err != nil {
+		return nil, fmt.Errorf(errConnectHANACloud, err)
+	}
+
+	return &external{
+		client:  cfgClient,
+		timeout: operation.DefaultTimeout,
+		log:     c.log,
+	}, nil
+}
+
+// external observes and updates the configuration of a HANA Cloud instance.
+type external struct {
+	client  cfgclient.Client
+	timeout time.Duration
+	log     logging.Logger
+}
+
+func (e *external) Disconnect(_ context.Context) error {
+	return nil
+}
+
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.InstanceConfiguration)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotInstanceConfiguration)
+	}
+
+	// The parameters stay on the instance, there is nothing to delete
+	if meta.WasDeleted(cr) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	params := cr.Spec.ForProvider
+	status := &cr.Status.AtProvider
+
+	// Wait for the last update to finish before comparing, the parameters
+	// only change once the operation has been applied
+	pending, err := operation.Resume(ctx, e.client, params.ServiceInstanceID, &status.Operation, e.timeout, time.Now())
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	if pending {
+		cr.SetConditions(xpv1.Unavailable().WithMessage(fmt.Sprintf(msgOperationInProgress, status.Operation.ID)))
+		return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, nil
+	}
+
+	current, err := e.client.Get(ctx, params.ServiceInstanceID)
+	if err != nil {
+		return managed.ExternalObservation{}, fmt.Errorf(errGetParameters, err)
+	}
+
+	status.Parameters = observed(params.Parameters, current)
+	upToDate := len(changed(params.Parameters, current)) == 0
+	if upToDate {
+		cr.SetConditions(xpv1.Available())
+	}
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: upToDate,
+	}, nil
+}
+
+func (e *external) Create(_ context.Context, _ resource.Managed) (managed.ExternalCreation, error) {
+	// The configuration of an instance always exists - nothing to create
+	return managed.ExternalCreation{}, nil
+}
+
+func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.InstanceConfiguration)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotInstanceConfiguration)
+	}
+
+	params := cr.Spec.ForProvider
+
+	current, err := e.client.Get(ctx, params.ServiceInstanceID)
+	if err != nil {
+		return managed.ExternalUpdate{}, fmt.Errorf(errGetParameters, err)
+	}
+
+	toUpdate := changed(params.Parameters, current)
+	if len(toUpdate) == 0 {
+		return managed.ExternalUpdate{}, nil
+	}
+
+	e.log.Info("Updating instance configuration",
+		"name", cr.Name,
+		"serviceInstanceID", params.ServiceInstanceID,
+		"parameters", len(toUpdate))
+
+	opID, err := e.client.Update(ctx, params.ServiceInstanceID, toUpdate)
+	if err != nil {
+		return managed.ExternalUpdate{}, fmt.Errorf(errUpdateParameters, err)
+	}
+
+	operation.Start(&cr.Status.AtProvider.Operation, opID, time.Now())
+	cr.SetConditions(xpv1.Unavailable().WithMessage(fmt.Sprintf(msgOperationInProgress, opID)))
+	return managed.ExternalUpdate{}, nil
+}
+
+func (e *external) Delete(_ context.Context, _ resource.Managed) (managed.ExternalDelete, error) {
+	// Parameters are left as they are, the Admin API has no way to restore
+	// their previous values
+	return managed.ExternalDelete{}, nil
+}
+
+// parameterKey identifies a parameter on the instance.
+type parameterKey struct {
+	file, section, key string
+}
+
+func currentValues(current []cfgclient.Parameter) map[parameterKey]string {
+	values := make(map[parameterKey]string, len(current))
+	for _, p := range current {
+		values[parameterKey{p.File, p.Section, p.Key}] = p.Value
+	}
+	return values
+}
+
+// changed returns the desired parameters whose value differs from the current
+// one or that are not set on the instance.
+func changed(desired []v1alpha1.ConfigurationParameter, current []cfgclient.Parameter) []cfgclient.Parameter {
+	values := currentValues(current)
+	var out []cfgclient.Parameter
+	for _, p := range desired {
+		if v, ok := values[parameterKey{p.File, p.Section, p.Key}]; ok && v == p.Value {
+			continue
+		}
+		out = append(out, cfgclient.Parameter{File: p.File, Section: p.Section, Key: p.Key, Value: p.Value})
+	}
+	return out
+}
+
+// observed returns the current values of the desired parameters.
+func observed(desired []v1alpha1.ConfigurationParameter, current []cfgclient.Parameter) []v1alpha1.ConfigurationParameter {
+	values := currentValues(current)
+	var out []v1alpha1.ConfigurationParameter
+	for _, p := range desired {
+		if v, ok := values[parameterKey{p.File, p.Section, p.Key}]; ok {
+			out = append(out, v1alpha1.ConfigurationParameter{File: p.File, Section: p.Section, Key: p.Key, Value: v})
+		}
+	}
+	return out
+}
@@ -0,0 +1,297 @@
+/*
+Copyright 2026 SAP SE.
+*/
+
+package instanceconfiguration
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/SAP/crossplane-provider-hana/apis/inventory/v1alpha1"
+	cfgclient "github.com/SAP/crossplane-provider-hana/internal/clients/hanacloud/configuration"
+	"github.com/SAP/crossplane-provider-hana/internal/clients/hanacloud/operation"
+)
+
+// MockLogger is a mock implementation of logging.Logger
+type MockLogger struct{}
+
+func (l *MockLogger) Debug(_ string, _ ...interface{}) {}
+func (l *MockLogger) Info(_ string, _ ...interface{})  {}
+func (l *MockLogger) WithValues(_ ...interface{}) logging.Logger {
+	return l
+}
+
+// mockConfigurationClient mocks the configuration.Client interface
+type mockConfigurationClient struct {
+	MockGet          func(ctx context.Context, serviceInstanceID string) ([]cfgclient.Parameter, error)
+	MockUpdate       func(ctx context.Context, serviceInstanceID string, params []cfgclient.Parameter) (string, error)
+	MockGetOperation func(ctx context.Context, serviceInstanceID, operationID string) (operation.Operation, error)
+}
+
+func (m *mockConfigurationClient) Get(ctx context.Context, serviceInstanceID string) ([]cfgclient.Parameter, error) {
+	return m.MockGet(ctx, serviceInstanceID)
+}
+
+func (m *mockConfigurationClient) Update(ctx context.Context, serviceInstanceID string, params []cfgclient.Parameter) (string, error) {
+	return m.MockUpdate(ctx, serviceInstanceID, params)
+}
+
+func (m *mockConfigurationClient) GetOperation(ctx context.Context, serviceInstanceID, operationID string) (operation.Operation, error) {
+	return m.MockGetOperation(ctx, serviceInstanceID, operationID)
+}
+
+var (
+	docstore     = v1alpha1.ConfigurationParameter{File: "indexserver.ini", Section: "json", Key: "enabled", Value: "true"}
+	scriptserver = v1alpha1.ConfigurationParameter{File: "daemon.ini", Section: "scriptserver", Key: "instances", Value: "1"}
+
+	started = &metav1.Time{Time: time.Now()}
+)
+
+func current(params ...v1alpha1.ConfigurationParameter) func(context.Context, string) ([]cfgclient.Parameter, error) {
+	return func(_ context.Context, _ string) ([]cfgclient.Parameter, error) {
+		out := []cfgclient.Parameter{{File: "global.ini", Section: "persistence", Key: "log_mode", Value: "normal"}}
+		for _, p := range params {
+			out = append(out, cfgclient.Parameter{File: p.File, Section: p.Section, Key: p.Key, Value: p.Value})
+		}
+		return out, nil
+	}
+}
+
+func runningOperation(opID string) v1alpha1.OperationObservation {
+	return v1alpha1.OperationObservation{ID: opID, State: operation.StateInProgress, StartTime: started}
+}
+
+func instanceConfiguration(opID string) *v1alpha1.InstanceConfiguration {
+	var op v1alpha1.OperationObservation
+	if opID != "" {
+		op = runningOperation(opID)
+	}
+	return &v1alpha1.InstanceConfiguration{
+		Spec: v1alpha1.InstanceConfigurationSpec{
+			ForProvider: v1alpha1.InstanceConfigurationParameters{
+				ServiceInstanceID: "test-instance-id",
+				Parameters:        []v1alpha1.ConfigurationParameter{docstore, scriptserver},
+			},
+		},
+		Status: v1alpha1.InstanceConfigurationStatus{
+			AtProvider: v1alpha1.InstanceConfigurationObservation{Operation: op},
+		},
+	}
+}
+
+func TestObserve(t *testing.T) {
+	errBoom := errors.New("boom")
+	scriptserverOff := scriptserver
+	scriptserverOff.Value = "0"
+
+	type want struct {
+		o      managed.ExternalObservation
+		status v1alpha1.InstanceConfigurationObservation
+		err    error
+	}
+
+	cases := map[string]struct {
+		reason string
+		client cfgclient.Client
+		mg     resource.Managed
+		want   want
+	}{
+		"ErrNotInstanceConfiguration": {
+			reason: "An error should be returned if the managed resource is not an *InstanceConfiguration",
+			want: want{
+				err: errors.New(errNotInstanceConfiguration),
+			},
+		},
+		"ErrGet": {
+			reason: "Any error getting the configuration should be returned",
+			client: &mockConfigurationClient{
+				MockGet: func(_ context.Context, _ string) ([]cfgclient.Parameter, error) {
+					return nil, errBoom
+				},
+			},
+			mg: instanceConfiguration(""),
+			want: want{
+				err: fmt.Errorf(errGetParameters, errBoom),
+			},
+		},
+		"UpToDate": {
+			reason: "The resource should be up to date if all desired parameters have their value",
+			client: &mockConfigurationClient{MockGet: current(docstore, scriptserver)},
+			mg:     instanceConfiguration(""),
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				status: v1alpha1.InstanceConfigurationObservation{
+					Parameters: []v1alpha1.ConfigurationParameter{docstore, scriptserver},
+				},
+			},
+		},
+		"ValueDiffers": {
+			reason: "The resource should not be up to date if a parameter has another value",
+			client: &mockConfigurationClient{MockGet: current(docstore, scriptserverOff)},
+			mg:     instanceConfiguration(""),
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+				status: v1alpha1.InstanceConfigurationObservation{
+					Parameters: []v1alpha1.ConfigurationParameter{docstore, scriptserverOff},
+				},
+			},
+		},
+		"ParameterNotSet": {
+			reason: "The resource should not be up to date if a parameter is not set on the instance",
+			client: &mockConfigurationClient{MockGet: current(docstore)},
+			mg:     instanceConfiguration(""),
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+				status: v1alpha1.InstanceConfigurationObservation{
+					Parameters: []v1alpha1.ConfigurationParameter{docstore},
+				},
+			},
+		},
+		"OperationInProgress": {
+			reason: "A pending operation should be waited for without comparing the parameters",
+			client: &mockConfigurationClient{
+				MockGetOperation: func(_ context.Context, _, opID string) (operation.Operation, error) {
+					return operation.Operation{ID: opID, State: operation.StateInProgress}, nil
+				},
+			},
+			mg: instanceConfiguration("op-1"),
+			want: want{
+				o:      managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				status: v1alpha1.InstanceConfigurationObservation{Operation: runningOperation("op-1")},
+			},
+		},
+		"OperationFailed": {
+			reason: "A failed operation should be recorded and the parameters compared again",
+			client: &mockConfigurationClient{
+				MockGetOperation: func(_ context.Context, _, opID string) (operation.Operation, error) {
+					return operation.Operation{ID: opID, State: operation.StateFailed, Message: "restart failed"}, nil
+				},
+				MockGet: current(docstore),
+			},
+			mg: instanceConfiguration("op-1"),
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+				status: v1alpha1.InstanceConfigurationObservation{
+					Parameters: []v1alpha1.ConfigurationParameter{docstore},
+					Operation: v1alpha1.OperationObservation{
+						ID: "op-1", State: operation.StateFailed, Message: "restart failed", StartTime: started,
+					},
+				},
+			},
+		},
+		"ErrGetOperation": {
+			reason: "Any error getting the pending operation should be returned",
+			client: &mockConfigurationClient{
+				MockGetOperation: func(_ context.Context, _, _ string) (operation.Operation, error) {
+					return operation.Operation{}, errBoom
+				},
+			},
+			mg: instanceConfiguration("op-1"),
+			want: want{
+				status: v1alpha1.InstanceConfigurationObservation{Operation: runningOperation("op-1")},
+				err:    fmt.Errorf("cannot get state of operation %s: %w", "op-1", errBoom),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{client: tc.client, timeout: operation.DefaultTimeout, log: &MockLogger{}}
+			got, err := e.Observe(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if cr, ok := tc.mg.(*v1alpha1.InstanceConfiguration); ok {
+				if diff := cmp.Diff(tc.want.status, cr.Status.AtProvider); diff != "" {
+					t.Errorf("\n%s\ne.Observe(...): -want status, +got status:\n%s\n", tc.reason, diff)
+				}
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type want struct {
+		updated []cfgclient.Parameter
+		opID    string
+		err     error
+	}
+
+	cases := map[string]struct {
+		reason    string
+		get       func(context.Context, string) ([]cfgclient.Parameter, error)
+		updateErr error
+		want      want
+	}{
+		"OnlyChangedParameters": {
+			reason: "Only the parameters that differ should be sent and the operation recorded",
+			get:    current(docstore),
+			want: want{
+				updated: []cfgclient.Parameter{{File: "daemon.ini", Section: "scriptserver", Key: "instances", Value: "1"}},
+				opID:    "op-1",
+			},
+		},
+		"NothingChanged": {
+			reason: "No update should be requested if all parameters have their value",
+			get:    current(docstore, scriptserver),
+		},
+		"ErrUpdate": {
+			reason:    "Any error updating the configuration should be returned",
+			get:       current(),
+			updateErr: errBoom,
+			want: want{
+				updated: []cfgclient.Parameter{
+					{File: "indexserver.ini", Section: "json", Key: "enabled", Value: "true"},
+					{File: "daemon.ini", Section: "scriptserver", Key: "instances", Value: "1"},
+				},
+				err: fmt.Errorf(errUpdateParameters, errBoom),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var updated []cfgclient.Parameter
+			e := &external{
+				client: &mockConfigurationClient{
+					MockGet: tc.get,
+					MockUpdate: func(_ context.Context, _ string, params []cfgclient.Parameter) (string, error) {
+						updated = params
+						if tc.updateErr != nil {
+							return "", tc.updateErr
+						}
+						return "op-1", nil
+					},
+				},
+				log: &MockLogger{},
+			}
+			cr := instanceConfiguration("")
+			_, err := e.Update(context.Background(), cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.updated, updated); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want parameters, +got parameters:\n%s\n", tc.reason, diff)
+			}
+			if got := cr.Status.AtProvider.Operation.ID; got != tc.want.opID {
+				t.Errorf("\n%s\ne.Update(...): want operation %q, got %q\n", tc.reason, tc.want.opID, got)
+			}
+		})
+	}
+}
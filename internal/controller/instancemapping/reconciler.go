@@ -8,6 +8,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 	"github.com/crossplane/crossplane-runtime/pkg/controller"
@@ -24,7 +25,12 @@ import (
 	"github.com/SAP/crossplane-provider-hana/apis/inventory/v1alpha1"
 	"github.com/SAP/crossplane-provider-hana/internal/clients/hanacloud"
 	imclient "github.com/SAP/crossplane-provider-hana/internal/clients/hanacloud/instancemapping"
+	"github.com/SAP/crossplane-provider-hana/internal/clients/hanacloud/operation"
+	"github.com/SAP/crossplane-provider-hana/internal/clients/proxy"
+	"github.com/SAP/crossplane-provider-hana/internal/controller/externalname"
 	"github.com/SAP/crossplane-provider-hana/internal/controller/features"
+	"github.com/SAP/crossplane-provider-hana/internal/controller/outcome"
+	"github.com/SAP/crossplane-provider-hana/internal/controller/poll"
 )
 
 const (
@@ -32,10 +38,13 @@ const (
 	errGetCredentialsSecret  = "cannot get admin credentials secret: %w"
 	errMissingCredentialsKey = "credentials key %q not found in secret"
 	errParseCredentials      = "cannot parse admin API credentials: %w"
+	errGetProxy              = "cannot get proxy configuration: %w"
 	errConnectHANACloud      = "cannot connect to HANA Cloud API: %w"
 	errListMappings          = "cannot list instance mappings: %w"
 	errCreateMapping         = "cannot create instance mapping: %w"
 	errDeleteMapping         = "cannot delete instance mapping: %w"
+
+	msgOperationInProgress = "operation %s is applying the instance mapping"
 )
 
 // ClientFactory creates an instancemapping.Client from credentials.
@@ -58,8 +67,9 @@ func Setup(mgr ctrl.Manager, o controller.Options) error {
 	log := o.Logger.WithValues("controller", name)
 	r := managed.NewReconciler(mgr,
 		resource.ManagedKind(v1alpha1.InstanceMappingGroupVersionKind),
-		managed.WithExternalConnecter(NewConnector(mgr.GetClient(), log, nil)),
+		outcome.WithExternalConnecter(name, NewConnector(mgr.GetClient(), log, nil)),
 		managed.WithLogger(log),
+		poll.WithJitterHook(v1alpha1.InstanceMappingKind, nil),
 		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
 		features.ConfigureBetaManagementPolicies(o),
 	)
@@ -120,6 +130,11 @@ func (c *Connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 		return nil, fmt.Errorf(errParseCredentials, err)
 	}
 
+	creds.ProxyURL, err = proxy.URL(ctx, c.kube, cr.Spec.ForProvider.Proxy)
+	if err != nil {
+		return nil, fmt.Errorf(errGetProxy, err)
+	}
+
 	// Create client using factory
 	imClient, err := c.clientFactory(ctx, creds, c.log.WithValues("instancemapping", cr.Name))
 	if err != nil {
@@ -129,15 +144,17 @@ func (c *Connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 	c.log.Info("Connected to HANA Cloud Admin API", "instancemapping", cr.Name)
 
 	return &external{
-		client: imClient,
-		log:    c.log,
+		client:  imClient,
+		timeout: operation.DefaultTimeout,
+		log:     c.log,
 	}, nil
 }
 
 // external observes, creates, updates, or deletes an external resource.
 type external struct {
-	client imclient.Client
-	log    logging.Logger
+	client  imclient.Client
+	timeout time.Duration
+	log     logging.Logger
 }
 
 func (e *external) Disconnect(_ context.Context) error {
@@ -158,16 +175,30 @@ func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 		"primaryID", params.PrimaryID,
 		"secondaryID", params.SecondaryID)
 
+	// Creating or deleting the mapping may take a while. Report it as
+	// existing until the operation has finished instead of requesting the
+	// change again.
+	pending, err := operation.Resume(ctx, e.client, params.ServiceInstanceID, &cr.Status.AtProvider.Operation, e.timeout, time.Now())
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	if pending {
+		cr.SetConditions(xpv1.Unavailable().WithMessage(fmt.Sprintf(msgOperationInProgress, cr.Status.AtProvider.Operation.ID)))
+		return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, nil
+	}
+
 	mappings, err := e.client.List(ctx, params.ServiceInstanceID)
 	if err != nil {
 		return managed.ExternalObservation{}, fmt.Errorf(errListMappings, err)
 	}
 
-	// Look for our specific mapping
+	// Look for our specific mapping. Mappings of different platforms may
+	// share an instance, so the platform has to match as well.
 	for _, mapping := range mappings {
-		if mapping.PrimaryID == params.PrimaryID && stringPtrEqual(mapping.SecondaryID, params.SecondaryID) {
+		if mapping.Platform == params.Platform && mapping.PrimaryID == params.PrimaryID && stringPtrEqual(mapping.SecondaryID, params.SecondaryID) {
 			cr.Status.AtProvider.MappingExists = true
 			cr.Status.AtProvider.LastSyncTime = &metav1.Time{Time: metav1.Now().Time}
+			cr.Status.AtProvider.ExternalID = externalID(params)
 			cr.SetConditions(xpv1.Available())
 
 			e.log.Debug("Instance mapping found",
@@ -176,13 +207,15 @@ func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 				"secondaryID", mapping.SecondaryID)
 
 			return managed.ExternalObservation{
-				ResourceExists:   true,
-				ResourceUpToDate: true,
+				ResourceExists:    true,
+				ResourceUpToDate:  true,
+				ConnectionDetails: externalname.ConnectionDetails(cr.Status.AtProvider.ExternalID),
 			}, nil
 		}
 	}
 
 	cr.Status.AtProvider.MappingExists = false
+	cr.Status.AtProvider.ExternalID = ""
 
 	e.log.Debug("Instance mapping not found",
 		"serviceInstanceID", params.ServiceInstanceID,
@@ -214,9 +247,13 @@ func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 		IsDefault:   params.IsDefault,
 	}
 
-	if err := e.client.Create(ctx, params.ServiceInstanceID, req); err != nil {
+	opID, err := e.client.Create(ctx, params.ServiceInstanceID, req)
+	if err != nil {
 		return managed.ExternalCreation{}, fmt.Errorf(errCreateMapping, err)
 	}
+	if opID != "" {
+		operation.Start(&cr.Status.AtProvider.Operation, opID, time.Now())
+	}
 
 	cr.SetConditions(xpv1.Creating())
 	return managed.ExternalCreation{}, nil
@@ -233,6 +270,11 @@ func (e *external) Delete(ctx context.Context, mg resource.Managed) (managed.Ext
 		return managed.ExternalDelete{}, errors.New(errNotInstanceMapping)
 	}
 
+	// The mapping is still being created or deleted, wait for the operation
+	if operation.Pending(cr.Status.AtProvider.Operation) {
+		return managed.ExternalDelete{}, nil
+	}
+
 	params := cr.Spec.ForProvider
 	secondaryID := ""
 	if params.SecondaryID != nil {
@@ -245,14 +287,28 @@ func (e *external) Delete(ctx context.Context, mg resource.Managed) (managed.Ext
 		"primaryID", params.PrimaryID,
 		"secondaryID", secondaryID)
 
-	if err := e.client.Delete(ctx, params.ServiceInstanceID, params.PrimaryID, secondaryID); err != nil {
+	opID, err := e.client.Delete(ctx, params.ServiceInstanceID, params.PrimaryID, secondaryID)
+	if err != nil {
 		return managed.ExternalDelete{}, fmt.Errorf(errDeleteMapping, err)
 	}
+	if opID != "" {
+		operation.Start(&cr.Status.AtProvider.Operation, opID, time.Now())
+	}
 
 	cr.SetConditions(xpv1.Deleting())
 	return managed.ExternalDelete{}, nil
 }
 
+// externalID returns the stable identifier of a mapping, formatted as
+// <serviceInstanceID>/<primaryID>[/<secondaryID>].
+func externalID(params v1alpha1.InstanceMappingParameters) string {
+	id := params.ServiceInstanceID + "/" + params.PrimaryID
+	if params.SecondaryID != nil && *params.SecondaryID != "" {
+		id += "/" + *params.SecondaryID
+	}
+	return id
+}
+
 // stringPtrEqual compares two optional string pointers for equality.
 func stringPtrEqual(a, b *string) bool {
 	if a == nil && b == nil {
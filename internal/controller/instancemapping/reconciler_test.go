@@ -18,6 +18,8 @@ import (
 
 	"github.com/SAP/crossplane-provider-hana/apis/inventory/v1alpha1"
 	imclient "github.com/SAP/crossplane-provider-hana/internal/clients/hanacloud/instancemapping"
+	"github.com/SAP/crossplane-provider-hana/internal/clients/hanacloud/operation"
+	"github.com/SAP/crossplane-provider-hana/internal/controller/externalname"
 )
 
 const testNamespace = "test-namespace"
@@ -33,26 +35,32 @@ func (l *MockLogger) WithValues(_ ...interface{}) logging.Logger {
 
 // mockInstanceMappingClient mocks the instancemapping.Client interface
 type mockInstanceMappingClient struct {
-	MockList   func(ctx context.Context, serviceInstanceID string) ([]imclient.InstanceMapping, error)
-	MockCreate func(ctx context.Context, serviceInstanceID string, req imclient.CreateMappingRequest) error
-	MockDelete func(ctx context.Context, serviceInstanceID, primaryID, secondaryID string) error
+	MockList         func(ctx context.Context, serviceInstanceID string) ([]imclient.InstanceMapping, error)
+	MockCreate       func(ctx context.Context, serviceInstanceID string, req imclient.CreateMappingRequest) (string, error)
+	MockDelete       func(ctx context.Context, serviceInstanceID, primaryID, secondaryID string) (string, error)
+	MockGetOperation func(ctx context.Context, serviceInstanceID, operationID string) (operation.Operation, error)
 }
 
 func (m *mockInstanceMappingClient) List(ctx context.Context, serviceInstanceID string) ([]imclient.InstanceMapping, error) {
 	return m.MockList(ctx, serviceInstanceID)
 }
 
-func (m *mockInstanceMappingClient) Create(ctx context.Context, serviceInstanceID string, req imclient.CreateMappingRequest) error {
+func (m *mockInstanceMappingClient) Create(ctx context.Context, serviceInstanceID string, req imclient.CreateMappingRequest) (string, error) {
 	return m.MockCreate(ctx, serviceInstanceID, req)
 }
 
-func (m *mockInstanceMappingClient) Delete(ctx context.Context, serviceInstanceID, primaryID, secondaryID string) error {
+func (m *mockInstanceMappingClient) Delete(ctx context.Context, serviceInstanceID, primaryID, secondaryID string) (string, error) {
 	return m.MockDelete(ctx, serviceInstanceID, primaryID, secondaryID)
 }
 
+func (m *mockInstanceMappingClient) GetOperation(ctx context.Context, serviceInstanceID, operationID string) (operation.Operation, error) {
+	return m.MockGetOperation(ctx, serviceInstanceID, operationID)
+}
+
 func TestObserve(t *testing.T) {
 	errBoom := errors.New("boom")
 	secondaryID := testNamespace
+	spaceGUID := "ffffffff-1111-2222-3333-444444444444"
 
 	type fields struct {
 		client imclient.Client
@@ -116,8 +124,9 @@ func TestObserve(t *testing.T) {
 			},
 			want: want{
 				o: managed.ExternalObservation{
-					ResourceExists:   true,
-					ResourceUpToDate: true,
+					ResourceExists:    true,
+					ResourceUpToDate:  true,
+					ConnectionDetails: externalname.ConnectionDetails("test-instance-id/cluster-1/test-namespace"),
 				},
 			},
 		},
@@ -153,9 +162,81 @@ func TestObserve(t *testing.T) {
 			},
 			want: want{
 				o: managed.ExternalObservation{
-					ResourceExists:   true,
-					ResourceUpToDate: true,
+					ResourceExists:    true,
+					ResourceUpToDate:  true,
+					ConnectionDetails: externalname.ConnectionDetails("test-instance-id/cluster-1"),
+				},
+			},
+		},
+		"MappingExistsCloudFoundry": {
+			reason: "ResourceExists should be true when the org and space mapping is found",
+			fields: fields{
+				client: &mockInstanceMappingClient{
+					MockList: func(ctx context.Context, serviceInstanceID string) ([]imclient.InstanceMapping, error) {
+						return []imclient.InstanceMapping{
+							{
+								Platform:    "kubernetes",
+								PrimaryID:   "cluster-1",
+								SecondaryID: &secondaryID,
+							},
+							{
+								Platform:    "cloudfoundry",
+								PrimaryID:   "aaaaaaaa-bbbb-cccc-dddd-eeeeeeeeeeee",
+								SecondaryID: &spaceGUID,
+							},
+						}, nil
+					},
+				},
+				log: &MockLogger{},
+			},
+			args: args{
+				mg: &v1alpha1.InstanceMapping{
+					Spec: v1alpha1.InstanceMappingSpec{
+						ForProvider: v1alpha1.InstanceMappingParameters{
+							ServiceInstanceID: "test-instance-id",
+							Platform:          "cloudfoundry",
+							PrimaryID:         "aaaaaaaa-bbbb-cccc-dddd-eeeeeeeeeeee",
+							SecondaryID:       &spaceGUID,
+						},
+					},
+				},
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  true,
+					ConnectionDetails: externalname.ConnectionDetails("test-instance-id/aaaaaaaa-bbbb-cccc-dddd-eeeeeeeeeeee/ffffffff-1111-2222-3333-444444444444"),
+				},
+			},
+		},
+		"MappingOfOtherPlatform": {
+			reason: "ResourceExists should be false when only a mapping of another platform has the same IDs",
+			fields: fields{
+				client: &mockInstanceMappingClient{
+					MockList: func(ctx context.Context, serviceInstanceID string) ([]imclient.InstanceMapping, error) {
+						return []imclient.InstanceMapping{
+							{
+								Platform:  "subaccount-api-access",
+								PrimaryID: "aaaaaaaa-bbbb-cccc-dddd-eeeeeeeeeeee",
+							},
+						}, nil
+					},
 				},
+				log: &MockLogger{},
+			},
+			args: args{
+				mg: &v1alpha1.InstanceMapping{
+					Spec: v1alpha1.InstanceMappingSpec{
+						ForProvider: v1alpha1.InstanceMappingParameters{
+							ServiceInstanceID: "test-instance-id",
+							Platform:          "cloudfoundry",
+							PrimaryID:         "aaaaaaaa-bbbb-cccc-dddd-eeeeeeeeeeee",
+						},
+					},
+				},
+			},
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: false},
 			},
 		},
 		"MappingNotFoundEmptyList": {
@@ -301,7 +382,7 @@ func TestCreate(t *testing.T) {
 			reason: "No error should be returned when we successfully create a mapping with all fields",
 			fields: fields{
 				client: &mockInstanceMappingClient{
-					MockCreate: func(ctx context.Context, serviceInstanceID string, req imclient.CreateMappingRequest) error {
+					MockCreate: func(ctx context.Context, serviceInstanceID string, req imclient.CreateMappingRequest) (string, error) {
 						if serviceInstanceID != "test-instance-id" {
 							t.Errorf("expected serviceInstanceID 'test-instance-id', got %s", serviceInstanceID)
 						}
@@ -317,7 +398,7 @@ func TestCreate(t *testing.T) {
 						if !req.IsDefault {
 							t.Error("expected isDefault to be true")
 						}
-						return nil
+						return "", nil
 					},
 				},
 				log: &MockLogger{},
@@ -341,11 +422,11 @@ func TestCreate(t *testing.T) {
 			reason: "No error should be returned when secondaryID is nil",
 			fields: fields{
 				client: &mockInstanceMappingClient{
-					MockCreate: func(ctx context.Context, serviceInstanceID string, req imclient.CreateMappingRequest) error {
+					MockCreate: func(ctx context.Context, serviceInstanceID string, req imclient.CreateMappingRequest) (string, error) {
 						if req.SecondaryID != nil {
 							t.Errorf("expected nil secondaryID, got %v", req.SecondaryID)
 						}
-						return nil
+						return "", nil
 					},
 				},
 				log: &MockLogger{},
@@ -368,8 +449,8 @@ func TestCreate(t *testing.T) {
 			reason: "Any errors encountered while creating the mapping should be returned",
 			fields: fields{
 				client: &mockInstanceMappingClient{
-					MockCreate: func(ctx context.Context, serviceInstanceID string, req imclient.CreateMappingRequest) error {
-						return errBoom
+					MockCreate: func(ctx context.Context, serviceInstanceID string, req imclient.CreateMappingRequest) (string, error) {
+						return "", errBoom
 					},
 				},
 				log: &MockLogger{},
@@ -484,7 +565,7 @@ func TestDelete(t *testing.T) {
 			reason: "No error should be returned when we successfully delete a mapping with both IDs",
 			fields: fields{
 				client: &mockInstanceMappingClient{
-					MockDelete: func(ctx context.Context, serviceInstanceID, primaryID, secondaryIDParam string) error {
+					MockDelete: func(ctx context.Context, serviceInstanceID, primaryID, secondaryIDParam string) (string, error) {
 						if serviceInstanceID != "test-instance-id" {
 							t.Errorf("expected serviceInstanceID 'test-instance-id', got %s", serviceInstanceID)
 						}
@@ -494,7 +575,7 @@ func TestDelete(t *testing.T) {
 						if secondaryIDParam != testNamespace {
 							t.Errorf("expected secondaryID 'test-namespace', got %s", secondaryIDParam)
 						}
-						return nil
+						return "", nil
 					},
 				},
 				log: &MockLogger{},
@@ -517,11 +598,11 @@ func TestDelete(t *testing.T) {
 			reason: "No error should be returned when secondaryID is nil (should pass empty string)",
 			fields: fields{
 				client: &mockInstanceMappingClient{
-					MockDelete: func(ctx context.Context, serviceInstanceID, primaryID, secondaryIDParam string) error {
+					MockDelete: func(ctx context.Context, serviceInstanceID, primaryID, secondaryIDParam string) (string, error) {
 						if secondaryIDParam != "" {
 							t.Errorf("expected empty secondaryID, got %s", secondaryIDParam)
 						}
-						return nil
+						return "", nil
 					},
 				},
 				log: &MockLogger{},
@@ -544,8 +625,8 @@ func TestDelete(t *testing.T) {
 			reason: "Any errors encountered while deleting the mapping should be returned",
 			fields: fields{
 				client: &mockInstanceMappingClient{
-					MockDelete: func(ctx context.Context, serviceInstanceID, primaryID, secondaryIDParam string) error {
-						return errBoom
+					MockDelete: func(ctx context.Context, serviceInstanceID, primaryID, secondaryIDParam string) (string, error) {
+						return "", errBoom
 					},
 				},
 				log: &MockLogger{},
@@ -578,6 +659,69 @@ func TestDelete(t *testing.T) {
 	}
 }
 
+func TestAsyncOperation(t *testing.T) {
+	state := operation.StateInProgress
+	deleted := false
+	client := &mockInstanceMappingClient{
+		MockCreate: func(_ context.Context, _ string, _ imclient.CreateMappingRequest) (string, error) {
+			return "op-1", nil
+		},
+		MockDelete: func(_ context.Context, _, _, _ string) (string, error) {
+			deleted = true
+			return "", nil
+		},
+		MockGetOperation: func(_ context.Context, _, operationID string) (operation.Operation, error) {
+			return operation.Operation{ID: operationID, State: state}, nil
+		},
+		MockList: func(_ context.Context, _ string) ([]imclient.InstanceMapping, error) {
+			return nil, nil
+		},
+	}
+	e := &external{client: client, timeout: operation.DefaultTimeout, log: &MockLogger{}}
+	cr := &v1alpha1.InstanceMapping{
+		Spec: v1alpha1.InstanceMappingSpec{
+			ForProvider: v1alpha1.InstanceMappingParameters{
+				ServiceInstanceID: "test-instance-id",
+				Platform:          "kubernetes",
+				PrimaryID:         "cluster-1",
+			},
+		},
+	}
+
+	if _, err := e.Create(context.Background(), cr); err != nil {
+		t.Fatalf("e.Create(...): unexpected error: %v", err)
+	}
+	if got := cr.Status.AtProvider.Operation; got.ID != "op-1" || got.State != operation.StateInProgress || got.StartTime == nil {
+		t.Fatalf("e.Create(...): want operation op-1 in progress, got %+v", got)
+	}
+
+	// While the operation runs the mapping is reported as existing, so that
+	// it is neither created again nor deleted
+	o, err := e.Observe(context.Background(), cr)
+	if err != nil {
+		t.Fatalf("e.Observe(...): unexpected error: %v", err)
+	}
+	if diff := cmp.Diff(managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, o); diff != "" {
+		t.Errorf("e.Observe(...): -want, +got:\n%s\n", diff)
+	}
+	if _, err := e.Delete(context.Background(), cr); err != nil || deleted {
+		t.Errorf("e.Delete(...): want no delete while the operation runs, got deleted %t, error %v", deleted, err)
+	}
+
+	// Once it failed the mapping is observed again
+	state = operation.StateFailed
+	o, err = e.Observe(context.Background(), cr)
+	if err != nil {
+		t.Fatalf("e.Observe(...): unexpected error: %v", err)
+	}
+	if o.ResourceExists {
+		t.Errorf("e.Observe(...): want the missing mapping to be reported after the operation failed")
+	}
+	if got := cr.Status.AtProvider.Operation.State; got != operation.StateFailed {
+		t.Errorf("e.Observe(...): want operation state %s, got %s", operation.StateFailed, got)
+	}
+}
+
 func TestStringPtrEqual(t *testing.T) {
 	x := "x"
 	y := "y"
@@ -9,6 +9,8 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"slices"
+	"time"
 
 	servicescloudsapv1 "github.com/SAP/sap-btp-service-operator/api/v1"
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
@@ -16,6 +18,7 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/controller"
 	"github.com/crossplane/crossplane-runtime/pkg/event"
 	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
 	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 	corev1 "k8s.io/api/core/v1"
@@ -30,7 +33,11 @@ import (
 	apisv1alpha1 "github.com/SAP/crossplane-provider-hana/apis/v1alpha1"
 	"github.com/SAP/crossplane-provider-hana/internal/clients/hanacloud"
 	"github.com/SAP/crossplane-provider-hana/internal/clients/remotecluster"
+	"github.com/SAP/crossplane-provider-hana/internal/controller/externalname"
 	"github.com/SAP/crossplane-provider-hana/internal/controller/features"
+	"github.com/SAP/crossplane-provider-hana/internal/controller/instancemapping"
+	"github.com/SAP/crossplane-provider-hana/internal/controller/outcome"
+	"github.com/SAP/crossplane-provider-hana/internal/controller/poll"
 )
 
 const (
@@ -43,6 +50,9 @@ const (
 	errMissingInstanceID       = "ServiceInstance on remote cluster has no instanceID"
 	errGetServiceBinding       = "cannot get ServiceBinding from remote cluster: %w"
 	errGetAdminSecret          = "cannot get admin API credentials secret from remote cluster: %w"
+	errNoAdminCredentials      = "neither adminBindingRef nor adminCredentialsSecretRef is set"
+	errGetAdminCredsSecret     = "cannot get admin API credentials secret: %w"
+	errMissingAdminCredsKey    = "key %q not found in admin API credentials secret"
 	errMissingAdminAPIData     = "admin API credentials secret missing required keys"
 	errParseAdminAPI           = "cannot parse admin API credentials: %w"
 	errGetConfigMap            = "cannot get ConfigMap from remote cluster: %w"
@@ -52,16 +62,31 @@ const (
 	errCreateInstanceMapping   = "cannot create InstanceMapping: %w"
 	errGetInstanceMapping      = "cannot get InstanceMapping: %w"
 	errUpdateCredentialsSecret = "cannot update credentials secret: %w"
+	errListInstanceMappings    = "cannot list InstanceMappings: %w"
+	errDeleteInstanceMapping   = "cannot delete InstanceMapping: %w"
+	errFinalizeInstanceMapping = "cannot remove finalizer of InstanceMapping: %w"
+	errRemoteMappingNotRemoved = "HANA Cloud mapping of InstanceMapping %s could not be verified as removed, removing its finalizer anyway: %v"
+	errGetTargetNamespace      = "cannot get target namespace %q from Kyma cluster: %w"
+	errTargetNamespaceNotFound = "target namespace %q does not exist on Kyma cluster"
+	errCreateTargetNamespace   = "cannot create target namespace %q on Kyma cluster: %w"
+	errCrossNamespace          = "%s %q is outside the namespace %q of the KymaInstanceMapping, cross-namespace references are denied"
 
 	// Resource naming suffixes
 	credentialsSecretSuffix = "-admin-creds"
 	instanceMappingSuffix   = "-mapping"
 
-	// Default namespace for child resources
+	// Default namespace for child resources of cluster-scoped mappings
 	defaultCredentialsNamespace = "crossplane-system"
 
 	// Key for credentials in the secret
 	credentialsKey = "credentials"
+
+	// teardownTimeout is how long child InstanceMappings get to remove their
+	// HANA Cloud mapping on their own before the KymaInstanceMapping removes it
+	// and finalizes them
+	teardownTimeout = 10 * time.Minute
+
+	reasonForceFinalized event.Reason = "ForceFinalizedInstanceMapping"
 )
 
 // Setup adds a controller that reconciles KymaInstanceMapping managed resources.
@@ -74,15 +99,18 @@ func Setup(mgr ctrl.Manager, o controller.Options) error {
 	}
 
 	log := o.Logger.WithValues("controller", name)
+	recorder := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
 	r := managed.NewReconciler(mgr,
 		resource.ManagedKind(v1alpha1.KymaInstanceMappingGroupVersionKind),
-		managed.WithExternalConnecter(NewConnector(
+		outcome.WithExternalConnecter(name, NewConnector(
 			mgr.GetClient(),
 			resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1alpha1.ProviderConfigUsage{}),
 			log,
+			recorder,
 		)),
 		managed.WithLogger(log),
-		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+		poll.WithJitterHook(v1alpha1.KymaInstanceMappingKind, nil),
+		managed.WithRecorder(recorder),
 		managed.WithConnectionPublishers(cps...))
 
 	return ctrl.NewControllerManagedBy(mgr).
@@ -95,17 +123,23 @@ func Setup(mgr ctrl.Manager, o controller.Options) error {
 
 // Connector is exported for testing.
 type Connector struct {
-	kube  client.Client
-	usage resource.Tracker
-	log   logging.Logger
+	kube          client.Client
+	usage         resource.Tracker
+	log           logging.Logger
+	recorder      event.Recorder
+	clientFactory instancemapping.ClientFactory
+	remoteClients *remotecluster.Cache
 }
 
 // NewConnector creates a Connector for testing.
-func NewConnector(kube client.Client, usage resource.Tracker, log logging.Logger) *Connector {
+func NewConnector(kube client.Client, usage resource.Tracker, log logging.Logger, recorder event.Recorder) *Connector {
 	return &Connector{
-		kube:  kube,
-		usage: usage,
-		log:   log,
+		kube:          kube,
+		usage:         usage,
+		log:           log,
+		recorder:      recorder,
+		clientFactory: instancemapping.DefaultClientFactory,
+		remoteClients: remotecluster.NewCache(remotecluster.DefaultCacheTTL),
 	}
 }
 
@@ -127,6 +161,10 @@ func (c *Connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 		return nil, errors.New(errNotKymaInstanceMapping)
 	}
 
+	if err := checkNamespaces(cr); err != nil {
+		return nil, err
+	}
+
 	if err := c.usage.Track(ctx, mg); err != nil {
 		return nil, fmt.Errorf(errTrackPCUsage, err)
 	}
@@ -146,8 +184,8 @@ func (c *Connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 			return nil, err
 		}
 
-		// Create remote cluster client
-		clusterClient, extractErr = remotecluster.CreateRemoteClient(ctx, kubeconfigData)
+		// Reuse the remote cluster client of an unchanged kubeconfig
+		clusterClient, extractErr = c.remoteClients.Get(ctx, kubeconfigData)
 		if extractErr != nil {
 			return nil, fmt.Errorf(errCreateRemoteClient, extractErr)
 		}
@@ -155,8 +193,14 @@ func (c *Connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 		c.log.Info("Connected to remote Kyma cluster", "mapping", cr.Name)
 	}
 
-	// Extract all data from cluster (local or remote)
-	kymaData, extractErr := extractKymaData(ctx, clusterClient, cr)
+	// Extract all data from cluster (local or remote). The Kyma resources
+	// may already be gone while the mapping is deleted, the teardown then
+	// continues without fresh credentials.
+	kymaData, extractErr := extractKymaData(ctx, c.kube, clusterClient, cr)
+	if extractErr != nil && meta.WasDeleted(cr) {
+		c.log.Info("Cannot extract data from Kyma cluster, tearing down without fresh credentials", "mapping", cr.Name, "error", extractErr)
+		return c.external(clusterClient, nil), nil
+	}
 	if extractErr != nil {
 		return nil, fmt.Errorf(errExtractKymaData, extractErr)
 	}
@@ -169,12 +213,26 @@ func (c *Connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 		ServiceInstanceReady: kymaData.serviceInstanceReady,
 	}
 
+	return c.external(clusterClient, kymaData), nil
+}
+
+func (c *Connector) external(clusterClient client.Client, kymaData *kymaExtractedData) *External {
+	recorder := c.recorder
+	if recorder == nil {
+		recorder = event.NewNopRecorder()
+	}
+	factory := c.clientFactory
+	if factory == nil {
+		factory = instancemapping.DefaultClientFactory
+	}
 	return &External{
 		managementClient: c.kube,
 		clusterClient:    clusterClient,
 		kymaData:         kymaData,
 		log:              c.log,
-	}, nil
+		recorder:         recorder,
+		clientFactory:    factory,
+	}
 }
 
 // getKubeconfigData reads the kubeconfig from the secret on the management cluster.
@@ -205,8 +263,10 @@ func (c *Connector) getKubeconfigData(ctx context.Context, cr *v1alpha1.KymaInst
 	return kubeconfigData, nil
 }
 
-// extractKymaData fetches and extracts all required data from the remote Kyma cluster
-func extractKymaData(ctx context.Context, remoteClient client.Client, cr *v1alpha1.KymaInstanceMapping) (*kymaExtractedData, error) {
+// extractKymaData fetches and extracts all required data from the remote Kyma
+// cluster, and the admin API credentials from the management cluster if they
+// are not bound on the Kyma cluster
+func extractKymaData(ctx context.Context, managementClient, remoteClient client.Client, cr *v1alpha1.KymaInstanceMapping) (*kymaExtractedData, error) {
 	data := &kymaExtractedData{}
 
 	// 1. Get ServiceInstance to extract instanceID
@@ -226,28 +286,16 @@ func extractKymaData(ctx context.Context, remoteClient client.Client, cr *v1alph
 	}
 	data.serviceInstanceID = serviceInstance.Status.InstanceID
 
-	// 2. Get ServiceBinding to find credentials secret
-	serviceBinding := &servicescloudsapv1.ServiceBinding{}
-	if err := remoteClient.Get(ctx, types.NamespacedName{
-		Name:      cr.Spec.ForProvider.AdminBindingRef.Name,
-		Namespace: cr.Spec.ForProvider.AdminBindingRef.Namespace,
-	}, serviceBinding); err != nil {
-		return nil, fmt.Errorf(errGetServiceBinding, err)
-	}
-
-	// 3. Get admin API credentials secret
-	adminSecret := &corev1.Secret{}
-	if err := remoteClient.Get(ctx, types.NamespacedName{
-		Name:      serviceBinding.Spec.SecretName,
-		Namespace: serviceBinding.Namespace,
-	}, adminSecret); err != nil {
-		return nil, fmt.Errorf(errGetAdminSecret, err)
+	// 2. and 3. Get admin API credentials
+	var creds hanacloud.AdminAPICredentials
+	var err error
+	if ref := cr.Spec.ForProvider.AdminCredentialsSecretRef; ref != nil {
+		creds, err = getManagementAdminAPICredentials(ctx, managementClient, ref)
+	} else {
+		creds, err = getBoundAdminAPICredentials(ctx, remoteClient, cr)
 	}
-
-	// Parse admin API credentials
-	creds, err := parseAdminAPICredentials(adminSecret.Data)
 	if err != nil {
-		return nil, fmt.Errorf(errParseAdminAPI, err)
+		return nil, err
 	}
 	data.adminAPICredentials = creds
 
@@ -277,6 +325,63 @@ func extractKymaData(ctx context.Context, remoteClient client.Client, cr *v1alph
 	return data, nil
 }
 
+// getBoundAdminAPICredentials reads the admin API credentials from the secret
+// of the ServiceBinding on the Kyma cluster
+func getBoundAdminAPICredentials(ctx context.Context, remoteClient client.Client, cr *v1alpha1.KymaInstanceMapping) (hanacloud.AdminAPICredentials, error) {
+	ref := cr.Spec.ForProvider.AdminBindingRef
+	if ref == nil {
+		return hanacloud.AdminAPICredentials{}, errors.New(errNoAdminCredentials)
+	}
+
+	// 2. Get ServiceBinding to find credentials secret
+	serviceBinding := &servicescloudsapv1.ServiceBinding{}
+	if err := remoteClient.Get(ctx, types.NamespacedName{
+		Name:      ref.Name,
+		Namespace: ref.Namespace,
+	}, serviceBinding); err != nil {
+		return hanacloud.AdminAPICredentials{}, fmt.Errorf(errGetServiceBinding, err)
+	}
+
+	// 3. Get admin API credentials secret
+	adminSecret := &corev1.Secret{}
+	if err := remoteClient.Get(ctx, types.NamespacedName{
+		Name:      serviceBinding.Spec.SecretName,
+		Namespace: serviceBinding.Namespace,
+	}, adminSecret); err != nil {
+		return hanacloud.AdminAPICredentials{}, fmt.Errorf(errGetAdminSecret, err)
+	}
+
+	// Parse admin API credentials
+	creds, err := parseAdminAPICredentials(adminSecret.Data)
+	if err != nil {
+		return hanacloud.AdminAPICredentials{}, fmt.Errorf(errParseAdminAPI, err)
+	}
+	return creds, nil
+}
+
+// getManagementAdminAPICredentials reads the admin API credentials from a
+// secret on the management cluster, skipping the ServiceBinding lookup
+func getManagementAdminAPICredentials(ctx context.Context, managementClient client.Client, ref *v1alpha1.AdminCredentialsSecretRef) (hanacloud.AdminAPICredentials, error) {
+	secret := &corev1.Secret{}
+	if err := managementClient.Get(ctx, types.NamespacedName{
+		Name:      ref.Name,
+		Namespace: ref.Namespace,
+	}, secret); err != nil {
+		return hanacloud.AdminAPICredentials{}, fmt.Errorf(errGetAdminCredsSecret, err)
+	}
+
+	credentialsJSON, ok := secret.Data[ref.Key]
+	if !ok {
+		return hanacloud.AdminAPICredentials{}, fmt.Errorf(errMissingAdminCredsKey, ref.Key)
+	}
+
+	creds, err := hanacloud.ParseAdminAPICredentials(credentialsJSON)
+	if err != nil {
+		return hanacloud.AdminAPICredentials{}, fmt.Errorf(errParseAdminAPI, err)
+	}
+	return creds, nil
+}
+
 // isServiceInstanceReady checks if the ServiceInstance has a Ready condition set to True
 func isServiceInstanceReady(si *servicescloudsapv1.ServiceInstance) bool {
 	for _, cond := range si.Status.Conditions {
@@ -314,80 +419,219 @@ type External struct {
 	clusterClient    client.Client
 	kymaData         *kymaExtractedData
 	log              logging.Logger
+	recorder         event.Recorder
+	clientFactory    instancemapping.ClientFactory
 }
 
 func (e *External) Disconnect(_ context.Context) error {
 	return nil
 }
 
-// getCredentialsNamespace returns the namespace for child resources
+// getCredentialsNamespace returns the namespace for the credentials Secret.
+// A namespaced mapping anchors it in its own namespace.
 func getCredentialsNamespace(cr *v1alpha1.KymaInstanceMapping) string {
 	if cr.Spec.ForProvider.CredentialsSecretNamespace != "" {
 		return cr.Spec.ForProvider.CredentialsSecretNamespace
 	}
+	if ns := cr.GetNamespace(); ns != "" {
+		return ns
+	}
 	return defaultCredentialsNamespace
 }
 
+// checkNamespaces denies a namespaced mapping Secrets outside of its own
+// namespace on the management cluster. Cluster-scoped mappings may reference
+// any namespace.
+func checkNamespaces(cr *v1alpha1.KymaInstanceMapping) error {
+	ns := cr.GetNamespace()
+	if ns == "" {
+		return nil
+	}
+	refs := [][2]string{{"credentialsSecretNamespace", getCredentialsNamespace(cr)}}
+	if ref := cr.Spec.ForProvider.KymaConnectionRef; ref != nil {
+		refs = append(refs, [2]string{"kymaConnectionRef.secretRef.namespace", ref.SecretRef.Namespace})
+	}
+	if ref := cr.Spec.ForProvider.AdminCredentialsSecretRef; ref != nil {
+		refs = append(refs, [2]string{"adminCredentialsSecretRef.namespace", ref.Namespace})
+	}
+	for _, ref := range refs {
+		if ref[1] != ns {
+			return fmt.Errorf(errCrossNamespace, ref[0], ref[1], ns)
+		}
+	}
+	return nil
+}
+
 // getChildResourceNames returns the names for child Secret and InstanceMapping
 func getChildResourceNames(cr *v1alpha1.KymaInstanceMapping) (secretName, imName string) {
 	return cr.Name + credentialsSecretSuffix, cr.Name + instanceMappingSuffix
 }
 
+// A target is a namespace mapped by a child InstanceMapping.
+type target struct {
+	namespace *string
+	imName    string
+}
+
+// getTargets returns the namespaces to map. TargetNamespaces get one child
+// InstanceMapping each, named after the namespace.
+func getTargets(cr *v1alpha1.KymaInstanceMapping) []target {
+	_, imName := getChildResourceNames(cr)
+	if len(cr.Spec.ForProvider.TargetNamespaces) == 0 {
+		return []target{{namespace: cr.Spec.ForProvider.TargetNamespace, imName: imName}}
+	}
+	targets := make([]target, 0, len(cr.Spec.ForProvider.TargetNamespaces))
+	for _, ns := range cr.Spec.ForProvider.TargetNamespaces {
+		targets = append(targets, target{namespace: ptr.To(ns), imName: imName + "-" + ns})
+	}
+	return targets
+}
+
+// ownerReference makes the KymaInstanceMapping the controller of a child
+// resource.
+func ownerReference(cr *v1alpha1.KymaInstanceMapping) metav1.OwnerReference {
+	return metav1.OwnerReference{
+		APIVersion:         v1alpha1.KymaInstanceMappingGroupVersionKind.GroupVersion().String(),
+		Kind:               v1alpha1.KymaInstanceMappingKind,
+		Name:               cr.Name,
+		UID:                cr.UID,
+		Controller:         ptr.To(true),
+		BlockOwnerDeletion: ptr.To(true),
+	}
+}
+
 func (e *External) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
 	cr, ok := mg.(*v1alpha1.KymaInstanceMapping)
 	if !ok {
 		return managed.ExternalObservation{}, errors.New(errNotKymaInstanceMapping)
 	}
 
+	// The mapping is gone once all child InstanceMappings are
+	if meta.WasDeleted(cr) {
+		children, err := e.childInstanceMappings(ctx, cr)
+		if err != nil {
+			return managed.ExternalObservation{}, err
+		}
+		return managed.ExternalObservation{ResourceExists: len(children) > 0}, nil
+	}
+
 	secretName, imName := getChildResourceNames(cr)
 	ns := getCredentialsNamespace(cr)
+	targets := getTargets(cr)
+	multiple := len(cr.Spec.ForProvider.TargetNamespaces) > 0
 
 	e.log.Info("Observing KymaInstanceMapping",
 		"name", cr.Name,
-		"instanceMappingName", imName,
+		"instanceMappings", len(targets),
 		"secretName", secretName)
 
-	// Check if child InstanceMapping exists
-	im := &v1alpha1.InstanceMapping{}
-	err := e.managementClient.Get(ctx, types.NamespacedName{Name: imName}, im)
-	if err != nil {
-		if apierrors.IsNotFound(err) {
-			e.log.Debug("Child InstanceMapping not found", "name", imName)
-			return managed.ExternalObservation{ResourceExists: false}, nil
+	// Check which child InstanceMappings exist
+	var mappings []v1alpha1.TargetMappingObservation
+	var single *v1alpha1.InstanceMapping
+	missing := 0
+	ready, synced := true, true
+	for _, t := range targets {
+		im := &v1alpha1.InstanceMapping{}
+		if err := e.managementClient.Get(ctx, types.NamespacedName{Name: t.imName, Namespace: cr.GetNamespace()}, im); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return managed.ExternalObservation{}, fmt.Errorf(errGetInstanceMapping, err)
+			}
+			e.log.Debug("Child InstanceMapping not found", "name", t.imName)
+			missing++
+			ready, synced = false, false
+			continue
+		}
+		single = im
+		imReady := isConditionTrue(im.Status.Conditions, xpv1.TypeReady)
+		imSynced := isConditionTrue(im.Status.Conditions, xpv1.TypeSynced)
+		ready, synced = ready && imReady, synced && imSynced
+		if multiple {
+			mappings = append(mappings, v1alpha1.TargetMappingObservation{
+				TargetNamespace:     ptr.Deref(t.namespace, ""),
+				InstanceMappingName: t.imName,
+				ExternalID:          im.Status.AtProvider.ExternalID,
+				Ready:               imReady,
+				Synced:              imSynced,
+			})
 		}
-		return managed.ExternalObservation{}, fmt.Errorf(errGetInstanceMapping, err)
+	}
+	if missing == len(targets) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	stale, err := e.staleInstanceMappings(ctx, cr, targets)
+	if err != nil {
+		return managed.ExternalObservation{}, err
 	}
 
 	// Update status with child resource references
 	cr.Status.AtProvider.ChildResources = &v1alpha1.ChildResourcesReference{
-		InstanceMappingName:        imName,
 		CredentialsSecretName:      secretName,
 		CredentialsSecretNamespace: ns,
-		InstanceMappingReady:       isConditionTrue(im.Status.Conditions, xpv1.TypeReady),
-		InstanceMappingSynced:      isConditionTrue(im.Status.Conditions, xpv1.TypeSynced),
-	}
-
-	// Propagate status from child InstanceMapping
-	if im.Status.AtProvider.MappingExists {
-		cr.Status.AtProvider.Hana = &v1alpha1.HANACloudObservation{
-			MappingID: &v1alpha1.MappingID{
-				ServiceInstanceID: im.Spec.ForProvider.ServiceInstanceID,
-				PrimaryID:         im.Spec.ForProvider.PrimaryID,
-				SecondaryID:       im.Spec.ForProvider.SecondaryID,
-			},
-			Ready: cr.Status.AtProvider.ChildResources.InstanceMappingReady,
+		InstanceMappingReady:       ready,
+		InstanceMappingSynced:      synced,
+	}
+	cr.Status.AtProvider.Mappings = mappings
+
+	// Propagate status from a single child InstanceMapping
+	cr.Status.AtProvider.ExternalID = ""
+	if !multiple {
+		cr.Status.AtProvider.ChildResources.InstanceMappingName = imName
+		cr.Status.AtProvider.ExternalID = single.Status.AtProvider.ExternalID
+		if single.Status.AtProvider.MappingExists {
+			cr.Status.AtProvider.Hana = &v1alpha1.HANACloudObservation{
+				MappingID: &v1alpha1.MappingID{
+					ServiceInstanceID: single.Spec.ForProvider.ServiceInstanceID,
+					PrimaryID:         single.Spec.ForProvider.PrimaryID,
+					SecondaryID:       single.Spec.ForProvider.SecondaryID,
+				},
+				Ready: ready,
+			}
 		}
 	}
 
-	// Set conditions based on child status
-	if cr.Status.AtProvider.ChildResources.InstanceMappingReady {
+	// Set conditions based on child status, which is only ready once all
+	// child InstanceMappings are
+	if ready {
 		cr.SetConditions(xpv1.Available())
 	}
 
-	return managed.ExternalObservation{
+	o := managed.ExternalObservation{
 		ResourceExists:   true,
-		ResourceUpToDate: true,
-	}, nil
+		ResourceUpToDate: missing == 0 && len(stale) == 0,
+	}
+	if id := cr.Status.AtProvider.ExternalID; id != "" {
+		o.ConnectionDetails = externalname.ConnectionDetails(id)
+	}
+	return o, nil
+}
+
+// childInstanceMappings returns all child InstanceMappings of the
+// KymaInstanceMapping, which share its namespace.
+func (e *External) childInstanceMappings(ctx context.Context, cr *v1alpha1.KymaInstanceMapping) ([]v1alpha1.InstanceMapping, error) {
+	list := &v1alpha1.InstanceMappingList{}
+	if err := e.managementClient.List(ctx, list, client.InNamespace(cr.GetNamespace())); err != nil {
+		return nil, fmt.Errorf(errListInstanceMappings, err)
+	}
+	var children []v1alpha1.InstanceMapping
+	for _, im := range list.Items {
+		if metav1.IsControlledBy(&im, cr) {
+			children = append(children, im)
+		}
+	}
+	return children, nil
+}
+
+// staleInstanceMappings returns the child InstanceMappings of namespaces that
+// are no longer targeted.
+func (e *External) staleInstanceMappings(ctx context.Context, cr *v1alpha1.KymaInstanceMapping, targets []target) ([]v1alpha1.InstanceMapping, error) {
+	children, err := e.childInstanceMappings(ctx, cr)
+	if err != nil {
+		return nil, err
+	}
+	return slices.DeleteFunc(children, func(im v1alpha1.InstanceMapping) bool {
+		return slices.ContainsFunc(targets, func(t target) bool { return t.imName == im.Name })
+	}), nil
 }
 
 func (e *External) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
@@ -409,18 +653,9 @@ func (e *External) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 	credentialsJSON := buildCredentialsJSON(e.kymaData.adminAPICredentials)
 	secret := &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      secretName,
-			Namespace: ns,
-			OwnerReferences: []metav1.OwnerReference{
-				{
-					APIVersion:         v1alpha1.KymaInstanceMappingGroupVersionKind.GroupVersion().String(),
-					Kind:               v1alpha1.KymaInstanceMappingKind,
-					Name:               cr.Name,
-					UID:                cr.UID,
-					Controller:         ptr.To(true),
-					BlockOwnerDeletion: ptr.To(true),
-				},
-			},
+			Name:            secretName,
+			Namespace:       ns,
+			OwnerReferences: []metav1.OwnerReference{ownerReference(cr)},
 		},
 		Data: map[string][]byte{
 			credentialsKey: credentialsJSON,
@@ -442,71 +677,217 @@ func (e *External) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 		}
 	}
 
-	// Step 2: Create InstanceMapping CR
-	im := &v1alpha1.InstanceMapping{
-		ObjectMeta: metav1.ObjectMeta{
-			Name: imName,
-			OwnerReferences: []metav1.OwnerReference{
-				{
-					APIVersion:         v1alpha1.KymaInstanceMappingGroupVersionKind.GroupVersion().String(),
-					Kind:               v1alpha1.KymaInstanceMappingKind,
-					Name:               cr.Name,
-					UID:                cr.UID,
-					Controller:         ptr.To(true),
-					BlockOwnerDeletion: ptr.To(true),
-				},
-			},
-		},
-		Spec: v1alpha1.InstanceMappingSpec{
-			ForProvider: v1alpha1.InstanceMappingParameters{
-				ServiceInstanceID: e.kymaData.serviceInstanceID,
-				Platform:          "kubernetes",
-				PrimaryID:         e.kymaData.clusterID,
-				SecondaryID:       cr.Spec.ForProvider.TargetNamespace,
-				IsDefault:         cr.Spec.ForProvider.IsDefault,
-				AdminCredentialsSecretRef: v1alpha1.AdminCredentialsSecretRef{
-					Name:      secretName,
-					Namespace: ns,
-					Key:       credentialsKey,
-				},
-			},
-		},
-	}
-
-	if err := e.managementClient.Create(ctx, im); err != nil && !apierrors.IsAlreadyExists(err) {
-		return managed.ExternalCreation{}, fmt.Errorf(errCreateInstanceMapping, err)
+	// Step 2: Create InstanceMapping CRs
+	if err := e.createInstanceMappings(ctx, cr); err != nil {
+		return managed.ExternalCreation{}, err
 	}
 
 	// Update status
 	cr.Status.AtProvider.ChildResources = &v1alpha1.ChildResourcesReference{
-		InstanceMappingName:        imName,
 		CredentialsSecretName:      secretName,
 		CredentialsSecretNamespace: ns,
 	}
+	if len(cr.Spec.ForProvider.TargetNamespaces) == 0 {
+		cr.Status.AtProvider.ChildResources.InstanceMappingName = imName
+	}
 
 	cr.SetConditions(xpv1.Creating())
 	return managed.ExternalCreation{}, nil
 }
 
-func (e *External) Update(_ context.Context, _ resource.Managed) (managed.ExternalUpdate, error) {
-	// KymaInstanceMapping doesn't need update - child InstanceMapping handles it
+// createInstanceMappings creates the child InstanceMappings of all targeted
+// namespaces that do not exist yet.
+func (e *External) createInstanceMappings(ctx context.Context, cr *v1alpha1.KymaInstanceMapping) error {
+	secretName, _ := getChildResourceNames(cr)
+	for _, t := range getTargets(cr) {
+		if err := e.checkTargetNamespace(ctx, cr, t.namespace); err != nil {
+			return err
+		}
+
+		im := &v1alpha1.InstanceMapping{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            t.imName,
+				Namespace:       cr.GetNamespace(),
+				OwnerReferences: []metav1.OwnerReference{ownerReference(cr)},
+			},
+			Spec: v1alpha1.InstanceMappingSpec{
+				ForProvider: v1alpha1.InstanceMappingParameters{
+					ServiceInstanceID: e.kymaData.serviceInstanceID,
+					Platform:          "kubernetes",
+					PrimaryID:         e.kymaData.clusterID,
+					SecondaryID:       t.namespace,
+					IsDefault:         cr.Spec.ForProvider.IsDefault,
+					AdminCredentialsSecretRef: v1alpha1.AdminCredentialsSecretRef{
+						Name:      secretName,
+						Namespace: getCredentialsNamespace(cr),
+						Key:       credentialsKey,
+					},
+				},
+			},
+		}
+
+		if err := e.managementClient.Create(ctx, im); err != nil && !apierrors.IsAlreadyExists(err) {
+			return fmt.Errorf(errCreateInstanceMapping, err)
+		}
+	}
+	return nil
+}
+
+// checkTargetNamespace verifies that a target namespace exists on the Kyma
+// cluster, or creates it, as selected by the TargetNamespaceCheck. Otherwise
+// the HANA Cloud mapping may point at a namespace that never gets a binding.
+// The namespace of the ServiceInstance is not checked.
+func (e *External) checkTargetNamespace(ctx context.Context, cr *v1alpha1.KymaInstanceMapping, namespace *string) error {
+	check := cr.Spec.ForProvider.TargetNamespaceCheck
+	if namespace == nil || check == "" || check == v1alpha1.TargetNamespaceCheckNone {
+		return nil
+	}
+
+	err := e.clusterClient.Get(ctx, types.NamespacedName{Name: *namespace}, &corev1.Namespace{})
+	switch {
+	case err == nil:
+		return nil
+	case !apierrors.IsNotFound(err):
+		return fmt.Errorf(errGetTargetNamespace, *namespace, err)
+	case check == v1alpha1.TargetNamespaceCheckVerify:
+		return fmt.Errorf(errTargetNamespaceNotFound, *namespace)
+	}
+
+	e.log.Info("Creating target namespace on Kyma cluster", "name", cr.Name, "namespace", *namespace)
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: *namespace}}
+	if err := e.clusterClient.Create(ctx, ns); err != nil && !apierrors.IsAlreadyExists(err) {
+		return fmt.Errorf(errCreateTargetNamespace, *namespace, err)
+	}
+	return nil
+}
+
+// Update creates the child InstanceMappings of added namespaces and deletes
+// those of removed ones.
+func (e *External) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.KymaInstanceMapping)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotKymaInstanceMapping)
+	}
+
+	if err := e.createInstanceMappings(ctx, cr); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
+	stale, err := e.staleInstanceMappings(ctx, cr, getTargets(cr))
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	for i := range stale {
+		e.log.Info("Deleting InstanceMapping of removed namespace", "name", cr.Name, "instanceMappingName", stale[i].Name)
+		if err := e.managementClient.Delete(ctx, &stale[i]); client.IgnoreNotFound(err) != nil {
+			return managed.ExternalUpdate{}, fmt.Errorf(errDeleteInstanceMapping, err)
+		}
+	}
 	return managed.ExternalUpdate{}, nil
 }
 
-func (e *External) Delete(_ context.Context, mg resource.Managed) (managed.ExternalDelete, error) {
+// Delete tears down the child InstanceMappings explicitly instead of relying
+// on garbage collection, which only starts once the KymaInstanceMapping is
+// gone. Children that do not remove their HANA Cloud mapping within the
+// teardown timeout, e.g. because their credentials were rotated, have their
+// mapping removed with the current credentials and are finalized.
+func (e *External) Delete(ctx context.Context, mg resource.Managed) (managed.ExternalDelete, error) {
 	cr, ok := mg.(*v1alpha1.KymaInstanceMapping)
 	if !ok {
 		return managed.ExternalDelete{}, errors.New(errNotKymaInstanceMapping)
 	}
 
-	e.log.Info("Deleting KymaInstanceMapping - child resources will be garbage collected",
-		"name", cr.Name)
-
-	// Owner references will handle cascading delete of Secret and InstanceMapping
+	e.log.Info("Deleting child InstanceMappings of KymaInstanceMapping", "name", cr.Name)
 	cr.SetConditions(xpv1.Deleting())
+
+	// Children stuck on rotated credentials can finish with fresh ones
+	if err := e.refreshCredentials(ctx, cr); err != nil {
+		return managed.ExternalDelete{}, err
+	}
+
+	children, err := e.childInstanceMappings(ctx, cr)
+	if err != nil {
+		return managed.ExternalDelete{}, err
+	}
+
+	expired := cr.GetDeletionTimestamp() != nil && time.Since(cr.GetDeletionTimestamp().Time) > teardownTimeout
+	for i := range children {
+		im := &children[i]
+		if !meta.WasDeleted(im) {
+			if err := e.managementClient.Delete(ctx, im); client.IgnoreNotFound(err) != nil {
+				return managed.ExternalDelete{}, fmt.Errorf(errDeleteInstanceMapping, err)
+			}
+			continue
+		}
+		if !expired {
+			continue
+		}
+		if err := e.removeRemoteMapping(ctx, im); err != nil {
+			e.recorder.Event(cr, event.Warning(reasonForceFinalized, fmt.Errorf(errRemoteMappingNotRemoved, im.Name, err)))
+		}
+		meta.RemoveFinalizer(im, managed.FinalizerName)
+		if err := e.managementClient.Update(ctx, im); client.IgnoreNotFound(err) != nil {
+			return managed.ExternalDelete{}, fmt.Errorf(errFinalizeInstanceMapping, err)
+		}
+	}
+
 	return managed.ExternalDelete{}, nil
 }
 
+// refreshCredentials writes the current admin API credentials into the
+// credentials Secret of the child InstanceMappings.
+func (e *External) refreshCredentials(ctx context.Context, cr *v1alpha1.KymaInstanceMapping) error {
+	if e.kymaData == nil {
+		return nil
+	}
+	secretName, _ := getChildResourceNames(cr)
+	secret := &corev1.Secret{}
+	if err := e.managementClient.Get(ctx, types.NamespacedName{Name: secretName, Namespace: getCredentialsNamespace(cr)}, secret); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf(errUpdateCredentialsSecret, err)
+	}
+	credentialsJSON := buildCredentialsJSON(e.kymaData.adminAPICredentials)
+	if string(secret.Data[credentialsKey]) == string(credentialsJSON) {
+		return nil
+	}
+	secret.Data = map[string][]byte{credentialsKey: credentialsJSON}
+	if err := e.managementClient.Update(ctx, secret); err != nil {
+		return fmt.Errorf(errUpdateCredentialsSecret, err)
+	}
+	return nil
+}
+
+// removeRemoteMapping removes the HANA Cloud mapping of a child
+// InstanceMapping with the current credentials and verifies it is gone.
+func (e *External) removeRemoteMapping(ctx context.Context, im *v1alpha1.InstanceMapping) error {
+	if e.kymaData == nil {
+		return errors.New("no current admin API credentials")
+	}
+	imClient, err := e.clientFactory(ctx, e.kymaData.adminAPICredentials, e.log.WithValues("instancemapping", im.Name))
+	if err != nil {
+		return err
+	}
+
+	params := im.Spec.ForProvider
+	secondaryID := ptr.Deref(params.SecondaryID, "")
+	if _, err := imClient.Delete(ctx, params.ServiceInstanceID, params.PrimaryID, secondaryID); err != nil {
+		return err
+	}
+
+	mappings, err := imClient.List(ctx, params.ServiceInstanceID)
+	if err != nil {
+		return err
+	}
+	for _, m := range mappings {
+		if m.PrimaryID == params.PrimaryID && ptr.Deref(m.SecondaryID, "") == secondaryID {
+			return errors.New("mapping still exists")
+		}
+	}
+	return nil
+}
+
 // buildCredentialsJSON creates the JSON credentials blob for the intermediate secret
 func buildCredentialsJSON(creds hanacloud.AdminAPICredentials) []byte {
 	data, err := json.Marshal(creds)
@@ -9,20 +9,28 @@ import (
 	"encoding/json"
 	"strings"
 	"testing"
+	"time"
 
 	servicescloudsapv1 "github.com/SAP/sap-btp-service-operator/api/v1"
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
 	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/google/go-cmp/cmp"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	"github.com/SAP/crossplane-provider-hana/apis/inventory/v1alpha1"
 	apisv1alpha1 "github.com/SAP/crossplane-provider-hana/apis/v1alpha1"
 	"github.com/SAP/crossplane-provider-hana/internal/clients/hanacloud"
+	imclient "github.com/SAP/crossplane-provider-hana/internal/clients/hanacloud/instancemapping"
+	"github.com/SAP/crossplane-provider-hana/internal/clients/hanacloud/operation"
+	"github.com/SAP/crossplane-provider-hana/internal/controller/externalname"
 )
 
 // stringPtr returns a pointer to the given string value
@@ -82,7 +90,7 @@ func TestConnector_Connect(t *testing.T) {
 							Name:      "hana-instance",
 							Namespace: "default",
 						},
-						AdminBindingRef: v1alpha1.ResourceReference{
+						AdminBindingRef: &v1alpha1.ResourceReference{
 							Name:      "admin-binding",
 							Namespace: "default",
 						},
@@ -139,7 +147,7 @@ func TestConnector_Connect(t *testing.T) {
 							Name:      "hana-instance",
 							Namespace: "default",
 						},
-						AdminBindingRef: v1alpha1.ResourceReference{
+						AdminBindingRef: &v1alpha1.ResourceReference{
 							Name:      "admin-binding",
 							Namespace: "default",
 						},
@@ -155,6 +163,47 @@ func TestConnector_Connect(t *testing.T) {
 			wantErr: true,
 			errMsg:  "cannot get kubeconfig secret",
 		},
+		{
+			name: "denies namespaced mapping a kubeconfig secret in another namespace",
+			cr: &v1alpha1.KymaInstanceMapping{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-mapping",
+					Namespace: "team-a",
+				},
+				Spec: v1alpha1.KymaInstanceMappingSpec{
+					ForProvider: v1alpha1.KymaInstanceMappingParameters{
+						KymaConnectionRef: &v1alpha1.KymaConnectionReference{
+							SecretRef: v1alpha1.SecretReference{
+								Name:      "kyma-kubeconfig",
+								Namespace: "default",
+							},
+						},
+						ServiceInstanceRef: v1alpha1.ResourceReference{
+							Name:      "hana-instance",
+							Namespace: "default",
+						},
+					},
+				},
+			},
+			wantErr: true,
+			errMsg:  `kymaConnectionRef.secretRef.namespace "default" is outside the namespace "team-a"`,
+		},
+		{
+			name: "denies namespaced mapping a credentials secret in another namespace",
+			cr: &v1alpha1.KymaInstanceMapping{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-mapping",
+					Namespace: "team-a",
+				},
+				Spec: v1alpha1.KymaInstanceMappingSpec{
+					ForProvider: v1alpha1.KymaInstanceMappingParameters{
+						CredentialsSecretNamespace: "crossplane-system",
+					},
+				},
+			},
+			wantErr: true,
+			errMsg:  `credentialsSecretNamespace "crossplane-system" is outside the namespace "team-a"`,
+		},
 	}
 
 	for _, tt := range tests {
@@ -209,6 +258,7 @@ func TestExternal_Observe(t *testing.T) {
 		cr         *v1alpha1.KymaInstanceMapping
 		existingIM *v1alpha1.InstanceMapping
 		want       bool // want ResourceExists
+		wantID     string
 		wantErr    bool
 	}{
 		{
@@ -246,11 +296,13 @@ func TestExternal_Observe(t *testing.T) {
 						},
 					},
 					AtProvider: v1alpha1.InstanceMappingObservation{
+						ExternalID:    "test-instance-id/test-cluster-id/target-ns",
 						MappingExists: true,
 					},
 				},
 			},
 			want:    true,
+			wantID:  "test-instance-id/test-cluster-id/target-ns",
 			wantErr: false,
 		},
 		{
@@ -311,6 +363,12 @@ func TestExternal_Observe(t *testing.T) {
 			if obs.ResourceExists != tt.want {
 				t.Errorf("Observe() ResourceExists = %v, want %v", obs.ResourceExists, tt.want)
 			}
+			if got := tt.cr.Status.AtProvider.ExternalID; got != tt.wantID {
+				t.Errorf("Observe() ExternalID = %q, want %q", got, tt.wantID)
+			}
+			if got := string(obs.ConnectionDetails[externalname.ConnectionDetailExternalID]); got != tt.wantID {
+				t.Errorf("Observe() externalID connection detail = %q, want %q", got, tt.wantID)
+			}
 
 			// Verify status is updated when InstanceMapping exists
 			if tt.existingIM != nil && tt.cr.Status.AtProvider.ChildResources != nil {
@@ -325,9 +383,11 @@ func TestExternal_Observe(t *testing.T) {
 
 func TestExternal_Create(t *testing.T) {
 	tests := []struct {
-		name    string
-		cr      *v1alpha1.KymaInstanceMapping
-		wantErr bool
+		name      string
+		cr        *v1alpha1.KymaInstanceMapping
+		wantErr   bool
+		secretNS  string
+		mappingNS string
 	}{
 		{
 			name: "successfully creates child resources",
@@ -344,7 +404,27 @@ func TestExternal_Create(t *testing.T) {
 					},
 				},
 			},
-			wantErr: false,
+			wantErr:   false,
+			secretNS:  "crossplane-system",
+			mappingNS: "",
+		},
+		{
+			name: "anchors child resources in the namespace of a namespaced mapping",
+			cr: &v1alpha1.KymaInstanceMapping{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-mapping",
+					Namespace: "team-a",
+					UID:       "test-uid",
+				},
+				Spec: v1alpha1.KymaInstanceMappingSpec{
+					ForProvider: v1alpha1.KymaInstanceMappingParameters{
+						TargetNamespace: stringPtr("target-ns"),
+					},
+				},
+			},
+			wantErr:   false,
+			secretNS:  "team-a",
+			mappingNS: "team-a",
 		},
 	}
 
@@ -392,7 +472,7 @@ func TestExternal_Create(t *testing.T) {
 			secret := &corev1.Secret{}
 			err = fakeClient.Get(context.Background(), client.ObjectKey{
 				Name:      tt.cr.Name + "-admin-creds",
-				Namespace: "crossplane-system",
+				Namespace: tt.secretNS,
 			}, secret)
 			if err != nil {
 				t.Errorf("Create() failed to create credentials secret: %v", err)
@@ -401,7 +481,8 @@ func TestExternal_Create(t *testing.T) {
 			// Verify InstanceMapping was created
 			im := &v1alpha1.InstanceMapping{}
 			err = fakeClient.Get(context.Background(), client.ObjectKey{
-				Name: tt.cr.Name + "-mapping",
+				Name:      tt.cr.Name + "-mapping",
+				Namespace: tt.mappingNS,
 			}, im)
 			if err != nil {
 				t.Errorf("Create() failed to create InstanceMapping: %v", err)
@@ -416,6 +497,10 @@ func TestExternal_Create(t *testing.T) {
 				t.Errorf("InstanceMapping.PrimaryID = %v, want %v",
 					im.Spec.ForProvider.PrimaryID, "test-cluster-id")
 			}
+			if im.Spec.ForProvider.AdminCredentialsSecretRef.Namespace != tt.secretNS {
+				t.Errorf("InstanceMapping.AdminCredentialsSecretRef.Namespace = %v, want %v",
+					im.Spec.ForProvider.AdminCredentialsSecretRef.Namespace, tt.secretNS)
+			}
 		})
 	}
 }
@@ -493,7 +578,7 @@ func TestExtractKymaData(t *testing.T) {
 							Name:      "hana-instance",
 							Namespace: "default",
 						},
-						AdminBindingRef: v1alpha1.ResourceReference{
+						AdminBindingRef: &v1alpha1.ResourceReference{
 							Name:      "admin-binding",
 							Namespace: "default",
 						},
@@ -517,6 +602,104 @@ func TestExtractKymaData(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "reads admin API credentials from management cluster secret",
+			objects: []client.Object{
+				&servicescloudsapv1.ServiceInstance{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "hana-instance",
+						Namespace: "default",
+					},
+					Status: servicescloudsapv1.ServiceInstanceStatus{
+						InstanceID: "test-instance-id",
+					},
+				},
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "hana-admin-api",
+						Namespace: "crossplane-system",
+					},
+					Data: map[string][]byte{
+						"credentials": []byte(`{"baseurl":"https://hana-cloud-api.example.com","uaa":` + string(uaaJSON) + `}`),
+					},
+				},
+				&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "sap-btp-operator-config",
+						Namespace: "kyma-system",
+					},
+					Data: map[string]string{
+						"CLUSTER_ID": "test-cluster-id",
+					},
+				},
+			},
+			cr: &v1alpha1.KymaInstanceMapping{
+				Spec: v1alpha1.KymaInstanceMappingSpec{
+					ForProvider: v1alpha1.KymaInstanceMappingParameters{
+						ServiceInstanceRef: v1alpha1.ResourceReference{
+							Name:      "hana-instance",
+							Namespace: "default",
+						},
+						AdminCredentialsSecretRef: &v1alpha1.AdminCredentialsSecretRef{
+							Name:      "hana-admin-api",
+							Namespace: "crossplane-system",
+							Key:       "credentials",
+						},
+					},
+				},
+			},
+			wantData: &kymaExtractedData{
+				serviceInstanceID:   "test-instance-id",
+				clusterID:           "test-cluster-id",
+				serviceInstanceName: "hana-instance",
+				adminAPICredentials: hanacloud.AdminAPICredentials{
+					BaseURL: "https://hana-cloud-api.example.com",
+					UAA: hanacloud.UAAConfig{
+						URL:          "https://uaa.example.com",
+						ClientID:     "test-client",
+						ClientSecret: "test-secret",
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "fails when management cluster secret lacks the key",
+			objects: []client.Object{
+				&servicescloudsapv1.ServiceInstance{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "hana-instance",
+						Namespace: "default",
+					},
+					Status: servicescloudsapv1.ServiceInstanceStatus{
+						InstanceID: "test-instance-id",
+					},
+				},
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "hana-admin-api",
+						Namespace: "crossplane-system",
+					},
+				},
+			},
+			cr: &v1alpha1.KymaInstanceMapping{
+				Spec: v1alpha1.KymaInstanceMappingSpec{
+					ForProvider: v1alpha1.KymaInstanceMappingParameters{
+						ServiceInstanceRef: v1alpha1.ResourceReference{
+							Name:      "hana-instance",
+							Namespace: "default",
+						},
+						AdminCredentialsSecretRef: &v1alpha1.AdminCredentialsSecretRef{
+							Name:      "hana-admin-api",
+							Namespace: "crossplane-system",
+							Key:       "credentials",
+						},
+					},
+				},
+			},
+			wantErr:     true,
+			errContains: `key "credentials" not found`,
+		},
 		{
 			name:    "fails when ServiceInstance not found",
 			objects: []client.Object{},
@@ -527,7 +710,7 @@ func TestExtractKymaData(t *testing.T) {
 							Name:      "missing-instance",
 							Namespace: "default",
 						},
-						AdminBindingRef: v1alpha1.ResourceReference{
+						AdminBindingRef: &v1alpha1.ResourceReference{
 							Name:      "admin-binding",
 							Namespace: "default",
 						},
@@ -550,7 +733,7 @@ func TestExtractKymaData(t *testing.T) {
 				WithObjects(tt.objects...).
 				Build()
 
-			data, err := extractKymaData(context.Background(), fakeClient, tt.cr)
+			data, err := extractKymaData(context.Background(), fakeClient, fakeClient, tt.cr)
 
 			if tt.wantErr {
 				if err == nil {
@@ -601,3 +784,382 @@ func TestExtractKymaData(t *testing.T) {
 		})
 	}
 }
+
+// childInstanceMapping returns a ready child InstanceMapping of the given
+// KymaInstanceMapping.
+func childInstanceMapping(cr *v1alpha1.KymaInstanceMapping, name string) *v1alpha1.InstanceMapping {
+	return &v1alpha1.InstanceMapping{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            name,
+			OwnerReferences: []metav1.OwnerReference{ownerReference(cr)},
+		},
+		Status: v1alpha1.InstanceMappingStatus{
+			ResourceStatus: xpv1.ResourceStatus{
+				ConditionedStatus: xpv1.ConditionedStatus{
+					Conditions: []xpv1.Condition{
+						{Type: xpv1.TypeReady, Status: corev1.ConditionTrue},
+						{Type: xpv1.TypeSynced, Status: corev1.ConditionTrue},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestExternal_ObserveTargetNamespaces(t *testing.T) {
+	cr := &v1alpha1.KymaInstanceMapping{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-mapping",
+			UID:  "test-uid",
+		},
+		Spec: v1alpha1.KymaInstanceMappingSpec{
+			ForProvider: v1alpha1.KymaInstanceMappingParameters{
+				TargetNamespaces: []string{"ns-a", "ns-b"},
+			},
+		},
+	}
+
+	tests := []struct {
+		name         string
+		existing     []client.Object
+		wantExists   bool
+		wantUpToDate bool
+		wantMappings int
+		wantReady    bool
+	}{
+		{
+			name:       "no child InstanceMapping exists",
+			wantExists: false,
+		},
+		{
+			name:         "all child InstanceMappings exist and are ready",
+			existing:     []client.Object{childInstanceMapping(cr, "test-mapping-mapping-ns-a"), childInstanceMapping(cr, "test-mapping-mapping-ns-b")},
+			wantExists:   true,
+			wantUpToDate: true,
+			wantMappings: 2,
+			wantReady:    true,
+		},
+		{
+			name:         "child InstanceMapping of an added namespace is missing",
+			existing:     []client.Object{childInstanceMapping(cr, "test-mapping-mapping-ns-a")},
+			wantExists:   true,
+			wantMappings: 1,
+		},
+		{
+			name: "child InstanceMapping of a removed namespace is left",
+			existing: []client.Object{
+				childInstanceMapping(cr, "test-mapping-mapping-ns-a"),
+				childInstanceMapping(cr, "test-mapping-mapping-ns-b"),
+				childInstanceMapping(cr, "test-mapping-mapping-ns-c"),
+			},
+			wantExists:   true,
+			wantMappings: 2,
+			wantReady:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			_ = v1alpha1.SchemeBuilder.AddToScheme(scheme)
+			_ = corev1.AddToScheme(scheme)
+
+			e := &External{
+				managementClient: fake.NewClientBuilder().WithScheme(scheme).WithObjects(tt.existing...).Build(),
+				kymaData:         &kymaExtractedData{},
+				log:              logging.NewNopLogger(),
+			}
+
+			mg := cr.DeepCopy()
+			obs, err := e.Observe(context.Background(), mg)
+			if err != nil {
+				t.Fatalf("Observe() unexpected error = %v", err)
+			}
+			if obs.ResourceExists != tt.wantExists {
+				t.Errorf("Observe() ResourceExists = %v, want %v", obs.ResourceExists, tt.wantExists)
+			}
+			if obs.ResourceUpToDate != tt.wantUpToDate {
+				t.Errorf("Observe() ResourceUpToDate = %v, want %v", obs.ResourceUpToDate, tt.wantUpToDate)
+			}
+			if len(mg.Status.AtProvider.Mappings) != tt.wantMappings {
+				t.Errorf("Observe() Mappings = %v, want %d", mg.Status.AtProvider.Mappings, tt.wantMappings)
+			}
+			if tt.wantExists && mg.Status.AtProvider.ChildResources.InstanceMappingReady != tt.wantReady {
+				t.Errorf("Observe() InstanceMappingReady = %v, want %v", mg.Status.AtProvider.ChildResources.InstanceMappingReady, tt.wantReady)
+			}
+		})
+	}
+}
+
+func TestExternal_Update(t *testing.T) {
+	cr := &v1alpha1.KymaInstanceMapping{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-mapping",
+			UID:  "test-uid",
+		},
+		Spec: v1alpha1.KymaInstanceMappingSpec{
+			ForProvider: v1alpha1.KymaInstanceMappingParameters{
+				TargetNamespaces: []string{"ns-a", "ns-b"},
+			},
+		},
+	}
+
+	scheme := runtime.NewScheme()
+	_ = v1alpha1.SchemeBuilder.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+
+	unrelated := childInstanceMapping(cr, "other-mapping")
+	unrelated.OwnerReferences = nil
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		childInstanceMapping(cr, "test-mapping-mapping-ns-a"),
+		childInstanceMapping(cr, "test-mapping-mapping-ns-c"),
+		unrelated,
+	).Build()
+
+	e := &External{
+		managementClient: fakeClient,
+		kymaData: &kymaExtractedData{
+			serviceInstanceID: "test-instance-id",
+			clusterID:         "test-cluster-id",
+		},
+		log: logging.NewNopLogger(),
+	}
+
+	if _, err := e.Update(context.Background(), cr); err != nil {
+		t.Fatalf("Update() unexpected error = %v", err)
+	}
+
+	list := &v1alpha1.InstanceMappingList{}
+	if err := fakeClient.List(context.Background(), list); err != nil {
+		t.Fatalf("List() unexpected error = %v", err)
+	}
+	var names []string
+	for _, im := range list.Items {
+		names = append(names, im.Name)
+	}
+	want := "other-mapping,test-mapping-mapping-ns-a,test-mapping-mapping-ns-b"
+	if got := strings.Join(names, ","); got != want {
+		t.Errorf("Update() InstanceMappings = %s, want %s", got, want)
+	}
+
+	im := &v1alpha1.InstanceMapping{}
+	if err := fakeClient.Get(context.Background(), client.ObjectKey{Name: "test-mapping-mapping-ns-b"}, im); err != nil {
+		t.Fatalf("Get() unexpected error = %v", err)
+	}
+	if ptr.Deref(im.Spec.ForProvider.SecondaryID, "") != "ns-b" {
+		t.Errorf("InstanceMapping.SecondaryID = %v, want %v", im.Spec.ForProvider.SecondaryID, "ns-b")
+	}
+}
+
+// mockIMClient is a mock HANA Cloud instance mapping client.
+type mockIMClient struct {
+	mappings []imclient.InstanceMapping
+	deleted  []string
+}
+
+func (m *mockIMClient) List(_ context.Context, _ string) ([]imclient.InstanceMapping, error) {
+	return m.mappings, nil
+}
+
+func (m *mockIMClient) Create(_ context.Context, _ string, _ imclient.CreateMappingRequest) (string, error) {
+	return "", nil
+}
+
+func (m *mockIMClient) Delete(_ context.Context, _, primaryID, secondaryID string) (string, error) {
+	m.deleted = append(m.deleted, primaryID+"/"+secondaryID)
+	return "", nil
+}
+
+func (m *mockIMClient) GetOperation(_ context.Context, _, operationID string) (operation.Operation, error) {
+	return operation.Operation{ID: operationID, State: operation.StateSucceeded}, nil
+}
+
+// mockRecorder records the reasons of the events it is sent.
+type mockRecorder struct {
+	reasons []event.Reason
+}
+
+func (r *mockRecorder) Event(_ runtime.Object, e event.Event) {
+	r.reasons = append(r.reasons, e.Reason)
+}
+
+func (r *mockRecorder) WithAnnotations(_ ...string) event.Recorder {
+	return r
+}
+
+func TestExternal_CheckTargetNamespace(t *testing.T) {
+	existing := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "existing"}}
+
+	tests := []struct {
+		name       string
+		check      string
+		namespace  *string
+		errMsg     string
+		wantExists bool
+	}{
+		{
+			name:      "no check maps a missing namespace",
+			check:     v1alpha1.TargetNamespaceCheckNone,
+			namespace: stringPtr("missing"),
+		},
+		{
+			name:  "namespace of the ServiceInstance is not checked",
+			check: v1alpha1.TargetNamespaceCheckVerify,
+		},
+		{
+			name:       "verify accepts an existing namespace",
+			check:      v1alpha1.TargetNamespaceCheckVerify,
+			namespace:  stringPtr("existing"),
+			wantExists: true,
+		},
+		{
+			name:      "verify rejects a missing namespace",
+			check:     v1alpha1.TargetNamespaceCheckVerify,
+			namespace: stringPtr("missing"),
+			errMsg:    `target namespace "missing" does not exist on Kyma cluster`,
+		},
+		{
+			name:       "create creates a missing namespace",
+			check:      v1alpha1.TargetNamespaceCheckCreate,
+			namespace:  stringPtr("missing"),
+			wantExists: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			_ = corev1.AddToScheme(scheme)
+			clusterClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(existing.DeepCopy()).Build()
+
+			e := &External{
+				clusterClient: clusterClient,
+				log:           logging.NewNopLogger(),
+			}
+			cr := &v1alpha1.KymaInstanceMapping{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-mapping"},
+				Spec: v1alpha1.KymaInstanceMappingSpec{
+					ForProvider: v1alpha1.KymaInstanceMappingParameters{
+						TargetNamespace:      tt.namespace,
+						TargetNamespaceCheck: tt.check,
+					},
+				},
+			}
+
+			err := e.checkTargetNamespace(context.Background(), cr, tt.namespace)
+			if tt.errMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errMsg) {
+					t.Errorf("checkTargetNamespace() error = %v, want error containing %q", err, tt.errMsg)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("checkTargetNamespace() unexpected error = %v", err)
+			}
+			if tt.namespace == nil {
+				return
+			}
+			err = clusterClient.Get(context.Background(), client.ObjectKey{Name: *tt.namespace}, &corev1.Namespace{})
+			if exists := err == nil; exists != tt.wantExists {
+				t.Errorf("namespace %q exists = %v, want %v", *tt.namespace, exists, tt.wantExists)
+			}
+		})
+	}
+}
+
+func TestExternal_Delete(t *testing.T) {
+	deletingChild := func(cr *v1alpha1.KymaInstanceMapping) *v1alpha1.InstanceMapping {
+		im := childInstanceMapping(cr, "test-mapping-mapping")
+		im.Finalizers = []string{managed.FinalizerName}
+		im.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+		im.Spec.ForProvider = v1alpha1.InstanceMappingParameters{
+			ServiceInstanceID: "test-instance-id",
+			PrimaryID:         "test-cluster-id",
+			SecondaryID:       stringPtr("target-ns"),
+		}
+		return im
+	}
+
+	tests := []struct {
+		name         string
+		deletedSince time.Duration
+		child        func(cr *v1alpha1.KymaInstanceMapping) *v1alpha1.InstanceMapping
+		remote       []imclient.InstanceMapping
+		wantChild    bool
+		wantDeleted  []string
+		wantEvents   []event.Reason
+	}{
+		{
+			name: "child InstanceMapping is deleted",
+			child: func(cr *v1alpha1.KymaInstanceMapping) *v1alpha1.InstanceMapping {
+				return childInstanceMapping(cr, "test-mapping-mapping")
+			},
+		},
+		{
+			name:         "deleting child InstanceMapping is left to its controller within the timeout",
+			deletedSince: time.Minute,
+			child:        deletingChild,
+			wantChild:    true,
+		},
+		{
+			name:         "stuck child InstanceMapping is finalized once its mapping is removed",
+			deletedSince: teardownTimeout + time.Minute,
+			child:        deletingChild,
+			wantDeleted:  []string{"test-cluster-id/target-ns"},
+		},
+		{
+			name:         "stuck child InstanceMapping is force-finalized with a warning if its mapping remains",
+			deletedSince: teardownTimeout + time.Minute,
+			child:        deletingChild,
+			remote:       []imclient.InstanceMapping{{PrimaryID: "test-cluster-id", SecondaryID: stringPtr("target-ns")}},
+			wantDeleted:  []string{"test-cluster-id/target-ns"},
+			wantEvents:   []event.Reason{reasonForceFinalized},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			_ = v1alpha1.SchemeBuilder.AddToScheme(scheme)
+			_ = corev1.AddToScheme(scheme)
+
+			cr := &v1alpha1.KymaInstanceMapping{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-mapping",
+					UID:  "test-uid",
+				},
+			}
+			if tt.deletedSince > 0 {
+				cr.DeletionTimestamp = &metav1.Time{Time: time.Now().Add(-tt.deletedSince)}
+			}
+
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(tt.child(cr)).Build()
+			imClient := &mockIMClient{mappings: tt.remote}
+			recorder := &mockRecorder{}
+			e := &External{
+				managementClient: fakeClient,
+				kymaData:         &kymaExtractedData{},
+				log:              logging.NewNopLogger(),
+				recorder:         recorder,
+				clientFactory: func(_ context.Context, _ hanacloud.AdminAPICredentials, _ logging.Logger) (imclient.Client, error) {
+					return imClient, nil
+				},
+			}
+
+			if _, err := e.Delete(context.Background(), cr); err != nil {
+				t.Fatalf("Delete() unexpected error = %v", err)
+			}
+
+			err := fakeClient.Get(context.Background(), client.ObjectKey{Name: "test-mapping-mapping"}, &v1alpha1.InstanceMapping{})
+			if gotChild := err == nil; gotChild != tt.wantChild {
+				t.Errorf("Delete() child InstanceMapping exists = %v, want %v", gotChild, tt.wantChild)
+			}
+			if diff := cmp.Diff(tt.wantDeleted, imClient.deleted); diff != "" {
+				t.Errorf("Delete() -want deleted mappings, +got deleted mappings:\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.wantEvents, recorder.reasons); diff != "" {
+				t.Errorf("Delete() -want events, +got events:\n%s", diff)
+			}
+		})
+	}
+}
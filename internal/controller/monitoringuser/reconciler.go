@@ -0,0 +1,472 @@
+/*
+Copyright 2026 SAP SE or an SAP affiliate company and contributors.
+*/
+
+package monitoringuser
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"slices"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	corev1 "k8s.io/api/core/v1"
+
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/connection"
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/SAP/crossplane-provider-hana/internal/clients/hana"
+	"github.com/SAP/crossplane-provider-hana/internal/clients/hana/monitoringuser"
+	"github.com/SAP/crossplane-provider-hana/internal/clients/hana/privilege"
+	"github.com/SAP/crossplane-provider-hana/internal/clients/hana/user"
+	"github.com/SAP/crossplane-provider-hana/internal/clients/xsql"
+	"github.com/SAP/crossplane-provider-hana/internal/utils"
+
+	"github.com/SAP/crossplane-provider-hana/apis/admin/v1alpha1"
+	apisv1alpha1 "github.com/SAP/crossplane-provider-hana/apis/v1alpha1"
+	"github.com/SAP/crossplane-provider-hana/internal/controller/approval"
+	"github.com/SAP/crossplane-provider-hana/internal/controller/externalname"
+	"github.com/SAP/crossplane-provider-hana/internal/controller/features"
+	"github.com/SAP/crossplane-provider-hana/internal/controller/outcome"
+	"github.com/SAP/crossplane-provider-hana/internal/controller/poll"
+)
+
+const (
+	errNotMonitoringUser = "managed resource is not a MonitoringUser custom resource"
+	errTrackPCUsage      = "cannot track ProviderConfig usage: %w"
+	errGetPC             = "cannot get ProviderConfig: %w"
+	errGetTLS            = "cannot get TLS configuration: %w"
+
+	errGetPasswordSecret = "cannot get password secret: %w"
+	errKeyNotFound       = "key %s not found in secret %s/%s"
+	errFormatGrants      = "cannot format monitoring grants: %w"
+	errSelectUser        = "cannot select monitoring user: %w"
+	errCreateUser        = "cannot create monitoring user: %w"
+	errUpdateUser        = "cannot update monitoring user: %w"
+	errDropUser          = "cannot drop monitoring user: %w"
+
+	usergroupDefault = "DEFAULT"
+)
+
+// Setup adds a controller that reconciles MonitoringUser managed resources.
+func Setup(mgr ctrl.Manager, o controller.Options, db xsql.Connector) error {
+	name := managed.ControllerName(v1alpha1.MonitoringUserGroupKind)
+
+	cps := []managed.ConnectionPublisher{managed.NewAPISecretPublisher(mgr.GetClient(), mgr.GetScheme())}
+	if o.Features.Enabled(features.EnableAlphaExternalSecretStores) {
+		cps = append(cps, connection.NewDetailsManager(mgr.GetClient(), apisv1alpha1.StoreConfigGroupVersionKind))
+	}
+
+	log := o.Logger.WithValues("controller", name)
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1alpha1.MonitoringUserGroupVersionKind),
+		outcome.WithExternalConnecter(name, &connector{
+			kube:      mgr.GetClient(),
+			usage:     resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1alpha1.ProviderConfigUsage{}),
+			newClient: user.New,
+			log:       log,
+			db:        db,
+		}),
+		managed.WithLogger(log),
+		managed.WithInitializers(externalname.NewInitializer(mgr.GetClient(), nameField)),
+		managed.WithPollInterval(o.PollInterval),
+		poll.WithJitterHook(v1alpha1.MonitoringUserKind, nil),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+		managed.WithConnectionPublishers(cps...),
+		features.ConfigureBetaManagementPolicies(o))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		For(&v1alpha1.MonitoringUser{}).
+		Complete(r)
+}
+
+// nameField maps the external name to the username.
+func nameField(mg resource.Managed) *string {
+	cr, ok := mg.(*v1alpha1.MonitoringUser)
+	if !ok {
+		return nil
+	}
+	return &cr.Spec.ForProvider.Username
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube      client.Client
+	usage     resource.Tracker
+	newClient func(db xsql.DB, username string) user.Client
+	log       logging.Logger
+	db        xsql.Connector
+}
+
+// Connect produces an ExternalClient for the database of the ProviderConfig
+// of the MonitoringUser.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1alpha1.MonitoringUser)
+	if !ok {
+		return nil, errors.New(errNotMonitoringUser)
+	}
+
+	if err := c.usage.Track(ctx, mg); err != nil {
+		return nil, fmt.Errorf(errTrackPCUsage, err)
+	}
+
+	pc := &apisv1alpha1.ProviderConfig{}
+	if err := c.kube.Get(ctx, types.NamespacedName{Name: cr.GetProviderConfigReference().Name}, pc); err != nil {
+		return nil, fmt.Errorf(errGetPC, err)
+	}
+
+	data, err := hana.Credentials(ctx, c.kube, pc)
+	if err != nil {
+		return nil, err
+	}
+
+	creds, err := hana.ConnectionCredentials(ctx, c.kube, pc, data)
+	if err != nil {
+		return nil, fmt.Errorf(errGetTLS, err)
+	}
+
+	conn, err := c.db.Connect(ctx, creds)
+	if err != nil {
+		return nil, fmt.Errorf("cannot connect to HANA DB: %w", err)
+	}
+
+	if err := hana.ReportActiveEndpoint(ctx, c.kube, pc, conn); err != nil {
+		c.log.Info("Cannot report active endpoint", "providerConfig", pc.Name, "error", err)
+	}
+
+	cl := c.newClient(conn, string(creds[xpv1.ResourceCredentialsSecretUserKey]))
+	if op := pc.Spec.UsergroupOperator; op != nil {
+		cl = cl.ForUsergroupOperator(op.Usergroup)
+	}
+	if pc.Spec.CatalogRead {
+		cl = cl.WithCatalogRead()
+	}
+
+	// Publish the endpoint the provider is actually connected to
+	endpoint := string(creds[xpv1.ResourceCredentialsSecretEndpointKey])
+	port := string(creds[xpv1.ResourceCredentialsSecretPortKey])
+	if host, p, err := net.SplitHostPort(hana.Endpoint(conn)); err == nil {
+		endpoint, port = host, p
+	}
+
+	return &external{
+		client:           cl,
+		bundle:           monitoringuser.NewBundle(conn),
+		kube:             c.kube,
+		log:              c.log,
+		grantPolicy:      pc.Spec.GrantPolicy,
+		defaultUsergroup: pc.Spec.DefaultUsergroup,
+		identifierCase:   pc.Spec.IdentifierCase,
+		endpoint:         endpoint,
+		port:             port,
+	}, nil
+}
+
+// An ExternalClient manages a monitoring user as a user holding the
+// monitoring bundle. Grants of the bundle that are no longer desired are
+// revoked, any other grants of the user are left alone.
+type external struct {
+	client monitoringuser.MonitoringUserClient
+	bundle monitoringuser.Bundle
+	kube   client.Client
+	log    logging.Logger
+
+	grantPolicy      *apisv1alpha1.GrantPolicy
+	defaultUsergroup string
+	identifierCase   string
+	endpoint         string
+	port             string
+}
+
+func (c *external) Disconnect(ctx context.Context) error {
+	return nil
+}
+
+// desired returns the parameters of the user a MonitoringUser describes.
+func (c *external) desired(cr *v1alpha1.MonitoringUser) (*v1alpha1.UserParameters, error) {
+	p := cr.Spec.ForProvider
+	privileges, roles := c.bundle.Grants(p.Statistics)
+	privileges, err := privilege.FormatPrivilegeStrings(privileges, c.client.GetDefaultSchema())
+	if err != nil {
+		return nil, fmt.Errorf(errFormatGrants, err)
+	}
+	roles, err = privilege.FormatRoleStrings(roles)
+	if err != nil {
+		return nil, fmt.Errorf(errFormatGrants, err)
+	}
+
+	usergroup := p.Usergroup
+	if usergroup == "" {
+		usergroup = c.defaultUsergroup
+	}
+	if usergroup == "" {
+		usergroup = usergroupDefault
+	}
+
+	return &v1alpha1.UserParameters{
+		Username:   utils.FoldIdentifier(p.Username, c.identifierCase == apisv1alpha1.IdentifierCasePreserve),
+		Usergroup:  hana.FoldIdentifier(c.identifierCase, usergroup),
+		Privileges: privileges,
+		Roles:      roles,
+		Authentication: v1alpha1.Authentication{
+			Password: &v1alpha1.Password{PasswordSecretRef: p.PasswordSecretRef.DeepCopy()},
+		},
+		// Monitoring tools log on unattended, so the password never expires
+		PasswordNeverExpires: true,
+	}, nil
+}
+
+// diff returns the changes that bring the observed user to the desired one.
+type diff struct {
+	grantPrivileges  []string
+	revokePrivileges []string
+	grantRoles       []string
+	usergroup        bool
+	password         bool
+	lifetimeCheck    bool
+}
+
+func (d diff) upToDate() bool {
+	return len(d.grantPrivileges) == 0 && len(d.revokePrivileges) == 0 && len(d.grantRoles) == 0 &&
+		!d.usergroup && !d.password && !d.lifetimeCheck
+}
+
+func (c *external) diff(desired *v1alpha1.UserParameters, observed *v1alpha1.UserObservation) (diff, error) {
+	managedPrivileges, err := privilege.FormatPrivilegeStrings(c.bundle.ManagedPrivileges(), c.client.GetDefaultSchema())
+	if err != nil {
+		return diff{}, fmt.Errorf(errFormatGrants, err)
+	}
+
+	d := diff{
+		usergroup:     observed.Usergroup != nil && *observed.Usergroup != desired.Usergroup,
+		password:      observed.PasswordUpToDate != nil && !*observed.PasswordUpToDate,
+		lifetimeCheck: observed.IsPasswordLifetimeCheckEnabled != nil && *observed.IsPasswordLifetimeCheckEnabled,
+	}
+	for _, p := range desired.Privileges {
+		if !slices.Contains(observed.Privileges, p) {
+			d.grantPrivileges = append(d.grantPrivileges, p)
+		}
+	}
+	for _, p := range managedPrivileges {
+		if !slices.Contains(desired.Privileges, p) && slices.Contains(observed.Privileges, p) {
+			d.revokePrivileges = append(d.revokePrivileges, p)
+		}
+	}
+	for _, r := range desired.Roles {
+		if !slices.Contains(observed.Roles, r) {
+			d.grantRoles = append(d.grantRoles, r)
+		}
+	}
+	return d, nil
+}
+
+// read returns the desired parameters, the password and the observed user.
+func (c *external) read(ctx context.Context, cr *v1alpha1.MonitoringUser) (*v1alpha1.UserParameters, string, *v1alpha1.UserObservation, error) {
+	desired, err := c.desired(cr)
+	if err != nil {
+		return nil, "", nil, err
+	}
+	password, err := c.getPassword(ctx, cr)
+	if err != nil {
+		return nil, "", nil, err
+	}
+	observed, err := c.client.Read(ctx, desired, password)
+	if err != nil {
+		return nil, "", nil, fmt.Errorf(errSelectUser, err)
+	}
+	return desired, password, observed, nil
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.MonitoringUser)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotMonitoringUser)
+	}
+
+	desired, password, observed, err := c.read(ctx, cr)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	if observed.Username == nil {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	d, err := c.diff(desired, observed)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
+	cr.Status.AtProvider = v1alpha1.MonitoringUserObservation{
+		Username:         *observed.Username,
+		Privileges:       intersect(desired.Privileges, observed.Privileges),
+		Roles:            intersect(desired.Roles, observed.Roles),
+		PasswordUpToDate: observed.PasswordUpToDate,
+	}
+	cr.SetConditions(xpv1.Available())
+
+	return managed.ExternalObservation{
+		ResourceExists:    true,
+		ResourceUpToDate:  d.upToDate(),
+		ConnectionDetails: c.connectionDetails(desired.Username, password),
+	}, nil
+}
+
+// intersect returns the values of a that are also in b.
+func intersect(a, b []string) []string {
+	var res []string
+	for _, v := range a {
+		if slices.Contains(b, v) {
+			res = append(res, v)
+		}
+	}
+	return res
+}
+
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.MonitoringUser)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotMonitoringUser)
+	}
+
+	cr.SetConditions(xpv1.Creating())
+
+	desired, err := c.desired(cr)
+	if err != nil {
+		return managed.ExternalCreation{}, err
+	}
+	if err := c.enforceGrantPolicy(cr, desired.Privileges, desired.Roles); err != nil {
+		return managed.ExternalCreation{}, fmt.Errorf(errCreateUser, err)
+	}
+	password, err := c.getPassword(ctx, cr)
+	if err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
+	if err := c.client.Create(ctx, desired, password, nil); err != nil {
+		return managed.ExternalCreation{}, fmt.Errorf(errCreateUser, err)
+	}
+
+	c.log.Info("Created monitoring user", "name", cr.Name, "username", desired.Username)
+	return managed.ExternalCreation{ConnectionDetails: c.connectionDetails(desired.Username, password)}, nil
+}
+
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.MonitoringUser)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotMonitoringUser)
+	}
+
+	desired, password, observed, err := c.read(ctx, cr)
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	d, err := c.diff(desired, observed)
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	if err := c.enforceGrantPolicy(cr, d.grantPrivileges, d.grantRoles); err != nil {
+		return managed.ExternalUpdate{}, fmt.Errorf(errUpdateUser, err)
+	}
+
+	if d.usergroup {
+		if err := c.client.UpdateUsergroup(ctx, desired.Username, desired.Usergroup); err != nil {
+			return managed.ExternalUpdate{}, fmt.Errorf(errUpdateUser, err)
+		}
+	}
+	if err := c.client.UpdatePrivileges(ctx, desired.Username, d.grantPrivileges, d.revokePrivileges); err != nil {
+		return managed.ExternalUpdate{}, fmt.Errorf(errUpdateUser, err)
+	}
+	if err := c.client.UpdateRoles(ctx, desired.Username, d.grantRoles, nil); err != nil {
+		return managed.ExternalUpdate{}, fmt.Errorf(errUpdateUser, err)
+	}
+	if d.password {
+		if err := c.client.UpdatePassword(ctx, desired.Username, password, false); err != nil {
+			return managed.ExternalUpdate{}, fmt.Errorf(errUpdateUser, err)
+		}
+	}
+	if d.lifetimeCheck {
+		if err := c.client.UpdatePasswordLifetimeCheck(ctx, desired.Username, false); err != nil {
+			return managed.ExternalUpdate{}, fmt.Errorf(errUpdateUser, err)
+		}
+	}
+
+	c.log.Info("Updated monitoring user", "name", cr.Name, "username", desired.Username)
+	return managed.ExternalUpdate{ConnectionDetails: c.connectionDetails(desired.Username, password)}, nil
+}
+
+// enforceGrantPolicy rejects grants of the monitoring bundle forbidden by the
+// grant policy of the ProviderConfig and holds back high-risk grants until
+// they are approved.
+func (c *external) enforceGrantPolicy(cr *v1alpha1.MonitoringUser, privileges, roles []string) error {
+	if err := privilege.CheckGrantPolicy(c.grantPolicy, privileges, roles, c.client.GetDefaultSchema()); err != nil {
+		c.log.Info("Grant rejected by grant policy", "name", cr.Name, "error", err)
+		cr.SetConditions(apisv1alpha1.GrantForbidden(err))
+		return err
+	}
+	// No admission webhook stamps the approval annotations of
+	// MonitoringUsers, so high-risk grants of the bundle stay pending
+	if err := approval.Check(cr, false, c.grantPolicy, privileges, roles, c.client.GetDefaultSchema()); err != nil {
+		c.log.Info("Grant waiting for approval", "name", cr.Name, "error", err)
+		return err
+	}
+	return nil
+}
+
+func (c *external) Delete(ctx context.Context, mg resource.Managed) (managed.ExternalDelete, error) {
+	cr, ok := mg.(*v1alpha1.MonitoringUser)
+	if !ok {
+		return managed.ExternalDelete{}, errors.New(errNotMonitoringUser)
+	}
+
+	cr.SetConditions(xpv1.Deleting())
+
+	username := utils.FoldIdentifier(cr.Spec.ForProvider.Username, c.identifierCase == apisv1alpha1.IdentifierCasePreserve)
+	if err := c.client.Delete(ctx, &v1alpha1.UserParameters{Username: username}); err != nil {
+		return managed.ExternalDelete{}, fmt.Errorf(errDropUser, err)
+	}
+
+	c.log.Info("Dropped monitoring user", "name", cr.Name, "username", username)
+	return managed.ExternalDelete{}, nil
+}
+
+// connectionDetails returns the credentials monitoring tools connect with.
+func (c *external) connectionDetails(username, password string) managed.ConnectionDetails {
+	details := managed.ConnectionDetails{
+		"user": []byte(username),
+		xpv1.ResourceCredentialsSecretPasswordKey: []byte(password),
+	}
+	if c.endpoint != "" {
+		details[xpv1.ResourceCredentialsSecretEndpointKey] = []byte(c.endpoint)
+	}
+	if c.port != "" {
+		details[xpv1.ResourceCredentialsSecretPortKey] = []byte(c.port)
+	}
+	return details
+}
+
+// getPassword returns the password of the password secret of a
+// MonitoringUser.
+func (c *external) getPassword(ctx context.Context, cr *v1alpha1.MonitoringUser) (string, error) {
+	ref := cr.Spec.ForProvider.PasswordSecretRef
+	s := &corev1.Secret{}
+	if err := c.kube.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, s); err != nil {
+		return "", fmt.Errorf(errGetPasswordSecret, err)
+	}
+	password, ok := s.Data[ref.Key]
+	if !ok {
+		return "", fmt.Errorf(errKeyNotFound, ref.Key, ref.Namespace, ref.Name)
+	}
+	return string(password), nil
+}
@@ -0,0 +1,325 @@
+/*
+Copyright 2026 SAP SE or an SAP affiliate company and contributors.
+*/
+
+package monitoringuser
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/SAP/crossplane-provider-hana/apis/admin/v1alpha1"
+	apisv1alpha1 "github.com/SAP/crossplane-provider-hana/apis/v1alpha1"
+	"github.com/SAP/crossplane-provider-hana/internal/clients/hana/privilege"
+	"github.com/SAP/crossplane-provider-hana/internal/clients/hana/user"
+	"github.com/SAP/crossplane-provider-hana/internal/controller/approval"
+)
+
+type mockClient struct {
+	MockRead       func(ctx context.Context, parameters *v1alpha1.UserParameters, password string) (*v1alpha1.UserObservation, error)
+	grantedPrivs   []string
+	revokedPrivs   []string
+	grantedRoles   []string
+	passwordUpdate bool
+	created        bool
+}
+
+func (m *mockClient) Read(ctx context.Context, parameters *v1alpha1.UserParameters, password string) (*v1alpha1.UserObservation, error) {
+	return m.MockRead(ctx, parameters, password)
+}
+
+func (m *mockClient) Create(ctx context.Context, parameters *v1alpha1.UserParameters, password string, providers []user.ResolvedUserMapping) error {
+	m.created = true
+	return nil
+}
+
+func (m *mockClient) Delete(ctx context.Context, parameters *v1alpha1.UserParameters) error {
+	return nil
+}
+
+func (m *mockClient) UpdatePrivileges(ctx context.Context, grantee string, toGrant, toRevoke []string) error {
+	m.grantedPrivs, m.revokedPrivs = toGrant, toRevoke
+	return nil
+}
+
+func (m *mockClient) UpdateRoles(ctx context.Context, grantee string, toGrant, toRevoke []string) error {
+	m.grantedRoles = toGrant
+	return nil
+}
+
+func (m *mockClient) UpdateUsergroup(ctx context.Context, username, usergroup string) error {
+	return nil
+}
+
+func (m *mockClient) UpdatePassword(ctx context.Context, username, password string, forceFirstPasswordChange bool) error {
+	m.passwordUpdate = true
+	return nil
+}
+
+func (m *mockClient) UpdatePasswordLifetimeCheck(ctx context.Context, username string, isPasswordLifetimeCheckEnabled bool) error {
+	return nil
+}
+
+func (m *mockClient) GetDefaultSchema() string {
+	return "ADMIN"
+}
+
+func newMonitoringUser(statistics bool) *v1alpha1.MonitoringUser {
+	return &v1alpha1.MonitoringUser{Spec: v1alpha1.MonitoringUserSpec{ForProvider: v1alpha1.MonitoringUserParameters{
+		Username:          "MONITOR",
+		PasswordSecretRef: xpv1.SecretKeySelector{SecretReference: xpv1.SecretReference{Namespace: "default", Name: "monitor"}, Key: "password"},
+		Statistics:        statistics,
+	}}}
+}
+
+func passwordKube(err error) client.Client {
+	return &test.MockClient{
+		MockGet: test.NewMockGetFn(err, func(obj client.Object) error {
+			obj.(*corev1.Secret).Data = map[string][]byte{"password": []byte("Secret1")}
+			return nil
+		}),
+	}
+}
+
+func observed(privileges, roles []string, passwordUpToDate bool) *v1alpha1.UserObservation {
+	username, usergroup, lifetimeCheck := "MONITOR", "DEFAULT", false
+	return &v1alpha1.UserObservation{
+		Username:                       &username,
+		Usergroup:                      &usergroup,
+		Privileges:                     privileges,
+		Roles:                          roles,
+		PasswordUpToDate:               &passwordUpToDate,
+		IsPasswordLifetimeCheckEnabled: &lifetimeCheck,
+	}
+}
+
+func TestObserve(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type want struct {
+		exists   bool
+		upToDate bool
+		status   v1alpha1.MonitoringUserObservation
+		err      error
+	}
+
+	cases := map[string]struct {
+		reason   string
+		cr       *v1alpha1.MonitoringUser
+		kube     client.Client
+		observed *v1alpha1.UserObservation
+		readErr  error
+		want     want
+	}{
+		"ErrGetPassword": {
+			reason: "An error should be returned if the password secret cannot be read",
+			cr:     newMonitoringUser(false),
+			kube:   passwordKube(errBoom),
+			want:   want{err: fmt.Errorf(errGetPasswordSecret, errBoom)},
+		},
+		"ErrSelectUser": {
+			reason:  "An error should be returned if the user cannot be read",
+			cr:      newMonitoringUser(false),
+			kube:    passwordKube(nil),
+			readErr: errBoom,
+			want:    want{err: fmt.Errorf(errSelectUser, errBoom)},
+		},
+		"NotFound": {
+			reason:   "A missing user should not exist",
+			cr:       newMonitoringUser(false),
+			kube:     passwordKube(nil),
+			observed: &v1alpha1.UserObservation{},
+			want:     want{},
+		},
+		"UpToDate": {
+			reason:   "A user holding the bundle should be up to date, other grants are ignored",
+			cr:       newMonitoringUser(false),
+			kube:     passwordKube(nil),
+			observed: observed([]string{"CATALOG READ", "AUDIT READ"}, []string{`"MONITORING"`}, true),
+			want: want{
+				exists:   true,
+				upToDate: true,
+				status:   v1alpha1.MonitoringUserObservation{Username: "MONITOR", Privileges: []string{"CATALOG READ"}, Roles: []string{`"MONITORING"`}, PasswordUpToDate: new(true)},
+			},
+		},
+		"MissingGrants": {
+			reason:   "A user missing grants of the bundle should not be up to date",
+			cr:       newMonitoringUser(true),
+			kube:     passwordKube(nil),
+			observed: observed([]string{"CATALOG READ"}, nil, true),
+			want: want{
+				exists: true,
+				status: v1alpha1.MonitoringUserObservation{Username: "MONITOR", Privileges: []string{"CATALOG READ"}, PasswordUpToDate: new(true)},
+			},
+		},
+		"StatisticsNoLongerDesired": {
+			reason:   "A user holding the statistics privileges without statistics should not be up to date",
+			cr:       newMonitoringUser(false),
+			kube:     passwordKube(nil),
+			observed: observed([]string{"CATALOG READ", `SELECT ON SCHEMA "_SYS_STATISTICS"`}, []string{`"MONITORING"`}, true),
+			want: want{
+				exists: true,
+				status: v1alpha1.MonitoringUserObservation{Username: "MONITOR", Privileges: []string{"CATALOG READ"}, Roles: []string{`"MONITORING"`}, PasswordUpToDate: new(true)},
+			},
+		},
+		"PasswordChanged": {
+			reason:   "A user logging on with another password should not be up to date",
+			cr:       newMonitoringUser(false),
+			kube:     passwordKube(nil),
+			observed: observed([]string{"CATALOG READ"}, []string{`"MONITORING"`}, false),
+			want: want{
+				exists: true,
+				status: v1alpha1.MonitoringUserObservation{Username: "MONITOR", Privileges: []string{"CATALOG READ"}, Roles: []string{`"MONITORING"`}, PasswordUpToDate: new(false)},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{
+				client: &mockClient{MockRead: func(ctx context.Context, parameters *v1alpha1.UserParameters, password string) (*v1alpha1.UserObservation, error) {
+					return tc.observed, tc.readErr
+				}},
+				kube: tc.kube,
+				log:  logging.NewNopLogger(),
+			}
+			got, err := e.Observe(context.Background(), tc.cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.exists, got.ResourceExists); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want exists, +got exists:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.upToDate, got.ResourceUpToDate); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want up to date, +got up to date:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.status, tc.cr.Status.AtProvider); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want status, +got status:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	type want struct {
+		grantedPrivs   []string
+		revokedPrivs   []string
+		grantedRoles   []string
+		passwordUpdate bool
+		details        managed.ConnectionDetails
+	}
+
+	cases := map[string]struct {
+		reason   string
+		cr       *v1alpha1.MonitoringUser
+		observed *v1alpha1.UserObservation
+		want     want
+	}{
+		"GrantBundle": {
+			reason:   "Missing grants of the bundle should be granted",
+			cr:       newMonitoringUser(true),
+			observed: observed(nil, nil, true),
+			want: want{
+				grantedPrivs: []string{"CATALOG READ", `SELECT ON SCHEMA "_SYS_STATISTICS"`},
+				grantedRoles: []string{`"MONITORING"`},
+				details:      managed.ConnectionDetails{"user": []byte("MONITOR"), "password": []byte("Secret1")},
+			},
+		},
+		"RevokeStatistics": {
+			reason:   "The statistics privileges should be revoked if statistics is unset, other grants are left alone",
+			cr:       newMonitoringUser(false),
+			observed: observed([]string{"CATALOG READ", "AUDIT READ", `SELECT ON SCHEMA "_SYS_STATISTICS"`}, []string{`"MONITORING"`}, true),
+			want: want{
+				revokedPrivs: []string{`SELECT ON SCHEMA "_SYS_STATISTICS"`},
+				details:      managed.ConnectionDetails{"user": []byte("MONITOR"), "password": []byte("Secret1")},
+			},
+		},
+		"ResetPassword": {
+			reason:   "The password should be reset to the one of the secret",
+			cr:       newMonitoringUser(false),
+			observed: observed([]string{"CATALOG READ"}, []string{`"MONITORING"`}, false),
+			want: want{
+				passwordUpdate: true,
+				details:        managed.ConnectionDetails{"user": []byte("MONITOR"), "password": []byte("Secret1")},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			m := &mockClient{MockRead: func(ctx context.Context, parameters *v1alpha1.UserParameters, password string) (*v1alpha1.UserObservation, error) {
+				return tc.observed, nil
+			}}
+			e := external{client: m, kube: passwordKube(nil), log: logging.NewNopLogger()}
+			got, err := e.Update(context.Background(), tc.cr)
+			if err != nil {
+				t.Fatalf("\n%s\ne.Update(...): unexpected error: %v\n", tc.reason, err)
+			}
+			gotWant := want{
+				grantedPrivs:   m.grantedPrivs,
+				revokedPrivs:   m.revokedPrivs,
+				grantedRoles:   m.grantedRoles,
+				passwordUpdate: m.passwordUpdate,
+				details:        got.ConnectionDetails,
+			}
+			if diff := cmp.Diff(tc.want, gotWant, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestGrantPolicy(t *testing.T) {
+	cases := map[string]struct {
+		reason      string
+		policy      *apisv1alpha1.GrantPolicy
+		wantErr     error
+		wantApplied bool
+	}{
+		"NoPolicy": {
+			reason:      "The bundle should be granted without a grant policy",
+			wantApplied: true,
+		},
+		"Forbidden": {
+			reason:  "The bundle should not be granted if the grant policy forbids one of its grants",
+			policy:  &apisv1alpha1.GrantPolicy{ForbiddenPrivileges: []string{"CATALOG READ"}},
+			wantErr: privilege.ErrForbiddenGrant,
+		},
+		"HighRisk": {
+			reason:  "High-risk grants of the bundle should stay pending, as no webhook verifies their approval",
+			policy:  &apisv1alpha1.GrantPolicy{HighRiskRoles: []string{"MONITORING"}},
+			wantErr: approval.ErrApprovalUnverified,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			m := &mockClient{MockRead: func(ctx context.Context, parameters *v1alpha1.UserParameters, password string) (*v1alpha1.UserObservation, error) {
+				return observed(nil, nil, true), nil
+			}}
+			e := external{client: m, kube: passwordKube(nil), log: logging.NewNopLogger(), grantPolicy: tc.policy}
+
+			_, err := e.Create(context.Background(), newMonitoringUser(false))
+			if !errors.Is(err, tc.wantErr) || (tc.wantErr == nil) != (err == nil) {
+				t.Errorf("\n%s\ne.Create(...): want error %v, got %v\n", tc.reason, tc.wantErr, err)
+			}
+			_, err = e.Update(context.Background(), newMonitoringUser(false))
+			if !errors.Is(err, tc.wantErr) || (tc.wantErr == nil) != (err == nil) {
+				t.Errorf("\n%s\ne.Update(...): want error %v, got %v\n", tc.reason, tc.wantErr, err)
+			}
+			if applied := m.created || m.grantedPrivs != nil || m.grantedRoles != nil; applied != tc.wantApplied {
+				t.Errorf("\n%s\nwant grants applied %t, got %t\n", tc.reason, tc.wantApplied, applied)
+			}
+		})
+	}
+}
@@ -0,0 +1,181 @@
+/*
+Copyright 2026 SAP SE or an SAP affiliate company and contributors.
+*/
+
+package outcome
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/SAP/go-hdb/driver"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/prometheus/client_golang/prometheus"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	apisv1alpha1 "github.com/SAP/crossplane-provider-hana/apis/v1alpha1"
+	"github.com/SAP/crossplane-provider-hana/internal/audit"
+	"github.com/SAP/crossplane-provider-hana/internal/clients/hana"
+	"github.com/SAP/crossplane-provider-hana/internal/clients/hana/privilege"
+	"github.com/SAP/crossplane-provider-hana/internal/sqlstats"
+)
+
+// Results of a reconcile.
+const (
+	ResultCreated = "created"
+	ResultUpdated = "updated"
+	ResultDeleted = "deleted"
+	ResultNoop    = "noop"
+	ResultError   = "error"
+)
+
+// Classes of the errors of failed reconciles.
+const (
+	// ClassParse are privileges or roles in the spec that cannot be parsed.
+	ClassParse = "parse"
+	// ClassAuth are HANA rejecting the credentials or the privileges of the
+	// technical user.
+	ClassAuth = "auth"
+	// ClassSQL are any other errors returned by HANA.
+	ClassSQL = "sql"
+	// ClassK8s are errors returned by the Kubernetes API server.
+	ClassK8s = "k8s"
+	// ClassOther are all remaining errors, e.g. unreachable endpoints.
+	ClassOther = "other"
+)
+
+const (
+	errCodeAuthFailed            = 10
+	errCodeInsufficientPrivilege = 258
+)
+
+var reconciles = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "hana_managed_resource_reconcile_outcomes_total",
+	Help: "Outcomes of the reconciles of managed resources, by controller, result and class of the error.",
+}, []string{"controller", "result", "error_class"})
+
+func init() {
+	metrics.Registry.MustRegister(reconciles)
+}
+
+// Classify returns the class of a reconcile error.
+func Classify(err error) string {
+	var dbError driver.DBError
+	var status apierrors.APIStatus
+	switch {
+	case errors.Is(err, privilege.ErrParse):
+		return ClassParse
+	case errors.Is(err, hana.ErrMissingPrivilege):
+		return ClassAuth
+	case errors.As(err, &dbError):
+		if c := dbError.Code(); c == errCodeAuthFailed || c == errCodeInsufficientPrivilege {
+			return ClassAuth
+		}
+		return ClassSQL
+	case errors.As(err, &status):
+		return ClassK8s
+	default:
+		return ClassOther
+	}
+}
+
+func record(controller, result string, err error) {
+	class := ""
+	if err != nil {
+		result, class = ResultError, Classify(err)
+	}
+	reconciles.WithLabelValues(controller, result, class).Inc()
+}
+
+// WithExternalConnecter returns a managed.ReconcilerOption that connects with
+// c and counts the outcome of each reconcile of the named controller.
+func WithExternalConnecter(controller string, c managed.ExternalConnecter) managed.ReconcilerOption {
+	return managed.WithExternalConnecter(&connecter{controller: controller, next: c})
+}
+
+type connecter struct {
+	controller string
+	next       managed.ExternalConnecter
+}
+
+func (c *connecter) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	ec, err := c.next.Connect(ctx, mg)
+	if err != nil {
+		record(c.controller, "", err)
+		return nil, err
+	}
+	return &external{controller: c.controller, next: ec, counter: &sqlstats.Counter{}}, nil
+}
+
+// external counts the outcomes of the calls of the managed reconciler. A
+// reconcile ends after Observe if the resource is up to date, otherwise with
+// the call that creates, updates or deletes it. The statements of these calls
+// are attributed to the resource in the audit log. The SQL all calls of the
+// reconcile run is counted and reported in the status of the resource.
+type external struct {
+	controller string
+	next       managed.ExternalClient
+	counter    *sqlstats.Counter
+	start      time.Time
+}
+
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	e.start = time.Now()
+	o, err := e.next.Observe(sqlstats.WithCounter(ctx, e.counter), mg)
+	if err != nil || (o.ResourceExists && o.ResourceUpToDate && !meta.WasDeleted(mg)) {
+		record(e.controller, ResultNoop, err)
+	}
+	e.report(mg, true)
+	return o, err
+}
+
+func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	c, err := e.next.Create(e.actionContext(ctx, mg, audit.ActionCreate), mg)
+	record(e.controller, ResultCreated, err)
+	e.report(mg, false)
+	return c, err
+}
+
+func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	u, err := e.next.Update(e.actionContext(ctx, mg, audit.ActionUpdate), mg)
+	record(e.controller, ResultUpdated, err)
+	e.report(mg, false)
+	return u, err
+}
+
+func (e *external) Delete(ctx context.Context, mg resource.Managed) (managed.ExternalDelete, error) {
+	d, err := e.next.Delete(e.actionContext(ctx, mg, audit.ActionDelete), mg)
+	record(e.controller, ResultDeleted, err)
+	e.report(mg, false)
+	return d, err
+}
+
+func (e *external) actionContext(ctx context.Context, mg resource.Managed, action string) context.Context {
+	return sqlstats.WithCounter(audit.WithAction(ctx, e.controller, mg, action), e.counter)
+}
+
+// report sets the SQL statistics of the reconcile so far in the status of mg,
+// if it reports them. An observation only replaces the statistics if the
+// counts changed: the duration differs on every reconcile, and writing it
+// each time would update the status, and thus requeue the resource, on every
+// poll.
+func (e *external) report(mg resource.Managed, observation bool) {
+	r, ok := mg.(apisv1alpha1.SQLStatisticsReporter)
+	if !ok {
+		return
+	}
+	s := e.counter.Statistics(time.Since(e.start).Round(time.Millisecond))
+	if prev := r.GetSQLStatistics(); observation && prev != nil &&
+		prev.StatementsExecuted == s.StatementsExecuted && prev.QueriesExecuted == s.QueriesExecuted {
+		return
+	}
+	r.SetSQLStatistics(s)
+}
+
+func (e *external) Disconnect(ctx context.Context) error {
+	return e.next.Disconnect(ctx)
+}
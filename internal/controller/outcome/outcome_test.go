@@ -0,0 +1,221 @@
+/*
+Copyright 2026 SAP SE or an SAP affiliate company and contributors.
+*/
+
+package outcome
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/SAP/crossplane-provider-hana/apis/admin/v1alpha1"
+	apisv1alpha1 "github.com/SAP/crossplane-provider-hana/apis/v1alpha1"
+	"github.com/SAP/crossplane-provider-hana/internal/clients/fake"
+	"github.com/SAP/crossplane-provider-hana/internal/clients/hana"
+	"github.com/SAP/crossplane-provider-hana/internal/clients/hana/privilege"
+	"github.com/SAP/crossplane-provider-hana/internal/clients/xsql"
+	"github.com/SAP/crossplane-provider-hana/internal/sqlstats"
+)
+
+// dbError is a HANA error with a code.
+type dbError struct {
+	code int
+}
+
+func (e dbError) Error() string   { return fmt.Sprintf("SQL error %d", e.code) }
+func (e dbError) StmtNo() int     { return 0 }
+func (e dbError) Code() int       { return e.code }
+func (e dbError) Position() int   { return 0 }
+func (e dbError) Level() int      { return 1 }
+func (e dbError) Text() string    { return e.Error() }
+func (e dbError) IsWarning() bool { return false }
+func (e dbError) IsError() bool   { return true }
+func (e dbError) IsFatal() bool   { return false }
+
+func TestClassify(t *testing.T) {
+	_, errParse := privilege.NormalizePrivilegeStrings([]string{"CATALOG READ WITH GRANT OPTION"})
+
+	cases := map[string]struct {
+		err  error
+		want string
+	}{
+		"Parse": {
+			err:  fmt.Errorf("cannot create user: %w", errParse),
+			want: ClassParse,
+		},
+		"AuthenticationFailed": {
+			err:  fmt.Errorf("cannot connect: %w", dbError{code: errCodeAuthFailed}),
+			want: ClassAuth,
+		},
+		"InsufficientPrivilege": {
+			err:  dbError{code: errCodeInsufficientPrivilege},
+			want: ClassAuth,
+		},
+		"MissingPrivilege": {
+			err:  fmt.Errorf("cannot delete role: %w", hana.ErrMissingPrivilege),
+			want: ClassAuth,
+		},
+		"SQL": {
+			err:  fmt.Errorf("cannot create user: %w", dbError{code: 331}),
+			want: ClassSQL,
+		},
+		"K8s": {
+			err:  fmt.Errorf("cannot get ProviderConfig: %w", apierrors.NewNotFound(schema.GroupResource{}, "default")),
+			want: ClassK8s,
+		},
+		"Other": {
+			err:  errors.New("dial tcp: i/o timeout"),
+			want: ClassOther,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := Classify(tc.err); got != tc.want {
+				t.Errorf("Classify(%v) = %q, want %q", tc.err, got, tc.want)
+			}
+		})
+	}
+}
+
+func TestWithExternalConnecter(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type call func(ctx context.Context, e managed.ExternalClient) error
+
+	cases := map[string]struct {
+		reason     string
+		client     managed.ExternalClientFns
+		connectErr error
+		call       call
+		result     string
+		class      string
+	}{
+		"Noop": {
+			reason: "An up to date resource should be counted as noop",
+			client: managed.ExternalClientFns{ObserveFn: func(_ context.Context, _ resource.Managed) (managed.ExternalObservation, error) {
+				return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, nil
+			}},
+			call: func(ctx context.Context, e managed.ExternalClient) error {
+				_, err := e.Observe(ctx, &v1alpha1.User{})
+				return err
+			},
+			result: ResultNoop,
+		},
+		"Created": {
+			reason: "A created resource should be counted as created",
+			client: managed.ExternalClientFns{CreateFn: func(_ context.Context, _ resource.Managed) (managed.ExternalCreation, error) {
+				return managed.ExternalCreation{}, nil
+			}},
+			call: func(ctx context.Context, e managed.ExternalClient) error {
+				_, err := e.Create(ctx, &v1alpha1.User{})
+				return err
+			},
+			result: ResultCreated,
+		},
+		"UpdateError": {
+			reason: "A failed update should be counted as error with the class of the error",
+			client: managed.ExternalClientFns{UpdateFn: func(_ context.Context, _ resource.Managed) (managed.ExternalUpdate, error) {
+				return managed.ExternalUpdate{}, dbError{code: 331}
+			}},
+			call: func(ctx context.Context, e managed.ExternalClient) error {
+				_, err := e.Update(ctx, &v1alpha1.User{})
+				return err
+			},
+			result: ResultError,
+			class:  ClassSQL,
+		},
+		"ConnectError": {
+			reason:     "A failed connect should be counted as error",
+			connectErr: errBoom,
+			result:     ResultError,
+			class:      ClassOther,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			controller := "test/" + name
+			o := &connecter{controller: controller, next: managed.ExternalConnectorFn(func(_ context.Context, _ resource.Managed) (managed.ExternalClient, error) {
+				if tc.connectErr != nil {
+					return nil, tc.connectErr
+				}
+				return &tc.client, nil
+			})}
+
+			e, err := o.Connect(context.Background(), &v1alpha1.User{})
+			if err == nil {
+				_ = tc.call(context.Background(), e)
+			}
+
+			if got := testutil.ToFloat64(reconciles.WithLabelValues(controller, tc.result, tc.class)); got != 1 {
+				t.Errorf("\n%s\nreconciles{result=%q, error_class=%q} = %v, want 1", tc.reason, tc.result, tc.class, got)
+			}
+		})
+	}
+}
+
+func TestReportSQLStatistics(t *testing.T) {
+	db := fake.MockDB{
+		MockExecContext: func(ctx context.Context, query string, args ...any) (sql.Result, error) { return nil, nil },
+		MockQueryRowContext: func(ctx context.Context, query string, args ...any) *sql.Row {
+			return nil
+		},
+	}
+	cdb, _ := sqlstats.NewConnector(fake.MockConnector{MockConnect: func(context.Context, map[string][]byte) (xsql.DB, error) { return db, nil }}).Connect(context.Background(), nil)
+	client := &managed.ExternalClientFns{
+		ObserveFn: func(ctx context.Context, _ resource.Managed) (managed.ExternalObservation, error) {
+			_ = cdb.QueryRowContext(ctx, "SELECT")
+			_ = cdb.QueryRowContext(ctx, "SELECT")
+			return managed.ExternalObservation{ResourceExists: true}, nil
+		},
+		UpdateFn: func(ctx context.Context, _ resource.Managed) (managed.ExternalUpdate, error) {
+			_, _ = cdb.ExecContext(ctx, "GRANT")
+			return managed.ExternalUpdate{}, nil
+		},
+	}
+	connect := func() managed.ExternalClient {
+		o := &connecter{controller: "test/sqlstatistics", next: managed.ExternalConnectorFn(func(_ context.Context, _ resource.Managed) (managed.ExternalClient, error) {
+			return client, nil
+		})}
+		e, _ := o.Connect(context.Background(), &v1alpha1.User{})
+		return e
+	}
+	counts := func(s *apisv1alpha1.SQLStatistics) [2]int64 {
+		return [2]int64{s.StatementsExecuted, s.QueriesExecuted}
+	}
+
+	cr := &v1alpha1.User{}
+	e := connect()
+	_, _ = e.Observe(context.Background(), cr)
+	_, _ = e.Update(context.Background(), cr)
+	if got := counts(cr.GetSQLStatistics()); got != [2]int64{1, 2} {
+		t.Errorf("statistics of an observation and update: got %v, want [1 2]", got)
+	}
+
+	// An observation with other counts replaces the statistics.
+	_, _ = connect().Observe(context.Background(), cr)
+	if got := counts(cr.GetSQLStatistics()); got != [2]int64{0, 2} {
+		t.Errorf("statistics of an observation: got %v, want [0 2]", got)
+	}
+
+	// An observation with the same counts keeps the statistics, so that the
+	// status does not change on every poll.
+	previous := &apisv1alpha1.SQLStatistics{QueriesExecuted: 2, Duration: metav1.Duration{Duration: time.Hour}}
+	cr.SetSQLStatistics(previous)
+	_, _ = connect().Observe(context.Background(), cr)
+	if cr.GetSQLStatistics() != previous {
+		t.Errorf("statistics of an observation with unchanged counts: got %v, want %v", cr.GetSQLStatistics(), previous)
+	}
+}
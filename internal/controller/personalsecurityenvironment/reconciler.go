@@ -6,38 +6,61 @@ package personalsecurityenvironment
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/pem"
 	"errors"
 	"fmt"
+	"slices"
+	"strings"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	"github.com/crossplane/crossplane-runtime/pkg/connection"
 	"github.com/crossplane/crossplane-runtime/pkg/controller"
 	"github.com/crossplane/crossplane-runtime/pkg/event"
 	"github.com/crossplane/crossplane-runtime/pkg/logging"
-	"github.com/crossplane/crossplane-runtime/pkg/meta"
 	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
 	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 
 	adminv1alpha1 "github.com/SAP/crossplane-provider-hana/apis/admin/v1alpha1"
 	"github.com/SAP/crossplane-provider-hana/apis/v1alpha1"
+	"github.com/SAP/crossplane-provider-hana/internal/clients/hana"
 	"github.com/SAP/crossplane-provider-hana/internal/clients/hana/personalsecurityenvironment"
 	"github.com/SAP/crossplane-provider-hana/internal/clients/xsql"
+	"github.com/SAP/crossplane-provider-hana/internal/controller/capability"
+	"github.com/SAP/crossplane-provider-hana/internal/controller/externalname"
 	"github.com/SAP/crossplane-provider-hana/internal/controller/features"
+	"github.com/SAP/crossplane-provider-hana/internal/controller/outcome"
+	"github.com/SAP/crossplane-provider-hana/internal/controller/poll"
+	"github.com/SAP/crossplane-provider-hana/internal/controller/x509provider"
 )
 
 const (
 	errNotPersonalSecurityEnvironment = "managed resource is not a PersonalSecurityEnvironment custom resource"
 	errTrackPCUsage                   = "cannot track ProviderConfig usage: %w"
 	errGetPC                          = "cannot get ProviderConfig: %w"
-	errNoSecretRef                    = "ProviderConfig does not reference a credentials Secret"
-	errGetSecret                      = "cannot get credentials Secret: %w"
+	errGetTLS                         = "cannot get TLS configuration: %w"
 	errDbFail                         = "cannot connect to HANA db: %w"
+	errX509ProviderRefEmpty           = "X509ProviderRef must have either ProviderRef, ProviderSelector or Name specified"
+	errGetCRLSecret                   = "cannot get CRL Secret: %w"
+	errCRLKeyNotFound                 = "key %s not found in CRL Secret %s/%s"
+	errInvalidCRL                     = "key %s of CRL Secret %s/%s does not hold a PEM encoded X509 CRL"
+	errPurposes                       = "cannot query the purposes of the PSE: %w"
+	errPurposesSet                    = "PSE cannot be dropped while these purposes are set: %s"
+	errUnsetPurposes                  = "cannot unset the purposes of the PSE: %w"
+
+	msgNotValidX509Provider = "Object is not a valid X509Provider"
+	msgNotValidSecret       = "Object is not a valid Secret"
+	msgListFailed           = "Failed to list PersonalSecurityEnvironments"
 )
 
 // Setup adds a controller that reconciles PersonalSecurityEnvironment managed resources.
@@ -53,7 +76,7 @@ func Setup(mgr ctrl.Manager, o controller.Options, db xsql.Connector) error {
 	t := resource.NewProviderConfigUsageTracker(mgr.GetClient(), &v1alpha1.ProviderConfigUsage{})
 	r := managed.NewReconciler(mgr,
 		resource.ManagedKind(adminv1alpha1.PersonalSecurityEnvironmentGroupVersionKind),
-		managed.WithExternalConnecter(&connector{
+		outcome.WithExternalConnecter(name, &connector{
 			kube:      mgr.GetClient(),
 			usage:     t,
 			newClient: personalsecurityenvironment.New,
@@ -61,7 +84,9 @@ func Setup(mgr ctrl.Manager, o controller.Options, db xsql.Connector) error {
 			db:        db,
 		}),
 		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithInitializers(externalname.NewInitializer(mgr.GetClient(), nameField)),
 		managed.WithPollInterval(o.PollInterval),
+		poll.WithJitterHook(adminv1alpha1.PersonalSecurityEnvironmentKind, nil),
 		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
 		managed.WithConnectionPublishers(cps...),
 		features.ConfigureBetaManagementPolicies(o))
@@ -70,9 +95,89 @@ func Setup(mgr ctrl.Manager, o controller.Options, db xsql.Connector) error {
 		Named(name).
 		WithOptions(o.ForControllerRuntime()).
 		For(&adminv1alpha1.PersonalSecurityEnvironment{}).
+		Watches(
+			&adminv1alpha1.X509Provider{},
+			handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []reconcile.Request {
+				return generateReconcileRequestsFromX509Provider(ctx, obj, mgr.GetClient(), log)
+			}),
+		).
+		Watches(
+			&corev1.Secret{},
+			handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []reconcile.Request {
+				return generateReconcileRequestsFromCRLSecret(ctx, obj, mgr.GetClient(), log)
+			}),
+		).
 		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
 }
 
+// nameField maps the external name to the name of the PSE.
+func nameField(mg resource.Managed) *string {
+	cr, ok := mg.(*adminv1alpha1.PersonalSecurityEnvironment)
+	if !ok {
+		return nil
+	}
+	return &cr.Spec.ForProvider.Name
+}
+
+// generateReconcileRequestsFromX509Provider enqueues every PSE that references
+// the changed X509Provider, either by name or by label selector.
+func generateReconcileRequestsFromX509Provider(ctx context.Context, obj client.Object, kube client.Client, log logging.Logger) []reconcile.Request {
+	provider, ok := obj.(*adminv1alpha1.X509Provider)
+	if !ok {
+		log.Info(msgNotValidX509Provider)
+		return []reconcile.Request{}
+	}
+
+	pses := &adminv1alpha1.PersonalSecurityEnvironmentList{}
+	if err := kube.List(ctx, pses); err != nil {
+		log.Info(msgListFailed, "error", err)
+		return []reconcile.Request{}
+	}
+
+	requests := []reconcile.Request{}
+	for _, pse := range pses.Items {
+		if x509provider.References(&pse, pse.Spec.ForProvider.X509ProviderRef, provider) {
+			log.Info("X509Provider for PSE changed", "pse", pse.GetName(), "x509provider", provider.GetName())
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name: pse.Name,
+				},
+			})
+		}
+	}
+	return requests
+}
+
+// generateReconcileRequestsFromCRLSecret enqueues every PSE whose CRL is
+// stored in the changed Secret, so revocations apply without waiting for the
+// next poll.
+func generateReconcileRequestsFromCRLSecret(ctx context.Context, obj client.Object, kube client.Client, log logging.Logger) []reconcile.Request {
+	secret, ok := obj.(*corev1.Secret)
+	if !ok {
+		log.Info(msgNotValidSecret)
+		return []reconcile.Request{}
+	}
+
+	pses := &adminv1alpha1.PersonalSecurityEnvironmentList{}
+	if err := kube.List(ctx, pses); err != nil {
+		log.Info(msgListFailed, "error", err)
+		return []reconcile.Request{}
+	}
+
+	requests := []reconcile.Request{}
+	for _, pse := range pses.Items {
+		if ref := pse.Spec.ForProvider.CRLSecretRef; ref != nil && ref.Name == secret.GetName() && ref.Namespace == secret.GetNamespace() {
+			log.Info("CRL Secret for PSE changed", "pse", pse.GetName(), "secret", secret.GetName())
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name: pse.Name,
+				},
+			})
+		}
+	}
+	return requests
+}
+
 // A connector is expected to produce an ExternalClient when its Connect method
 // is called.
 type connector struct {
@@ -103,23 +208,31 @@ func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 		return nil, fmt.Errorf(errGetPC, err)
 	}
 
-	ref := pc.Spec.Credentials.ConnectionSecretRef
-	if ref == nil {
-		return nil, errors.New(errNoSecretRef)
-	}
-
-	s := &corev1.Secret{}
-	if err := c.kube.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, s); err != nil {
-		return nil, fmt.Errorf(errGetSecret, err)
+	data, err := hana.Credentials(ctx, c.kube, pc)
+	if err != nil {
+		return nil, err
 	}
 
 	c.log.Info("Connecting to personalsecurityenvironment resource", "name", cr.Name)
 
-	conn, err := c.db.Connect(ctx, s.Data)
+	creds, err := hana.ConnectionCredentials(ctx, c.kube, pc, data)
+	if err != nil {
+		return nil, fmt.Errorf(errGetTLS, err)
+	}
+
+	conn, err := c.db.Connect(ctx, creds)
 	if err != nil {
 		return nil, fmt.Errorf(errDbFail, err)
 	}
 
+	if err := hana.ReportActiveEndpoint(ctx, c.kube, pc, conn); err != nil {
+		c.log.Info("Cannot report active endpoint", "providerConfig", pc.Name, "error", err)
+	}
+
+	if err := hana.Require(ctx, conn, hana.ManagePSEs); err != nil {
+		return capability.Disabled(err), nil
+	}
+
 	return &external{
 		client: c.newClient(conn),
 		kube:   c.kube,
@@ -159,18 +272,25 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 		}, nil
 	}
 
-	providerName, err := c.getX509ProviderName(ctx, parameters.X509ProviderRef)
+	providerName, err := c.getX509ProviderName(ctx, cr, parameters.X509ProviderRef)
 	if err != nil {
 		return managed.ExternalObservation{}, fmt.Errorf("failed to get provider for pse: %w", err)
 	}
 
+	crl, err := c.getCRL(ctx, parameters.CRLSecretRef)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
 	cr.Status.AtProvider = *observed
+	cr.Status.AtProvider.ExternalID = observed.Name
 	cr.Status.SetConditions(xpv1.Available())
-	meta.SetExternalName(cr, observed.Name)
 
 	return managed.ExternalObservation{
-		ResourceExists:   true,
-		ResourceUpToDate: isUpToDate(parameters, *observed, providerName),
+		ResourceExists: true,
+		ResourceUpToDate: isUpToDate(parameters, *observed, providerName, retainedCertificates(cr)) &&
+			crlDigest(crl) == appliedCRL(cr),
+		ConnectionDetails: externalname.ConnectionDetails(observed.Name),
 	}, nil
 }
 
@@ -184,12 +304,23 @@ func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 
 	parameters := cr.Spec.ForProvider.DeepCopy()
 
-	providerName, err := c.getX509ProviderName(ctx, parameters.X509ProviderRef)
+	providerName, err := c.getX509ProviderName(ctx, cr, parameters.X509ProviderRef)
 	if err != nil {
 		return managed.ExternalCreation{}, fmt.Errorf("failed to get provider for pse: %w", err)
 	}
 
-	return managed.ExternalCreation{}, c.client.Create(ctx, parameters, providerName)
+	crl, err := c.getCRL(ctx, parameters.CRLSecretRef)
+	if err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
+	if err := c.client.Create(ctx, parameters, providerName); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+
+	// A new PSE has no CRL, whatever was applied to a previous one.
+	cr.Status.CRL = nil
+	return managed.ExternalCreation{}, c.applyCRL(ctx, cr, parameters.Name, crl)
 }
 
 func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
@@ -203,30 +334,175 @@ func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.Ext
 
 	c.log.Info("Updating Personal Security Environment", "name", cr.Name)
 
-	toAdd := certListDifference(parameters.CertificateRefs, observed.CertificateRefs)
-	toRemove := certListDifference(observed.CertificateRefs, parameters.CertificateRefs)
-
-	providerName, err := c.getX509ProviderName(ctx, parameters.X509ProviderRef)
+	providerName, err := c.getX509ProviderName(ctx, cr, parameters.X509ProviderRef)
 	if err != nil {
 		return managed.ExternalUpdate{}, fmt.Errorf("failed to get provider for pse: %w", err)
 	}
 
+	crl, err := c.getCRL(ctx, parameters.CRLSecretRef)
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
 	// Avoid setting the provider name if it hasn't changed
 	if providerName == cr.Status.AtProvider.X509ProviderName {
 		providerName = ""
 	}
 
-	if err := c.client.Update(ctx, parameters.Name, toAdd, toRemove, providerName); err != nil {
-		return managed.ExternalUpdate{}, err
+	if rotation := parameters.CertificateRotation; rotation != nil && rotation.Strategy == adminv1alpha1.RotationStrategyAddBeforeRemove {
+		if err := c.rotate(ctx, cr, parameters, observed, providerName); err != nil {
+			return managed.ExternalUpdate{}, err
+		}
+	} else {
+		toAdd := certListDifference(parameters.CertificateRefs, observed.CertificateRefs)
+		toRemove := certListDifference(observed.CertificateRefs, parameters.CertificateRefs)
+
+		if err := c.client.Update(ctx, parameters.Name, toAdd, toRemove, providerName); err != nil {
+			return managed.ExternalUpdate{}, err
+		}
+
+		cr.Status.AtProvider.CertificateRefs = parameters.CertificateRefs
 	}
 
-	cr.Status.AtProvider.CertificateRefs = parameters.CertificateRefs
+	if err := c.applyCRL(ctx, cr, parameters.Name, crl); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
 
 	return managed.ExternalUpdate{
 		ConnectionDetails: managed.ConnectionDetails{},
 	}, nil
 }
 
+// rotate applies certificate changes with the AddBeforeRemove strategy: new
+// certificates are added first and superseded ones are only dropped once the
+// additions succeeded, keeping up to KeepPrevious of them in the PSE.
+func (c *external) rotate(ctx context.Context, cr *adminv1alpha1.PersonalSecurityEnvironment, parameters *adminv1alpha1.PersonalSecurityEnvironmentParameters, observed *adminv1alpha1.PersonalSecurityEnvironmentObservation, providerName string) error {
+	if cr.Status.Rotation == nil {
+		cr.Status.Rotation = &adminv1alpha1.CertificateRotationStatus{}
+	}
+	status := cr.Status.Rotation
+
+	toAdd := rotationListDifference(parameters.CertificateRefs, observed.CertificateRefs)
+	if len(toAdd) > 0 {
+		status.Phase = adminv1alpha1.RotationPhaseAdding
+		if err := c.client.Update(ctx, parameters.Name, toAdd, nil, providerName); err != nil {
+			return err
+		}
+		providerName = ""
+	}
+
+	superseded := rotationListDifference(observed.CertificateRefs, parameters.CertificateRefs)
+	retained := keepPrevious(superseded, status.RetainedCertificateRefs, parameters.CertificateRotation.KeepPrevious)
+	toRemove := rotationListDifference(superseded, retained)
+
+	if len(toRemove) > 0 || providerName != "" {
+		status.Phase = adminv1alpha1.RotationPhaseRemoving
+		if err := c.client.Update(ctx, parameters.Name, nil, toRemove, providerName); err != nil {
+			return err
+		}
+	}
+
+	if len(toAdd) > 0 || len(toRemove) > 0 {
+		now := metav1.Now()
+		status.LastRotationTime = &now
+	}
+	status.Phase = adminv1alpha1.RotationPhaseComplete
+	status.RetainedCertificateRefs = retained
+	cr.Status.AtProvider.CertificateRefs = append(parameters.CertificateRefs, retained...)
+
+	return nil
+}
+
+// keepPrevious selects up to n superseded certificates to keep in the PSE.
+// Newly superseded certificates are preferred over previously retained ones.
+func keepPrevious(superseded, retained []adminv1alpha1.CertificateRef, n int) []adminv1alpha1.CertificateRef {
+	if n <= 0 {
+		return nil
+	}
+	candidates := rotationListDifference(superseded, retained)
+	for _, cert := range retained {
+		// Previously retained certificates that were dropped meanwhile are forgotten
+		if len(rotationListDifference([]adminv1alpha1.CertificateRef{cert}, superseded)) == 0 {
+			candidates = append(candidates, cert)
+		}
+	}
+	if len(candidates) > n {
+		candidates = candidates[:n]
+	}
+	return candidates
+}
+
+// retainedCertificates returns the superseded certificates the PSE is
+// expected to keep under its rotation strategy.
+func retainedCertificates(cr *adminv1alpha1.PersonalSecurityEnvironment) []adminv1alpha1.CertificateRef {
+	rotation := cr.Spec.ForProvider.CertificateRotation
+	if rotation == nil || rotation.Strategy != adminv1alpha1.RotationStrategyAddBeforeRemove || cr.Status.Rotation == nil {
+		return nil
+	}
+	return cr.Status.Rotation.RetainedCertificateRefs
+}
+
+// getCRL returns the PEM encoded CRL referenced by ref, or an empty string if
+// the PSE has no CRL.
+func (c *external) getCRL(ctx context.Context, ref *xpv1.SecretKeySelector) (string, error) {
+	if ref == nil {
+		return "", nil
+	}
+	secret := &corev1.Secret{}
+	if err := c.kube.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, secret); err != nil {
+		return "", fmt.Errorf(errGetCRLSecret, err)
+	}
+	crl, ok := secret.Data[ref.Key]
+	if !ok {
+		return "", fmt.Errorf(errCRLKeyNotFound, ref.Key, ref.Namespace, ref.Name)
+	}
+	if block, _ := pem.Decode(crl); block == nil || block.Type != "X509 CRL" {
+		return "", fmt.Errorf(errInvalidCRL, ref.Key, ref.Namespace, ref.Name)
+	}
+	return string(crl), nil
+}
+
+// applyCRL replaces the CRL of the PSE if it differs from the applied one and
+// records the applied CRL in the status. An empty crl drops the CRL.
+func (c *external) applyCRL(ctx context.Context, cr *adminv1alpha1.PersonalSecurityEnvironment, pseName, crl string) error {
+	digest := crlDigest(crl)
+	if digest == appliedCRL(cr) {
+		return nil
+	}
+
+	if appliedCRL(cr) != "" {
+		if err := c.client.DropCRL(ctx, pseName); err != nil {
+			return err
+		}
+		cr.Status.CRL = nil
+	}
+
+	if crl != "" {
+		if err := c.client.AddCRL(ctx, pseName, crl); err != nil {
+			return err
+		}
+		now := metav1.Now()
+		cr.Status.CRL = &adminv1alpha1.CRLStatus{SHA256: digest, LastUpdateTime: &now}
+	}
+	return nil
+}
+
+// appliedCRL returns the digest of the CRL applied to the PSE.
+func appliedCRL(cr *adminv1alpha1.PersonalSecurityEnvironment) string {
+	if cr.Status.CRL == nil {
+		return ""
+	}
+	return cr.Status.CRL.SHA256
+}
+
+func crlDigest(crl string) string {
+	if crl == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(crl))
+	return hex.EncodeToString(sum[:])
+}
+
 func (c *external) Delete(ctx context.Context, mg resource.Managed) (managed.ExternalDelete, error) {
 	cr, ok := mg.(*adminv1alpha1.PersonalSecurityEnvironment)
 	if !ok {
@@ -239,32 +515,66 @@ func (c *external) Delete(ctx context.Context, mg resource.Managed) (managed.Ext
 
 	cr.SetConditions(xpv1.Deleting())
 
+	// HANA refuses to drop a PSE while purposes are set for it. The X509
+	// purpose of the provider the PSE manages is unset along with it; any
+	// other purpose was set outside of the PSE and blocks its deletion.
+	purposes, err := c.client.Purposes(ctx, parameters.Name)
+	if err != nil {
+		return managed.ExternalDelete{}, fmt.Errorf(errPurposes, err)
+	}
+	if cr.GetAnnotations()[v1alpha1.AnnotationDeletionPolicy] != v1alpha1.DeletionPolicyCascade {
+		if blocking := foreignPurposes(purposes, cr.Status.AtProvider.X509ProviderName); len(blocking) > 0 {
+			cr.SetConditions(v1alpha1.PurposesSet(blocking))
+			return managed.ExternalDelete{}, fmt.Errorf(errPurposesSet, strings.Join(blocking, ", "))
+		}
+	}
+	if err := c.client.UnsetPurposes(ctx, parameters.Name, purposes); err != nil {
+		return managed.ExternalDelete{}, fmt.Errorf(errUnsetPurposes, err)
+	}
+
 	return managed.ExternalDelete{}, c.client.Delete(ctx, parameters)
 }
 
-func isUpToDate(p *adminv1alpha1.PersonalSecurityEnvironmentParameters, o adminv1alpha1.PersonalSecurityEnvironmentObservation, providerName string) bool {
-	return len(p.CertificateRefs) == len(o.CertificateRefs) &&
-		len(certListDifference(p.CertificateRefs, o.CertificateRefs)) == 0 &&
+// foreignPurposes returns the purposes other than the X509 purpose for the
+// provider the PSE manages.
+func foreignPurposes(purposes []personalsecurityenvironment.Purpose, providerName string) []string {
+	var foreign []string
+	for _, p := range purposes {
+		if p.Purpose == "X509" && p.Object == providerName {
+			continue
+		}
+		foreign = append(foreign, p.String())
+	}
+	return foreign
+}
+
+func isUpToDate(p *adminv1alpha1.PersonalSecurityEnvironmentParameters, o adminv1alpha1.PersonalSecurityEnvironmentObservation, providerName string, retained []adminv1alpha1.CertificateRef) bool {
+	difference := certListDifference
+	if p.CertificateRotation != nil && p.CertificateRotation.Strategy == adminv1alpha1.RotationStrategyAddBeforeRemove {
+		difference = rotationListDifference
+	}
+	desired := append(slices.Clone(p.CertificateRefs), retained...)
+	return len(desired) == len(o.CertificateRefs) &&
+		len(difference(desired, o.CertificateRefs)) == 0 &&
 		providerName == o.X509ProviderName &&
 		p.Name == o.Name
 }
 
-func (c *external) getX509ProviderName(ctx context.Context, ref *adminv1alpha1.X509ProviderRef) (string, error) {
+func (c *external) getX509ProviderName(ctx context.Context, cr *adminv1alpha1.PersonalSecurityEnvironment, ref *adminv1alpha1.X509ProviderRef) (string, error) {
 	if ref == nil {
 		return "", nil
 	}
 
+	provider, err := x509provider.Resolve(ctx, c.kube, cr, *ref)
 	switch {
-	case ref.ProviderRef != nil:
-		provider := adminv1alpha1.X509Provider{}
-		if err := c.kube.Get(ctx, types.NamespacedName{Name: ref.ProviderRef.Name}, &provider); err != nil {
-			return "", err
-		}
+	case err != nil:
+		return "", err
+	case provider != nil:
 		return provider.Spec.ForProvider.Name, nil
 	case ref.Name != "":
 		return ref.Name, nil
 	default:
-		return "", errors.New("X509ProviderRef must have either ProviderRef or Name specified")
+		return "", errors.New(errX509ProviderRefEmpty)
 	}
 }
 
@@ -290,3 +600,23 @@ func certDifferent(certA, certB adminv1alpha1.CertificateRef) bool {
 	return (certA.ID != nil && certB.ID != nil && *certA.ID == *certB.ID) ||
 		(certA.Name != nil && certB.Name != nil && *certA.Name != "" && *certA.Name == *certB.Name)
 }
+
+// rotationListDifference returns the certificates that are in 'a' but not in 'b'.
+// A renewed certificate keeps its name but gets a new ID, so IDs take
+// precedence over names when both sides have one.
+func rotationListDifference(a, b []adminv1alpha1.CertificateRef) []adminv1alpha1.CertificateRef {
+	var diff []adminv1alpha1.CertificateRef
+	for _, certA := range a {
+		if !slices.ContainsFunc(b, func(certB adminv1alpha1.CertificateRef) bool { return sameRotatedCert(certA, certB) }) {
+			diff = append(diff, certA)
+		}
+	}
+	return diff
+}
+
+func sameRotatedCert(certA, certB adminv1alpha1.CertificateRef) bool {
+	if certA.ID != nil && certB.ID != nil {
+		return *certA.ID == *certB.ID
+	}
+	return certDifferent(certA, certB)
+}
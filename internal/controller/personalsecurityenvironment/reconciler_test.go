@@ -16,11 +16,18 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 	"github.com/crossplane/crossplane-runtime/pkg/test"
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	"github.com/SAP/crossplane-provider-hana/apis/admin/v1alpha1"
+	apisv1alpha1 "github.com/SAP/crossplane-provider-hana/apis/v1alpha1"
 	"github.com/SAP/crossplane-provider-hana/internal/clients/hana/personalsecurityenvironment"
+	"github.com/SAP/crossplane-provider-hana/internal/controller/externalname"
+	"github.com/SAP/crossplane-provider-hana/internal/controller/x509provider"
 )
 
 // Unlike many Kubernetes projects Crossplane does not use third party testing
@@ -31,7 +38,26 @@ import (
 // https://github.com/golang/go/wiki/TestComments
 // https://github.com/crossplane/crossplane/blob/master/CONTRIBUTING.md#contributing-code
 
-const testProvider = "test-provider"
+const (
+	testProvider = "test-provider"
+	testCRL      = "-----BEGIN X509 CRL-----\nMAA=\n-----END X509 CRL-----\n"
+)
+
+var testCRLSecretRef = &xpv1.SecretKeySelector{
+	SecretReference: xpv1.SecretReference{Name: "test-crl", Namespace: "crossplane-system"},
+	Key:             "crl.pem",
+}
+
+// withCRL returns a MockGet object function that fills a Secret with the
+// supplied CRL.
+func withCRL(crl string) test.ObjectFn {
+	return func(obj client.Object) error {
+		if secret, ok := obj.(*corev1.Secret); ok {
+			secret.Data = map[string][]byte{"crl.pem": []byte(crl)}
+		}
+		return nil
+	}
+}
 
 func TestObserve(t *testing.T) {
 	errBoom := errors.New("boom")
@@ -175,8 +201,9 @@ func TestObserve(t *testing.T) {
 			},
 			want: want{
 				o: managed.ExternalObservation{
-					ResourceExists:   true,
-					ResourceUpToDate: true,
+					ResourceExists:    true,
+					ResourceUpToDate:  true,
+					ConnectionDetails: externalname.ConnectionDetails("test-pse"),
 				},
 			},
 		},
@@ -226,11 +253,136 @@ func TestObserve(t *testing.T) {
 			},
 			want: want{
 				o: managed.ExternalObservation{
-					ResourceExists:   true,
-					ResourceUpToDate: false,
+					ResourceExists:    true,
+					ResourceUpToDate:  false,
+					ConnectionDetails: externalname.ConnectionDetails("test-pse"),
 				},
 			},
 		},
+		"SuccessProviderSelector": {
+			reason: "Should resolve the X509Provider by label selector",
+			fields: fields{
+				client: &mockPersonalSecurityEnvironmentClient{
+					MockRead: func(ctx context.Context, parameters *v1alpha1.PersonalSecurityEnvironmentParameters) (*v1alpha1.PersonalSecurityEnvironmentObservation, error) {
+						return &v1alpha1.PersonalSecurityEnvironmentObservation{
+							Name:             "test-pse",
+							X509ProviderName: testProvider,
+						}, nil
+					},
+				},
+				kube: &test.MockClient{
+					MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
+						providers := obj.(*v1alpha1.X509ProviderList)
+						providers.Items = append(providers.Items, v1alpha1.X509Provider{
+							Spec: v1alpha1.X509ProviderSpec{
+								ForProvider: v1alpha1.X509ProviderParameters{Name: testProvider},
+							},
+						})
+						return nil
+					}),
+				},
+				log: &mockLogger{},
+			},
+			args: args{
+				mg: &v1alpha1.PersonalSecurityEnvironment{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "test-pse",
+					},
+					Spec: v1alpha1.PersonalSecurityEnvironmentSpec{
+						ForProvider: v1alpha1.PersonalSecurityEnvironmentParameters{
+							Name: "test-pse",
+							X509ProviderRef: &v1alpha1.X509ProviderRef{
+								ProviderSelector: &xpv1.Selector{MatchLabels: map[string]string{"team": "a"}},
+							},
+						},
+					},
+				},
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  true,
+					ConnectionDetails: externalname.ConnectionDetails("test-pse"),
+				},
+			},
+		},
+		"ErrProviderSelectorNoMatch": {
+			reason: "Should return error when no X509Provider matches the selector",
+			fields: fields{
+				client: &mockPersonalSecurityEnvironmentClient{
+					MockRead: func(ctx context.Context, parameters *v1alpha1.PersonalSecurityEnvironmentParameters) (*v1alpha1.PersonalSecurityEnvironmentObservation, error) {
+						return &v1alpha1.PersonalSecurityEnvironmentObservation{
+							Name:             "test-pse",
+							X509ProviderName: testProvider,
+						}, nil
+					},
+				},
+				kube: &test.MockClient{
+					MockList: test.NewMockListFn(nil),
+				},
+				log: &mockLogger{},
+			},
+			args: args{
+				mg: &v1alpha1.PersonalSecurityEnvironment{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "test-pse",
+					},
+					Spec: v1alpha1.PersonalSecurityEnvironmentSpec{
+						ForProvider: v1alpha1.PersonalSecurityEnvironmentParameters{
+							Name: "test-pse",
+							X509ProviderRef: &v1alpha1.X509ProviderRef{
+								ProviderSelector: &xpv1.Selector{MatchLabels: map[string]string{"team": "a"}},
+							},
+						},
+					},
+				},
+			},
+			want: want{
+				err: fmt.Errorf("failed to get provider for pse: %w", x509provider.ErrNoneSelected),
+			},
+		},
+		"ErrProviderSelectorAmbiguous": {
+			reason: "Should return error when more than one X509Provider matches the selector",
+			fields: fields{
+				client: &mockPersonalSecurityEnvironmentClient{
+					MockRead: func(ctx context.Context, parameters *v1alpha1.PersonalSecurityEnvironmentParameters) (*v1alpha1.PersonalSecurityEnvironmentObservation, error) {
+						return &v1alpha1.PersonalSecurityEnvironmentObservation{
+							Name:             "test-pse",
+							X509ProviderName: testProvider,
+						}, nil
+					},
+				},
+				kube: &test.MockClient{
+					MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
+						providers := obj.(*v1alpha1.X509ProviderList)
+						providers.Items = []v1alpha1.X509Provider{
+							{ObjectMeta: metav1.ObjectMeta{Name: "idp-b"}},
+							{ObjectMeta: metav1.ObjectMeta{Name: "idp-a"}},
+						}
+						return nil
+					}),
+				},
+				log: &mockLogger{},
+			},
+			args: args{
+				mg: &v1alpha1.PersonalSecurityEnvironment{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "test-pse",
+					},
+					Spec: v1alpha1.PersonalSecurityEnvironmentSpec{
+						ForProvider: v1alpha1.PersonalSecurityEnvironmentParameters{
+							Name: "test-pse",
+							X509ProviderRef: &v1alpha1.X509ProviderRef{
+								ProviderSelector: &xpv1.Selector{MatchLabels: map[string]string{"team": "a"}},
+							},
+						},
+					},
+				},
+			},
+			want: want{
+				err: fmt.Errorf("failed to get provider for pse: %w", errors.New("more than one X509Provider matches the providerSelector: idp-a, idp-b")),
+			},
+		},
 		"ErrGetProviderName": {
 			reason: "Should return error when getting provider name fails",
 			fields: fields{
@@ -264,7 +416,74 @@ func TestObserve(t *testing.T) {
 				},
 			},
 			want: want{
-				err: fmt.Errorf("failed to get provider for pse: %w", errBoom),
+				err: fmt.Errorf("failed to get provider for pse: %w", fmt.Errorf("cannot get X509Provider test-provider-ref: %w", errBoom)),
+			},
+		},
+		"SuccessCRLOutOfDate": {
+			reason: "Should report the PSE out of date when the referenced CRL was not applied",
+			fields: fields{
+				client: &mockPersonalSecurityEnvironmentClient{
+					MockRead: func(ctx context.Context, parameters *v1alpha1.PersonalSecurityEnvironmentParameters) (*v1alpha1.PersonalSecurityEnvironmentObservation, error) {
+						return &v1alpha1.PersonalSecurityEnvironmentObservation{Name: "test-pse"}, nil
+					},
+				},
+				kube: &test.MockClient{
+					MockGet: test.NewMockGetFn(nil, withCRL(testCRL)),
+				},
+				log: &mockLogger{},
+			},
+			args: args{
+				mg: &v1alpha1.PersonalSecurityEnvironment{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "test-pse",
+					},
+					Spec: v1alpha1.PersonalSecurityEnvironmentSpec{
+						ForProvider: v1alpha1.PersonalSecurityEnvironmentParameters{
+							Name:         "test-pse",
+							CRLSecretRef: testCRLSecretRef,
+						},
+					},
+					Status: v1alpha1.PersonalSecurityEnvironmentStatus{
+						CRL: &v1alpha1.CRLStatus{SHA256: crlDigest("previous")},
+					},
+				},
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  false,
+					ConnectionDetails: externalname.ConnectionDetails("test-pse"),
+				},
+			},
+		},
+		"ErrInvalidCRL": {
+			reason: "Should return error when the referenced Secret does not hold a PEM encoded CRL",
+			fields: fields{
+				client: &mockPersonalSecurityEnvironmentClient{
+					MockRead: func(ctx context.Context, parameters *v1alpha1.PersonalSecurityEnvironmentParameters) (*v1alpha1.PersonalSecurityEnvironmentObservation, error) {
+						return &v1alpha1.PersonalSecurityEnvironmentObservation{Name: "test-pse"}, nil
+					},
+				},
+				kube: &test.MockClient{
+					MockGet: test.NewMockGetFn(nil, withCRL("not a crl")),
+				},
+				log: &mockLogger{},
+			},
+			args: args{
+				mg: &v1alpha1.PersonalSecurityEnvironment{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "test-pse",
+					},
+					Spec: v1alpha1.PersonalSecurityEnvironmentSpec{
+						ForProvider: v1alpha1.PersonalSecurityEnvironmentParameters{
+							Name:         "test-pse",
+							CRLSecretRef: testCRLSecretRef,
+						},
+					},
+				},
+			},
+			want: want{
+				err: fmt.Errorf(errInvalidCRL, "crl.pem", "crossplane-system", "test-crl"),
 			},
 		},
 	}
@@ -346,7 +565,7 @@ func TestCreate(t *testing.T) {
 				},
 			},
 			want: want{
-				err: fmt.Errorf("failed to get provider for pse: %w", errBoom),
+				err: fmt.Errorf("failed to get provider for pse: %w", fmt.Errorf("cannot get X509Provider test-provider-ref: %w", errBoom)),
 			},
 		},
 		"ErrCreate": {
@@ -596,6 +815,144 @@ func TestUpdate(t *testing.T) {
 	}
 }
 
+func TestRotate(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type update struct {
+		toAdd    []v1alpha1.CertificateRef
+		toRemove []v1alpha1.CertificateRef
+	}
+
+	type args struct {
+		keepPrevious int
+		spec         []v1alpha1.CertificateRef
+		observed     []v1alpha1.CertificateRef
+		retained     []v1alpha1.CertificateRef
+		updateErr    error
+	}
+
+	type want struct {
+		err      error
+		updates  []update
+		phase    string
+		retained []v1alpha1.CertificateRef
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"RenewedCertificate": {
+			reason: "A certificate with a new ID for the same name should be added before the old one is dropped",
+			args: args{
+				spec:     []v1alpha1.CertificateRef{{ID: new(2), Name: new("cert1")}},
+				observed: []v1alpha1.CertificateRef{{ID: new(1), Name: new("cert1")}},
+			},
+			want: want{
+				updates: []update{
+					{toAdd: []v1alpha1.CertificateRef{{ID: new(2), Name: new("cert1")}}},
+					{toRemove: []v1alpha1.CertificateRef{{ID: new(1), Name: new("cert1")}}},
+				},
+				phase: v1alpha1.RotationPhaseComplete,
+			},
+		},
+		"KeepPrevious": {
+			reason: "Up to keepPrevious superseded certificates should stay in the PSE, newest first",
+			args: args{
+				keepPrevious: 1,
+				spec:         []v1alpha1.CertificateRef{{ID: new(3), Name: new("cert1")}},
+				observed: []v1alpha1.CertificateRef{
+					{ID: new(1), Name: new("cert1")},
+					{ID: new(2), Name: new("cert1")},
+				},
+				retained: []v1alpha1.CertificateRef{{ID: new(1), Name: new("cert1")}},
+			},
+			want: want{
+				updates: []update{
+					{toAdd: []v1alpha1.CertificateRef{{ID: new(3), Name: new("cert1")}}},
+					{toRemove: []v1alpha1.CertificateRef{{ID: new(1), Name: new("cert1")}}},
+				},
+				phase:    v1alpha1.RotationPhaseComplete,
+				retained: []v1alpha1.CertificateRef{{ID: new(2), Name: new("cert1")}},
+			},
+		},
+		"ErrAdd": {
+			reason: "Superseded certificates should not be dropped if adding the new ones fails",
+			args: args{
+				spec:      []v1alpha1.CertificateRef{{ID: new(2), Name: new("cert1")}},
+				observed:  []v1alpha1.CertificateRef{{ID: new(1), Name: new("cert1")}},
+				updateErr: errBoom,
+			},
+			want: want{
+				err: errBoom,
+				updates: []update{
+					{toAdd: []v1alpha1.CertificateRef{{ID: new(2), Name: new("cert1")}}},
+				},
+				phase: v1alpha1.RotationPhaseAdding,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var updates []update
+			e := external{
+				client: &mockPersonalSecurityEnvironmentClient{
+					MockUpdate: func(ctx context.Context, pseName string, toAdd, toRemove []v1alpha1.CertificateRef, providerName string) error {
+						updates = append(updates, update{toAdd: toAdd, toRemove: toRemove})
+						return tc.args.updateErr
+					},
+				},
+				kube: &test.MockClient{},
+				log:  &mockLogger{},
+			}
+			cr := &v1alpha1.PersonalSecurityEnvironment{
+				Spec: v1alpha1.PersonalSecurityEnvironmentSpec{
+					ForProvider: v1alpha1.PersonalSecurityEnvironmentParameters{
+						Name:            "test-pse",
+						X509ProviderRef: &v1alpha1.X509ProviderRef{Name: testProvider},
+						CertificateRefs: tc.args.spec,
+						CertificateRotation: &v1alpha1.CertificateRotation{
+							Strategy:     v1alpha1.RotationStrategyAddBeforeRemove,
+							KeepPrevious: tc.args.keepPrevious,
+						},
+					},
+				},
+				Status: v1alpha1.PersonalSecurityEnvironmentStatus{
+					AtProvider: v1alpha1.PersonalSecurityEnvironmentObservation{
+						Name:             "test-pse",
+						X509ProviderName: testProvider,
+						CertificateRefs:  tc.args.observed,
+					},
+					Rotation: &v1alpha1.CertificateRotationStatus{
+						RetainedCertificateRefs: tc.args.retained,
+					},
+				},
+			}
+			_, err := e.Update(context.Background(), cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.updates, updates, cmp.AllowUnexported(update{})); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want updates, +got updates:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.phase, cr.Status.Rotation.Phase); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want phase, +got phase:\n%s\n", tc.reason, diff)
+			}
+			if tc.want.err == nil {
+				if diff := cmp.Diff(tc.want.retained, cr.Status.Rotation.RetainedCertificateRefs); diff != "" {
+					t.Errorf("\n%s\ne.Update(...): -want retained, +got retained:\n%s\n", tc.reason, diff)
+				}
+				upToDate := isUpToDate(&cr.Spec.ForProvider, cr.Status.AtProvider, testProvider, retainedCertificates(cr))
+				if !upToDate {
+					t.Errorf("\n%s\ne.Update(...): PSE should be up to date after rotation", tc.reason)
+				}
+			}
+		})
+	}
+}
+
 func TestDelete(t *testing.T) {
 	errBoom := errors.New("boom")
 
@@ -611,7 +968,8 @@ func TestDelete(t *testing.T) {
 	}
 
 	type want struct {
-		err error
+		err     error
+		blocked bool
 	}
 
 	cases := map[string]struct {
@@ -660,6 +1018,154 @@ func TestDelete(t *testing.T) {
 				err: errBoom,
 			},
 		},
+		"ErrPurposes": {
+			reason: "Any errors encountered while querying the purposes of the PersonalSecurityEnvironment should be returned",
+			fields: fields{
+				client: &mockPersonalSecurityEnvironmentClient{
+					MockPurposes: func(ctx context.Context, pseName string) ([]personalsecurityenvironment.Purpose, error) {
+						return nil, errBoom
+					},
+				},
+				kube: &test.MockClient{},
+				log:  &mockLogger{},
+			},
+			args: args{
+				mg: &v1alpha1.PersonalSecurityEnvironment{
+					Spec: v1alpha1.PersonalSecurityEnvironmentSpec{
+						ForProvider: v1alpha1.PersonalSecurityEnvironmentParameters{Name: "test-pse"},
+					},
+				},
+			},
+			want: want{
+				err: fmt.Errorf(errPurposes, errBoom),
+			},
+		},
+		"ForeignPurposesBlock": {
+			reason: "Purposes set outside of the PersonalSecurityEnvironment should block its deletion",
+			fields: fields{
+				client: &mockPersonalSecurityEnvironmentClient{
+					MockPurposes: func(ctx context.Context, pseName string) ([]personalsecurityenvironment.Purpose, error) {
+						return []personalsecurityenvironment.Purpose{
+							{Purpose: "X509", Object: "idp"},
+							{Purpose: "X509", Object: "other-idp"},
+							{Purpose: "REMOTE SOURCE", Object: "rs"},
+						}, nil
+					},
+					MockUnsetPurposes: func(ctx context.Context, pseName string, purposes []personalsecurityenvironment.Purpose) error {
+						return errBoom
+					},
+					MockDelete: func(ctx context.Context, parameters *v1alpha1.PersonalSecurityEnvironmentParameters) error {
+						return errBoom
+					},
+				},
+				kube: &test.MockClient{},
+				log:  &mockLogger{},
+			},
+			args: args{
+				mg: &v1alpha1.PersonalSecurityEnvironment{
+					Spec: v1alpha1.PersonalSecurityEnvironmentSpec{
+						ForProvider: v1alpha1.PersonalSecurityEnvironmentParameters{Name: "test-pse"},
+					},
+					Status: v1alpha1.PersonalSecurityEnvironmentStatus{
+						AtProvider: v1alpha1.PersonalSecurityEnvironmentObservation{X509ProviderName: "idp"},
+					},
+				},
+			},
+			want: want{
+				err:     fmt.Errorf(errPurposesSet, "X509 FOR other-idp, REMOTE SOURCE FOR rs"),
+				blocked: true,
+			},
+		},
+		"ManagedPurposeUnset": {
+			reason: "The X509 purpose of the provider the PersonalSecurityEnvironment manages should be unset before it is dropped",
+			fields: fields{
+				client: &mockPersonalSecurityEnvironmentClient{
+					MockPurposes: func(ctx context.Context, pseName string) ([]personalsecurityenvironment.Purpose, error) {
+						return []personalsecurityenvironment.Purpose{{Purpose: "X509", Object: "idp"}}, nil
+					},
+					MockUnsetPurposes: func(ctx context.Context, pseName string, purposes []personalsecurityenvironment.Purpose) error {
+						if diff := cmp.Diff([]personalsecurityenvironment.Purpose{{Purpose: "X509", Object: "idp"}}, purposes); diff != "" {
+							return fmt.Errorf("unexpected purposes: %s", diff)
+						}
+						return nil
+					},
+				},
+				kube: &test.MockClient{},
+				log:  &mockLogger{},
+			},
+			args: args{
+				mg: &v1alpha1.PersonalSecurityEnvironment{
+					Spec: v1alpha1.PersonalSecurityEnvironmentSpec{
+						ForProvider: v1alpha1.PersonalSecurityEnvironmentParameters{Name: "test-pse"},
+					},
+					Status: v1alpha1.PersonalSecurityEnvironmentStatus{
+						AtProvider: v1alpha1.PersonalSecurityEnvironmentObservation{X509ProviderName: "idp"},
+					},
+				},
+			},
+			want: want{
+				err: nil,
+			},
+		},
+		"CascadeUnsetsAllPurposes": {
+			reason: "With the cascade deletion policy all purposes should be unset before the PersonalSecurityEnvironment is dropped",
+			fields: fields{
+				client: &mockPersonalSecurityEnvironmentClient{
+					MockPurposes: func(ctx context.Context, pseName string) ([]personalsecurityenvironment.Purpose, error) {
+						return []personalsecurityenvironment.Purpose{{Purpose: "REMOTE SOURCE", Object: "rs"}}, nil
+					},
+					MockUnsetPurposes: func(ctx context.Context, pseName string, purposes []personalsecurityenvironment.Purpose) error {
+						if len(purposes) != 1 {
+							return fmt.Errorf("unexpected purposes: %v", purposes)
+						}
+						return nil
+					},
+				},
+				kube: &test.MockClient{},
+				log:  &mockLogger{},
+			},
+			args: args{
+				mg: &v1alpha1.PersonalSecurityEnvironment{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{apisv1alpha1.AnnotationDeletionPolicy: apisv1alpha1.DeletionPolicyCascade},
+					},
+					Spec: v1alpha1.PersonalSecurityEnvironmentSpec{
+						ForProvider: v1alpha1.PersonalSecurityEnvironmentParameters{Name: "test-pse"},
+					},
+				},
+			},
+			want: want{
+				err: nil,
+			},
+		},
+		"ErrUnsetPurposes": {
+			reason: "Any errors encountered while unsetting the purposes should be returned",
+			fields: fields{
+				client: &mockPersonalSecurityEnvironmentClient{
+					MockPurposes: func(ctx context.Context, pseName string) ([]personalsecurityenvironment.Purpose, error) {
+						return []personalsecurityenvironment.Purpose{{Purpose: "X509", Object: "idp"}}, nil
+					},
+					MockUnsetPurposes: func(ctx context.Context, pseName string, purposes []personalsecurityenvironment.Purpose) error {
+						return errBoom
+					},
+				},
+				kube: &test.MockClient{},
+				log:  &mockLogger{},
+			},
+			args: args{
+				mg: &v1alpha1.PersonalSecurityEnvironment{
+					Spec: v1alpha1.PersonalSecurityEnvironmentSpec{
+						ForProvider: v1alpha1.PersonalSecurityEnvironmentParameters{Name: "test-pse"},
+					},
+					Status: v1alpha1.PersonalSecurityEnvironmentStatus{
+						AtProvider: v1alpha1.PersonalSecurityEnvironmentObservation{X509ProviderName: "idp"},
+					},
+				},
+			},
+			want: want{
+				err: fmt.Errorf(errUnsetPurposes, errBoom),
+			},
+		},
 		"Success": {
 			reason: "No error should be returned when we successfully delete a PersonalSecurityEnvironment",
 			fields: fields{
@@ -697,6 +1203,12 @@ func TestDelete(t *testing.T) {
 			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
 				t.Errorf("\n%s\ne.Delete(...): -want error, +got error:\n%s\n", tc.reason, diff)
 			}
+			if cr, ok := tc.args.mg.(*v1alpha1.PersonalSecurityEnvironment); ok {
+				blocked := cr.GetCondition(apisv1alpha1.TypeDeletionBlocked).Reason == apisv1alpha1.ReasonPurposesSet
+				if blocked != tc.want.blocked {
+					t.Errorf("\n%s\ne.Delete(...): want blocked %t, got %t\n", tc.reason, tc.want.blocked, blocked)
+				}
+			}
 		})
 	}
 }
@@ -718,10 +1230,15 @@ func (l *mockLogger) WithValues(_ ...any) logging.Logger { return l }
 
 // mockPersonalSecurityEnvironmentClient implements the personalsecurityenvironment.PersonalSecurityEnvironmentClient interface for testing
 type mockPersonalSecurityEnvironmentClient struct {
-	MockRead   func(ctx context.Context, parameters *v1alpha1.PersonalSecurityEnvironmentParameters) (*v1alpha1.PersonalSecurityEnvironmentObservation, error)
-	MockCreate func(ctx context.Context, parameters *v1alpha1.PersonalSecurityEnvironmentParameters, providerName string) error
-	MockUpdate func(ctx context.Context, pseName string, toAdd, toRemove []v1alpha1.CertificateRef, providerName string) error
-	MockDelete func(ctx context.Context, parameters *v1alpha1.PersonalSecurityEnvironmentParameters) error
+	MockRead    func(ctx context.Context, parameters *v1alpha1.PersonalSecurityEnvironmentParameters) (*v1alpha1.PersonalSecurityEnvironmentObservation, error)
+	MockCreate  func(ctx context.Context, parameters *v1alpha1.PersonalSecurityEnvironmentParameters, providerName string) error
+	MockUpdate  func(ctx context.Context, pseName string, toAdd, toRemove []v1alpha1.CertificateRef, providerName string) error
+	MockDelete  func(ctx context.Context, parameters *v1alpha1.PersonalSecurityEnvironmentParameters) error
+	MockAddCRL  func(ctx context.Context, pseName, crl string) error
+	MockDropCRL func(ctx context.Context, pseName string) error
+
+	MockPurposes      func(ctx context.Context, pseName string) ([]personalsecurityenvironment.Purpose, error)
+	MockUnsetPurposes func(ctx context.Context, pseName string, purposes []personalsecurityenvironment.Purpose) error
 }
 
 func (m *mockPersonalSecurityEnvironmentClient) Read(ctx context.Context, parameters *v1alpha1.PersonalSecurityEnvironmentParameters) (*v1alpha1.PersonalSecurityEnvironmentObservation, error) {
@@ -752,6 +1269,111 @@ func (m *mockPersonalSecurityEnvironmentClient) Delete(ctx context.Context, para
 	return nil
 }
 
+func (m *mockPersonalSecurityEnvironmentClient) AddCRL(ctx context.Context, pseName, crl string) error {
+	if m.MockAddCRL != nil {
+		return m.MockAddCRL(ctx, pseName, crl)
+	}
+	return nil
+}
+
+func (m *mockPersonalSecurityEnvironmentClient) DropCRL(ctx context.Context, pseName string) error {
+	if m.MockDropCRL != nil {
+		return m.MockDropCRL(ctx, pseName)
+	}
+	return nil
+}
+
+func TestApplyCRL(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type want struct {
+		status *v1alpha1.CRLStatus
+		calls  []string
+		err    error
+	}
+
+	cases := map[string]struct {
+		reason  string
+		applied *v1alpha1.CRLStatus
+		crl     string
+		addErr  error
+		want    want
+	}{
+		"Add": {
+			reason: "A referenced CRL should be added to a PSE without one",
+			crl:    testCRL,
+			want: want{
+				status: &v1alpha1.CRLStatus{SHA256: crlDigest(testCRL)},
+				calls:  []string{"add"},
+			},
+		},
+		"Replace": {
+			reason:  "A changed CRL should replace the applied one",
+			applied: &v1alpha1.CRLStatus{SHA256: crlDigest("previous")},
+			crl:     testCRL,
+			want: want{
+				status: &v1alpha1.CRLStatus{SHA256: crlDigest(testCRL)},
+				calls:  []string{"drop", "add"},
+			},
+		},
+		"Drop": {
+			reason:  "The applied CRL should be dropped once it is no longer referenced",
+			applied: &v1alpha1.CRLStatus{SHA256: crlDigest(testCRL)},
+			want: want{
+				calls: []string{"drop"},
+			},
+		},
+		"Unchanged": {
+			reason:  "An applied CRL should not be touched",
+			applied: &v1alpha1.CRLStatus{SHA256: crlDigest(testCRL)},
+			crl:     testCRL,
+			want: want{
+				status: &v1alpha1.CRLStatus{SHA256: crlDigest(testCRL)},
+			},
+		},
+		"ErrAdd": {
+			reason:  "A failed replacement should not report the previous CRL as applied",
+			applied: &v1alpha1.CRLStatus{SHA256: crlDigest("previous")},
+			crl:     testCRL,
+			addErr:  errBoom,
+			want: want{
+				calls: []string{"drop", "add"},
+				err:   errBoom,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var calls []string
+			e := external{
+				client: &mockPersonalSecurityEnvironmentClient{
+					MockAddCRL: func(ctx context.Context, pseName, crl string) error {
+						calls = append(calls, "add")
+						return tc.addErr
+					},
+					MockDropCRL: func(ctx context.Context, pseName string) error {
+						calls = append(calls, "drop")
+						return nil
+					},
+				},
+				log: &mockLogger{},
+			}
+			cr := &v1alpha1.PersonalSecurityEnvironment{Status: v1alpha1.PersonalSecurityEnvironmentStatus{CRL: tc.applied}}
+			err := e.applyCRL(context.Background(), cr, "test-pse", tc.crl)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.applyCRL(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.calls, calls); diff != "" {
+				t.Errorf("\n%s\ne.applyCRL(...): -want calls, +got calls:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.status, cr.Status.CRL, cmpopts.IgnoreFields(v1alpha1.CRLStatus{}, "LastUpdateTime")); diff != "" {
+				t.Errorf("\n%s\ne.applyCRL(...): -want status, +got status:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
 func TestCertListDifference(t *testing.T) {
 	type args struct {
 		a []v1alpha1.CertificateRef
@@ -967,3 +1589,133 @@ func TestCertListDifference(t *testing.T) {
 		})
 	}
 }
+
+func TestGenerateReconcileRequestsFromX509Provider(t *testing.T) {
+	provider := &v1alpha1.X509Provider{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "test-provider-ref",
+			Labels: map[string]string{"team": "a"},
+		},
+	}
+	pseByRef := v1alpha1.PersonalSecurityEnvironment{
+		ObjectMeta: metav1.ObjectMeta{Name: "pse-by-ref"},
+		Spec: v1alpha1.PersonalSecurityEnvironmentSpec{
+			ForProvider: v1alpha1.PersonalSecurityEnvironmentParameters{
+				X509ProviderRef: &v1alpha1.X509ProviderRef{
+					ProviderRef: &xpv1.Reference{Name: "test-provider-ref"},
+				},
+			},
+		},
+	}
+	pseBySelector := v1alpha1.PersonalSecurityEnvironment{
+		ObjectMeta: metav1.ObjectMeta{Name: "pse-by-selector"},
+		Spec: v1alpha1.PersonalSecurityEnvironmentSpec{
+			ForProvider: v1alpha1.PersonalSecurityEnvironmentParameters{
+				X509ProviderRef: &v1alpha1.X509ProviderRef{
+					ProviderSelector: &xpv1.Selector{MatchLabels: map[string]string{"team": "a"}},
+				},
+			},
+		},
+	}
+	pseOther := v1alpha1.PersonalSecurityEnvironment{
+		ObjectMeta: metav1.ObjectMeta{Name: "pse-other"},
+		Spec: v1alpha1.PersonalSecurityEnvironmentSpec{
+			ForProvider: v1alpha1.PersonalSecurityEnvironmentParameters{
+				X509ProviderRef: &v1alpha1.X509ProviderRef{
+					ProviderSelector: &xpv1.Selector{MatchLabels: map[string]string{"team": "b"}},
+				},
+			},
+		},
+	}
+	pseByName := v1alpha1.PersonalSecurityEnvironment{
+		ObjectMeta: metav1.ObjectMeta{Name: "pse-by-name"},
+		Spec: v1alpha1.PersonalSecurityEnvironmentSpec{
+			ForProvider: v1alpha1.PersonalSecurityEnvironmentParameters{
+				X509ProviderRef: &v1alpha1.X509ProviderRef{Name: testProvider},
+			},
+		},
+	}
+
+	errBoom := errors.New("boom")
+
+	type args struct {
+		kube client.Client
+		obj  client.Object
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   []reconcile.Request
+		logMsg string
+	}{
+		"ErrNotX509Provider": {
+			reason: "An empty Request should be returned if the resource is not an *X509Provider",
+			args: args{
+				kube: &test.MockClient{},
+				obj:  nil,
+			},
+			want:   []reconcile.Request{},
+			logMsg: msgNotValidX509Provider,
+		},
+		"ErrListPSEs": {
+			reason: "An empty Request should be returned if we can't list the PSEs",
+			args: args{
+				kube: &test.MockClient{
+					MockList: test.NewMockListFn(errBoom),
+				},
+				obj: provider,
+			},
+			want:   []reconcile.Request{},
+			logMsg: msgListFailed,
+		},
+		"MatchingPSEs": {
+			reason: "PSEs referencing the provider by reference or matching selector should be enqueued",
+			args: args{
+				kube: &test.MockClient{
+					MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
+						pses := obj.(*v1alpha1.PersonalSecurityEnvironmentList)
+						pses.Items = append(pses.Items, pseByRef, pseBySelector, pseOther, pseByName)
+						return nil
+					}),
+				},
+				obj: provider,
+			},
+			want: []reconcile.Request{
+				{NamespacedName: types.NamespacedName{Name: "pse-by-ref"}},
+				{NamespacedName: types.NamespacedName{Name: "pse-by-selector"}},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			log := &mockLogger{}
+			got := generateReconcileRequestsFromX509Provider(context.Background(), tc.args.obj, tc.args.kube, log)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\ngenerateReconcileRequestsFromX509Provider(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if tc.logMsg != "" {
+				if len(log.msgs) == 0 {
+					t.Errorf("\n%s\ngenerateReconcileRequestsFromX509Provider(...): expected log message: %s, got none", tc.reason, tc.logMsg)
+				} else if gotMsg := log.msgs[len(log.msgs)-1]; gotMsg != tc.logMsg {
+					t.Errorf("\n%s\ngenerateReconcileRequestsFromX509Provider(...): -want log message, +got log message:\n-%s\n+%s\n", tc.reason, tc.logMsg, gotMsg)
+				}
+			}
+		})
+	}
+}
+
+func (m *mockPersonalSecurityEnvironmentClient) Purposes(ctx context.Context, pseName string) ([]personalsecurityenvironment.Purpose, error) {
+	if m.MockPurposes != nil {
+		return m.MockPurposes(ctx, pseName)
+	}
+	return nil, nil
+}
+
+func (m *mockPersonalSecurityEnvironmentClient) UnsetPurposes(ctx context.Context, pseName string, purposes []personalsecurityenvironment.Purpose) error {
+	if m.MockUnsetPurposes != nil {
+		return m.MockUnsetPurposes(ctx, pseName, purposes)
+	}
+	return nil
+}
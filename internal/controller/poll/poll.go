@@ -0,0 +1,61 @@
+/*
+Copyright 2026 SAP SE or an SAP affiliate company and contributors.
+*/
+
+// Package poll spreads the polls of managed resources over time, so that
+// resources sharing a poll interval do not query HANA in bursts.
+package poll
+
+import (
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+)
+
+var (
+	mu      sync.RWMutex
+	jitters = map[string]time.Duration{}
+)
+
+// SetJitter sets the jitter of the poll interval of the controller of the
+// kind. It must be set before the controller is set up.
+func SetJitter(kind string, jitter time.Duration) {
+	mu.Lock()
+	defer mu.Unlock()
+	jitters[kind] = jitter
+}
+
+// Jitter returns the jitter of the poll interval of the controller of the
+// kind, zero if none is set.
+func Jitter(kind string) time.Duration {
+	mu.RLock()
+	defer mu.RUnlock()
+	return jitters[kind]
+}
+
+// WithJitterHook returns a reconciler option adding the jitter of the
+// controller of the kind to the poll interval. The poll interval is the one
+// returned by hook, or the one of the reconciler if hook is nil.
+func WithJitterHook(kind string, hook managed.PollIntervalHook) managed.ReconcilerOption {
+	return managed.WithPollIntervalHook(JitterHook(Jitter(kind), hook))
+}
+
+// JitterHook returns a hook adding a random duration between -jitter and
+// +jitter to the poll interval returned by hook. The result is never shorter
+// than half of that poll interval, so that a jitter close to the poll
+// interval does not requeue resources right away.
+func JitterHook(jitter time.Duration, hook managed.PollIntervalHook) managed.PollIntervalHook {
+	return func(mg resource.Managed, d time.Duration) time.Duration {
+		if hook != nil {
+			d = hook(mg, d)
+		}
+		if jitter <= 0 {
+			return d
+		}
+		jittered := d + time.Duration((rand.Float64()*2-1)*float64(jitter)) //nolint:gosec // No need for secure randomness.
+		return max(jittered, d/2)
+	}
+}
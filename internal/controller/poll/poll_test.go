@@ -0,0 +1,62 @@
+/*
+Copyright 2026 SAP SE or an SAP affiliate company and contributors.
+*/
+
+package poll
+
+import (
+	"testing"
+	"time"
+
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+)
+
+func TestJitterHook(t *testing.T) {
+	cases := map[string]struct {
+		reason   string
+		interval time.Duration
+		jitter   time.Duration
+		hook     func(resource.Managed, time.Duration) time.Duration
+		min, max time.Duration
+	}{
+		"NoJitter": {
+			reason:   "Without jitter the poll interval should be kept",
+			interval: time.Minute,
+			min:      time.Minute,
+			max:      time.Minute,
+		},
+		"Jitter": {
+			reason:   "The jitter should be added to or subtracted from the poll interval",
+			interval: time.Minute,
+			jitter:   10 * time.Second,
+			min:      50 * time.Second,
+			max:      70 * time.Second,
+		},
+		"JitterAboveInterval": {
+			reason:   "The poll interval should not be shortened below its half",
+			interval: time.Minute,
+			jitter:   5 * time.Minute,
+			min:      30 * time.Second,
+			max:      6 * time.Minute,
+		},
+		"Hook": {
+			reason:   "The jitter should apply to the poll interval returned by the hook",
+			interval: time.Minute,
+			jitter:   time.Minute,
+			hook:     func(_ resource.Managed, _ time.Duration) time.Duration { return time.Hour },
+			min:      59 * time.Minute,
+			max:      61 * time.Minute,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			hook := JitterHook(tc.jitter, tc.hook)
+			for range 100 {
+				if got := hook(nil, tc.interval); got < tc.min || got > tc.max {
+					t.Fatalf("\n%s\nJitterHook(...): got %s, want between %s and %s", tc.reason, got, tc.min, tc.max)
+				}
+			}
+		})
+	}
+}
@@ -8,15 +8,17 @@ import (
 	"context"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
-	corev1 "k8s.io/api/core/v1"
 
+	"github.com/SAP/crossplane-provider-hana/internal/clients/hana"
 	"github.com/SAP/crossplane-provider-hana/internal/clients/xsql"
 	"github.com/SAP/crossplane-provider-hana/internal/utils"
 
+	"github.com/SAP/crossplane-provider-hana/internal/clients/hana/privilege"
 	"github.com/SAP/crossplane-provider-hana/internal/clients/hana/role"
 
 	"errors"
 	"fmt"
+	"strings"
 
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -30,20 +32,31 @@ import (
 
 	"github.com/SAP/crossplane-provider-hana/apis/admin/v1alpha1"
 	apisv1alpha1 "github.com/SAP/crossplane-provider-hana/apis/v1alpha1"
+	"github.com/SAP/crossplane-provider-hana/internal/controller/approval"
+	"github.com/SAP/crossplane-provider-hana/internal/controller/capability"
+	"github.com/SAP/crossplane-provider-hana/internal/controller/externalname"
 	"github.com/SAP/crossplane-provider-hana/internal/controller/features"
+	"github.com/SAP/crossplane-provider-hana/internal/controller/outcome"
+	"github.com/SAP/crossplane-provider-hana/internal/controller/poll"
 )
 
 const (
-	errNotRole      = "managed resource is not a Role custom resource"
-	errTrackPCUsage = "cannot track ProviderConfig usage: %w"
-	errGetPC        = "cannot get ProviderConfig: %w"
-	errNoSecretRef  = "ProviderConfig does not reference a credentials Secret"
-	errGetSecret    = "cannot get credentials Secret: %w"
+	errNotRole          = "managed resource is not a Role custom resource"
+	errTrackPCUsage     = "cannot track ProviderConfig usage: %w"
+	errGetPC            = "cannot get ProviderConfig: %w"
+	errGetExecutionUser = "cannot get execution user: %w"
+	errGetTLS           = "cannot get TLS configuration: %w"
 
 	errSelectRole = "cannot select role: %w"
 	errCreateRole = "cannot create role: %w"
 	errUpdateRole = "cannot update role: %w"
 	errDropRole   = "cannot drop role: %w"
+
+	errReadComment = "cannot read role comment: %w"
+	errSetComment  = "cannot set role comment: %w"
+
+	errDependents = "cannot list resources depending on the role: %w"
+	errBlocked    = "role is still granted to %s"
 )
 
 // Setup adds a controller that reconciles Role managed resources.
@@ -54,15 +67,18 @@ func Setup(mgr ctrl.Manager, o controller.Options, db xsql.Connector) error {
 	t := resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1alpha1.ProviderConfigUsage{})
 	r := managed.NewReconciler(mgr,
 		resource.ManagedKind(v1alpha1.RoleGroupVersionKind),
-		managed.WithExternalConnecter(&connector{
+		outcome.WithExternalConnecter(name, &connector{
 			kube:      mgr.GetClient(),
 			usage:     t,
 			newClient: role.New,
 			log:       log,
 			db:        db,
+
+			approvalsStamped: o.Features.Enabled(features.AdmissionWebhooks),
 		}),
 		managed.WithLogger(log),
 		managed.WithPollInterval(o.PollInterval),
+		poll.WithJitterHook(v1alpha1.RoleKind, nil),
 		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
 		features.ConfigureBetaManagementPolicies(o))
 
@@ -80,6 +96,10 @@ type connector struct {
 	newClient func(db xsql.DB, username string) role.Client
 	log       logging.Logger
 	db        xsql.Connector
+
+	// approvalsStamped is set if the admission webhook stamps the approval
+	// annotations of Roles
+	approvalsStamped bool
 }
 
 // Connect typically produces an ExternalClient by:
@@ -102,32 +122,70 @@ func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 		return nil, fmt.Errorf(errGetPC, err)
 	}
 
-	ref := pc.Spec.Credentials.ConnectionSecretRef
-	if ref == nil {
-		return nil, errors.New(errNoSecretRef)
-	}
-
-	s := &corev1.Secret{}
-	if err := c.kube.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, s); err != nil {
-		return nil, fmt.Errorf(errGetSecret, err)
+	data, err := hana.Credentials(ctx, c.kube, pc)
+	if err != nil {
+		return nil, err
 	}
 
 	c.log.Info("Connecting to role resource", "name", cr.Name)
 
-	username := string(s.Data[xpv1.ResourceCredentialsSecretUserKey])
+	creds, err := hana.ConnectionCredentials(ctx, c.kube, pc, data)
+	if err != nil {
+		return nil, fmt.Errorf(errGetTLS, err)
+	}
+
+	// Manage the role as its execution user, if it names one
+	creds, err = hana.ExecutionCredentials(ctx, c.kube, cr.Spec.ForProvider.ExecutionUserSecretRef, creds)
+	if err != nil {
+		return nil, fmt.Errorf(errGetExecutionUser, err)
+	}
+	username := string(creds[xpv1.ResourceCredentialsSecretUserKey])
 
-	conn, err := c.db.Connect(ctx, s.Data)
+	conn, err := c.db.Connect(ctx, creds)
 	if err != nil {
 		return nil, fmt.Errorf("cannot connect to HANA DB: %w", err)
 	}
 
+	if err := hana.ReportActiveEndpoint(ctx, c.kube, pc, conn); err != nil {
+		c.log.Info("Cannot report active endpoint", "providerConfig", pc.Name, "error", err)
+	}
+
+	if err := hana.Require(ctx, conn, hana.ManageRoles); err != nil {
+		return capability.Disabled(err), nil
+	}
+
 	return &external{
-		client: c.newClient(conn, username),
-		kube:   c.kube,
-		log:    c.log,
+		client:         c.newClient(conn, username),
+		kube:           c.kube,
+		log:            c.log,
+		grantPolicy:    pc.Spec.GrantPolicy,
+		comments:       pc.Spec.ObjectComments,
+		identifierCase: pc.Spec.IdentifierCase,
+		db:             conn,
+		defaultSchema:  username,
+
+		approvalsStamped: c.approvalsStamped,
 	}, nil
 }
 
+// NewObserver returns an ExternalClient for Roles of the ProviderConfig that
+// shares an existing connection. Callers must only call Observe, which never
+// changes the catalog. The connection credentials are those of the
+// connection.
+func NewObserver(kube client.Client, conn xsql.DB, pc *apisv1alpha1.ProviderConfig, creds map[string][]byte, log logging.Logger) managed.ExternalClient {
+	username := string(creds[xpv1.ResourceCredentialsSecretUserKey])
+	return &external{
+		client:         role.New(conn, username),
+		kube:           kube,
+		log:            log,
+		grantPolicy:    pc.Spec.GrantPolicy,
+		comments:       pc.Spec.ObjectComments,
+		identifierCase: pc.Spec.IdentifierCase,
+		db:             conn,
+		defaultSchema:  username,
+	}
+}
+
 func (c *external) Disconnect(ctx context.Context) error {
 	return nil
 }
@@ -135,9 +193,16 @@ func (c *external) Disconnect(ctx context.Context) error {
 // An ExternalClient observes, then either creates, updates, or deletes an
 // external resource to ensure it reflects the managed resource's desired state.
 type external struct {
-	client role.RoleClient
-	kube   client.Client
-	log    logging.Logger
+	client         role.RoleClient
+	kube           client.Client
+	log            logging.Logger
+	grantPolicy    *apisv1alpha1.GrantPolicy
+	comments       *apisv1alpha1.ObjectCommentsConfig
+	identifierCase string
+	db             xsql.DB
+	defaultSchema  string
+
+	approvalsStamped bool
 }
 
 func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
@@ -148,7 +213,7 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 
 	c.log.Info("Observing role resource", "name", cr.Name)
 
-	parameters := buildDesiredParameters(cr)
+	parameters := buildDesiredParameters(cr, c.identifierCase)
 
 	observed, err := c.client.Read(ctx, parameters)
 
@@ -164,21 +229,37 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 
 	cr.Status.AtProvider.RoleName = observed.RoleName
 	cr.Status.AtProvider.Schema = observed.Schema
-	cr.Status.AtProvider.Privileges = observed.Privileges
-	cr.Status.AtProvider.LdapGroups = observed.LdapGroups
+	cr.Status.AtProvider.Privileges = utils.SortedSet(observed.Privileges)
+	cr.Status.AtProvider.LdapGroups = utils.SortedSet(observed.LdapGroups)
 	cr.Status.AtProvider.Rolegroup = observed.Rolegroup
+	cr.Status.AtProvider.ExternalID = externalname.Qualified(observed.Schema, observed.RoleName)
 
 	cr.SetConditions(xpv1.Available())
 
+	if c.grantPolicy != nil {
+		if err := privilege.CheckGrantPolicy(c.grantPolicy, parameters.Privileges, nil, c.defaultSchema); err != nil {
+			cr.SetConditions(apisv1alpha1.GrantForbidden(err))
+		} else {
+			cr.SetConditions(apisv1alpha1.GrantAllowed())
+		}
+	}
+
 	isUpToDate := upToDate(observed, parameters)
+	if isUpToDate {
+		if isUpToDate, err = c.isCommentUpToDate(ctx, cr, parameters); err != nil {
+			c.log.Info("Error reading role comment", "name", cr.Name, "error", err)
+			return managed.ExternalObservation{}, fmt.Errorf(errReadComment, err)
+		}
+	}
 	c.log.Info("Observed role resource",
 		"name", cr.Name,
 		"roleName", parameters.RoleName,
 		"upToDate", isUpToDate)
 
 	return managed.ExternalObservation{
-		ResourceExists:   true,
-		ResourceUpToDate: isUpToDate,
+		ResourceExists:    true,
+		ResourceUpToDate:  isUpToDate,
+		ConnectionDetails: externalname.ConnectionDetails(cr.Status.AtProvider.ExternalID),
 	}, nil
 }
 
@@ -205,7 +286,7 @@ func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 
 	cr.SetConditions(xpv1.Creating())
 
-	parameters := buildDesiredParameters(cr)
+	parameters := buildDesiredParameters(cr, c.identifierCase)
 
 	c.log.Info("Creating role with parameters",
 		"roleName", parameters.RoleName,
@@ -214,6 +295,10 @@ func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 		"ldapGroups", parameters.LdapGroups,
 		"noGrantToCreator", parameters.NoGrantToCreator)
 
+	if err := c.enforceGrantPolicy(cr, parameters.Privileges); err != nil {
+		return managed.ExternalCreation{}, fmt.Errorf(errCreateRole, err)
+	}
+
 	err := c.client.Create(ctx, parameters)
 
 	if err != nil {
@@ -223,10 +308,15 @@ func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 
 	cr.Status.AtProvider.RoleName = parameters.RoleName
 	cr.Status.AtProvider.Schema = parameters.Schema
-	cr.Status.AtProvider.Privileges = parameters.Privileges
-	cr.Status.AtProvider.LdapGroups = parameters.LdapGroups
+	cr.Status.AtProvider.Privileges = utils.SortedSet(parameters.Privileges)
+	cr.Status.AtProvider.LdapGroups = utils.SortedSet(parameters.LdapGroups)
 	cr.Status.AtProvider.Rolegroup = parameters.Rolegroup
 
+	// The role exists now, so a missing comment is left to the next update
+	if err := c.updateComment(ctx, cr, parameters); err != nil {
+		c.log.Info("Error setting role comment", "name", cr.Name, "error", err)
+	}
+
 	c.log.Info("Successfully created role resource", "name", cr.Name, "roleName", parameters.RoleName)
 	return managed.ExternalCreation{
 		ConnectionDetails: managed.ConnectionDetails{},
@@ -241,7 +331,7 @@ func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.Ext
 
 	c.log.Info("Updating role resource", "name", cr.Name, "roleName", cr.Spec.ForProvider.RoleName)
 
-	parameters := buildDesiredParameters(cr)
+	parameters := buildDesiredParameters(cr, c.identifierCase)
 
 	observedLdapGroups := cr.Status.AtProvider.LdapGroups
 	desiredLdapGroups := parameters.LdapGroups
@@ -263,7 +353,7 @@ func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.Ext
 			c.log.Info("Error updating role LDAP groups", "name", cr.Name, "error", err)
 			return managed.ExternalUpdate{}, fmt.Errorf(errUpdateRole, err)
 		}
-		cr.Status.AtProvider.LdapGroups = parameters.LdapGroups
+		cr.Status.AtProvider.LdapGroups = utils.SortedSet(parameters.LdapGroups)
 		c.log.Info("Updated role LDAP groups", "name", cr.Name, "roleName", parameters.RoleName)
 	}
 
@@ -274,12 +364,16 @@ func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.Ext
 			"privilegesToAdd", privilegesToAdd,
 			"privilegesToRemove", privilegesToRemove)
 
+		if err := c.enforceGrantPolicy(cr, privilegesToAdd); err != nil {
+			return managed.ExternalUpdate{}, fmt.Errorf(errUpdateRole, err)
+		}
+
 		err := c.client.UpdatePrivileges(ctx, parameters, privilegesToAdd, privilegesToRemove)
 		if err != nil {
 			c.log.Info("Error updating role privileges", "name", cr.Name, "error", err)
 			return managed.ExternalUpdate{}, fmt.Errorf(errUpdateRole, err)
 		}
-		cr.Status.AtProvider.Privileges = parameters.Privileges
+		cr.Status.AtProvider.Privileges = utils.SortedSet(parameters.Privileges)
 		c.log.Info("Updated role privileges", "name", cr.Name, "roleName", parameters.RoleName)
 	}
 
@@ -299,10 +393,57 @@ func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.Ext
 		c.log.Info("Updated role rolegroup", "name", cr.Name, "roleName", parameters.RoleName)
 	}
 
+	if err := c.updateComment(ctx, cr, parameters); err != nil {
+		c.log.Info("Error setting role comment", "name", cr.Name, "error", err)
+		return managed.ExternalUpdate{}, err
+	}
+
 	c.log.Info("Successfully updated role resource", "name", cr.Name, "roleName", parameters.RoleName)
 	return managed.ExternalUpdate{}, nil
 }
 
+// isCommentUpToDate ignores the comment unless the ProviderConfig maintains
+// comments.
+func (c *external) isCommentUpToDate(ctx context.Context, cr *v1alpha1.Role, parameters *v1alpha1.RoleParameters) (bool, error) {
+	comment := hana.ManagedComment(c.comments, v1alpha1.RoleKind, cr.Name)
+	if comment == "" {
+		return true, nil
+	}
+	observed, err := hana.ReadComment(ctx, c.db, hana.CommentOnRole, parameters.RoleName)
+	return observed == comment, err
+}
+
+// updateComment sets the comment if the ProviderConfig maintains comments.
+func (c *external) updateComment(ctx context.Context, cr *v1alpha1.Role, parameters *v1alpha1.RoleParameters) error {
+	comment := hana.ManagedComment(c.comments, v1alpha1.RoleKind, cr.Name)
+	if comment == "" {
+		return nil
+	}
+	name := utils.QuoteIdentifier(parameters.RoleName)
+	if parameters.Schema != "" {
+		name = utils.QuoteIdentifier(parameters.Schema) + "." + name
+	}
+	if err := hana.SetComment(ctx, c.db, hana.CommentOnRole, name, comment); err != nil {
+		return fmt.Errorf(errSetComment, err)
+	}
+	return nil
+}
+
+// enforceGrantPolicy rejects grants forbidden by the grant policy of the
+// ProviderConfig and holds back high-risk grants until they are approved.
+func (c *external) enforceGrantPolicy(cr *v1alpha1.Role, privileges []string) error {
+	if err := privilege.CheckGrantPolicy(c.grantPolicy, privileges, nil, c.defaultSchema); err != nil {
+		c.log.Info("Grant rejected by grant policy", "name", cr.Name, "error", err)
+		cr.SetConditions(apisv1alpha1.GrantForbidden(err))
+		return err
+	}
+	if err := approval.Check(cr, c.approvalsStamped, c.grantPolicy, privileges, nil, c.defaultSchema); err != nil {
+		c.log.Info("Grant waiting for approval", "name", cr.Name, "error", err)
+		return err
+	}
+	return nil
+}
+
 func (c *external) Delete(ctx context.Context, mg resource.Managed) (managed.ExternalDelete, error) {
 	cr, ok := mg.(*v1alpha1.Role)
 	if !ok {
@@ -311,10 +452,21 @@ func (c *external) Delete(ctx context.Context, mg resource.Managed) (managed.Ext
 
 	c.log.Info("Deleting role resource", "name", cr.Name, "roleName", cr.Spec.ForProvider.RoleName)
 
-	parameters := buildDesiredParameters(cr)
+	parameters := buildDesiredParameters(cr, c.identifierCase)
 
 	cr.SetConditions(xpv1.Deleting())
 
+	if cr.GetAnnotations()[apisv1alpha1.AnnotationDeletionPolicy] != apisv1alpha1.DeletionPolicyCascade {
+		dependents, err := c.dependents(ctx, parameters)
+		if err != nil {
+			return managed.ExternalDelete{}, fmt.Errorf(errDependents, err)
+		}
+		if len(dependents) > 0 {
+			cr.SetConditions(apisv1alpha1.DeletionBlocked(dependents))
+			return managed.ExternalDelete{}, fmt.Errorf(errBlocked, strings.Join(dependents, ", "))
+		}
+	}
+
 	err := c.client.Delete(ctx, parameters)
 
 	if err != nil {
@@ -326,18 +478,48 @@ func (c *external) Delete(ctx context.Context, mg resource.Managed) (managed.Ext
 	return managed.ExternalDelete{}, err
 }
 
+// dependents returns the managed Users the role is granted to.
+func (c *external) dependents(ctx context.Context, parameters *v1alpha1.RoleParameters) ([]string, error) {
+	name := fmt.Sprintf(`"%s"`, utils.EscapeDoubleQuotes(parameters.RoleName))
+	if parameters.Schema != "" {
+		name = fmt.Sprintf(`"%s".%s`, utils.EscapeDoubleQuotes(parameters.Schema), name)
+	}
+
+	users := &v1alpha1.UserList{}
+	if err := c.kube.List(ctx, users); err != nil {
+		return nil, err
+	}
+	var dependents []string
+	for _, u := range users.Items {
+		// Roles that cannot be parsed are rejected when the User is reconciled
+		if matched, err := privilege.MatchRoles(u.Spec.ForProvider.Roles, []string{name}); err == nil && len(matched) > 0 {
+			dependents = append(dependents, v1alpha1.UserKind+"/"+u.Name)
+		}
+	}
+	return dependents, nil
+}
+
 // buildDesiredParameters constructs the desired role parameters from the CR spec.
 // Note: We preserve the original case for all fields because:
 // - RoleName/Schema: HANA uses double-quoted identifiers which preserve case
 // - Privileges: May contain schema/object names that are case-sensitive
 // - LdapGroups: LDAP Distinguished Names are case-sensitive
-func buildDesiredParameters(cr *v1alpha1.Role) *v1alpha1.RoleParameters {
-	return &v1alpha1.RoleParameters{
+// unless the ProviderConfig folds unquoted identifiers to uppercase. LDAP
+// groups are never folded.
+func buildDesiredParameters(cr *v1alpha1.Role, identifierCase string) *v1alpha1.RoleParameters {
+	parameters := &v1alpha1.RoleParameters{
 		RoleName:         cr.Spec.ForProvider.RoleName,
 		Schema:           cr.Spec.ForProvider.Schema,
-		Privileges:       cr.Spec.ForProvider.Privileges,
+		Privileges:       privilege.WithTypedPrivileges(cr.Spec.ForProvider.Privileges, cr.Spec.ForProvider.TypedPrivileges),
 		LdapGroups:       cr.Spec.ForProvider.LdapGroups,
 		NoGrantToCreator: cr.Spec.ForProvider.NoGrantToCreator,
 		Rolegroup:        cr.Spec.ForProvider.Rolegroup,
 	}
+	parameters.RoleName = hana.FoldIdentifier(identifierCase, parameters.RoleName)
+	parameters.Schema = hana.FoldIdentifier(identifierCase, parameters.Schema)
+	parameters.Rolegroup = hana.FoldIdentifier(identifierCase, parameters.Rolegroup)
+	if identifierCase == apisv1alpha1.IdentifierCaseUpper {
+		parameters.Privileges = privilege.FoldPrivilegeStrings(parameters.Privileges)
+	}
+	return parameters
 }
@@ -11,8 +11,11 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/SAP/crossplane-provider-hana/internal/clients/hana"
+	"github.com/SAP/crossplane-provider-hana/internal/clients/hana/privilege"
 	"github.com/SAP/crossplane-provider-hana/internal/clients/hana/role"
 	"github.com/SAP/crossplane-provider-hana/internal/clients/xsql"
+	"github.com/SAP/crossplane-provider-hana/internal/controller/externalname"
 
 	"github.com/SAP/crossplane-provider-hana/apis/admin/v1alpha1"
 	apisv1alpha1 "github.com/SAP/crossplane-provider-hana/apis/v1alpha1"
@@ -24,6 +27,7 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/test"
 	"github.com/google/go-cmp/cmp"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -157,7 +161,7 @@ func TestConnect(t *testing.T) {
 					},
 				},
 			},
-			want: errors.New(errNoSecretRef),
+			want: errors.New(hana.ErrNoSecretRef),
 		},
 		"ErrGetConnectionSecret": {
 			reason: "An error should be returned if we can't get our ProviderConfig's connection secret",
@@ -184,13 +188,45 @@ func TestConnect(t *testing.T) {
 					},
 				},
 			},
-			want: fmt.Errorf(errGetSecret, errBoom),
+			want: fmt.Errorf(hana.ErrGetSecret, errBoom),
+		},
+		"ErrGetExecutionUserSecret": {
+			reason: "An error should be returned if we can't get the secret of the execution user",
+			fields: fields{
+				kube: &test.MockClient{
+					MockGet: func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+						switch o := obj.(type) {
+						case *apisv1alpha1.ProviderConfig:
+							o.Spec.Credentials.ConnectionSecretRef = &xpv1.SecretReference{Name: "admin"}
+						case *corev1.Secret:
+							if key.Name != "admin" {
+								return errBoom
+							}
+						}
+						return nil
+					},
+				},
+				usage: resource.TrackerFn(func(ctx context.Context, mg resource.Managed) error { return nil }),
+			},
+			args: args{
+				mg: &v1alpha1.Role{
+					Spec: v1alpha1.RoleSpec{
+						ResourceSpec: xpv1.ResourceSpec{
+							ProviderConfigReference: &xpv1.Reference{},
+						},
+						ForProvider: v1alpha1.RoleParameters{
+							ExecutionUserSecretRef: &xpv1.SecretReference{Name: "schema-owner"},
+						},
+					},
+				},
+			},
+			want: fmt.Errorf(errGetExecutionUser, fmt.Errorf("cannot get execution user secret: %w", errBoom)),
 		},
 	}
 
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
-			e := &connector{kube: tc.fields.kube, usage: tc.fields.usage, newClient: tc.fields.newClient}
+			e := &connector{kube: tc.fields.kube, usage: tc.fields.usage, newClient: tc.fields.newClient, log: &MockLogger{}}
 			_, err := e.Connect(tc.args.ctx, tc.args.mg)
 			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
 				t.Errorf("\n%s\ne.Connect(...): -want error, +got error:\n%s\n", tc.reason, diff)
@@ -281,6 +317,37 @@ func TestObserve(t *testing.T) {
 				err: nil,
 			},
 		},
+		"Exists": {
+			reason: "An existing role should publish its schema-qualified name as external ID",
+			fields: fields{
+				client: mockClient{
+					MockRead: func(ctx context.Context, parameters *v1alpha1.RoleParameters) (observed *v1alpha1.RoleObservation, err error) {
+						return &v1alpha1.RoleObservation{
+							RoleName: "DEMO_ROLE",
+							Schema:   "DEMO_SCHEMA",
+						}, nil
+					},
+				},
+				log: &MockLogger{},
+			},
+			args: args{
+				mg: &v1alpha1.Role{
+					Spec: v1alpha1.RoleSpec{
+						ForProvider: v1alpha1.RoleParameters{
+							RoleName: "DEMO_ROLE",
+							Schema:   "DEMO_SCHEMA",
+						},
+					},
+				},
+			},
+			want: want{
+				c: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  true,
+					ConnectionDetails: externalname.ConnectionDetails("DEMO_SCHEMA.DEMO_ROLE"),
+				},
+			},
+		},
 	}
 
 	for name, tc := range cases {
@@ -301,8 +368,9 @@ func TestCreate(t *testing.T) {
 	errBoom := errors.New("boom")
 
 	type fields struct {
-		client role.RoleClient
-		log    logging.Logger
+		client      role.RoleClient
+		log         logging.Logger
+		grantPolicy *apisv1alpha1.GrantPolicy
 	}
 
 	type args struct {
@@ -353,6 +421,33 @@ func TestCreate(t *testing.T) {
 				err: fmt.Errorf(errCreateRole, errBoom),
 			},
 		},
+		"ErrForbiddenGrant": {
+			reason: "Privileges forbidden by the grant policy of the ProviderConfig should be rejected",
+			fields: fields{
+				client: mockClient{
+					MockCreate: func(ctx context.Context, parameters *v1alpha1.RoleParameters) error {
+						return errBoom
+					},
+				},
+				log: &MockLogger{},
+				grantPolicy: &apisv1alpha1.GrantPolicy{
+					ForbiddenPrivileges: []string{"USER ADMIN"},
+				},
+			},
+			args: args{
+				mg: &v1alpha1.Role{
+					Spec: v1alpha1.RoleSpec{
+						ForProvider: v1alpha1.RoleParameters{
+							RoleName:   "DEMO_ROLE",
+							Privileges: []string{"USER ADMIN"},
+						},
+					},
+				},
+			},
+			want: want{
+				err: fmt.Errorf(errCreateRole, fmt.Errorf("%w: privileges USER ADMIN", privilege.ErrForbiddenGrant)),
+			},
+		},
 		"Success": {
 			reason: "No error should be returned when we successfully create a role",
 			fields: fields{
@@ -381,7 +476,7 @@ func TestCreate(t *testing.T) {
 
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
-			e := external{client: tc.fields.client, log: tc.fields.log}
+			e := external{client: tc.fields.client, log: tc.fields.log, grantPolicy: tc.fields.grantPolicy}
 			got, err := e.Create(tc.args.ctx, tc.args.mg)
 			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
 				t.Errorf("\n%s\ne.Create(...): -want error, +got error:\n%s\n", tc.reason, diff)
@@ -398,9 +493,22 @@ func TestDelete(t *testing.T) {
 
 	type fields struct {
 		client role.RoleClient
+		kube   client.Client
 		log    logging.Logger
 	}
 
+	noUsers := &test.MockClient{MockList: test.NewMockListFn(nil)}
+	usersOfRole := &test.MockClient{
+		MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
+			users := obj.(*v1alpha1.UserList)
+			users.Items = []v1alpha1.User{{
+				ObjectMeta: metav1.ObjectMeta{Name: "demo-user"},
+				Spec:       v1alpha1.UserSpec{ForProvider: v1alpha1.UserParameters{Roles: []string{"demo_role"}}},
+			}}
+			return nil
+		}),
+	}
+
 	type args struct {
 		ctx context.Context
 		mg  resource.Managed
@@ -433,7 +541,8 @@ func TestDelete(t *testing.T) {
 						return errBoom
 					},
 				},
-				log: &MockLogger{},
+				kube: noUsers,
+				log:  &MockLogger{},
 			},
 			args: args{
 				mg: &v1alpha1.Role{
@@ -448,6 +557,52 @@ func TestDelete(t *testing.T) {
 				err: fmt.Errorf(errDropRole, errBoom),
 			},
 		},
+		"ErrDependents": {
+			reason: "The deletion should wait while the role is granted to managed Users",
+			fields: fields{
+				kube: usersOfRole,
+				log:  &MockLogger{},
+			},
+			args: args{
+				mg: &v1alpha1.Role{
+					Spec: v1alpha1.RoleSpec{
+						ForProvider: v1alpha1.RoleParameters{
+							RoleName: "DEMO_ROLE",
+						},
+					},
+				},
+			},
+			want: want{
+				err: fmt.Errorf(errBlocked, "User/demo-user"),
+			},
+		},
+		"Cascade": {
+			reason: "The role should be dropped despite dependent Users with the cascade deletion policy",
+			fields: fields{
+				client: mockClient{
+					MockDelete: func(ctx context.Context, parameters *v1alpha1.RoleParameters) error {
+						return nil
+					},
+				},
+				kube: usersOfRole,
+				log:  &MockLogger{},
+			},
+			args: args{
+				mg: &v1alpha1.Role{
+					ObjectMeta: metav1.ObjectMeta{
+						Annotations: map[string]string{apisv1alpha1.AnnotationDeletionPolicy: apisv1alpha1.DeletionPolicyCascade},
+					},
+					Spec: v1alpha1.RoleSpec{
+						ForProvider: v1alpha1.RoleParameters{
+							RoleName: "DEMO_ROLE",
+						},
+					},
+				},
+			},
+			want: want{
+				err: nil,
+			},
+		},
 		"Success": {
 			reason: "No error should be returned when we successfully delete a role",
 			fields: fields{
@@ -456,7 +611,8 @@ func TestDelete(t *testing.T) {
 						return nil
 					},
 				},
-				log: &MockLogger{},
+				kube: noUsers,
+				log:  &MockLogger{},
 			},
 			args: args{
 				mg: &v1alpha1.Role{
@@ -475,7 +631,7 @@ func TestDelete(t *testing.T) {
 
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
-			e := external{client: tc.fields.client, log: tc.fields.log}
+			e := external{client: tc.fields.client, kube: tc.fields.kube, log: tc.fields.log}
 			_, err := e.Delete(tc.args.ctx, tc.args.mg)
 			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
 				t.Errorf("\n%s\ne.Delete(...): -want error, +got error:\n%s\n", tc.reason, diff)
@@ -486,9 +642,10 @@ func TestDelete(t *testing.T) {
 
 func TestBuildDesiredParameters(t *testing.T) {
 	cases := map[string]struct {
-		reason string
-		cr     *v1alpha1.Role
-		want   *v1alpha1.RoleParameters
+		reason         string
+		cr             *v1alpha1.Role
+		identifierCase string
+		want           *v1alpha1.RoleParameters
 	}{
 		"AllFields": {
 			reason: "All ForProvider fields should be copied verbatim, preserving case and special characters",
@@ -513,11 +670,33 @@ func TestBuildDesiredParameters(t *testing.T) {
 				Rolegroup:        "MY_ROLEGROUP",
 			},
 		},
+		"FoldIdentifiers": {
+			reason: "Unquoted identifiers should be folded if the ProviderConfig folds identifiers, quoted ones and LDAP groups kept",
+			cr: &v1alpha1.Role{
+				Spec: v1alpha1.RoleSpec{
+					ForProvider: v1alpha1.RoleParameters{
+						RoleName:   "reader",
+						Schema:     `"mySchema"`,
+						Privileges: []string{"SELECT ON SCHEMA testSchema", `SELECT ON "mySchema".mytable`, "CATALOG READ"},
+						LdapGroups: []string{"cn=Readers,dc=example,dc=com"},
+						Rolegroup:  "my_rolegroup",
+					},
+				},
+			},
+			identifierCase: apisv1alpha1.IdentifierCaseUpper,
+			want: &v1alpha1.RoleParameters{
+				RoleName:   "READER",
+				Schema:     "mySchema",
+				Privileges: []string{`SELECT ON SCHEMA "TESTSCHEMA"`, `SELECT ON "mySchema"."MYTABLE"`, "CATALOG READ"},
+				LdapGroups: []string{"cn=Readers,dc=example,dc=com"},
+				Rolegroup:  "MY_ROLEGROUP",
+			},
+		},
 	}
 
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
-			got := buildDesiredParameters(tc.cr)
+			got := buildDesiredParameters(tc.cr, tc.identifierCase)
 			if diff := cmp.Diff(tc.want, got); diff != "" {
 				t.Errorf("\n%s\nbuildDesiredParameters(...): -want, +got:\n%s\n", tc.reason, diff)
 			}
@@ -8,8 +8,8 @@ import (
 	"context"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
-	corev1 "k8s.io/api/core/v1"
 
+	"github.com/SAP/crossplane-provider-hana/internal/clients/hana"
 	"github.com/SAP/crossplane-provider-hana/internal/clients/hana/rolegroup"
 	"github.com/SAP/crossplane-provider-hana/internal/clients/xsql"
 
@@ -28,15 +28,17 @@ import (
 
 	"github.com/SAP/crossplane-provider-hana/apis/admin/v1alpha1"
 	apisv1alpha1 "github.com/SAP/crossplane-provider-hana/apis/v1alpha1"
+	"github.com/SAP/crossplane-provider-hana/internal/controller/externalname"
 	"github.com/SAP/crossplane-provider-hana/internal/controller/features"
+	"github.com/SAP/crossplane-provider-hana/internal/controller/outcome"
+	"github.com/SAP/crossplane-provider-hana/internal/controller/poll"
 )
 
 const (
 	errNotRolegroup = "managed resource is not a rolegroup custom resource"
 	errTrackPCUsage = "cannot track ProviderConfig usage: %w"
 	errGetPC        = "cannot get ProviderConfig: %w"
-	errNoSecretRef  = "ProviderConfig does not reference a credentials Secret"
-	errGetSecret    = "cannot get credentials Secret: %w"
+	errGetTLS       = "cannot get TLS configuration: %w"
 
 	errSelectRolegroup = "cannot select rolegroup: %w"
 	errCreateRolegroup = "cannot create rolegroup: %w"
@@ -52,7 +54,7 @@ func Setup(mgr ctrl.Manager, o controller.Options, db xsql.Connector) error {
 	t := resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1alpha1.ProviderConfigUsage{})
 	r := managed.NewReconciler(mgr,
 		resource.ManagedKind(v1alpha1.RolegroupGroupVersionKind),
-		managed.WithExternalConnecter(&connector{
+		outcome.WithExternalConnecter(name, &connector{
 			kube:      mgr.GetClient(),
 			usage:     t,
 			newClient: rolegroup.New,
@@ -61,6 +63,7 @@ func Setup(mgr ctrl.Manager, o controller.Options, db xsql.Connector) error {
 		}),
 		managed.WithLogger(log),
 		managed.WithPollInterval(o.PollInterval),
+		poll.WithJitterHook(v1alpha1.RolegroupKind, nil),
 		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
 		features.ConfigureBetaManagementPolicies(o))
 
@@ -100,23 +103,27 @@ func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 		return nil, fmt.Errorf(errGetPC, err)
 	}
 
-	ref := pc.Spec.Credentials.ConnectionSecretRef
-	if ref == nil {
-		return nil, errors.New(errNoSecretRef)
-	}
-
-	s := &corev1.Secret{}
-	if err := c.kube.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, s); err != nil {
-		return nil, fmt.Errorf(errGetSecret, err)
+	data, err := hana.Credentials(ctx, c.kube, pc)
+	if err != nil {
+		return nil, err
 	}
 
 	c.log.Info("Connecting to rolegroup resource", "name", cr.Name)
 
-	conn, err := c.db.Connect(ctx, s.Data)
+	creds, err := hana.ConnectionCredentials(ctx, c.kube, pc, data)
+	if err != nil {
+		return nil, fmt.Errorf(errGetTLS, err)
+	}
+
+	conn, err := c.db.Connect(ctx, creds)
 	if err != nil {
 		return nil, fmt.Errorf("cannot connect to HANA DB: %w", err)
 	}
 
+	if err := hana.ReportActiveEndpoint(ctx, c.kube, pc, conn); err != nil {
+		c.log.Info("Cannot report active endpoint", "providerConfig", pc.Name, "error", err)
+	}
+
 	return &external{
 		client: c.newClient(conn),
 		kube:   c.kube,
@@ -160,6 +167,7 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 
 	cr.Status.AtProvider.RolegroupName = observed.RolegroupName
 	cr.Status.AtProvider.DisableRoleAdmin = observed.DisableRoleAdmin
+	cr.Status.AtProvider.ExternalID = observed.RolegroupName
 
 	cr.SetConditions(xpv1.Available())
 
@@ -170,8 +178,9 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 		"upToDate", isUpToDate)
 
 	return managed.ExternalObservation{
-		ResourceExists:   true,
-		ResourceUpToDate: isUpToDate,
+		ResourceExists:    true,
+		ResourceUpToDate:  isUpToDate,
+		ConnectionDetails: externalname.ConnectionDetails(cr.Status.AtProvider.ExternalID),
 	}, nil
 
 }
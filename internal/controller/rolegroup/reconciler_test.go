@@ -20,6 +20,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	"github.com/SAP/crossplane-provider-hana/internal/clients/hana"
 	"github.com/SAP/crossplane-provider-hana/internal/clients/hana/rolegroup"
 	"github.com/SAP/crossplane-provider-hana/internal/clients/xsql"
 
@@ -135,7 +136,7 @@ func TestConnect(t *testing.T) {
 					},
 				},
 			},
-			want: errors.New(errNoSecretRef),
+			want: errors.New(hana.ErrNoSecretRef),
 		},
 		"ErrGetConnectionSecret": {
 			reason: "An error should be returned if we can't get our ProviderConfig's connection secret",
@@ -162,7 +163,7 @@ func TestConnect(t *testing.T) {
 					},
 				},
 			},
-			want: fmt.Errorf(errGetSecret, errBoom),
+			want: fmt.Errorf(hana.ErrGetSecret, errBoom),
 		},
 	}
 
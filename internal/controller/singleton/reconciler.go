@@ -0,0 +1,205 @@
+/*
+Copyright 2026 SAP SE or an SAP affiliate company and contributors.
+*/
+
+package singleton
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/SAP/crossplane-provider-hana/internal/clients/hana"
+	"github.com/SAP/crossplane-provider-hana/internal/clients/hana/singleton"
+	"github.com/SAP/crossplane-provider-hana/internal/clients/xsql"
+
+	"github.com/SAP/crossplane-provider-hana/apis/admin/v1alpha1"
+	apisv1alpha1 "github.com/SAP/crossplane-provider-hana/apis/v1alpha1"
+	"github.com/SAP/crossplane-provider-hana/internal/controller/features"
+	"github.com/SAP/crossplane-provider-hana/internal/controller/outcome"
+	"github.com/SAP/crossplane-provider-hana/internal/controller/poll"
+)
+
+const (
+	errNotSingleton = "managed resource is not a Singleton custom resource"
+	errTrackPCUsage = "cannot track ProviderConfig usage: %w"
+	errGetPC        = "cannot get ProviderConfig: %w"
+	errGetTLS       = "cannot get TLS configuration: %w"
+
+	errObserve = "cannot run observation query: %w"
+	errCreate  = "cannot run create statement: %w"
+	errDrop    = "cannot run drop statement: %w"
+	errNotSeen = "the observation query does not find the artifact of the create statement, which is not run again; fix the query, or remove the crossplane.io/external-create-succeeded annotation to create the artifact again"
+)
+
+// Setup adds a controller that reconciles Singleton managed resources.
+func Setup(mgr ctrl.Manager, o controller.Options, db xsql.Connector) error {
+	name := managed.ControllerName(v1alpha1.SingletonGroupKind)
+
+	log := o.Logger.WithValues("controller", name)
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1alpha1.SingletonGroupVersionKind),
+		outcome.WithExternalConnecter(name, &connector{
+			kube:      mgr.GetClient(),
+			usage:     resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1alpha1.ProviderConfigUsage{}),
+			newClient: singleton.New,
+			log:       log,
+			db:        db,
+		}),
+		managed.WithLogger(log),
+		managed.WithPollInterval(o.PollInterval),
+		poll.WithJitterHook(v1alpha1.SingletonKind, nil),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+		features.ConfigureBetaManagementPolicies(o))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		For(&v1alpha1.Singleton{}).
+		Complete(r)
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube      client.Client
+	usage     resource.Tracker
+	newClient func(db xsql.DB) singleton.Client
+	log       logging.Logger
+	db        xsql.Connector
+}
+
+// Connect produces an ExternalClient for the database of the ProviderConfig
+// of the Singleton.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1alpha1.Singleton)
+	if !ok {
+		return nil, errors.New(errNotSingleton)
+	}
+
+	if err := c.usage.Track(ctx, mg); err != nil {
+		return nil, fmt.Errorf(errTrackPCUsage, err)
+	}
+
+	pc := &apisv1alpha1.ProviderConfig{}
+	if err := c.kube.Get(ctx, types.NamespacedName{Name: cr.GetProviderConfigReference().Name}, pc); err != nil {
+		return nil, fmt.Errorf(errGetPC, err)
+	}
+
+	data, err := hana.Credentials(ctx, c.kube, pc)
+	if err != nil {
+		return nil, err
+	}
+
+	creds, err := hana.ConnectionCredentials(ctx, c.kube, pc, data)
+	if err != nil {
+		return nil, fmt.Errorf(errGetTLS, err)
+	}
+
+	conn, err := c.db.Connect(ctx, creds)
+	if err != nil {
+		return nil, fmt.Errorf("cannot connect to HANA DB: %w", err)
+	}
+
+	if err := hana.ReportActiveEndpoint(ctx, c.kube, pc, conn); err != nil {
+		c.log.Info("Cannot report active endpoint", "providerConfig", pc.Name, "error", err)
+	}
+
+	return &external{
+		client: c.newClient(conn),
+		log:    c.log,
+	}, nil
+}
+
+// An ExternalClient runs the create statement of a Singleton if its
+// observation query does not find the artifact, and the drop statement when
+// the Singleton is deleted. Singletons have nothing to update.
+type external struct {
+	client singleton.SingletonClient
+	log    logging.Logger
+}
+
+func (c *external) Disconnect(ctx context.Context) error {
+	return nil
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.Singleton)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotSingleton)
+	}
+
+	if meta.WasDeleted(cr) && cr.Spec.ForProvider.DropStatement == "" {
+		// The artifact is kept, so the Singleton is gone once deleted
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	observed, err := c.client.Read(ctx, &cr.Spec.ForProvider)
+	if err != nil {
+		return managed.ExternalObservation{}, fmt.Errorf(errObserve, err)
+	}
+	cr.Status.AtProvider = *observed
+
+	if !observed.Exists {
+		// A query that does not find what the statement created would run
+		// the statement on every reconcile
+		if !meta.WasDeleted(cr) && !meta.GetExternalCreateSucceeded(cr).IsZero() {
+			return managed.ExternalObservation{}, errors.New(errNotSeen)
+		}
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	cr.SetConditions(xpv1.Available())
+	return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, nil
+}
+
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.Singleton)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotSingleton)
+	}
+
+	cr.SetConditions(xpv1.Creating())
+
+	if err := c.client.Create(ctx, &cr.Spec.ForProvider); err != nil {
+		return managed.ExternalCreation{}, fmt.Errorf(errCreate, err)
+	}
+
+	c.log.Info("Ran create statement of singleton", "name", cr.Name)
+	return managed.ExternalCreation{}, nil
+}
+
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	if _, ok := mg.(*v1alpha1.Singleton); !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotSingleton)
+	}
+	return managed.ExternalUpdate{}, nil
+}
+
+func (c *external) Delete(ctx context.Context, mg resource.Managed) (managed.ExternalDelete, error) {
+	cr, ok := mg.(*v1alpha1.Singleton)
+	if !ok {
+		return managed.ExternalDelete{}, errors.New(errNotSingleton)
+	}
+
+	cr.SetConditions(xpv1.Deleting())
+
+	if err := c.client.Delete(ctx, &cr.Spec.ForProvider); err != nil {
+		return managed.ExternalDelete{}, fmt.Errorf(errDrop, err)
+	}
+
+	c.log.Info("Ran drop statement of singleton", "name", cr.Name)
+	return managed.ExternalDelete{}, nil
+}
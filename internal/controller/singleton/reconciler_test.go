@@ -0,0 +1,169 @@
+/*
+Copyright 2026 SAP SE or an SAP affiliate company and contributors.
+*/
+
+package singleton
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/SAP/crossplane-provider-hana/apis/admin/v1alpha1"
+)
+
+type mockClient struct {
+	MockRead   func(ctx context.Context, parameters *v1alpha1.SingletonParameters) (*v1alpha1.SingletonObservation, error)
+	MockCreate func(ctx context.Context, parameters *v1alpha1.SingletonParameters) error
+	MockDelete func(ctx context.Context, parameters *v1alpha1.SingletonParameters) error
+}
+
+func (m mockClient) Read(ctx context.Context, parameters *v1alpha1.SingletonParameters) (*v1alpha1.SingletonObservation, error) {
+	return m.MockRead(ctx, parameters)
+}
+
+func (m mockClient) Create(ctx context.Context, parameters *v1alpha1.SingletonParameters) error {
+	return m.MockCreate(ctx, parameters)
+}
+
+func (m mockClient) Delete(ctx context.Context, parameters *v1alpha1.SingletonParameters) error {
+	return m.MockDelete(ctx, parameters)
+}
+
+type singletonModifier func(*v1alpha1.Singleton)
+
+func withDropStatement(s string) singletonModifier {
+	return func(cr *v1alpha1.Singleton) { cr.Spec.ForProvider.DropStatement = s }
+}
+
+func withCreateSucceeded() singletonModifier {
+	return func(cr *v1alpha1.Singleton) { meta.SetExternalCreateSucceeded(cr, time.Now()) }
+}
+
+func withDeleted() singletonModifier {
+	return func(cr *v1alpha1.Singleton) { cr.SetDeletionTimestamp(&metav1.Time{Time: time.Now()}) }
+}
+
+func newSingleton(m ...singletonModifier) *v1alpha1.Singleton {
+	cr := &v1alpha1.Singleton{Spec: v1alpha1.SingletonSpec{ForProvider: v1alpha1.SingletonParameters{
+		ObservationQuery: "SELECT 1 FROM SYS.ST_SPATIAL_REFERENCE_SYSTEMS WHERE SRS_ID = 1000004326",
+		CreateStatement:  "CREATE SPATIAL REFERENCE SYSTEM ...",
+	}}}
+	for _, f := range m {
+		f(cr)
+	}
+	return cr
+}
+
+func TestObserve(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type want struct {
+		o     managed.ExternalObservation
+		read  bool
+		ready xpv1.Condition
+		err   error
+	}
+
+	cases := map[string]struct {
+		reason   string
+		cr       *v1alpha1.Singleton
+		observed *v1alpha1.SingletonObservation
+		err      error
+		want     want
+	}{
+		"ErrRead": {
+			reason: "Any errors encountered while running the observation query should be returned",
+			cr:     newSingleton(),
+			err:    errBoom,
+			want:   want{read: true, err: fmt.Errorf(errObserve, errBoom)},
+		},
+		"Missing": {
+			reason:   "A missing artifact should be created",
+			cr:       newSingleton(),
+			observed: &v1alpha1.SingletonObservation{},
+			want:     want{read: true},
+		},
+		"Exists": {
+			reason:   "An existing artifact should be adopted and up to date",
+			cr:       newSingleton(),
+			observed: &v1alpha1.SingletonObservation{Exists: true},
+			want:     want{o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, read: true, ready: xpv1.Available()},
+		},
+		"NotSeenAfterCreate": {
+			reason:   "The statement should not run again if the query does not find what it created",
+			cr:       newSingleton(withCreateSucceeded()),
+			observed: &v1alpha1.SingletonObservation{},
+			want:     want{read: true, err: errors.New(errNotSeen)},
+		},
+		"DeletedWithDropStatement": {
+			reason:   "A deleted Singleton with a drop statement should exist until its artifact is dropped",
+			cr:       newSingleton(withDeleted(), withCreateSucceeded(), withDropStatement("DROP ...")),
+			observed: &v1alpha1.SingletonObservation{},
+			want:     want{read: true},
+		},
+		"DeletedKeep": {
+			reason: "A deleted Singleton without a drop statement should be gone without running the query",
+			cr:     newSingleton(withDeleted()),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			read := false
+			e := external{
+				client: mockClient{
+					MockRead: func(_ context.Context, _ *v1alpha1.SingletonParameters) (*v1alpha1.SingletonObservation, error) {
+						read = true
+						return tc.observed, tc.err
+					},
+				},
+				log: logging.NewNopLogger(),
+			}
+			got, err := e.Observe(context.Background(), tc.cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if read != tc.want.read {
+				t.Errorf("\n%s\ne.Observe(...): ran the observation query: %t, want %t", tc.reason, read, tc.want.read)
+			}
+			if tc.want.ready.Type == "" {
+				return
+			}
+			if diff := cmp.Diff(tc.want.ready, tc.cr.GetCondition(xpv1.TypeReady), test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want ready condition, +got ready condition:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCreateDelete(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	e := external{
+		client: mockClient{
+			MockCreate: func(_ context.Context, _ *v1alpha1.SingletonParameters) error { return errBoom },
+			MockDelete: func(_ context.Context, _ *v1alpha1.SingletonParameters) error { return errBoom },
+		},
+		log: logging.NewNopLogger(),
+	}
+	if _, err := e.Create(context.Background(), newSingleton()); !errors.Is(err, errBoom) {
+		t.Errorf("e.Create(...) = %v, want %v", err, errBoom)
+	}
+	if _, err := e.Delete(context.Background(), newSingleton(withDropStatement("DROP ..."))); !errors.Is(err, errBoom) {
+		t.Errorf("e.Delete(...) = %v, want %v", err, errBoom)
+	}
+}
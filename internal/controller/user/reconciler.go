@@ -5,23 +5,34 @@ import (
 	"errors"
 	"fmt"
 	"maps"
+	"net"
 	"slices"
+	"strconv"
 	"strings"
+	"time"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 
+	"github.com/SAP/crossplane-provider-hana/internal/clients/hana"
 	"github.com/SAP/crossplane-provider-hana/internal/clients/hana/privilege"
 	"github.com/SAP/crossplane-provider-hana/internal/clients/hana/user"
 	"github.com/SAP/crossplane-provider-hana/internal/clients/xsql"
 	"github.com/SAP/crossplane-provider-hana/internal/utils"
 
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
+	"github.com/crossplane/crossplane-runtime/pkg/connection"
 	"github.com/crossplane/crossplane-runtime/pkg/controller"
 	"github.com/crossplane/crossplane-runtime/pkg/event"
 	"github.com/crossplane/crossplane-runtime/pkg/logging"
@@ -29,49 +40,123 @@ import (
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 
 	"github.com/SAP/crossplane-provider-hana/apis/admin/v1alpha1"
+	schemav1alpha1 "github.com/SAP/crossplane-provider-hana/apis/schema/v1alpha1"
 	apisv1alpha1 "github.com/SAP/crossplane-provider-hana/apis/v1alpha1"
+	"github.com/SAP/crossplane-provider-hana/internal/controller/approval"
+	"github.com/SAP/crossplane-provider-hana/internal/controller/externalname"
 	"github.com/SAP/crossplane-provider-hana/internal/controller/features"
+	"github.com/SAP/crossplane-provider-hana/internal/controller/outcome"
+	"github.com/SAP/crossplane-provider-hana/internal/controller/poll"
+	"github.com/SAP/crossplane-provider-hana/internal/controller/x509provider"
 )
 
 const (
 	errNotUser                 = "managed resource is not a User custom resource"
 	errTrackPCUsage            = "cannot track ProviderConfig usage: %w"
 	errGetPC                   = "cannot get ProviderConfig: %w"
-	errNoSecretRef             = "ProviderConfig does not reference a credentials Secret"
 	errGetPasswordSecretFailed = "cannot get password secret: %w"
-	errGetSecret               = "cannot get credentials Secret: %w"
+	errGetExecutionUser        = "cannot get execution user: %w"
+	errGetTLS                  = "cannot get TLS configuration: %w"
 	errKeyNotFound             = "key %s not found in secret %s/%s"
-
-	errSelectUser       = "cannot select user: %w"
-	errCreateUser       = "cannot create user: %w"
-	errUpdateUser       = "cannot update user: %w"
-	errDropUser         = "cannot drop user: %w"
-	errFilterPrivileges = "cannot filter privileges: %w"
-
-	msgNotValidSecret = "Object is not a valid secret"
-	msgListFailed     = "Failed to list users"
+	errNoSecretStore           = "the password is in an external secret store, but external secret stores are not enabled"
+	errGetPasswordStore        = "cannot get password from secret store: %w"
+	errStoreKeyNotFound        = "key %s not found in secret %s of secret store %s"
+
+	errSelectUser             = "cannot select user: %w"
+	errCreateUser             = "cannot create user: %w"
+	errUpdateUser             = "cannot update user: %w"
+	errDropUser               = "cannot drop user: %w"
+	errProtected              = "cannot drop user %s: the User is protected by its protectionPolicy"
+	errFilterPrivileges       = "cannot filter privileges: %w"
+	errRenameUser             = "username cannot be changed from %s to %s, set the " + v1alpha1.AnnotationRenamePolicy + " annotation to " + v1alpha1.RenamePolicyRecreate + " to recreate the user"
+	errOperatorUsergroup      = "usergroup operator mode only manages users of usergroup %s, not %s"
+	errConvertRestricted      = "cannot convert between restricted and standard user in place, the user has to be recreated"
+	errRevocationsUnconfirmed = "%d pending revocations exceed the revocation threshold of %d; set the %s annotation to %d to revoke them"
+	errReadComment            = "cannot read user comment: %w"
+	errCheckTargets           = "cannot check privilege targets: %w"
+	errCheckDependencies      = "cannot check X.509 dependencies: %w"
+	errSetComment             = "cannot set user comment: %w"
+	errIndexSecretRef         = "cannot index users by password secret: %w"
+	errIndexPCRef             = "cannot index users by provider config: %w"
+
+	msgNotValidSecret  = "Object is not a valid secret"
+	msgListFailed      = "Failed to list users"
+	msgListPCFailed    = "Failed to list provider configs"
+	msgAspectsPending  = "Waiting for %s to converge"
+	msgDependencies    = "Waiting for %s to become ready"
+	msgDefaultsApplied = "Granted default privileges and roles: %s"
+	msgDefaultsSkipped = "Skipped default privileges and roles: %s"
+	msgUpdating        = "Updating user: %s"
+
+	reasonDefaultsApplied  event.Reason = "DefaultsApplied"
+	reasonDefaultsSkipped  event.Reason = "DefaultsSkipped"
+	reasonUpdating         event.Reason = "UpdatingUser"
+	reasonPasswordExpiring event.Reason = "PasswordExpiring"
+
+	// passwordExpiryWarningDays is the default number of days before its
+	// expiry that a password is reported as expiring.
+	passwordExpiryWarningDays = 14
+
+	usergroupDefault = "DEFAULT"
+	roleDefault      = "PUBLIC"
+
+	// connectionDetailCACertificate is the connection detail key of the CA
+	// certificate of the endpoint, as expected when mounting it as a file.
+	connectionDetailCACertificate = "ca.crt"
+
+	// passwordSecretRefIndex indexes Users by the namespace/name of their
+	// password Secret.
+	passwordSecretRefIndex = "spec.forProvider.authentication.password.passwordSecretRef"
+
+	// providerConfigRefIndex indexes Users by the name of their ProviderConfig.
+	providerConfigRefIndex = "spec.providerConfigRef.name"
 )
 
-// Setup adds a controller that reconciles User managed resources.
-func Setup(mgr ctrl.Manager, o controller.Options, db xsql.Connector) error {
+// Setup adds a controller that reconciles User managed resources. Changes of
+// password Secrets only enqueue Users if the Secrets are in one of the
+// secretNamespaces, or in any namespace if there are none.
+func Setup(mgr ctrl.Manager, o controller.Options, db xsql.Connector, secretNamespaces []string) error {
 	name := managed.ControllerName(v1alpha1.UserGroupKind)
 
+	cps := []managed.ConnectionPublisher{managed.NewAPISecretPublisher(mgr.GetClient(), mgr.GetScheme())}
+	var store secretStore
+	if o.Features.Enabled(features.EnableAlphaExternalSecretStores) {
+		dm := connection.NewDetailsManager(mgr.GetClient(), apisv1alpha1.StoreConfigGroupVersionKind)
+		cps = append(cps, dm)
+		store = dm
+	}
+
 	log := o.Logger.WithValues("controller", name)
 	t := resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1alpha1.ProviderConfigUsage{})
+	recorder := event.NewAPIRecorder(mgr.GetEventRecorderFor(name))
 	r := managed.NewReconciler(mgr,
 		resource.ManagedKind(v1alpha1.UserGroupVersionKind),
-		managed.WithExternalConnecter(&connector{
+		outcome.WithExternalConnecter(name, &connector{
 			kube:      mgr.GetClient(),
 			usage:     t,
 			newClient: user.New,
 			log:       log,
+			recorder:  recorder,
 			db:        db,
+			store:     store,
+
+			approvalsStamped: o.Features.Enabled(features.AdmissionWebhooks),
 		}),
 		managed.WithLogger(log),
+		managed.WithInitializers(externalname.NewInitializer(mgr.GetClient(), nameField)),
 		managed.WithPollInterval(o.PollInterval),
-		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+		poll.WithJitterHook(v1alpha1.UserKind, nil),
+		managed.WithRecorder(recorder),
+		managed.WithConnectionPublishers(cps...),
 		features.ConfigureBetaManagementPolicies(o))
 
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &v1alpha1.User{}, passwordSecretRefIndex, indexPasswordSecretRef); err != nil {
+		return fmt.Errorf(errIndexSecretRef, err)
+	}
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &v1alpha1.User{}, providerConfigRefIndex, indexProviderConfigRef); err != nil {
+		return fmt.Errorf(errIndexPCRef, err)
+	}
+
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
 		For(&v1alpha1.User{}).
@@ -80,10 +165,39 @@ func Setup(mgr ctrl.Manager, o controller.Options, db xsql.Connector) error {
 			handler.EnqueueRequestsFromMapFunc(handler.MapFunc(func(ctx context.Context, obj client.Object) []reconcile.Request {
 				return generateReconcileRequestsFromSecret(ctx, obj, mgr.GetClient(), log)
 			})),
+			builder.WithPredicates(predicate.NewPredicateFuncs(inNamespaces(secretNamespaces))),
+		).
+		Watches(
+			&apisv1alpha1.ProviderConfig{},
+			handler.EnqueueRequestsFromMapFunc(handler.MapFunc(func(ctx context.Context, obj client.Object) []reconcile.Request {
+				return generateReconcileRequestsFromProviderConfigs(ctx, []string{obj.GetName()}, mgr.GetClient(), log)
+			})),
+			builder.WithPredicates(predicate.GenerationChangedPredicate{}),
+		).
+		Watches(
+			&corev1.Secret{},
+			handler.EnqueueRequestsFromMapFunc(handler.MapFunc(func(ctx context.Context, obj client.Object) []reconcile.Request {
+				return generateReconcileRequestsFromCredentialsSecret(ctx, obj, mgr.GetClient(), log)
+			})),
+		).
+		Watches(
+			&schemav1alpha1.DbSchema{},
+			handler.EnqueueRequestsFromMapFunc(handler.MapFunc(func(ctx context.Context, obj client.Object) []reconcile.Request {
+				return generateReconcileRequestsFromSchema(ctx, mgr.GetClient(), log)
+			})),
 		).
 		Complete(r)
 }
 
+// nameField maps the external name to the username.
+func nameField(mg resource.Managed) *string {
+	cr, ok := mg.(*v1alpha1.User)
+	if !ok {
+		return nil
+	}
+	return &cr.Spec.ForProvider.Username
+}
+
 func generateReconcileRequestsFromSecret(ctx context.Context, obj client.Object, kube client.Client, log logging.Logger) []reconcile.Request {
 	log.Info("Enqueueing requests from secret")
 	secret, ok := obj.(*corev1.Secret)
@@ -93,7 +207,7 @@ func generateReconcileRequestsFromSecret(ctx context.Context, obj client.Object,
 	}
 
 	users := &v1alpha1.UserList{}
-	if err := kube.List(ctx, users); err != nil {
+	if err := kube.List(ctx, users, client.MatchingFields{passwordSecretRefIndex: secret.GetNamespace() + "/" + secret.GetName()}); err != nil {
 		log.Info(msgListFailed, "error", err)
 		return []reconcile.Request{}
 	}
@@ -118,6 +232,106 @@ func generateReconcileRequestsFromSecret(ctx context.Context, obj client.Object,
 	return requests
 }
 
+// indexPasswordSecretRef returns the namespace/name of the password Secret of
+// a User, so that a Secret event only lists the Users referencing it.
+func indexPasswordSecretRef(obj client.Object) []string {
+	cr, ok := obj.(*v1alpha1.User)
+	if !ok {
+		return nil
+	}
+	password := cr.Spec.ForProvider.Authentication.Password
+	if password == nil || password.PasswordSecretRef == nil {
+		return nil
+	}
+	return []string{password.PasswordSecretRef.Namespace + "/" + password.PasswordSecretRef.Name}
+}
+
+// indexProviderConfigRef returns the name of the ProviderConfig of a User, so
+// that a ProviderConfig change only enqueues the Users using it.
+func indexProviderConfigRef(obj client.Object) []string {
+	cr, ok := obj.(*v1alpha1.User)
+	if !ok || cr.GetProviderConfigReference() == nil {
+		return nil
+	}
+	return []string{cr.GetProviderConfigReference().Name}
+}
+
+// generateReconcileRequestsFromProviderConfigs enqueues the Users of the given
+// ProviderConfigs, so a changed endpoint or credentials are picked up without
+// waiting for the poll interval.
+func generateReconcileRequestsFromProviderConfigs(ctx context.Context, names []string, kube client.Client, log logging.Logger) []reconcile.Request {
+	requests := []reconcile.Request{}
+	for _, name := range names {
+		users := &v1alpha1.UserList{}
+		if err := kube.List(ctx, users, client.MatchingFields{providerConfigRefIndex: name}); err != nil {
+			log.Info(msgListFailed, "error", err)
+			return []reconcile.Request{}
+		}
+		for _, user := range users.Items {
+			if ref := user.GetProviderConfigReference(); ref != nil && ref.Name == name {
+				requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: user.Name}})
+			}
+		}
+	}
+	return requests
+}
+
+// generateReconcileRequestsFromCredentialsSecret enqueues the Users of the
+// ProviderConfigs whose credentials are stored in the given Secret.
+func generateReconcileRequestsFromCredentialsSecret(ctx context.Context, obj client.Object, kube client.Client, log logging.Logger) []reconcile.Request {
+	pcs := &apisv1alpha1.ProviderConfigList{}
+	if err := kube.List(ctx, pcs); err != nil {
+		log.Info(msgListPCFailed, "error", err)
+		return []reconcile.Request{}
+	}
+
+	var names []string
+	for _, pc := range pcs.Items {
+		if ref := pc.Spec.Credentials.ConnectionSecretRef; ref != nil &&
+			ref.Namespace == obj.GetNamespace() &&
+			ref.Name == obj.GetName() {
+			names = append(names, pc.Name)
+		}
+	}
+	return generateReconcileRequestsFromProviderConfigs(ctx, names, kube, log)
+}
+
+// inNamespaces returns a filter that accepts objects in the given namespaces,
+// or all objects if none are given. Each value may hold a comma-separated list
+// of namespaces.
+func inNamespaces(namespaces []string) func(client.Object) bool {
+	allowed := map[string]bool{}
+	for _, value := range namespaces {
+		for _, ns := range strings.Split(value, ",") {
+			if ns = strings.TrimSpace(ns); ns != "" {
+				allowed[ns] = true
+			}
+		}
+	}
+	return func(obj client.Object) bool {
+		return len(allowed) == 0 || allowed[obj.GetNamespace()]
+	}
+}
+
+// generateReconcileRequestsFromSchema enqueues the users holding back
+// privileges on missing objects, so that they are granted as soon as a
+// managed schema appears.
+func generateReconcileRequestsFromSchema(ctx context.Context, kube client.Client, log logging.Logger) []reconcile.Request {
+	users := &v1alpha1.UserList{}
+	if err := kube.List(ctx, users); err != nil {
+		log.Info(msgListFailed, "error", err)
+		return []reconcile.Request{}
+	}
+
+	requests := []reconcile.Request{}
+	for _, user := range users.Items {
+		if user.GetCondition(apisv1alpha1.TypeGrantTargets).Reason == apisv1alpha1.ReasonMissingTarget {
+			requests = append(requests, reconcile.Request{NamespacedName: types.NamespacedName{Name: user.Name}})
+		}
+	}
+	return requests
+}
+
 // A connector is expected to produce an ExternalClient when its Connect method
 // is called.
 type connector struct {
@@ -126,6 +340,17 @@ type connector struct {
 	usage     resource.Tracker
 	newClient func(xsql.DB, string) user.Client
 	log       logging.Logger
+	recorder  event.Recorder
+	store     secretStore
+
+	// approvalsStamped is set if the admission webhook stamps the approval
+	// annotations of Users
+	approvalsStamped bool
+}
+
+// A secretStore reads the secrets of external secret stores.
+type secretStore interface {
+	FetchConnection(ctx context.Context, so resource.ConnectionSecretOwner) (managed.ConnectionDetails, error)
 }
 
 // Connect typically produces an ExternalClient by:
@@ -148,38 +373,161 @@ func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 		return nil, fmt.Errorf(errGetPC, err)
 	}
 
-	ref := pc.Spec.Credentials.ConnectionSecretRef
-	if ref == nil {
-		return nil, errors.New(errNoSecretRef)
-	}
-
-	secret := &corev1.Secret{}
-	if err := c.kube.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, secret); err != nil {
-		return nil, fmt.Errorf(errGetSecret, err)
+	data, err := hana.Credentials(ctx, c.kube, pc)
+	if err != nil {
+		return nil, err
 	}
 
 	c.log.Info("Connecting to user resource", "name", cr.Name)
 
-	username := string(secret.Data[xpv1.ResourceCredentialsSecretUserKey])
+	creds, err := hana.ConnectionCredentials(ctx, c.kube, pc, data)
+	if err != nil {
+		return nil, fmt.Errorf(errGetTLS, err)
+	}
+
+	// Manage the user as its execution user, if it names one
+	creds, err = hana.ExecutionCredentials(ctx, c.kube, cr.Spec.ForProvider.ExecutionUserSecretRef, creds)
+	if err != nil {
+		return nil, fmt.Errorf(errGetExecutionUser, err)
+	}
+	username := string(creds[xpv1.ResourceCredentialsSecretUserKey])
 
-	conn, err := c.db.Connect(ctx, secret.Data)
+	conn, err := c.db.Connect(ctx, creds)
 	if err != nil {
 		return nil, fmt.Errorf("cannot connect to HANA DB: %w", err)
 	}
 
+	if err := hana.ReportActiveEndpoint(ctx, c.kube, pc, conn); err != nil {
+		c.log.Info("Cannot report active endpoint", "providerConfig", pc.Name, "error", err)
+	}
+
+	e := newExternal(c.kube, c.newClient(conn, username), conn, pc, creds, c.log)
+	if c.recorder != nil {
+		e.recorder = c.recorder
+	}
+	e.store = c.store
+	e.approvalsStamped = c.approvalsStamped
+	return e, nil
+}
+
+// NewObserver returns an ExternalClient for Users of the ProviderConfig that
+// shares an existing connection. Callers must only call Observe, which never
+// changes the catalog. The connection credentials are those of the
+// connection.
+func NewObserver(kube client.Client, conn xsql.DB, pc *apisv1alpha1.ProviderConfig, creds map[string][]byte, log logging.Logger) managed.ExternalClient {
+	username := string(creds[xpv1.ResourceCredentialsSecretUserKey])
+	return newExternal(kube, user.New(conn, username), conn, pc, creds, log)
+}
+
+func newExternal(kube client.Client, cl user.Client, conn xsql.DB, pc *apisv1alpha1.ProviderConfig, creds map[string][]byte, log logging.Logger) *external {
+	// Publish the endpoint the provider is actually connected to
+	endpoint := string(creds[xpv1.ResourceCredentialsSecretEndpointKey])
+	port := string(creds[xpv1.ResourceCredentialsSecretPortKey])
+	if host, p, err := net.SplitHostPort(hana.Endpoint(conn)); err == nil {
+		endpoint, port = host, p
+	}
+
+	var operatorUsergroup string
+	if op := pc.Spec.UsergroupOperator; op != nil {
+		operatorUsergroup = op.Usergroup
+		cl = cl.ForUsergroupOperator(operatorUsergroup)
+	}
+	if pc.Spec.CatalogRead {
+		cl = cl.WithCatalogRead()
+	}
+
 	return &external{
-		client: c.newClient(conn, username),
-		kube:   c.kube,
-		log:    c.log,
-	}, nil
+		client:            cl,
+		operatorUsergroup: operatorUsergroup,
+		defaultUsergroup:  pc.Spec.DefaultUsergroup,
+		defaultPrivilege:  pc.Spec.DefaultPrivilege,
+		kube:              kube,
+		log:               log,
+		grantPolicy:       pc.Spec.GrantPolicy,
+		comments:          pc.Spec.ObjectComments,
+		identifierCase:    pc.Spec.IdentifierCase,
+		db:                conn,
+		endpoint:          endpoint,
+		port:              port,
+		caCertificate:     hana.ServerCA(conn),
+		recorder:          event.NewNopRecorder(),
+
+		passwordExpiryWarning: passwordExpiryWarning(pc),
+	}
+}
+
+// passwordExpiryWarning returns the password expiry warning period of a
+// ProviderConfig in days.
+func passwordExpiryWarning(pc *apisv1alpha1.ProviderConfig) int32 {
+	if days := pc.Spec.PasswordExpiryWarningDays; days != nil {
+		return *days
+	}
+	return passwordExpiryWarningDays
+}
+
+// observePasswordExpiry reports the days until the password of a user
+// expires, and whether it expires within the warning period. A warning event
+// is recorded when the password enters the warning period or expires.
+func (c *external) observePasswordExpiry(cr *v1alpha1.User, now time.Time) {
+	status := &cr.Status.AtProvider
+	expiresAt := status.PasswordExpiresAt
+	if expiresAt == nil {
+		status.DaysUntilPasswordExpiry = nil
+		// Only clear a condition reported before, users whose password does
+		// not expire do not get one
+		if cr.GetCondition(apisv1alpha1.TypePasswordExpiring).Status == corev1.ConditionTrue {
+			cr.SetConditions(apisv1alpha1.PasswordValid())
+		}
+		return
+	}
+
+	days := int32(expiresAt.Sub(now) / (24 * time.Hour))
+	status.DaysUntilPasswordExpiry = &days
+
+	previous := cr.GetCondition(apisv1alpha1.TypePasswordExpiring).Reason
+	var condition xpv1.Condition
+	switch {
+	case !expiresAt.After(now):
+		condition = apisv1alpha1.PasswordExpired(*expiresAt)
+	case days < c.passwordExpiryWarning:
+		condition = apisv1alpha1.PasswordExpiresSoon(*expiresAt)
+	default:
+		cr.SetConditions(apisv1alpha1.PasswordValid())
+		return
+	}
+	cr.SetConditions(condition)
+	if condition.Reason != previous {
+		c.recorder.Event(cr, event.Warning(reasonPasswordExpiring, errors.New(condition.Message)))
+	}
 }
 
 // An ExternalClient observes, then either creates, updates, or deletes an
 // external resource to ensure it reflects the managed resource's desired state.
 type external struct {
-	client user.UserClient
-	kube   client.Client
-	log    logging.Logger
+	client      user.UserClient
+	kube        client.Client
+	log         logging.Logger
+	grantPolicy *apisv1alpha1.GrantPolicy
+	comments    *apisv1alpha1.ObjectCommentsConfig
+	db          xsql.DB
+	endpoint    string
+	port        string
+	recorder    event.Recorder
+	store       secretStore
+
+	// caCertificate is the PEM encoded CA certificate of the endpoint
+	caCertificate []byte
+
+	operatorUsergroup string
+	defaultUsergroup  string
+	defaultPrivilege  string
+	identifierCase    string
+
+	// passwordExpiryWarning is how many days before its expiry a password
+	// is reported as expiring
+	passwordExpiryWarning int32
+
+	approvalsStamped bool
 }
 
 func (c *external) Disconnect(ctx context.Context) error {
@@ -195,9 +543,20 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 
 	c.log.Info("Observing user resource", "name", cr.Name)
 
-	parameters := handleDefaults(cr)
+	parameters := handleDefaults(cr, c.defaultUsergroup, c.defaultPrivilege, c.identifierCase)
+
+	renamed, err := checkRename(cr, parameters.Username)
+	if err != nil {
+		c.log.Info("Error handling changed username", "name", cr.Name, "error", err)
+		return managed.ExternalObservation{}, err
+	}
+	if renamed {
+		// The user of the previous username is dropped in Update, so that
+		// Observe never changes the catalog
+		c.log.Info("User of previous username waiting to be dropped", "name", cr.Name, "previous", *cr.Status.AtProvider.Username, "username", parameters.Username)
+		return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false}, nil
+	}
 
-	var err error
 	parameters.Privileges, err = privilege.FormatPrivilegeStrings(parameters.Privileges, c.client.GetDefaultSchema())
 	if err != nil {
 		c.log.Info("Error converting privileges", "name", cr.Name, "error", err)
@@ -229,44 +588,325 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 		return managed.ExternalObservation{ResourceExists: false}, nil
 	}
 
-	observed, err = privilege.FilterManagedPrivileges(observed, parameters.Privileges, cr.Status.AtProvider.Privileges, cr.Spec.PrivilegeManagementPolicy, c.client.GetDefaultSchema())
+	if !ignored(observed, parameters, user.FieldPrivileges) {
+		if err := c.holdMissingTargets(ctx, cr, parameters); err != nil {
+			return managed.ExternalObservation{}, err
+		}
+	}
+
+	observed, err = privilege.FilterManagedPrivileges(observed, parameters.Privileges, cr.Status.AtProvider.Privileges, cr.Spec.PrivilegeManagementPolicy, c.client.GetDefaultSchema(), cr.Spec.PrivilegeSelectors...)
 	if err != nil {
 		c.log.Info("Error filtering managed privileges", "name", cr.Name, "error", err)
 		return managed.ExternalObservation{}, fmt.Errorf(errFilterPrivileges, err)
 	}
 
+	observed.Privileges, err = withoutOwnerPrivilege(observed.Privileges, parameters, c.client.GetDefaultSchema())
+	if err != nil {
+		return managed.ExternalObservation{}, fmt.Errorf("cannot convert privileges: %w", err)
+	}
+
+	unrevokable := cr.Status.AtProvider.UnrevokableRoles
+	if ignored(observed, parameters, user.FieldRoles) {
+		observed.UnrevokableRoles = unrevokable
+	} else {
+		observed.Roles, observed.UnrevokableRoles = holdUnrevokableRoles(observed.Roles, parameters.Roles, unrevokable)
+	}
+
 	cr.Status.AtProvider = *observed
+	cr.Status.AtProvider.ExternalID = parameters.Username
+	switch {
+	case len(observed.UnrevokableRoles) > 0:
+		cr.SetConditions(apisv1alpha1.RolesNotRevoked(utils.SortedSet(observed.UnrevokableRoles)))
+	case len(unrevokable) > 0:
+		cr.SetConditions(apisv1alpha1.RolesRevoked())
+	}
+	privileges, roles := defaultGrants(cr, parameters.Username, c.defaultPrivilege)
+	cr.Status.AtProvider.AppliedDefaults = append(privileges, roles...)
+	cr.Status.AtProvider.PendingRevocations = pendingRevocations(observed, parameters)
+	if c.grantPolicy != nil && c.grantPolicy.RevocationThreshold != nil {
+		if err := c.checkRevocations(cr, cr.Status.AtProvider.PendingRevocations); err != nil {
+			cr.SetConditions(apisv1alpha1.RevocationsUnconfirmed(err))
+		} else {
+			cr.SetConditions(apisv1alpha1.RevocationsConfirmed())
+		}
+	}
+	c.observePasswordExpiry(cr, time.Now())
+
+	if c.grantPolicy != nil {
+		if err := privilege.CheckGrantPolicy(c.grantPolicy, parameters.Privileges, parameters.Roles, c.client.GetDefaultSchema()); err != nil {
+			cr.SetConditions(apisv1alpha1.GrantForbidden(err))
+		} else {
+			cr.SetConditions(apisv1alpha1.GrantAllowed())
+		}
+	}
+
+	// The user is only available once all managed aspects converged, so
+	// grants that keep failing in Update do not go unnoticed
+	aspects := observeAspects(observed, parameters)
+	if cr.GetCondition(apisv1alpha1.TypeGrantTargets).Reason == apisv1alpha1.ReasonMissingTarget {
+		aspects.Privileges = false
+	}
+	cr.Status.AtProvider.Aspects = aspects
+	normalizeStatus(&cr.Status.AtProvider)
+
+	var unready []string
+	if parameters.Authentication.WaitForDependencies {
+		if unready, err = c.unreadyDependencies(ctx, cr, parameters.Authentication.X509Providers); err != nil {
+			return managed.ExternalObservation{}, fmt.Errorf(errCheckDependencies, err)
+		}
+	}
 
 	// Set condition based on authentication errors or normal availability
-	if authError != nil {
+	switch pending := pendingAspects(aspects); {
+	case authError != nil:
 		cr.SetConditions(xpv1.Unavailable().WithMessage(authError.Error()))
-	} else {
+	case len(pending) > 0:
+		cr.SetConditions(xpv1.Unavailable().WithMessage(fmt.Sprintf(msgAspectsPending, strings.Join(pending, ", "))))
+	case len(unready) > 0:
+		cr.SetConditions(xpv1.Unavailable().WithMessage(fmt.Sprintf(msgDependencies, strings.Join(unready, ", "))))
+	default:
 		cr.SetConditions(xpv1.Available())
 	}
 
-	isUpToDate := upToDate(observed, parameters)
+	isUpToDate := upToDate(observed, parameters) && isResourceTagUpToDate(observed, cr.Name)
+	if isUpToDate {
+		if isUpToDate, err = c.isCommentUpToDate(ctx, cr, parameters.Username); err != nil {
+			c.log.Info("Error reading user comment", "name", cr.Name, "error", err)
+			return managed.ExternalObservation{}, fmt.Errorf(errReadComment, err)
+		}
+	}
 
 	c.log.Info("Observed user resource",
 		"name", cr.Name,
 		"username", parameters.Username,
 		"upToDate", isUpToDate)
 
+	// The password is only published once it is set in HANA, so that the
+	// connection secret never runs ahead of a pending password change
+	if observed.PasswordUpToDate == nil || !*observed.PasswordUpToDate {
+		password = ""
+	}
+
 	return managed.ExternalObservation{
-		ResourceExists:   true,
-		ResourceUpToDate: isUpToDate,
+		ResourceExists:    true,
+		ResourceUpToDate:  isUpToDate,
+		ConnectionDetails: c.connectionDetails(parameters, password),
 	}, nil
 }
 
+// holdMissingTargets removes the privileges on schemas and objects that do
+// not exist yet from the parameters, so that they are not granted on every
+// reconcile, and reports the missing objects in the GrantTargets condition.
+// The privileges are granted once the objects appear.
+func (c *external) holdMissingTargets(ctx context.Context, cr *v1alpha1.User, parameters *v1alpha1.UserParameters) error {
+	missing, err := c.client.MissingTargets(ctx, parameters.Privileges)
+	if err != nil {
+		c.log.Info("Error checking privilege targets", "name", cr.Name, "error", err)
+		return fmt.Errorf(errCheckTargets, err)
+	}
+	if len(missing) == 0 {
+		if cr.GetCondition(apisv1alpha1.TypeGrantTargets).Reason == apisv1alpha1.ReasonMissingTarget {
+			cr.SetConditions(apisv1alpha1.GrantTargetsAvailable())
+		}
+		return nil
+	}
+
+	var objects []string
+	for _, m := range missing {
+		parameters.Privileges = slices.DeleteFunc(parameters.Privileges, func(p string) bool { return p == m.Privilege })
+		if !slices.Contains(objects, m.Object) {
+			objects = append(objects, m.Object)
+		}
+	}
+	c.log.Info("Holding back privileges on missing objects", "name", cr.Name, "objects", objects)
+	cr.SetConditions(apisv1alpha1.GrantTargetsMissing(objects))
+	return nil
+}
+
+// checkRename compares the username with the one last observed. A changed
+// username is rejected unless the User opts into recreation, in which case it
+// reports that the user of the previous username is to be dropped.
+func checkRename(cr *v1alpha1.User, username string) (bool, error) {
+	previous := cr.Status.AtProvider.Username
+	if previous == nil || *previous == "" || *previous == username {
+		return false, nil
+	}
+	if cr.GetAnnotations()[v1alpha1.AnnotationRenamePolicy] != v1alpha1.RenamePolicyRecreate {
+		return false, fmt.Errorf(errRenameUser, *previous, username)
+	}
+	if cr.Spec.ProtectionPolicy == v1alpha1.ProtectionPolicyProtected {
+		return false, fmt.Errorf(errProtected, *previous)
+	}
+	return true, nil
+}
+
+// dropPreviousUser drops the user of the previous username if the User opts
+// into recreation, so that the user of the new username is created by the
+// next reconcile. It reports whether it dropped a user.
+func (c *external) dropPreviousUser(ctx context.Context, cr *v1alpha1.User, username string) (bool, error) {
+	renamed, err := checkRename(cr, username)
+	if err != nil || !renamed {
+		return false, err
+	}
+
+	previous := *cr.Status.AtProvider.Username
+	c.log.Info("Dropping user of previous username", "name", cr.Name, "previous", previous, "username", username)
+	if err := c.client.Delete(ctx, &v1alpha1.UserParameters{Username: previous, TerminateSessionsOnDelete: cr.Spec.ForProvider.TerminateSessionsOnDelete}); err != nil && !user.IsInvalidUserName(err) {
+		return false, fmt.Errorf(errDropUser, err)
+	}
+	cr.Status.AtProvider = v1alpha1.UserObservation{}
+	return true, nil
+}
+
+// connectionDetails returns the connection details of a user. The password
+// is left out when it is not managed through a secret.
+func (c *external) connectionDetails(parameters *v1alpha1.UserParameters, password string) managed.ConnectionDetails {
+	details := managed.ConnectionDetails{
+		"user":                                  []byte(parameters.Username),
+		externalname.ConnectionDetailExternalID: []byte(parameters.Username),
+	}
+	if password != "" {
+		details[xpv1.ResourceCredentialsSecretPasswordKey] = []byte(password)
+	}
+	if c.endpoint != "" {
+		details[xpv1.ResourceCredentialsSecretEndpointKey] = []byte(c.endpoint)
+	}
+	if c.port != "" {
+		details[xpv1.ResourceCredentialsSecretPortKey] = []byte(c.port)
+	}
+	if parameters.PublishCACertificate && len(c.caCertificate) > 0 {
+		details[connectionDetailCACertificate] = c.caCertificate
+	}
+	return details
+}
+
 func upToDate(observed *v1alpha1.UserObservation, desired *v1alpha1.UserParameters) bool {
-	return isPasswordUpToDate(observed, desired) &&
-		isX509MappingsUpToDate(observed, desired) &&
+	return len(pendingAspects(observeAspects(observed, desired))) == 0 &&
+		isRestrictedUpToDate(observed, desired) &&
+		isClientConnectUpToDate(observed, desired) &&
+		isValidityUpToDate(observed, desired) &&
 		observed.Usergroup != nil &&
 		*observed.Usergroup == desired.Usergroup &&
 		observed.IsPasswordLifetimeCheckEnabled != nil &&
-		*observed.IsPasswordLifetimeCheckEnabled == desired.IsPasswordLifetimeCheckEnabled &&
-		maps.Equal(observed.Parameters, desired.Parameters) &&
-		utils.ArraysEqual(observed.Privileges, desired.Privileges) &&
-		utils.ArraysEqual(observed.Roles, desired.Roles)
+		*observed.IsPasswordLifetimeCheckEnabled == desired.IsPasswordLifetimeCheckEnabled
+}
+
+// observeAspects reports which managed aspects of the user converged.
+func observeAspects(observed *v1alpha1.UserObservation, desired *v1alpha1.UserParameters) *v1alpha1.UserAspectsObservation {
+	return &v1alpha1.UserAspectsObservation{
+		Privileges:    ignored(observed, desired, user.FieldPrivileges) || utils.ArraysEqual(observed.Privileges, desired.Privileges),
+		Roles:         ignored(observed, desired, user.FieldRoles) || utils.ArraysEqual(observed.Roles, desired.Roles),
+		Parameters:    ignored(observed, desired, user.FieldParameters) || maps.Equal(observed.Parameters, desired.Parameters),
+		X509Providers: ignored(observed, desired, user.FieldX509Providers) || isX509MappingsUpToDate(observed, desired),
+		Password:      ignored(observed, desired, user.FieldPassword) || isPasswordUpToDate(observed, desired),
+	}
+}
+
+// holdUnrevokableRoles returns the observed roles as if the roles that could
+// not be revoked were, and those of them that are still granted. A role that
+// could not lose its admin option counts as granted as desired.
+func holdUnrevokableRoles(observed, desired, unrevokable []string) (roles, held []string) {
+	if len(unrevokable) == 0 {
+		return observed, nil
+	}
+	for _, r := range observed {
+		isUnrevokable := slices.ContainsFunc(unrevokable, func(u string) bool { return privilege.SameRole(r, u) })
+		if !isUnrevokable || slices.Contains(desired, r) {
+			roles = append(roles, r)
+			continue
+		}
+		held = append(held, r)
+		if i := slices.IndexFunc(desired, func(d string) bool { return privilege.SameRole(r, d) }); i >= 0 {
+			roles = append(roles, desired[i])
+		}
+	}
+	return roles, held
+}
+
+// pendingAspects returns the names of the aspects that did not converge.
+func pendingAspects(a *v1alpha1.UserAspectsObservation) []string {
+	var pending []string
+	for _, aspect := range []struct {
+		name      string
+		converged bool
+	}{
+		{"privileges", a.Privileges},
+		{"roles", a.Roles},
+		{"parameters", a.Parameters},
+		{"x509Providers", a.X509Providers},
+		{"password", a.Password},
+	} {
+		if !aspect.converged {
+			pending = append(pending, aspect.name)
+		}
+	}
+	return pending
+}
+
+// normalizeStatus sorts and deduplicates the slices of the observation, so the
+// status only changes when the user does and not with the order in which the
+// catalog returned its rows. Parameters is a map, whose keys are always
+// serialized in order.
+func normalizeStatus(o *v1alpha1.UserObservation) {
+	o.Privileges = utils.SortedSet(o.Privileges)
+	o.Roles = utils.SortedSet(o.Roles)
+	o.UnrevokableRoles = utils.SortedSet(o.UnrevokableRoles)
+	o.UnobservedFields = utils.SortedSet(o.UnobservedFields)
+	if len(o.X509Providers) > 0 {
+		providers := slices.Clone(o.X509Providers)
+		slices.SortFunc(providers, func(a, b v1alpha1.X509UserMapping) int {
+			return compareX509Mappings(a, b)
+		})
+		o.X509Providers = slices.CompactFunc(providers, func(a, b v1alpha1.X509UserMapping) bool {
+			return x509MappingKey(a) == x509MappingKey(b)
+		})
+	}
+}
+
+func compareX509Mappings(a, b v1alpha1.X509UserMapping) int {
+	return strings.Compare(x509MappingKey(a), x509MappingKey(b))
+}
+
+func x509MappingKey(m v1alpha1.X509UserMapping) string {
+	ref := ""
+	if m.ProviderRef != nil {
+		ref = m.ProviderRef.Name
+	}
+	return m.Name + "\x00" + ref + "\x00" + m.SubjectName
+}
+
+// isResourceTagUpToDate ignores the tag if the parameters could not be
+// observed.
+func isResourceTagUpToDate(observed *v1alpha1.UserObservation, name string) bool {
+	return unobserved(observed, user.FieldParameters) || observed.ManagedResource == name
+}
+
+// ignored returns true if field is not reconciled, either because it could not
+// be observed or because the User leaves it to another system.
+func ignored(observed *v1alpha1.UserObservation, desired *v1alpha1.UserParameters, field string) bool {
+	return unobserved(observed, field) || !manages(desired, field)
+}
+
+// manages returns false if the User opted out of reconciling field.
+func manages(desired *v1alpha1.UserParameters, field string) bool {
+	var flag *bool
+	switch field {
+	case user.FieldPrivileges:
+		flag = desired.ManagePrivileges
+	case user.FieldRoles:
+		flag = desired.ManageRoles
+	case user.FieldParameters:
+		flag = desired.ManageParameters
+	case user.FieldPassword:
+		flag = desired.ManagePassword
+	}
+	return flag == nil || *flag
+}
+
+// unobserved returns true if field could not be observed in usergroup
+// operator mode and is therefore not reconciled.
+func unobserved(observed *v1alpha1.UserObservation, field string) bool {
+	return slices.Contains(observed.UnobservedFields, field)
 }
 
 func isPasswordUpToDate(observed *v1alpha1.UserObservation, desired *v1alpha1.UserParameters) bool {
@@ -276,6 +916,37 @@ func isPasswordUpToDate(observed *v1alpha1.UserObservation, desired *v1alpha1.Us
 	return observed.PasswordUpToDate == nil
 }
 
+// isRestrictedUpToDate treats an unobserved restricted flag as up to date.
+func isRestrictedUpToDate(observed *v1alpha1.UserObservation, desired *v1alpha1.UserParameters) bool {
+	return observed.RestrictedUser == nil || *observed.RestrictedUser == desired.RestrictedUser
+}
+
+// isClientConnectUpToDate ignores client connect unless it is set.
+func isClientConnectUpToDate(observed *v1alpha1.UserObservation, desired *v1alpha1.UserParameters) bool {
+	if desired.ClientConnect == nil {
+		return true
+	}
+	return observed.IsClientConnectEnabled != nil && *observed.IsClientConnectEnabled == *desired.ClientConnect
+}
+
+// isValidityUpToDate ignores the validity period unless connection
+// restrictions are set. Timestamps are compared at second precision.
+func isValidityUpToDate(observed *v1alpha1.UserObservation, desired *v1alpha1.UserParameters) bool {
+	if desired.ConnectionRestrictions == nil {
+		return true
+	}
+	return timeEqual(observed.ValidUntil, desired.ConnectionRestrictions.ValidUntil) &&
+		(desired.ConnectionRestrictions.ValidFrom == nil ||
+			timeEqual(observed.ValidFrom, desired.ConnectionRestrictions.ValidFrom))
+}
+
+func timeEqual(a, b *metav1.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Truncate(time.Second).Equal(b.Truncate(time.Second))
+}
+
 func isX509MappingsUpToDate(observed *v1alpha1.UserObservation, desired *v1alpha1.UserParameters) bool {
 	if desired.Authentication.X509Providers != nil {
 		return utils.ArraysEqual(observed.X509Providers, desired.Authentication.X509Providers)
@@ -293,13 +964,25 @@ func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 
 	cr.SetConditions(xpv1.Creating())
 
-	parameters := &cr.Spec.ForProvider
+	parameters := handleDefaults(cr, c.defaultUsergroup, c.defaultPrivilege, c.identifierCase)
 
 	c.log.Info("Creating user with parameters",
 		"username", parameters.Username,
 		"restrictedUser", parameters.RestrictedUser,
 		"usergroup", parameters.Usergroup)
 
+	if err := c.checkOperatorUsergroup(parameters.Usergroup); err != nil {
+		return managed.ExternalCreation{}, fmt.Errorf(errCreateUser, err)
+	}
+
+	if err := c.holdMissingTargets(ctx, cr, parameters); err != nil {
+		return managed.ExternalCreation{}, fmt.Errorf(errCreateUser, err)
+	}
+
+	if err := c.enforceGrantPolicy(cr, parameters.Privileges, parameters.Roles); err != nil {
+		return managed.ExternalCreation{}, fmt.Errorf(errCreateUser, err)
+	}
+
 	password, pasErr := c.getPassword(ctx, cr)
 
 	if pasErr != nil {
@@ -308,7 +991,7 @@ func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 	}
 
 	// Get resolved X509 providers for user creation
-	providersToAdd, err := c.ResolveUserMappings(ctx, parameters.Authentication.X509Providers, cr.GetNamespace())
+	providersToAdd, err := c.ResolveUserMappings(ctx, cr, parameters.Authentication.X509Providers)
 	if err != nil {
 		c.log.Info("Error resolving user X.509 providers", "name", cr.Name, "error", err)
 		return managed.ExternalCreation{}, fmt.Errorf(errCreateUser, err)
@@ -319,13 +1002,20 @@ func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 		return managed.ExternalCreation{}, fmt.Errorf(errCreateUser, err)
 	}
 
+	// The user exists now, so a missing tag or comment is left to the next update
+	if err := c.client.UpdateResourceTag(ctx, parameters.Username, cr.Name); err != nil {
+		c.log.Info("Error tagging user", "name", cr.Name, "error", err)
+	}
+	if err := c.updateComment(ctx, cr, parameters.Username); err != nil {
+		c.log.Info("Error setting user comment", "name", cr.Name, "error", err)
+	}
+
+	c.recordDefaults(cr, parameters.Username)
+
 	c.log.Info("Successfully created user resource", "name", cr.Name, "username", parameters.Username)
 
 	return managed.ExternalCreation{
-		ConnectionDetails: managed.ConnectionDetails{
-			"user":     []byte(parameters.Username),
-			"password": []byte(password),
-		},
+		ConnectionDetails: c.connectionDetails(parameters, password),
 	}, nil
 }
 
@@ -334,49 +1024,162 @@ func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.Ext
 	if !ok {
 		return managed.ExternalUpdate{}, errors.New(errNotUser)
 	}
+	defer normalizeStatus(&cr.Status.AtProvider)
 
-	c.log.Info("Updating user resource", "name", cr.Name, "username", cr.Spec.ForProvider.Username)
+	if dropped, err := c.dropPreviousUser(ctx, cr, foldUsername(&cr.Spec.ForProvider, c.identifierCase)); err != nil || dropped {
+		return managed.ExternalUpdate{}, err
+	}
 
-	desired, observed, err := c.buildUpdateInputs(cr)
+	desired, observed, err := c.buildUpdateInputs(ctx, cr)
 	if err != nil {
 		return managed.ExternalUpdate{}, err
 	}
 
-	if err := c.updatePrivileges(ctx, cr, desired, observed); err != nil {
-		return managed.ExternalUpdate{}, err
+	if !isRestrictedUpToDate(observed, desired) {
+		return managed.ExternalUpdate{}, errors.New(errConvertRestricted)
 	}
 
-	if err := c.updateRoles(ctx, cr, desired, observed); err != nil {
+	if err := c.checkOperatorUsergroup(desired.Usergroup); err != nil {
+		return managed.ExternalUpdate{}, fmt.Errorf(errUpdateUser, err)
+	}
+
+	if err := c.checkRevocations(cr, pendingRevocations(observed, desired)); err != nil {
+		c.log.Info("Revocations waiting for confirmation", "name", cr.Name, "error", err)
+		return managed.ExternalUpdate{}, fmt.Errorf(errUpdateUser, err)
+	}
+
+	changes := describeUpdate(cr, desired, observed)
+	c.log.Info("Updating user resource", "name", cr.Name, "username", desired.Username, "changes", changes)
+	if changes != "" {
+		c.recorder.Event(cr, event.Normal(reasonUpdating, fmt.Sprintf(msgUpdating, changes)))
+	}
+
+	if !ignored(observed, desired, user.FieldPrivileges) {
+		if err := c.updatePrivileges(ctx, cr, desired, observed); err != nil {
+			return managed.ExternalUpdate{}, err
+		}
+	}
+
+	if !ignored(observed, desired, user.FieldRoles) {
+		if err := c.updateRoles(ctx, cr, desired, observed); err != nil {
+			return managed.ExternalUpdate{}, err
+		}
+	}
+
+	if !ignored(observed, desired, user.FieldParameters) {
+		if err := c.updateParameters(ctx, cr, desired, observed); err != nil {
+			return managed.ExternalUpdate{}, err
+		}
+	}
+
+	if err := c.updateUsergroup(ctx, cr, desired, observed); err != nil {
 		return managed.ExternalUpdate{}, err
 	}
 
-	if err := c.updateParameters(ctx, cr, desired, observed); err != nil {
+	if !ignored(observed, desired, user.FieldX509Providers) {
+		if err := c.updateX509Providers(ctx, cr, desired, observed); err != nil {
+			return managed.ExternalUpdate{}, err
+		}
+	}
+
+	if err := c.updatePasswordLifetimeCheck(ctx, cr, desired, observed); err != nil {
 		return managed.ExternalUpdate{}, err
 	}
 
-	if err := c.updateUsergroup(ctx, cr, desired, observed); err != nil {
+	if err := c.updateClientConnect(ctx, cr, desired, observed); err != nil {
 		return managed.ExternalUpdate{}, err
 	}
 
-	if err := c.updateX509Providers(ctx, cr, desired, observed); err != nil {
+	if err := c.updateValidity(ctx, cr, desired, observed); err != nil {
 		return managed.ExternalUpdate{}, err
 	}
 
-	if err := c.updatePasswordLifetimeCheck(ctx, cr, desired, observed); err != nil {
+	if err := c.updateResourceTag(ctx, cr, desired, observed); err != nil {
 		return managed.ExternalUpdate{}, err
 	}
 
-	if err := c.updatePassword(ctx, cr, desired); err != nil {
+	if err := c.updateComment(ctx, cr, desired.Username); err != nil {
+		c.log.Info("Error setting user comment", "name", cr.Name, "error", err)
 		return managed.ExternalUpdate{}, err
 	}
 
+	var password string
+	if !ignored(observed, desired, user.FieldPassword) {
+		if password, err = c.updatePassword(ctx, cr, desired); err != nil {
+			return managed.ExternalUpdate{}, err
+		}
+	}
+
 	c.log.Info("Successfully updated user resource", "name", cr.Name, "username", desired.Username)
-	return managed.ExternalUpdate{}, nil
+	if password == "" {
+		return managed.ExternalUpdate{}, nil
+	}
+	return managed.ExternalUpdate{
+		ConnectionDetails: c.connectionDetails(desired, password),
+	}, nil
+}
+
+// describeUpdate summarizes what Update is about to change, e.g.
+// "privileges +2/-1, parameters changed: LOCALE, password: rotate". The full
+// lists are only logged at debug level by the individual update steps.
+func describeUpdate(cr *v1alpha1.User, desired *v1alpha1.UserParameters, observed *v1alpha1.UserObservation) string {
+	var changes []string
+	if !ignored(observed, desired, user.FieldPrivileges) {
+		if isEqual, toGrant, toRevoke := utils.ArraysBothDiff(desired.Privileges, observed.Privileges); !isEqual {
+			changes = append(changes, fmt.Sprintf("privileges +%d/-%d", len(toGrant), len(toRevoke)))
+		}
+	}
+	if !ignored(observed, desired, user.FieldRoles) {
+		if isEqual, toGrant, toRevoke := utils.ArraysBothDiff(desired.Roles, observed.Roles); !isEqual {
+			changes = append(changes, fmt.Sprintf("roles +%d/-%d", len(toGrant), len(toRevoke)))
+		}
+	}
+	if !ignored(observed, desired, user.FieldParameters) {
+		changed := utils.MapDiff(desired.Parameters, observed.Parameters)
+		maps.Copy(changed, utils.MapDiff(observed.Parameters, desired.Parameters))
+		if len(changed) > 0 {
+			changes = append(changes, "parameters changed: "+strings.Join(utils.SortedKeys(changed), ", "))
+		}
+	}
+	if observed.Usergroup == nil || *observed.Usergroup != desired.Usergroup {
+		changes = append(changes, fmt.Sprintf("usergroup: %s -> %s", ptr.Deref(observed.Usergroup, ""), desired.Usergroup))
+	}
+	if !ignored(observed, desired, user.FieldX509Providers) {
+		if isEqual, toAdd, toRemove := utils.ArraysBothDiffFunc(desired.Authentication.X509Providers, observed.X509Providers, compareX509Mappings); !isEqual {
+			changes = append(changes, fmt.Sprintf("X.509 providers +%d/-%d", len(toAdd), len(toRemove)))
+		}
+	}
+	if observed.IsPasswordLifetimeCheckEnabled == nil || *observed.IsPasswordLifetimeCheckEnabled != desired.IsPasswordLifetimeCheckEnabled {
+		changes = append(changes, "password lifetime check: "+enabled(desired.IsPasswordLifetimeCheckEnabled))
+	}
+	if !isClientConnectUpToDate(observed, desired) {
+		changes = append(changes, "client connect: "+enabled(*desired.ClientConnect))
+	}
+	if !isValidityUpToDate(observed, desired) {
+		changes = append(changes, "validity")
+	}
+	if !ignored(observed, desired, user.FieldPassword) {
+		if status := cr.Status.AtProvider; status.PasswordUpToDate != nil && !*status.PasswordUpToDate {
+			if cr.Spec.ForProvider.Authentication.Password == nil || (status.IsPasswordEnabled != nil && !*status.IsPasswordEnabled) {
+				changes = append(changes, "password authentication: "+enabled(ptr.Deref(status.IsPasswordEnabled, false)))
+			} else {
+				changes = append(changes, "password: rotate")
+			}
+		}
+	}
+	return strings.Join(changes, ", ")
+}
+
+func enabled(b bool) string {
+	if b {
+		return "enabled"
+	}
+	return "disabled"
 }
 
 // buildUpdateInputs assembles the desired and observed states needed by every
 // step in Update.
-func (c *external) buildUpdateInputs(cr *v1alpha1.User) (*v1alpha1.UserParameters, *v1alpha1.UserObservation, error) {
+func (c *external) buildUpdateInputs(ctx context.Context, cr *v1alpha1.User) (*v1alpha1.UserParameters, *v1alpha1.UserObservation, error) {
 	desired, err := c.buildDesiredParameters(cr)
 	if err != nil {
 		c.log.Info("Error building desired parameters", "name", cr.Name, "error", err)
@@ -384,7 +1187,13 @@ func (c *external) buildUpdateInputs(cr *v1alpha1.User) (*v1alpha1.UserParameter
 	}
 
 	observed := c.buildObservedParameters(cr)
-	observed, err = privilege.FilterManagedPrivileges(observed, cr.Spec.ForProvider.Privileges, cr.Status.AtProvider.Privileges, cr.Spec.PrivilegeManagementPolicy, c.client.GetDefaultSchema())
+	if !ignored(observed, desired, user.FieldPrivileges) {
+		if err := c.holdMissingTargets(ctx, cr, desired); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	observed, err = privilege.FilterManagedPrivileges(observed, privilege.WithTypedPrivileges(cr.Spec.ForProvider.Privileges, cr.Spec.ForProvider.TypedPrivileges), cr.Status.AtProvider.Privileges, cr.Spec.PrivilegeManagementPolicy, c.client.GetDefaultSchema(), cr.Spec.PrivilegeSelectors...)
 	if err != nil {
 		c.log.Info("Error filtering managed privileges", "name", cr.Name, "error", err)
 		return nil, nil, fmt.Errorf(errFilterPrivileges, err)
@@ -395,12 +1204,28 @@ func (c *external) buildUpdateInputs(cr *v1alpha1.User) (*v1alpha1.UserParameter
 func (c *external) updatePrivileges(ctx context.Context, cr *v1alpha1.User, desired *v1alpha1.UserParameters, observed *v1alpha1.UserObservation) error {
 	// Update privileges if needed
 	if isEqual, toGrant, toRevoke := utils.ArraysBothDiff(desired.Privileges, observed.Privileges); !isEqual {
-		c.log.Info("Updating user privileges",
+		c.log.Debug("Updating user privileges",
 			"name", cr.Name,
 			"username", desired.Username,
 			"toGrant", toGrant,
 			"toRevoke", toRevoke)
 
+		if err := c.enforceGrantPolicy(cr, toGrant, nil); err != nil {
+			return fmt.Errorf(errUpdateUser, err)
+		}
+
+		// Revoking what someone else granted is logged with the grantors, so
+		// the decision can be audited
+		for _, g := range observed.ForeignGrants {
+			if slices.Contains(toRevoke, g.Privilege) {
+				c.log.Info("Revoking privilege granted by another user",
+					"name", cr.Name,
+					"username", desired.Username,
+					"privilege", g.Privilege,
+					"grantor", g.Grantor)
+			}
+		}
+
 		err := c.client.UpdatePrivileges(ctx, desired.Username, toGrant, toRevoke)
 		if err != nil {
 			c.log.Info("Error updating user privileges", "name", cr.Name, "error", err)
@@ -416,14 +1241,26 @@ func (c *external) updatePrivileges(ctx context.Context, cr *v1alpha1.User, desi
 func (c *external) updateRoles(ctx context.Context, cr *v1alpha1.User, desired *v1alpha1.UserParameters, observed *v1alpha1.UserObservation) error {
 	// Update roles if needed
 	if isEqual, toGrant, toRevoke := utils.ArraysBothDiff(desired.Roles, observed.Roles); !isEqual {
-		c.log.Info("Updating user roles",
+		c.log.Debug("Updating user roles",
 			"name", cr.Name,
 			"username", desired.Username,
 			"toGrant", toGrant,
 			"toRevoke", toRevoke)
 
+		if err := c.enforceGrantPolicy(cr, nil, toGrant); err != nil {
+			return fmt.Errorf(errUpdateUser, err)
+		}
+
 		err := c.client.UpdateRoles(ctx, desired.Username, toGrant, toRevoke)
-		if err != nil {
+		var unrevokable *user.UnrevokableRolesError
+		switch {
+		case errors.As(err, &unrevokable):
+			// Leave the roles granted instead of failing the whole update
+			// on every reconcile
+			c.log.Info("Insufficient privilege to revoke user roles, leaving them granted", "name", cr.Name, "roles", unrevokable.Roles)
+			cr.Status.AtProvider.UnrevokableRoles = utils.SortedSet(append(cr.Status.AtProvider.UnrevokableRoles, unrevokable.Roles...))
+			cr.SetConditions(apisv1alpha1.RolesNotRevoked(cr.Status.AtProvider.UnrevokableRoles))
+		case err != nil:
 			c.log.Info("Error updating user roles", "name", cr.Name, "error", err)
 			return fmt.Errorf(errUpdateUser, err)
 		}
@@ -435,10 +1272,81 @@ func (c *external) updateRoles(ctx context.Context, cr *v1alpha1.User, desired *
 	return nil
 }
 
+// checkOperatorUsergroup rejects usergroups the technical user does not
+// operate in usergroup operator mode.
+func (c *external) checkOperatorUsergroup(usergroup string) error {
+	if c.operatorUsergroup == "" || usergroup == c.operatorUsergroup {
+		return nil
+	}
+	return fmt.Errorf(errOperatorUsergroup, c.operatorUsergroup, usergroup)
+}
+
+// enforceGrantPolicy rejects grants forbidden by the grant policy of the
+// ProviderConfig and holds back high-risk grants until they are approved.
+func (c *external) enforceGrantPolicy(cr *v1alpha1.User, privileges, roles []string) error {
+	if err := privilege.CheckGrantPolicy(c.grantPolicy, privileges, roles, c.client.GetDefaultSchema()); err != nil {
+		c.log.Info("Grant rejected by grant policy", "name", cr.Name, "error", err)
+		cr.SetConditions(apisv1alpha1.GrantForbidden(err))
+		return err
+	}
+	if err := approval.Check(cr, c.approvalsStamped, c.grantPolicy, privileges, roles, c.client.GetDefaultSchema()); err != nil {
+		c.log.Info("Grant waiting for approval", "name", cr.Name, "error", err)
+		return err
+	}
+	return nil
+}
+
+// pendingRevocations returns the managed privileges and roles that are granted
+// to the user but no longer desired, or nil if there are none.
+func pendingRevocations(observed *v1alpha1.UserObservation, desired *v1alpha1.UserParameters) *v1alpha1.PendingRevocations {
+	var privileges, roles []string
+	if !ignored(observed, desired, user.FieldPrivileges) {
+		_, _, privileges = utils.ArraysBothDiff(desired.Privileges, observed.Privileges)
+	}
+	if !ignored(observed, desired, user.FieldRoles) {
+		_, _, roles = utils.ArraysBothDiff(desired.Roles, observed.Roles)
+	}
+	if len(privileges)+len(roles) == 0 {
+		return nil
+	}
+	return &v1alpha1.PendingRevocations{
+		Count:             len(privileges) + len(roles),
+		Privileges:        utils.SortedSet(privileges),
+		Roles:             utils.SortedSet(roles),
+		ForeignPrivileges: utils.SortedSet(foreignPrivileges(observed, privileges)),
+	}
+}
+
+// foreignPrivileges returns those of the privileges that users other than the
+// technical user granted.
+func foreignPrivileges(observed *v1alpha1.UserObservation, privileges []string) []string {
+	var foreign []string
+	for _, g := range observed.ForeignGrants {
+		if slices.Contains(privileges, g.Privilege) && !slices.Contains(foreign, g.Privilege) {
+			foreign = append(foreign, g.Privilege)
+		}
+	}
+	return foreign
+}
+
+// checkRevocations holds back pending revocations beyond the revocation
+// threshold of the grant policy until the confirm-revocations annotation
+// confirms their number.
+func (c *external) checkRevocations(cr *v1alpha1.User, pending *v1alpha1.PendingRevocations) error {
+	if c.grantPolicy == nil || c.grantPolicy.RevocationThreshold == nil || pending == nil {
+		return nil
+	}
+	threshold := int(*c.grantPolicy.RevocationThreshold)
+	if pending.Count <= threshold || cr.GetAnnotations()[apisv1alpha1.AnnotationConfirmRevocations] == strconv.Itoa(pending.Count) {
+		return nil
+	}
+	return fmt.Errorf(errRevocationsUnconfirmed, pending.Count, threshold, apisv1alpha1.AnnotationConfirmRevocations, pending.Count)
+}
+
 func (c *external) updateParameters(ctx context.Context, cr *v1alpha1.User, desired *v1alpha1.UserParameters, observed *v1alpha1.UserObservation) error {
 	// Update parameters if needed
 	if isEqual, parametersToSet, parametersToClear := utils.MapsBothDiff(desired.Parameters, observed.Parameters); !isEqual {
-		c.log.Info("Updating user parameters",
+		c.log.Debug("Updating user parameters",
 			"name", cr.Name,
 			"username", desired.Username,
 			"parametersToSet", parametersToSet,
@@ -479,21 +1387,21 @@ func (c *external) updateX509Providers(ctx context.Context, cr *v1alpha1.User, d
 	desiredProviders := desired.Authentication.X509Providers
 	observedProviders := observed.X509Providers
 
-	isEqual, providerMappingsToAdd, providerMappingsToRemove := utils.ArraysBothDiff(desiredProviders, observedProviders)
-	providersToAdd, err := c.ResolveUserMappings(ctx, providerMappingsToAdd, cr.GetNamespace())
+	isEqual, providerMappingsToAdd, providerMappingsToRemove := utils.ArraysBothDiffFunc(desiredProviders, observedProviders, compareX509Mappings)
+	providersToAdd, err := c.ResolveUserMappings(ctx, cr, providerMappingsToAdd)
 	if err != nil {
 		c.log.Info("Error resolving user X.509 providers", "name", cr.Name, "error", err)
 		return fmt.Errorf(errUpdateUser, err)
 	}
 
-	providersToRemove, err := c.ResolveUserMappings(ctx, providerMappingsToRemove, cr.GetNamespace())
+	providersToRemove, err := c.ResolveUserMappings(ctx, cr, providerMappingsToRemove)
 	if err != nil {
 		c.log.Info("Error resolving user X.509 providers", "name", cr.Name, "error", err)
 		return fmt.Errorf(errUpdateUser, err)
 	}
 
 	if !isEqual {
-		c.log.Info("Updating user X.509 providers",
+		c.log.Debug("Updating user X.509 providers",
 			"name", cr.Name,
 			"username", desired.Username,
 			"toAdd", providersToAdd,
@@ -528,30 +1436,104 @@ func (c *external) updatePasswordLifetimeCheck(ctx context.Context, cr *v1alpha1
 	return nil
 }
 
-func (c *external) updatePassword(ctx context.Context, cr *v1alpha1.User, desired *v1alpha1.UserParameters) error {
+func (c *external) updateClientConnect(ctx context.Context, cr *v1alpha1.User, desired *v1alpha1.UserParameters, observed *v1alpha1.UserObservation) error {
+	if isClientConnectUpToDate(observed, desired) {
+		return nil
+	}
+	c.log.Info("Updating user client connect",
+		"name", cr.Name,
+		"username", desired.Username,
+		"current", observed.IsClientConnectEnabled,
+		"desired", *desired.ClientConnect)
+	if err := c.client.UpdateClientConnect(ctx, desired.Username, *desired.ClientConnect); err != nil {
+		c.log.Info("Error updating user client connect", "name", cr.Name, "error", err)
+		return fmt.Errorf(errUpdateUser, err)
+	}
+	cr.Status.AtProvider.IsClientConnectEnabled = desired.ClientConnect
+	c.log.Info("Updated user client connect", "name", cr.Name, "username", desired.Username)
+	return nil
+}
+
+// isCommentUpToDate ignores the comment unless the ProviderConfig maintains
+// comments.
+func (c *external) isCommentUpToDate(ctx context.Context, cr *v1alpha1.User, username string) (bool, error) {
+	comment := hana.ManagedComment(c.comments, v1alpha1.UserKind, cr.Name)
+	if comment == "" {
+		return true, nil
+	}
+	observed, err := hana.ReadComment(ctx, c.db, hana.CommentOnUser, username)
+	return observed == comment, err
+}
+
+// updateComment sets the comment if the ProviderConfig maintains comments.
+// Setting it again is harmless, so it is not compared first.
+func (c *external) updateComment(ctx context.Context, cr *v1alpha1.User, username string) error {
+	comment := hana.ManagedComment(c.comments, v1alpha1.UserKind, cr.Name)
+	if comment == "" {
+		return nil
+	}
+	if err := hana.SetComment(ctx, c.db, hana.CommentOnUser, utils.QuoteIdentifier(username), comment); err != nil {
+		return fmt.Errorf(errSetComment, err)
+	}
+	return nil
+}
+
+func (c *external) updateResourceTag(ctx context.Context, cr *v1alpha1.User, desired *v1alpha1.UserParameters, observed *v1alpha1.UserObservation) error {
+	if isResourceTagUpToDate(observed, cr.Name) {
+		return nil
+	}
+	if err := c.client.UpdateResourceTag(ctx, desired.Username, cr.Name); err != nil {
+		c.log.Info("Error tagging user", "name", cr.Name, "error", err)
+		return fmt.Errorf(errUpdateUser, err)
+	}
+	cr.Status.AtProvider.ManagedResource = cr.Name
+	c.log.Info("Tagged user with its resource", "name", cr.Name, "username", desired.Username)
+	return nil
+}
+
+func (c *external) updateValidity(ctx context.Context, cr *v1alpha1.User, desired *v1alpha1.UserParameters, observed *v1alpha1.UserObservation) error {
+	if isValidityUpToDate(observed, desired) {
+		return nil
+	}
+	c.log.Info("Updating user validity",
+		"name", cr.Name,
+		"username", desired.Username,
+		"validFrom", desired.ConnectionRestrictions.ValidFrom,
+		"validUntil", desired.ConnectionRestrictions.ValidUntil)
+	if err := c.client.UpdateValidity(ctx, desired.Username, desired.ConnectionRestrictions); err != nil {
+		c.log.Info("Error updating user validity", "name", cr.Name, "error", err)
+		return fmt.Errorf(errUpdateUser, err)
+	}
+	c.log.Info("Updated user validity", "name", cr.Name, "username", desired.Username)
+	return nil
+}
+
+// updatePassword returns the new password if it was changed.
+func (c *external) updatePassword(ctx context.Context, cr *v1alpha1.User, desired *v1alpha1.UserParameters) (string, error) {
 	if cr.Status.AtProvider.PasswordUpToDate != nil && !*cr.Status.AtProvider.PasswordUpToDate {
 		if cr.Spec.ForProvider.Authentication.Password == nil || (cr.Status.AtProvider.IsPasswordEnabled != nil && !*cr.Status.AtProvider.IsPasswordEnabled) {
 			if err := c.client.TogglePasswordAuthentication(ctx, desired.Username, *cr.Status.AtProvider.IsPasswordEnabled); err != nil {
 				c.log.Info("Error disabling password authentication", "name", cr.Name, "error", err)
-				return fmt.Errorf(errUpdateUser, err)
+				return "", fmt.Errorf(errUpdateUser, err)
 			}
 		} else {
 			c.log.Info("Updating user password", "name", cr.Name, "username", desired.Username)
 			password, err := c.getPassword(ctx, cr)
 			if err != nil {
-				return fmt.Errorf(errUpdateUser, err)
+				return "", fmt.Errorf(errUpdateUser, err)
 			}
 			err = c.client.UpdatePassword(ctx, desired.Username, password, desired.Authentication.Password.ForceFirstPasswordChange)
 			if err != nil {
 				c.log.Info("Error updating user password", "name", cr.Name, "error", err)
-				return fmt.Errorf(errUpdateUser, err)
+				return "", fmt.Errorf(errUpdateUser, err)
 			}
 			upToDate := true
 			cr.Status.AtProvider.PasswordUpToDate = &upToDate
 			c.log.Info("Updated user password", "name", cr.Name, "username", desired.Username)
+			return password, nil
 		}
 	}
-	return nil
+	return "", nil
 }
 
 func (c *external) transformParameters(parameters map[string]string) map[string]string {
@@ -589,7 +1571,7 @@ func (c *external) transformParameters(parameters map[string]string) map[string]
 }
 
 func (c *external) buildDesiredParameters(cr *v1alpha1.User) (*v1alpha1.UserParameters, error) {
-	parameters := handleDefaults(cr)
+	parameters := handleDefaults(cr, c.defaultUsergroup, c.defaultPrivilege, c.identifierCase)
 
 	// Normalize roles and privileges to the same canonical (quoted) form Observe()
 	// uses to populate cr.Status.AtProvider. Without this, updateRoles/updatePrivileges
@@ -627,11 +1609,18 @@ func (c *external) Delete(ctx context.Context, mg resource.Managed) (managed.Ext
 	c.log.Info("Deleting user resource", "name", cr.Name, "username", cr.Spec.ForProvider.Username)
 
 	parameters := &v1alpha1.UserParameters{
-		Username: cr.Spec.ForProvider.Username,
+		Username:                  foldUsername(&cr.Spec.ForProvider, c.identifierCase),
+		TerminateSessionsOnDelete: cr.Spec.ForProvider.TerminateSessionsOnDelete,
 	}
 
 	cr.SetConditions(xpv1.Deleting())
 
+	if cr.Spec.ProtectionPolicy == v1alpha1.ProtectionPolicyProtected {
+		c.log.Info("Not deleting protected user", "name", cr.Name, "username", parameters.Username)
+		cr.SetConditions(apisv1alpha1.DeletionProtected())
+		return managed.ExternalDelete{}, fmt.Errorf(errProtected, parameters.Username)
+	}
+
 	err := c.client.Delete(ctx, parameters)
 	if err != nil {
 		c.log.Info("Error deleting user", "name", cr.Name, "error", err)
@@ -648,6 +1637,10 @@ func (c *external) getPassword(ctx context.Context, user *v1alpha1.User) (newPwd
 		return "", nil
 	}
 
+	if ref := passwordObj.PasswordStoreRef; ref != nil {
+		return c.getStorePassword(ctx, ref)
+	}
+
 	if passwordObj.PasswordSecretRef == nil {
 		c.log.Info("Warning: PasswordSecretRef is nil, using empty password", "name", user.Name)
 		return "", nil
@@ -671,6 +1664,35 @@ func (c *external) getPassword(ctx context.Context, user *v1alpha1.User) (newPwd
 	return newPwd, nil
 }
 
+// getStorePassword returns the password of a user from an external secret
+// store.
+func (c *external) getStorePassword(ctx context.Context, ref *v1alpha1.PasswordStoreReference) (string, error) {
+	if c.store == nil {
+		return "", errors.New(errNoSecretStore)
+	}
+	configRef := ref.StoreConfigRef
+	if configRef == nil {
+		configRef = &xpv1.Reference{Name: "default"}
+	}
+
+	// The store reads the secret a resource publishes its connection details
+	// to, in the scope of its namespace
+	owner := &v1alpha1.User{}
+	owner.SetNamespace(ref.Scope)
+	owner.SetPublishConnectionDetailsTo(&xpv1.PublishConnectionDetailsTo{Name: ref.Name, SecretStoreConfigRef: configRef})
+
+	data, err := c.store.FetchConnection(ctx, owner)
+	if err != nil {
+		return "", fmt.Errorf(errGetPasswordStore, err)
+	}
+	password, ok := data[ref.Key]
+	if !ok {
+		return "", fmt.Errorf(errStoreKeyNotFound, ref.Key, ref.Name, configRef.Name)
+	}
+	c.log.Info("Got password from secret store", "name", ref.Name, "storeConfig", configRef.Name)
+	return string(password), nil
+}
+
 func handleAuthError(cr *v1alpha1.User, log logging.Logger, err error) (bool, error) {
 	switch {
 	case err == nil:
@@ -690,39 +1712,220 @@ func handleAuthError(cr *v1alpha1.User, log logging.Logger, err error) (bool, er
 	}
 }
 
-func handleDefaults(cr *v1alpha1.User) *v1alpha1.UserParameters {
-	parameters := cr.Spec.ForProvider.DeepCopy()
-	defaultPrivilege := privilege.GetDefaultPrivilege(parameters.Username)
+// defaultGrants returns the privileges and roles a user is granted by default,
+// unless it opts out of them. Restricted users get no defaults. The default
+// privilege is expanded from the template of the ProviderConfig.
+func defaultGrants(cr *v1alpha1.User, username, defaultPrivilege string) (privileges, roles []string) {
+	p := cr.Spec.ForProvider
+	if p.RestrictedUser {
+		return nil, nil
+	}
+	if cr.Spec.PrivilegeManagementPolicy == "strict" && !p.NoDefaultPrivilege {
+		privileges = append(privileges, privilege.ExpandDefaultPrivilege(defaultPrivilege, username))
+	}
+	if !p.NoDefaultRole {
+		roles = append(roles, roleDefault)
+	}
+	return privileges, roles
+}
+
+// recordDefaults records which default privileges and roles a created user
+// was granted and which it opted out of.
+func (c *external) recordDefaults(cr *v1alpha1.User, username string) {
+	privileges, roles := defaultGrants(cr, username, c.defaultPrivilege)
+	if applied := append(privileges, roles...); len(applied) > 0 {
+		c.recorder.Event(cr, event.Normal(reasonDefaultsApplied, fmt.Sprintf(msgDefaultsApplied, strings.Join(applied, ", "))))
+	}
+
+	var skipped []string
+	p := cr.Spec.ForProvider
+	if !p.RestrictedUser && p.NoDefaultPrivilege && cr.Spec.PrivilegeManagementPolicy == "strict" {
+		skipped = append(skipped, privilege.ExpandDefaultPrivilege(c.defaultPrivilege, username))
+	}
+	if !p.RestrictedUser && p.NoDefaultRole {
+		skipped = append(skipped, roleDefault)
+	}
+	if len(skipped) > 0 {
+		c.recorder.Event(cr, event.Normal(reasonDefaultsSkipped, fmt.Sprintf(msgDefaultsSkipped, strings.Join(skipped, ", "))))
+	}
+}
 
-	if cr.Spec.PrivilegeManagementPolicy == "strict" &&
-		!parameters.RestrictedUser && !slices.Contains(parameters.Privileges, defaultPrivilege) {
-		// Append default Privilege
-		parameters.Privileges = append(parameters.Privileges, defaultPrivilege)
+// withoutOwnerPrivilege removes the privilege HANA grants the owner of the
+// schema of the user from the observed privileges, unless it is desired. It
+// cannot be revoked, so it is ignored once a user opts out of it or the
+// ProviderConfig configures another default privilege.
+func withoutOwnerPrivilege(observed []string, parameters *v1alpha1.UserParameters, defaultSchema string) ([]string, error) {
+	owner, err := privilege.FormatPrivilegeStrings([]string{privilege.GetDefaultPrivilege(parameters.Username)}, defaultSchema)
+	if err != nil {
+		return nil, err
 	}
+	return slices.DeleteFunc(slices.Clone(observed), func(p string) bool {
+		return slices.Contains(owner, p) && !slices.Contains(parameters.Privileges, p)
+	}), nil
+}
+
+func handleDefaults(cr *v1alpha1.User, defaultUsergroup, defaultPrivilege, identifierCase string) *v1alpha1.UserParameters {
+	parameters := cr.Spec.ForProvider.DeepCopy()
+	parameters.Username = foldUsername(&cr.Spec.ForProvider, identifierCase)
+	parameters.Privileges = privilege.WithTypedPrivileges(parameters.Privileges, parameters.TypedPrivileges)
 
-	// Append default Role
-	if !parameters.RestrictedUser && !slices.Contains(parameters.Roles, "PUBLIC") {
-		parameters.Roles = append(parameters.Roles, "PUBLIC")
+	// The webhook leaves the usergroup empty if the ProviderConfig did not
+	// exist yet when the User was admitted
+	if parameters.Usergroup == "" {
+		parameters.Usergroup = defaultUsergroup
 	}
+	if parameters.Usergroup == "" {
+		parameters.Usergroup = usergroupDefault
+	}
+	privileges, roles := defaultGrants(cr, parameters.Username, defaultPrivilege)
+	for _, p := range privileges {
+		if !slices.Contains(parameters.Privileges, p) {
+			parameters.Privileges = append(parameters.Privileges, p)
+		}
+	}
+	for _, r := range roles {
+		if !slices.Contains(parameters.Roles, r) {
+			parameters.Roles = append(parameters.Roles, r)
+		}
+	}
+	foldIdentifiers(parameters, identifierCase)
+	parameters.Parameters = setParameters(parameters.Parameters)
+	neverExpire(parameters)
 
 	return parameters
 }
 
-func (c *external) ResolveUserMappings(ctx context.Context, mappings []v1alpha1.X509UserMapping, namespace string) ([]user.ResolvedUserMapping, error) {
-	resolved := make([]user.ResolvedUserMapping, 0, len(mappings))
+// neverExpire applies PasswordNeverExpires: the password lifetime check is
+// disabled, which exempts the user from the maximum password lifetime of its
+// usergroup and of the instance, and passwords are set without forcing a
+// change on first logon.
+func neverExpire(parameters *v1alpha1.UserParameters) {
+	if !parameters.PasswordNeverExpires {
+		return
+	}
+	parameters.IsPasswordLifetimeCheckEnabled = false
+	if parameters.Authentication.Password != nil {
+		parameters.Authentication.Password.ForceFirstPasswordChange = false
+	}
+}
+
+// setParameters returns the parameters without those set to an empty string.
+// An empty value clears the parameter like leaving it out does, as HANA does
+// not report parameters without a value.
+func setParameters(parameters map[string]string) map[string]string {
+	if parameters == nil {
+		return nil
+	}
+	set := make(map[string]string, len(parameters))
+	for key, value := range parameters {
+		if value != "" {
+			set[key] = value
+		}
+	}
+	return set
+}
+
+// foldUsername returns the name HANA stores for the user. The username is
+// folded to uppercase unless the user is case-sensitive or the ProviderConfig
+// preserves the case of identifiers.
+func foldUsername(parameters *v1alpha1.UserParameters, identifierCase string) string {
+	return utils.FoldIdentifier(parameters.Username, parameters.CaseSensitive || identifierCase == apisv1alpha1.IdentifierCasePreserve)
+}
+
+// foldIdentifiers folds the unquoted identifiers in the usergroup, privileges
+// and roles to uppercase if the ProviderConfig folds identifiers.
+func foldIdentifiers(parameters *v1alpha1.UserParameters, identifierCase string) {
+	if identifierCase != apisv1alpha1.IdentifierCaseUpper {
+		return
+	}
+	parameters.Usergroup = utils.FoldUnquotedIdentifier(parameters.Usergroup)
+	parameters.Privileges = privilege.FoldPrivilegeStrings(parameters.Privileges)
+	parameters.Roles = privilege.FoldRoleStrings(parameters.Roles)
+}
+
+// unreadyDependencies returns the X509Provider resources of the mappings, and
+// the PersonalSecurityEnvironment resources referencing them, that are not
+// Ready. Referenced X509Provider resources that do not exist yet, and
+// providerSelectors that match none, are reported as unready as well. Mappings
+// by name only depend on the X509Provider resources managing a provider of
+// that name, if any.
+func (c *external) unreadyDependencies(ctx context.Context, cr *v1alpha1.User, mappings []v1alpha1.X509UserMapping) ([]string, error) {
+	var providers []v1alpha1.X509Provider
+	var unready []string
 	for _, mapping := range mappings {
-		var name, subjectName string
+		if mapping.Name != "" {
+			list := &v1alpha1.X509ProviderList{}
+			if err := c.kube.List(ctx, list); err != nil {
+				return nil, err
+			}
+			for _, p := range list.Items {
+				if p.Spec.ForProvider.Name == mapping.Name {
+					providers = append(providers, p)
+				}
+			}
+			continue
+		}
+		p, err := x509provider.Resolve(ctx, c.kube, cr, mapping.X509ProviderRef)
 		switch {
-		case mapping.Name != "":
-			name = mapping.Name
-		case mapping.ProviderRef != nil:
-			x509providerObj := &v1alpha1.X509Provider{}
-			if err := c.kube.Get(ctx, types.NamespacedName{Namespace: namespace, Name: mapping.ProviderRef.Name}, x509providerObj); err != nil {
+		case apierrors.IsNotFound(err):
+			unready = unreadyList(unready, "X509Provider", mapping.ProviderRef.Name)
+		case errors.Is(err, x509provider.ErrNoneSelected):
+			unready = unreadyList(unready, "X509Provider", labels.SelectorFromSet(mapping.ProviderSelector.MatchLabels).String())
+		case err != nil:
+			return nil, err
+		case p != nil:
+			providers = append(providers, *p)
+		}
+	}
+	if len(providers) == 0 {
+		return unready, nil
+	}
+
+	pses := &v1alpha1.PersonalSecurityEnvironmentList{}
+	if err := c.kube.List(ctx, pses); err != nil {
+		return nil, err
+	}
+
+	for _, p := range providers {
+		if !isReady(&p) {
+			unready = unreadyList(unready, "X509Provider", p.GetName())
+		}
+		for _, pse := range pses.Items {
+			if x509provider.References(&pse, pse.Spec.ForProvider.X509ProviderRef, &p) && !isReady(&pse) {
+				unready = unreadyList(unready, "PersonalSecurityEnvironment", pse.GetName())
+			}
+		}
+	}
+	return unready, nil
+}
+
+func isReady(o resource.Conditioned) bool {
+	return o.GetCondition(xpv1.TypeReady).Status == corev1.ConditionTrue
+}
+
+// unreadyList adds the resource of the kind to the list, unless it is listed
+// already.
+func unreadyList(list []string, kind, name string) []string {
+	entry := kind + "/" + name
+	if slices.Contains(list, entry) {
+		return list
+	}
+	return append(list, entry)
+}
+
+func (c *external) ResolveUserMappings(ctx context.Context, cr *v1alpha1.User, mappings []v1alpha1.X509UserMapping) ([]user.ResolvedUserMapping, error) {
+	resolved := make([]user.ResolvedUserMapping, 0, len(mappings))
+	for _, mapping := range mappings {
+		name, subjectName := mapping.Name, ""
+		if name == "" {
+			p, err := x509provider.Resolve(ctx, c.kube, cr, mapping.X509ProviderRef)
+			if err != nil {
 				return nil, fmt.Errorf("cannot resolve X.509 provider reference: %w", err)
 			}
-			name = x509providerObj.Spec.ForProvider.Name
-		default:
-			return nil, errors.New("cannot resolve X.509 provider reference: no name or providerRef specified")
+			if p == nil {
+				return nil, errors.New("cannot resolve X.509 provider reference: no name, providerRef or providerSelector specified")
+			}
+			name = p.Spec.ForProvider.Name
 		}
 		if mapping.SubjectName != "" {
 			subjectName = mapping.SubjectName
@@ -77,8 +77,9 @@ func TestObserveAuthenticationErrors(t *testing.T) {
 			},
 			want: want{
 				c: managed.ExternalObservation{
-					ResourceExists:   true,
-					ResourceUpToDate: true, // All fields match despite auth error
+					ResourceExists:    true,
+					ResourceUpToDate:  true, // All fields match despite auth error
+					ConnectionDetails: managed.ConnectionDetails{"user": []byte(demoUser), "externalID": []byte(demoUser)},
 				},
 				err: nil,
 			},
@@ -120,8 +121,9 @@ func TestObserveAuthenticationErrors(t *testing.T) {
 			},
 			want: want{
 				c: managed.ExternalObservation{
-					ResourceExists:   true,
-					ResourceUpToDate: true, // All fields match despite auth error
+					ResourceExists:    true,
+					ResourceUpToDate:  true, // All fields match despite auth error
+					ConnectionDetails: managed.ConnectionDetails{"user": []byte(demoUser), "externalID": []byte(demoUser)},
 				},
 				err: nil,
 			},
@@ -163,8 +165,9 @@ func TestObserveAuthenticationErrors(t *testing.T) {
 			},
 			want: want{
 				c: managed.ExternalObservation{
-					ResourceExists:   true,
-					ResourceUpToDate: true, // All fields match despite auth error
+					ResourceExists:    true,
+					ResourceUpToDate:  true, // All fields match despite auth error
+					ConnectionDetails: managed.ConnectionDetails{"user": []byte(demoUser), "externalID": []byte(demoUser)},
 				},
 				err: nil,
 			},
@@ -210,8 +213,9 @@ func TestObserveAuthenticationErrors(t *testing.T) {
 			},
 			want: want{
 				c: managed.ExternalObservation{
-					ResourceExists:   true,
-					ResourceUpToDate: false, // Resource is actually out of date (usergroup mismatch)
+					ResourceExists:    true,
+					ResourceUpToDate:  false, // Resource is actually out of date (usergroup mismatch)
+					ConnectionDetails: managed.ConnectionDetails{"user": []byte(demoUser), "externalID": []byte(demoUser)},
 				},
 				err: nil,
 			},
@@ -255,8 +259,9 @@ func TestObserveAuthenticationErrors(t *testing.T) {
 			},
 			want: want{
 				c: managed.ExternalObservation{
-					ResourceExists:   true,
-					ResourceUpToDate: true, // All configuration matches and password is up to date
+					ResourceExists:    true,
+					ResourceUpToDate:  true, // All configuration matches and password is up to date
+					ConnectionDetails: managed.ConnectionDetails{"user": []byte(demoUser), "externalID": []byte(demoUser)},
 				},
 				err: nil,
 			},
@@ -9,29 +9,49 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
 	"github.com/crossplane/crossplane-runtime/pkg/logging"
 	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 	"github.com/crossplane/crossplane-runtime/pkg/test"
 	"github.com/google/go-cmp/cmp"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
+	"github.com/SAP/crossplane-provider-hana/internal/clients/hana"
 	"github.com/SAP/crossplane-provider-hana/internal/clients/hana/privilege"
 	"github.com/SAP/crossplane-provider-hana/internal/clients/hana/user"
 	"github.com/SAP/crossplane-provider-hana/internal/clients/xsql"
 
 	"github.com/SAP/crossplane-provider-hana/apis/admin/v1alpha1"
 	apisv1alpha1 "github.com/SAP/crossplane-provider-hana/apis/v1alpha1"
+	"github.com/SAP/crossplane-provider-hana/internal/controller/x509provider"
 )
 
 const demoUser = "DEMO_USER"
 
+// mockRecorder records the reasons of the events it is sent.
+type mockRecorder struct {
+	reasons []event.Reason
+}
+
+func (r *mockRecorder) Event(_ runtime.Object, e event.Event) {
+	r.reasons = append(r.reasons, e.Reason)
+}
+
+func (r *mockRecorder) WithAnnotations(_ ...string) event.Recorder {
+	return r
+}
+
 // MockLogger is a mock implementation of logging.Logger
 type MockLogger struct {
 	msgs          []string
@@ -58,7 +78,12 @@ type mockUserClient struct {
 	MockRead                   func(ctx context.Context, parameters *v1alpha1.UserParameters, password string) (observed *v1alpha1.UserObservation, err error)
 	MockCreate                 func(ctx context.Context, parameters *v1alpha1.UserParameters, password string, providers []user.ResolvedUserMapping) error
 	MockDelete                 func(ctx context.Context, parameters *v1alpha1.UserParameters) error
+	MockUpdateParameters       func(ctx context.Context, username string, parametersToSet, parametersToClear map[string]string) error
+	MockUpdateClientConnect    func(ctx context.Context, username string, enabled bool) error
+	MockUpdateValidity         func(ctx context.Context, username string, restrictions *v1alpha1.ConnectionRestrictions) error
+	MockUpdateResourceTag      func(ctx context.Context, username, resource string) error
 	MockFormatPrivilegeStrings func(privilegeStrings []string) ([]string, error)
+	MockMissingTargets         func(ctx context.Context, privileges []string) ([]privilege.MissingTarget, error)
 }
 
 // Implement the methods that user.Client struct has
@@ -88,6 +113,9 @@ func (m mockUserClient) UpdatePrivileges(ctx context.Context, grantee string, to
 }
 
 func (m mockUserClient) UpdateParameters(ctx context.Context, username string, parametersToSet, parametersToClear map[string]string) error {
+	if m.MockUpdateParameters != nil {
+		return m.MockUpdateParameters(ctx, username, parametersToSet, parametersToClear)
+	}
 	return nil
 }
 
@@ -115,6 +143,34 @@ func (m mockUserClient) TogglePasswordAuthentication(ctx context.Context, userna
 	return nil
 }
 
+func (m mockUserClient) UpdateResourceTag(ctx context.Context, username, resource string) error {
+	if m.MockUpdateResourceTag != nil {
+		return m.MockUpdateResourceTag(ctx, username, resource)
+	}
+	return nil
+}
+
+func (m mockUserClient) UpdateValidity(ctx context.Context, username string, restrictions *v1alpha1.ConnectionRestrictions) error {
+	if m.MockUpdateValidity != nil {
+		return m.MockUpdateValidity(ctx, username, restrictions)
+	}
+	return nil
+}
+
+func (m mockUserClient) UpdateClientConnect(ctx context.Context, username string, enabled bool) error {
+	if m.MockUpdateClientConnect != nil {
+		return m.MockUpdateClientConnect(ctx, username, enabled)
+	}
+	return nil
+}
+
+func (m mockUserClient) MissingTargets(ctx context.Context, privileges []string) ([]privilege.MissingTarget, error) {
+	if m.MockMissingTargets != nil {
+		return m.MockMissingTargets(ctx, privileges)
+	}
+	return nil, nil
+}
+
 func (m mockUserClient) GetDefaultSchema() string {
 	return "DEFAULT_SCHEMA" // Default schema for testing
 }
@@ -195,7 +251,7 @@ func TestConnect(t *testing.T) {
 					},
 				},
 			},
-			want: errors.New(errNoSecretRef),
+			want: errors.New(hana.ErrNoSecretRef),
 		},
 		"ErrGetConnectionSecret": {
 			reason: "An error should be returned if we can't get our ProviderConfig's connection secret",
@@ -222,13 +278,45 @@ func TestConnect(t *testing.T) {
 					},
 				},
 			},
-			want: fmt.Errorf(errGetSecret, errBoom),
+			want: fmt.Errorf(hana.ErrGetSecret, errBoom),
+		},
+		"ErrGetExecutionUserSecret": {
+			reason: "An error should be returned if we can't get the secret of the execution user",
+			fields: fields{
+				kube: &test.MockClient{
+					MockGet: func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+						switch o := obj.(type) {
+						case *apisv1alpha1.ProviderConfig:
+							o.Spec.Credentials.ConnectionSecretRef = &xpv1.SecretReference{Name: "admin"}
+						case *corev1.Secret:
+							if key.Name != "admin" {
+								return errBoom
+							}
+						}
+						return nil
+					},
+				},
+				usage: resource.TrackerFn(func(ctx context.Context, mg resource.Managed) error { return nil }),
+			},
+			args: args{
+				mg: &v1alpha1.User{
+					Spec: v1alpha1.UserSpec{
+						ResourceSpec: xpv1.ResourceSpec{
+							ProviderConfigReference: &xpv1.Reference{},
+						},
+						ForProvider: v1alpha1.UserParameters{
+							ExecutionUserSecretRef: &xpv1.SecretReference{Name: "schema-owner"},
+						},
+					},
+				},
+			},
+			want: fmt.Errorf(errGetExecutionUser, fmt.Errorf("cannot get execution user secret: %w", errBoom)),
 		},
 	}
 
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
-			e := &connector{kube: tc.fields.kube, usage: tc.fields.usage, newClient: tc.fields.newClient}
+			e := &connector{kube: tc.fields.kube, usage: tc.fields.usage, newClient: tc.fields.newClient, log: &MockLogger{}}
 			_, err := e.Connect(tc.args.ctx, tc.args.mg)
 			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
 				t.Errorf("\n%s\ne.Connect(...): -want error, +got error:\n%s\n", tc.reason, diff)
@@ -239,6 +327,7 @@ func TestConnect(t *testing.T) {
 
 func TestObserve(t *testing.T) {
 	errBoom := errors.New("boom")
+	errInterrupted := fmt.Errorf("query interrupted: %w", context.DeadlineExceeded)
 
 	type fields struct {
 		client user.UserClient
@@ -296,6 +385,55 @@ func TestObserve(t *testing.T) {
 				err: fmt.Errorf(errSelectUser, errBoom),
 			},
 		},
+		"RenamePending": {
+			reason: "A username changed with recreation should be reported as not up to date, leaving the previous user to Update",
+			fields: fields{
+				client: mockUserClient{
+					MockDelete: func(ctx context.Context, parameters *v1alpha1.UserParameters) error {
+						t.Errorf("Observe must not drop the user of the previous username")
+						return nil
+					},
+				},
+				log: &MockLogger{},
+			},
+			args: args{
+				mg: &v1alpha1.User{
+					ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{v1alpha1.AnnotationRenamePolicy: v1alpha1.RenamePolicyRecreate}},
+					Spec: v1alpha1.UserSpec{
+						ForProvider: v1alpha1.UserParameters{
+							Username: demoUser,
+						},
+					},
+					Status: v1alpha1.UserStatus{AtProvider: v1alpha1.UserObservation{Username: new("OLD_USER")}},
+				},
+			},
+			want: want{
+				c: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+			},
+		},
+		"ErrObserveInterrupted": {
+			reason: "A user query cut short by a timeout should be returned as an error, so that the user is not created again",
+			fields: fields{
+				client: mockUserClient{
+					MockRead: func(ctx context.Context, parameters *v1alpha1.UserParameters, password string) (observed *v1alpha1.UserObservation, err error) {
+						return &v1alpha1.UserObservation{}, errInterrupted
+					},
+				},
+				log: &MockLogger{},
+			},
+			args: args{
+				mg: &v1alpha1.User{
+					Spec: v1alpha1.UserSpec{
+						ForProvider: v1alpha1.UserParameters{
+							Username: demoUser,
+						},
+					},
+				},
+			},
+			want: want{
+				err: fmt.Errorf(errSelectUser, errInterrupted),
+			},
+		},
 		"Success": {
 			reason: "No error should be returned when we successfully observe a User",
 			fields: fields{
@@ -329,8 +467,89 @@ func TestObserve(t *testing.T) {
 			},
 			want: want{
 				c: managed.ExternalObservation{
-					ResourceExists:   true,
-					ResourceUpToDate: true,
+					ResourceExists:    true,
+					ResourceUpToDate:  true,
+					ConnectionDetails: managed.ConnectionDetails{"user": []byte(demoUser), "externalID": []byte(demoUser)},
+				},
+				err: nil,
+			},
+		},
+		"UnobservedGrants": {
+			reason: "Privileges and roles the usergroup operator cannot read should not make the user out of date",
+			fields: fields{
+				client: mockUserClient{
+					MockRead: func(ctx context.Context, parameters *v1alpha1.UserParameters, password string) (observed *v1alpha1.UserObservation, err error) {
+						return &v1alpha1.UserObservation{
+							Username:                       new(demoUser),
+							UnobservedFields:               []string{user.FieldPrivileges, user.FieldRoles},
+							Usergroup:                      new("DEFAULT"),
+							PasswordUpToDate:               nil, // No password authentication
+							IsPasswordLifetimeCheckEnabled: new(true),
+							Parameters:                     make(map[string]string),
+							X509Providers:                  []v1alpha1.X509UserMapping{},
+						}, nil
+					},
+				},
+				log: &MockLogger{},
+			},
+			args: args{
+				mg: &v1alpha1.User{
+					Spec: v1alpha1.UserSpec{
+						ForProvider: v1alpha1.UserParameters{
+							Username:                       demoUser,
+							Usergroup:                      "DEFAULT",
+							IsPasswordLifetimeCheckEnabled: true,
+						},
+						PrivilegeManagementPolicy: "strict",
+					},
+				},
+			},
+			want: want{
+				c: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  true,
+					ConnectionDetails: managed.ConnectionDetails{"user": []byte(demoUser), "externalID": []byte(demoUser)},
+				},
+				err: nil,
+			},
+		},
+		"RestrictedUserMismatch": {
+			reason: "A standard user observed as restricted user should not be up to date",
+			fields: fields{
+				client: mockUserClient{
+					MockRead: func(ctx context.Context, parameters *v1alpha1.UserParameters, password string) (observed *v1alpha1.UserObservation, err error) {
+						return &v1alpha1.UserObservation{
+							Username:                       new(demoUser),
+							Privileges:                     []string{privilege.GetDefaultPrivilege("DEMO_USER")},
+							Roles:                          []string{`"PUBLIC"`},
+							Usergroup:                      new("DEFAULT"),
+							PasswordUpToDate:               nil, // No password authentication
+							RestrictedUser:                 new(true),
+							IsPasswordLifetimeCheckEnabled: new(true),
+							Parameters:                     make(map[string]string),
+							X509Providers:                  []v1alpha1.X509UserMapping{},
+						}, nil
+					},
+				},
+				log: &MockLogger{},
+			},
+			args: args{
+				mg: &v1alpha1.User{
+					Spec: v1alpha1.UserSpec{
+						ForProvider: v1alpha1.UserParameters{
+							Username:                       demoUser,
+							Usergroup:                      "DEFAULT",
+							IsPasswordLifetimeCheckEnabled: true,
+						},
+						PrivilegeManagementPolicy: "strict",
+					},
+				},
+			},
+			want: want{
+				c: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  false,
+					ConnectionDetails: managed.ConnectionDetails{"user": []byte(demoUser), "externalID": []byte(demoUser)},
 				},
 				err: nil,
 			},
@@ -369,8 +588,9 @@ func TestObserve(t *testing.T) {
 			},
 			want: want{
 				c: managed.ExternalObservation{
-					ResourceExists:   true,
-					ResourceUpToDate: true,
+					ResourceExists:    true,
+					ResourceUpToDate:  true,
+					ConnectionDetails: managed.ConnectionDetails{"user": []byte(demoUser), "externalID": []byte(demoUser)},
 				},
 				err: nil,
 			},
@@ -413,8 +633,9 @@ func TestObserve(t *testing.T) {
 			},
 			want: want{
 				c: managed.ExternalObservation{
-					ResourceExists:   true,
-					ResourceUpToDate: true,
+					ResourceExists:    true,
+					ResourceUpToDate:  true,
+					ConnectionDetails: managed.ConnectionDetails{"user": []byte(demoUser), "externalID": []byte(demoUser)},
 				},
 				err: nil,
 			},
@@ -514,8 +735,9 @@ func TestObserve(t *testing.T) {
 			},
 			want: want{
 				c: managed.ExternalObservation{
-					ResourceExists:   true,
-					ResourceUpToDate: true,
+					ResourceExists:    true,
+					ResourceUpToDate:  true,
+					ConnectionDetails: managed.ConnectionDetails{"user": []byte(demoUser), "externalID": []byte(demoUser)},
 				},
 				err: nil,
 			},
@@ -552,8 +774,9 @@ func TestObserve(t *testing.T) {
 			},
 			want: want{
 				c: managed.ExternalObservation{
-					ResourceExists:   true,
-					ResourceUpToDate: true,
+					ResourceExists:    true,
+					ResourceUpToDate:  true,
+					ConnectionDetails: managed.ConnectionDetails{"user": []byte(demoUser), "externalID": []byte(demoUser)},
 				},
 				err: nil,
 			},
@@ -589,8 +812,9 @@ func TestObserve(t *testing.T) {
 			},
 			want: want{
 				c: managed.ExternalObservation{
-					ResourceExists:   true,
-					ResourceUpToDate: false, // Should be out of date
+					ResourceExists:    true,
+					ResourceUpToDate:  false, // Should be out of date
+					ConnectionDetails: managed.ConnectionDetails{"user": []byte(demoUser), "externalID": []byte(demoUser)},
 				},
 				err: nil,
 			},
@@ -611,111 +835,474 @@ func TestObserve(t *testing.T) {
 	}
 }
 
-func TestCreate(t *testing.T) {
-	errBoom := errors.New("boom")
-
-	type fields struct {
-		client user.UserClient
-		log    logging.Logger
-	}
-
-	type args struct {
-		ctx context.Context
-		mg  resource.Managed
-	}
-
-	type want struct {
-		c   managed.ExternalCreation
-		err error
+func TestObserveConnectionDetails(t *testing.T) {
+	secretRef := &xpv1.SecretKeySelector{SecretReference: xpv1.SecretReference{Name: "pw", Namespace: "default"}, Key: "password"}
+	kube := &test.MockClient{
+		MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+			obj.(*corev1.Secret).Data = map[string][]byte{"password": []byte("s3cret")}
+			return nil
+		}),
 	}
 
 	cases := map[string]struct {
-		reason string
-		fields fields
-		args   args
-		want   want
+		reason           string
+		passwordUpToDate bool
+		publishCA        bool
+		want             managed.ConnectionDetails
 	}{
-		"ErrNotUser": {
-			reason: "An error should be returned if the managed resource is not a *User",
-			args: args{
-				mg: nil,
-			},
-			want: want{
-				err: errors.New(errNotUser),
+		"PasswordUpToDate": {
+			reason:           "The managed password should be published with the endpoint once it is set in HANA",
+			passwordUpToDate: true,
+			want: managed.ConnectionDetails{
+				"user":       []byte(demoUser),
+				"externalID": []byte(demoUser),
+				"password":   []byte("s3cret"),
+				"endpoint":   []byte("hana.example.com"),
+				"port":       []byte("443"),
 			},
 		},
-		"ErrCreate": {
-			reason: "Any errors encountered while creating the User should be returned",
-			fields: fields{
-				client: mockUserClient{
-					MockCreate: func(ctx context.Context, parameters *v1alpha1.UserParameters, password string, providers []user.ResolvedUserMapping) error {
-						return errBoom
-					},
-				},
-				log: &MockLogger{},
-			},
-			args: args{
-				mg: &v1alpha1.User{
-					Spec: v1alpha1.UserSpec{
-						ForProvider: v1alpha1.UserParameters{
-							Username: demoUser,
-						},
-					},
-				},
+		"PasswordPending": {
+			reason: "A password that is not yet set in HANA should not be published",
+			want: managed.ConnectionDetails{
+				"user":       []byte(demoUser),
+				"externalID": []byte(demoUser),
+				"endpoint":   []byte("hana.example.com"),
+				"port":       []byte("443"),
 			},
-			want: want{
-				err: fmt.Errorf(errCreateUser, errBoom),
+		},
+		"PublishCACertificate": {
+			reason:    "The CA certificate of the endpoint should be published if the User opts in",
+			publishCA: true,
+			want: managed.ConnectionDetails{
+				"user":       []byte(demoUser),
+				"externalID": []byte(demoUser),
+				"endpoint":   []byte("hana.example.com"),
+				"port":       []byte("443"),
+				"ca.crt":     []byte("-----BEGIN CERTIFICATE-----"),
 			},
 		},
-		"Success": {
-			reason: "No error should be returned when we successfully create a User",
-			fields: fields{
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{
 				client: mockUserClient{
-					MockCreate: func(ctx context.Context, parameters *v1alpha1.UserParameters, password string, providers []user.ResolvedUserMapping) error {
-						return nil
+					MockRead: func(ctx context.Context, parameters *v1alpha1.UserParameters, password string) (*v1alpha1.UserObservation, error) {
+						return &v1alpha1.UserObservation{
+							Username:         new(demoUser),
+							PasswordUpToDate: new(tc.passwordUpToDate),
+						}, nil
 					},
 				},
-				log: &MockLogger{},
-			},
-			args: args{
-				mg: &v1alpha1.User{
-					Spec: v1alpha1.UserSpec{
-						ForProvider: v1alpha1.UserParameters{
-							Username: demoUser,
+				kube:          kube,
+				log:           &MockLogger{},
+				endpoint:      "hana.example.com",
+				port:          "443",
+				caCertificate: []byte("-----BEGIN CERTIFICATE-----"),
+			}
+			mg := &v1alpha1.User{
+				Spec: v1alpha1.UserSpec{
+					ForProvider: v1alpha1.UserParameters{
+						Username: demoUser,
+						Authentication: v1alpha1.Authentication{
+							Password: &v1alpha1.Password{PasswordSecretRef: secretRef},
 						},
+						PublishCACertificate: tc.publishCA,
 					},
+					PrivilegeManagementPolicy: "strict",
 				},
-			},
-			want: want{
-				err: nil,
-				c: managed.ExternalCreation{ConnectionDetails: managed.ConnectionDetails{
-					"password": {},
-					"user":     []byte(demoUser),
-				}},
-			},
+			}
+			got, err := e.Observe(context.Background(), mg)
+			if err != nil {
+				t.Fatalf("\n%s\ne.Observe(...): unexpected error: %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want, got.ConnectionDetails); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	cases := map[string]struct {
+		reason            string
+		restricted        bool
+		clientConnect     *bool
+		observed          v1alpha1.UserObservation
+		operatorUsergroup string
+		wantClientConnect *bool
+		wantReasons       []event.Reason
+		wantErr           error
+	}{
+		"ErrOperatorUsergroup": {
+			reason:            "Users outside the operated usergroup should not be updated in usergroup operator mode",
+			operatorUsergroup: "OPS",
+			observed:          v1alpha1.UserObservation{RestrictedUser: new(false)},
+			wantErr:           fmt.Errorf(errUpdateUser, fmt.Errorf(errOperatorUsergroup, "OPS", "DEFAULT")),
+		},
+		"ErrConvertRestricted": {
+			reason:     "A standard user should not be converted into a restricted user",
+			restricted: true,
+			observed:   v1alpha1.UserObservation{RestrictedUser: new(false)},
+			wantErr:    errors.New(errConvertRestricted),
+		},
+		"EnableClientConnect": {
+			reason:            "Client connect should be enabled for a restricted user that asks for it",
+			restricted:        true,
+			clientConnect:     new(true),
+			observed:          v1alpha1.UserObservation{RestrictedUser: new(true), IsClientConnectEnabled: new(false)},
+			wantClientConnect: new(true),
+			wantReasons:       []event.Reason{reasonUpdating},
+		},
+		"ClientConnectUnmanaged": {
+			reason:      "Client connect should be left alone when it is not set",
+			observed:    v1alpha1.UserObservation{RestrictedUser: new(false), IsClientConnectEnabled: new(false)},
+			wantReasons: []event.Reason{reasonUpdating},
 		},
 	}
 
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
-			e := external{client: tc.fields.client, log: tc.fields.log}
-			got, err := e.Create(tc.args.ctx, tc.args.mg)
-			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
-				t.Errorf("\n%s\ne.Create(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			var gotClientConnect *bool
+			recorder := &mockRecorder{}
+			e := external{
+				client: mockUserClient{
+					MockUpdateClientConnect: func(ctx context.Context, username string, enabled bool) error {
+						gotClientConnect = &enabled
+						return nil
+					},
+				},
+				log:               &MockLogger{},
+				recorder:          recorder,
+				operatorUsergroup: tc.operatorUsergroup,
 			}
-			if diff := cmp.Diff(tc.want.c, got); diff != "" {
-				t.Errorf("\n%s\ne.Create(...): -want, +got:\n%s\n", tc.reason, diff)
+			observed := tc.observed
+			observed.IsPasswordLifetimeCheckEnabled = new(true)
+			observed.Usergroup = new("DEFAULT")
+			mg := &v1alpha1.User{
+				Spec: v1alpha1.UserSpec{
+					ForProvider: v1alpha1.UserParameters{
+						Username:                       demoUser,
+						Usergroup:                      "DEFAULT",
+						RestrictedUser:                 tc.restricted,
+						ClientConnect:                  tc.clientConnect,
+						IsPasswordLifetimeCheckEnabled: true,
+					},
+					PrivilegeManagementPolicy: "lax",
+				},
+				Status: v1alpha1.UserStatus{AtProvider: observed},
+			}
+			_, err := e.Update(context.Background(), mg)
+			if diff := cmp.Diff(tc.wantErr, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.wantClientConnect, gotClientConnect); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want client connect, +got client connect:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.wantReasons, recorder.reasons); diff != "" {
+				t.Errorf("\n%s\ne.Update(...): -want events, +got events:\n%s\n", tc.reason, diff)
 			}
 		})
 	}
 }
 
-func TestDelete(t *testing.T) {
-	errBoom := errors.New("boom")
-
-	type fields struct {
-		client user.UserClient
-		log    logging.Logger
+func TestUpdateParameters(t *testing.T) {
+	type call struct {
+		toSet, toClear map[string]string
+	}
+	cases := map[string]struct {
+		reason   string
+		desired  map[string]string
+		observed map[string]string
+		want     *call
+	}{
+		"Clear": {
+			reason:   "A parameter set to an empty string should be cleared",
+			desired:  map[string]string{"LOCALE": "en_US", "EMAIL ADDRESS": ""},
+			observed: map[string]string{"LOCALE": "en_US", "EMAIL ADDRESS": "demo@example.com"},
+			want:     &call{toSet: map[string]string{}, toClear: map[string]string{"EMAIL ADDRESS": "demo@example.com"}},
+		},
+		"Cleared": {
+			reason:   "A parameter set to an empty string should not be updated once it is cleared",
+			desired:  map[string]string{"LOCALE": "en_US", "EMAIL ADDRESS": ""},
+			observed: map[string]string{"LOCALE": "en_US"},
+		},
+		"Unset": {
+			reason:   "A parameter left out should be cleared like one set to an empty string",
+			desired:  map[string]string{"LOCALE": "en_US"},
+			observed: map[string]string{"LOCALE": "en_US", "CLIENT": "100"},
+			want:     &call{toSet: map[string]string{}, toClear: map[string]string{"CLIENT": "100"}},
+		},
+		"Reset": {
+			reason:   "A parameter changed in the database should be set again",
+			desired:  map[string]string{"LOCALE": "en_US", "CLIENT": "100"},
+			observed: map[string]string{"LOCALE": "de_DE"},
+			want:     &call{toSet: map[string]string{"LOCALE": "en_US", "CLIENT": "100"}, toClear: map[string]string{}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var got *call
+			e := external{
+				client: mockUserClient{
+					MockUpdateParameters: func(ctx context.Context, username string, parametersToSet, parametersToClear map[string]string) error {
+						got = &call{toSet: parametersToSet, toClear: parametersToClear}
+						return nil
+					},
+				},
+				log: &MockLogger{},
+			}
+			cr := &v1alpha1.User{
+				Spec: v1alpha1.UserSpec{
+					ForProvider:               v1alpha1.UserParameters{Username: demoUser, Parameters: tc.desired},
+					PrivilegeManagementPolicy: "lax",
+				},
+				Status: v1alpha1.UserStatus{AtProvider: v1alpha1.UserObservation{Parameters: tc.observed}},
+			}
+			desired, observed, err := e.buildUpdateInputs(context.Background(), cr)
+			if err != nil {
+				t.Fatalf("\n%s\ne.buildUpdateInputs(...): %v", tc.reason, err)
+			}
+			if err := e.updateParameters(context.Background(), cr, desired, observed); err != nil {
+				t.Fatalf("\n%s\ne.updateParameters(...): %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want, got, cmp.AllowUnexported(call{})); diff != "" {
+				t.Errorf("\n%s\ne.updateParameters(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestPendingRevocations(t *testing.T) {
+	cases := map[string]struct {
+		reason   string
+		desired  v1alpha1.UserParameters
+		observed v1alpha1.UserObservation
+		want     *v1alpha1.PendingRevocations
+	}{
+		"None": {
+			reason:   "No revocations should be pending if all grants are desired",
+			desired:  v1alpha1.UserParameters{Privileges: []string{"CATALOG READ"}, Roles: []string{"MONITORING"}},
+			observed: v1alpha1.UserObservation{Privileges: []string{"CATALOG READ"}, Roles: []string{"MONITORING"}},
+		},
+		"Pending": {
+			reason:   "Granted privileges and roles that are not desired should be pending revocation",
+			desired:  v1alpha1.UserParameters{Privileges: []string{"CATALOG READ"}},
+			observed: v1alpha1.UserObservation{Privileges: []string{"TRACE ADMIN", "CATALOG READ", "AUDIT READ"}, Roles: []string{"MONITORING"}},
+			want: &v1alpha1.PendingRevocations{
+				Count:      3,
+				Privileges: []string{"AUDIT READ", "TRACE ADMIN"},
+				Roles:      []string{"MONITORING"},
+			},
+		},
+		"Foreign": {
+			reason:  "Pending privileges another user granted should be told apart from those of the provider",
+			desired: v1alpha1.UserParameters{Privileges: []string{"CATALOG READ"}},
+			observed: v1alpha1.UserObservation{
+				Privileges: []string{"TRACE ADMIN", "CATALOG READ", "AUDIT READ"},
+				ForeignGrants: []v1alpha1.PrivilegeGrantObservation{
+					{Privilege: "AUDIT READ", Grantor: "ADMIN"},
+					{Privilege: "CATALOG READ", Grantor: "ADMIN"},
+				},
+			},
+			want: &v1alpha1.PendingRevocations{
+				Count:             2,
+				Privileges:        []string{"AUDIT READ", "TRACE ADMIN"},
+				ForeignPrivileges: []string{"AUDIT READ"},
+			},
+		},
+		"Unmanaged": {
+			reason:   "Roles of a user that leaves them to another system should not be pending revocation",
+			desired:  v1alpha1.UserParameters{ManageRoles: new(false)},
+			observed: v1alpha1.UserObservation{Roles: []string{"MONITORING"}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := pendingRevocations(&tc.observed, &tc.desired)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\npendingRevocations(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestCheckRevocations(t *testing.T) {
+	pending := &v1alpha1.PendingRevocations{Count: 3}
+
+	cases := map[string]struct {
+		reason      string
+		threshold   *int32
+		annotations map[string]string
+		pending     *v1alpha1.PendingRevocations
+		want        error
+	}{
+		"NoThreshold": {
+			reason:  "Revocations should not be limited without a revocation threshold",
+			pending: pending,
+		},
+		"WithinThreshold": {
+			reason:    "Revocations within the threshold should not need confirmation",
+			threshold: new(int32(3)),
+			pending:   pending,
+		},
+		"NonePending": {
+			reason:    "Nothing should need confirmation if no revocations are pending",
+			threshold: new(int32(0)),
+		},
+		"Unconfirmed": {
+			reason:    "Revocations beyond the threshold should wait for confirmation",
+			threshold: new(int32(2)),
+			pending:   pending,
+			want:      fmt.Errorf(errRevocationsUnconfirmed, 3, 2, apisv1alpha1.AnnotationConfirmRevocations, 3),
+		},
+		"Confirmed": {
+			reason:      "Revocations beyond the threshold should proceed once their number is confirmed",
+			threshold:   new(int32(2)),
+			annotations: map[string]string{apisv1alpha1.AnnotationConfirmRevocations: "3"},
+			pending:     pending,
+		},
+		"StaleConfirmation": {
+			reason:      "A confirmation of fewer revocations should not cover revocations added later",
+			threshold:   new(int32(0)),
+			annotations: map[string]string{apisv1alpha1.AnnotationConfirmRevocations: "2"},
+			pending:     pending,
+			want:        fmt.Errorf(errRevocationsUnconfirmed, 3, 0, apisv1alpha1.AnnotationConfirmRevocations, 3),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{grantPolicy: &apisv1alpha1.GrantPolicy{RevocationThreshold: tc.threshold}}
+			cr := &v1alpha1.User{ObjectMeta: metav1.ObjectMeta{Annotations: tc.annotations}}
+			err := e.checkRevocations(cr, tc.pending)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.checkRevocations(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDescribeUpdate(t *testing.T) {
+	cases := map[string]struct {
+		reason   string
+		desired  v1alpha1.UserParameters
+		observed v1alpha1.UserObservation
+		status   v1alpha1.UserObservation
+		want     string
+	}{
+		"UpToDate": {
+			reason:   "Nothing should be described when the user is up to date",
+			desired:  v1alpha1.UserParameters{Usergroup: "DEFAULT", Privileges: []string{"CREATE ANY"}},
+			observed: v1alpha1.UserObservation{Usergroup: new("DEFAULT"), IsPasswordLifetimeCheckEnabled: new(false), Privileges: []string{"CREATE ANY"}},
+		},
+		"Changes": {
+			reason: "Changes should be counted or named instead of listed",
+			desired: v1alpha1.UserParameters{
+				Usergroup:  "DEFAULT",
+				Privileges: []string{"CREATE ANY", "AUDIT ADMIN", "CATALOG READ"},
+				Roles:      []string{"PUBLIC"},
+				Parameters: map[string]string{"LOCALE": "de_DE", "CLIENT": "100"},
+				Authentication: v1alpha1.Authentication{
+					Password: &v1alpha1.Password{},
+				},
+			},
+			observed: v1alpha1.UserObservation{
+				Usergroup:                      new("DEFAULT"),
+				IsPasswordLifetimeCheckEnabled: new(false),
+				Privileges:                     []string{"CREATE ANY", "USER ADMIN"},
+				Roles:                          []string{"PUBLIC"},
+				Parameters:                     map[string]string{"LOCALE": "en_US", "CLIENT": "100", "TIME ZONE": "UTC"},
+			},
+			status: v1alpha1.UserObservation{PasswordUpToDate: new(false)},
+			want:   "privileges +2/-1, parameters changed: LOCALE, TIME ZONE, password: rotate",
+		},
+		"Usergroup": {
+			reason:   "A usergroup change should show both usergroups",
+			desired:  v1alpha1.UserParameters{Usergroup: "OPS", IsPasswordLifetimeCheckEnabled: true},
+			observed: v1alpha1.UserObservation{Usergroup: new("DEFAULT"), IsPasswordLifetimeCheckEnabled: new(false)},
+			want:     "usergroup: DEFAULT -> OPS, password lifetime check: enabled",
+		},
+		"Unmanaged": {
+			reason: "Fields the user opted out of should not be described",
+			desired: v1alpha1.UserParameters{
+				Usergroup:        "DEFAULT",
+				ManagePrivileges: new(false),
+				Privileges:       []string{"AUDIT ADMIN"},
+			},
+			observed: v1alpha1.UserObservation{Usergroup: new("DEFAULT"), IsPasswordLifetimeCheckEnabled: new(false)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cr := &v1alpha1.User{
+				Spec:   v1alpha1.UserSpec{ForProvider: tc.desired},
+				Status: v1alpha1.UserStatus{AtProvider: tc.status},
+			}
+			got := describeUpdate(cr, &tc.desired, &tc.observed)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\ndescribeUpdate(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestIsValidityUpToDate(t *testing.T) {
+	until := metav1.NewTime(time.Date(2027, 3, 31, 23, 59, 59, 0, time.UTC))
+	untilNanos := metav1.NewTime(until.Add(500 * time.Millisecond))
+	later := metav1.NewTime(until.Add(time.Hour))
+
+	cases := map[string]struct {
+		reason       string
+		restrictions *v1alpha1.ConnectionRestrictions
+		observed     v1alpha1.UserObservation
+		want         bool
+	}{
+		"Unmanaged": {
+			reason:   "The validity period should be ignored without connection restrictions",
+			observed: v1alpha1.UserObservation{ValidUntil: &until},
+			want:     true,
+		},
+		"SecondPrecision": {
+			reason:       "Timestamps should be compared at second precision",
+			restrictions: &v1alpha1.ConnectionRestrictions{ValidUntil: &until},
+			observed:     v1alpha1.UserObservation{ValidUntil: &untilNanos},
+			want:         true,
+		},
+		"ValidUntilChanged": {
+			reason:       "A different end of the validity period should need an update",
+			restrictions: &v1alpha1.ConnectionRestrictions{ValidUntil: &later},
+			observed:     v1alpha1.UserObservation{ValidUntil: &until},
+			want:         false,
+		},
+		"ValidUntilRemoved": {
+			reason:       "An unset end of the validity period should need an update if the user expires",
+			restrictions: &v1alpha1.ConnectionRestrictions{},
+			observed:     v1alpha1.UserObservation{ValidUntil: &until},
+			want:         false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			desired := &v1alpha1.UserParameters{ConnectionRestrictions: tc.restrictions}
+			if got := isValidityUpToDate(&tc.observed, desired); got != tc.want {
+				t.Errorf("\n%s\nisValidityUpToDate(...): want %t, got %t", tc.reason, tc.want, got)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		client      user.UserClient
+		log         logging.Logger
+		grantPolicy *apisv1alpha1.GrantPolicy
 	}
 
 	type args struct {
@@ -724,7 +1311,9 @@ func TestDelete(t *testing.T) {
 	}
 
 	type want struct {
-		err error
+		c      managed.ExternalCreation
+		err    error
+		events []event.Reason
 	}
 
 	cases := map[string]struct {
@@ -742,11 +1331,11 @@ func TestDelete(t *testing.T) {
 				err: errors.New(errNotUser),
 			},
 		},
-		"ErrDelete": {
-			reason: "Any errors encountered while deleting the User should be returned",
+		"ErrCreate": {
+			reason: "Any errors encountered while creating the User should be returned",
 			fields: fields{
 				client: mockUserClient{
-					MockDelete: func(ctx context.Context, parameters *v1alpha1.UserParameters) error {
+					MockCreate: func(ctx context.Context, parameters *v1alpha1.UserParameters, password string, providers []user.ResolvedUserMapping) error {
 						return errBoom
 					},
 				},
@@ -762,14 +1351,41 @@ func TestDelete(t *testing.T) {
 				},
 			},
 			want: want{
-				err: fmt.Errorf(errDropUser, errBoom),
+				err: fmt.Errorf(errCreateUser, errBoom),
+			},
+		},
+		"ErrForbiddenGrant": {
+			reason: "Privileges forbidden by the grant policy of the ProviderConfig should be rejected",
+			fields: fields{
+				client: mockUserClient{
+					MockCreate: func(ctx context.Context, parameters *v1alpha1.UserParameters, password string, providers []user.ResolvedUserMapping) error {
+						return errBoom
+					},
+				},
+				log: &MockLogger{},
+				grantPolicy: &apisv1alpha1.GrantPolicy{
+					ForbiddenPrivileges: []string{"USER ADMIN"},
+				},
+			},
+			args: args{
+				mg: &v1alpha1.User{
+					Spec: v1alpha1.UserSpec{
+						ForProvider: v1alpha1.UserParameters{
+							Username:   demoUser,
+							Privileges: []string{"USER ADMIN WITH ADMIN OPTION"},
+						},
+					},
+				},
+			},
+			want: want{
+				err: fmt.Errorf(errCreateUser, fmt.Errorf("%w: privileges USER ADMIN WITH ADMIN OPTION", privilege.ErrForbiddenGrant)),
 			},
 		},
 		"Success": {
-			reason: "No error should be returned when we successfully delete a User",
+			reason: "No error should be returned when we successfully create a User",
 			fields: fields{
 				client: mockUserClient{
-					MockDelete: func(ctx context.Context, parameters *v1alpha1.UserParameters) error {
+					MockCreate: func(ctx context.Context, parameters *v1alpha1.UserParameters, password string, providers []user.ResolvedUserMapping) error {
 						return nil
 					},
 				},
@@ -786,68 +1402,315 @@ func TestDelete(t *testing.T) {
 			},
 			want: want{
 				err: nil,
+				c: managed.ExternalCreation{ConnectionDetails: managed.ConnectionDetails{
+					"user":       []byte(demoUser),
+					"externalID": []byte(demoUser),
+				}},
+				events: []event.Reason{reasonDefaultsApplied},
 			},
 		},
-	}
-
-	for name, tc := range cases {
-		t.Run(name, func(t *testing.T) {
-			e := external{client: tc.fields.client, log: tc.fields.log}
-			_, err := e.Delete(tc.args.ctx, tc.args.mg)
-			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
-				t.Errorf("\n%s\ne.Delete(...): -want error, +got error:\n%s\n", tc.reason, diff)
-			}
-		})
-	}
-}
-
-func TestGenerateReconcileRequestsFromSecret(t *testing.T) {
-	user1 := &v1alpha1.User{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      "testUserName1",
-			Namespace: "testUserNamespace1",
-		},
-		Spec: v1alpha1.UserSpec{
-			ForProvider: v1alpha1.UserParameters{
-				Authentication: v1alpha1.Authentication{
-					Password: &v1alpha1.Password{
-						PasswordSecretRef: &xpv1.SecretKeySelector{
-							SecretReference: xpv1.SecretReference{
-								Namespace: "testSecretNamespace1",
-								Name:      "testSecretName1",
-							},
+		"SkipsDefaults": {
+			reason: "Defaults the User opts out of should be reported as skipped",
+			fields: fields{
+				client: mockUserClient{
+					MockCreate: func(ctx context.Context, parameters *v1alpha1.UserParameters, password string, providers []user.ResolvedUserMapping) error {
+						return nil
+					},
+				},
+				log: &MockLogger{},
+			},
+			args: args{
+				mg: &v1alpha1.User{
+					Spec: v1alpha1.UserSpec{
+						PrivilegeManagementPolicy: "strict",
+						ForProvider: v1alpha1.UserParameters{
+							Username:           demoUser,
+							NoDefaultRole:      true,
+							NoDefaultPrivilege: true,
 						},
 					},
 				},
 			},
+			want: want{
+				c: managed.ExternalCreation{ConnectionDetails: managed.ConnectionDetails{
+					"user":       []byte(demoUser),
+					"externalID": []byte(demoUser),
+				}},
+				events: []event.Reason{reasonDefaultsSkipped},
+			},
 		},
-	}
-	user2 := &v1alpha1.User{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      "testUserName2",
-			Namespace: "testUserNamespace2",
+		"FoldsUsername": {
+			reason: "A username that is not case-sensitive should be created in uppercase",
+			fields: fields{
+				client: mockUserClient{
+					MockCreate: func(ctx context.Context, parameters *v1alpha1.UserParameters, password string, providers []user.ResolvedUserMapping) error {
+						if parameters.Username != demoUser {
+							return fmt.Errorf("unexpected username %s", parameters.Username)
+						}
+						return nil
+					},
+				},
+				log: &MockLogger{},
+			},
+			args: args{
+				mg: &v1alpha1.User{
+					Spec: v1alpha1.UserSpec{
+						ForProvider: v1alpha1.UserParameters{
+							Username: "demo_user",
+						},
+					},
+				},
+			},
+			want: want{
+				c: managed.ExternalCreation{ConnectionDetails: managed.ConnectionDetails{
+					"user":       []byte(demoUser),
+					"externalID": []byte(demoUser),
+				}},
+				events: []event.Reason{reasonDefaultsApplied},
+			},
 		},
-		Spec: v1alpha1.UserSpec{
-			ForProvider: v1alpha1.UserParameters{
-				Authentication: v1alpha1.Authentication{
-					Password: &v1alpha1.Password{
-						PasswordSecretRef: &xpv1.SecretKeySelector{
-							SecretReference: xpv1.SecretReference{
-								Namespace: "testSecretNamespace2",
-								Name:      "testSecretName2",
-							},
+		"KeepsCaseSensitiveUsername": {
+			reason: "A case-sensitive username should be created as written",
+			fields: fields{
+				client: mockUserClient{
+					MockCreate: func(ctx context.Context, parameters *v1alpha1.UserParameters, password string, providers []user.ResolvedUserMapping) error {
+						if parameters.Username != "demo_user" {
+							return fmt.Errorf("unexpected username %s", parameters.Username)
+						}
+						return nil
+					},
+				},
+				log: &MockLogger{},
+			},
+			args: args{
+				mg: &v1alpha1.User{
+					Spec: v1alpha1.UserSpec{
+						ForProvider: v1alpha1.UserParameters{
+							Username:      "demo_user",
+							CaseSensitive: true,
 						},
 					},
 				},
 			},
+			want: want{
+				c: managed.ExternalCreation{ConnectionDetails: managed.ConnectionDetails{
+					"user":       []byte("demo_user"),
+					"externalID": []byte("demo_user"),
+				}},
+				events: []event.Reason{reasonDefaultsApplied},
+			},
 		},
 	}
 
-	secret := &corev1.Secret{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      "testSecretName1",
-			Namespace: "testSecretNamespace1",
-		},
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			recorder := &mockRecorder{}
+			e := external{client: tc.fields.client, log: tc.fields.log, grantPolicy: tc.fields.grantPolicy, recorder: recorder}
+			got, err := e.Create(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.c, got); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.events, recorder.reasons); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want events, +got events:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type fields struct {
+		client user.UserClient
+		log    logging.Logger
+	}
+
+	type args struct {
+		ctx context.Context
+		mg  resource.Managed
+	}
+
+	type want struct {
+		protected bool
+		err       error
+	}
+
+	cases := map[string]struct {
+		reason string
+		fields fields
+		args   args
+		want   want
+	}{
+		"ErrNotUser": {
+			reason: "An error should be returned if the managed resource is not a *User",
+			args: args{
+				mg: nil,
+			},
+			want: want{
+				err: errors.New(errNotUser),
+			},
+		},
+		"ErrDelete": {
+			reason: "Any errors encountered while deleting the User should be returned",
+			fields: fields{
+				client: mockUserClient{
+					MockDelete: func(ctx context.Context, parameters *v1alpha1.UserParameters) error {
+						return errBoom
+					},
+				},
+				log: &MockLogger{},
+			},
+			args: args{
+				mg: &v1alpha1.User{
+					Spec: v1alpha1.UserSpec{
+						ForProvider: v1alpha1.UserParameters{
+							Username: demoUser,
+						},
+					},
+				},
+			},
+			want: want{
+				err: fmt.Errorf(errDropUser, errBoom),
+			},
+		},
+		"Protected": {
+			reason: "A protected User should not be dropped and its deletion should be blocked",
+			fields: fields{
+				client: mockUserClient{
+					MockDelete: func(ctx context.Context, parameters *v1alpha1.UserParameters) error {
+						return errBoom
+					},
+				},
+				log: &MockLogger{},
+			},
+			args: args{
+				mg: &v1alpha1.User{
+					Spec: v1alpha1.UserSpec{
+						ForProvider: v1alpha1.UserParameters{
+							Username: demoUser,
+						},
+						ProtectionPolicy: v1alpha1.ProtectionPolicyProtected,
+					},
+				},
+			},
+			want: want{
+				protected: true,
+				err:       fmt.Errorf(errProtected, demoUser),
+			},
+		},
+		"Unprotected": {
+			reason: "An unprotected User should be dropped",
+			fields: fields{
+				client: mockUserClient{
+					MockDelete: func(ctx context.Context, parameters *v1alpha1.UserParameters) error {
+						return nil
+					},
+				},
+				log: &MockLogger{},
+			},
+			args: args{
+				mg: &v1alpha1.User{
+					Spec: v1alpha1.UserSpec{
+						ForProvider: v1alpha1.UserParameters{
+							Username: demoUser,
+						},
+						ProtectionPolicy: v1alpha1.ProtectionPolicyUnprotected,
+					},
+				},
+			},
+		},
+		"Success": {
+			reason: "No error should be returned when we successfully delete a User",
+			fields: fields{
+				client: mockUserClient{
+					MockDelete: func(ctx context.Context, parameters *v1alpha1.UserParameters) error {
+						return nil
+					},
+				},
+				log: &MockLogger{},
+			},
+			args: args{
+				mg: &v1alpha1.User{
+					Spec: v1alpha1.UserSpec{
+						ForProvider: v1alpha1.UserParameters{
+							Username: demoUser,
+						},
+					},
+				},
+			},
+			want: want{
+				err: nil,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.fields.client, log: tc.fields.log}
+			_, err := e.Delete(tc.args.ctx, tc.args.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Delete(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if cr, ok := tc.args.mg.(*v1alpha1.User); ok {
+				protected := cr.GetCondition(apisv1alpha1.TypeDeletionBlocked).Reason == apisv1alpha1.ReasonProtected
+				if protected != tc.want.protected {
+					t.Errorf("\n%s\ne.Delete(...): protected condition = %v, want %v", tc.reason, protected, tc.want.protected)
+				}
+			}
+		})
+	}
+}
+
+func TestGenerateReconcileRequestsFromSecret(t *testing.T) {
+	user1 := &v1alpha1.User{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "testUserName1",
+			Namespace: "testUserNamespace1",
+		},
+		Spec: v1alpha1.UserSpec{
+			ForProvider: v1alpha1.UserParameters{
+				Authentication: v1alpha1.Authentication{
+					Password: &v1alpha1.Password{
+						PasswordSecretRef: &xpv1.SecretKeySelector{
+							SecretReference: xpv1.SecretReference{
+								Namespace: "testSecretNamespace1",
+								Name:      "testSecretName1",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	user2 := &v1alpha1.User{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "testUserName2",
+			Namespace: "testUserNamespace2",
+		},
+		Spec: v1alpha1.UserSpec{
+			ForProvider: v1alpha1.UserParameters{
+				Authentication: v1alpha1.Authentication{
+					Password: &v1alpha1.Password{
+						PasswordSecretRef: &xpv1.SecretKeySelector{
+							SecretReference: xpv1.SecretReference{
+								Namespace: "testSecretNamespace2",
+								Name:      "testSecretName2",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "testSecretName1",
+			Namespace: "testSecretNamespace1",
+		},
 	}
 
 	errBoom := errors.New("boom")
@@ -969,3 +1832,1090 @@ func TestGenerateReconcileRequestsFromSecret(t *testing.T) {
 		})
 	}
 }
+
+func TestGenerateReconcileRequestsFromCredentialsSecret(t *testing.T) {
+	errBoom := errors.New("boom")
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "crossplane-system", Name: "hana-creds"}}
+	pc := func(name, secretName string) apisv1alpha1.ProviderConfig {
+		return apisv1alpha1.ProviderConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: apisv1alpha1.ProviderConfigSpec{
+				Credentials: apisv1alpha1.ProviderCredentials{
+					ConnectionSecretRef: &xpv1.SecretReference{Namespace: "crossplane-system", Name: secretName},
+				},
+			},
+		}
+	}
+	userOf := func(name, pcName string) v1alpha1.User {
+		u := v1alpha1.User{ObjectMeta: metav1.ObjectMeta{Name: name}}
+		u.SetProviderConfigReference(&xpv1.Reference{Name: pcName})
+		return u
+	}
+
+	cases := map[string]struct {
+		reason string
+		kube   client.Client
+		want   []reconcile.Request
+	}{
+		"ErrListProviderConfigs": {
+			reason: "An empty Request should be returned if we can't list the ProviderConfigs",
+			kube:   &test.MockClient{MockList: test.NewMockListFn(errBoom)},
+			want:   []reconcile.Request{},
+		},
+		"UsersOfProviderConfig": {
+			reason: "Only the Users of the ProviderConfigs using the Secret should be enqueued",
+			kube: &test.MockClient{
+				MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
+					switch l := obj.(type) {
+					case *apisv1alpha1.ProviderConfigList:
+						l.Items = []apisv1alpha1.ProviderConfig{pc("default", "hana-creds"), pc("other", "other-creds")}
+					case *v1alpha1.UserList:
+						l.Items = []v1alpha1.User{userOf("alice", "default"), userOf("bob", "other")}
+					}
+					return nil
+				}),
+			},
+			want: []reconcile.Request{{NamespacedName: types.NamespacedName{Name: "alice"}}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := generateReconcileRequestsFromCredentialsSecret(context.Background(), secret, tc.kube, &MockLogger{})
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\ngenerateReconcileRequestsFromCredentialsSecret(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestIndexPasswordSecretRef(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		obj    client.Object
+		want   []string
+	}{
+		"NotUser": {
+			reason: "Objects other than Users should not be indexed",
+			obj:    &corev1.Secret{},
+		},
+		"NoPassword": {
+			reason: "Users without a password Secret should not be indexed",
+			obj:    &v1alpha1.User{},
+		},
+		"PasswordSecret": {
+			reason: "Users should be indexed by the namespace and name of their password Secret",
+			obj: &v1alpha1.User{
+				Spec: v1alpha1.UserSpec{
+					ForProvider: v1alpha1.UserParameters{
+						Authentication: v1alpha1.Authentication{
+							Password: &v1alpha1.Password{
+								PasswordSecretRef: &xpv1.SecretKeySelector{
+									SecretReference: xpv1.SecretReference{Namespace: "ns", Name: "secret"},
+								},
+							},
+						},
+					},
+				},
+			},
+			want: []string{"ns/secret"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, indexPasswordSecretRef(tc.obj)); diff != "" {
+				t.Errorf("\n%s\nindexPasswordSecretRef(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestInNamespaces(t *testing.T) {
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "users"}}
+
+	if !inNamespaces(nil)(secret) {
+		t.Errorf("inNamespaces(nil): want Secrets in all namespaces accepted")
+	}
+	if !inNamespaces([]string{"crossplane-system", "users"})(secret) {
+		t.Errorf("inNamespaces(...): want Secret in allowed namespace accepted")
+	}
+	if !inNamespaces([]string{"crossplane-system, users"})(secret) {
+		t.Errorf("inNamespaces(...): want Secret in comma-separated allowed namespace accepted")
+	}
+	if inNamespaces([]string{"crossplane-system"})(secret) {
+		t.Errorf("inNamespaces(...): want Secret in other namespace rejected")
+	}
+}
+
+func TestHandleDefaultsUsergroup(t *testing.T) {
+	cases := map[string]struct {
+		reason           string
+		usergroup        string
+		defaultUsergroup string
+		want             string
+	}{
+		"Explicit": {
+			reason:           "An explicit usergroup should be kept",
+			usergroup:        "APP",
+			defaultUsergroup: "CROSSPLANE",
+			want:             "APP",
+		},
+		"ProviderConfig": {
+			reason:           "The defaultUsergroup of the ProviderConfig should be used without a usergroup",
+			defaultUsergroup: "CROSSPLANE",
+			want:             "CROSSPLANE",
+		},
+		"Default": {
+			reason: "The DEFAULT usergroup should be used without any usergroup configured",
+			want:   "DEFAULT",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cr := &v1alpha1.User{Spec: v1alpha1.UserSpec{ForProvider: v1alpha1.UserParameters{Username: "DEMO_USER", Usergroup: tc.usergroup}}}
+			if got := handleDefaults(cr, tc.defaultUsergroup, "", "").Usergroup; got != tc.want {
+				t.Errorf("\n%s\nhandleDefaults(...): want %s, got %s", tc.reason, tc.want, got)
+			}
+		})
+	}
+}
+
+func TestHandleDefaultsIdentifierCase(t *testing.T) {
+	spec := v1alpha1.UserParameters{
+		Username:      "demo_user",
+		Usergroup:     "app_users",
+		Privileges:    []string{"SELECT ON SCHEMA myschema", `SELECT ON SCHEMA "mySchema"`},
+		Roles:         []string{"reader", `"Writer"`},
+		NoDefaultRole: true,
+	}
+
+	cases := map[string]struct {
+		reason         string
+		identifierCase string
+		want           *v1alpha1.UserParameters
+	}{
+		"Default": {
+			reason: "By default only the username should be folded",
+			want: &v1alpha1.UserParameters{
+				Username:      "DEMO_USER",
+				Usergroup:     "app_users",
+				Privileges:    []string{"SELECT ON SCHEMA myschema", `SELECT ON SCHEMA "mySchema"`},
+				Roles:         []string{"reader", `"Writer"`},
+				NoDefaultRole: true,
+			},
+		},
+		"Upper": {
+			reason:         "All unquoted identifiers should be folded, quoted ones kept",
+			identifierCase: apisv1alpha1.IdentifierCaseUpper,
+			want: &v1alpha1.UserParameters{
+				Username:      "DEMO_USER",
+				Usergroup:     "APP_USERS",
+				Privileges:    []string{`SELECT ON SCHEMA "MYSCHEMA"`, `SELECT ON SCHEMA "mySchema"`},
+				Roles:         []string{`"READER"`, `"Writer"`},
+				NoDefaultRole: true,
+			},
+		},
+		"Preserve": {
+			reason:         "No identifier should be folded, not even the username",
+			identifierCase: apisv1alpha1.IdentifierCasePreserve,
+			want: &v1alpha1.UserParameters{
+				Username:      "demo_user",
+				Usergroup:     "app_users",
+				Privileges:    []string{"SELECT ON SCHEMA myschema", `SELECT ON SCHEMA "mySchema"`},
+				Roles:         []string{"reader", `"Writer"`},
+				NoDefaultRole: true,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cr := &v1alpha1.User{Spec: v1alpha1.UserSpec{ForProvider: *spec.DeepCopy()}}
+			got := handleDefaults(cr, "", "", tc.identifierCase)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\nhandleDefaults(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestHandleDefaultsTypedPrivileges(t *testing.T) {
+	cr := &v1alpha1.User{Spec: v1alpha1.UserSpec{ForProvider: v1alpha1.UserParameters{
+		Username:   "DEMO_USER",
+		Usergroup:  "DEFAULT",
+		Privileges: []string{`SELECT ON SCHEMA "mySchema"`},
+		TypedPrivileges: []v1alpha1.TypedPrivilege{
+			{Type: v1alpha1.PrivilegeTypeSchema, Name: "SELECT", Schema: "mySchema"},
+			{Type: v1alpha1.PrivilegeTypeObject, Name: "SELECT", Schema: "mySchema", Object: "myTable"},
+		},
+		NoDefaultRole: true,
+	}}}
+
+	got := handleDefaults(cr, "", "", apisv1alpha1.IdentifierCaseUpper)
+	want := []string{`SELECT ON SCHEMA "mySchema"`, `SELECT ON "mySchema"."myTable"`}
+	if diff := cmp.Diff(want, got.Privileges); diff != "" {
+		t.Errorf("handleDefaults(...): typed privileges should be granted as written, -want, +got:\n%s", diff)
+	}
+}
+
+func TestCreateDefaults(t *testing.T) {
+	var got *v1alpha1.UserParameters
+	e := external{
+		client: mockUserClient{
+			MockCreate: func(ctx context.Context, parameters *v1alpha1.UserParameters, password string, providers []user.ResolvedUserMapping) error {
+				got = parameters
+				return nil
+			},
+		},
+		log:               &MockLogger{},
+		recorder:          event.NewNopRecorder(),
+		operatorUsergroup: "APPS",
+		defaultUsergroup:  "APPS",
+	}
+	cr := &v1alpha1.User{Spec: v1alpha1.UserSpec{ForProvider: v1alpha1.UserParameters{
+		Username: demoUser,
+		TypedPrivileges: []v1alpha1.TypedPrivilege{
+			{Type: v1alpha1.PrivilegeTypeSchema, Name: "SELECT", Schema: "mySchema"},
+		},
+		NoDefaultRole: true,
+	}}}
+
+	if _, err := e.Create(context.Background(), cr); err != nil {
+		t.Fatalf("e.Create(...): users should be created with the defaults of the ProviderConfig, got error: %v", err)
+	}
+	if got.Usergroup != "APPS" {
+		t.Errorf("e.Create(...): want usergroup APPS, got %q", got.Usergroup)
+	}
+	if diff := cmp.Diff([]string{`SELECT ON SCHEMA "mySchema"`}, got.Privileges); diff != "" {
+		t.Errorf("e.Create(...): typed privileges should be granted on create, -want, +got:\n%s", diff)
+	}
+}
+
+func TestHandleDefaultsPasswordNeverExpires(t *testing.T) {
+	cases := map[string]struct {
+		reason        string
+		neverExpires  bool
+		wantLifetime  bool
+		wantForceOnce bool
+	}{
+		"Default": {
+			reason:        "The lifetime check and first password change should be kept as configured",
+			wantLifetime:  true,
+			wantForceOnce: true,
+		},
+		"NeverExpires": {
+			reason:       "A password that never expires should disable the lifetime check and the forced first password change",
+			neverExpires: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cr := &v1alpha1.User{Spec: v1alpha1.UserSpec{ForProvider: v1alpha1.UserParameters{
+				Username:                       "DEMO_USER",
+				IsPasswordLifetimeCheckEnabled: true,
+				PasswordNeverExpires:           tc.neverExpires,
+				Authentication: v1alpha1.Authentication{
+					Password: &v1alpha1.Password{ForceFirstPasswordChange: true},
+				},
+			}}}
+			got := handleDefaults(cr, "", "", "")
+			if got.IsPasswordLifetimeCheckEnabled != tc.wantLifetime {
+				t.Errorf("\n%s\nhandleDefaults(...): want lifetime check %t, got %t", tc.reason, tc.wantLifetime, got.IsPasswordLifetimeCheckEnabled)
+			}
+			if got.Authentication.Password.ForceFirstPasswordChange != tc.wantForceOnce {
+				t.Errorf("\n%s\nhandleDefaults(...): want forced first password change %t, got %t", tc.reason, tc.wantForceOnce, got.Authentication.Password.ForceFirstPasswordChange)
+			}
+			if !cr.Spec.ForProvider.IsPasswordLifetimeCheckEnabled || !cr.Spec.ForProvider.Authentication.Password.ForceFirstPasswordChange {
+				t.Errorf("\n%s\nhandleDefaults(...): the spec should not be changed", tc.reason)
+			}
+		})
+	}
+}
+
+func TestDefaultGrants(t *testing.T) {
+	defaultPrivilege := privilege.GetDefaultPrivilege(demoUser)
+
+	cases := map[string]struct {
+		reason         string
+		policy         string
+		template       string
+		params         v1alpha1.UserParameters
+		wantPrivileges []string
+		wantRoles      []string
+	}{
+		"Strict": {
+			reason:         "A user under the strict policy should get the default privilege and role",
+			policy:         "strict",
+			wantPrivileges: []string{defaultPrivilege},
+			wantRoles:      []string{"PUBLIC"},
+		},
+		"Template": {
+			reason:         "The default privilege should be expanded from the template of the ProviderConfig",
+			policy:         "strict",
+			template:       `CREATE ANY ON SCHEMA "${USERNAME}"`,
+			wantPrivileges: []string{`CREATE ANY ON SCHEMA "DEMO_USER"`},
+			wantRoles:      []string{"PUBLIC"},
+		},
+		"Lax": {
+			reason:    "A user under another policy should only get the default role",
+			policy:    "lax",
+			wantRoles: []string{"PUBLIC"},
+		},
+		"Restricted": {
+			reason: "A restricted user should get no defaults",
+			policy: "strict",
+			params: v1alpha1.UserParameters{RestrictedUser: true},
+		},
+		"OptedOut": {
+			reason: "A user opting out of the defaults should get none",
+			policy: "strict",
+			params: v1alpha1.UserParameters{NoDefaultRole: true, NoDefaultPrivilege: true},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cr := &v1alpha1.User{Spec: v1alpha1.UserSpec{PrivilegeManagementPolicy: tc.policy, ForProvider: tc.params}}
+			privileges, roles := defaultGrants(cr, demoUser, tc.template)
+			if diff := cmp.Diff(tc.wantPrivileges, privileges); diff != "" {
+				t.Errorf("\n%s\ndefaultGrants(...): -want privileges, +got privileges:\n%s", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.wantRoles, roles); diff != "" {
+				t.Errorf("\n%s\ndefaultGrants(...): -want roles, +got roles:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDropPreviousUser(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type want struct {
+		dropped string
+		status  v1alpha1.UserObservation
+		err     error
+	}
+
+	cases := map[string]struct {
+		reason      string
+		annotations map[string]string
+		protection  string
+		observed    *string
+		deleteErr   error
+		want        want
+	}{
+		"Unchanged": {
+			reason:   "Nothing should happen if the username was not changed",
+			observed: new("DEMO_USER"),
+			want:     want{status: v1alpha1.UserObservation{Username: new("DEMO_USER")}},
+		},
+		"NotObserved": {
+			reason: "Nothing should happen if no user was observed yet",
+		},
+		"Rejected": {
+			reason:   "A changed username should be rejected by default",
+			observed: new("OLD_USER"),
+			want: want{
+				status: v1alpha1.UserObservation{Username: new("OLD_USER")},
+				err:    fmt.Errorf(errRenameUser, "OLD_USER", "DEMO_USER"),
+			},
+		},
+		"Recreate": {
+			reason:      "The user of the previous username should be dropped if the User opts into recreation",
+			annotations: map[string]string{v1alpha1.AnnotationRenamePolicy: v1alpha1.RenamePolicyRecreate},
+			observed:    new("OLD_USER"),
+			want:        want{dropped: "OLD_USER"},
+		},
+		"Protected": {
+			reason:      "The user of the previous username should not be dropped if the User is protected",
+			annotations: map[string]string{v1alpha1.AnnotationRenamePolicy: v1alpha1.RenamePolicyRecreate},
+			protection:  v1alpha1.ProtectionPolicyProtected,
+			observed:    new("OLD_USER"),
+			want: want{
+				status: v1alpha1.UserObservation{Username: new("OLD_USER")},
+				err:    fmt.Errorf(errProtected, "OLD_USER"),
+			},
+		},
+		"ErrDrop": {
+			reason:      "Any errors encountered while dropping the previous user should be returned",
+			annotations: map[string]string{v1alpha1.AnnotationRenamePolicy: v1alpha1.RenamePolicyRecreate},
+			observed:    new("OLD_USER"),
+			deleteErr:   errBoom,
+			want: want{
+				dropped: "OLD_USER",
+				status:  v1alpha1.UserObservation{Username: new("OLD_USER")},
+				err:     fmt.Errorf(errDropUser, errBoom),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var dropped string
+			e := external{
+				client: mockUserClient{
+					MockDelete: func(ctx context.Context, parameters *v1alpha1.UserParameters) error {
+						dropped = parameters.Username
+						return tc.deleteErr
+					},
+				},
+				log: &MockLogger{},
+			}
+			cr := &v1alpha1.User{
+				Spec:   v1alpha1.UserSpec{ProtectionPolicy: tc.protection},
+				Status: v1alpha1.UserStatus{AtProvider: v1alpha1.UserObservation{Username: tc.observed}},
+			}
+			cr.SetAnnotations(tc.annotations)
+
+			got, err := e.dropPreviousUser(context.Background(), cr, "DEMO_USER")
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.dropPreviousUser(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.dropped != "" && tc.want.err == nil, got); diff != "" {
+				t.Errorf("\n%s\ne.dropPreviousUser(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.dropped, dropped); diff != "" {
+				t.Errorf("\n%s\ne.dropPreviousUser(...): -want dropped, +got dropped:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.status, cr.Status.AtProvider); diff != "" {
+				t.Errorf("\n%s\ne.dropPreviousUser(...): -want status, +got status:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestHoldMissingTargets(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type want struct {
+		privileges []string
+		condition  xpv1.Condition
+		err        error
+	}
+
+	cases := map[string]struct {
+		reason    string
+		missing   []privilege.MissingTarget
+		checkErr  error
+		condition *xpv1.Condition
+		want      want
+	}{
+		"AllTargetsExist": {
+			reason: "All privileges should be kept and no condition set if all targets exist",
+			want: want{
+				privileges: []string{`SELECT ON SCHEMA "APP"`, `SELECT ON SCHEMA "STAGING"`},
+				condition:  xpv1.Condition{Type: apisv1alpha1.TypeGrantTargets, Status: corev1.ConditionUnknown},
+			},
+		},
+		"MissingTarget": {
+			reason: "Privileges on missing targets should be held back and reported",
+			missing: []privilege.MissingTarget{
+				{Privilege: `SELECT ON SCHEMA "STAGING"`, Object: `SCHEMA "STAGING"`},
+			},
+			want: want{
+				privileges: []string{`SELECT ON SCHEMA "APP"`},
+				condition:  apisv1alpha1.GrantTargetsMissing([]string{`SCHEMA "STAGING"`}),
+			},
+		},
+		"TargetAppeared": {
+			reason:    "The condition should be cleared once the missing targets exist",
+			condition: new(apisv1alpha1.GrantTargetsMissing([]string{`SCHEMA "STAGING"`})),
+			want: want{
+				privileges: []string{`SELECT ON SCHEMA "APP"`, `SELECT ON SCHEMA "STAGING"`},
+				condition:  apisv1alpha1.GrantTargetsAvailable(),
+			},
+		},
+		"ErrCheck": {
+			reason:   "Any errors encountered while checking the targets should be returned",
+			checkErr: errBoom,
+			want: want{
+				privileges: []string{`SELECT ON SCHEMA "APP"`, `SELECT ON SCHEMA "STAGING"`},
+				condition:  xpv1.Condition{Type: apisv1alpha1.TypeGrantTargets, Status: corev1.ConditionUnknown},
+				err:        fmt.Errorf(errCheckTargets, errBoom),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{
+				client: mockUserClient{
+					MockMissingTargets: func(ctx context.Context, privileges []string) ([]privilege.MissingTarget, error) {
+						return tc.missing, tc.checkErr
+					},
+				},
+				log: &MockLogger{},
+			}
+			cr := &v1alpha1.User{}
+			if tc.condition != nil {
+				cr.SetConditions(*tc.condition)
+			}
+			parameters := &v1alpha1.UserParameters{Privileges: []string{`SELECT ON SCHEMA "APP"`, `SELECT ON SCHEMA "STAGING"`}}
+
+			err := e.holdMissingTargets(context.Background(), cr, parameters)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.holdMissingTargets(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.privileges, parameters.Privileges); diff != "" {
+				t.Errorf("\n%s\ne.holdMissingTargets(...): -want privileges, +got privileges:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.condition, cr.GetCondition(apisv1alpha1.TypeGrantTargets), test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\ne.holdMissingTargets(...): -want condition, +got condition:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUnreadyDependencies(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	provider := func(name, hanaName string, ready bool) v1alpha1.X509Provider {
+		p := v1alpha1.X509Provider{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{"idp": name}},
+			Spec:       v1alpha1.X509ProviderSpec{ForProvider: v1alpha1.X509ProviderParameters{Name: hanaName}},
+		}
+		if ready {
+			p.SetConditions(xpv1.Available())
+		}
+		return p
+	}
+	pse := func(name string, ref *v1alpha1.X509ProviderRef, ready bool) v1alpha1.PersonalSecurityEnvironment {
+		p := v1alpha1.PersonalSecurityEnvironment{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       v1alpha1.PersonalSecurityEnvironmentSpec{ForProvider: v1alpha1.PersonalSecurityEnvironmentParameters{X509ProviderRef: ref}},
+		}
+		if ready {
+			p.SetConditions(xpv1.Available())
+		}
+		return p
+	}
+
+	cases := map[string]struct {
+		reason    string
+		mappings  []v1alpha1.X509UserMapping
+		providers []v1alpha1.X509Provider
+		pses      []v1alpha1.PersonalSecurityEnvironment
+		listErr   error
+		want      []string
+		err       error
+	}{
+		"NoMappings": {
+			reason: "A user without X.509 mappings should not wait for anything",
+		},
+		"AllReady": {
+			reason:    "No dependencies should be reported once the provider and its PSE are Ready",
+			mappings:  []v1alpha1.X509UserMapping{{X509ProviderRef: v1alpha1.X509ProviderRef{ProviderRef: &xpv1.Reference{Name: "idp"}}}},
+			providers: []v1alpha1.X509Provider{provider("idp", "IDP", true)},
+			pses:      []v1alpha1.PersonalSecurityEnvironment{pse("idp-pse", &v1alpha1.X509ProviderRef{ProviderRef: &xpv1.Reference{Name: "idp"}}, true)},
+		},
+		"ProviderMissing": {
+			reason:   "A referenced X509Provider that does not exist yet should be waited for",
+			mappings: []v1alpha1.X509UserMapping{{X509ProviderRef: v1alpha1.X509ProviderRef{ProviderRef: &xpv1.Reference{Name: "idp"}}}},
+			want:     []string{"X509Provider/idp"},
+		},
+		"ProviderAndPSENotReady": {
+			reason:    "The provider and the PSEs referencing it should be waited for until they are Ready",
+			mappings:  []v1alpha1.X509UserMapping{{X509ProviderRef: v1alpha1.X509ProviderRef{ProviderRef: &xpv1.Reference{Name: "idp"}}}},
+			providers: []v1alpha1.X509Provider{provider("idp", "IDP", false)},
+			pses: []v1alpha1.PersonalSecurityEnvironment{
+				pse("by-ref", &v1alpha1.X509ProviderRef{ProviderRef: &xpv1.Reference{Name: "idp"}}, false),
+				pse("by-name", &v1alpha1.X509ProviderRef{Name: "IDP"}, false),
+				pse("by-selector", &v1alpha1.X509ProviderRef{ProviderSelector: &xpv1.Selector{MatchLabels: map[string]string{"idp": "idp"}}}, false),
+				pse("other", &v1alpha1.X509ProviderRef{Name: "OTHER"}, false),
+				pse("none", nil, false),
+			},
+			want: []string{"X509Provider/idp", "PersonalSecurityEnvironment/by-ref", "PersonalSecurityEnvironment/by-name", "PersonalSecurityEnvironment/by-selector"},
+		},
+		"SelectorMatchesNothing": {
+			reason:   "A providerSelector that matches no X509Provider yet should be waited for",
+			mappings: []v1alpha1.X509UserMapping{{X509ProviderRef: v1alpha1.X509ProviderRef{ProviderSelector: &xpv1.Selector{MatchLabels: map[string]string{"idp": "idp"}}}}},
+			want:     []string{"X509Provider/idp=idp"},
+		},
+		"MappingByName": {
+			reason:    "Mappings by name should depend on the X509Providers managing a provider of that name",
+			mappings:  []v1alpha1.X509UserMapping{{X509ProviderRef: v1alpha1.X509ProviderRef{Name: "IDP"}}},
+			providers: []v1alpha1.X509Provider{provider("idp", "IDP", false), provider("other", "OTHER", false)},
+			want:      []string{"X509Provider/idp"},
+		},
+		"ErrList": {
+			reason:   "Any errors encountered while listing the dependencies should be returned",
+			mappings: []v1alpha1.X509UserMapping{{X509ProviderRef: v1alpha1.X509ProviderRef{Name: "IDP"}}},
+			listErr:  errBoom,
+			err:      errBoom,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			kube := &test.MockClient{
+				MockGet: func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+					for _, p := range tc.providers {
+						if p.GetName() == key.Name {
+							p.DeepCopyInto(obj.(*v1alpha1.X509Provider))
+							return nil
+						}
+					}
+					return apierrors.NewNotFound(schema.GroupResource{Resource: "x509providers"}, key.Name)
+				},
+				MockList: func(_ context.Context, list client.ObjectList, _ ...client.ListOption) error {
+					if tc.listErr != nil {
+						return tc.listErr
+					}
+					switch l := list.(type) {
+					case *v1alpha1.X509ProviderList:
+						l.Items = tc.providers
+					case *v1alpha1.PersonalSecurityEnvironmentList:
+						l.Items = tc.pses
+					}
+					return nil
+				},
+			}
+			e := external{kube: kube, log: &MockLogger{}}
+			got, err := e.unreadyDependencies(context.Background(), &v1alpha1.User{}, tc.mappings)
+			if diff := cmp.Diff(tc.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.unreadyDependencies(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("\n%s\ne.unreadyDependencies(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestResolveUserMappings(t *testing.T) {
+	selector := []v1alpha1.X509UserMapping{{X509ProviderRef: v1alpha1.X509ProviderRef{ProviderSelector: &xpv1.Selector{MatchLabels: map[string]string{"idp": "idp"}}}}}
+	provider := func(name, hanaName string) v1alpha1.X509Provider {
+		return v1alpha1.X509Provider{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       v1alpha1.X509ProviderSpec{ForProvider: v1alpha1.X509ProviderParameters{Name: hanaName}},
+		}
+	}
+
+	cases := map[string]struct {
+		reason    string
+		providers []v1alpha1.X509Provider
+		want      []user.ResolvedUserMapping
+		err       error
+	}{
+		"Selected": {
+			reason:    "The X509Provider matching the selector should be mapped",
+			providers: []v1alpha1.X509Provider{provider("idp", "IDP")},
+			want:      []user.ResolvedUserMapping{{Name: "IDP", SubjectName: "ANY"}},
+		},
+		"NoMatch": {
+			reason: "An error should be returned if no X509Provider matches the selector",
+			err:    fmt.Errorf("cannot resolve X.509 provider reference: %w", x509provider.ErrNoneSelected),
+		},
+		"Ambiguous": {
+			reason:    "An error should be returned if more than one X509Provider matches the selector, instead of mapping whichever is listed first",
+			providers: []v1alpha1.X509Provider{provider("idp-b", "IDP_B"), provider("idp-a", "IDP_A")},
+			err:       fmt.Errorf("cannot resolve X.509 provider reference: %w", errors.New("more than one X509Provider matches the providerSelector: idp-a, idp-b")),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			kube := &test.MockClient{
+				MockList: func(_ context.Context, list client.ObjectList, _ ...client.ListOption) error {
+					list.(*v1alpha1.X509ProviderList).Items = tc.providers
+					return nil
+				},
+			}
+			e := external{kube: kube, log: &MockLogger{}}
+			got, err := e.ResolveUserMappings(context.Background(), &v1alpha1.User{}, selector)
+			if diff := cmp.Diff(tc.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.ResolveUserMappings(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if err == nil {
+				if diff := cmp.Diff(tc.want, got); diff != "" {
+					t.Errorf("\n%s\ne.ResolveUserMappings(...): -want, +got:\n%s\n", tc.reason, diff)
+				}
+			}
+		})
+	}
+}
+
+func TestObserveAspects(t *testing.T) {
+	cases := map[string]struct {
+		reason     string
+		privileges []string
+		roles      []string
+		parameters map[string]string
+		observed   map[string]string
+		want       *v1alpha1.UserAspectsObservation
+		ready      xpv1.Condition
+	}{
+		"Converged": {
+			reason:     "The user should be available once all aspects converged",
+			privileges: []string{privilege.GetDefaultPrivilege("DEMO_USER")},
+			roles:      []string{`"PUBLIC"`},
+			want:       &v1alpha1.UserAspectsObservation{Privileges: true, Roles: true, Parameters: true, X509Providers: true, Password: true},
+			ready:      xpv1.Available(),
+		},
+		"GrantsPending": {
+			reason: "The user should not be available while privileges and roles are not granted",
+			want:   &v1alpha1.UserAspectsObservation{Parameters: true, X509Providers: true, Password: true},
+			ready:  xpv1.Unavailable().WithMessage(fmt.Sprintf(msgAspectsPending, "privileges, roles")),
+		},
+		"ParameterCleared": {
+			reason:     "A parameter set to an empty string should converge once it is cleared",
+			privileges: []string{privilege.GetDefaultPrivilege("DEMO_USER")},
+			roles:      []string{`"PUBLIC"`},
+			parameters: map[string]string{"LOCALE": "en_US", "EMAIL ADDRESS": ""},
+			observed:   map[string]string{"LOCALE": "en_US"},
+			want:       &v1alpha1.UserAspectsObservation{Privileges: true, Roles: true, Parameters: true, X509Providers: true, Password: true},
+			ready:      xpv1.Available(),
+		},
+		"ParameterNotCleared": {
+			reason:     "A parameter set to an empty string should be pending while it is still set",
+			privileges: []string{privilege.GetDefaultPrivilege("DEMO_USER")},
+			roles:      []string{`"PUBLIC"`},
+			parameters: map[string]string{"EMAIL ADDRESS": ""},
+			observed:   map[string]string{"EMAIL ADDRESS": "demo@example.com"},
+			want:       &v1alpha1.UserAspectsObservation{Privileges: true, Roles: true, X509Providers: true, Password: true},
+			ready:      xpv1.Unavailable().WithMessage(fmt.Sprintf(msgAspectsPending, "parameters")),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{
+				client: mockUserClient{
+					MockRead: func(ctx context.Context, parameters *v1alpha1.UserParameters, password string) (*v1alpha1.UserObservation, error) {
+						return &v1alpha1.UserObservation{
+							Username:                       new("DEMO_USER"),
+							Privileges:                     tc.privileges,
+							Roles:                          tc.roles,
+							Parameters:                     tc.observed,
+							Usergroup:                      new("DEFAULT"),
+							IsPasswordLifetimeCheckEnabled: new(true),
+						}, nil
+					},
+				},
+				log: &MockLogger{},
+			}
+			cr := &v1alpha1.User{
+				Spec: v1alpha1.UserSpec{
+					ForProvider: v1alpha1.UserParameters{
+						Username:                       "DEMO_USER",
+						Usergroup:                      "DEFAULT",
+						Parameters:                     tc.parameters,
+						IsPasswordLifetimeCheckEnabled: true,
+					},
+					PrivilegeManagementPolicy: "strict",
+				},
+			}
+
+			if _, err := e.Observe(context.Background(), cr); err != nil {
+				t.Fatalf("\n%s\ne.Observe(...): unexpected error: %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want, cr.Status.AtProvider.Aspects); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want aspects, +got aspects:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.ready, cr.GetCondition(xpv1.TypeReady), test.EquateConditions()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want condition, +got condition:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestUpToDateUnmanaged(t *testing.T) {
+	observed := &v1alpha1.UserObservation{
+		Privileges:                     []string{`SELECT ON SCHEMA "HDI_CONTAINER"`},
+		Roles:                          []string{`"PUBLIC"`, `"HDI_ROLE"`},
+		Usergroup:                      new("DEFAULT"),
+		IsPasswordLifetimeCheckEnabled: new(true),
+		PasswordUpToDate:               new(false),
+	}
+
+	cases := map[string]struct {
+		reason  string
+		desired *v1alpha1.UserParameters
+		want    bool
+	}{
+		"Managed": {
+			reason: "Privileges, roles and password differing from the spec should not be up to date",
+			desired: &v1alpha1.UserParameters{
+				Roles:                          []string{`"PUBLIC"`},
+				Usergroup:                      "DEFAULT",
+				IsPasswordLifetimeCheckEnabled: true,
+				Authentication:                 v1alpha1.Authentication{Password: &v1alpha1.Password{}},
+			},
+			want: false,
+		},
+		"Unmanaged": {
+			reason: "Aspects left to another system should not be reconciled",
+			desired: &v1alpha1.UserParameters{
+				Roles:                          []string{`"PUBLIC"`},
+				Usergroup:                      "DEFAULT",
+				IsPasswordLifetimeCheckEnabled: true,
+				Authentication:                 v1alpha1.Authentication{Password: &v1alpha1.Password{}},
+				ManagePrivileges:               new(false),
+				ManageRoles:                    new(false),
+				ManagePassword:                 new(false),
+			},
+			want: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := upToDate(observed, tc.desired); got != tc.want {
+				t.Errorf("\n%s\nupToDate(...): want %t, got %t", tc.reason, tc.want, got)
+			}
+		})
+	}
+}
+
+func TestHoldUnrevokableRoles(t *testing.T) {
+	type want struct {
+		roles []string
+		held  []string
+	}
+
+	cases := map[string]struct {
+		reason      string
+		observed    []string
+		desired     []string
+		unrevokable []string
+		want        want
+	}{
+		"NoneUnrevokable": {
+			reason:   "The observed roles should be kept if all roles could be revoked",
+			observed: []string{`"PUBLIC"`, `"OLD"`},
+			desired:  []string{`"PUBLIC"`},
+			want:     want{roles: []string{`"PUBLIC"`, `"OLD"`}},
+		},
+		"StillGranted": {
+			reason:      "A role that could not be revoked should not count as observed",
+			observed:    []string{`"PUBLIC"`, `"hdi::access"`},
+			desired:     []string{`"PUBLIC"`},
+			unrevokable: []string{`"hdi::access"`},
+			want:        want{roles: []string{`"PUBLIC"`}, held: []string{`"hdi::access"`}},
+		},
+		"RevokedMeanwhile": {
+			reason:      "A role that is no longer granted should no longer be held",
+			observed:    []string{`"PUBLIC"`},
+			desired:     []string{`"PUBLIC"`},
+			unrevokable: []string{`"hdi::access"`},
+			want:        want{roles: []string{`"PUBLIC"`}},
+		},
+		"DesiredAgain": {
+			reason:      "A role that is desired again should count as observed",
+			observed:    []string{`"hdi::access"`},
+			desired:     []string{`"hdi::access"`},
+			unrevokable: []string{`"hdi::access"`},
+			want:        want{roles: []string{`"hdi::access"`}},
+		},
+		"AdminOptionKept": {
+			reason:      "A role that could not lose its admin option should count as granted as desired",
+			observed:    []string{`"hdi::access" WITH ADMIN OPTION`},
+			desired:     []string{`"hdi::access"`},
+			unrevokable: []string{`"hdi::access"`},
+			want:        want{roles: []string{`"hdi::access"`}, held: []string{`"hdi::access" WITH ADMIN OPTION`}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			roles, held := holdUnrevokableRoles(tc.observed, tc.desired, tc.unrevokable)
+			if diff := cmp.Diff(tc.want, want{roles: roles, held: held}, cmp.AllowUnexported(want{})); diff != "" {
+				t.Errorf("\n%s\nholdUnrevokableRoles(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestNormalizeStatus(t *testing.T) {
+	o := &v1alpha1.UserObservation{
+		Privileges: []string{"USER ADMIN", "CATALOG READ", "USER ADMIN"},
+		Roles:      []string{`"PUBLIC"`, `"MONITORING"`},
+		X509Providers: []v1alpha1.X509UserMapping{
+			{X509ProviderRef: v1alpha1.X509ProviderRef{Name: "PROVIDER_B"}, SubjectName: "CN=b"},
+			{X509ProviderRef: v1alpha1.X509ProviderRef{Name: "PROVIDER_A"}, SubjectName: "CN=a"},
+			{X509ProviderRef: v1alpha1.X509ProviderRef{Name: "PROVIDER_B"}, SubjectName: "CN=b"},
+		},
+	}
+	want := &v1alpha1.UserObservation{
+		Privileges: []string{"CATALOG READ", "USER ADMIN"},
+		Roles:      []string{`"MONITORING"`, `"PUBLIC"`},
+		X509Providers: []v1alpha1.X509UserMapping{
+			{X509ProviderRef: v1alpha1.X509ProviderRef{Name: "PROVIDER_A"}, SubjectName: "CN=a"},
+			{X509ProviderRef: v1alpha1.X509ProviderRef{Name: "PROVIDER_B"}, SubjectName: "CN=b"},
+		},
+	}
+
+	normalizeStatus(o)
+	if diff := cmp.Diff(want, o); diff != "" {
+		t.Errorf("normalizeStatus(...): -want, +got:\n%s\n", diff)
+	}
+}
+
+func TestObservePasswordExpiry(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	in := func(d time.Duration) *metav1.Time {
+		return &metav1.Time{Time: now.Add(d)}
+	}
+
+	type want struct {
+		days   *int32
+		reason xpv1.ConditionReason
+		events []event.Reason
+	}
+
+	cases := map[string]struct {
+		reason    string
+		expiresAt *metav1.Time
+		previous  *xpv1.Condition
+		want      want
+	}{
+		"NoExpiry": {
+			reason: "Users whose password does not expire should not get a condition",
+		},
+		"NoLongerExpires": {
+			reason:   "A reported expiry should be cleared once the password no longer expires",
+			previous: new(apisv1alpha1.PasswordExpiresSoon(metav1.Time{Time: now})),
+			want:     want{reason: apisv1alpha1.ReasonPasswordValid},
+		},
+		"Valid": {
+			reason:    "A password expiring after the warning period should be valid",
+			expiresAt: in(30 * 24 * time.Hour),
+			want:      want{days: new(int32(30)), reason: apisv1alpha1.ReasonPasswordValid},
+		},
+		"ExpiresSoon": {
+			reason:    "A password expiring within the warning period should be reported with a warning event",
+			expiresAt: in(3*24*time.Hour + time.Hour),
+			want: want{
+				days:   new(int32(3)),
+				reason: apisv1alpha1.ReasonPasswordExpiresSoon,
+				events: []event.Reason{reasonPasswordExpiring},
+			},
+		},
+		"StillExpiresSoon": {
+			reason:    "The warning event should only be recorded when the password enters the warning period",
+			expiresAt: in(2 * 24 * time.Hour),
+			previous:  new(apisv1alpha1.PasswordExpiresSoon(*in(2 * 24 * time.Hour))),
+			want:      want{days: new(int32(2)), reason: apisv1alpha1.ReasonPasswordExpiresSoon},
+		},
+		"Expired": {
+			reason:    "An expired password should be reported with a warning event",
+			expiresAt: in(-36 * time.Hour),
+			previous:  new(apisv1alpha1.PasswordExpiresSoon(*in(-36 * time.Hour))),
+			want: want{
+				days:   new(int32(-1)),
+				reason: apisv1alpha1.ReasonPasswordExpired,
+				events: []event.Reason{reasonPasswordExpiring},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			recorder := &mockRecorder{}
+			e := &external{recorder: recorder, passwordExpiryWarning: passwordExpiryWarningDays}
+			cr := &v1alpha1.User{}
+			cr.Status.AtProvider.PasswordExpiresAt = tc.expiresAt
+			if tc.previous != nil {
+				cr.SetConditions(*tc.previous)
+			}
+			e.observePasswordExpiry(cr, now)
+			if diff := cmp.Diff(tc.want.days, cr.Status.AtProvider.DaysUntilPasswordExpiry); diff != "" {
+				t.Errorf("\n%s\ne.observePasswordExpiry(...): -want days, +got days:\n%s", tc.reason, diff)
+			}
+			if got := cr.GetCondition(apisv1alpha1.TypePasswordExpiring).Reason; got != tc.want.reason {
+				t.Errorf("\n%s\ne.observePasswordExpiry(...): want reason %q, got %q", tc.reason, tc.want.reason, got)
+			}
+			if diff := cmp.Diff(tc.want.events, recorder.reasons); diff != "" {
+				t.Errorf("\n%s\ne.observePasswordExpiry(...): -want events, +got events:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+// mockSecretStore returns the secret of the owner it fetches.
+type mockSecretStore struct {
+	data map[string][]byte
+	err  error
+	got  *xpv1.PublishConnectionDetailsTo
+	ns   string
+}
+
+func (m *mockSecretStore) FetchConnection(_ context.Context, so resource.ConnectionSecretOwner) (managed.ConnectionDetails, error) {
+	m.got, m.ns = so.GetPublishConnectionDetailsTo(), so.GetNamespace()
+	return m.data, m.err
+}
+
+func TestGetStorePassword(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	cases := map[string]struct {
+		reason  string
+		store   *mockSecretStore
+		ref     *v1alpha1.PasswordStoreReference
+		want    string
+		wantRef *xpv1.PublishConnectionDetailsTo
+		wantErr error
+	}{
+		"Password": {
+			reason:  "The password should be read from the secret of the default StoreConfig",
+			store:   &mockSecretStore{data: map[string][]byte{"password": []byte("secret")}},
+			ref:     &v1alpha1.PasswordStoreReference{Name: "demo-user", Scope: "hana", Key: "password"},
+			want:    "secret",
+			wantRef: &xpv1.PublishConnectionDetailsTo{Name: "demo-user", SecretStoreConfigRef: &xpv1.Reference{Name: "default"}},
+		},
+		"StoreConfig": {
+			reason:  "The password should be read from the secret store of the referenced StoreConfig",
+			store:   &mockSecretStore{data: map[string][]byte{"password": []byte("secret")}},
+			ref:     &v1alpha1.PasswordStoreReference{Name: "demo-user", Key: "password", StoreConfigRef: &xpv1.Reference{Name: "vault"}},
+			want:    "secret",
+			wantRef: &xpv1.PublishConnectionDetailsTo{Name: "demo-user", SecretStoreConfigRef: &xpv1.Reference{Name: "vault"}},
+		},
+		"ErrNotEnabled": {
+			reason:  "A password in a secret store should be an error without external secret stores",
+			ref:     &v1alpha1.PasswordStoreReference{Name: "demo-user", Key: "password"},
+			wantErr: errors.New(errNoSecretStore),
+		},
+		"ErrFetch": {
+			reason:  "Any errors encountered while reading the secret store should be returned",
+			store:   &mockSecretStore{err: errBoom},
+			ref:     &v1alpha1.PasswordStoreReference{Name: "demo-user", Key: "password"},
+			wantRef: &xpv1.PublishConnectionDetailsTo{Name: "demo-user", SecretStoreConfigRef: &xpv1.Reference{Name: "default"}},
+			wantErr: fmt.Errorf(errGetPasswordStore, errBoom),
+		},
+		"ErrKeyNotFound": {
+			reason:  "A secret without the key should be an error",
+			store:   &mockSecretStore{data: map[string][]byte{}},
+			ref:     &v1alpha1.PasswordStoreReference{Name: "demo-user", Key: "password"},
+			wantRef: &xpv1.PublishConnectionDetailsTo{Name: "demo-user", SecretStoreConfigRef: &xpv1.Reference{Name: "default"}},
+			wantErr: fmt.Errorf(errStoreKeyNotFound, "password", "demo-user", "default"),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{log: logging.NewNopLogger()}
+			if tc.store != nil {
+				e.store = tc.store
+			}
+			got, err := e.getStorePassword(context.Background(), tc.ref)
+			if diff := cmp.Diff(tc.wantErr, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.getStorePassword(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if got != tc.want {
+				t.Errorf("\n%s\ne.getStorePassword(...): want %q, got %q", tc.reason, tc.want, got)
+			}
+			if tc.store == nil {
+				return
+			}
+			if diff := cmp.Diff(tc.wantRef, tc.store.got); diff != "" {
+				t.Errorf("\n%s\ne.getStorePassword(...): -want secret, +got secret:\n%s\n", tc.reason, diff)
+			}
+			if tc.store.ns != tc.ref.Scope {
+				t.Errorf("\n%s\ne.getStorePassword(...): want scope %q, got %q", tc.reason, tc.ref.Scope, tc.store.ns)
+			}
+		})
+	}
+}
@@ -8,8 +8,8 @@ import (
 	"context"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
-	corev1 "k8s.io/api/core/v1"
 
+	"github.com/SAP/crossplane-provider-hana/internal/clients/hana"
 	"github.com/SAP/crossplane-provider-hana/internal/clients/hana/usergroup"
 	"github.com/SAP/crossplane-provider-hana/internal/clients/xsql"
 	"github.com/SAP/crossplane-provider-hana/internal/utils"
@@ -29,15 +29,18 @@ import (
 
 	"github.com/SAP/crossplane-provider-hana/apis/admin/v1alpha1"
 	apisv1alpha1 "github.com/SAP/crossplane-provider-hana/apis/v1alpha1"
+	"github.com/SAP/crossplane-provider-hana/internal/controller/capability"
+	"github.com/SAP/crossplane-provider-hana/internal/controller/externalname"
 	"github.com/SAP/crossplane-provider-hana/internal/controller/features"
+	"github.com/SAP/crossplane-provider-hana/internal/controller/outcome"
+	"github.com/SAP/crossplane-provider-hana/internal/controller/poll"
 )
 
 const (
 	errNotUsergroup = "managed resource is not a usergroup custom resource"
 	errTrackPCUsage = "cannot track ProviderConfig usage: %w"
 	errGetPC        = "cannot get ProviderConfig: %w"
-	errNoSecretRef  = "ProviderConfig does not reference a credentials Secret"
-	errGetSecret    = "cannot get credentials Secret: %w"
+	errGetTLS       = "cannot get TLS configuration: %w"
 
 	errSelectUsergroup = "cannot select usergroup: %w"
 	errCreateUsergroup = "cannot create usergroup: %w"
@@ -53,7 +56,7 @@ func Setup(mgr ctrl.Manager, o controller.Options, db xsql.Connector) error {
 	t := resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1alpha1.ProviderConfigUsage{})
 	r := managed.NewReconciler(mgr,
 		resource.ManagedKind(v1alpha1.UsergroupGroupVersionKind),
-		managed.WithExternalConnecter(&connector{
+		outcome.WithExternalConnecter(name, &connector{
 			kube:      mgr.GetClient(),
 			usage:     t,
 			newClient: usergroup.New,
@@ -62,6 +65,7 @@ func Setup(mgr ctrl.Manager, o controller.Options, db xsql.Connector) error {
 		}),
 		managed.WithLogger(log),
 		managed.WithPollInterval(o.PollInterval),
+		poll.WithJitterHook(v1alpha1.UsergroupKind, nil),
 		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
 		features.ConfigureBetaManagementPolicies(o))
 
@@ -101,27 +105,36 @@ func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 		return nil, fmt.Errorf(errGetPC, err)
 	}
 
-	ref := pc.Spec.Credentials.ConnectionSecretRef
-	if ref == nil {
-		return nil, errors.New(errNoSecretRef)
-	}
-
-	s := &corev1.Secret{}
-	if err := c.kube.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, s); err != nil {
-		return nil, fmt.Errorf(errGetSecret, err)
+	data, err := hana.Credentials(ctx, c.kube, pc)
+	if err != nil {
+		return nil, err
 	}
 
 	c.log.Info("Connecting to usergroup resource", "name", cr.Name)
 
-	conn, err := c.db.Connect(ctx, s.Data)
+	creds, err := hana.ConnectionCredentials(ctx, c.kube, pc, data)
+	if err != nil {
+		return nil, fmt.Errorf(errGetTLS, err)
+	}
+
+	conn, err := c.db.Connect(ctx, creds)
 	if err != nil {
 		return nil, fmt.Errorf("cannot connect to HANA DB: %w", err)
 	}
 
+	if err := hana.ReportActiveEndpoint(ctx, c.kube, pc, conn); err != nil {
+		c.log.Info("Cannot report active endpoint", "providerConfig", pc.Name, "error", err)
+	}
+
+	if err := hana.Require(ctx, conn, hana.ManageUsergroups); err != nil {
+		return capability.Disabled(err), nil
+	}
+
 	return &external{
-		client: c.newClient(conn),
-		kube:   c.kube,
-		log:    c.log,
+		client:         c.newClient(conn),
+		kube:           c.kube,
+		log:            c.log,
+		identifierCase: pc.Spec.IdentifierCase,
 	}, nil
 }
 
@@ -132,9 +145,10 @@ func (c *external) Disconnect(ctx context.Context) error {
 // An ExternalClient observes, then either creates, updates, or deletes an
 // external resource to ensure it reflects the managed resource's desired state.
 type external struct {
-	client usergroup.UsergroupClient
-	kube   client.Client
-	log    logging.Logger
+	client         usergroup.UsergroupClient
+	kube           client.Client
+	log            logging.Logger
+	identifierCase string
 }
 
 func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
@@ -145,7 +159,7 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 
 	c.log.Info("Observing usergroup resource", "name", cr.Name)
 
-	parameters := buildDesiredParameters(cr)
+	parameters := buildDesiredParameters(cr, c.identifierCase)
 
 	observed, err := c.client.Read(ctx, parameters)
 
@@ -162,6 +176,7 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 	cr.Status.AtProvider.UsergroupName = observed.UsergroupName
 	cr.Status.AtProvider.DisableUserAdmin = observed.DisableUserAdmin
 	cr.Status.AtProvider.Parameters = observed.Parameters
+	cr.Status.AtProvider.ExternalID = observed.UsergroupName
 
 	cr.SetConditions(xpv1.Available())
 
@@ -172,8 +187,9 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 		"upToDate", isUpToDate)
 
 	return managed.ExternalObservation{
-		ResourceExists:   true,
-		ResourceUpToDate: isUpToDate,
+		ResourceExists:    true,
+		ResourceUpToDate:  isUpToDate,
+		ConnectionDetails: externalname.ConnectionDetails(cr.Status.AtProvider.ExternalID),
 	}, nil
 
 }
@@ -200,7 +216,7 @@ func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 
 	cr.SetConditions(xpv1.Creating())
 
-	parameters := buildDesiredParameters(cr)
+	parameters := buildDesiredParameters(cr, c.identifierCase)
 
 	c.log.Info("Creating usergroup with parameters",
 		"usergroupName", parameters.UsergroupName,
@@ -234,7 +250,7 @@ func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.Ext
 
 	c.log.Info("Updating usergroup resource", "name", cr.Name, "usergroupName", cr.Spec.ForProvider.UsergroupName)
 
-	parameters := buildDesiredParameters(cr)
+	parameters := buildDesiredParameters(cr, c.identifierCase)
 	// usergroup.Client has additional functions not defined in global interface
 	ugClient, _ := c.client.(usergroup.Client)
 	if cr.Status.AtProvider.DisableUserAdmin != parameters.DisableUserAdmin {
@@ -286,7 +302,7 @@ func (c *external) Delete(ctx context.Context, mg resource.Managed) (managed.Ext
 	c.log.Info("Deleting usergroup resource", "name", cr.Name, "usergroupName", cr.Spec.ForProvider.UsergroupName)
 
 	parameters := &v1alpha1.UsergroupParameters{
-		UsergroupName: cr.Spec.ForProvider.UsergroupName,
+		UsergroupName: hana.FoldIdentifier(c.identifierCase, cr.Spec.ForProvider.UsergroupName),
 	}
 
 	cr.SetConditions(xpv1.Deleting())
@@ -302,9 +318,9 @@ func (c *external) Delete(ctx context.Context, mg resource.Managed) (managed.Ext
 	return managed.ExternalDelete{}, err
 }
 
-func buildDesiredParameters(cr *v1alpha1.Usergroup) *v1alpha1.UsergroupParameters {
+func buildDesiredParameters(cr *v1alpha1.Usergroup, identifierCase string) *v1alpha1.UsergroupParameters {
 	return &v1alpha1.UsergroupParameters{
-		UsergroupName:      cr.Spec.ForProvider.UsergroupName,
+		UsergroupName:      hana.FoldIdentifier(identifierCase, cr.Spec.ForProvider.UsergroupName),
 		DisableUserAdmin:   cr.Spec.ForProvider.DisableUserAdmin,
 		NoGrantToCreator:   cr.Spec.ForProvider.NoGrantToCreator,
 		Parameters:         cr.Spec.ForProvider.Parameters,
@@ -20,8 +20,10 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	"github.com/SAP/crossplane-provider-hana/internal/clients/hana"
 	"github.com/SAP/crossplane-provider-hana/internal/clients/hana/usergroup"
 	"github.com/SAP/crossplane-provider-hana/internal/clients/xsql"
+	"github.com/SAP/crossplane-provider-hana/internal/controller/externalname"
 
 	"github.com/SAP/crossplane-provider-hana/apis/admin/v1alpha1"
 	apisv1alpha1 "github.com/SAP/crossplane-provider-hana/apis/v1alpha1"
@@ -143,7 +145,7 @@ func TestConnect(t *testing.T) {
 					},
 				},
 			},
-			want: errors.New(errNoSecretRef),
+			want: errors.New(hana.ErrNoSecretRef),
 		},
 		"ErrGetConnectionSecret": {
 			reason: "An error should be returned if we can't get our ProviderConfig's connection secret",
@@ -170,7 +172,7 @@ func TestConnect(t *testing.T) {
 					},
 				},
 			},
-			want: fmt.Errorf(errGetSecret, errBoom),
+			want: fmt.Errorf(hana.ErrGetSecret, errBoom),
 		},
 	}
 
@@ -266,6 +268,35 @@ func TestObserve(t *testing.T) {
 				err: nil,
 			},
 		},
+		"Exists": {
+			reason: "An existing usergroup should publish its name as external ID",
+			fields: fields{
+				client: mockClient{
+					MockRead: func(ctx context.Context, parameters *v1alpha1.UsergroupParameters) (observed *v1alpha1.UsergroupObservation, err error) {
+						return &v1alpha1.UsergroupObservation{
+							UsergroupName: "DEMO_USERGROUP",
+						}, nil
+					},
+				},
+				log: &MockLogger{},
+			},
+			args: args{
+				mg: &v1alpha1.Usergroup{
+					Spec: v1alpha1.UsergroupSpec{
+						ForProvider: v1alpha1.UsergroupParameters{
+							UsergroupName: "DEMO_USERGROUP",
+						},
+					},
+				},
+			},
+			want: want{
+				c: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  true,
+					ConnectionDetails: externalname.ConnectionDetails("DEMO_USERGROUP"),
+				},
+			},
+		},
 	}
 
 	for name, tc := range cases {
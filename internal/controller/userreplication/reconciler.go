@@ -0,0 +1,382 @@
+/*
+Copyright 2026 SAP SE or an SAP affiliate company and contributors.
+*/
+
+package userreplication
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/controller"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/SAP/crossplane-provider-hana/apis/admin/v1alpha1"
+	apisv1alpha1 "github.com/SAP/crossplane-provider-hana/apis/v1alpha1"
+	"github.com/SAP/crossplane-provider-hana/internal/clients/hana"
+	"github.com/SAP/crossplane-provider-hana/internal/clients/hana/privilege"
+	"github.com/SAP/crossplane-provider-hana/internal/clients/xsql"
+	"github.com/SAP/crossplane-provider-hana/internal/controller/approval"
+	"github.com/SAP/crossplane-provider-hana/internal/controller/features"
+	"github.com/SAP/crossplane-provider-hana/internal/controller/outcome"
+	"github.com/SAP/crossplane-provider-hana/internal/controller/poll"
+	"github.com/SAP/crossplane-provider-hana/internal/utils"
+)
+
+const (
+	errNotUserReplication = "managed resource is not a UserReplication custom resource"
+	errTrackPCUsage       = "cannot track ProviderConfig usage: %w"
+	errGetPC              = "cannot get ProviderConfig: %w"
+	errGetTLS             = "cannot get TLS configuration: %w"
+
+	errQuerySource     = "cannot query grants of source %s: %w"
+	errQueryTarget     = "cannot query grants of target user %s: %w"
+	errGrantPrivileges = "cannot grant privileges to target user %s: %w"
+	errGrantRoles      = "cannot grant roles to target user %s: %w"
+)
+
+// Setup adds a controller that reconciles UserReplication managed resources.
+func Setup(mgr ctrl.Manager, o controller.Options, db xsql.Connector) error {
+	name := managed.ControllerName(v1alpha1.UserReplicationGroupKind)
+
+	log := o.Logger.WithValues("controller", name)
+	t := resource.NewProviderConfigUsageTracker(mgr.GetClient(), &apisv1alpha1.ProviderConfigUsage{})
+	r := managed.NewReconciler(mgr,
+		resource.ManagedKind(v1alpha1.UserReplicationGroupVersionKind),
+		outcome.WithExternalConnecter(name, &connector{
+			kube:      mgr.GetClient(),
+			usage:     t,
+			newClient: newPrivilegeClient,
+			log:       log,
+			db:        db,
+		}),
+		managed.WithLogger(log),
+		managed.WithPollInterval(o.PollInterval),
+		poll.WithJitterHook(v1alpha1.UserReplicationKind, nil),
+		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
+		features.ConfigureBetaManagementPolicies(o))
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		For(&v1alpha1.UserReplication{}).
+		Complete(r)
+}
+
+func newPrivilegeClient(db xsql.DB) privilege.Client {
+	return &privilege.PrivilegeClient{DB: db}
+}
+
+// A connector is expected to produce an ExternalClient when its Connect method
+// is called.
+type connector struct {
+	kube      client.Client
+	usage     resource.Tracker
+	newClient func(xsql.DB) privilege.Client
+	log       logging.Logger
+	db        xsql.Connector
+}
+
+// Connect typically produces an ExternalClient by:
+// 1. Tracking that the managed resource is using a ProviderConfig.
+// 2. Getting the managed resource's ProviderConfig.
+// 3. Getting the credentials specified by the ProviderConfig.
+// 4. Using the credentials to form a client.
+func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1alpha1.UserReplication)
+	if !ok {
+		return nil, errors.New(errNotUserReplication)
+	}
+
+	if err := c.usage.Track(ctx, mg); err != nil {
+		return nil, fmt.Errorf(errTrackPCUsage, err)
+	}
+
+	pc := &apisv1alpha1.ProviderConfig{}
+	if err := c.kube.Get(ctx, types.NamespacedName{Name: cr.GetProviderConfigReference().Name}, pc); err != nil {
+		return nil, fmt.Errorf(errGetPC, err)
+	}
+
+	data, err := hana.Credentials(ctx, c.kube, pc)
+	if err != nil {
+		return nil, err
+	}
+
+	c.log.Info("Connecting to user replication resource", "name", cr.Name)
+
+	creds, err := hana.ConnectionCredentials(ctx, c.kube, pc, data)
+	if err != nil {
+		return nil, fmt.Errorf(errGetTLS, err)
+	}
+
+	conn, err := c.db.Connect(ctx, creds)
+	if err != nil {
+		return nil, fmt.Errorf("cannot connect to HANA DB: %w", err)
+	}
+
+	if err := hana.ReportActiveEndpoint(ctx, c.kube, pc, conn); err != nil {
+		c.log.Info("Cannot report active endpoint", "providerConfig", pc.Name, "error", err)
+	}
+
+	return &external{
+		client:        c.newClient(conn),
+		log:           c.log,
+		grantPolicy:   pc.Spec.GrantPolicy,
+		defaultSchema: string(creds[xpv1.ResourceCredentialsSecretUserKey]),
+	}, nil
+}
+
+// An ExternalClient observes, then either creates, updates, or deletes an
+// external resource to ensure it reflects the managed resource's desired state.
+type external struct {
+	client        privilege.Client
+	log           logging.Logger
+	grantPolicy   *apisv1alpha1.GrantPolicy
+	defaultSchema string
+}
+
+// grants are the privileges and roles of a grantee.
+type grants struct {
+	privileges []string
+	roles      []string
+}
+
+func (c *external) Disconnect(ctx context.Context) error {
+	return nil
+}
+
+func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.UserReplication)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotUserReplication)
+	}
+
+	c.log.Info("Observing user replication resource", "name", cr.Name)
+
+	// Deleting a replication leaves the grants in place
+	if meta.WasDeleted(cr) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	// Grants the source gains after a one-time replication are not copied
+	if cr.Spec.ForProvider.Mode == v1alpha1.ReplicationModeOnce && cr.Status.AtProvider.ReplicatedAt != nil {
+		cr.SetConditions(xpv1.Available())
+		return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, nil
+	}
+
+	source, target, err := c.read(ctx, cr)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	missing := missingGrants(source, target)
+
+	cr.Status.AtProvider.Privileges = utils.SortedSet(intersect(source.privileges, target.privileges))
+	cr.Status.AtProvider.Roles = utils.SortedSet(intersect(source.roles, target.roles))
+	if missing.empty() && cr.Status.AtProvider.ReplicatedAt == nil {
+		cr.Status.AtProvider.ReplicatedAt = &metav1.Time{Time: time.Now()}
+	}
+
+	c.log.Info("Observed user replication resource",
+		"name", cr.Name,
+		"targetUser", cr.Spec.ForProvider.TargetUser,
+		"missingPrivileges", len(missing.privileges),
+		"missingRoles", len(missing.roles))
+
+	// Until the target held all grants once, an interrupted replication is
+	// resumed by Create, also in Once mode
+	if cr.Status.AtProvider.ReplicatedAt == nil {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	cr.SetConditions(xpv1.Available())
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: missing.empty(),
+	}, nil
+}
+
+func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.UserReplication)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotUserReplication)
+	}
+
+	c.log.Info("Creating user replication resource", "name", cr.Name, "targetUser", cr.Spec.ForProvider.TargetUser)
+
+	cr.SetConditions(xpv1.Creating())
+
+	if err := c.replicate(ctx, cr); err != nil {
+		return managed.ExternalCreation{}, err
+	}
+	cr.Status.AtProvider.ReplicatedAt = &metav1.Time{Time: time.Now()}
+
+	c.log.Info("Successfully created user replication resource", "name", cr.Name, "targetUser", cr.Spec.ForProvider.TargetUser)
+	return managed.ExternalCreation{}, nil
+}
+
+func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.UserReplication)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotUserReplication)
+	}
+
+	c.log.Info("Updating user replication resource", "name", cr.Name, "targetUser", cr.Spec.ForProvider.TargetUser)
+
+	if err := c.replicate(ctx, cr); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+
+	c.log.Info("Successfully updated user replication resource", "name", cr.Name, "targetUser", cr.Spec.ForProvider.TargetUser)
+	return managed.ExternalUpdate{}, nil
+}
+
+// Delete leaves the replicated grants to the target user, which usually
+// replaces the source once the replication is no longer needed.
+func (c *external) Delete(ctx context.Context, mg resource.Managed) (managed.ExternalDelete, error) {
+	cr, ok := mg.(*v1alpha1.UserReplication)
+	if !ok {
+		return managed.ExternalDelete{}, errors.New(errNotUserReplication)
+	}
+
+	c.log.Info("Deleting user replication resource, the replicated grants are kept", "name", cr.Name, "targetUser", cr.Spec.ForProvider.TargetUser)
+	cr.SetConditions(xpv1.Deleting())
+	return managed.ExternalDelete{}, nil
+}
+
+// replicate grants the target user the privileges and roles of the source it
+// does not hold yet.
+func (c *external) replicate(ctx context.Context, cr *v1alpha1.UserReplication) error {
+	source, target, err := c.read(ctx, cr)
+	if err != nil {
+		return err
+	}
+	missing := missingGrants(source, target)
+	targetUser := cr.Spec.ForProvider.TargetUser
+
+	if err := c.enforceGrantPolicy(cr, missing); err != nil {
+		return err
+	}
+
+	unlock, err := privilege.LockGrantee(ctx, targetUser)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	if err := c.client.GrantPrivileges(ctx, c.defaultSchema, targetUser, missing.privileges); err != nil {
+		c.log.Info("Error granting privileges", "name", cr.Name, "error", err)
+		return fmt.Errorf(errGrantPrivileges, targetUser, err)
+	}
+	if err := c.client.GrantRoles(ctx, c.defaultSchema, targetUser, missing.roles); err != nil {
+		c.log.Info("Error granting roles", "name", cr.Name, "error", err)
+		return fmt.Errorf(errGrantRoles, targetUser, err)
+	}
+
+	cr.Status.AtProvider.Privileges = utils.SortedSet(source.privileges)
+	cr.Status.AtProvider.Roles = utils.SortedSet(source.roles)
+	c.log.Info("Replicated grants",
+		"name", cr.Name,
+		"targetUser", targetUser,
+		"privileges", len(missing.privileges),
+		"roles", len(missing.roles))
+	return nil
+}
+
+// enforceGrantPolicy rejects grants forbidden by the grant policy of the
+// ProviderConfig and holds back high-risk grants until they are approved.
+func (c *external) enforceGrantPolicy(cr *v1alpha1.UserReplication, g grants) error {
+	if err := privilege.CheckGrantPolicy(c.grantPolicy, g.privileges, g.roles, c.defaultSchema); err != nil {
+		c.log.Info("Grant rejected by grant policy", "name", cr.Name, "error", err)
+		cr.SetConditions(apisv1alpha1.GrantForbidden(err))
+		return err
+	}
+	// No admission webhook stamps the approval annotations of
+	// UserReplications, so their high-risk grants stay pending
+	if err := approval.Check(cr, false, c.grantPolicy, g.privileges, g.roles, c.defaultSchema); err != nil {
+		c.log.Info("Grant waiting for approval", "name", cr.Name, "error", err)
+		return err
+	}
+	return nil
+}
+
+// read queries the grants of the source and the target user.
+func (c *external) read(ctx context.Context, cr *v1alpha1.UserReplication) (source, target grants, err error) {
+	p := cr.Spec.ForProvider
+	name, granteeType := p.Source.User, privilege.GranteeTypeUser
+	if p.Source.Role != "" {
+		name, granteeType = p.Source.Role, privilege.GranteeTypeRole
+	}
+
+	if source, err = c.query(ctx, name, granteeType); err != nil {
+		c.log.Info("Error querying grants of source", "name", cr.Name, "source", name, "error", err)
+		return grants{}, grants{}, fmt.Errorf(errQuerySource, name, err)
+	}
+	if p.Source.User != "" {
+		// HANA grants the owner of a schema its privileges, they cannot be
+		// granted by the provider
+		owner, err := privilege.FormatPrivilegeStrings([]string{privilege.GetDefaultPrivilege(p.Source.User)}, c.defaultSchema)
+		if err != nil {
+			return grants{}, grants{}, fmt.Errorf(errQuerySource, name, err)
+		}
+		source.privileges = difference(source.privileges, owner)
+	}
+	if target, err = c.query(ctx, p.TargetUser, privilege.GranteeTypeUser); err != nil {
+		c.log.Info("Error querying grants of target user", "name", cr.Name, "targetUser", p.TargetUser, "error", err)
+		return grants{}, grants{}, fmt.Errorf(errQueryTarget, p.TargetUser, err)
+	}
+	return source, target, nil
+}
+
+func (c *external) query(ctx context.Context, grantee string, granteeType privilege.GranteeType) (grants, error) {
+	privileges, err := c.client.QueryPrivileges(ctx, grantee, granteeType)
+	if err != nil {
+		return grants{}, err
+	}
+	roles, err := c.client.QueryRoles(ctx, grantee, granteeType)
+	if err != nil {
+		return grants{}, err
+	}
+	return grants{privileges: privileges, roles: roles}, nil
+}
+
+// missingGrants returns the grants of the source the target does not hold.
+func missingGrants(source, target grants) grants {
+	return grants{
+		privileges: difference(source.privileges, target.privileges),
+		roles:      difference(source.roles, target.roles),
+	}
+}
+
+func (g grants) empty() bool {
+	return len(g.privileges) == 0 && len(g.roles) == 0
+}
+
+func difference(a, b []string) []string {
+	var res []string
+	for _, s := range a {
+		if !slices.Contains(b, s) {
+			res = append(res, s)
+		}
+	}
+	return res
+}
+
+func intersect(a, b []string) []string {
+	var res []string
+	for _, s := range a {
+		if slices.Contains(b, s) {
+			res = append(res, s)
+		}
+	}
+	return res
+}
@@ -0,0 +1,289 @@
+/*
+Copyright 2026 SAP SE or an SAP affiliate company and contributors.
+*/
+
+package userreplication
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/SAP/crossplane-provider-hana/apis/admin/v1alpha1"
+	apisv1alpha1 "github.com/SAP/crossplane-provider-hana/apis/v1alpha1"
+	"github.com/SAP/crossplane-provider-hana/internal/clients/hana/privilege"
+)
+
+// MockLogger is a mock implementation of logging.Logger
+type MockLogger struct{}
+
+func (l *MockLogger) Debug(_ string, _ ...interface{}) {}
+func (l *MockLogger) Info(_ string, _ ...interface{})  {}
+func (l *MockLogger) WithValues(_ ...interface{}) logging.Logger {
+	return l
+}
+
+// mockPrivilegeClient serves the grants of fixed grantees and records the
+// grants it is asked to make.
+type mockPrivilegeClient struct {
+	privileges map[string][]string
+	roles      map[string][]string
+	queryErr   error
+	grantErr   error
+
+	grantedPrivileges []string
+	grantedRoles      []string
+}
+
+func (m *mockPrivilegeClient) GrantPrivileges(_ context.Context, _ privilege.DefaultSchema, _ privilege.Grantee, privileges []string) error {
+	m.grantedPrivileges = append(m.grantedPrivileges, privileges...)
+	return m.grantErr
+}
+
+func (m *mockPrivilegeClient) GrantRoles(_ context.Context, _ privilege.DefaultSchema, _ privilege.Grantee, roles []string) error {
+	m.grantedRoles = append(m.grantedRoles, roles...)
+	return nil
+}
+
+func (m *mockPrivilegeClient) RevokePrivileges(_ context.Context, _ privilege.DefaultSchema, _ privilege.Grantee, _ []string) error {
+	return errors.New("unexpected revoke")
+}
+
+func (m *mockPrivilegeClient) RevokeRoles(_ context.Context, _ privilege.DefaultSchema, _ privilege.Grantee, _ []string) error {
+	return errors.New("unexpected revoke")
+}
+
+func (m *mockPrivilegeClient) QueryPrivileges(_ context.Context, grantee privilege.Grantee, _ privilege.GranteeType) ([]string, error) {
+	return m.privileges[grantee], m.queryErr
+}
+
+func (m *mockPrivilegeClient) QueryPrivilegeGrants(_ context.Context, _ privilege.Grantee, _ privilege.GranteeType) ([]privilege.PrivilegeGrant, error) {
+	return nil, errors.New("unexpected query of grants")
+}
+
+func (m *mockPrivilegeClient) QueryRoles(_ context.Context, grantee privilege.Grantee, _ privilege.GranteeType) ([]string, error) {
+	return m.roles[grantee], m.queryErr
+}
+
+const (
+	sourceUser = "APP_V1"
+	targetUser = "APP_V2"
+)
+
+var (
+	ownerPrivilege = `CREATE ANY ON SCHEMA "APP_V1" WITH GRANT OPTION`
+	selectSales    = `SELECT ON SCHEMA "SALES"`
+	insertSales    = `INSERT ON SCHEMA "SALES"`
+
+	replicatedAt = &metav1.Time{Time: time.Now()}
+)
+
+func replication(mode string, at *metav1.Time) *v1alpha1.UserReplication {
+	return &v1alpha1.UserReplication{
+		Spec: v1alpha1.UserReplicationSpec{
+			ForProvider: v1alpha1.UserReplicationParameters{
+				Source:     v1alpha1.ReplicationSource{User: sourceUser},
+				TargetUser: targetUser,
+				Mode:       mode,
+			},
+		},
+		Status: v1alpha1.UserReplicationStatus{
+			AtProvider: v1alpha1.UserReplicationObservation{ReplicatedAt: at},
+		},
+	}
+}
+
+func sourceGrants() *mockPrivilegeClient {
+	return &mockPrivilegeClient{
+		privileges: map[string][]string{
+			sourceUser: {ownerPrivilege, selectSales, insertSales},
+			targetUser: {selectSales},
+		},
+		roles: map[string][]string{
+			sourceUser: {`"PUBLIC"`, `"SALES_READER"`},
+			targetUser: {`"PUBLIC"`},
+		},
+	}
+}
+
+func TestObserve(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type want struct {
+		o          managed.ExternalObservation
+		privileges []string
+		roles      []string
+		err        error
+	}
+
+	cases := map[string]struct {
+		reason string
+		client *mockPrivilegeClient
+		cr     *v1alpha1.UserReplication
+		want   want
+	}{
+		"NotReplicatedYet": {
+			reason: "A replication whose target misses grants of the source should be created",
+			client: sourceGrants(),
+			cr:     replication(v1alpha1.ReplicationModeContinuous, nil),
+			want: want{
+				o:          managed.ExternalObservation{ResourceExists: false},
+				privileges: []string{selectSales},
+				roles:      []string{`"PUBLIC"`},
+			},
+		},
+		"SourceGainedGrants": {
+			reason: "A continuous replication should copy grants the source gained",
+			client: sourceGrants(),
+			cr:     replication(v1alpha1.ReplicationModeContinuous, replicatedAt),
+			want: want{
+				o:          managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false},
+				privileges: []string{selectSales},
+				roles:      []string{`"PUBLIC"`},
+			},
+		},
+		"OnceReplicated": {
+			reason: "A one-time replication should not look at the grants again",
+			client: &mockPrivilegeClient{queryErr: errBoom},
+			cr:     replication(v1alpha1.ReplicationModeOnce, replicatedAt),
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+		"UpToDate": {
+			reason: "A target holding all grants of the source except its owner privilege should be up to date",
+			client: &mockPrivilegeClient{
+				privileges: map[string][]string{
+					sourceUser: {ownerPrivilege, selectSales},
+					targetUser: {selectSales},
+				},
+			},
+			cr: replication(v1alpha1.ReplicationModeContinuous, nil),
+			want: want{
+				o:          managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+				privileges: []string{selectSales},
+			},
+		},
+		"ErrQuery": {
+			reason: "Any error querying the grants should be returned",
+			client: &mockPrivilegeClient{queryErr: errBoom},
+			cr:     replication(v1alpha1.ReplicationModeContinuous, nil),
+			want: want{
+				err: fmt.Errorf(errQuerySource, sourceUser, errBoom),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{client: tc.client, log: &MockLogger{}, defaultSchema: "ADMIN"}
+			got, err := e.Observe(context.Background(), tc.cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.o, got); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.privileges, tc.cr.Status.AtProvider.Privileges); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want privileges, +got privileges:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.roles, tc.cr.Status.AtProvider.Roles); diff != "" {
+				t.Errorf("\n%s\ne.Observe(...): -want roles, +got roles:\n%s\n", tc.reason, diff)
+			}
+			if tc.want.o.ResourceExists && tc.cr.Status.AtProvider.ReplicatedAt == nil {
+				t.Errorf("\n%s\ne.Observe(...): want replicatedAt set", tc.reason)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	errBoom := errors.New("boom")
+
+	type want struct {
+		privileges []string
+		roles      []string
+		err        error
+	}
+
+	cases := map[string]struct {
+		reason      string
+		client      *mockPrivilegeClient
+		grantPolicy *apisv1alpha1.GrantPolicy
+		want        want
+	}{
+		"MissingGrants": {
+			reason: "Only the grants the target misses should be granted, without the owner privilege of the source",
+			client: sourceGrants(),
+			want: want{
+				privileges: []string{insertSales},
+				roles:      []string{`"SALES_READER"`},
+			},
+		},
+		"Forbidden": {
+			reason:      "Grants forbidden by the grant policy should not be replicated",
+			client:      sourceGrants(),
+			grantPolicy: &apisv1alpha1.GrantPolicy{ForbiddenRoles: []string{"SALES_READER"}},
+		},
+		"ErrGrant": {
+			reason: "Any error granting the privileges should be returned",
+			client: func() *mockPrivilegeClient {
+				c := sourceGrants()
+				c.grantErr = errBoom
+				return c
+			}(),
+			want: want{
+				privileges: []string{insertSales},
+				err:        fmt.Errorf(errGrantPrivileges, targetUser, errBoom),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := &external{client: tc.client, log: &MockLogger{}, grantPolicy: tc.grantPolicy, defaultSchema: "ADMIN"}
+			cr := replication(v1alpha1.ReplicationModeContinuous, nil)
+			_, err := e.Create(context.Background(), cr)
+			if tc.grantPolicy != nil {
+				if err == nil {
+					t.Fatalf("\n%s\ne.Create(...): want error, got nil", tc.reason)
+				}
+				if got := cr.GetCondition(apisv1alpha1.TypeGrantPolicy).Status; got != "False" {
+					t.Errorf("\n%s\ne.Create(...): want GrantPolicy condition False, got %s", tc.reason, got)
+				}
+			} else if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.privileges, tc.client.grantedPrivileges); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want granted privileges, +got granted privileges:\n%s\n", tc.reason, diff)
+			}
+			if diff := cmp.Diff(tc.want.roles, tc.client.grantedRoles); diff != "" {
+				t.Errorf("\n%s\ne.Create(...): -want granted roles, +got granted roles:\n%s\n", tc.reason, diff)
+			}
+			if err == nil && cr.Status.AtProvider.ReplicatedAt == nil {
+				t.Errorf("\n%s\ne.Create(...): want replicatedAt set", tc.reason)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	c := &mockPrivilegeClient{}
+	e := &external{client: c, log: &MockLogger{}}
+	cr := replication(v1alpha1.ReplicationModeContinuous, replicatedAt)
+	if _, err := e.Delete(context.Background(), cr); err != nil {
+		t.Fatalf("e.Delete(...): unexpected error: %v", err)
+	}
+	if got := cr.GetCondition(xpv1.TypeReady).Reason; got != xpv1.ReasonDeleting {
+		t.Errorf("e.Delete(...): want reason %s, got %s", xpv1.ReasonDeleting, got)
+	}
+}
@@ -10,7 +10,6 @@ import (
 
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
 	"github.com/pkg/errors"
-	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -26,9 +25,14 @@ import (
 
 	adminv1alpha1 "github.com/SAP/crossplane-provider-hana/apis/admin/v1alpha1"
 	"github.com/SAP/crossplane-provider-hana/apis/v1alpha1"
+	"github.com/SAP/crossplane-provider-hana/internal/clients/hana"
 	"github.com/SAP/crossplane-provider-hana/internal/clients/hana/x509provider"
 	"github.com/SAP/crossplane-provider-hana/internal/clients/xsql"
+	"github.com/SAP/crossplane-provider-hana/internal/controller/capability"
+	"github.com/SAP/crossplane-provider-hana/internal/controller/externalname"
 	"github.com/SAP/crossplane-provider-hana/internal/controller/features"
+	"github.com/SAP/crossplane-provider-hana/internal/controller/outcome"
+	"github.com/SAP/crossplane-provider-hana/internal/controller/poll"
 )
 
 const (
@@ -36,11 +40,14 @@ const (
 	errTrackPCUsage            = "cannot track ProviderConfig usage"
 	errGetPC                   = "cannot get ProviderConfig"
 	errGetCreds                = "cannot get credentials"
-	errNoSecretRef             = "ProviderConfig does not reference a credentials Secret"
 	errGetPasswordSecretFailed = "cannot get password secret: %w"
-	errGetSecret               = "cannot get credentials Secret: %w"
+	errGetTLS                  = "cannot get TLS configuration"
 	errKeyNotFound             = "key %s not found in secret %s/%s"
 	errDbFail                  = "cannot connect to HANA db"
+	errReadRenamed             = "cannot read renamed X.509 provider"
+	errCreateRenamed           = "cannot create renamed X.509 provider"
+	errReadPrevious            = "cannot read previous X.509 provider"
+	errDropPrevious            = "cannot drop previous X.509 provider"
 )
 
 // Setup adds a controller that reconciles X509Provider managed resources.
@@ -55,7 +62,7 @@ func Setup(mgr ctrl.Manager, o controller.Options, db xsql.Connector) error {
 	t := resource.NewProviderConfigUsageTracker(mgr.GetClient(), &v1alpha1.ProviderConfigUsage{})
 	r := managed.NewReconciler(mgr,
 		resource.ManagedKind(adminv1alpha1.X509ProviderGroupVersionKind),
-		managed.WithExternalConnecter(&connector{
+		outcome.WithExternalConnecter(name, &connector{
 			kube:      mgr.GetClient(),
 			usage:     t,
 			newClient: x509provider.New,
@@ -63,7 +70,9 @@ func Setup(mgr ctrl.Manager, o controller.Options, db xsql.Connector) error {
 			db:        db,
 		}),
 		managed.WithLogger(o.Logger.WithValues("controller", name)),
+		managed.WithInitializers(externalname.NewInitializer(mgr.GetClient(), nameField)),
 		managed.WithPollInterval(o.PollInterval),
+		poll.WithJitterHook(adminv1alpha1.X509ProviderKind, nil),
 		managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))),
 		managed.WithConnectionPublishers(cps...),
 		features.ConfigureBetaManagementPolicies(o))
@@ -75,6 +84,15 @@ func Setup(mgr ctrl.Manager, o controller.Options, db xsql.Connector) error {
 		Complete(ratelimiter.NewReconciler(name, r, o.GlobalRateLimiter))
 }
 
+// nameField maps the external name to the name of the X.509 provider.
+func nameField(mg resource.Managed) *string {
+	cr, ok := mg.(*adminv1alpha1.X509Provider)
+	if !ok {
+		return nil
+	}
+	return &cr.Spec.ForProvider.Name
+}
+
 // A connector is expected to produce an ExternalClient when its Connect method
 // is called.
 type connector struct {
@@ -105,23 +123,31 @@ func (c *connector) Connect(ctx context.Context, mg resource.Managed) (managed.E
 		return nil, errors.Wrap(err, errGetPC)
 	}
 
-	ref := pc.Spec.Credentials.ConnectionSecretRef
-	if ref == nil {
-		return nil, errors.New(errNoSecretRef)
-	}
-
-	s := &corev1.Secret{}
-	if err := c.kube.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, s); err != nil {
-		return nil, errors.Wrap(err, errGetSecret)
+	data, err := hana.Credentials(ctx, c.kube, pc)
+	if err != nil {
+		return nil, err
 	}
 
 	c.log.Info("Connecting to X509 provider resource", "name", cr.Name)
 
-	conn, err := c.db.Connect(ctx, s.Data)
+	creds, err := hana.ConnectionCredentials(ctx, c.kube, pc, data)
+	if err != nil {
+		return nil, errors.Wrap(err, errGetTLS)
+	}
+
+	conn, err := c.db.Connect(ctx, creds)
 	if err != nil {
 		return nil, errors.Wrap(err, errDbFail)
 	}
 
+	if err := hana.ReportActiveEndpoint(ctx, c.kube, pc, conn); err != nil {
+		c.log.Info("Cannot report active endpoint", "providerConfig", pc.Name, "error", err)
+	}
+
+	if err := hana.Require(ctx, conn, hana.ManageX509Providers); err != nil {
+		return capability.Disabled(err), nil
+	}
+
 	return &external{
 		client: c.newClient(conn),
 		kube:   c.kube,
@@ -144,7 +170,57 @@ func (c *external) Disconnect(ctx context.Context) error {
 func isUpToDate(p adminv1alpha1.X509ProviderParameters, o adminv1alpha1.X509ProviderObservation) bool {
 	return o.Issuer != nil &&
 		p.Issuer == *o.Issuer &&
-		slices.Equal(p.MatchingRules, o.MatchingRules)
+		slices.Equal(p.MatchingRules, o.MatchingRules) &&
+		(p.Priority == nil || (o.Priority != nil && *o.Priority == *p.Priority))
+}
+
+// parametersOf returns the parameters of the X.509 provider, named by its
+// external name.
+func parametersOf(cr *adminv1alpha1.X509Provider) *adminv1alpha1.X509ProviderParameters {
+	parameters := cr.Spec.ForProvider.DeepCopy()
+	if name := meta.GetExternalName(cr); name != "" {
+		parameters.Name = name
+	}
+	return parameters
+}
+
+// handleRename moves an X.509 provider observed under a previous name to its
+// current name. HANA cannot rename X.509 providers, so the provider is created
+// under the new name before the previous one is dropped. Both steps are
+// skipped if already done, so a failed rename is picked up again on the next
+// reconcile.
+func (c *external) handleRename(ctx context.Context, cr *adminv1alpha1.X509Provider, parameters *adminv1alpha1.X509ProviderParameters) error {
+	previous := cr.Status.AtProvider.Name
+	if previous == nil || *previous == "" || *previous == parameters.Name {
+		return nil
+	}
+
+	c.log.Info("Renaming X.509 provider", "from", *previous, "to", parameters.Name)
+
+	renamed, err := c.client.Read(ctx, parameters)
+	if err != nil {
+		return errors.Wrap(err, errReadRenamed)
+	}
+	if renamed == nil {
+		if err := c.client.Create(ctx, parameters); err != nil {
+			return errors.Wrap(err, errCreateRenamed)
+		}
+	}
+
+	old := parameters.DeepCopy()
+	old.Name = *previous
+	observed, err := c.client.Read(ctx, old)
+	if err != nil {
+		return errors.Wrap(err, errReadPrevious)
+	}
+	if observed != nil {
+		if err := c.client.Delete(ctx, old); err != nil {
+			return errors.Wrap(err, errDropPrevious)
+		}
+	}
+
+	cr.Status.AtProvider = adminv1alpha1.X509ProviderObservation{}
+	return nil
 }
 
 func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
@@ -155,9 +231,13 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 
 	c.log.Info("Observing X.509 provider resource", "name", cr.Name)
 
-	parameters := cr.Spec.ForProvider
+	parameters := parametersOf(cr)
 
-	observed, err := c.client.Read(ctx, &parameters)
+	if err := c.handleRename(ctx, cr, parameters); err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
+	observed, err := c.client.Read(ctx, parameters)
 	if err != nil {
 		return managed.ExternalObservation{}, err
 	} else if observed == nil {
@@ -165,18 +245,13 @@ func (c *external) Observe(ctx context.Context, mg resource.Managed) (managed.Ex
 	}
 
 	cr.Status.AtProvider = *observed
+	cr.Status.AtProvider.ExternalID = parameters.Name
 	cr.Status.SetConditions(xpv1.Available())
 
-	if !isUpToDate(parameters, *observed) {
-		return managed.ExternalObservation{
-			ResourceExists:   true,
-			ResourceUpToDate: false,
-		}, nil
-	}
-
 	return managed.ExternalObservation{
-		ResourceExists:   true,
-		ResourceUpToDate: true,
+		ResourceExists:    true,
+		ResourceUpToDate:  isUpToDate(*parameters, *observed),
+		ConnectionDetails: externalname.ConnectionDetails(parameters.Name),
 	}, nil
 }
 
@@ -188,14 +263,12 @@ func (c *external) Create(ctx context.Context, mg resource.Managed) (managed.Ext
 
 	c.log.Info("Creating X.509 provider resource", "name", cr.Name)
 
-	parameters := cr.Spec.ForProvider.DeepCopy()
+	parameters := parametersOf(cr)
 
 	if err := c.client.Create(ctx, parameters); err != nil {
 		return managed.ExternalCreation{}, err
 	}
 
-	meta.SetExternalName(cr, cr.Spec.ForProvider.Name)
-
 	return managed.ExternalCreation{
 		// Optionally return any details that may be required to connect to the
 		// external resource. These will be stored as the connection secret.
@@ -209,7 +282,7 @@ func (c *external) Update(ctx context.Context, mg resource.Managed) (managed.Ext
 		return managed.ExternalUpdate{}, errors.New(errNotX509Provider)
 	}
 
-	parameters := cr.Spec.ForProvider.DeepCopy()
+	parameters := parametersOf(cr)
 	observation := cr.Status.AtProvider.DeepCopy()
 
 	c.log.Info("Updating X.509 provider resource", "name", cr.Name)
@@ -229,7 +302,7 @@ func (c *external) Delete(ctx context.Context, mg resource.Managed) (managed.Ext
 		return managed.ExternalDelete{}, errors.New(errNotX509Provider)
 	}
 
-	parameters := cr.Spec.ForProvider.DeepCopy()
+	parameters := parametersOf(cr)
 
 	c.log.Info("Deleting X.509 provider", "name", cr.Name)
 	cr.SetConditions(xpv1.Deleting())
@@ -17,6 +17,7 @@ import (
 
 	"github.com/SAP/crossplane-provider-hana/apis/admin/v1alpha1"
 	"github.com/SAP/crossplane-provider-hana/internal/clients/hana/x509provider"
+	"github.com/SAP/crossplane-provider-hana/internal/controller/externalname"
 )
 
 // Unlike many Kubernetes projects Crossplane does not use third party testing
@@ -30,6 +31,9 @@ import (
 func TestObserve(t *testing.T) {
 	errBoom := errors.New("boom")
 
+	// created records whether the renamed provider was created.
+	var created bool
+
 	type fields struct {
 		client x509provider.X509ProviderClient
 		log    logging.Logger
@@ -140,8 +144,9 @@ func TestObserve(t *testing.T) {
 			},
 			want: want{
 				o: managed.ExternalObservation{
-					ResourceExists:   true,
-					ResourceUpToDate: true,
+					ResourceExists:    true,
+					ResourceUpToDate:  true,
+					ConnectionDetails: externalname.ConnectionDetails("test-provider"),
 				},
 			},
 		},
@@ -172,15 +177,130 @@ func TestObserve(t *testing.T) {
 			},
 			want: want{
 				o: managed.ExternalObservation{
-					ResourceExists:   true,
-					ResourceUpToDate: false,
+					ResourceExists:    true,
+					ResourceUpToDate:  false,
+					ConnectionDetails: externalname.ConnectionDetails("test-provider"),
+				},
+			},
+		},
+		"SuccessPriorityOutOfDate": {
+			reason: "Should return ResourceUpToDate false when the priority differs",
+			fields: fields{
+				client: &mockX509ProviderClient{
+					MockRead: func(ctx context.Context, parameters *v1alpha1.X509ProviderParameters) (*v1alpha1.X509ProviderObservation, error) {
+						return &v1alpha1.X509ProviderObservation{
+							Name:     new("test-provider"),
+							Issuer:   new("CN=Test CA"),
+							Priority: new(1),
+						}, nil
+					},
+				},
+				log: &mockLogger{},
+			},
+			args: args{
+				mg: &v1alpha1.X509Provider{
+					Spec: v1alpha1.X509ProviderSpec{
+						ForProvider: v1alpha1.X509ProviderParameters{
+							Name:     "test-provider",
+							Issuer:   "CN=Test CA",
+							Priority: new(2),
+						},
+					},
+				},
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  false,
+					ConnectionDetails: externalname.ConnectionDetails("test-provider"),
+				},
+			},
+		},
+		"SuccessRenamed": {
+			reason: "Should create the provider under its new name and drop the previous one",
+			fields: fields{
+				client: &mockX509ProviderClient{
+					MockRead: func(ctx context.Context, parameters *v1alpha1.X509ProviderParameters) (*v1alpha1.X509ProviderObservation, error) {
+						if parameters.Name == "old-provider" {
+							return &v1alpha1.X509ProviderObservation{Name: new("old-provider"), Issuer: new("CN=Test CA")}, nil
+						}
+						if !created {
+							return nil, nil
+						}
+						return &v1alpha1.X509ProviderObservation{Name: new("new-provider"), Issuer: new("CN=Test CA")}, nil
+					},
+					MockCreate: func(ctx context.Context, parameters *v1alpha1.X509ProviderParameters) error {
+						if parameters.Name != "new-provider" {
+							return errBoom
+						}
+						created = true
+						return nil
+					},
+					MockDelete: func(ctx context.Context, parameters *v1alpha1.X509ProviderParameters) error {
+						if parameters.Name != "old-provider" {
+							return errBoom
+						}
+						return nil
+					},
+				},
+				log: &mockLogger{},
+			},
+			args: args{
+				mg: &v1alpha1.X509Provider{
+					Spec: v1alpha1.X509ProviderSpec{
+						ForProvider: v1alpha1.X509ProviderParameters{
+							Name:   "new-provider",
+							Issuer: "CN=Test CA",
+						},
+					},
+					Status: v1alpha1.X509ProviderStatus{
+						AtProvider: v1alpha1.X509ProviderObservation{Name: new("old-provider")},
+					},
+				},
+			},
+			want: want{
+				o: managed.ExternalObservation{
+					ResourceExists:    true,
+					ResourceUpToDate:  true,
+					ConnectionDetails: externalname.ConnectionDetails("new-provider"),
+				},
+			},
+		},
+		"ErrDropPrevious": {
+			reason: "Should return an error when the previous provider cannot be dropped",
+			fields: fields{
+				client: &mockX509ProviderClient{
+					MockRead: func(ctx context.Context, parameters *v1alpha1.X509ProviderParameters) (*v1alpha1.X509ProviderObservation, error) {
+						return &v1alpha1.X509ProviderObservation{Name: new(parameters.Name), Issuer: new("CN=Test CA")}, nil
+					},
+					MockDelete: func(ctx context.Context, parameters *v1alpha1.X509ProviderParameters) error {
+						return errBoom
+					},
 				},
+				log: &mockLogger{},
+			},
+			args: args{
+				mg: &v1alpha1.X509Provider{
+					Spec: v1alpha1.X509ProviderSpec{
+						ForProvider: v1alpha1.X509ProviderParameters{
+							Name:   "new-provider",
+							Issuer: "CN=Test CA",
+						},
+					},
+					Status: v1alpha1.X509ProviderStatus{
+						AtProvider: v1alpha1.X509ProviderObservation{Name: new("old-provider")},
+					},
+				},
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errDropPrevious),
 			},
 		},
 	}
 
 	for name, tc := range cases {
 		t.Run(name, func(t *testing.T) {
+			created = false
 			e := external{client: tc.fields.client, log: tc.fields.log}
 			got, err := e.Observe(tc.args.ctx, tc.args.mg)
 			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
@@ -0,0 +1,95 @@
+/*
+Copyright 2026 SAP SE or an SAP affiliate company and contributors.
+*/
+
+package x509provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/SAP/crossplane-provider-hana/apis/admin/v1alpha1"
+)
+
+// ErrNoneSelected is returned if no X509Provider matches a providerSelector.
+var ErrNoneSelected = errors.New("no X509Provider matches the providerSelector")
+
+const (
+	errGetReferenced = "cannot get X509Provider %s: %w"
+	errListSelected  = "cannot list X509Providers: %w"
+	errAmbiguous     = "more than one X509Provider matches the providerSelector: %s"
+)
+
+// Resolve returns the X509Provider the reference of the from object selects by
+// providerRef or providerSelector, or nil if it references a provider by name.
+// A providerSelector must match exactly one X509Provider, so that the provider
+// set in HANA does not depend on the order they are listed in. With
+// matchControllerRef, it only matches X509Providers with the same controller
+// as the from object.
+func Resolve(ctx context.Context, kube client.Reader, from metav1.Object, ref v1alpha1.X509ProviderRef) (*v1alpha1.X509Provider, error) {
+	switch {
+	case ref.ProviderRef != nil:
+		p := &v1alpha1.X509Provider{}
+		if err := kube.Get(ctx, types.NamespacedName{Name: ref.ProviderRef.Name}, p); err != nil {
+			return nil, fmt.Errorf(errGetReferenced, ref.ProviderRef.Name, err)
+		}
+		return p, nil
+	case ref.ProviderSelector != nil:
+		list := &v1alpha1.X509ProviderList{}
+		if err := kube.List(ctx, list, client.MatchingLabels(ref.ProviderSelector.MatchLabels)); err != nil {
+			return nil, fmt.Errorf(errListSelected, err)
+		}
+		list.Items = slices.DeleteFunc(list.Items, func(p v1alpha1.X509Provider) bool {
+			return !controllersMatch(ref.ProviderSelector, from, &p)
+		})
+		switch len(list.Items) {
+		case 0:
+			return nil, ErrNoneSelected
+		case 1:
+			return &list.Items[0], nil
+		default:
+			names := make([]string, 0, len(list.Items))
+			for _, p := range list.Items {
+				names = append(names, p.GetName())
+			}
+			slices.Sort(names)
+			return nil, fmt.Errorf(errAmbiguous, strings.Join(names, ", "))
+		}
+	default:
+		return nil, nil
+	}
+}
+
+// References returns whether the reference of the from object refers to the
+// X509Provider, by providerRef, providerSelector or the name of the provider
+// in HANA.
+func References(from metav1.Object, ref *v1alpha1.X509ProviderRef, p *v1alpha1.X509Provider) bool {
+	switch {
+	case ref == nil:
+		return false
+	case ref.ProviderRef != nil:
+		return ref.ProviderRef.Name == p.GetName()
+	case ref.ProviderSelector != nil:
+		return labels.SelectorFromSet(ref.ProviderSelector.MatchLabels).Matches(labels.Set(p.GetLabels())) &&
+			controllersMatch(ref.ProviderSelector, from, p)
+	default:
+		return ref.Name != "" && ref.Name == p.Spec.ForProvider.Name
+	}
+}
+
+// controllersMatch returns whether the X509Provider has the same controller as
+// the from object, if the selector requires it.
+func controllersMatch(s *xpv1.Selector, from metav1.Object, p *v1alpha1.X509Provider) bool {
+	return !ptr.Deref(s.MatchControllerRef, false) || meta.HaveSameController(from, p)
+}
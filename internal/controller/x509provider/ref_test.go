@@ -0,0 +1,77 @@
+/*
+Copyright 2026 SAP SE or an SAP affiliate company and contributors.
+*/
+
+package x509provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/SAP/crossplane-provider-hana/apis/admin/v1alpha1"
+)
+
+func TestResolveMatchControllerRef(t *testing.T) {
+	controlledBy := func(uid types.UID) []metav1.OwnerReference {
+		return []metav1.OwnerReference{{UID: uid, Controller: ptr.To(true)}}
+	}
+	provider := func(name string, uid types.UID) v1alpha1.X509Provider {
+		return v1alpha1.X509Provider{ObjectMeta: metav1.ObjectMeta{
+			Name:            name,
+			Labels:          map[string]string{"idp": "idp"},
+			OwnerReferences: controlledBy(uid),
+		}}
+	}
+	from := &v1alpha1.PersonalSecurityEnvironment{ObjectMeta: metav1.ObjectMeta{OwnerReferences: controlledBy("composite")}}
+	providers := []v1alpha1.X509Provider{provider("other", "other-composite"), provider("idp", "composite")}
+
+	cases := map[string]struct {
+		reason string
+		match  *bool
+		want   string
+		err    error
+	}{
+		"MatchControllerRef": {
+			reason: "Only the X509Provider with the same controller should be selected",
+			match:  ptr.To(true),
+			want:   "idp",
+		},
+		"AnyController": {
+			reason: "All X509Providers matching the labels should be selected without matchControllerRef",
+			err:    fmt.Errorf(errAmbiguous, "idp, other"),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			kube := &test.MockClient{
+				MockList: func(_ context.Context, list client.ObjectList, _ ...client.ListOption) error {
+					list.(*v1alpha1.X509ProviderList).Items = append([]v1alpha1.X509Provider(nil), providers...)
+					return nil
+				},
+			}
+			ref := v1alpha1.X509ProviderRef{ProviderSelector: &xpv1.Selector{MatchLabels: map[string]string{"idp": "idp"}, MatchControllerRef: tc.match}}
+			got, err := Resolve(context.Background(), kube, from, ref)
+			if diff := cmp.Diff(tc.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nResolve(...): -want error, +got error:\n%s\n", tc.reason, diff)
+			}
+			if err == nil && got.GetName() != tc.want {
+				t.Errorf("\n%s\nResolve(...): want %s, got %s\n", tc.reason, tc.want, got.GetName())
+			}
+			for _, p := range providers {
+				if want := !ptr.Deref(tc.match, false) || p.GetName() == "idp"; References(from, &ref, &p) != want {
+					t.Errorf("\n%s\nReferences(..., %s): want %t\n", tc.reason, p.GetName(), want)
+				}
+			}
+		})
+	}
+}
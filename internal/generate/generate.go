@@ -0,0 +1,89 @@
+/*
+Copyright 2026 SAP SE or an SAP affiliate company and contributors.
+*/
+
+// Package generate renders example manifests that bootstrap the provider: a
+// ProviderConfig with its credentials Secret and a User authenticating with
+// X.509 certificates through an X509Provider and a PSE.
+package generate
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io"
+	"text/template"
+
+	"github.com/SAP/crossplane-provider-hana/internal/clients/hana/x509provider"
+)
+
+const (
+	errInvalidIssuer  = "issuer: %w"
+	errInvalidSubject = "subject: %w"
+	errRender         = "cannot render %s: %w"
+)
+
+//go:embed templates/*.yaml
+var templates embed.FS
+
+// manifests are rendered in this order, so each manifest follows the ones it
+// references.
+var manifests = []string{
+	"secret.yaml",
+	"providerconfig.yaml",
+	"x509provider.yaml",
+	"pse.yaml",
+	"user.yaml",
+}
+
+// Options customize the generated manifests.
+type Options struct {
+	// Endpoint and Port of the HANA database.
+	Endpoint string
+	Port     int
+	// Namespace of the credentials Secret.
+	Namespace string
+	// ProviderConfig is the name of the ProviderConfig the managed
+	// resources use.
+	ProviderConfig string
+	// Issuer is the distinguished name of the CA issuing the client
+	// certificates.
+	Issuer string
+	// Subject is the distinguished name of the client certificate of the
+	// user.
+	Subject string
+	// Username of the user.
+	Username string
+}
+
+// Write renders the example manifests as one multi-document YAML stream.
+func Write(w io.Writer, o Options) error {
+	var err error
+	if o.Issuer, err = x509provider.NormalizeIssuer(o.Issuer); err != nil {
+		return fmt.Errorf(errInvalidIssuer, err)
+	}
+	if o.Subject, err = x509provider.NormalizeIssuer(o.Subject); err != nil {
+		return fmt.Errorf(errInvalidSubject, err)
+	}
+
+	t, err := template.New("").Funcs(template.FuncMap{"quote": quote}).ParseFS(templates, "templates/*.yaml")
+	if err != nil {
+		return err
+	}
+	for _, name := range manifests {
+		if _, err := io.WriteString(w, "---\n"); err != nil {
+			return err
+		}
+		if err := t.ExecuteTemplate(w, name, o); err != nil {
+			return fmt.Errorf(errRender, name, err)
+		}
+	}
+	return nil
+}
+
+// quote returns s as a double-quoted YAML string, so flag values cannot
+// change the structure of the manifests.
+func quote(s string) (string, error) {
+	b, err := json.Marshal(s)
+	return string(b), err
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2026 SAP SE or an SAP affiliate company and contributors.
+*/
+
+package generate
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/google/go-cmp/cmp"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
+
+	"github.com/SAP/crossplane-provider-hana/apis/admin/v1alpha1"
+	apisv1alpha1 "github.com/SAP/crossplane-provider-hana/apis/v1alpha1"
+)
+
+func TestWrite(t *testing.T) {
+	o := Options{
+		Endpoint:       "hana.example.com",
+		Port:           443,
+		Namespace:      "crossplane-system",
+		ProviderConfig: "default",
+		Issuer:         "cn=Example CA,O=Example",
+		Subject:        "CN=app, O=Example",
+		Username:       "APP",
+	}
+
+	var b strings.Builder
+	if err := Write(&b, o); err != nil {
+		t.Fatalf("Write(...): %v", err)
+	}
+	docs := strings.Split(strings.TrimPrefix(b.String(), "---\n"), "---\n")
+	if len(docs) != len(manifests) {
+		t.Fatalf("Write(...): got %d manifests, want %d", len(docs), len(manifests))
+	}
+
+	// Each manifest must decode into its API type without unknown fields.
+	secret := &corev1.Secret{}
+	pc := &apisv1alpha1.ProviderConfig{}
+	provider := &v1alpha1.X509Provider{}
+	pse := &v1alpha1.PersonalSecurityEnvironment{}
+	user := &v1alpha1.User{}
+	for i, obj := range []any{secret, pc, provider, pse, user} {
+		if err := yaml.UnmarshalStrict([]byte(docs[i]), obj); err != nil {
+			t.Fatalf("%s: %v", manifests[i], err)
+		}
+	}
+
+	if diff := cmp.Diff(map[string]string{"endpoint": "hana.example.com", "port": "443", "username": "<username>", "password": "<password>"}, secret.StringData); diff != "" {
+		t.Errorf("Secret: -want stringData, +got stringData:\n%s", diff)
+	}
+	if ref := pc.Spec.Credentials.ConnectionSecretRef; ref == nil || ref.Name != secret.Name || ref.Namespace != secret.Namespace {
+		t.Errorf("ProviderConfig: connectionSecretRef %+v does not reference Secret %s/%s", ref, secret.Namespace, secret.Name)
+	}
+	if got, want := provider.Spec.ForProvider.Issuer, "CN=Example CA, O=Example"; got != want {
+		t.Errorf("X509Provider: issuer = %q, want %q", got, want)
+	}
+	if got := pse.Spec.ForProvider.X509ProviderRef.ProviderRef.Name; got != provider.Name {
+		t.Errorf("PersonalSecurityEnvironment: providerRef = %q, want %q", got, provider.Name)
+	}
+	if got, want := user.Spec.ForProvider.Authentication.X509Providers[0].SubjectName, o.Subject; got != want {
+		t.Errorf("User: subjectName = %q, want %q", got, want)
+	}
+	for _, mg := range []resource.Managed{provider, pse, user} {
+		if ref := mg.GetProviderConfigReference(); ref == nil || ref.Name != pc.Name {
+			t.Errorf("%s: providerConfigRef %+v does not reference ProviderConfig %s", mg.GetName(), ref, pc.Name)
+		}
+	}
+}
+
+func TestWriteInvalid(t *testing.T) {
+	cases := map[string]struct {
+		reason string
+		o      Options
+	}{
+		"InvalidIssuer": {
+			reason: "A malformed issuer should be rejected",
+			o:      Options{Issuer: "Example CA", Subject: "CN=app"},
+		},
+		"InvalidSubject": {
+			reason: "A malformed subject should be rejected",
+			o:      Options{Issuer: "CN=Example CA", Subject: "app"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if err := Write(&strings.Builder{}, tc.o); err == nil {
+				t.Errorf("\n%s\nWrite(...): want error, got nil", tc.reason)
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2026 SAP SE or an SAP affiliate company and contributors.
+*/
+
+// Package sqlstats counts the statements and queries the provider runs against
+// HANA on behalf of a reconcile, so the load each controller puts on a
+// database can be quantified.
+package sqlstats
+
+import (
+	"context"
+	"database/sql"
+	"sync/atomic"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apisv1alpha1 "github.com/SAP/crossplane-provider-hana/apis/v1alpha1"
+	"github.com/SAP/crossplane-provider-hana/internal/clients/xsql"
+)
+
+// A Counter counts the statements and queries run with contexts carrying it.
+// It is safe for concurrent use, as clients run queries in parallel.
+type Counter struct {
+	statements atomic.Int64
+	queries    atomic.Int64
+}
+
+// Statistics returns the counts so far as SQL statistics of a reconcile that
+// took d.
+func (c *Counter) Statistics(d time.Duration) *apisv1alpha1.SQLStatistics {
+	return &apisv1alpha1.SQLStatistics{
+		StatementsExecuted: c.statements.Load(),
+		QueriesExecuted:    c.queries.Load(),
+		Duration:           metav1.Duration{Duration: d},
+	}
+}
+
+type counterKey struct{}
+
+// WithCounter returns a context whose statements and queries are counted by c.
+func WithCounter(ctx context.Context, c *Counter) context.Context {
+	return context.WithValue(ctx, counterKey{}, c)
+}
+
+func counter(ctx context.Context) *Counter {
+	c, _ := ctx.Value(counterKey{}).(*Counter)
+	return c
+}
+
+// NewConnector returns a Connector whose DBs count the statements and queries
+// run with a context carrying a Counter.
+func NewConnector(next xsql.Connector) xsql.Connector {
+	return &connector{Connector: next}
+}
+
+type connector struct {
+	xsql.Connector
+}
+
+func (c *connector) Connect(ctx context.Context, creds map[string][]byte) (xsql.DB, error) {
+	db, err := c.Connector.Connect(ctx, creds)
+	if err != nil {
+		return nil, err
+	}
+	return &countedDB{DB: db}, nil
+}
+
+// countedDB counts ExecContext calls as statements, and QueryContext and
+// QueryRowContext calls as queries. Failed calls are counted as well, as they
+// reached the database all the same.
+type countedDB struct {
+	xsql.DB
+}
+
+// Unwrap returns the counted DB.
+func (d *countedDB) Unwrap() xsql.DB {
+	return d.DB
+}
+
+func (d *countedDB) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	if c := counter(ctx); c != nil {
+		c.statements.Add(1)
+	}
+	return d.DB.ExecContext(ctx, query, args...)
+}
+
+func (d *countedDB) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	if c := counter(ctx); c != nil {
+		c.queries.Add(1)
+	}
+	return d.DB.QueryContext(ctx, query, args...)
+}
+
+func (d *countedDB) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	if c := counter(ctx); c != nil {
+		c.queries.Add(1)
+	}
+	return d.DB.QueryRowContext(ctx, query, args...)
+}
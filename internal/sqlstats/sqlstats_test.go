@@ -0,0 +1,61 @@
+/*
+Copyright 2026 SAP SE or an SAP affiliate company and contributors.
+*/
+
+package sqlstats
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	apisv1alpha1 "github.com/SAP/crossplane-provider-hana/apis/v1alpha1"
+	"github.com/SAP/crossplane-provider-hana/internal/clients/fake"
+	"github.com/SAP/crossplane-provider-hana/internal/clients/xsql"
+)
+
+func TestCountedDB(t *testing.T) {
+	errBoom := errors.New("boom")
+	db := fake.MockDB{
+		MockExecContext: func(ctx context.Context, query string, args ...any) (sql.Result, error) {
+			return nil, errBoom
+		},
+		MockQueryRowContext: func(ctx context.Context, query string, args ...any) *sql.Row {
+			return nil
+		},
+	}
+	c := NewConnector(fake.MockConnector{MockConnect: func(context.Context, map[string][]byte) (xsql.DB, error) { return db, nil }})
+	cdb, err := c.Connect(context.Background(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Statements outside of a counted context are not counted anywhere.
+	_, _ = cdb.ExecContext(context.Background(), "GRANT")
+
+	counter := &Counter{}
+	ctx := WithCounter(context.Background(), counter)
+	var wg sync.WaitGroup
+	for range 3 {
+		wg.Go(func() {
+			_, _ = cdb.ExecContext(ctx, "GRANT")
+			_, _ = cdb.QueryContext(ctx, "SELECT")
+			_ = cdb.QueryRowContext(ctx, "SELECT")
+		})
+	}
+	wg.Wait()
+
+	want := &apisv1alpha1.SQLStatistics{StatementsExecuted: 3, QueriesExecuted: 6, Duration: metav1.Duration{Duration: time.Second}}
+	if diff := cmp.Diff(want, counter.Statistics(time.Second)); diff != "" {
+		t.Errorf("Statistics(...): -want, +got:\n%s", diff)
+	}
+	if u, ok := cdb.(interface{ Unwrap() xsql.DB }); !ok || u.Unwrap() == nil {
+		t.Errorf("countedDB does not unwrap to the counted DB")
+	}
+}
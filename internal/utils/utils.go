@@ -1,7 +1,10 @@
 package utils
 
 import (
+	"cmp"
+	"maps"
 	"regexp"
+	"slices"
 	"strings"
 )
 
@@ -13,6 +16,31 @@ func EscapeDoubleQuotes(input string) string {
 	return strings.ReplaceAll(input, `"`, `""`)
 }
 
+// QuoteIdentifier wraps an identifier in double quotes, escaping embedded quotes,
+// so HANA takes it verbatim instead of folding it to uppercase.
+func QuoteIdentifier(identifier string) string {
+	return `"` + EscapeDoubleQuotes(identifier) + `"`
+}
+
+// FoldIdentifier returns the name HANA stores for an identifier. Unquoted
+// identifiers are folded to uppercase, case-sensitive ones are kept as written.
+func FoldIdentifier(identifier string, caseSensitive bool) string {
+	if caseSensitive {
+		return identifier
+	}
+	return strings.ToUpper(identifier)
+}
+
+// FoldUnquotedIdentifier returns the name HANA stores for an identifier as it
+// would be written in SQL. Identifiers in double quotes are kept as written,
+// with escaped quotes unescaped, all others are folded to uppercase.
+func FoldUnquotedIdentifier(identifier string) string {
+	if len(identifier) >= 2 && identifier[0] == '"' && identifier[len(identifier)-1] == '"' {
+		return strings.ReplaceAll(identifier[1:len(identifier)-1], `""`, `"`)
+	}
+	return strings.ToUpper(identifier)
+}
+
 // TrimOuterDoubleQuotes removes outer double quotes if the string is properly quoted.
 // Handles escaped quotes and won't break malformed strings.
 // "INSERT ON SCHEMA NEW_SCHEMA" becomes INSERT ON SCHEMA NEW_SCHEMA
@@ -96,12 +124,32 @@ func ArrayToUpper(arr []string) []string {
 	return upperArr
 }
 
+// SortedSet returns a sorted copy of arr without duplicates, so that slices
+// written to status do not change between reconciles with the order of the
+// catalog.
+func SortedSet[A cmp.Ordered](arr []A) []A {
+	if len(arr) == 0 {
+		return arr
+	}
+	return slices.Compact(slices.Sorted(slices.Values(arr)))
+}
+
 func ArraysEqual[A comparable](arr1, arr2 []A) bool {
 	isEqual, _, _, _ := arraysEqualWithDifference(arr1, arr2)
 	return isEqual
 }
 
-func ArraysBothDiff[A comparable](arr1, arr2 []A) (isEqual bool, onlyInArr1 []A, onlyInArr2 []A) {
+// ArraysBothDiff returns whether the arrays hold the same elements, and the
+// elements only in arr1 and only in arr2. Both are sorted without duplicates,
+// so that the statements built from them and the logs listing them do not
+// change order between reconciles.
+func ArraysBothDiff[A cmp.Ordered](arr1, arr2 []A) (isEqual bool, onlyInArr1 []A, onlyInArr2 []A) {
+	return ArraysBothDiffFunc(arr1, arr2, cmp.Compare[A])
+}
+
+// ArraysBothDiffFunc is like ArraysBothDiff for elements that are not ordered
+// by themselves. The differences are sorted by compare.
+func ArraysBothDiffFunc[A comparable](arr1, arr2 []A, compare func(a, b A) int) (isEqual bool, onlyInArr1 []A, onlyInArr2 []A) {
 	isEqual, set1, set2, leftDifference := arraysEqualWithDifference(arr1, arr2)
 	if isEqual {
 		return true, nil, nil
@@ -112,9 +160,17 @@ func ArraysBothDiff[A comparable](arr1, arr2 []A) (isEqual bool, onlyInArr1 []A,
 	rightDifference := MapDiff(set2, set1)
 	leftArray := setToArray(leftDifference)
 	rightArray := setToArray(rightDifference)
+	slices.SortFunc(leftArray, compare)
+	slices.SortFunc(rightArray, compare)
 	return false, leftArray, rightArray
 }
 
+// SortedKeys returns the keys of m in order, so that statements and logs
+// built from a map do not change order with its iteration order.
+func SortedKeys[K cmp.Ordered, V any](m map[K]V) []K {
+	return slices.Sorted(maps.Keys(m))
+}
+
 func arraysEqualWithDifference[A comparable](arr1, arr2 []A) (bool, map[A]struct{}, map[A]struct{}, map[A]struct{}) {
 	set1 := arrayToSet(arr1)
 	set2 := arrayToSet(arr2)
@@ -128,13 +184,19 @@ func arraysEqualWithDifference[A comparable](arr1, arr2 []A) (bool, map[A]struct
 	return true, set1, set2, nil
 }
 
+// MapsBothDiff returns whether the maps are equal, the entries of map1 that
+// are missing from map2 or differ in it, and the entries of map2 whose keys
+// are missing from map1. Applied to desired and observed parameters, these are
+// the parameters to set and to clear.
 func MapsBothDiff[K, V comparable](map1, map2 map[K]V) (isEqual bool, onlyInMap1 map[K]V, onlyInMap2 map[K]V) {
-	leftDifference := MapDiff(map1, map2)
-	if len(leftDifference) != 0 || len(map1) != len(map2) {
-		return false, nil, nil
+	onlyInMap1 = MapDiff(map1, map2)
+	onlyInMap2 = make(map[K]V)
+	for key, val := range map2 {
+		if _, ok := map1[key]; !ok {
+			onlyInMap2[key] = val
+		}
 	}
-	rightDifference := MapDiff(map2, map1)
-	return true, leftDifference, rightDifference
+	return len(onlyInMap1) == 0 && len(onlyInMap2) == 0, onlyInMap1, onlyInMap2
 }
 
 func MapDiff[K, V comparable](map1, map2 map[K]V) map[K]V {
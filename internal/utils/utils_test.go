@@ -1,6 +1,13 @@
 package utils
 
-import "testing"
+import (
+	"cmp"
+	"maps"
+	"reflect"
+	"slices"
+	"testing"
+	"testing/quick"
+)
 
 func TestTrimOuterDoubleQuotes(t *testing.T) {
 	tests := []struct {
@@ -123,6 +130,102 @@ func TestEscapeDoubleQuotes(t *testing.T) {
 	}
 }
 
+func TestQuoteIdentifier(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "uppercase identifier",
+			input:    `DEMO_USER`,
+			expected: `"DEMO_USER"`,
+		},
+		{
+			name:     "mixed case identifier",
+			input:    `demo_User`,
+			expected: `"demo_User"`,
+		},
+		{
+			name:     "identifier with quotes",
+			input:    `de"mo`,
+			expected: `"de""mo"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := QuoteIdentifier(tt.input)
+			if result != tt.expected {
+				t.Errorf("QuoteIdentifier(%q) = %q, want %q", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFoldIdentifier(t *testing.T) {
+	tests := []struct {
+		name          string
+		input         string
+		caseSensitive bool
+		expected      string
+	}{
+		{
+			name:     "unquoted identifier is folded",
+			input:    `demo_User`,
+			expected: `DEMO_USER`,
+		},
+		{
+			name:          "case-sensitive identifier is kept",
+			input:         `demo_User`,
+			caseSensitive: true,
+			expected:      `demo_User`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := FoldIdentifier(tt.input, tt.caseSensitive)
+			if result != tt.expected {
+				t.Errorf("FoldIdentifier(%q, %t) = %q, want %q", tt.input, tt.caseSensitive, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFoldUnquotedIdentifier(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "unquoted identifier is folded",
+			input:    `demo_Schema`,
+			expected: `DEMO_SCHEMA`,
+		},
+		{
+			name:     "quoted identifier is kept",
+			input:    `"demo_Schema"`,
+			expected: `demo_Schema`,
+		},
+		{
+			name:     "escaped quotes are unescaped",
+			input:    `"my""Schema"`,
+			expected: `my"Schema`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := FoldUnquotedIdentifier(tt.input)
+			if result != tt.expected {
+				t.Errorf("FoldUnquotedIdentifier(%q) = %q, want %q", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
 func TestConvertBackslashEscapesToHanaEscapes(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -236,6 +339,75 @@ func TestPreprocessPrivilegeStrings(t *testing.T) {
 	}
 }
 
+func TestMapsBothDiff(t *testing.T) {
+	tests := []struct {
+		name        string
+		desired     map[string]string
+		observed    map[string]string
+		wantEqual   bool
+		wantToSet   map[string]string
+		wantToClear map[string]string
+	}{
+		{
+			name:        "equal",
+			desired:     map[string]string{"LOCALE": "en_US"},
+			observed:    map[string]string{"LOCALE": "en_US"},
+			wantEqual:   true,
+			wantToSet:   map[string]string{},
+			wantToClear: map[string]string{},
+		},
+		{
+			name:        "changed value is set, not cleared",
+			desired:     map[string]string{"LOCALE": "de_DE"},
+			observed:    map[string]string{"LOCALE": "en_US"},
+			wantToSet:   map[string]string{"LOCALE": "de_DE"},
+			wantToClear: map[string]string{},
+		},
+		{
+			name:        "missing parameter is set",
+			desired:     map[string]string{"LOCALE": "en_US", "CLIENT": "100"},
+			observed:    map[string]string{"LOCALE": "en_US"},
+			wantToSet:   map[string]string{"CLIENT": "100"},
+			wantToClear: map[string]string{},
+		},
+		{
+			name:        "parameter only observed is cleared",
+			desired:     map[string]string{"LOCALE": "en_US"},
+			observed:    map[string]string{"LOCALE": "en_US", "EMAIL ADDRESS": "demo@example.com"},
+			wantToSet:   map[string]string{},
+			wantToClear: map[string]string{"EMAIL ADDRESS": "demo@example.com"},
+		},
+		{
+			name:        "same size with different keys",
+			desired:     map[string]string{"CLIENT": "100"},
+			observed:    map[string]string{"LOCALE": "en_US"},
+			wantToSet:   map[string]string{"CLIENT": "100"},
+			wantToClear: map[string]string{"LOCALE": "en_US"},
+		},
+		{
+			name:        "nil maps are equal",
+			wantEqual:   true,
+			wantToSet:   map[string]string{},
+			wantToClear: map[string]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			isEqual, toSet, toClear := MapsBothDiff(tt.desired, tt.observed)
+			if isEqual != tt.wantEqual {
+				t.Errorf("MapsBothDiff() isEqual = %v, want %v", isEqual, tt.wantEqual)
+			}
+			if !reflect.DeepEqual(toSet, tt.wantToSet) {
+				t.Errorf("MapsBothDiff() to set = %v, want %v", toSet, tt.wantToSet)
+			}
+			if !reflect.DeepEqual(toClear, tt.wantToClear) {
+				t.Errorf("MapsBothDiff() to clear = %v, want %v", toClear, tt.wantToClear)
+			}
+		})
+	}
+}
+
 func TestMapDiffOnlyDesired(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -355,3 +527,158 @@ func TestMapDiffOnlyDesired(t *testing.T) {
 		})
 	}
 }
+
+func TestSortedSet(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    []string
+		expected []string
+	}{
+		{
+			name:     "nil stays nil",
+			input:    nil,
+			expected: nil,
+		},
+		{
+			name:     "empty stays empty",
+			input:    []string{},
+			expected: []string{},
+		},
+		{
+			name:     "sorted and deduplicated",
+			input:    []string{`SELECT ON SCHEMA "B"`, "CATALOG READ", `SELECT ON SCHEMA "B"`, `SELECT ON SCHEMA "A"`},
+			expected: []string{"CATALOG READ", `SELECT ON SCHEMA "A"`, `SELECT ON SCHEMA "B"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := SortedSet(tt.input)
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("SortedSet(%v) = %v, want %v", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestArraysBothDiff(t *testing.T) {
+	tests := []struct {
+		name        string
+		arr1, arr2  []string
+		wantEqual   bool
+		wantOnlyIn1 []string
+		wantOnlyIn2 []string
+	}{
+		{
+			name:      "equal in any order",
+			arr1:      []string{"B", "A", "A"},
+			arr2:      []string{"A", "B"},
+			wantEqual: true,
+		},
+		{
+			name:        "differences are sorted",
+			arr1:        []string{"D", "A", "C", "B"},
+			arr2:        []string{"Z", "B", "Y", "X"},
+			wantOnlyIn1: []string{"A", "C", "D"},
+			wantOnlyIn2: []string{"X", "Y", "Z"},
+		},
+		{
+			name:        "duplicates are listed once",
+			arr1:        []string{"A", "A"},
+			arr2:        nil,
+			wantOnlyIn1: []string{"A"},
+			wantOnlyIn2: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			isEqual, onlyIn1, onlyIn2 := ArraysBothDiff(tt.arr1, tt.arr2)
+			if isEqual != tt.wantEqual {
+				t.Errorf("ArraysBothDiff() isEqual = %v, want %v", isEqual, tt.wantEqual)
+			}
+			if !reflect.DeepEqual(onlyIn1, tt.wantOnlyIn1) {
+				t.Errorf("ArraysBothDiff() only in arr1 = %v, want %v", onlyIn1, tt.wantOnlyIn1)
+			}
+			if !reflect.DeepEqual(onlyIn2, tt.wantOnlyIn2) {
+				t.Errorf("ArraysBothDiff() only in arr2 = %v, want %v", onlyIn2, tt.wantOnlyIn2)
+			}
+		})
+	}
+}
+
+// TestArraysBothDiffProperties checks on random arrays that the differences
+// are sorted sets, disjoint from the other array, and turn arr2 into arr1.
+func TestArraysBothDiffProperties(t *testing.T) {
+	property := func(arr1, arr2 []uint8) bool {
+		isEqual, onlyIn1, onlyIn2 := ArraysBothDiff(arr1, arr2)
+		if isEqual != (len(onlyIn1) == 0 && len(onlyIn2) == 0) {
+			return false
+		}
+		if !slices.Equal(onlyIn1, SortedSet(onlyIn1)) || !slices.Equal(onlyIn2, SortedSet(onlyIn2)) {
+			return false
+		}
+		for _, v := range onlyIn1 {
+			if slices.Contains(arr2, v) {
+				return false
+			}
+		}
+		for _, v := range onlyIn2 {
+			if slices.Contains(arr1, v) {
+				return false
+			}
+		}
+		applied := slices.DeleteFunc(slices.Clone(arr2), func(v uint8) bool { return slices.Contains(onlyIn2, v) })
+		return slices.Equal(SortedSet(append(applied, onlyIn1...)), SortedSet(slices.Clone(arr1)))
+	}
+	if err := quick.Check(property, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+// TestArraysBothDiffFuncDeterministic checks that the differences of elements
+// without an order of their own do not change order between calls.
+func TestArraysBothDiffFuncDeterministic(t *testing.T) {
+	type mapping struct{ name, subject string }
+	compare := func(a, b mapping) int {
+		return cmp.Or(cmp.Compare(a.name, b.name), cmp.Compare(a.subject, b.subject))
+	}
+	arr1 := []mapping{{"P2", "CN=b"}, {"P1", "CN=b"}, {"P1", "CN=a"}}
+	want := []mapping{{"P1", "CN=a"}, {"P1", "CN=b"}, {"P2", "CN=b"}}
+	for range 20 {
+		if _, onlyIn1, _ := ArraysBothDiffFunc(arr1, nil, compare); !slices.Equal(onlyIn1, want) {
+			t.Fatalf("ArraysBothDiffFunc() only in arr1 = %v, want %v", onlyIn1, want)
+		}
+	}
+}
+
+// TestMapsBothDiffProperties checks on random maps that applying the entries
+// to set and to clear to map2 yields map1.
+func TestMapsBothDiffProperties(t *testing.T) {
+	property := func(map1, map2 map[uint8]uint8) bool {
+		isEqual, toSet, toClear := MapsBothDiff(map1, map2)
+		applied := maps.Clone(map2)
+		if applied == nil {
+			applied = map[uint8]uint8{}
+		}
+		maps.Copy(applied, toSet)
+		for key := range toClear {
+			if _, ok := map1[key]; ok {
+				return false
+			}
+			delete(applied, key)
+		}
+		return isEqual == maps.Equal(map1, map2) && maps.Equal(applied, map1)
+	}
+	if err := quick.Check(property, nil); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSortedKeys(t *testing.T) {
+	got := SortedKeys(map[string]int{"TIME ZONE": 1, "CLIENT": 2, "LOCALE": 3})
+	want := []string{"CLIENT", "LOCALE", "TIME ZONE"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("SortedKeys() = %v, want %v", got, want)
+	}
+}
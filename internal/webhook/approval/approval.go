@@ -0,0 +1,67 @@
+/*
+Copyright 2026 SAP SE or an SAP affiliate company and contributors.
+*/
+
+package approval
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	apisv1alpha1 "github.com/SAP/crossplane-provider-hana/apis/v1alpha1"
+)
+
+const errDecodeOld = "cannot decode old object: %w"
+
+// DecodeOld decodes the old object of the admission request in ctx into old.
+// It reports false if there is no old object, e.g. on create.
+func DecodeOld(ctx context.Context, old runtime.Object) (bool, error) {
+	req, err := admission.RequestFromContext(ctx)
+	if err != nil || len(req.OldObject.Raw) == 0 {
+		return false, nil
+	}
+	if err := json.Unmarshal(req.OldObject.Raw, old); err != nil {
+		return false, fmt.Errorf(errDecodeOld, err)
+	}
+	return true, nil
+}
+
+// Stamp writes the identity behind the admission request in ctx into the
+// approval annotations of obj. A request that changes the grants makes its
+// user the requester and revokes any previous approval. Any other request
+// keeps the requester, and setting the approved-by annotation records the
+// requesting user as approver, so approvals cannot be forged.
+func Stamp(ctx context.Context, obj metav1.Object, oldAnnotations map[string]string, grantsChanged bool) {
+	req, err := admission.RequestFromContext(ctx)
+	if err != nil {
+		return
+	}
+	identity := req.UserInfo.Username
+
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+
+	if grantsChanged {
+		annotations[apisv1alpha1.AnnotationRequestedBy] = identity
+		delete(annotations, apisv1alpha1.AnnotationApprovedBy)
+		obj.SetAnnotations(annotations)
+		return
+	}
+
+	if requester, ok := oldAnnotations[apisv1alpha1.AnnotationRequestedBy]; ok {
+		annotations[apisv1alpha1.AnnotationRequestedBy] = requester
+	} else {
+		delete(annotations, apisv1alpha1.AnnotationRequestedBy)
+	}
+	if approver, ok := annotations[apisv1alpha1.AnnotationApprovedBy]; ok && approver != oldAnnotations[apisv1alpha1.AnnotationApprovedBy] {
+		annotations[apisv1alpha1.AnnotationApprovedBy] = identity
+	}
+	obj.SetAnnotations(annotations)
+}
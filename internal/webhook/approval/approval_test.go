@@ -0,0 +1,82 @@
+/*
+Copyright 2026 SAP SE or an SAP affiliate company and contributors.
+*/
+
+package approval
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	apisv1alpha1 "github.com/SAP/crossplane-provider-hana/apis/v1alpha1"
+)
+
+func withUser(username string) context.Context {
+	return admission.NewContextWithRequest(context.Background(), admission.Request{
+		AdmissionRequest: admissionv1.AdmissionRequest{
+			UserInfo: authenticationv1.UserInfo{Username: username},
+		},
+	})
+}
+
+func TestStamp(t *testing.T) {
+	cases := map[string]struct {
+		reason         string
+		ctx            context.Context
+		annotations    map[string]string
+		oldAnnotations map[string]string
+		grantsChanged  bool
+		want           map[string]string
+	}{
+		"NoRequest": {
+			reason:      "Annotations should be left alone outside of an admission request",
+			ctx:         context.Background(),
+			annotations: map[string]string{apisv1alpha1.AnnotationApprovedBy: "mallory"},
+			want:        map[string]string{apisv1alpha1.AnnotationApprovedBy: "mallory"},
+		},
+		"GrantsChanged": {
+			reason:        "Changing the grants should record the requester and revoke the approval",
+			ctx:           withUser("alice"),
+			annotations:   map[string]string{apisv1alpha1.AnnotationApprovedBy: "bob"},
+			grantsChanged: true,
+			want:          map[string]string{apisv1alpha1.AnnotationRequestedBy: "alice"},
+		},
+		"Approve": {
+			reason:         "Setting the approved-by annotation should record the approving user",
+			ctx:            withUser("bob"),
+			annotations:    map[string]string{apisv1alpha1.AnnotationRequestedBy: "alice", apisv1alpha1.AnnotationApprovedBy: "yes"},
+			oldAnnotations: map[string]string{apisv1alpha1.AnnotationRequestedBy: "alice"},
+			want:           map[string]string{apisv1alpha1.AnnotationRequestedBy: "alice", apisv1alpha1.AnnotationApprovedBy: "bob"},
+		},
+		"ForgedApproval": {
+			reason:         "An approval naming someone else should be recorded as the requesting user",
+			ctx:            withUser("alice"),
+			annotations:    map[string]string{apisv1alpha1.AnnotationRequestedBy: "alice", apisv1alpha1.AnnotationApprovedBy: "bob"},
+			oldAnnotations: map[string]string{apisv1alpha1.AnnotationRequestedBy: "alice"},
+			want:           map[string]string{apisv1alpha1.AnnotationRequestedBy: "alice", apisv1alpha1.AnnotationApprovedBy: "alice"},
+		},
+		"ForgedRequester": {
+			reason:         "The requester should only change together with the grants",
+			ctx:            withUser("bob"),
+			annotations:    map[string]string{apisv1alpha1.AnnotationRequestedBy: "carol", apisv1alpha1.AnnotationApprovedBy: "bob"},
+			oldAnnotations: map[string]string{apisv1alpha1.AnnotationRequestedBy: "alice", apisv1alpha1.AnnotationApprovedBy: "bob"},
+			want:           map[string]string{apisv1alpha1.AnnotationRequestedBy: "alice", apisv1alpha1.AnnotationApprovedBy: "bob"},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			obj := &metav1.ObjectMeta{Annotations: tc.annotations}
+			Stamp(tc.ctx, obj, tc.oldAnnotations, tc.grantsChanged)
+			if diff := cmp.Diff(tc.want, obj.GetAnnotations()); diff != "" {
+				t.Errorf("\n%s\nStamp(...): -want, +got:\n%s\n", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+/*
+Copyright 2026 SAP SE or an SAP affiliate company and contributors.
+*/
+
+package role
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	xpwebhook "github.com/crossplane/crossplane-runtime/pkg/webhook"
+
+	"github.com/SAP/crossplane-provider-hana/apis/admin/v1alpha1"
+	"github.com/SAP/crossplane-provider-hana/internal/webhook/approval"
+)
+
+const errNotRole = "object is not a Role custom resource"
+
+// +kubebuilder:webhook:path=/mutate-admin-hana-sap-crossplane-io-v1alpha1-role,mutating=true,failurePolicy=fail,sideEffects=None,groups=admin.hana.sap.crossplane.io,resources=roles,verbs=create;update,versions=v1alpha1,name=roles.admin.hana.sap.crossplane.io,admissionReviewVersions=v1
+
+// Setup registers the mutating webhook for Role managed resources.
+func Setup(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&v1alpha1.Role{}).
+		WithDefaulter(NewDefaulter()).
+		Complete()
+}
+
+// NewDefaulter returns a mutator that records who requested and who approved
+// the privileges of a Role.
+func NewDefaulter() *xpwebhook.Mutator {
+	return xpwebhook.NewMutator(xpwebhook.WithMutationFns(
+		stampApproval,
+	))
+}
+
+func stampApproval(ctx context.Context, obj runtime.Object) error {
+	cr, ok := obj.(*v1alpha1.Role)
+	if !ok {
+		return errors.New(errNotRole)
+	}
+	old := &v1alpha1.Role{}
+	hasOld, err := approval.DecodeOld(ctx, old)
+	if err != nil {
+		return err
+	}
+	grantsChanged := !hasOld ||
+		!slices.Equal(old.Spec.ForProvider.Privileges, cr.Spec.ForProvider.Privileges) ||
+		!slices.Equal(old.Spec.ForProvider.TypedPrivileges, cr.Spec.ForProvider.TypedPrivileges)
+	approval.Stamp(ctx, cr, old.GetAnnotations(), grantsChanged)
+	return nil
+}
@@ -0,0 +1,258 @@
+/*
+Copyright 2026 SAP SE or an SAP affiliate company and contributors.
+*/
+
+package user
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	xpwebhook "github.com/crossplane/crossplane-runtime/pkg/webhook"
+
+	"github.com/SAP/crossplane-provider-hana/apis/admin/v1alpha1"
+	apisv1alpha1 "github.com/SAP/crossplane-provider-hana/apis/v1alpha1"
+	"github.com/SAP/crossplane-provider-hana/internal/clients/hana/privilege"
+	"github.com/SAP/crossplane-provider-hana/internal/webhook/approval"
+)
+
+const (
+	errNotUser             = "object is not a User custom resource"
+	errNormalizePrivileges = "cannot normalize privileges: %w"
+	errConvertRestricted   = "restrictedUser cannot be changed: HANA cannot convert between restricted and standard users in place, recreate the user instead"
+	errGetPC               = "cannot get ProviderConfig: %w"
+	errRename              = "username cannot be changed: set the " + v1alpha1.AnnotationRenamePolicy + " annotation to " + v1alpha1.RenamePolicyRecreate + " to drop the user and create a new one"
+
+	policyStrict     = "strict"
+	usergroupDefault = "DEFAULT"
+	providerConfig   = "default"
+)
+
+// +kubebuilder:webhook:path=/mutate-admin-hana-sap-crossplane-io-v1alpha1-user,mutating=true,failurePolicy=fail,sideEffects=None,groups=admin.hana.sap.crossplane.io,resources=users,verbs=create;update,versions=v1alpha1,name=users.admin.hana.sap.crossplane.io,admissionReviewVersions=v1
+
+// +kubebuilder:webhook:path=/validate-admin-hana-sap-crossplane-io-v1alpha1-user,mutating=false,failurePolicy=fail,sideEffects=None,groups=admin.hana.sap.crossplane.io,resources=users,verbs=update,versions=v1alpha1,name=users.admin.hana.sap.crossplane.io,admissionReviewVersions=v1
+
+// Setup registers the defaulting and validating webhooks for User managed
+// resources.
+func Setup(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&v1alpha1.User{}).
+		WithDefaulter(NewDefaulter(mgr.GetAPIReader())).
+		WithValidator(NewValidator()).
+		Complete()
+}
+
+// NewDefaulter returns a defaulter that writes the defaults the User
+// controller would otherwise only apply in memory into the stored spec. The
+// ProviderConfigs are read through kube.
+func NewDefaulter(kube client.Reader) *xpwebhook.Mutator {
+	return xpwebhook.NewMutator(xpwebhook.WithMutationFns(
+		defaultPrivilegeManagementPolicy,
+		defaultUsergroup(kube),
+		foldUsername(kube),
+		normalizePrivileges(kube),
+		stampApproval,
+	))
+}
+
+// NewValidator returns a validator that rejects changes HANA cannot apply to
+// an existing user.
+func NewValidator() *xpwebhook.Validator {
+	return xpwebhook.NewValidator(xpwebhook.WithValidateUpdateFns(
+		validateRestrictedUser,
+		validateUsername,
+	))
+}
+
+func asUser(obj runtime.Object) (*v1alpha1.User, error) {
+	cr, ok := obj.(*v1alpha1.User)
+	if !ok {
+		return nil, errors.New(errNotUser)
+	}
+	return cr, nil
+}
+
+func defaultPrivilegeManagementPolicy(_ context.Context, obj runtime.Object) error {
+	cr, err := asUser(obj)
+	if err != nil {
+		return err
+	}
+	if cr.Spec.PrivilegeManagementPolicy == "" {
+		cr.Spec.PrivilegeManagementPolicy = policyStrict
+	}
+	return nil
+}
+
+// defaultUsergroup defaults the usergroup to the defaultUsergroup of the
+// ProviderConfig, or DEFAULT. The usergroup is left empty while the
+// ProviderConfig does not exist yet, the User controller then resolves it.
+func defaultUsergroup(kube client.Reader) xpwebhook.MutateFn {
+	return func(ctx context.Context, obj runtime.Object) error {
+		cr, err := asUser(obj)
+		if err != nil {
+			return err
+		}
+		if cr.Spec.ForProvider.Usergroup != "" {
+			return nil
+		}
+		pc, err := getProviderConfig(ctx, kube, cr)
+		if err != nil || pc == nil {
+			return err
+		}
+		cr.Spec.ForProvider.Usergroup = pc.Spec.DefaultUsergroup
+		if cr.Spec.ForProvider.Usergroup == "" {
+			cr.Spec.ForProvider.Usergroup = usergroupDefault
+		}
+		return nil
+	}
+}
+
+// getProviderConfig returns the ProviderConfig of the User, or nil while it
+// does not exist yet.
+func getProviderConfig(ctx context.Context, kube client.Reader, cr *v1alpha1.User) (*apisv1alpha1.ProviderConfig, error) {
+	name := providerConfig
+	if ref := cr.GetProviderConfigReference(); ref != nil && ref.Name != "" {
+		name = ref.Name
+	}
+	pc := &apisv1alpha1.ProviderConfig{}
+	if err := kube.Get(ctx, types.NamespacedName{Name: name}, pc); err != nil {
+		if kerrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf(errGetPC, err)
+	}
+	return pc, nil
+}
+
+// identifierCase returns the identifier case strategy of the ProviderConfig
+// of the User, which is unset while the ProviderConfig does not exist yet.
+func identifierCase(ctx context.Context, kube client.Reader, cr *v1alpha1.User) (string, error) {
+	pc, err := getProviderConfig(ctx, kube, cr)
+	if err != nil || pc == nil {
+		return "", err
+	}
+	return pc.Spec.IdentifierCase, nil
+}
+
+// foldUsername uppercases the username the same way HANA folds unquoted
+// identifiers, unless the user is case-sensitive or the ProviderConfig
+// preserves the case of identifiers. The username is immutable, so it is only
+// folded on create to keep existing resources admissible.
+func foldUsername(kube client.Reader) xpwebhook.MutateFn {
+	return func(ctx context.Context, obj runtime.Object) error {
+		cr, err := asUser(obj)
+		if err != nil {
+			return err
+		}
+		if cr.Spec.ForProvider.CaseSensitive {
+			return nil
+		}
+		if req, err := admission.RequestFromContext(ctx); err == nil && req.Operation != admissionv1.Create {
+			return nil
+		}
+		ic, err := identifierCase(ctx, kube, cr)
+		if err != nil || ic == apisv1alpha1.IdentifierCasePreserve {
+			return err
+		}
+		cr.Spec.ForProvider.Username = strings.ToUpper(cr.Spec.ForProvider.Username)
+		return nil
+	}
+}
+
+// normalizePrivileges formats the privileges to their canonical form, in
+// which all identifiers are quoted. Unquoted identifiers are folded first if
+// the ProviderConfig folds identifiers to uppercase.
+func normalizePrivileges(kube client.Reader) xpwebhook.MutateFn {
+	return func(ctx context.Context, obj runtime.Object) error {
+		cr, err := asUser(obj)
+		if err != nil {
+			return err
+		}
+		if len(cr.Spec.ForProvider.Privileges) == 0 {
+			return nil
+		}
+		ic, err := identifierCase(ctx, kube, cr)
+		if err != nil {
+			return err
+		}
+		privileges := cr.Spec.ForProvider.Privileges
+		if ic == apisv1alpha1.IdentifierCaseUpper {
+			privileges = privilege.FoldPrivilegeStrings(privileges)
+		}
+		privileges, err = privilege.NormalizePrivilegeStrings(privileges)
+		if err != nil {
+			return fmt.Errorf(errNormalizePrivileges, err)
+		}
+		cr.Spec.ForProvider.Privileges = privileges
+		return nil
+	}
+}
+
+// stampApproval records who requested and who approved the grants of the
+// user. It runs last so that grants are compared in their normalized form.
+func stampApproval(ctx context.Context, obj runtime.Object) error {
+	cr, err := asUser(obj)
+	if err != nil {
+		return err
+	}
+	old := &v1alpha1.User{}
+	hasOld, err := approval.DecodeOld(ctx, old)
+	if err != nil {
+		return err
+	}
+	grantsChanged := !hasOld ||
+		!slices.Equal(old.Spec.ForProvider.Privileges, cr.Spec.ForProvider.Privileges) ||
+		!slices.Equal(old.Spec.ForProvider.TypedPrivileges, cr.Spec.ForProvider.TypedPrivileges) ||
+		!slices.Equal(old.Spec.ForProvider.Roles, cr.Spec.ForProvider.Roles)
+	approval.Stamp(ctx, cr, old.GetAnnotations(), grantsChanged)
+	return nil
+}
+
+// validateRestrictedUser rejects switching between a restricted and a
+// standard user.
+func validateRestrictedUser(_ context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	old, err := asUser(oldObj)
+	if err != nil {
+		return nil, err
+	}
+	cr, err := asUser(newObj)
+	if err != nil {
+		return nil, err
+	}
+	if old.Spec.ForProvider.RestrictedUser != cr.Spec.ForProvider.RestrictedUser {
+		return nil, errors.New(errConvertRestricted)
+	}
+	return nil, nil
+}
+
+// validateUsername rejects changing the username unless the User opts into
+// recreating the user.
+func validateUsername(_ context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	old, err := asUser(oldObj)
+	if err != nil {
+		return nil, err
+	}
+	cr, err := asUser(newObj)
+	if err != nil {
+		return nil, err
+	}
+	if old.Spec.ForProvider.Username == "" || cr.Spec.ForProvider.Username == "" ||
+		old.Spec.ForProvider.Username == cr.Spec.ForProvider.Username {
+		return nil, nil
+	}
+	if cr.GetAnnotations()[v1alpha1.AnnotationRenamePolicy] != v1alpha1.RenamePolicyRecreate {
+		return nil, errors.New(errRename)
+	}
+	return admission.Warnings{fmt.Sprintf("user %s will be dropped and user %s created", old.Spec.ForProvider.Username, cr.Spec.ForProvider.Username)}, nil
+}
@@ -0,0 +1,288 @@
+/*
+Copyright 2026 SAP SE or an SAP affiliate company and contributors.
+*/
+
+package user
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	admissionv1 "k8s.io/api/admission/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/SAP/crossplane-provider-hana/apis/admin/v1alpha1"
+	apisv1alpha1 "github.com/SAP/crossplane-provider-hana/apis/v1alpha1"
+)
+
+func withOperation(op admissionv1.Operation) context.Context {
+	return admission.NewContextWithRequest(context.Background(), admission.Request{
+		AdmissionRequest: admissionv1.AdmissionRequest{Operation: op},
+	})
+}
+
+// withProviderConfig returns a client that only knows the supplied
+// ProviderConfig.
+func withProviderConfig(pc apisv1alpha1.ProviderConfig) client.Reader {
+	return &test.MockClient{
+		MockGet: func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+			if key.Name != pc.Name {
+				return kerrors.NewNotFound(schema.GroupResource{Resource: "providerconfigs"}, key.Name)
+			}
+			pc.DeepCopyInto(obj.(*apisv1alpha1.ProviderConfig))
+			return nil
+		},
+	}
+}
+
+func TestDefault(t *testing.T) {
+	defaultPC := apisv1alpha1.ProviderConfig{}
+	defaultPC.SetName("default")
+
+	cases := map[string]struct {
+		reason  string
+		ctx     context.Context
+		kube    client.Reader
+		spec    v1alpha1.UserSpec
+		want    v1alpha1.UserSpec
+		wantErr bool
+	}{
+		"CreateAppliesAllDefaults": {
+			reason: "All defaults but the PUBLIC role, which the controller grants, should be written into the spec on create",
+			ctx:    withOperation(admissionv1.Create),
+			spec: v1alpha1.UserSpec{
+				ForProvider: v1alpha1.UserParameters{
+					Username:   "demo_user",
+					Privileges: []string{"CATALOG READ", "SELECT ON SCHEMA myschema", "SELECT ON mytable"},
+				},
+			},
+			want: v1alpha1.UserSpec{
+				PrivilegeManagementPolicy: "strict",
+				ForProvider: v1alpha1.UserParameters{
+					Username:   "DEMO_USER",
+					Usergroup:  "DEFAULT",
+					Privileges: []string{"CATALOG READ", `SELECT ON SCHEMA "myschema"`, "SELECT ON mytable"},
+				},
+			},
+		},
+		"UpdateKeepsUsername": {
+			reason: "The immutable username should not be folded on update",
+			ctx:    withOperation(admissionv1.Update),
+			spec: v1alpha1.UserSpec{
+				PrivilegeManagementPolicy: "lax",
+				ForProvider: v1alpha1.UserParameters{
+					Username:  "demo_user",
+					Usergroup: "MYGROUP",
+					Roles:     []string{"PUBLIC"},
+				},
+			},
+			want: v1alpha1.UserSpec{
+				PrivilegeManagementPolicy: "lax",
+				ForProvider: v1alpha1.UserParameters{
+					Username:  "demo_user",
+					Usergroup: "MYGROUP",
+					Roles:     []string{"PUBLIC"},
+				},
+			},
+		},
+		"CaseSensitiveUsernameKept": {
+			reason: "A case-sensitive username should not be folded",
+			ctx:    withOperation(admissionv1.Create),
+			spec: v1alpha1.UserSpec{
+				ForProvider: v1alpha1.UserParameters{
+					Username:      "demo_user",
+					CaseSensitive: true,
+				},
+			},
+			want: v1alpha1.UserSpec{
+				PrivilegeManagementPolicy: "strict",
+				ForProvider: v1alpha1.UserParameters{
+					Username:      "demo_user",
+					CaseSensitive: true,
+					Usergroup:     "DEFAULT",
+				},
+			},
+		},
+		"ProviderConfigUsergroup": {
+			reason: "The usergroup should default to the defaultUsergroup of the referenced ProviderConfig",
+			ctx:    withOperation(admissionv1.Create),
+			kube: withProviderConfig(apisv1alpha1.ProviderConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: "example"},
+				Spec:       apisv1alpha1.ProviderConfigSpec{DefaultUsergroup: "CROSSPLANE"},
+			}),
+			spec: v1alpha1.UserSpec{
+				ResourceSpec: xpv1.ResourceSpec{ProviderConfigReference: &xpv1.Reference{Name: "example"}},
+				ForProvider:  v1alpha1.UserParameters{Username: "DEMO_USER"},
+			},
+			want: v1alpha1.UserSpec{
+				ResourceSpec:              xpv1.ResourceSpec{ProviderConfigReference: &xpv1.Reference{Name: "example"}},
+				PrivilegeManagementPolicy: "strict",
+				ForProvider: v1alpha1.UserParameters{
+					Username:  "DEMO_USER",
+					Usergroup: "CROSSPLANE",
+				},
+			},
+		},
+		"ProviderConfigFoldsIdentifiers": {
+			reason: "Unquoted identifiers in privileges should be folded if the ProviderConfig folds identifiers",
+			ctx:    withOperation(admissionv1.Create),
+			kube: withProviderConfig(apisv1alpha1.ProviderConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: "default"},
+				Spec:       apisv1alpha1.ProviderConfigSpec{IdentifierCase: apisv1alpha1.IdentifierCaseUpper},
+			}),
+			spec: v1alpha1.UserSpec{
+				ForProvider: v1alpha1.UserParameters{
+					Username:   "demo_user",
+					Privileges: []string{"SELECT ON SCHEMA myschema", `SELECT ON "mySchema".mytable`, "SELECT ON mytable"},
+				},
+			},
+			want: v1alpha1.UserSpec{
+				PrivilegeManagementPolicy: "strict",
+				ForProvider: v1alpha1.UserParameters{
+					Username:   "DEMO_USER",
+					Usergroup:  "DEFAULT",
+					Privileges: []string{`SELECT ON SCHEMA "MYSCHEMA"`, `SELECT ON "mySchema"."MYTABLE"`, `SELECT ON "MYTABLE"`},
+				},
+			},
+		},
+		"ProviderConfigPreservesIdentifiers": {
+			reason: "The username should not be folded if the ProviderConfig preserves the case of identifiers",
+			ctx:    withOperation(admissionv1.Create),
+			kube: withProviderConfig(apisv1alpha1.ProviderConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: "default"},
+				Spec:       apisv1alpha1.ProviderConfigSpec{IdentifierCase: apisv1alpha1.IdentifierCasePreserve},
+			}),
+			spec: v1alpha1.UserSpec{
+				ForProvider: v1alpha1.UserParameters{Username: "demo_user"},
+			},
+			want: v1alpha1.UserSpec{
+				PrivilegeManagementPolicy: "strict",
+				ForProvider: v1alpha1.UserParameters{
+					Username:  "demo_user",
+					Usergroup: "DEFAULT",
+				},
+			},
+		},
+		"ProviderConfigNotFound": {
+			reason: "The usergroup should be left to the controller while the ProviderConfig does not exist",
+			ctx:    withOperation(admissionv1.Create),
+			kube:   withProviderConfig(apisv1alpha1.ProviderConfig{}),
+			spec: v1alpha1.UserSpec{
+				ForProvider: v1alpha1.UserParameters{Username: "DEMO_USER"},
+			},
+			want: v1alpha1.UserSpec{
+				PrivilegeManagementPolicy: "strict",
+				ForProvider: v1alpha1.UserParameters{
+					Username: "DEMO_USER",
+				},
+			},
+		},
+		"ErrGetProviderConfig": {
+			reason: "Any other error getting the ProviderConfig should be returned",
+			ctx:    withOperation(admissionv1.Create),
+			kube:   &test.MockClient{MockGet: test.NewMockGetFn(errors.New("boom"))},
+			spec: v1alpha1.UserSpec{
+				ForProvider: v1alpha1.UserParameters{Username: "DEMO_USER"},
+			},
+			wantErr: true,
+		},
+		"InvalidPrivilege": {
+			reason: "An unparsable privilege should be rejected",
+			ctx:    withOperation(admissionv1.Create),
+			spec: v1alpha1.UserSpec{
+				ForProvider: v1alpha1.UserParameters{
+					Username:   "DEMO_USER",
+					Privileges: []string{"CATALOG READ WITH GRANT OPTION"},
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			kube := tc.kube
+			if kube == nil {
+				kube = withProviderConfig(defaultPC)
+			}
+			cr := &v1alpha1.User{Spec: tc.spec}
+			err := NewDefaulter(kube).Default(tc.ctx, cr)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("\n%s\nDefault(...): expected error, got nil", tc.reason)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("\n%s\nDefault(...): unexpected error: %v", tc.reason, err)
+			}
+			if diff := cmp.Diff(tc.want, cr.Spec); diff != "" {
+				t.Errorf("\n%s\nDefault(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
+
+func TestDefaultNotUser(t *testing.T) {
+	if err := NewDefaulter(withProviderConfig(apisv1alpha1.ProviderConfig{})).Default(context.Background(), &v1alpha1.Role{}); err == nil {
+		t.Errorf("Default(...): expected error for non-User object, got nil")
+	}
+}
+
+func TestValidateUpdate(t *testing.T) {
+	cases := map[string]struct {
+		reason      string
+		annotations map[string]string
+		old         v1alpha1.UserParameters
+		new         v1alpha1.UserParameters
+		wantErr     bool
+	}{
+		"ClientConnectChange": {
+			reason: "Changes HANA can apply in place should be admitted",
+			old:    v1alpha1.UserParameters{Username: "DEMO_USER", RestrictedUser: true},
+			new:    v1alpha1.UserParameters{Username: "DEMO_USER", RestrictedUser: true, ClientConnect: new(true)},
+		},
+		"RestrictedToStandard": {
+			reason:  "A restricted user should not be turned into a standard user",
+			old:     v1alpha1.UserParameters{Username: "DEMO_USER", RestrictedUser: true},
+			new:     v1alpha1.UserParameters{Username: "DEMO_USER"},
+			wantErr: true,
+		},
+		"StandardToRestricted": {
+			reason:  "A standard user should not be turned into a restricted user",
+			old:     v1alpha1.UserParameters{Username: "DEMO_USER"},
+			new:     v1alpha1.UserParameters{Username: "DEMO_USER", RestrictedUser: true},
+			wantErr: true,
+		},
+		"Rename": {
+			reason:  "A changed username should be rejected by default",
+			old:     v1alpha1.UserParameters{Username: "DEMO_USER"},
+			new:     v1alpha1.UserParameters{Username: "NEW_USER"},
+			wantErr: true,
+		},
+		"RenameRecreate": {
+			reason:      "A changed username should be admitted if the User opts into recreation",
+			annotations: map[string]string{v1alpha1.AnnotationRenamePolicy: v1alpha1.RenamePolicyRecreate},
+			old:         v1alpha1.UserParameters{Username: "DEMO_USER"},
+			new:         v1alpha1.UserParameters{Username: "NEW_USER"},
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			old := &v1alpha1.User{Spec: v1alpha1.UserSpec{ForProvider: tc.old}}
+			cr := &v1alpha1.User{Spec: v1alpha1.UserSpec{ForProvider: tc.new}}
+			cr.SetAnnotations(tc.annotations)
+			_, err := NewValidator().ValidateUpdate(context.Background(), old, cr)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("\n%s\nValidateUpdate(...): want error %t, got %v", tc.reason, tc.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,27 @@
+/*
+Copyright 2026 SAP SE or an SAP affiliate company and contributors.
+*/
+
+package webhook
+
+import (
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/SAP/crossplane-provider-hana/internal/webhook/role"
+	"github.com/SAP/crossplane-provider-hana/internal/webhook/user"
+	"github.com/SAP/crossplane-provider-hana/internal/webhook/x509provider"
+)
+
+// Setup registers all HANA admission webhooks with the supplied manager.
+func Setup(mgr ctrl.Manager) error {
+	for _, setup := range []func(ctrl.Manager) error{
+		role.Setup,
+		user.Setup,
+		x509provider.Setup,
+	} {
+		if err := setup(mgr); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+/*
+Copyright 2026 SAP SE or an SAP affiliate company and contributors.
+*/
+
+package x509provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	xpwebhook "github.com/crossplane/crossplane-runtime/pkg/webhook"
+
+	"github.com/SAP/crossplane-provider-hana/apis/admin/v1alpha1"
+	"github.com/SAP/crossplane-provider-hana/internal/clients/hana/x509provider"
+	"github.com/SAP/crossplane-provider-hana/internal/webhook/approval"
+)
+
+const (
+	errNotX509Provider = "object is not a X509Provider custom resource"
+	errInvalidIssuer   = "issuer is not a valid RFC 2253 distinguished name: %w"
+)
+
+// +kubebuilder:webhook:path=/mutate-admin-hana-sap-crossplane-io-v1alpha1-x509provider,mutating=true,failurePolicy=fail,sideEffects=None,groups=admin.hana.sap.crossplane.io,resources=x509providers,verbs=create;update,versions=v1alpha1,name=x509providers.admin.hana.sap.crossplane.io,admissionReviewVersions=v1
+
+// Setup registers the mutating webhook for X509Provider managed resources.
+func Setup(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&v1alpha1.X509Provider{}).
+		WithDefaulter(NewDefaulter()).
+		Complete()
+}
+
+// NewDefaulter returns a mutator that rejects malformed issuers before they
+// reach HANA and writes valid ones in their canonical form.
+func NewDefaulter() *xpwebhook.Mutator {
+	return xpwebhook.NewMutator(xpwebhook.WithMutationFns(
+		normalizeIssuer,
+	))
+}
+
+// normalizeIssuer normalizes the issuer distinguished name. An issuer that did
+// not change is left alone, so existing resources stay admissible and can
+// still be deleted.
+func normalizeIssuer(ctx context.Context, obj runtime.Object) error {
+	cr, ok := obj.(*v1alpha1.X509Provider)
+	if !ok {
+		return errors.New(errNotX509Provider)
+	}
+	old := &v1alpha1.X509Provider{}
+	hasOld, err := approval.DecodeOld(ctx, old)
+	if err != nil {
+		return err
+	}
+	if hasOld && old.Spec.ForProvider.Issuer == cr.Spec.ForProvider.Issuer {
+		return nil
+	}
+	issuer, err := x509provider.NormalizeIssuer(cr.Spec.ForProvider.Issuer)
+	if err != nil {
+		return fmt.Errorf(errInvalidIssuer, err)
+	}
+	cr.Spec.ForProvider.Issuer = issuer
+	return nil
+}
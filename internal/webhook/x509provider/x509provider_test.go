@@ -0,0 +1,86 @@
+/*
+Copyright 2026 SAP SE or an SAP affiliate company and contributors.
+*/
+
+package x509provider
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/SAP/crossplane-provider-hana/apis/admin/v1alpha1"
+)
+
+// withOldIssuer returns the context of an update of a X509Provider with the
+// supplied issuer.
+func withOldIssuer(t *testing.T, issuer string) context.Context {
+	t.Helper()
+	old := &v1alpha1.X509Provider{Spec: v1alpha1.X509ProviderSpec{ForProvider: v1alpha1.X509ProviderParameters{Issuer: issuer}}}
+	raw, err := json.Marshal(old)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return admission.NewContextWithRequest(context.Background(), admission.Request{
+		AdmissionRequest: admissionv1.AdmissionRequest{
+			Operation: admissionv1.Update,
+			OldObject: runtime.RawExtension{Raw: raw},
+		},
+	})
+}
+
+func TestDefault(t *testing.T) {
+	cases := map[string]struct {
+		reason  string
+		ctx     context.Context
+		issuer  string
+		want    string
+		wantErr bool
+	}{
+		"CreateNormalizes": {
+			reason: "The issuer should be written in its canonical form on create",
+			ctx:    context.Background(),
+			issuer: "cn=Test CA,O=SAP SE",
+			want:   "CN=Test CA, O=SAP SE",
+		},
+		"CreateRejectsInvalid": {
+			reason:  "A malformed issuer should be rejected on create",
+			ctx:     context.Background(),
+			issuer:  "Test CA",
+			wantErr: true,
+		},
+		"UpdateNormalizesChanged": {
+			reason: "A changed issuer should be normalized on update",
+			ctx:    withOldIssuer(t, "CN=Old CA"),
+			issuer: "CN=New CA;O=SAP SE",
+			want:   "CN=New CA, O=SAP SE",
+		},
+		"UpdateKeepsUnchanged": {
+			reason: "An unchanged issuer should be kept, even if it is malformed",
+			ctx:    withOldIssuer(t, "Test CA"),
+			issuer: "Test CA",
+			want:   "Test CA",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cr := &v1alpha1.X509Provider{Spec: v1alpha1.X509ProviderSpec{ForProvider: v1alpha1.X509ProviderParameters{Issuer: tc.issuer}}}
+			err := NewDefaulter().Default(tc.ctx, cr)
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("\n%s\nDefault(...): error = %v, wantErr %v", tc.reason, err, tc.wantErr)
+			}
+			if tc.wantErr {
+				return
+			}
+			if diff := cmp.Diff(tc.want, cr.Spec.ForProvider.Issuer); diff != "" {
+				t.Errorf("\n%s\nDefault(...): -want issuer, +got issuer:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
@@ -158,7 +158,7 @@ func createKymaInstanceMapping(t *testing.T, ctx context.Context, c client.Clien
 					Name:      resources.ServiceInstance.Name,
 					Namespace: resources.ServiceInstance.Namespace,
 				},
-				AdminBindingRef: inventoryv1alpha1.ResourceReference{
+				AdminBindingRef: &inventoryv1alpha1.ResourceReference{
 					Name:      resources.ServiceBinding.Name,
 					Namespace: resources.ServiceBinding.Namespace,
 				},
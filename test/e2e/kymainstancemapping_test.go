@@ -248,7 +248,7 @@ func TestKymaInstanceMappingMissingServiceInstance(t *testing.T) {
 							Name:      "non-existent-instance",
 							Namespace: testNamespace,
 						},
-						AdminBindingRef: inventoryv1alpha1.ResourceReference{
+						AdminBindingRef: &inventoryv1alpha1.ResourceReference{
 							Name:      "non-existent-binding",
 							Namespace: testNamespace,
 						},
@@ -9,6 +9,7 @@ import (
 	"sync"
 
 	imclient "github.com/SAP/crossplane-provider-hana/internal/clients/hanacloud/instancemapping"
+	"github.com/SAP/crossplane-provider-hana/internal/clients/hanacloud/operation"
 )
 
 // Compile-time interface check
@@ -65,7 +66,7 @@ func (m *MockInstanceMappingClient) List(ctx context.Context, serviceInstanceID
 }
 
 // Create stores a mapping and records the call.
-func (m *MockInstanceMappingClient) Create(ctx context.Context, serviceInstanceID string, req imclient.CreateMappingRequest) error {
+func (m *MockInstanceMappingClient) Create(ctx context.Context, serviceInstanceID string, req imclient.CreateMappingRequest) (string, error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
@@ -75,17 +76,17 @@ func (m *MockInstanceMappingClient) Create(ctx context.Context, serviceInstanceI
 	})
 
 	if m.CreateErr != nil {
-		return m.CreateErr
+		return "", m.CreateErr
 	}
 
 	// Store the mapping so List returns it
 	m.mappings[serviceInstanceID] = append(m.mappings[serviceInstanceID], imclient.InstanceMapping(req))
 
-	return nil
+	return "", nil
 }
 
 // Delete removes a mapping and records the call.
-func (m *MockInstanceMappingClient) Delete(ctx context.Context, serviceInstanceID, primaryID, secondaryID string) error {
+func (m *MockInstanceMappingClient) Delete(ctx context.Context, serviceInstanceID, primaryID, secondaryID string) (string, error) {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
@@ -96,7 +97,7 @@ func (m *MockInstanceMappingClient) Delete(ctx context.Context, serviceInstanceI
 	})
 
 	if m.DeleteErr != nil {
-		return m.DeleteErr
+		return "", m.DeleteErr
 	}
 
 	// Remove matching mapping
@@ -111,7 +112,13 @@ func (m *MockInstanceMappingClient) Delete(ctx context.Context, serviceInstanceI
 	}
 	m.mappings[serviceInstanceID] = filtered
 
-	return nil
+	return "", nil
+}
+
+// GetOperation reports every operation as succeeded, the mock applies all
+// changes synchronously.
+func (m *MockInstanceMappingClient) GetOperation(ctx context.Context, serviceInstanceID, operationID string) (operation.Operation, error) {
+	return operation.Operation{ID: operationID, State: operation.StateSucceeded}, nil
 }
 
 // Reset clears all recorded calls and stored mappings.